@@ -10,6 +10,10 @@ type AuthenticatedUser struct {
 	AuthenticatedByRealm string     `json:"-"` // Name of realm that authenticated this user
 	Credential           Credential `json:"credential"`
 	Role                 Role       `json:"role"`
+
+	// Metadata is what the realm knows of the user, the native realm
+	// sets it to the user's api key.
+	Metadata interface{} `json:"-"`
 }
 
 type Credential struct {
@@ -77,6 +77,7 @@ func NewFileRealm(opts *config.FileRealmOption) (*FileRealm, error) {
 			Role: auth.Role{
 				Type:   basicAuth.Role.Type,
 				Groups: basicAuth.Role.Groups,
+				Apps:   basicAuth.Role.Apps,
 			},
 		})
 	}
@@ -87,6 +88,7 @@ func NewFileRealm(opts *config.FileRealmOption) (*FileRealm, error) {
 			Role: auth.Role{
 				Type:   basicAuth.Role.Type,
 				Groups: basicAuth.Role.Groups,
+				Apps:   basicAuth.Role.Apps,
 			},
 		})
 	}
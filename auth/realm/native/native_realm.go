@@ -67,6 +67,7 @@ func (n *NativeRealm) Authenticate(ctx context.Context, cred *auth.Credential) (
 		AuthenticatedByRealm: n.GetName(),
 		Credential:           *cred,
 		Role:                 apiKey.Role,
+		Metadata:             apiKey,
 	}
 
 	return authUser, nil
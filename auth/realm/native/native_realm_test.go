@@ -67,6 +67,18 @@ func TestNativeRealm_Authenticate(t *testing.T) {
 					Type:   auth.RoleUIAdmin,
 					Groups: []string{"paystack"},
 				},
+				Metadata: &datastore.APIKey{
+					UID: "abcd",
+					Role: auth.Role{
+						Type:   auth.RoleUIAdmin,
+						Groups: []string{"paystack"},
+					},
+					MaskID:    "DkwB9HnZxy4DqZMi",
+					Hash:      "R4rtPIELUaJ9fx6suLreIpH3IaLzbxRcODy3a0Zm1qM=",
+					Salt:      "6y9yQZWqbE1AMHvfUewuYwasycmoe_zg5g==",
+					ExpiresAt: 0,
+					CreatedAt: 0,
+				},
 			},
 			wantErr: false,
 		},
@@ -73,6 +73,18 @@ func Init(authConfig *config.AuthConfiguration, apiKeyRepo datastore.APIKeyRepos
 	return nil
 }
 
+// NewConfigChangeListener returns a config.ConfigChangeListener that
+// rebuilds the realm chain from the new auth configuration. The current
+// chain is kept if the new one can't be built.
+func NewConfigChangeListener(apiKeyRepo datastore.APIKeyRepository) config.ConfigChangeListener {
+	return func(cfg config.Configuration) {
+		err := Init(&cfg.Auth, apiKeyRepo)
+		if err != nil {
+			log.WithError(err).Error("failed to reload realm chain")
+		}
+	}
+}
+
 // Authenticate calls the Authenticate method of all registered realms.
 // If at least one realm can authenticate the given auth.Credential, Authenticate will not return an error
 func (rc *RealmChain) Authenticate(ctx context.Context, cred *auth.Credential) (*auth.AuthenticatedUser, error) {
@@ -3,6 +3,8 @@ package realm_chain
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 
@@ -329,3 +331,89 @@ func TestInit(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfigChangeListener_ReloadPicksUpNewPassword(t *testing.T) {
+	writeConfig := func(p, password string) {
+		cfg := fmt.Sprintf(`{"auth": {"require_auth": true, "file": {"basic": [{"username": "test", "password": %q, "role": {"type": "super_user"}}]}}}`, password)
+		require.NoError(t, os.WriteFile(p, []byte(cfg), 0o644))
+	}
+
+	authenticate := func(password string) error {
+		rc, err := Get()
+		require.NoError(t, err)
+
+		_, err = rc.Authenticate(context.Background(), &auth.Credential{
+			Type:     auth.CredentialTypeBasic,
+			Username: "test",
+			Password: password,
+		})
+		return err
+	}
+
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(p, "old-password")
+
+	err := config.LoadConfig(p)
+	require.NoError(t, err)
+
+	cfg, err := config.Get()
+	require.NoError(t, err)
+
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(gomock.NewController(t))
+	require.NoError(t, Init(&cfg.Auth, apiKeyRepo))
+	require.NoError(t, authenticate("old-password"))
+
+	r := config.NewReloader(p)
+	r.AddListener(NewConfigChangeListener(apiKeyRepo))
+
+	writeConfig(p, "new-password")
+
+	_, err = r.Reload()
+	require.NoError(t, err)
+
+	require.ErrorIs(t, authenticate("old-password"), ErrAuthFailed)
+	require.NoError(t, authenticate("new-password"))
+}
+
+func TestNewConfigChangeListener_ReloadKeepsCliRealms(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	require.NoError(t, os.WriteFile(p, []byte(`{"base_url": "http://localhost:5005"}`), 0o644))
+
+	err := config.LoadConfig(p)
+	require.NoError(t, err)
+
+	// as if convoy was started with --auth --basic-auth, the file doesn't
+	// require auth at all
+	resolve := func(c *config.Configuration) error {
+		c.Auth.RequireAuth = true
+		c.Auth.File.Basic = []config.BasicAuth{{Username: "test", Password: "cli-password", Role: auth.Role{Type: auth.RoleSuperUser}}}
+		return nil
+	}
+
+	cfg, err := config.Get()
+	require.NoError(t, err)
+	require.NoError(t, resolve(&cfg))
+	config.Override(&cfg)
+
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(gomock.NewController(t))
+	require.NoError(t, Init(&cfg.Auth, apiKeyRepo))
+
+	r := config.NewReloader(p)
+	r.SetResolver(resolve)
+	r.AddListener(NewConfigChangeListener(apiKeyRepo))
+
+	require.NoError(t, os.WriteFile(p, []byte(`{"base_url": "https://convoy.example.com"}`), 0o644))
+
+	_, err = r.Reload()
+	require.NoError(t, err)
+
+	rc, err := Get()
+	require.NoError(t, err)
+
+	// the noop realm would let any credential in
+	_, err = rc.Authenticate(context.Background(), &auth.Credential{Type: auth.CredentialTypeBasic, Username: "test", Password: "wrong"})
+	require.ErrorIs(t, err, ErrAuthFailed)
+
+	_, err = rc.Authenticate(context.Background(), &auth.Credential{Type: auth.CredentialTypeBasic, Username: "test", Password: "cli-password"})
+	require.NoError(t, err)
+}
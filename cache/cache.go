@@ -15,16 +15,16 @@ type Cache interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// NewCache returns the configured backend wrapped in an InstrumentedCache.
 func NewCache(cfg config.CacheConfiguration) (Cache, error) {
 	if cfg.Type == config.RedisCacheProvider {
-		ca, err := rcache.NewRedisCache(cfg.Redis.Dsn)
+		ca, err := rcache.NewRedisCache(cfg.Redis)
 		if err != nil {
 			return nil, err
 		}
 
-		return ca, nil
+		return NewInstrumentedCache(ca), nil
 	}
 
-	return mcache.NewMemoryCache(), nil
-
+	return NewInstrumentedCache(mcache.NewMemoryCache(cfg.Memory)), nil
 }
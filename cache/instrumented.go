@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	rcache "github.com/frain-dev/convoy/cache/redis"
+	"github.com/frain-dev/convoy/config"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// OtherKeyPrefix is the prefix operations are counted under when their key
+// doesn't start with one of the known cache keys, e.g the dashboard's.
+const OtherKeyPrefix = "other"
+
+var keyPrefixes = []convoy.CacheKey{
+	convoy.ApplicationsCacheKey,
+	convoy.GroupsCacheKey,
+	convoy.GroupStatisticsCacheKey,
+	convoy.EventFingerprintsCacheKey,
+	convoy.AppStatisticsCacheKey,
+	convoy.EventTypesCacheKey,
+	convoy.MissingGroupsCacheKey,
+	convoy.MissingAppsCacheKey,
+}
+
+// finder is implemented by the backends, Get can't tell a hit from a miss.
+type finder interface {
+	Find(ctx context.Context, key string, data interface{}) (bool, error)
+}
+
+type Counts struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Sets    uint64 `json:"sets"`
+	Deletes uint64 `json:"deletes"`
+	Errors  uint64 `json:"errors"`
+}
+
+type Stats struct {
+	Backend  config.CacheProvider `json:"backend"`
+	Prefixes map[string]Counts    `json:"prefixes"`
+}
+
+// InstrumentedCache counts the operations on the cache it wraps by key
+// prefix. It also keeps the cache optional: a read that fails is a miss
+// and a write that fails is dropped, the entry is just fetched from the
+// datastore again. Failed deletes are returned, an entry that couldn't be
+// invalidated would be served stale.
+type InstrumentedCache struct {
+	cache   Cache
+	backend config.CacheProvider
+
+	// counts is never written to after construction, only its values are
+	counts map[string]*Counts
+
+	hits    *prometheus.Desc
+	misses  *prometheus.Desc
+	sets    *prometheus.Desc
+	deletes *prometheus.Desc
+	errors  *prometheus.Desc
+}
+
+func NewInstrumentedCache(c Cache) *InstrumentedCache {
+	counts := make(map[string]*Counts, len(keyPrefixes)+1)
+	for _, prefix := range keyPrefixes {
+		counts[prefix.String()] = &Counts{}
+	}
+	counts[OtherKeyPrefix] = &Counts{}
+
+	return &InstrumentedCache{
+		cache:   c,
+		backend: backendOf(c),
+		counts:  counts,
+		hits:    newCounterDesc("hits_total", "Number of cache reads that found an entry."),
+		misses:  newCounterDesc("misses_total", "Number of cache reads that found no entry."),
+		sets:    newCounterDesc("sets_total", "Number of cache writes."),
+		deletes: newCounterDesc("deletes_total", "Number of cache deletes."),
+		errors:  newCounterDesc("errors_total", "Number of cache operations that failed."),
+	}
+}
+
+func newCounterDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName("", "cache", name), help, []string{"backend", "prefix"}, nil)
+}
+
+func backendOf(c Cache) config.CacheProvider {
+	switch c.(type) {
+	case *rcache.RedisCache:
+		return config.RedisCacheProvider
+	case *mcache.MemoryCache:
+		return config.InMemoryCacheProvider
+	default:
+		return "unknown"
+	}
+}
+
+func (i *InstrumentedCache) Set(ctx context.Context, key string, data interface{}, ttl time.Duration) error {
+	counts := i.countsFor(key)
+	atomic.AddUint64(&counts.Sets, 1)
+
+	err := i.cache.Set(ctx, key, data, ttl)
+	if err != nil {
+		atomic.AddUint64(&counts.Errors, 1)
+		log.WithError(err).Warnf("failed to write %s to the cache", key)
+	}
+
+	return nil
+}
+
+// Get reads the entry under key into data, leaving data untouched on a
+// miss.
+func (i *InstrumentedCache) Get(ctx context.Context, key string, data interface{}) error {
+	counts := i.countsFor(key)
+
+	f, ok := i.cache.(finder)
+	if !ok {
+		err := i.cache.Get(ctx, key, data)
+		if err != nil {
+			atomic.AddUint64(&counts.Errors, 1)
+			log.WithError(err).Warnf("failed to read %s from the cache", key)
+		}
+		return nil
+	}
+
+	found, err := f.Find(ctx, key, data)
+	switch {
+	case err != nil:
+		atomic.AddUint64(&counts.Errors, 1)
+		log.WithError(err).Warnf("failed to read %s from the cache", key)
+	case found:
+		atomic.AddUint64(&counts.Hits, 1)
+	default:
+		atomic.AddUint64(&counts.Misses, 1)
+	}
+
+	return nil
+}
+
+func (i *InstrumentedCache) Delete(ctx context.Context, key string) error {
+	counts := i.countsFor(key)
+	atomic.AddUint64(&counts.Deletes, 1)
+
+	err := i.cache.Delete(ctx, key)
+	if err != nil {
+		atomic.AddUint64(&counts.Errors, 1)
+	}
+
+	return err
+}
+
+// Stats returns the counts so far and the backend they were counted on.
+func (i *InstrumentedCache) Stats() *Stats {
+	stats := &Stats{
+		Backend:  i.backend,
+		Prefixes: make(map[string]Counts, len(i.counts)),
+	}
+
+	for prefix, counts := range i.counts {
+		stats.Prefixes[prefix] = Counts{
+			Hits:    atomic.LoadUint64(&counts.Hits),
+			Misses:  atomic.LoadUint64(&counts.Misses),
+			Sets:    atomic.LoadUint64(&counts.Sets),
+			Deletes: atomic.LoadUint64(&counts.Deletes),
+			Errors:  atomic.LoadUint64(&counts.Errors),
+		}
+	}
+
+	return stats
+}
+
+func (i *InstrumentedCache) Describe(ch chan<- *prometheus.Desc) {
+	ch <- i.hits
+	ch <- i.misses
+	ch <- i.sets
+	ch <- i.deletes
+	ch <- i.errors
+}
+
+func (i *InstrumentedCache) Collect(ch chan<- prometheus.Metric) {
+	backend := string(i.backend)
+	for prefix, counts := range i.Stats().Prefixes {
+		ch <- prometheus.MustNewConstMetric(i.hits, prometheus.CounterValue, float64(counts.Hits), backend, prefix)
+		ch <- prometheus.MustNewConstMetric(i.misses, prometheus.CounterValue, float64(counts.Misses), backend, prefix)
+		ch <- prometheus.MustNewConstMetric(i.sets, prometheus.CounterValue, float64(counts.Sets), backend, prefix)
+		ch <- prometheus.MustNewConstMetric(i.deletes, prometheus.CounterValue, float64(counts.Deletes), backend, prefix)
+		ch <- prometheus.MustNewConstMetric(i.errors, prometheus.CounterValue, float64(counts.Errors), backend, prefix)
+	}
+}
+
+// countsFor returns the counts for the prefix of key, the ids after it
+// would make one set of counts per entity.
+func (i *InstrumentedCache) countsFor(key string) *Counts {
+	if n := strings.Index(key, ":"); n >= 0 {
+		if counts, ok := i.counts[key[:n]]; ok {
+			return counts
+		}
+	}
+
+	return i.counts[OtherKeyPrefix]
+}
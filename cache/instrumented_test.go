@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string
+}
+
+type failingCache struct{}
+
+func (failingCache) Set(context.Context, string, interface{}, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingCache) Get(context.Context, string, interface{}) error {
+	return errors.New("connection refused")
+}
+
+func (failingCache) Find(context.Context, string, interface{}) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingCache) Delete(context.Context, string) error {
+	return errors.New("connection refused")
+}
+
+func TestInstrumentedCache_CountsByPrefix(t *testing.T) {
+	ctx := context.Background()
+	c := NewInstrumentedCache(mcache.NewMemoryCache(config.MemoryCacheConfiguration{}))
+
+	groupKey := convoy.GroupsCacheKey.Get("group-1").String()
+	appKey := convoy.ApplicationsCacheKey.Get("app-1").String()
+
+	var got item
+	require.NoError(t, c.Get(ctx, groupKey, &got))
+	require.NoError(t, c.Set(ctx, groupKey, &item{Name: "group"}, time.Minute))
+	require.NoError(t, c.Get(ctx, groupKey, &got))
+	require.Equal(t, "group", got.Name)
+
+	require.NoError(t, c.Set(ctx, appKey, &item{Name: "app"}, time.Minute))
+	require.NoError(t, c.Delete(ctx, appKey))
+
+	// the dashboard's keys start with the group's id
+	require.NoError(t, c.Get(ctx, "group-1:0:0:daily", &got))
+
+	stats := c.Stats()
+	require.Equal(t, config.InMemoryCacheProvider, stats.Backend)
+	require.Equal(t, Counts{Hits: 1, Misses: 1, Sets: 1}, stats.Prefixes[convoy.GroupsCacheKey.String()])
+	require.Equal(t, Counts{Sets: 1, Deletes: 1}, stats.Prefixes[convoy.ApplicationsCacheKey.String()])
+	require.Equal(t, Counts{Misses: 1}, stats.Prefixes[OtherKeyPrefix])
+	require.Len(t, stats.Prefixes, len(keyPrefixes)+1)
+}
+
+func TestInstrumentedCache_FailuresAreCounted(t *testing.T) {
+	ctx := context.Background()
+	c := NewInstrumentedCache(failingCache{})
+	key := convoy.GroupsCacheKey.Get("group-1").String()
+
+	require.NoError(t, c.Set(ctx, key, &item{Name: "group"}, time.Minute))
+
+	got := item{Name: "unchanged"}
+	require.NoError(t, c.Get(ctx, key, &got))
+	require.Equal(t, "unchanged", got.Name)
+
+	require.Error(t, c.Delete(ctx, key))
+
+	stats := c.Stats()
+	require.Equal(t, config.CacheProvider("unknown"), stats.Backend)
+	require.Equal(t, Counts{Sets: 1, Deletes: 1, Errors: 3}, stats.Prefixes[convoy.GroupsCacheKey.String()])
+}
+
+func TestInstrumentedCache_Collect(t *testing.T) {
+	c := NewInstrumentedCache(mcache.NewMemoryCache(config.MemoryCacheConfiguration{}))
+
+	// five counters for each prefix
+	require.Equal(t, 5*(len(keyPrefixes)+1), testutil.CollectAndCount(c))
+}
+
+func TestNewCache_UnreachableRedisIsAMiss(t *testing.T) {
+	// nothing listens on the discard port
+	c, err := NewCache(config.CacheConfiguration{
+		Type:  config.RedisCacheProvider,
+		Redis: config.RedisCacheConfiguration{Address: "127.0.0.1:9"},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = c.Set(ctx, "key", &item{Name: "test_name"}, time.Minute)
+	require.NoError(t, err)
+
+	got := item{Name: "unchanged"}
+	err = c.Get(ctx, "key", &got)
+	require.NoError(t, err)
+	require.Equal(t, "unchanged", got.Name)
+
+	require.Error(t, c.Delete(ctx, "key"))
+
+	stats := c.(*InstrumentedCache).Stats()
+	require.Equal(t, config.RedisCacheProvider, stats.Backend)
+	require.Equal(t, uint64(3), stats.Prefixes[OtherKeyPrefix].Errors)
+}
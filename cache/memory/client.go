@@ -1,50 +1,176 @@
 package mcache
 
 import (
+	"container/list"
 	"context"
-	"errors"
+	"sync"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/go-redis/cache/v8"
 )
 
+// DefaultMaxEntries is how many entries the cache holds when the config
+// doesn't say, the least recently used are evicted past it.
+const DefaultMaxEntries = 128000
+
+// sweepInterval is how often expired entries that nobody reads are
+// removed, Get drops the ones it finds in between.
+const sweepInterval = time.Minute
+
+// MemoryCache is a least recently used cache bounded by a number of
+// entries. Values are stored serialized, so callers never share the
+// values they store or read with the cache or each other.
 type MemoryCache struct {
-	cache *cache.Cache
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // most recently used first
+
+	// codec only serializes, it's the same encoding the redis cache uses
+	codec *cache.Cache
 }
 
-const cacheSize = 128000
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
 
-func NewMemoryCache() *MemoryCache {
-	c := cache.New(&cache.Options{
-		LocalCache: cache.NewTinyLFU(cacheSize, time.Hour),
-	})
+func NewMemoryCache(cfg config.MemoryCacheConfiguration) *MemoryCache {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	m := &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		codec:      cache.New(&cache.Options{}),
+	}
 
-	return &MemoryCache{cache: c}
+	go m.sweep(sweepInterval)
+
+	return m
 }
 
 func (m *MemoryCache) Set(ctx context.Context, key string, data interface{}, ttl time.Duration) error {
-	return m.cache.Set(&cache.Item{
-		Ctx:   ctx,
-		Key:   key,
-		Value: data,
-		TTL:   ttl,
-	})
+	b, err := m.codec.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// the codec hands byte slices back as they are
+	b = append([]byte(nil), b...)
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expiresAt = b, expiresAt
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&entry{key: key, value: b, expiresAt: expiresAt})
+
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+
+	return nil
 }
 
+// Get fills data with the entry under key, leaving it untouched on a miss.
 func (m *MemoryCache) Get(ctx context.Context, key string, data interface{}) error {
-	err := m.cache.Get(ctx, key, &data)
+	_, err := m.Find(ctx, key, data)
+	return err
+}
 
-	if errors.Is(err, cache.ErrCacheMiss) {
-		return nil
+// Find is Get, but it also reports whether there was an entry under key.
+func (m *MemoryCache) Find(ctx context.Context, key string, data interface{}) (bool, error) {
+	m.mu.Lock()
+	el, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		return false, nil
 	}
 
+	e := el.Value.(*entry)
+	if e.expired(time.Now()) {
+		m.remove(el)
+		m.mu.Unlock()
+		return false, nil
+	}
+
+	m.order.MoveToFront(el)
+	b := e.value
+	m.mu.Unlock()
+
+	// b is never written to once stored, Set replaces it instead
+	err := m.codec.Unmarshal(b, data)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 func (m *MemoryCache) Delete(ctx context.Context, key string) error {
-	return m.cache.Delete(ctx, key)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+
+	return nil
+}
+
+// Len returns the number of entries held, including expired ones that
+// haven't been removed yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+func (m *MemoryCache) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		m.removeExpired(time.Now())
+	}
+}
+
+func (m *MemoryCache) removeExpired(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for el := m.order.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*entry).expired(now) {
+			m.remove(el)
+		}
+		el = prev
+	}
+}
+
+// remove must be called with mu held.
+func (m *MemoryCache) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.entries, el.Value.(*entry).key)
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
 }
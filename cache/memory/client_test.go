@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,14 +19,14 @@ type data struct {
 const key = "test_key"
 
 func Test_WriteToCache(t *testing.T) {
-	cache := NewMemoryCache()
+	cache := NewMemoryCache(config.MemoryCacheConfiguration{})
 
 	err := cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
 	require.NoError(t, err)
 }
 
 func Test_ReadFromCache(t *testing.T) {
-	cache := NewMemoryCache()
+	cache := NewMemoryCache(config.MemoryCacheConfiguration{})
 
 	err := cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
 	require.NoError(t, err)
@@ -38,7 +39,7 @@ func Test_ReadFromCache(t *testing.T) {
 }
 
 func Test_DeleteFromCache(t *testing.T) {
-	cache := NewMemoryCache()
+	cache := NewMemoryCache(config.MemoryCacheConfiguration{})
 
 	err := cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
 	require.NoError(t, err)
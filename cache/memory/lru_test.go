@@ -0,0 +1,119 @@
+package mcache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+type group struct {
+	Name   string
+	Config map[string]string
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(config.MemoryCacheConfiguration{MaxEntries: 2})
+
+	require.NoError(t, c.Set(ctx, "a", &group{Name: "a"}, time.Minute))
+	require.NoError(t, c.Set(ctx, "b", &group{Name: "b"}, time.Minute))
+
+	// reading a makes b the least recently used
+	var g group
+	require.NoError(t, c.Get(ctx, "a", &g))
+	require.Equal(t, "a", g.Name)
+
+	require.NoError(t, c.Set(ctx, "c", &group{Name: "c"}, time.Minute))
+	require.Equal(t, 2, c.Len())
+
+	g = group{}
+	require.NoError(t, c.Get(ctx, "b", &g))
+	require.Empty(t, g.Name)
+
+	require.NoError(t, c.Get(ctx, "a", &g))
+	require.Equal(t, "a", g.Name)
+}
+
+func TestMemoryCache_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	require.NoError(t, c.Set(ctx, "short", &group{Name: "short"}, 10*time.Millisecond))
+	require.NoError(t, c.Set(ctx, "long", &group{Name: "long"}, time.Minute))
+	require.NoError(t, c.Set(ctx, "swept", &group{Name: "swept"}, 10*time.Millisecond))
+
+	time.Sleep(20 * time.Millisecond)
+
+	// Get drops the expired entry it finds
+	var g group
+	require.NoError(t, c.Get(ctx, "short", &g))
+	require.Empty(t, g.Name)
+	require.Equal(t, 2, c.Len())
+
+	// the sweep drops the ones nobody reads
+	c.removeExpired(time.Now())
+	require.Equal(t, 1, c.Len())
+
+	require.NoError(t, c.Get(ctx, "long", &g))
+	require.Equal(t, "long", g.Name)
+}
+
+func TestMemoryCache_DoesNotAliasValues(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	stored := &group{Name: "a", Config: map[string]string{"header": "X-Convoy-Signature"}}
+	require.NoError(t, c.Set(ctx, "a", stored, time.Minute))
+
+	// changing the value after Set doesn't change the entry
+	stored.Config["header"] = "X-Changed"
+
+	var first group
+	require.NoError(t, c.Get(ctx, "a", &first))
+	require.Equal(t, "X-Convoy-Signature", first.Config["header"])
+
+	// nor does changing what one Get returned change the next
+	first.Config["header"] = "X-Changed"
+
+	var second group
+	require.NoError(t, c.Get(ctx, "a", &second))
+	require.Equal(t, "X-Convoy-Signature", second.Config["header"])
+}
+
+func TestMemoryCache_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(config.MemoryCacheConfiguration{MaxEntries: 50})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 500; j++ {
+				key := fmt.Sprintf("key-%d", (i*500+j)%100)
+
+				require.NoError(t, c.Set(ctx, key, &group{Name: key}, time.Minute))
+
+				var g group
+				require.NoError(t, c.Get(ctx, key, &g))
+
+				if j%10 == 0 {
+					require.NoError(t, c.Delete(ctx, key))
+				}
+
+				if j%100 == 0 {
+					c.removeExpired(time.Now())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, c.Len(), 50)
+}
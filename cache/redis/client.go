@@ -2,20 +2,22 @@ package rcache
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/go-redis/cache/v8"
 	"github.com/go-redis/redis/v8"
 )
 
 type RedisCache struct {
-	cache *cache.Cache
+	cache  *cache.Cache
+	prefix string
 }
 
-func NewRedisCache(dsn string) (*RedisCache, error) {
-	opts, err := redis.ParseURL(dsn)
-
+func NewRedisCache(cfg config.RedisCacheConfiguration) (*RedisCache, error) {
+	opts, err := redisOptions(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -26,34 +28,67 @@ func NewRedisCache(dsn string) (*RedisCache, error) {
 		Redis: client,
 	})
 
-	r := &RedisCache{cache: c}
+	r := &RedisCache{cache: c, prefix: cfg.KeyPrefix}
 
 	return r, nil
 }
 
+// redisOptions reads the connection options from the dsn when there is
+// one, and from the separate fields of cfg otherwise.
+func redisOptions(cfg config.RedisCacheConfiguration) (*redis.Options, error) {
+	if cfg.Dsn != "" {
+		return redis.ParseURL(cfg.Dsn)
+	}
+
+	if cfg.Address == "" {
+		return nil, errors.New("the redis cache needs either a dsn or an address")
+	}
+
+	opts := &redis.Options{
+		Addr:     cfg.Address,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DB:       cfg.Database,
+	}
+
+	if cfg.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return opts, nil
+}
+
+// Set stores data under key for ttl.
 func (r *RedisCache) Set(ctx context.Context, key string, data interface{}, ttl time.Duration) error {
 	return r.cache.Set(&cache.Item{
 		Ctx:   ctx,
-		Key:   key,
+		Key:   r.prefix + key,
 		Value: data,
 		TTL:   ttl,
 	})
 }
 
+// Get reads the entry under key into data, leaving data untouched on a
+// miss.
 func (r *RedisCache) Get(ctx context.Context, key string, data interface{}) error {
-	err := r.cache.Get(ctx, key, &data)
+	_, err := r.Find(ctx, key, data)
+	return err
+}
 
+// Find is Get, but it also reports whether there was an entry under key.
+func (r *RedisCache) Find(ctx context.Context, key string, data interface{}) (bool, error) {
+	err := r.cache.Get(ctx, r.prefix+key, data)
 	if errors.Is(err, cache.ErrCacheMiss) {
-		return nil
+		return false, nil
 	}
 
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
-	return r.cache.Delete(ctx, key)
+	return r.cache.Delete(ctx, r.prefix+key)
 }
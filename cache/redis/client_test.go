@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,10 +21,14 @@ func getDSN() string {
 	return os.Getenv("TEST_REDIS_DSN")
 }
 
+func getConfig() config.RedisCacheConfiguration {
+	return config.RedisCacheConfiguration{Dsn: getDSN()}
+}
+
 const key = "test_key"
 
 func Test_WriteToCache(t *testing.T) {
-	cache, err := NewRedisCache(getDSN())
+	cache, err := NewRedisCache(getConfig())
 	require.NoError(t, err)
 
 	err = cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
@@ -31,7 +36,7 @@ func Test_WriteToCache(t *testing.T) {
 }
 
 func Test_ReadFromCache(t *testing.T) {
-	cache, err := NewRedisCache(getDSN())
+	cache, err := NewRedisCache(getConfig())
 	require.NoError(t, err)
 
 	err = cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
@@ -45,7 +50,7 @@ func Test_ReadFromCache(t *testing.T) {
 }
 
 func Test_DeleteFromCache(t *testing.T) {
-	cache, err := NewRedisCache(getDSN())
+	cache, err := NewRedisCache(getConfig())
 	require.NoError(t, err)
 
 	err = cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
@@ -59,3 +64,28 @@ func Test_DeleteFromCache(t *testing.T) {
 
 	require.Equal(t, "", item.Name)
 }
+
+func Test_PrefixesKeys(t *testing.T) {
+	cfg := getConfig()
+
+	cfg.KeyPrefix = "convoy-a:"
+	a, err := NewRedisCache(cfg)
+	require.NoError(t, err)
+
+	cfg.KeyPrefix = "convoy-b:"
+	b, err := NewRedisCache(cfg)
+	require.NoError(t, err)
+
+	err = a.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
+	require.NoError(t, err)
+
+	// caches with different prefixes don't see each other's entries
+	var item data
+	err = b.Get(context.TODO(), key, &item)
+	require.NoError(t, err)
+	require.Equal(t, "", item.Name)
+
+	err = a.Get(context.TODO(), key, &item)
+	require.NoError(t, err)
+	require.Equal(t, "test_name", item.Name)
+}
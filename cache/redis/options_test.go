@@ -0,0 +1,75 @@
+package rcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_redisOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.RedisCacheConfiguration
+		wantAddr string
+		wantDB   int
+		wantTLS  bool
+		wantErr  bool
+	}{
+		{
+			name:     "dsn",
+			cfg:      config.RedisCacheConfiguration{Dsn: "redis://localhost:6379/2", Address: "ignored:6379"},
+			wantAddr: "localhost:6379",
+			wantDB:   2,
+		},
+		{
+			name:     "address",
+			cfg:      config.RedisCacheConfiguration{Address: "redis.internal:6380", Username: "convoy", Password: "secret", Database: 3, TLS: true},
+			wantAddr: "redis.internal:6380",
+			wantDB:   3,
+			wantTLS:  true,
+		},
+		{
+			name:    "neither",
+			cfg:     config.RedisCacheConfiguration{KeyPrefix: "convoy:"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := redisOptions(tc.cfg)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantAddr, opts.Addr)
+			require.Equal(t, tc.wantDB, opts.DB)
+			require.Equal(t, tc.wantTLS, opts.TLSConfig != nil)
+		})
+	}
+}
+
+func Test_UnreachableRedisReturnsErrors(t *testing.T) {
+	// nothing listens on the discard port
+	c, err := NewRedisCache(config.RedisCacheConfiguration{Address: "127.0.0.1:9"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = c.Set(ctx, "key", &struct{ Name string }{Name: "test_name"}, time.Minute)
+	require.Error(t, err)
+
+	item := struct{ Name string }{Name: "unchanged"}
+	found, err := c.Find(ctx, "key", &item)
+	require.Error(t, err)
+	require.False(t, found)
+	require.Equal(t, "unchanged", item.Name)
+
+	require.Error(t, c.Delete(ctx, "key"))
+}
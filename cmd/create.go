@@ -69,6 +69,19 @@ func createEndpointCommand(a *app) *cobra.Command {
 				return fmt.Errorf("could not fetch application from the database...%w", err)
 			}
 
+			// endpoints created without a secret take the app's deprecated
+			// secret, or a generated one
+			if util.IsStringEmpty(e.Secret) {
+				e.Secret = app.Secret
+			}
+
+			if util.IsStringEmpty(e.Secret) {
+				e.Secret, err = util.GenerateSecret()
+				if err != nil {
+					return fmt.Errorf("could not generate secret...%v", err)
+				}
+			}
+
 			app.Endpoints = append(app.Endpoints, *e)
 
 			ctx, cancelFn = getCtx()
@@ -94,6 +107,7 @@ func createEndpointCommand(a *app) *cobra.Command {
 
 	cmd.Flags().StringVar(&e.Description, "description", "", "Description of this endpoint")
 	cmd.Flags().StringVar(&e.TargetURL, "target", "", "The target url of this endpoint")
+	cmd.Flags().StringVar(&e.Secret, "secret", "", "The secret webhooks to this endpoint are signed with. If blank, it will be automatically generated")
 	cmd.Flags().StringVar(&appID, "app", "", "The app this endpoint belongs to")
 
 	return cmd
@@ -131,17 +145,11 @@ func createApplicationCommand(a *app) *cobra.Command {
 				return err
 			}
 
-			if util.IsStringEmpty(appSecret) {
-				appSecret, err = util.GenerateSecret()
-				if err != nil {
-					return fmt.Errorf("could not generate secret...%v", err)
-				}
-			}
-
 			app := &datastore.Application{
 				UID:            uuid.New().String(),
 				GroupID:        group.UID,
 				Title:          appName,
+				Secret:         appSecret,
 				CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 				UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 				Endpoints:      []datastore.Endpoint{},
@@ -164,7 +172,8 @@ func createApplicationCommand(a *app) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&groupID, "group", "", "Group that owns this application")
-	cmd.Flags().StringVar(&appSecret, "secret", "", "Provide the secret for app endpoint(s). If blank, it will be automatically generated")
+	cmd.Flags().StringVar(&appSecret, "secret", "", "Provide the default secret for app endpoint(s)")
+	_ = cmd.Flags().MarkDeprecated("secret", "endpoints are signed with their own secret, set it when creating the endpoint")
 
 	return cmd
 }
@@ -287,15 +296,23 @@ func createMessageCommand(a *app) *cobra.Command {
 				return errors.New("app has no enabled endpoints")
 			}
 
+			group, err := a.groupRepo.FetchGroupByID(ctx, appData.GroupID)
+			if err != nil {
+				return err
+			}
+
 			log.Println("Event ", string(d))
 			msg := &datastore.Event{
 				UID: uuid.New().String(),
 				AppMetadata: &datastore.AppMetadata{
-					UID: appData.UID,
+					UID:     appData.UID,
+					GroupID: appData.GroupID,
 				},
-				EventType: datastore.EventType(eventType),
-				Data:      d,
+				EventType:    datastore.EventType(eventType),
+				EventVersion: datastore.DefaultEventVersion,
+				Data:         d,
 
+				ExpiresAt:      group.EventExpiry(time.Now()),
 				CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 				UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 				DocumentStatus: datastore.ActiveDocumentStatus,
@@ -29,7 +29,9 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/objectstore"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/quota"
 	"github.com/spf13/cobra"
 
 	"github.com/frain-dev/convoy/datastore/mongo"
@@ -109,6 +111,14 @@ func ensureDefaultGroup(ctx context.Context, cfg config.Configuration, a *app) e
 				IntervalSeconds: cfg.GroupConfig.Strategy.Default.IntervalSeconds,
 				RetryLimit:      cfg.GroupConfig.Strategy.Default.RetryLimit,
 			},
+			ExponentialBackoff: datastore.ExponentialBackoffStrategyConfiguration{
+				RetryLimit: cfg.GroupConfig.Strategy.ExponentialBackoff.RetryLimit,
+			},
+			Linear: datastore.LinearStrategyConfiguration{
+				InitialIntervalSeconds: cfg.GroupConfig.Strategy.Linear.InitialIntervalSeconds,
+				StepSeconds:            cfg.GroupConfig.Strategy.Linear.StepSeconds,
+				RetryLimit:             cfg.GroupConfig.Strategy.Linear.RetryLimit,
+			},
 		},
 		Signature: datastore.SignatureConfiguration{
 			Header: config.SignatureHeaderProvider(cfg.GroupConfig.Signature.Header),
@@ -151,18 +161,32 @@ func ensureDefaultGroup(ctx context.Context, cfg config.Configuration, a *app) e
 }
 
 type app struct {
+	database          datastore.DatabaseClient
 	apiKeyRepo        datastore.APIKeyRepository
+	auditLogRepo      datastore.AuditLogRepository
+	archiveRepo       datastore.ArchiveRepository
 	groupRepo         datastore.GroupRepository
 	applicationRepo   datastore.ApplicationRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventBridgeRepo   datastore.EventBridgeRepository
+	eventTypeRepo     datastore.EventTypeRepository
+	exportJobRepo     datastore.ExportJobRepository
+	settingsRepo      datastore.GlobalSettingsRepository
 	eventQueue        queue.Queuer
 	deadLetterQueue   queue.Queuer
 	createEventQueue  queue.Queuer
 	logger            logger.Logger
 	tracer            tracer.Tracer
 	cache             cache.Cache
-	limiter           limiter.RateLimiter
+	limiter           *limiter.ReloadableLimiter
+	quota             quota.Counter
+	objectStore       objectstore.ObjectStore
+	configFile        string
+
+	// resolveConfig completes the configs reloaded from configFile like
+	// the running config was, it is nil for configs used as loaded.
+	resolveConfig config.Resolver
 }
 
 func getCtx() (context.Context, context.CancelFunc) {
@@ -177,7 +201,9 @@ func NewDB(cfg config.Configuration) (datastore.DatabaseClient, error) {
 			return nil, err
 		}
 		return db, nil
-	case config.InMemoryDatabaseProvider:
+	case config.InMemoryDatabaseProvider, config.BadgerDatabaseProvider:
+		// both are the embedded badger store, kept in a local directory
+		// so convoy can be tried out without a mongo server
 		bolt, err := badger.New(cfg)
 		if err != nil {
 			return nil, err
@@ -210,6 +236,25 @@ func preRun(app *app, db datastore.DatabaseClient) func(cmd *cobra.Command, args
 			return err
 		}
 
+		config.Override(&cfg)
+
+		checkConfig, err := cmd.Flags().GetBool("check-config")
+		if err != nil {
+			return err
+		}
+
+		if checkConfig {
+			// the exit code is what ci pipelines look at, a bad config
+			// exits with 1 like any other failed command
+			err = config.SetServerConfigDefaults(&cfg)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("config is valid")
+			os.Exit(0)
+		}
+
 		db, err := NewDB(cfg)
 		if err != nil {
 			return err
@@ -237,7 +282,7 @@ func preRun(app *app, db datastore.DatabaseClient) func(cmd *cobra.Command, args
 		var lS queue.Storage
 		var opts queue.QueueOptions
 		var ca cache.Cache
-		var li limiter.RateLimiter
+		var li *limiter.ReloadableLimiter
 
 		if cfg.Queue.Type == config.RedisQueueProvider {
 			rC, qFn, err = redisqueue.NewClient(cfg)
@@ -285,16 +330,33 @@ func preRun(app *app, db datastore.DatabaseClient) func(cmd *cobra.Command, args
 			return err
 		}
 
-		li, err = limiter.NewLimiter(cfg.Limiter)
+		li, err = limiter.NewReloadableLimiter(cfg.Limiter)
+		if err != nil {
+			return err
+		}
+
+		qc, err := quota.NewCounter(cfg.Limiter)
+		if err != nil {
+			return err
+		}
+
+		st, err := objectstore.NewObjectStore(cfg.Archive)
 		if err != nil {
 			return err
 		}
 
+		app.database = db
 		app.apiKeyRepo = db.APIRepo()
+		app.auditLogRepo = db.AuditLogRepo()
+		app.archiveRepo = db.ArchiveRepo()
 		app.groupRepo = db.GroupRepo()
 		app.eventRepo = db.EventRepo()
 		app.applicationRepo = db.AppRepo()
 		app.eventDeliveryRepo = db.EventDeliveryRepo()
+		app.eventBridgeRepo = db.EventBridgeRepo()
+		app.eventTypeRepo = db.EventTypeRepo()
+		app.exportJobRepo = db.ExportJobRepo()
+		app.settingsRepo = db.GlobalSettingsRepo()
 
 		app.eventQueue = NewQueue(opts, "EventQueue")
 		app.createEventQueue = NewQueue(opts, "CreateEventQueue")
@@ -304,6 +366,9 @@ func preRun(app *app, db datastore.DatabaseClient) func(cmd *cobra.Command, args
 		app.tracer = tr
 		app.cache = ca
 		app.limiter = li
+		app.quota = qc
+		app.objectStore = st
+		app.configFile = cfgPath
 
 		return ensureDefaultGroup(context.Background(), cfg, app)
 	}
@@ -335,11 +400,13 @@ func parsePersistentArgs(app *app, cmd *cobra.Command) {
 	var dbDsn string
 	var queue string
 	var configFile string
+	var checkConfig bool
 
 	cmd.PersistentFlags().StringVar(&configFile, "config", "./convoy.json", "Configuration file for convoy")
 	cmd.PersistentFlags().StringVar(&queue, "queue", "", "Queue provider (\"redis\" or \"in-memory\")")
 	cmd.PersistentFlags().StringVar(&dbDsn, "db", "", "Database dsn or path to in-memory file")
 	cmd.PersistentFlags().StringVar(&redisDsn, "redis", "", "Redis dsn")
+	cmd.PersistentFlags().BoolVar(&checkConfig, "check-config", false, "Validate the config file and environment, then exit")
 
 	cmd.AddCommand(addVersionCommand())
 	cmd.AddCommand(addCreateCommand(app))
@@ -350,6 +417,7 @@ func parsePersistentArgs(app *app, cmd *cobra.Command) {
 	cmd.AddCommand(addRetryCommand(app))
 	cmd.AddCommand(addSchedulerCommand(app))
 	cmd.AddCommand(addUpgradeCommand(app))
+	cmd.AddCommand(addMigrateCommand(app))
 }
 
 type ConvoyCli struct {
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/services"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func addMigrateCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate existing data",
+	}
+
+	cmd.AddCommand(migrateEndpointSecretsCommand(a))
+
+	return cmd
+}
+
+func migrateEndpointSecretsCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "endpoint-secrets",
+		Short: "Set a secret on every endpoint that has none, copying the app's deprecated secret when it has one",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appService := services.NewAppService(a.applicationRepo, a.eventRepo, a.eventDeliveryRepo, a.eventQueue, a.cache, a.limiter)
+
+			updated, err := appService.BackfillEndpointSecrets(context.Background())
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Set the secret of %d endpoint(s)", updated)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -3,6 +3,11 @@ package main
 import (
 	"context"
 	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/frain-dev/convoy"
@@ -11,7 +16,13 @@ import (
 	"github.com/frain-dev/convoy/worker/task"
 
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/logger"
+	"github.com/frain-dev/convoy/notification"
+	"github.com/frain-dev/convoy/notification/email"
+	"github.com/frain-dev/convoy/notification/noop"
 	"github.com/frain-dev/convoy/server"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/sources"
 	"github.com/frain-dev/convoy/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -20,6 +31,7 @@ import (
 func addServerCommand(a *app) *cobra.Command {
 
 	var env string
+	var host string
 	var baseUrl string
 	var sentry string
 	var limiter string
@@ -70,14 +82,16 @@ func addServerCommand(a *app) *cobra.Command {
 			}
 
 			// override config with cli flags
-			err = loadServerConfigFromCliFlags(cmd, &c)
+			err = resolveServerConfig(cmd, &c)
 			if err != nil {
 				return err
 			}
 
-			err = config.SetServerConfigDefaults(&c)
-			if err != nil {
-				return err
+			// the running config is the resolved one, reloads resolve the
+			// file the same way so the cli flags keep applying
+			config.Override(&c)
+			a.resolveConfig = func(c *config.Configuration) error {
+				return resolveServerConfig(cmd, c)
 			}
 
 			err = StartConvoyServer(a, c, withWorkers)
@@ -95,9 +109,10 @@ func addServerCommand(a *app) *cobra.Command {
 	cmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level")
 	cmd.Flags().StringVar(&logger, "logger", "info", "Logger")
 	cmd.Flags().StringVar(&env, "env", "development", "Convoy environment")
+	cmd.Flags().StringVar(&host, "host", "", "Server listen address")
 	cmd.Flags().StringVar(&baseUrl, "base-url", "", "Base Url - Used for the app portal")
-	cmd.Flags().StringVar(&cache, "cache", "redis", `Cache Provider ("redis" or "in-memory")`)
-	cmd.Flags().StringVar(&limiter, "limiter", "redis", `Rate limiter provider ("redis" or "in-memory")`)
+	cmd.Flags().StringVar(&cache, "cache", "redis", `Cache Provider ("redis" or "memory")`)
+	cmd.Flags().StringVar(&limiter, "limiter", "redis", `Rate limiter provider ("redis" or "memory")`)
 	cmd.Flags().StringVar(&sentry, "sentry", "", "Sentry DSN")
 	cmd.Flags().StringVar(&sslCertFile, "ssl-cert-file", "", "SSL certificate file")
 	cmd.Flags().StringVar(&sslKeyFile, "ssl-key-file", "", "SSL key file")
@@ -151,22 +166,110 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 		return errors.New("please provide the HTTP port in the convoy.json file")
 	}
 
+	reloader := config.NewReloader(a.configFile)
+	reloader.SetResolver(a.resolveConfig)
+	reloader.AddListener(a.limiter.OnConfigChange)
+	reloader.AddListener(realm_chain.NewConfigChangeListener(a.apiKeyRepo))
+	reloader.AddListener(logger.NewConfigChangeListener(a.logger))
+
+	watcher, err := config.NewWatcher(reloader)
+	if err != nil {
+		log.WithError(err).Error("failed to watch config file, changes will need a SIGHUP or a restart")
+	} else {
+		watcher.Start()
+		defer watcher.Stop()
+	}
+
+	httpConfig := cfg.Server.HTTP
+
+	var certs *server.CertificateReloader
+	if httpConfig.SSL {
+		certs, err = server.NewCertificateReloader(httpConfig.SSLCertFile, httpConfig.SSLKeyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// reload the config and the tls certificate on SIGHUP, failures are
+	// logged by the reloaders and leave the running ones in place
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for range hup {
+			_, _ = reloader.Reload()
+			if certs != nil {
+				_ = certs.Reload()
+			}
+		}
+	}()
+
+	// new requests, events and jobs stop being taken on the first signal,
+	// those in flight are waited for below
+	stopCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	readiness := &server.Readiness{}
 	srv := server.New(cfg,
 		a.eventRepo,
 		a.eventDeliveryRepo,
 		a.applicationRepo,
 		a.apiKeyRepo,
+		a.auditLogRepo,
+		a.archiveRepo,
+		a.exportJobRepo,
+		a.eventBridgeRepo,
+		a.eventTypeRepo,
+		a.settingsRepo,
 		a.groupRepo,
+		a.database,
+		reloader,
+		readiness,
 		a.eventQueue,
 		a.createEventQueue,
 		a.logger,
 		a.tracer,
 		a.cache,
-		a.limiter)
+		a.limiter,
+		a.quota,
+		a.objectStore)
+
+	// keep group statistics warm in the cache, the server reads them from there
+	groupService := services.NewGroupService(a.applicationRepo, a.groupRepo, a.eventRepo, a.eventDeliveryRepo, a.settingsRepo, a.limiter, a.cache)
+	statsRefresher := services.NewStatsRefresher(groupService, time.Duration(cfg.Statistics.RefreshInterval)*time.Second, cfg.Statistics.Workers)
+	statsRefresher.Start(stopCtx)
+
+	source, err := sources.NewEventSource(cfg.Source)
+	if err != nil {
+		return err
+	}
+
+	if source != nil {
+		eventService := services.NewEventService(a.applicationRepo, a.eventRepo, a.eventDeliveryRepo, a.eventTypeRepo, a.eventQueue, a.createEventQueue, a.cache)
+		handler := sources.NewIngestHandler(a.applicationRepo, a.groupRepo, eventService)
+
+		go func() {
+			err := source.Listen(stopCtx, handler)
+			if err != nil {
+				log.WithError(err).Error("event source stopped")
+			}
+		}()
+	}
+
+	var jobs sync.WaitGroup
+	var producers []*worker.Producer
+
+	// export jobs are run by the api, so their files are cleaned up here
+	// whether or not this server runs the workers
+	exportService := services.NewExportService(a.eventRepo, a.eventDeliveryRepo, a.exportJobRepo, a.objectStore)
+	worker.RegisterExportCleanupJob(stopCtx, &jobs, exportService, worker.ExportCleanupJobInterval)
 
 	if withWorkers {
 		// register tasks.
-		handler := task.ProcessEventDelivery(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter)
+		bridge := task.NewEventBridge(a.eventBridgeRepo, a.applicationRepo, a.groupRepo, a.eventRepo, a.createEventQueue)
+		metaEvents := task.NewMetaEventEmitter(a.eventDeliveryRepo, a.eventQueue)
+		notifier := newEndpointNotifier(cfg)
+		handler := task.ProcessEventDelivery(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter, bridge, metaEvents, notifier)
 		if err := task.CreateTasks(a.groupRepo, convoy.EventProcessor, handler); err != nil {
 			log.WithError(err).Error("failed to register tasks")
 			return err
@@ -179,7 +282,7 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			return err
 		}
 
-		worker.RegisterNewGroupTask(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter, a.eventRepo, a.cache, a.eventQueue)
+		worker.RegisterNewGroupTask(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter, a.eventRepo, a.cache, a.eventQueue, bridge, metaEvents, notifier)
 
 		log.Infof("Starting Convoy workers...")
 
@@ -194,18 +297,122 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 		if cfg.Queue.Type != config.InMemoryQueueProvider {
 			eventCreationProducer.Start(ctx)
 		}
+
+		producers = append(producers, eventCreationProducer, producer)
+
+		if a.objectStore != nil {
+			archiveService := services.NewArchiveService(a.groupRepo, a.eventRepo, a.eventDeliveryRepo, a.archiveRepo, a.objectStore)
+			worker.RegisterRetentionJob(stopCtx, &jobs, archiveService, worker.RetentionJobInterval)
+		}
+
+		healthScoreUpdater := task.NewHealthScoreUpdater(a.groupRepo, a.applicationRepo, a.eventDeliveryRepo, metaEvents, task.DefaultHealthScoreWindow)
+		worker.RegisterHealthScoreJob(stopCtx, &jobs, healthScoreUpdater, worker.HealthScoreJobInterval)
+
+		eventService := services.NewEventService(a.applicationRepo, a.eventRepo, a.eventDeliveryRepo, a.eventTypeRepo, a.eventQueue, a.createEventQueue, a.cache)
+		worker.RegisterStuckDeliveriesJob(stopCtx, &jobs, eventService, worker.StuckDeliveriesJobInterval, worker.StuckDeliveryMaxAge)
 	}
 
 	log.Infof("Started convoy server in %s", time.Since(start))
 
-	httpConfig := cfg.Server.HTTP
-	if httpConfig.SSL {
-		log.Infof("Started server with SSL: cert_file: %s, key_file: %s", httpConfig.SSLCertFile, httpConfig.SSLKeyFile)
-		return srv.ListenAndServeTLS(httpConfig.SSLCertFile, httpConfig.SSLKeyFile)
+	serveErr := make(chan error, 1)
+	go func() {
+		var err error
+		if certs != nil {
+			log.Infof("Started server with SSL: cert_file: %s, key_file: %s", httpConfig.SSLCertFile, httpConfig.SSLKeyFile)
+			srv.TLSConfig = certs.TLSConfig()
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			log.Infof("Server running on %s", srv.Addr)
+			err = srv.ListenAndServe()
+		}
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			stop()
+		}
+	}()
+
+	<-stopCtx.Done()
+
+	return drainConvoyServer(srv, readiness, &jobs, producers, serveErr)
+}
+
+// drainConvoyServer stops srv the way a load balancer expects: it is
+// marked not ready and keeps serving for the pre-stop delay, then new
+// requests are refused and the requests and deliveries in flight get the
+// configured drain period to finish.
+func drainConvoyServer(srv *http.Server, readiness *server.Readiness, jobs *sync.WaitGroup, producers []*worker.Producer, serveErr <-chan error) error {
+	readiness.Drain()
+
+	// the delay and drain period are read again as they may have been reloaded
+	preStopDelay, drainPeriod := config.DefaultHTTPPreStopDelay, config.DefaultHTTPDrainPeriod
+	if cfg, err := config.Get(); err == nil {
+		if delay, err := cfg.Server.HTTP.GetPreStopDelay(); err == nil {
+			preStopDelay = delay
+		}
+
+		if period, err := cfg.Server.HTTP.GetDrainPeriod(); err == nil {
+			drainPeriod = period
+		}
+	}
+
+	// requests keep being served until load balancers see the server
+	// isn't ready and stop routing to it
+	if preStopDelay > 0 {
+		log.Infof("Stopping convoy server in %s", preStopDelay)
+		time.Sleep(preStopDelay)
+	}
+
+	log.Infof("Stopping convoy server, waiting up to %s for requests and deliveries in flight", drainPeriod)
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainPeriod)
+	defer cancel()
+
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		log.WithError(err).Error("failed to stop server")
+	}
+
+	err = worker.Shutdown(ctx, jobs, producers...)
+
+	select {
+	case e := <-serveErr:
+		return e
+	default:
+		return err
+	}
+}
+
+// newEndpointNotifier returns the notifier telling app owners their
+// endpoints were disabled. Nobody is told when SMTP isn't configured.
+func newEndpointNotifier(cfg config.Configuration) notification.Notifier {
+	if util.IsStringEmpty(cfg.SMTP.URL) {
+		return noop.NewNoopNotifier()
+	}
+
+	notifier, err := email.NewEmailNotifier(&cfg.SMTP)
+	if err != nil {
+		log.WithError(err).Error("failed to set up the email notifier, endpoint owners will not be notified")
+		return noop.NewNoopNotifier()
+	}
+
+	return notifier
+}
+
+// resolveServerConfig completes c, a config loaded from the file, with the
+// cli flags and defaults of the server, like it is completed on start.
+func resolveServerConfig(cmd *cobra.Command, c *config.Configuration) error {
+	err := config.OverrideConfigWithCliFlags(cmd, c)
+	if err != nil {
+		return err
 	}
 
-	log.Infof("Server running on port %v", cfg.Server.HTTP.Port)
-	return srv.ListenAndServe()
+	err = loadServerConfigFromCliFlags(cmd, c)
+	if err != nil {
+		return err
+	}
+
+	return config.SetServerConfigDefaults(c)
 }
 
 func loadServerConfigFromCliFlags(cmd *cobra.Command, c *config.Configuration) error {
@@ -313,6 +520,16 @@ func loadServerConfigFromCliFlags(cmd *cobra.Command, c *config.Configuration) e
 		c.Server.HTTP.SSL = ssl
 	}
 
+	// CONVOY_HOST
+	host, err := cmd.Flags().GetString("host")
+	if err != nil {
+		return err
+	}
+
+	if !util.IsStringEmpty(host) {
+		c.Server.HTTP.Host = host
+	}
+
 	// PORT
 	port, err := cmd.Flags().GetUint32("port")
 	if err != nil {
@@ -2,12 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/server"
+	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/worker"
+	"github.com/frain-dev/convoy/worker/task"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -27,7 +33,21 @@ func addWorkerCommand(a *app) *cobra.Command {
 				return err
 			}
 
-			worker.RegisterNewGroupTask(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter, a.eventRepo, a.cache, a.eventQueue)
+			shutdownTimeout, err := cfg.Worker.GetGracefulShutdownTimeout()
+			if err != nil {
+				return err
+			}
+
+			// jobs stop being scheduled on the first signal, those running
+			// are waited for below
+			stopCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+			defer stop()
+
+			var jobs sync.WaitGroup
+
+			bridge := task.NewEventBridge(a.eventBridgeRepo, a.applicationRepo, a.groupRepo, a.eventRepo, a.createEventQueue)
+			metaEvents := task.NewMetaEventEmitter(a.eventDeliveryRepo, a.eventQueue)
+			worker.RegisterNewGroupTask(a.applicationRepo, a.eventDeliveryRepo, a.groupRepo, a.limiter, a.eventRepo, a.cache, a.eventQueue, bridge, metaEvents, newEndpointNotifier(cfg))
 			// register workers.
 			ctx := context.Background()
 			eventCreationProducer := worker.NewProducer(a.createEventQueue)
@@ -41,8 +61,25 @@ func addWorkerCommand(a *app) *cobra.Command {
 
 			}
 
+			if a.objectStore != nil {
+				archiveService := services.NewArchiveService(a.groupRepo, a.eventRepo, a.eventDeliveryRepo, a.archiveRepo, a.objectStore)
+				worker.RegisterRetentionJob(stopCtx, &jobs, archiveService, worker.RetentionJobInterval)
+			}
+
+			deletedDocumentTTL, err := cfg.Database.GetDeletedDocumentTTL()
+			if err != nil {
+				return err
+			}
+
+			purgeService := services.NewPurgeService(a.groupRepo, a.applicationRepo, a.apiKeyRepo, a.eventRepo, deletedDocumentTTL)
+			worker.RegisterPurgeJob(stopCtx, &jobs, purgeService, worker.PurgeJobInterval)
+
+			healthScoreUpdater := task.NewHealthScoreUpdater(a.groupRepo, a.applicationRepo, a.eventDeliveryRepo, metaEvents, task.DefaultHealthScoreWindow)
+			worker.RegisterHealthScoreJob(stopCtx, &jobs, healthScoreUpdater, worker.HealthScoreJobInterval)
+
 			worker.RegisterWorkerMetrics(a.eventQueue, cfg)
 			server.RegisterQueueMetrics(a.eventQueue, cfg)
+			server.RegisterCacheMetrics(a.cache)
 
 			router := chi.NewRouter()
 			router.Handle("/v1/metrics", promhttp.Handler())
@@ -55,15 +92,28 @@ func addWorkerCommand(a *app) *cobra.Command {
 				Addr:    fmt.Sprintf(":%d", workerPort),
 			}
 
-			log.Infof("Worker running on port %v", workerPort)
+			go func() {
+				log.Infof("Worker running on port %v", workerPort)
 
-			e := srv.ListenAndServe()
-			if e != nil {
-				return e
+				e := srv.ListenAndServe()
+				if e != nil && !errors.Is(e, http.ErrServerClosed) {
+					log.WithError(e).Error("worker server stopped")
+					stop()
+				}
+			}()
+
+			<-stopCtx.Done()
+			log.Infof("Stopping worker, waiting up to %s for jobs in flight", shutdownTimeout)
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			err = srv.Shutdown(shutdownCtx)
+			if err != nil {
+				log.WithError(err).Error("failed to stop worker server")
 			}
 
-			<-ctx.Done()
-			return ctx.Err()
+			return worker.Shutdown(shutdownCtx, &jobs, eventCreationProducer, producer)
 		},
 	}
 
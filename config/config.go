@@ -1,14 +1,21 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config/algo"
+	"github.com/ghodss/yaml"
 	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -17,13 +24,202 @@ import (
 const (
 	MaxResponseSizeKb = 50                       // in kilobytes
 	MaxResponseSize   = MaxResponseSizeKb * 1024 // in bytes
+
+	DefaultMaxEventPayloadSizeKb = 1024                                // in kilobytes
+	DefaultMaxEventPayloadSize   = DefaultMaxEventPayloadSizeKb * 1024 // in bytes
+	DefaultMaxRequestBodySizeKb  = 5 * 1024                            // in kilobytes
+	DefaultMaxRequestBodySize    = DefaultMaxRequestBodySizeKb * 1024  // in bytes
+
+	DefaultAPIRateLimitDuration = 60 // in seconds
+
+	DefaultStatisticsRefreshInterval = 60 // in seconds
+	DefaultStatisticsWorkers         = 4
+
+	DefaultSQSMaxMessages         = 10
+	DefaultSQSPollIntervalSeconds = 5
+
+	DefaultDeletedDocumentTTL = 30 * 24 * time.Hour
+
+	DefaultQueryTimeout     = 10 * time.Second
+	DefaultBulkWriteTimeout = 60 * time.Second
+	DefaultConnectTimeout   = 5 * time.Second
+
+	DefaultGracefulShutdownTimeout = 30 * time.Second
+
+	DefaultHTTPReadTimeout  = 30 * time.Second
+	DefaultHTTPWriteTimeout = 30 * time.Second
+	DefaultHTTPIdleTimeout  = 120 * time.Second
+	DefaultHTTPDrainPeriod  = 30 * time.Second
+	DefaultHTTPPreStopDelay = 5 * time.Second
+)
+
+var (
+	// cfgSingleton holds a *versionedConfig, it's swapped as a whole so a
+	// reader never sees half of a reload.
+	cfgSingleton atomic.Value
+
+	// storeMu orders stores so versions only go up.
+	storeMu sync.Mutex
 )
 
-var cfgSingleton atomic.Value
+type versionedConfig struct {
+	cfg     *Configuration
+	version uint64
+}
+
+// store makes c the config returned by Get, under the next version which
+// it returns.
+func store(c *Configuration) uint64 {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	var version uint64
+	if v, ok := cfgSingleton.Load().(*versionedConfig); ok {
+		version = v.version
+	}
+
+	version++
+	cfgSingleton.Store(&versionedConfig{cfg: c, version: version})
+	return version
+}
 
 type DatabaseConfiguration struct {
 	Type DatabaseProvider `json:"type" envconfig:"CONVOY_DB_TYPE"`
 	Dsn  string           `json:"dsn" envconfig:"CONVOY_DB_DSN"`
+
+	// DsnFile is read for the dsn when it is set, see readSecretFiles.
+	DsnFile string `json:"dsn_file" envconfig:"CONVOY_DB_DSN_FILE"`
+
+	// SkipIndexCreation stops convoy creating the indexes it needs on
+	// start, for database users without the privilege to.
+	SkipIndexCreation bool `json:"skip_index_creation" envconfig:"CONVOY_DB_SKIP_INDEX_CREATION"`
+
+	// DeletedDocumentTTL is how long, e.g. "720h", soft-deleted documents
+	// are kept before they are purged. It defaults to 30 days.
+	DeletedDocumentTTL string `json:"deleted_document_ttl" envconfig:"CONVOY_DB_DELETED_DOCUMENT_TTL"`
+
+	// QueryTimeout bounds, e.g. "10s", how long a request waits on a
+	// single database operation. BulkWriteTimeout does the same for
+	// operations that write many documents, it defaults to 60s.
+	QueryTimeout     string `json:"query_timeout" envconfig:"CONVOY_DB_QUERY_TIMEOUT"`
+	BulkWriteTimeout string `json:"bulk_write_timeout" envconfig:"CONVOY_DB_BULK_WRITE_TIMEOUT"`
+
+	// ReadPreference is where reads are sent, one of ReadPreferences.
+	// Writes always go to the primary.
+	ReadPreference ReadPreference `json:"read_preference" envconfig:"CONVOY_DB_READ_PREFERENCE"`
+	MaxPoolSize    uint64         `json:"max_pool_size" envconfig:"CONVOY_DB_MAX_POOL_SIZE"`
+	MinPoolSize    uint64         `json:"min_pool_size" envconfig:"CONVOY_DB_MIN_POOL_SIZE"`
+
+	// ConnectTimeout bounds, e.g. "5s", how long connecting to the
+	// database may take.
+	ConnectTimeout string `json:"connect_timeout" envconfig:"CONVOY_DB_CONNECT_TIMEOUT"`
+}
+
+type ReadPreference string
+
+const (
+	PrimaryReadPreference            ReadPreference = "primary"
+	PrimaryPreferredReadPreference   ReadPreference = "primaryPreferred"
+	SecondaryReadPreference          ReadPreference = "secondary"
+	SecondaryPreferredReadPreference ReadPreference = "secondaryPreferred"
+	NearestReadPreference            ReadPreference = "nearest"
+)
+
+var ReadPreferences = []ReadPreference{
+	PrimaryReadPreference,
+	PrimaryPreferredReadPreference,
+	SecondaryReadPreference,
+	SecondaryPreferredReadPreference,
+	NearestReadPreference,
+}
+
+// GetDeletedDocumentTTL returns how long soft-deleted documents are kept.
+func (d DatabaseConfiguration) GetDeletedDocumentTTL() (time.Duration, error) {
+	if IsStringEmpty(d.DeletedDocumentTTL) {
+		return DefaultDeletedDocumentTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(d.DeletedDocumentTTL)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid deleted document ttl %q, please provide a duration e.g 720h", d.DeletedDocumentTTL)
+	}
+
+	return ttl, nil
+}
+
+// GetQueryTimeout returns how long a single database operation may take.
+func (d DatabaseConfiguration) GetQueryTimeout() (time.Duration, error) {
+	return parseTimeout("query timeout", d.QueryTimeout, DefaultQueryTimeout)
+}
+
+// GetBulkWriteTimeout returns how long an operation writing many
+// documents may take.
+func (d DatabaseConfiguration) GetBulkWriteTimeout() (time.Duration, error) {
+	return parseTimeout("bulk write timeout", d.BulkWriteTimeout, DefaultBulkWriteTimeout)
+}
+
+// GetConnectTimeout returns how long connecting to the database may take.
+func (d DatabaseConfiguration) GetConnectTimeout() (time.Duration, error) {
+	return parseTimeout("connect timeout", d.ConnectTimeout, DefaultConnectTimeout)
+}
+
+func parseTimeout(name, value string, fallback time.Duration) (time.Duration, error) {
+	if IsStringEmpty(value) {
+		return fallback, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s %q, please provide a duration e.g 10s", name, value)
+	}
+
+	return timeout, nil
+}
+
+// RegionConfiguration places this deployment among the regions groups
+// can be replicated to.
+type RegionConfiguration struct {
+	// Name is the region this instance runs in. Database reads go to the
+	// nearest member tagged with it.
+	Name string `json:"name" envconfig:"CONVOY_REGION"`
+
+	// Endpoints maps each region to the url it is served on, a request
+	// is tagged with the region whose host it arrived on.
+	Endpoints RegionEndpoints `json:"endpoints" envconfig:"CONVOY_REGION_ENDPOINTS"`
+}
+
+// RegionEndpoints maps each region to the url it is served on.
+type RegionEndpoints map[string]string
+
+// Decode loads in config from an env var named `CONVOY_REGION_ENDPOINTS`,
+// the urls have colons so it is a json object rather than a list of pairs
+func (r *RegionEndpoints) Decode(value string) error {
+	endpoints := RegionEndpoints{}
+	err := json.Unmarshal([]byte(value), &endpoints)
+
+	*r = endpoints
+	return err
+}
+
+type WorkerConfiguration struct {
+	// GracefulShutdownTimeout is how long, e.g. "30s", a worker that is
+	// asked to stop waits for the jobs it is running to finish.
+	GracefulShutdownTimeout string `json:"graceful_shutdown_timeout" envconfig:"CONVOY_WORKER_GRACEFUL_SHUTDOWN_TIMEOUT"`
+}
+
+// GetGracefulShutdownTimeout returns how long a stopping worker waits for
+// its jobs.
+func (w WorkerConfiguration) GetGracefulShutdownTimeout() (time.Duration, error) {
+	if IsStringEmpty(w.GracefulShutdownTimeout) {
+		return DefaultGracefulShutdownTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(w.GracefulShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid graceful shutdown timeout %q, please provide a duration e.g 30s", w.GracefulShutdownTimeout)
+	}
+
+	return timeout, nil
 }
 
 type SentryConfiguration struct {
@@ -32,6 +228,34 @@ type SentryConfiguration struct {
 
 type ServerConfiguration struct {
 	HTTP HTTPServerConfiguration `json:"http"`
+
+	// ReservedGroupNames are group names that cannot be used in addition
+	// to the ones convoy reserves for itself.
+	ReservedGroupNames []string `json:"reserved_group_names" envconfig:"CONVOY_RESERVED_GROUP_NAMES"`
+
+	// FeatureFlags turns parts of the api off, e.g. {"admin_api": false}.
+	// A feature that isn't in the map is enabled.
+	FeatureFlags FeatureFlags `json:"feature_flags" envconfig:"CONVOY_FEATURE_FLAGS"`
+}
+
+const (
+	AppPortalFeatureFlag     = "app_portal"
+	AdminAPIFeatureFlag      = "admin_api"
+	EventBatchingFeatureFlag = "event_batching"
+)
+
+// featureFlags are the features that can be turned off, see the
+// *FeatureFlag constants.
+var featureFlags = []string{AppPortalFeatureFlag, AdminAPIFeatureFlag, EventBatchingFeatureFlag}
+
+// FeatureFlags maps a feature flag to whether the feature is enabled.
+type FeatureFlags map[string]bool
+
+// Enabled reports whether the feature named flag is enabled, features
+// are enabled unless they're turned off.
+func (f FeatureFlags) Enabled(flag string) bool {
+	enabled, ok := f[flag]
+	return !ok || enabled
 }
 
 type HTTPServerConfiguration struct {
@@ -40,6 +264,90 @@ type HTTPServerConfiguration struct {
 	SSLKeyFile  string `json:"ssl_key_file" envconfig:"CONVOY_SSL_KEY_FILE"`
 	Port        uint32 `json:"port" envconfig:"PORT"`
 	WorkerPort  uint32 `json:"worker_port" envconfig:"WORKER_PORT"`
+
+	// Host is the address the server listens on, every interface when
+	// it's empty.
+	Host string `json:"host" envconfig:"CONVOY_HOST"`
+
+	// ReadTimeout, WriteTimeout and IdleTimeout, e.g. "30s", bound how
+	// long reading a request, writing its response and keeping an idle
+	// connection open may take.
+	ReadTimeout  string `json:"read_timeout" envconfig:"CONVOY_HTTP_READ_TIMEOUT"`
+	WriteTimeout string `json:"write_timeout" envconfig:"CONVOY_HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  string `json:"idle_timeout" envconfig:"CONVOY_HTTP_IDLE_TIMEOUT"`
+
+	// DrainPeriod is how long, e.g. "30s", a server that is asked to stop
+	// waits for the requests and deliveries in flight to finish.
+	DrainPeriod string `json:"drain_period" envconfig:"CONVOY_HTTP_DRAIN_PERIOD"`
+
+	// PreStopDelay is how long, e.g. "5s", a server that is asked to stop
+	// keeps serving while reporting it isn't ready, so load balancers stop
+	// routing to it before it starts draining. "0s" drains straight away.
+	PreStopDelay string `json:"pre_stop_delay" envconfig:"CONVOY_HTTP_PRE_STOP_DELAY"`
+
+	// StreamHeartbeatInterval is the number of seconds between keep-alive
+	// comments sent on open event streams.
+	StreamHeartbeatInterval uint64 `json:"stream_heartbeat_interval" envconfig:"CONVOY_STREAM_HEARTBEAT_INTERVAL"`
+
+	// StreamMaxDuration is the number of seconds an event stream is kept
+	// open before the server closes it. The write timeout cuts streams
+	// off as well, so it has to be longer for streams to last this long.
+	StreamMaxDuration uint64 `json:"stream_max_duration" envconfig:"CONVOY_STREAM_MAX_DURATION"`
+
+	// MaxCountDateRange is the widest date range, in days, the event and
+	// event delivery count endpoints accept.
+	MaxCountDateRange uint64 `json:"max_count_date_range" envconfig:"CONVOY_MAX_COUNT_DATE_RANGE"`
+
+	// MaxRequestBodySize is the largest request body accepted, in
+	// kilobytes, it defaults to 5MB. It is never lower than twice
+	// MaxEventPayloadSize.
+	MaxRequestBodySize uint64 `json:"max_request_body_size" envconfig:"CONVOY_MAX_REQUEST_BODY_SIZE"`
+
+	// APIRateLimit is how many api requests each api key, or each address
+	// when requests aren't made with a key, can make every
+	// APIRateLimitDuration. Zero turns the limit off, keys with their own
+	// rate limit are still limited.
+	APIRateLimit int `json:"api_rate_limit" envconfig:"CONVOY_API_RATE_LIMIT"`
+
+	// APIRateLimitDuration is the window of APIRateLimit in seconds, it
+	// defaults to a minute.
+	APIRateLimitDuration uint64 `json:"api_rate_limit_duration" envconfig:"CONVOY_API_RATE_LIMIT_DURATION"`
+}
+
+// GetReadTimeout returns how long reading a request may take.
+func (h HTTPServerConfiguration) GetReadTimeout() (time.Duration, error) {
+	return parseTimeout("http read timeout", h.ReadTimeout, DefaultHTTPReadTimeout)
+}
+
+// GetWriteTimeout returns how long writing a response may take.
+func (h HTTPServerConfiguration) GetWriteTimeout() (time.Duration, error) {
+	return parseTimeout("http write timeout", h.WriteTimeout, DefaultHTTPWriteTimeout)
+}
+
+// GetIdleTimeout returns how long an idle connection is kept open.
+func (h HTTPServerConfiguration) GetIdleTimeout() (time.Duration, error) {
+	return parseTimeout("http idle timeout", h.IdleTimeout, DefaultHTTPIdleTimeout)
+}
+
+// GetDrainPeriod returns how long a stopping server waits for the
+// requests and deliveries in flight.
+func (h HTTPServerConfiguration) GetDrainPeriod() (time.Duration, error) {
+	return parseTimeout("http drain period", h.DrainPeriod, DefaultHTTPDrainPeriod)
+}
+
+// GetPreStopDelay returns how long a stopping server keeps serving before
+// it starts draining, unlike the timeouts it can be zero.
+func (h HTTPServerConfiguration) GetPreStopDelay() (time.Duration, error) {
+	if IsStringEmpty(h.PreStopDelay) {
+		return DefaultHTTPPreStopDelay, nil
+	}
+
+	delay, err := time.ParseDuration(h.PreStopDelay)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid http pre stop delay %q, please provide a duration e.g 5s", h.PreStopDelay)
+	}
+
+	return delay, nil
 }
 
 type QueueConfiguration struct {
@@ -48,7 +356,8 @@ type QueueConfiguration struct {
 }
 
 type RedisQueueConfiguration struct {
-	Dsn string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	Dsn     string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	DsnFile string `json:"dsn_file" envconfig:"CONVOY_REDIS_DSN_FILE"`
 }
 
 type FileRealmOption struct {
@@ -67,13 +376,14 @@ type NativeRealmOptions struct {
 }
 
 type SMTPConfiguration struct {
-	Provider string `json:"provider" envconfig:"CONVOY_SMTP_PROVIDER"`
-	URL      string `json:"url" envconfig:"CONVOY_SMTP_URL"`
-	Port     uint32 `json:"port" envconfig:"CONVOY_SMTP_PORT"`
-	Username string `json:"username" envconfig:"CONVOY_SMTP_USERNAME"`
-	Password string `json:"password" envconfig:"CONVOY_SMTP_PASSWORD"`
-	From     string `json:"from" envconfig:"CONVOY_SMTP_FROM"`
-	ReplyTo  string `json:"reply-to" envconfig:"CONVOY_SMTP_REPLY_TO"`
+	Provider     string `json:"provider" envconfig:"CONVOY_SMTP_PROVIDER"`
+	URL          string `json:"url" envconfig:"CONVOY_SMTP_URL"`
+	Port         uint32 `json:"port" envconfig:"CONVOY_SMTP_PORT"`
+	Username     string `json:"username" envconfig:"CONVOY_SMTP_USERNAME"`
+	Password     string `json:"password" envconfig:"CONVOY_SMTP_PASSWORD"`
+	PasswordFile string `json:"password_file" envconfig:"CONVOY_SMTP_PASSWORD_FILE"`
+	From         string `json:"from" envconfig:"CONVOY_SMTP_FROM"`
+	ReplyTo      string `json:"reply-to" envconfig:"CONVOY_SMTP_REPLY_TO"`
 }
 
 type ServerLogger struct {
@@ -90,12 +400,84 @@ type TracerConfiguration struct {
 }
 
 type CacheConfiguration struct {
-	Type  CacheProvider           `json:"type"  envconfig:"CONVOY_CACHE_PROVIDER"`
-	Redis RedisCacheConfiguration `json:"redis"`
+	Type   CacheProvider            `json:"type"  envconfig:"CONVOY_CACHE_PROVIDER"`
+	Redis  RedisCacheConfiguration  `json:"redis"`
+	Memory MemoryCacheConfiguration `json:"memory"`
+
+	// NotFoundTTL is the number of seconds a group or app that wasn't
+	// found is remembered as missing, 0 turns it off. About 10 seconds
+	// spares the datastore clients that retry deleted ids.
+	NotFoundTTL uint64 `json:"not_found_ttl" envconfig:"CONVOY_CACHE_NOT_FOUND_TTL"`
 }
 
+type MemoryCacheConfiguration struct {
+	// MaxEntries bounds the cache, the least recently used entries are
+	// evicted past it. It defaults to 128000.
+	MaxEntries int `json:"max_entries" envconfig:"CONVOY_CACHE_MEMORY_MAX_ENTRIES"`
+}
+
+// RedisCacheConfiguration connects to redis either with Dsn or, when it is
+// empty, with the address and credentials given separately.
 type RedisCacheConfiguration struct {
-	Dsn string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	Dsn          string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	DsnFile      string `json:"dsn_file" envconfig:"CONVOY_REDIS_DSN_FILE"`
+	Address      string `json:"address" envconfig:"CONVOY_CACHE_REDIS_ADDRESS"`
+	Username     string `json:"username" envconfig:"CONVOY_CACHE_REDIS_USERNAME"`
+	Password     string `json:"password" envconfig:"CONVOY_CACHE_REDIS_PASSWORD"`
+	PasswordFile string `json:"password_file" envconfig:"CONVOY_CACHE_REDIS_PASSWORD_FILE"`
+	Database     int    `json:"database" envconfig:"CONVOY_CACHE_REDIS_DATABASE"`
+	TLS          bool   `json:"tls" envconfig:"CONVOY_CACHE_REDIS_TLS"`
+
+	// KeyPrefix is prepended to every key, so that deployments sharing a
+	// redis don't read each other's entries.
+	KeyPrefix string `json:"key_prefix" envconfig:"CONVOY_CACHE_REDIS_KEY_PREFIX"`
+}
+
+type ArchiveConfiguration struct {
+	Type ArchiveProvider        `json:"type" envconfig:"CONVOY_ARCHIVE_PROVIDER"`
+	S3   S3ArchiveConfiguration `json:"s3"`
+}
+
+// S3ArchiveConfiguration points at an S3 compatible bucket. Archives of a
+// group are stored under <prefix>/<group id>/.
+type S3ArchiveConfiguration struct {
+	Endpoint  string `json:"endpoint" envconfig:"CONVOY_ARCHIVE_S3_ENDPOINT"`
+	Region    string `json:"region" envconfig:"CONVOY_ARCHIVE_S3_REGION"`
+	Bucket    string `json:"bucket" envconfig:"CONVOY_ARCHIVE_S3_BUCKET"`
+	AccessKey string `json:"access_key" envconfig:"CONVOY_ARCHIVE_S3_ACCESS_KEY"`
+	SecretKey string `json:"secret_key" envconfig:"CONVOY_ARCHIVE_S3_SECRET_KEY"`
+	Prefix    string `json:"prefix" envconfig:"CONVOY_ARCHIVE_S3_PREFIX"`
+}
+
+type SourceConfiguration struct {
+	Type SourceProvider         `json:"type" envconfig:"CONVOY_SOURCE_PROVIDER"`
+	SQS  SQSSourceConfiguration `json:"sqs"`
+}
+
+// SQSSourceConfiguration points at an SQS queue whose messages are
+// ingested as events. Without an access key the credentials are looked up
+// the way the AWS SDK does, from the environment, the shared config files
+// or the instance's role.
+type SQSSourceConfiguration struct {
+	QueueURL     string `json:"queue_url" envconfig:"CONVOY_SOURCE_SQS_QUEUE_URL"`
+	Region       string `json:"region" envconfig:"CONVOY_SOURCE_SQS_REGION"`
+	AccessKey    string `json:"access_key" envconfig:"CONVOY_SOURCE_SQS_ACCESS_KEY"`
+	SecretKey    string `json:"secret_key" envconfig:"CONVOY_SOURCE_SQS_SECRET_KEY"`
+	SessionToken string `json:"session_token" envconfig:"CONVOY_SOURCE_SQS_SESSION_TOKEN"`
+
+	// MaxMessages is how many messages are received at a time, from 1 to 10.
+	MaxMessages int `json:"max_messages" envconfig:"CONVOY_SOURCE_SQS_MAX_MESSAGES"`
+
+	// PollIntervalSeconds is how long to wait before polling an empty queue
+	// again.
+	PollIntervalSeconds int `json:"poll_interval_seconds" envconfig:"CONVOY_SOURCE_SQS_POLL_INTERVAL_SECONDS"`
+}
+
+type StatisticsConfiguration struct {
+	// RefreshInterval is how often, in seconds, group statistics are
+	// recomputed in the background.
+	RefreshInterval uint64 `json:"refresh_interval" envconfig:"CONVOY_STATISTICS_REFRESH_INTERVAL"`
+	Workers         int    `json:"workers" envconfig:"CONVOY_STATISTICS_WORKERS"`
 }
 
 type LimiterConfiguration struct {
@@ -104,7 +486,8 @@ type LimiterConfiguration struct {
 }
 
 type RedisLimiterConfiguration struct {
-	Dsn string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	Dsn     string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+	DsnFile string `json:"dsn_file" envconfig:"CONVOY_REDIS_DSN_FILE"`
 }
 
 type NewRelicConfiguration struct {
@@ -121,16 +504,28 @@ type Configuration struct {
 	Queue           QueueConfiguration    `json:"queue"`
 	Server          ServerConfiguration   `json:"server"`
 	MaxResponseSize uint64                `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
-	GroupConfig     GroupConfig           `json:"group"`
-	SMTP            SMTPConfiguration     `json:"smtp"`
-	Environment     string                `json:"env" envconfig:"CONVOY_ENV" required:"true" default:"development"`
-	MultipleTenants bool                  `json:"multiple_tenants"`
-	Logger          LoggerConfiguration   `json:"logger"`
-	Tracer          TracerConfiguration   `json:"tracer"`
-	NewRelic        NewRelicConfiguration `json:"new_relic"`
-	Cache           CacheConfiguration    `json:"cache"`
-	Limiter         LimiterConfiguration  `json:"limiter"`
-	BaseUrl         string                `json:"base_url" envconfig:"CONVOY_BASE_URL"`
+	// MaxEventPayloadSize is the largest event payload accepted, in
+	// kilobytes. Groups can set a stricter limit of their own.
+	MaxEventPayloadSize uint64                  `json:"max_event_payload_size" envconfig:"CONVOY_MAX_EVENT_PAYLOAD_SIZE"`
+	GroupConfig         GroupConfig             `json:"group"`
+	SMTP                SMTPConfiguration       `json:"smtp"`
+	Environment         string                  `json:"env" envconfig:"CONVOY_ENV" required:"true" default:"development"`
+	MultipleTenants     bool                    `json:"multiple_tenants" envconfig:"CONVOY_MULTIPLE_TENANTS"`
+	Logger              LoggerConfiguration     `json:"logger"`
+	Tracer              TracerConfiguration     `json:"tracer"`
+	NewRelic            NewRelicConfiguration   `json:"new_relic"`
+	Cache               CacheConfiguration      `json:"cache"`
+	Limiter             LimiterConfiguration    `json:"limiter"`
+	Statistics          StatisticsConfiguration `json:"statistics"`
+	Archive             ArchiveConfiguration    `json:"archive"`
+	Source              SourceConfiguration     `json:"source"`
+	Worker              WorkerConfiguration     `json:"worker"`
+	Region              RegionConfiguration     `json:"region"`
+	BaseUrl             string                  `json:"base_url" envconfig:"CONVOY_BASE_URL"`
+
+	// EncryptionKey encrypts secrets convoy has to store in a recoverable
+	// form, e.g. group proxy passwords.
+	EncryptionKey string `json:"encryption_key" envconfig:"CONVOY_ENCRYPTION_KEY"`
 }
 
 const (
@@ -144,13 +539,19 @@ const (
 	InMemoryQueueProvider              QueueProvider           = "in-memory"
 	DefaultStrategyProvider            StrategyProvider        = "default"
 	ExponentialBackoffStrategyProvider StrategyProvider        = "exponential-backoff"
+	LinearStrategyProvider             StrategyProvider        = "linear"
 	DefaultSignatureHeader             SignatureHeaderProvider = "X-Convoy-Signature"
 	ConsoleLoggerProvider              LoggerProvider          = "console"
 	NewRelicTracerProvider             TracerProvider          = "new_relic"
 	RedisCacheProvider                 CacheProvider           = "redis"
+	InMemoryCacheProvider              CacheProvider           = "memory"
 	RedisLimiterProvider               LimiterProvider         = "redis"
+	InMemoryLimiterProvider            LimiterProvider         = "memory"
 	MongodbDatabaseProvider            DatabaseProvider        = "mongodb"
 	InMemoryDatabaseProvider           DatabaseProvider        = "in-memory"
+	BadgerDatabaseProvider             DatabaseProvider        = "badger"
+	S3ArchiveProvider                  ArchiveProvider         = "s3"
+	SQSSourceProvider                  SourceProvider          = "sqs"
 )
 
 type GroupConfig struct {
@@ -164,6 +565,7 @@ type StrategyConfiguration struct {
 	Type               StrategyProvider                        `json:"type" envconfig:"CONVOY_STRATEGY_TYPE"`
 	Default            DefaultStrategyConfiguration            `json:"default"`
 	ExponentialBackoff ExponentialBackoffStrategyConfiguration `json:"exponentialBackoff,omitempty"`
+	Linear             LinearStrategyConfiguration             `json:"linear,omitempty"`
 }
 
 type DefaultStrategyConfiguration struct {
@@ -175,6 +577,14 @@ type ExponentialBackoffStrategyConfiguration struct {
 	RetryLimit uint64 `json:"retryLimit" envconfig:"CONVOY_RETRY_LIMIT"`
 }
 
+// LinearStrategyConfiguration is the default linear retry strategy of new
+// groups, see datastore.LinearStrategyConfiguration.
+type LinearStrategyConfiguration struct {
+	InitialIntervalSeconds uint64 `json:"initialIntervalSeconds" envconfig:"CONVOY_LINEAR_INITIAL_INTERVAL_SECONDS"`
+	StepSeconds            uint64 `json:"stepSeconds" envconfig:"CONVOY_LINEAR_STEP_SECONDS"`
+	RetryLimit             uint64 `json:"retryLimit" envconfig:"CONVOY_RETRY_LIMIT"`
+}
+
 type SignatureConfiguration struct {
 	Header SignatureHeaderProvider `json:"header" envconfig:"CONVOY_SIGNATURE_HEADER"`
 	Hash   string                  `json:"hash" envconfig:"CONVOY_SIGNATURE_HASH"`
@@ -187,6 +597,8 @@ type SignatureHeaderProvider string
 type LoggerProvider string
 type TracerProvider string
 type CacheProvider string
+type ArchiveProvider string
+type SourceProvider string
 type LimiterProvider string
 type DatabaseProvider string
 
@@ -198,12 +610,29 @@ func (s SignatureHeaderProvider) String() string {
 // previously for this to work.
 // Use this when you need to get access to the config object at runtime
 func Get() (Configuration, error) {
-	c, ok := cfgSingleton.Load().(*Configuration)
+	v, ok := cfgSingleton.Load().(*versionedConfig)
 	if !ok {
 		return Configuration{}, errors.New("call Load before this function")
 	}
 
-	return *c, nil
+	return *v.cfg, nil
+}
+
+// Override makes c the config returned by Get. It is used for the loaded
+// config once the cli flags and defaults are applied to it.
+func Override(c *Configuration) {
+	store(c)
+}
+
+// Version returns the version of the config returned by Get, it goes up
+// each time the config is loaded or reloaded.
+func Version() uint64 {
+	v, ok := cfgSingleton.Load().(*versionedConfig)
+	if !ok {
+		return 0
+	}
+
+	return v.version
 }
 
 // IsStringEmpty checks if the given string s is empty or not
@@ -249,276 +678,317 @@ func OverrideConfigWithCliFlags(cmd *cobra.Command, cfg *Configuration) error {
 		}
 	}
 
-	cfgSingleton.Store(cfg)
-
 	return nil
 }
 
-func overrideConfigWithEnvVars(c *Configuration, override *Configuration) {
-	// CONVOY_ENV
-	if !IsStringEmpty(override.Environment) {
-		c.Environment = override.Environment
-	}
-
-	// CONVOY_BASE_URL
-	if !IsStringEmpty(override.BaseUrl) {
-		c.BaseUrl = override.BaseUrl
-	}
-
-	// CONVOY_DB_TYPE
-	if !IsStringEmpty(string(override.Database.Type)) {
-		c.Database.Type = override.Database.Type
-	}
-
-	// CONVOY_DB_DSN
-	if !IsStringEmpty(override.Database.Dsn) {
-		c.Database.Dsn = override.Database.Dsn
-	}
-
-	// CONVOY_LIMITER_TYPE
-	if !IsStringEmpty(override.Sentry.Dsn) {
-		c.Sentry.Dsn = override.Sentry.Dsn
-	}
-
-	// CONVOY_LIMITER_TYPE
-	if !IsStringEmpty(string(override.Limiter.Type)) {
-		c.Limiter.Type = override.Limiter.Type
-	}
-
-	// CONVOY_REDIS_DSN
-	if !IsStringEmpty(override.Limiter.Redis.Dsn) {
-		c.Limiter.Redis.Dsn = override.Limiter.Redis.Dsn
-	}
-
-	// CONVOY_CACHE_PROVIDER
-	if !IsStringEmpty(string(override.Cache.Type)) {
-		c.Cache.Type = override.Cache.Type
-	}
-
-	// CONVOY_REDIS_DSN
-	if !IsStringEmpty(override.Cache.Redis.Dsn) {
-		c.Cache.Redis.Dsn = override.Cache.Redis.Dsn
-	}
-
-	// CONVOY_QUEUE_PROVIDER
-	if !IsStringEmpty(string(override.Queue.Type)) {
-		c.Queue.Type = override.Queue.Type
-	}
-
-	// CONVOY_REDIS_DSN
-	if !IsStringEmpty(override.Queue.Redis.Dsn) {
-		c.Queue.Redis.Dsn = override.Queue.Redis.Dsn
-	}
-
-	// CONVOY_REDIS_DSN
-	if !IsStringEmpty(override.Queue.Redis.Dsn) {
-		c.Queue.Redis.Dsn = override.Queue.Redis.Dsn
-	}
-
-	// CONVOY_LOGGER_PROVIDER
-	if !IsStringEmpty(string(override.Logger.Type)) {
-		c.Logger.Type = override.Logger.Type
-	}
-
-	// CONVOY_LOGGER_LEVEL
-	if !IsStringEmpty(override.Logger.ServerLog.Level) {
-		c.Logger.ServerLog.Level = override.Logger.ServerLog.Level
-	}
-
-	// PORT
-	if override.Server.HTTP.Port != 0 {
-		c.Server.HTTP.Port = override.Server.HTTP.Port
-	}
-
-	// WORKER_PORT
-	if override.Server.HTTP.WorkerPort != 0 {
-		c.Server.HTTP.WorkerPort = override.Server.HTTP.WorkerPort
-	}
-
-	// CONVOY_SSL_CERT_FILE
-	if !IsStringEmpty(override.Server.HTTP.SSLCertFile) {
-		c.Server.HTTP.SSLCertFile = override.Server.HTTP.SSLCertFile
-	}
-
-	// CONVOY_SSL_KEY_FILE
-	if !IsStringEmpty(override.Server.HTTP.SSLKeyFile) {
-		c.Server.HTTP.SSLKeyFile = override.Server.HTTP.SSLKeyFile
-	}
-
-	// CONVOY_STRATEGY_TYPE
-	if !IsStringEmpty(string(override.GroupConfig.Strategy.Type)) {
-		c.GroupConfig.Strategy.Type = override.GroupConfig.Strategy.Type
-	}
-
-	// CONVOY_SIGNATURE_HASH
-	if !IsStringEmpty(override.GroupConfig.Signature.Hash) {
-		c.GroupConfig.Signature.Hash = override.GroupConfig.Signature.Hash
-	}
-
-	// CONVOY_SIGNATURE_HEADER
-	if !IsStringEmpty(string(override.GroupConfig.Signature.Header)) {
-		c.GroupConfig.Signature.Header = override.GroupConfig.Signature.Header
-	}
-
-	// CONVOY_INTERVAL_SECONDS
-	if override.GroupConfig.Strategy.Default.IntervalSeconds != 0 {
-		c.GroupConfig.Strategy.Default.IntervalSeconds = override.GroupConfig.Strategy.Default.IntervalSeconds
-	}
-
-	// CONVOY_RETRY_LIMIT
-	if override.GroupConfig.Strategy.Default.RetryLimit != 0 {
-		c.GroupConfig.Strategy.Default.RetryLimit = override.GroupConfig.Strategy.Default.RetryLimit
-	}
-
-	// CONVOY_RETRY_LIMIT
-	if override.GroupConfig.Strategy.ExponentialBackoff.RetryLimit != 0 {
-		c.GroupConfig.Strategy.ExponentialBackoff.RetryLimit = override.GroupConfig.Strategy.ExponentialBackoff.RetryLimit
-	}
-
-	// CONVOY_SMTP_PROVIDER
-	if !IsStringEmpty(override.SMTP.Provider) {
-		c.SMTP.Provider = override.SMTP.Provider
-	}
+// envOverride maps an environment variable to the fields of the config it
+// overrides.
+type envOverride struct {
+	name string
 
-	// CONVOY_SMTP_FROM
-	if !IsStringEmpty(override.SMTP.From) {
-		c.SMTP.From = override.SMTP.From
-	}
+	// numeric overrides are ignored when they are zero, like when they
+	// are unset.
+	numeric bool
 
-	// CONVOY_SMTP_PASSWORD
-	if !IsStringEmpty(override.SMTP.Password) {
-		c.SMTP.Password = override.SMTP.Password
-	}
+	// apply copies the fields name was parsed into from env to c.
+	apply func(c, env *Configuration)
+}
 
-	// CONVOY_SMTP_REPLY_TO
-	if !IsStringEmpty(override.SMTP.ReplyTo) {
-		c.SMTP.ReplyTo = override.SMTP.ReplyTo
-	}
+// envOverrides are the environment variables that override the config
+// file. A config is built from, in increasing precedence, the defaults, the
+// config file, these variables and the cli flags, see LoadConfig and
+// OverrideConfigWithCliFlags.
+//
+// A variable that is unset or blank leaves the file's value alone. Some
+// variables, e.g. CONVOY_REDIS_DSN, override more than one field.
+var envOverrides = []envOverride{
+	{name: "CONVOY_ENV", apply: func(c, env *Configuration) { c.Environment = env.Environment }},
+	{name: "CONVOY_MULTIPLE_TENANTS", apply: func(c, env *Configuration) { c.MultipleTenants = env.MultipleTenants }},
+	{name: "CONVOY_BASE_URL", apply: func(c, env *Configuration) { c.BaseUrl = env.BaseUrl }},
+	{name: "CONVOY_ENCRYPTION_KEY", apply: func(c, env *Configuration) { c.EncryptionKey = env.EncryptionKey }},
+	{name: "CONVOY_MAX_RESPONSE_SIZE", numeric: true, apply: func(c, env *Configuration) { c.MaxResponseSize = env.MaxResponseSize }},
+	{name: "CONVOY_MAX_EVENT_PAYLOAD_SIZE", numeric: true, apply: func(c, env *Configuration) { c.MaxEventPayloadSize = env.MaxEventPayloadSize }},
+
+	// auth
+	{name: "CONVOY_REQUIRE_AUTH", apply: func(c, env *Configuration) { c.Auth.RequireAuth = env.Auth.RequireAuth }},
+	{name: "CONVOY_NATIVE_REALM_ENABLED", apply: func(c, env *Configuration) { c.Auth.Native.Enabled = env.Auth.Native.Enabled }},
+	{name: "CONVOY_BASIC_AUTH_CONFIG", apply: func(c, env *Configuration) { c.Auth.File.Basic = env.Auth.File.Basic }},
+	{name: "CONVOY_API_KEY_CONFIG", apply: func(c, env *Configuration) { c.Auth.File.APIKey = env.Auth.File.APIKey }},
+	{name: envBasicAuthUsername, apply: func(c, _ *Configuration) { firstBasicAuth(c).Username = os.Getenv(envBasicAuthUsername) }},
+	{name: envBasicAuthPassword, apply: func(c, _ *Configuration) { firstBasicAuth(c).Password = os.Getenv(envBasicAuthPassword) }},
+	{name: envBasicAuthPasswordFile, apply: func(c, _ *Configuration) { firstBasicAuth(c).PasswordFile = os.Getenv(envBasicAuthPasswordFile) }},
+
+	// database
+	{name: "CONVOY_DB_TYPE", apply: func(c, env *Configuration) { c.Database.Type = env.Database.Type }},
+	{name: "CONVOY_DB_DSN", apply: func(c, env *Configuration) { c.Database.Dsn = env.Database.Dsn }},
+	{name: "CONVOY_DB_DSN_FILE", apply: func(c, env *Configuration) { c.Database.DsnFile = env.Database.DsnFile }},
+	{name: "CONVOY_DB_SKIP_INDEX_CREATION", apply: func(c, env *Configuration) { c.Database.SkipIndexCreation = env.Database.SkipIndexCreation }},
+	{name: "CONVOY_DB_DELETED_DOCUMENT_TTL", apply: func(c, env *Configuration) { c.Database.DeletedDocumentTTL = env.Database.DeletedDocumentTTL }},
+	{name: "CONVOY_DB_QUERY_TIMEOUT", apply: func(c, env *Configuration) { c.Database.QueryTimeout = env.Database.QueryTimeout }},
+	{name: "CONVOY_DB_BULK_WRITE_TIMEOUT", apply: func(c, env *Configuration) { c.Database.BulkWriteTimeout = env.Database.BulkWriteTimeout }},
+	{name: "CONVOY_DB_READ_PREFERENCE", apply: func(c, env *Configuration) { c.Database.ReadPreference = env.Database.ReadPreference }},
+	{name: "CONVOY_DB_MAX_POOL_SIZE", numeric: true, apply: func(c, env *Configuration) { c.Database.MaxPoolSize = env.Database.MaxPoolSize }},
+	{name: "CONVOY_DB_MIN_POOL_SIZE", numeric: true, apply: func(c, env *Configuration) { c.Database.MinPoolSize = env.Database.MinPoolSize }},
+	{name: "CONVOY_DB_CONNECT_TIMEOUT", apply: func(c, env *Configuration) { c.Database.ConnectTimeout = env.Database.ConnectTimeout }},
+
+	{name: "CONVOY_SENTRY_DSN", apply: func(c, env *Configuration) { c.Sentry.Dsn = env.Sentry.Dsn }},
+
+	// redis backed components
+	{name: "CONVOY_QUEUE_PROVIDER", apply: func(c, env *Configuration) { c.Queue.Type = env.Queue.Type }},
+	{name: "CONVOY_REDIS_DSN", apply: func(c, env *Configuration) { c.Queue.Redis.Dsn = env.Queue.Redis.Dsn }},
+	{name: "CONVOY_REDIS_DSN_FILE", apply: func(c, env *Configuration) { c.Queue.Redis.DsnFile = env.Queue.Redis.DsnFile }},
+	{name: "CONVOY_LIMITER_TYPE", apply: func(c, env *Configuration) { c.Limiter.Type = env.Limiter.Type }},
+	{name: "CONVOY_REDIS_DSN", apply: func(c, env *Configuration) { c.Limiter.Redis.Dsn = env.Limiter.Redis.Dsn }},
+	{name: "CONVOY_REDIS_DSN_FILE", apply: func(c, env *Configuration) { c.Limiter.Redis.DsnFile = env.Limiter.Redis.DsnFile }},
+	{name: "CONVOY_CACHE_PROVIDER", apply: func(c, env *Configuration) { c.Cache.Type = env.Cache.Type }},
+	{name: "CONVOY_REDIS_DSN", apply: func(c, env *Configuration) { c.Cache.Redis.Dsn = env.Cache.Redis.Dsn }},
+	{name: "CONVOY_REDIS_DSN_FILE", apply: func(c, env *Configuration) { c.Cache.Redis.DsnFile = env.Cache.Redis.DsnFile }},
+	{name: "CONVOY_CACHE_REDIS_ADDRESS", apply: func(c, env *Configuration) { c.Cache.Redis.Address = env.Cache.Redis.Address }},
+	{name: "CONVOY_CACHE_REDIS_USERNAME", apply: func(c, env *Configuration) { c.Cache.Redis.Username = env.Cache.Redis.Username }},
+	{name: "CONVOY_CACHE_REDIS_PASSWORD", apply: func(c, env *Configuration) { c.Cache.Redis.Password = env.Cache.Redis.Password }},
+	{name: "CONVOY_CACHE_REDIS_PASSWORD_FILE", apply: func(c, env *Configuration) { c.Cache.Redis.PasswordFile = env.Cache.Redis.PasswordFile }},
+	{name: "CONVOY_CACHE_REDIS_DATABASE", numeric: true, apply: func(c, env *Configuration) { c.Cache.Redis.Database = env.Cache.Redis.Database }},
+	{name: "CONVOY_CACHE_REDIS_TLS", apply: func(c, env *Configuration) { c.Cache.Redis.TLS = env.Cache.Redis.TLS }},
+	{name: "CONVOY_CACHE_REDIS_KEY_PREFIX", apply: func(c, env *Configuration) { c.Cache.Redis.KeyPrefix = env.Cache.Redis.KeyPrefix }},
+	{name: "CONVOY_CACHE_MEMORY_MAX_ENTRIES", numeric: true, apply: func(c, env *Configuration) { c.Cache.Memory.MaxEntries = env.Cache.Memory.MaxEntries }},
+	{name: "CONVOY_CACHE_NOT_FOUND_TTL", numeric: true, apply: func(c, env *Configuration) { c.Cache.NotFoundTTL = env.Cache.NotFoundTTL }},
+
+	// server
+	{name: "SSL", apply: func(c, env *Configuration) { c.Server.HTTP.SSL = env.Server.HTTP.SSL }},
+	{name: "CONVOY_SSL_CERT_FILE", apply: func(c, env *Configuration) { c.Server.HTTP.SSLCertFile = env.Server.HTTP.SSLCertFile }},
+	{name: "CONVOY_SSL_KEY_FILE", apply: func(c, env *Configuration) { c.Server.HTTP.SSLKeyFile = env.Server.HTTP.SSLKeyFile }},
+	{name: "PORT", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.Port = env.Server.HTTP.Port }},
+	{name: "WORKER_PORT", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.WorkerPort = env.Server.HTTP.WorkerPort }},
+	{name: "CONVOY_HOST", apply: func(c, env *Configuration) { c.Server.HTTP.Host = env.Server.HTTP.Host }},
+	{name: "CONVOY_HTTP_READ_TIMEOUT", apply: func(c, env *Configuration) { c.Server.HTTP.ReadTimeout = env.Server.HTTP.ReadTimeout }},
+	{name: "CONVOY_HTTP_WRITE_TIMEOUT", apply: func(c, env *Configuration) { c.Server.HTTP.WriteTimeout = env.Server.HTTP.WriteTimeout }},
+	{name: "CONVOY_HTTP_IDLE_TIMEOUT", apply: func(c, env *Configuration) { c.Server.HTTP.IdleTimeout = env.Server.HTTP.IdleTimeout }},
+	{name: "CONVOY_HTTP_DRAIN_PERIOD", apply: func(c, env *Configuration) { c.Server.HTTP.DrainPeriod = env.Server.HTTP.DrainPeriod }},
+	{name: "CONVOY_HTTP_PRE_STOP_DELAY", apply: func(c, env *Configuration) { c.Server.HTTP.PreStopDelay = env.Server.HTTP.PreStopDelay }},
+	{name: "CONVOY_STREAM_HEARTBEAT_INTERVAL", numeric: true, apply: func(c, env *Configuration) {
+		c.Server.HTTP.StreamHeartbeatInterval = env.Server.HTTP.StreamHeartbeatInterval
+	}},
+	{name: "CONVOY_STREAM_MAX_DURATION", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.StreamMaxDuration = env.Server.HTTP.StreamMaxDuration }},
+	{name: "CONVOY_MAX_COUNT_DATE_RANGE", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.MaxCountDateRange = env.Server.HTTP.MaxCountDateRange }},
+	{name: "CONVOY_MAX_REQUEST_BODY_SIZE", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.MaxRequestBodySize = env.Server.HTTP.MaxRequestBodySize }},
+	{name: "CONVOY_API_RATE_LIMIT", numeric: true, apply: func(c, env *Configuration) { c.Server.HTTP.APIRateLimit = env.Server.HTTP.APIRateLimit }},
+	{name: "CONVOY_API_RATE_LIMIT_DURATION", numeric: true, apply: func(c, env *Configuration) {
+		c.Server.HTTP.APIRateLimitDuration = env.Server.HTTP.APIRateLimitDuration
+	}},
+	{name: "CONVOY_RESERVED_GROUP_NAMES", apply: func(c, env *Configuration) { c.Server.ReservedGroupNames = env.Server.ReservedGroupNames }},
+	{name: "CONVOY_FEATURE_FLAGS", apply: func(c, env *Configuration) { c.Server.FeatureFlags = env.Server.FeatureFlags }},
+
+	// group defaults
+	{name: "CONVOY_STRATEGY_TYPE", apply: func(c, env *Configuration) { c.GroupConfig.Strategy.Type = env.GroupConfig.Strategy.Type }},
+	{name: "CONVOY_INTERVAL_SECONDS", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.Default.IntervalSeconds = env.GroupConfig.Strategy.Default.IntervalSeconds
+	}},
+	{name: "CONVOY_RETRY_LIMIT", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.Default.RetryLimit = env.GroupConfig.Strategy.Default.RetryLimit
+	}},
+	{name: "CONVOY_RETRY_LIMIT", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.ExponentialBackoff.RetryLimit = env.GroupConfig.Strategy.ExponentialBackoff.RetryLimit
+	}},
+	{name: "CONVOY_RETRY_LIMIT", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.Linear.RetryLimit = env.GroupConfig.Strategy.Linear.RetryLimit
+	}},
+	{name: "CONVOY_LINEAR_INITIAL_INTERVAL_SECONDS", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.Linear.InitialIntervalSeconds = env.GroupConfig.Strategy.Linear.InitialIntervalSeconds
+	}},
+	{name: "CONVOY_LINEAR_STEP_SECONDS", numeric: true, apply: func(c, env *Configuration) {
+		c.GroupConfig.Strategy.Linear.StepSeconds = env.GroupConfig.Strategy.Linear.StepSeconds
+	}},
+	{name: "CONVOY_SIGNATURE_HEADER", apply: func(c, env *Configuration) { c.GroupConfig.Signature.Header = env.GroupConfig.Signature.Header }},
+	{name: "CONVOY_SIGNATURE_HASH", apply: func(c, env *Configuration) { c.GroupConfig.Signature.Hash = env.GroupConfig.Signature.Hash }},
+	{name: "CONVOY_DISABLE_ENDPOINT", apply: func(c, env *Configuration) { c.GroupConfig.DisableEndpoint = env.GroupConfig.DisableEndpoint }},
+	{name: "CONVOY_REPLAY_ATTACKS", apply: func(c, env *Configuration) { c.GroupConfig.ReplayAttacks = env.GroupConfig.ReplayAttacks }},
+
+	// smtp
+	{name: "CONVOY_SMTP_PROVIDER", apply: func(c, env *Configuration) { c.SMTP.Provider = env.SMTP.Provider }},
+	{name: "CONVOY_SMTP_URL", apply: func(c, env *Configuration) { c.SMTP.URL = env.SMTP.URL }},
+	{name: "CONVOY_SMTP_PORT", numeric: true, apply: func(c, env *Configuration) { c.SMTP.Port = env.SMTP.Port }},
+	{name: "CONVOY_SMTP_USERNAME", apply: func(c, env *Configuration) { c.SMTP.Username = env.SMTP.Username }},
+	{name: "CONVOY_SMTP_PASSWORD", apply: func(c, env *Configuration) { c.SMTP.Password = env.SMTP.Password }},
+	{name: "CONVOY_SMTP_PASSWORD_FILE", apply: func(c, env *Configuration) { c.SMTP.PasswordFile = env.SMTP.PasswordFile }},
+	{name: "CONVOY_SMTP_FROM", apply: func(c, env *Configuration) { c.SMTP.From = env.SMTP.From }},
+	{name: "CONVOY_SMTP_REPLY_TO", apply: func(c, env *Configuration) { c.SMTP.ReplyTo = env.SMTP.ReplyTo }},
+
+	// observability
+	{name: "CONVOY_LOGGER_PROVIDER", apply: func(c, env *Configuration) { c.Logger.Type = env.Logger.Type }},
+	{name: "CONVOY_LOGGER_LEVEL", apply: func(c, env *Configuration) { c.Logger.ServerLog.Level = env.Logger.ServerLog.Level }},
+	{name: "CONVOY_TRACER_PROVIDER", apply: func(c, env *Configuration) { c.Tracer.Type = env.Tracer.Type }},
+	{name: "CONVOY_NEWRELIC_APP_NAME", apply: func(c, env *Configuration) { c.NewRelic.AppName = env.NewRelic.AppName }},
+	{name: "CONVOY_NEWRELIC_LICENSE_KEY", apply: func(c, env *Configuration) { c.NewRelic.LicenseKey = env.NewRelic.LicenseKey }},
+	{name: "CONVOY_NEWRELIC_CONFIG_ENABLED", apply: func(c, env *Configuration) { c.NewRelic.ConfigEnabled = env.NewRelic.ConfigEnabled }},
+	{name: "CONVOY_NEWRELIC_DISTRIBUTED_TRACER_ENABLED", apply: func(c, env *Configuration) {
+		c.NewRelic.DistributedTracerEnabled = env.NewRelic.DistributedTracerEnabled
+	}},
+
+	{name: "CONVOY_STATISTICS_REFRESH_INTERVAL", numeric: true, apply: func(c, env *Configuration) { c.Statistics.RefreshInterval = env.Statistics.RefreshInterval }},
+	{name: "CONVOY_STATISTICS_WORKERS", numeric: true, apply: func(c, env *Configuration) { c.Statistics.Workers = env.Statistics.Workers }},
+
+	// archive
+	{name: "CONVOY_ARCHIVE_PROVIDER", apply: func(c, env *Configuration) { c.Archive.Type = env.Archive.Type }},
+	{name: "CONVOY_ARCHIVE_S3_ENDPOINT", apply: func(c, env *Configuration) { c.Archive.S3.Endpoint = env.Archive.S3.Endpoint }},
+	{name: "CONVOY_ARCHIVE_S3_REGION", apply: func(c, env *Configuration) { c.Archive.S3.Region = env.Archive.S3.Region }},
+	{name: "CONVOY_ARCHIVE_S3_BUCKET", apply: func(c, env *Configuration) { c.Archive.S3.Bucket = env.Archive.S3.Bucket }},
+	{name: "CONVOY_ARCHIVE_S3_ACCESS_KEY", apply: func(c, env *Configuration) { c.Archive.S3.AccessKey = env.Archive.S3.AccessKey }},
+	{name: "CONVOY_ARCHIVE_S3_SECRET_KEY", apply: func(c, env *Configuration) { c.Archive.S3.SecretKey = env.Archive.S3.SecretKey }},
+	{name: "CONVOY_ARCHIVE_S3_PREFIX", apply: func(c, env *Configuration) { c.Archive.S3.Prefix = env.Archive.S3.Prefix }},
+
+	// source
+	{name: "CONVOY_SOURCE_PROVIDER", apply: func(c, env *Configuration) { c.Source.Type = env.Source.Type }},
+	{name: "CONVOY_SOURCE_SQS_QUEUE_URL", apply: func(c, env *Configuration) { c.Source.SQS.QueueURL = env.Source.SQS.QueueURL }},
+	{name: "CONVOY_SOURCE_SQS_REGION", apply: func(c, env *Configuration) { c.Source.SQS.Region = env.Source.SQS.Region }},
+	{name: "CONVOY_SOURCE_SQS_ACCESS_KEY", apply: func(c, env *Configuration) { c.Source.SQS.AccessKey = env.Source.SQS.AccessKey }},
+	{name: "CONVOY_SOURCE_SQS_SECRET_KEY", apply: func(c, env *Configuration) { c.Source.SQS.SecretKey = env.Source.SQS.SecretKey }},
+	{name: "CONVOY_SOURCE_SQS_SESSION_TOKEN", apply: func(c, env *Configuration) { c.Source.SQS.SessionToken = env.Source.SQS.SessionToken }},
+	{name: "CONVOY_SOURCE_SQS_MAX_MESSAGES", numeric: true, apply: func(c, env *Configuration) { c.Source.SQS.MaxMessages = env.Source.SQS.MaxMessages }},
+	{name: "CONVOY_SOURCE_SQS_POLL_INTERVAL_SECONDS", numeric: true, apply: func(c, env *Configuration) {
+		c.Source.SQS.PollIntervalSeconds = env.Source.SQS.PollIntervalSeconds
+	}},
+
+	{name: "CONVOY_WORKER_GRACEFUL_SHUTDOWN_TIMEOUT", apply: func(c, env *Configuration) {
+		c.Worker.GracefulShutdownTimeout = env.Worker.GracefulShutdownTimeout
+	}},
+
+	// region
+	{name: "CONVOY_REGION", apply: func(c, env *Configuration) { c.Region.Name = env.Region.Name }},
+	{name: "CONVOY_REGION_ENDPOINTS", apply: func(c, env *Configuration) { c.Region.Endpoints = env.Region.Endpoints }},
+}
 
-	// CONVOY_SMTP_USERNAME
-	if !IsStringEmpty(override.SMTP.URL) {
-		c.SMTP.URL = override.SMTP.URL
-	}
+const (
+	// envBasicAuthUsername and envBasicAuthPassword set the credentials
+	// of the first basic auth user, so a single user can be configured
+	// without CONVOY_BASIC_AUTH_CONFIG.
+	envBasicAuthUsername     = "CONVOY_AUTH_BASIC_USERNAME"
+	envBasicAuthPassword     = "CONVOY_AUTH_BASIC_PASSWORD"
+	envBasicAuthPasswordFile = "CONVOY_AUTH_BASIC_PASSWORD_FILE"
+)
 
-	// CONVOY_SMTP_USERNAME
-	if !IsStringEmpty(override.SMTP.Username) {
-		c.SMTP.Username = override.SMTP.Username
+// firstBasicAuth returns the first basic auth user of c, adding a super
+// user when there is none.
+func firstBasicAuth(c *Configuration) *BasicAuth {
+	if len(c.Auth.File.Basic) == 0 {
+		c.Auth.File.Basic = BasicAuthConfig{{Role: auth.Role{Type: auth.RoleSuperUser}}}
 	}
 
-	// CONVOY_SMTP_PORT
-	if override.SMTP.Port != 0 {
-		c.SMTP.Port = override.SMTP.Port
-	}
+	return &c.Auth.File.Basic[0]
+}
 
-	// CONVOY_MAX_RESPONSE_SIZE
-	if override.MaxResponseSize != 0 {
-		c.MaxResponseSize = override.MaxResponseSize
-	}
+// overrideConfigWithEnvVars applies envOverrides to c, env is the config
+// the environment was parsed into.
+func overrideConfigWithEnvVars(c *Configuration, env *Configuration) {
+	for _, o := range envOverrides {
+		value, ok := os.LookupEnv(o.name)
+		if !ok || IsStringEmpty(value) {
+			continue
+		}
 
-	// CONVOY_NEWRELIC_APP_NAME
-	if !IsStringEmpty(override.NewRelic.AppName) {
-		c.NewRelic.AppName = override.NewRelic.AppName
-	}
+		if o.numeric {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && n == 0 {
+				continue
+			}
+		}
 
-	// CONVOY_NEWRELIC_LICENSE_KEY
-	if !IsStringEmpty(override.NewRelic.LicenseKey) {
-		c.NewRelic.LicenseKey = override.NewRelic.LicenseKey
+		o.apply(c, env)
 	}
+}
 
-	// CONVOY_API_KEY_CONFIG
-	if override.Auth.File.APIKey != nil {
-		c.Auth.File.APIKey = override.Auth.File.APIKey
+// LoadConfig is used to load the configuration from either the json config file
+// or the environment variables.
+func LoadConfig(p string) error {
+	c, err := loadConfig(p)
+	if err != nil {
+		return err
 	}
 
-	// CONVOY_BASIC_AUTH_CONFIG
-	if override.Auth.File.Basic != nil {
-		c.Auth.File.Basic = override.Auth.File.Basic
-	}
+	store(c)
+	return nil
+}
 
-	// boolean values are weird; we have to check if they are actually set
+// loadConfig reads and validates the config LoadConfig loads, without
+// making it the current config.
+func loadConfig(p string) (*Configuration, error) {
+	c := &Configuration{}
 
-	if _, ok := os.LookupEnv("CONVOY_MULTIPLE_TENANTS"); ok {
-		c.MultipleTenants = override.MultipleTenants
+	if _, err := os.Stat(p); err == nil {
+		err = readConfigFile(p, c)
+		if err != nil {
+			return nil, err
+		}
+	} else if errors.Is(err, os.ErrNotExist) {
+		log.Info("convoy config file not detected, will look for env vars or cli args")
 	}
 
-	if _, ok := os.LookupEnv("SSL"); ok {
-		c.Server.HTTP.SSL = override.Server.HTTP.SSL
-	}
+	ec := &Configuration{}
 
-	if _, ok := os.LookupEnv("CONVOY_DISABLE_ENDPOINT"); ok {
-		c.GroupConfig.DisableEndpoint = override.GroupConfig.DisableEndpoint
+	// load config from environment variables
+	err := envconfig.Process(envPrefix, ec)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, ok := os.LookupEnv("CONVOY_REPLAY_ATTACKS"); ok {
-		c.GroupConfig.ReplayAttacks = override.GroupConfig.ReplayAttacks
-	}
+	overrideConfigWithEnvVars(c, ec)
 
-	if _, ok := os.LookupEnv("CONVOY_NEWRELIC_CONFIG_ENABLED"); ok {
-		c.NewRelic.ConfigEnabled = override.NewRelic.ConfigEnabled
+	err = c.readSecretFiles()
+	if err != nil {
+		return nil, err
 	}
 
-	if _, ok := os.LookupEnv("CONVOY_REQUIRE_AUTH"); ok {
-		c.Auth.RequireAuth = override.Auth.RequireAuth
+	// mistakes are caught here rather than the first time the bad value
+	// is used
+	err = c.Validate()
+	if err != nil {
+		return nil, err
 	}
 
-	if _, ok := os.LookupEnv("CONVOY_NATIVE_REALM_ENABLED"); ok {
-		c.Auth.Native.Enabled = override.Auth.Native.Enabled
-	}
+	return c, nil
 }
 
-// LoadConfig is used to load the configuration from either the json config file
-// or the environment variables.
-func LoadConfig(p string) error {
-	c := &Configuration{}
+// readConfigFile decodes the config file at p into c. Files ending in
+// .yaml or .yml are read as YAML, files ending in .json as JSON, and any
+// other file as JSON only when it starts with an object.
+func readConfigFile(p string, c *Configuration) error {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
 
-	if _, err := os.Stat(p); err == nil {
-		f, err := os.Open(p)
+	if isYAML(p, data) {
+		// the yaml is converted to json so both formats share the json tags
+		data, err = yaml.YAMLToJSON(data)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to parse yaml config: %v", err)
 		}
-
-		defer f.Close()
-
-		// load config from config.json
-		if err := json.NewDecoder(f).Decode(&c); err != nil {
-			return err
-		}
-	} else if errors.Is(err, os.ErrNotExist) {
-		log.Info("convoy config.json not detected, will look for env vars or cli args")
 	}
 
-	ec := &Configuration{}
+	return json.NewDecoder(bytes.NewReader(data)).Decode(c)
+}
 
-	// load config from environment variables
-	err := envconfig.Process(envPrefix, ec)
-	if err != nil {
-		return err
+func isYAML(p string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
 	}
 
-	overrideConfigWithEnvVars(c, ec)
-
-	cfgSingleton.Store(c)
-	return nil
+	return !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
 }
 
+// SetServerConfigDefaults fills in the defaults of the server's config and
+// checks it, the sections the server can't run without are required.
 func SetServerConfigDefaults(c *Configuration) error {
+	var errs ValidationError
+
 	// if it's still empty, set it to development
 	if c.Environment == "" {
 		c.Environment = DevelopmentEnvironment
 	}
 
 	if c.Server.HTTP.Port == 0 {
-		return errors.New("http port cannot be zero")
-	}
-
-	err := ensureSSL(c.Server)
-	if err != nil {
-		return err
-	}
-
-	err = ensureSignature(c.GroupConfig.Signature)
-	if err != nil {
-		return err
+		errs.add(errors.New("http port cannot be zero"))
 	}
 
 	if c.GroupConfig.Signature.Header == "" {
@@ -536,22 +1006,41 @@ func SetServerConfigDefaults(c *Configuration) error {
 		c.MaxResponseSize = kb
 	}
 
-	err = ensureStrategyConfig(c.GroupConfig.Strategy)
-	if err != nil {
-		return err
+	if c.MaxEventPayloadSize == 0 {
+		c.MaxEventPayloadSize = DefaultMaxEventPayloadSize
+	} else {
+		c.MaxEventPayloadSize = c.MaxEventPayloadSize * 1024 // to bytes
 	}
 
-	err = ensureQueueConfig(c.Queue)
-	if err != nil {
-		return err
+	if c.Server.HTTP.MaxRequestBodySize == 0 {
+		c.Server.HTTP.MaxRequestBodySize = DefaultMaxRequestBodySize
+	} else {
+		c.Server.HTTP.MaxRequestBodySize = c.Server.HTTP.MaxRequestBodySize * 1024 // to bytes
 	}
 
-	err = ensureAuthConfig(c.Auth)
-	if err != nil {
-		return err
+	if c.Server.HTTP.MaxRequestBodySize < 2*c.MaxEventPayloadSize {
+		log.Warnf("maximum request body size of %dkb is too small for the maximum event payload size, using %dkb", c.Server.HTTP.MaxRequestBodySize/1024, 2*c.MaxEventPayloadSize/1024)
+		c.Server.HTTP.MaxRequestBodySize = 2 * c.MaxEventPayloadSize
+	}
+
+	if c.Server.HTTP.APIRateLimitDuration == 0 {
+		c.Server.HTTP.APIRateLimitDuration = DefaultAPIRateLimitDuration
 	}
 
-	cfgSingleton.Store(c)
+	if c.Statistics.RefreshInterval == 0 {
+		c.Statistics.RefreshInterval = DefaultStatisticsRefreshInterval
+	}
+
+	if c.Statistics.Workers <= 0 {
+		c.Statistics.Workers = DefaultStatisticsWorkers
+	}
+
+	errs = append(errs, c.validate(true)...)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	store(c)
 	return nil
 }
 
@@ -582,6 +1071,29 @@ func ensureAuthConfig(authCfg AuthConfiguration) error {
 	return nil
 }
 
+func ensureDatabaseConfig(dbCfg DatabaseConfiguration) error {
+	if !IsStringEmpty(string(dbCfg.ReadPreference)) {
+		valid := false
+		for _, rp := range ReadPreferences {
+			if dbCfg.ReadPreference == rp {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("invalid database read preference %q, must be one of %v", dbCfg.ReadPreference, ReadPreferences)
+		}
+	}
+
+	if dbCfg.MaxPoolSize != 0 && dbCfg.MinPoolSize > dbCfg.MaxPoolSize {
+		return fmt.Errorf("database min pool size %d cannot be larger than its max pool size %d", dbCfg.MinPoolSize, dbCfg.MaxPoolSize)
+	}
+
+	_, err := dbCfg.GetConnectTimeout()
+	return err
+}
+
 func ensureSignature(signature SignatureConfiguration) error {
 	_, ok := algo.M[signature.Hash]
 	if !ok {
@@ -615,6 +1127,60 @@ func ensureQueueConfig(queueCfg QueueConfiguration) error {
 	return nil
 }
 
+func ensureArchiveConfig(archiveCfg ArchiveConfiguration) error {
+	switch archiveCfg.Type {
+	case S3ArchiveProvider:
+		s3 := archiveCfg.S3
+		if s3.Endpoint == "" || s3.Bucket == "" || s3.AccessKey == "" || s3.SecretKey == "" {
+			return errors.New("endpoint, bucket, access key and secret key are required for s3 archive configuration")
+		}
+
+	case "":
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported archive type: %s", archiveCfg.Type)
+	}
+	return nil
+}
+
+func ensureSourceConfig(sourceCfg *SourceConfiguration) error {
+	switch sourceCfg.Type {
+	case SQSSourceProvider:
+		sqs := &sourceCfg.SQS
+		if sqs.QueueURL == "" {
+			return errors.New("queue url is required for sqs source configuration")
+		}
+
+		if (sqs.AccessKey == "") != (sqs.SecretKey == "") {
+			return errors.New("access key and secret key must be set together for sqs source configuration")
+		}
+
+		if sqs.MaxMessages == 0 {
+			sqs.MaxMessages = DefaultSQSMaxMessages
+		}
+
+		if sqs.MaxMessages < 1 || sqs.MaxMessages > 10 {
+			return errors.New("sqs max messages must be between 1 and 10")
+		}
+
+		if sqs.PollIntervalSeconds == 0 {
+			sqs.PollIntervalSeconds = DefaultSQSPollIntervalSeconds
+		}
+
+		if sqs.PollIntervalSeconds < 0 {
+			return errors.New("sqs poll interval seconds cannot be negative")
+		}
+
+	case "":
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported source type: %s", sourceCfg.Type)
+	}
+	return nil
+}
+
 func ensureStrategyConfig(strategyCfg StrategyConfiguration) error {
 	switch strategyCfg.Type {
 	case DefaultStrategyProvider:
@@ -625,6 +1191,11 @@ func ensureStrategyConfig(strategyCfg StrategyConfiguration) error {
 		if strategyCfg.ExponentialBackoff.RetryLimit == 0 {
 			return errors.New("retry limit is required for exponential backoff retry strategy configuration")
 		}
+	case LinearStrategyProvider:
+		linear := strategyCfg.Linear
+		if linear.InitialIntervalSeconds == 0 || linear.StepSeconds == 0 || linear.RetryLimit == 0 {
+			return errors.New("initial interval seconds, step seconds and retry limit are required for linear strategy configuration")
+		}
 	default:
 		return fmt.Errorf("unsupported strategy type: %s", strategyCfg.Type)
 	}
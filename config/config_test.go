@@ -1,9 +1,14 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/auth"
 
@@ -201,10 +206,65 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
-						Port: 80,
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
 					},
 				},
-				MaxResponseSize: 40 * 1024,
+				MaxResponseSize:     40 * 1024,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
+				GroupConfig: GroupConfig{
+					Strategy: StrategyConfiguration{
+						Type: "default",
+						Default: DefaultStrategyConfiguration{
+							IntervalSeconds: 125,
+							RetryLimit:      15,
+						},
+					},
+					Signature: SignatureConfiguration{
+						Header: DefaultSignatureHeader,
+						Hash:   "SHA256",
+					},
+					DisableEndpoint: false,
+				},
+				Environment:     DevelopmentEnvironment,
+				MultipleTenants: false,
+			},
+			wantErr:    false,
+			wantErrMsg: "",
+		},
+		{
+			name: "should_raise_MaxRequestBodySize_below_MaxEventPayloadSize",
+			args: args{
+				path: "./testdata/Config/small-max-request-body-size-convoy.json",
+			},
+			wantCfg: Configuration{
+				Database: DatabaseConfiguration{
+					Dsn: "mongodb://inside-config-file",
+				},
+				Queue: QueueConfiguration{
+					Type: RedisQueueProvider,
+					Redis: RedisQueueConfiguration{
+						Dsn: "redis://localhost:8379",
+					},
+				},
+				Server: ServerConfiguration{
+					HTTP: HTTPServerConfiguration{
+						Port:                 80,
+						MaxRequestBodySize:   1024 * 1024,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
+					},
+				},
+				MaxResponseSize:     40 * 1024,
+				MaxEventPayloadSize: 512 * 1024,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
 				GroupConfig: GroupConfig{
 					Strategy: StrategyConfiguration{
 						Type: "default",
@@ -242,10 +302,17 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
-						Port: 80,
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
 					},
 				},
-				MaxResponseSize: MaxResponseSize,
+				MaxResponseSize:     MaxResponseSize,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
 				GroupConfig: GroupConfig{
 					Strategy: StrategyConfiguration{
 						Type: "default",
@@ -283,10 +350,17 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
-						Port: 80,
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
 					},
 				},
-				MaxResponseSize: MaxResponseSize,
+				MaxResponseSize:     MaxResponseSize,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
 				GroupConfig: GroupConfig{
 					Strategy: StrategyConfiguration{
 						Type: "default",
@@ -324,10 +398,17 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
-						Port: 80,
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
 					},
 				},
-				MaxResponseSize: MaxResponseSize,
+				MaxResponseSize:     MaxResponseSize,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
 				Auth: AuthConfiguration{
 					RequireAuth: true,
 					File: FileRealmOption{
@@ -378,10 +459,17 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
-						Port: 80,
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
 					},
 				},
-				MaxResponseSize: MaxResponseSize,
+				MaxResponseSize:     MaxResponseSize,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
 				Auth: AuthConfiguration{
 					RequireAuth: true,
 					File: FileRealmOption{
@@ -414,6 +502,68 @@ func TestLoadConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_support_linear",
+			args: args{
+				path: "./testdata/Config/linear-config.json",
+			},
+			wantCfg: Configuration{
+				Database: DatabaseConfiguration{
+					Dsn: "mongodb://inside-config-file",
+				},
+				Queue: QueueConfiguration{
+					Type: RedisQueueProvider,
+					Redis: RedisQueueConfiguration{
+						Dsn: "redis://localhost:8379",
+					},
+				},
+				Server: ServerConfiguration{
+					HTTP: HTTPServerConfiguration{
+						Port:                 80,
+						MaxRequestBodySize:   DefaultMaxRequestBodySize,
+						APIRateLimitDuration: DefaultAPIRateLimitDuration,
+					},
+				},
+				MaxResponseSize:     MaxResponseSize,
+				MaxEventPayloadSize: DefaultMaxEventPayloadSize,
+				Statistics: StatisticsConfiguration{
+					RefreshInterval: DefaultStatisticsRefreshInterval,
+					Workers:         DefaultStatisticsWorkers,
+				},
+				Auth: AuthConfiguration{
+					RequireAuth: true,
+					File: FileRealmOption{
+						Basic: []BasicAuth{
+							{
+								Username: "123",
+								Password: "abc",
+								Role: auth.Role{
+									Type: "super_user",
+								},
+							},
+						},
+					},
+				},
+				GroupConfig: GroupConfig{
+					Strategy: StrategyConfiguration{
+						Type: LinearStrategyProvider,
+						Linear: LinearStrategyConfiguration{
+							InitialIntervalSeconds: 10,
+							StepSeconds:            30,
+							RetryLimit:             5,
+						},
+					},
+					Signature: SignatureConfiguration{
+						Header: DefaultSignatureHeader,
+						Hash:   "SHA256",
+					},
+					DisableEndpoint: false,
+				},
+				Environment:     DevelopmentEnvironment,
+				MultipleTenants: false,
+			},
+			wantErr: false,
+		},
 		{
 			name: "should_error_for_zero_port",
 			args: args{
@@ -477,6 +627,15 @@ func TestLoadConfig(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "retry limit is required for exponential backoff retry strategy configuration",
 		},
+		{
+			name: "should_error_for_zero_step_seconds_linear",
+			args: args{
+				path: "./testdata/Config/zero-step-seconds-linear.json",
+			},
+			wantCfg:    Configuration{},
+			wantErr:    true,
+			wantErrMsg: "initial interval seconds, step seconds and retry limit are required for linear strategy configuration",
+		},
 		{
 			name: "should_error_for_unsupported_strategy_type",
 			args: args{
@@ -553,13 +712,17 @@ func TestLoadConfig(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// values that are invalid on their own are caught by
+			// LoadConfig, missing sections by SetServerConfigDefaults
+			var cfg Configuration
 			err := LoadConfig(tt.args.path)
-			require.NoError(t, err)
+			if err == nil {
+				cfg, err = Get()
+				require.NoError(t, err)
 
-			cfg, err := Get()
-			require.NoError(t, err)
+				err = SetServerConfigDefaults(&cfg)
+			}
 
-			err = SetServerConfigDefaults(&cfg)
 			if tt.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tt.wantErrMsg, err.Error())
@@ -573,3 +736,432 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseConfiguration_GetDeletedDocumentTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "should_default_to_30_days", ttl: "", want: DefaultDeletedDocumentTTL},
+		{name: "should_parse_ttl", ttl: "168h", want: 168 * time.Hour},
+		{name: "should_reject_invalid_ttl", ttl: "30 days", wantErr: true},
+		{name: "should_reject_negative_ttl", ttl: "-1h", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ttl, err := DatabaseConfiguration{DeletedDocumentTTL: tc.ttl}.GetDeletedDocumentTTL()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, ttl)
+		})
+	}
+}
+
+func TestDatabaseConfiguration_GetQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "should_default_to_10_seconds", timeout: "", want: DefaultQueryTimeout},
+		{name: "should_parse_timeout", timeout: "2s", want: 2 * time.Second},
+		{name: "should_reject_invalid_timeout", timeout: "ten", wantErr: true},
+		{name: "should_reject_zero_timeout", timeout: "0s", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout, err := DatabaseConfiguration{QueryTimeout: tc.timeout}.GetQueryTimeout()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, timeout)
+		})
+	}
+}
+
+func TestHTTPServerConfiguration_Timeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     HTTPServerConfiguration
+		want    [4]time.Duration
+		wantErr bool
+	}{
+		{
+			name: "should_default_timeouts",
+			want: [4]time.Duration{DefaultHTTPReadTimeout, DefaultHTTPWriteTimeout, DefaultHTTPIdleTimeout, DefaultHTTPDrainPeriod},
+		},
+		{
+			name: "should_parse_timeouts",
+			cfg:  HTTPServerConfiguration{ReadTimeout: "5s", WriteTimeout: "10s", IdleTimeout: "1m", DrainPeriod: "45s"},
+			want: [4]time.Duration{5 * time.Second, 10 * time.Second, time.Minute, 45 * time.Second},
+		},
+		{
+			name:    "should_reject_invalid_drain_period",
+			cfg:     HTTPServerConfiguration{DrainPeriod: "forever"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got [4]time.Duration
+			var errs ValidationError
+			for i, get := range []func() (time.Duration, error){tc.cfg.GetReadTimeout, tc.cfg.GetWriteTimeout, tc.cfg.GetIdleTimeout, tc.cfg.GetDrainPeriod} {
+				timeout, err := get()
+				errs.add(err)
+				got[i] = timeout
+			}
+
+			if tc.wantErr {
+				require.Error(t, errs.err())
+				return
+			}
+
+			require.NoError(t, errs.err())
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHTTPServerConfiguration_GetPreStopDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "should_default_pre_stop_delay", want: DefaultHTTPPreStopDelay},
+		{name: "should_parse_pre_stop_delay", delay: "10s", want: 10 * time.Second},
+		{name: "should_allow_no_pre_stop_delay", delay: "0s", want: 0},
+		{name: "should_reject_negative_pre_stop_delay", delay: "-5s", wantErr: true},
+		{name: "should_reject_invalid_pre_stop_delay", delay: "soon", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, err := HTTPServerConfiguration{PreStopDelay: tc.delay}.GetPreStopDelay()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, delay)
+		})
+	}
+}
+
+func TestLoadConfig_ValidatesDatabaseConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantErrMsg string
+	}{
+		{
+			name: "should_accept_read_preference",
+			env:  map[string]string{"CONVOY_DB_READ_PREFERENCE": "secondaryPreferred"},
+		},
+		{
+			name:       "should_reject_misspelt_read_preference",
+			env:        map[string]string{"CONVOY_DB_READ_PREFERENCE": "secondary_preferred"},
+			wantErrMsg: `invalid database read preference "secondary_preferred", must be one of [primary primaryPreferred secondary secondaryPreferred nearest]`,
+		},
+		{
+			name:       "should_reject_min_pool_size_above_max",
+			env:        map[string]string{"CONVOY_DB_MIN_POOL_SIZE": "20", "CONVOY_DB_MAX_POOL_SIZE": "10"},
+			wantErrMsg: "database min pool size 20 cannot be larger than its max pool size 10",
+		},
+		{
+			name:       "should_reject_invalid_connect_timeout",
+			env:        map[string]string{"CONVOY_DB_CONNECT_TIMEOUT": "5"},
+			wantErrMsg: `invalid connect timeout "5", please provide a duration e.g 10s`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				setEnv(t, k, v)
+			}
+
+			err := LoadConfig("")
+			if tc.wantErrMsg != "" {
+				require.EqualError(t, err, tc.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadConfig_EnvOverlays(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		env     map[string]string
+		wantCfg func(c *Configuration)
+	}{
+		{
+			name: "should_override_database_and_queue",
+			path: "./testdata/Test_ConfigurationFromEnvironment/convoy.json",
+			env: map[string]string{
+				"CONVOY_DB_DSN":    "mongodb://from-env",
+				"CONVOY_REDIS_DSN": "redis://from-env:6379",
+			},
+			wantCfg: func(c *Configuration) {
+				c.Database.Dsn = "mongodb://from-env"
+				c.Queue.Redis.Dsn = "redis://from-env:6379"
+				c.Limiter.Redis.Dsn = "redis://from-env:6379"
+				c.Cache.Redis.Dsn = "redis://from-env:6379"
+			},
+		},
+		{
+			name: "should_override_nested_signature_and_strategy",
+			path: "./testdata/Test_ConfigurationFromEnvironment/convoy.json",
+			env: map[string]string{
+				"CONVOY_SIGNATURE_HASH":   "SHA512",
+				"CONVOY_STRATEGY_TYPE":    "exponential-backoff",
+				"CONVOY_INTERVAL_SECONDS": "30",
+				"CONVOY_RETRY_LIMIT":      "7",
+			},
+			wantCfg: func(c *Configuration) {
+				c.GroupConfig.Signature.Hash = "SHA512"
+				c.GroupConfig.Strategy.Type = ExponentialBackoffStrategyProvider
+				c.GroupConfig.Strategy.Default.IntervalSeconds = 30
+				c.GroupConfig.Strategy.Default.RetryLimit = 7
+				c.GroupConfig.Strategy.ExponentialBackoff.RetryLimit = 7
+				c.GroupConfig.Strategy.Linear.RetryLimit = 7
+			},
+		},
+		{
+			name: "should_override_basic_auth_credentials_and_keep_role",
+			path: "./testdata/Test_ConfigurationFromEnvironment/convoy.json",
+			env: map[string]string{
+				"CONVOY_AUTH_BASIC_USERNAME": "env-admin",
+				"CONVOY_AUTH_BASIC_PASSWORD": "env-password",
+			},
+			wantCfg: func(c *Configuration) {
+				c.Auth.File.Basic[0].Username = "env-admin"
+				c.Auth.File.Basic[0].Password = "env-password"
+			},
+		},
+		{
+			name: "should_add_super_user_when_file_has_no_basic_auth",
+			path: "./testdata/Config/no-port-convoy.json",
+			env: map[string]string{
+				"CONVOY_AUTH_BASIC_USERNAME": "env-admin",
+				"CONVOY_AUTH_BASIC_PASSWORD": "env-password",
+			},
+			wantCfg: func(c *Configuration) {
+				c.Auth.File.Basic = BasicAuthConfig{{
+					Username: "env-admin",
+					Password: "env-password",
+					Role:     auth.Role{Type: auth.RoleSuperUser},
+				}}
+			},
+		},
+		{
+			name: "should_override_fields_without_a_file_value",
+			path: "./testdata/Config/valid-convoy.json",
+			env: map[string]string{
+				"CONVOY_MULTIPLE_TENANTS":                    "true",
+				"CONVOY_TRACER_PROVIDER":                     "new_relic",
+				"CONVOY_NEWRELIC_DISTRIBUTED_TRACER_ENABLED": "true",
+				"CONVOY_REGION_ENDPOINTS":                    `{"eu": "https://eu.convoy.io", "us": "https://us.convoy.io"}`,
+			},
+			wantCfg: func(c *Configuration) {
+				c.MultipleTenants = true
+				c.Tracer.Type = NewRelicTracerProvider
+				c.NewRelic.DistributedTracerEnabled = true
+				c.Region.Endpoints = RegionEndpoints{"eu": "https://eu.convoy.io", "us": "https://us.convoy.io"}
+			},
+		},
+		{
+			name: "should_ignore_blank_and_zero_values",
+			path: "./testdata/Test_ConfigurationFromEnvironment/convoy.json",
+			env: map[string]string{
+				"CONVOY_DB_DSN":      " ",
+				"CONVOY_ENV":         "",
+				"PORT":               "0",
+				"CONVOY_RETRY_LIMIT": "0",
+			},
+			wantCfg: func(c *Configuration) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := LoadConfig(tc.path)
+			require.NoError(t, err)
+
+			want, err := Get()
+			require.NoError(t, err)
+			want.Auth.File.Basic = append(BasicAuthConfig(nil), want.Auth.File.Basic...)
+			tc.wantCfg(&want)
+
+			for k, v := range tc.env {
+				setEnv(t, k, v)
+			}
+
+			err = LoadConfig(tc.path)
+			require.NoError(t, err)
+
+			cfg, err := Get()
+			require.NoError(t, err)
+			require.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestEnvOverrides_CoverEveryVariable(t *testing.T) {
+	names := map[string]bool{}
+	for _, o := range envOverrides {
+		names[o.name] = true
+	}
+
+	var walk func(typ reflect.Type, path string)
+	walk = func(typ reflect.Type, path string) {
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.Type.Kind() == reflect.Struct {
+				walk(f.Type, path+f.Name+".")
+				continue
+			}
+
+			name := f.Tag.Get("envconfig")
+			require.NotEmpty(t, name, "%s%s has no environment variable", path, f.Name)
+			require.True(t, names[name], "%s is not in envOverrides", name)
+		}
+	}
+
+	walk(reflect.TypeOf(Configuration{}), "")
+}
+
+func TestLoadConfig_YAML(t *testing.T) {
+	load := func(p string) Configuration {
+		err := LoadConfig(p)
+		require.NoError(t, err)
+
+		cfg, err := Get()
+		require.NoError(t, err)
+
+		err = SetServerConfigDefaults(&cfg)
+		require.NoError(t, err)
+
+		return cfg
+	}
+
+	for _, name := range []string{"valid-convoy", "exponential-backoff-config"} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, load("./testdata/Config/"+name+".json"), load("./testdata/Config/"+name+".yaml"))
+		})
+	}
+
+	t.Run("sniffs_yaml_without_extension", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/Config/valid-convoy.yaml")
+		require.NoError(t, err)
+
+		p := filepath.Join(t.TempDir(), "convoy")
+		require.NoError(t, os.WriteFile(p, data, 0o644))
+
+		require.Equal(t, load("./testdata/Config/valid-convoy.json"), load(p))
+	})
+
+	t.Run("applies_env_overrides", func(t *testing.T) {
+		setEnv(t, "CONVOY_DB_DSN", "mongodb://from-env")
+
+		cfg := load("./testdata/Config/valid-convoy.yaml")
+		require.Equal(t, "mongodb://from-env", cfg.Database.Dsn)
+	})
+
+	t.Run("validates", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "convoy.yml")
+		require.NoError(t, os.WriteFile(p, []byte("server:\n  http:\n    port: 70000\n"), 0o644))
+
+		err := LoadConfig(p)
+		require.EqualError(t, err, "http port 70000 is out of range, must be between 1 and 65535")
+	})
+
+	t.Run("fails_for_invalid_yaml", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "convoy.yaml")
+		require.NoError(t, os.WriteFile(p, []byte("server: [\n"), 0o644))
+
+		err := LoadConfig(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse yaml config")
+	})
+}
+
+func TestLoadConfig_ReportsEveryProblem(t *testing.T) {
+	err := LoadConfig("./testdata/Config/many-problems-convoy.json")
+
+	var errs ValidationError
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, []string{
+		`invalid query timeout "5", please provide a duration e.g 10s`,
+		"http port 70000 is out of range, must be between 1 and 65535",
+		"invalid hash algorithm - 'SHA-256', must be one of [MD5 SHA1 SHA224 SHA256 SHA384 SHA512 SHA3_224 SHA3_256 SHA3_384 SHA3_512 SHA512_224 SHA512_256]",
+		"both interval seconds and retry limit are required for default strategy configuration",
+		"unsupported cache type: memcached",
+	}, strings.Split(err.Error(), "; "))
+	require.Len(t, errs, 5)
+}
+
+func TestSetServerConfigDefaults_RequiresSections(t *testing.T) {
+	err := LoadConfig("")
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+
+	err = SetServerConfigDefaults(&cfg)
+	require.EqualError(t, err, "http port cannot be zero; "+
+		"invalid hash algorithm - '', must be one of [MD5 SHA1 SHA224 SHA256 SHA384 SHA512 SHA3_224 SHA3_256 SHA3_384 SHA3_512 SHA512_224 SHA512_256]; "+
+		"unsupported strategy type: ; "+
+		"unsupported queue type: ")
+}
+
+func TestLoadConfig_FeatureFlags(t *testing.T) {
+	setEnv(t, "CONVOY_FEATURE_FLAGS", "admin_api:false")
+	err := LoadConfig("./testdata/Config/valid-convoy.json")
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.False(t, cfg.Server.FeatureFlags.Enabled(AdminAPIFeatureFlag))
+	require.True(t, cfg.Server.FeatureFlags.Enabled(AppPortalFeatureFlag))
+
+	setEnv(t, "CONVOY_FEATURE_FLAGS", "admin_api:false,billing:true")
+	err = LoadConfig("./testdata/Config/valid-convoy.json")
+	require.EqualError(t, err, `unknown feature flag "billing", must be one of [app_portal admin_api event_batching]`)
+}
+
+// setEnv sets the environment variable key for the duration of the test,
+// it restores the previous value on cleanup.
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+			return
+		}
+		_ = os.Unsetenv(key)
+	})
+}
@@ -9,9 +9,13 @@ import (
 type BasicAuthConfig []BasicAuth
 
 type BasicAuth struct {
-	Username string    `json:"username"`
-	Password string    `json:"password"`
-	Role     auth.Role `json:"role"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// PasswordFile is read for the password when it is set, see
+	// readSecretFiles.
+	PasswordFile string    `json:"password_file"`
+	Role         auth.Role `json:"role"`
 }
 
 // Decode loads in config from an env var named `CONVOY_BASIC_AUTH_CONFIG`
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// restartRequired are the fields a running convoy can't swap, e.g. the
+// server is already listening on its port. A reload that changes them
+// keeps their running values and reports them instead.
+var restartRequired = []struct {
+	name  string
+	field func(c *Configuration) interface{}
+}{
+	{name: "server.http.host", field: func(c *Configuration) interface{} { return &c.Server.HTTP.Host }},
+	{name: "server.http.port", field: func(c *Configuration) interface{} { return &c.Server.HTTP.Port }},
+	{name: "server.http.worker_port", field: func(c *Configuration) interface{} { return &c.Server.HTTP.WorkerPort }},
+	{name: "server.http.ssl", field: func(c *Configuration) interface{} { return &c.Server.HTTP.SSL }},
+	{name: "server.http.ssl_cert_file", field: func(c *Configuration) interface{} { return &c.Server.HTTP.SSLCertFile }},
+	{name: "server.http.ssl_key_file", field: func(c *Configuration) interface{} { return &c.Server.HTTP.SSLKeyFile }},
+	{name: "server.http.read_timeout", field: func(c *Configuration) interface{} { return &c.Server.HTTP.ReadTimeout }},
+	{name: "server.http.write_timeout", field: func(c *Configuration) interface{} { return &c.Server.HTTP.WriteTimeout }},
+	{name: "server.http.idle_timeout", field: func(c *Configuration) interface{} { return &c.Server.HTTP.IdleTimeout }},
+	{name: "database", field: func(c *Configuration) interface{} { return &c.Database }},
+	{name: "queue", field: func(c *Configuration) interface{} { return &c.Queue }},
+	{name: "cache", field: func(c *Configuration) interface{} { return &c.Cache }},
+}
+
+// ReloadResult describes a reload of the config.
+type ReloadResult struct {
+	// Version is the version of the reloaded config, see Version.
+	Version uint64 `json:"version"`
+
+	// RestartRequired are the fields that changed but keep their running
+	// values until convoy is restarted.
+	RestartRequired []string `json:"restart_required"`
+}
+
+// Resolver completes a config loaded from the file the way the running
+// config was completed at startup, e.g. with the cli flags and defaults.
+type Resolver func(c *Configuration) error
+
+// Reloader reloads the config file on demand, e.g. on SIGHUP, and tells
+// its listeners about the new config.
+type Reloader struct {
+	path    string
+	resolve Resolver
+
+	// mu serializes reloads, so listeners see the configs in the order
+	// they were loaded.
+	mu        sync.Mutex
+	listeners []ConfigChangeListener
+}
+
+func NewReloader(p string) *Reloader {
+	return &Reloader{path: p}
+}
+
+// SetResolver makes every reloaded config go through resolve before it is
+// compared with the running config, so the cli flags convoy was started
+// with keep applying.
+func (r *Reloader) SetResolver(resolve Resolver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resolve = resolve
+}
+
+// AddListener registers l to be notified of every reload.
+func (r *Reloader) AddListener(l ConfigChangeListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, l)
+}
+
+// Reload loads and validates the config file like LoadConfig, resolves it
+// like the running config was, then swaps it in for the running config. A
+// config that fails to load or validate leaves the running config in place.
+func (r *Reloader) Reload() (*ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	running, err := Get()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := loadConfig(r.path)
+	if err != nil {
+		log.WithError(err).Errorf("failed to reload config from %s", r.path)
+		return nil, err
+	}
+
+	if r.resolve != nil {
+		err = r.resolve(c)
+		if err != nil {
+			log.WithError(err).Errorf("failed to reload config from %s", r.path)
+			return nil, err
+		}
+	}
+
+	result := &ReloadResult{RestartRequired: []string{}}
+	for _, f := range restartRequired {
+		old, loaded := reflect.ValueOf(f.field(&running)).Elem(), reflect.ValueOf(f.field(c)).Elem()
+		if reflect.DeepEqual(old.Interface(), loaded.Interface()) {
+			continue
+		}
+
+		loaded.Set(old)
+		result.RestartRequired = append(result.RestartRequired, f.name)
+	}
+
+	result.Version = store(c)
+
+	log.Infof("reloaded config from %s", r.path)
+	if len(result.RestartRequired) > 0 {
+		log.Warnf("convoy must be restarted to apply the changes to %v", result.RestartRequired)
+	}
+
+	for _, l := range r.listeners {
+		l(*c)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, p, cfg string) {
+	err := os.WriteFile(p, []byte(cfg), 0o644)
+	require.NoError(t, err)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "logger": {"server_log": {"level": "info"}}}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+	version := Version()
+
+	r := NewReloader(p)
+
+	var reloaded []Configuration
+	r.AddListener(func(cfg Configuration) { reloaded = append(reloaded, cfg) })
+
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "logger": {"server_log": {"level": "debug"}}}`)
+
+	result, err := r.Reload()
+	require.NoError(t, err)
+	require.Equal(t, &ReloadResult{Version: version + 1, RestartRequired: []string{}}, result)
+	require.Equal(t, version+1, Version())
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "debug", cfg.Logger.ServerLog.Level)
+
+	require.Len(t, reloaded, 1)
+	require.Equal(t, cfg, reloaded[0])
+}
+
+func TestReloader_ReportsFieldsThatRequireRestart(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "database": {"dsn": "mongodb://localhost:27017"}, "server": {"http": {"port": 5005}}}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+
+	writeConfig(t, p, `{"base_url": "https://convoy.example.com", "database": {"dsn": "mongodb://mongo:27017"}, "server": {"http": {"port": 8080}}}`)
+
+	result, err := NewReloader(p).Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"server.http.port", "database"}, result.RestartRequired)
+
+	// the fields that can be swapped are, the others keep running
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "https://convoy.example.com", cfg.BaseUrl)
+	require.Equal(t, uint32(5005), cfg.Server.HTTP.Port)
+	require.Equal(t, "mongodb://localhost:27017", cfg.Database.Dsn)
+}
+
+func TestReloader_KeepsRunningConfigWhenInvalid(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005"}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+	version := Version()
+
+	r := NewReloader(p)
+	r.AddListener(func(cfg Configuration) { t.Error("listeners must not be told about an invalid config") })
+
+	writeConfig(t, p, `{"base_url": "https://convoy.example.com", "group": {"signature": {"hash": "SHA-256"}}}`)
+
+	_, err = r.Reload()
+	require.EqualError(t, err, "invalid hash algorithm - 'SHA-256', must be one of [MD5 SHA1 SHA224 SHA256 SHA384 SHA512 SHA3_224 SHA3_256 SHA3_384 SHA3_512 SHA512_224 SHA512_256]")
+	require.Equal(t, version, Version())
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:5005", cfg.BaseUrl)
+}
+
+func TestReloader_ResolvesLikeTheRunningConfig(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "server": {"http": {"port": 5005}}}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+
+	// as if convoy was started with --port 8080 --native
+	resolve := func(c *Configuration) error {
+		c.Server.HTTP.Port = 8080
+		c.Auth.Native.Enabled = true
+		return nil
+	}
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.NoError(t, resolve(&cfg))
+	Override(&cfg)
+
+	r := NewReloader(p)
+	r.SetResolver(resolve)
+
+	writeConfig(t, p, `{"base_url": "https://convoy.example.com", "server": {"http": {"port": 5005}}}`)
+
+	result, err := r.Reload()
+	require.NoError(t, err)
+	require.Empty(t, result.RestartRequired, "the flags still override the file")
+
+	cfg, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, "https://convoy.example.com", cfg.BaseUrl)
+	require.Equal(t, uint32(8080), cfg.Server.HTTP.Port)
+	require.True(t, cfg.Auth.Native.Enabled)
+}
+
+func TestReloader_KeepsRunningConfigWhenResolveFails(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005"}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+	version := Version()
+
+	r := NewReloader(p)
+	r.SetResolver(func(c *Configuration) error { return errors.New("http port cannot be zero") })
+
+	writeConfig(t, p, `{"base_url": "https://convoy.example.com"}`)
+
+	_, err = r.Reload()
+	require.EqualError(t, err, "http port cannot be zero")
+	require.Equal(t, version, Version())
+}
+
+func TestReloader_ComparesWithTheResolvedConfig(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "server": {"http": {"host": "0.0.0.0", "port": 5005}}}`)
+
+	err := LoadConfig(p)
+	require.NoError(t, err)
+
+	// as if convoy was started with --host 127.0.0.1 --port 8080
+	resolve := func(c *Configuration) error {
+		c.Server.HTTP.Host = "127.0.0.1"
+		c.Server.HTTP.Port = 8080
+		return nil
+	}
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.NoError(t, resolve(&cfg))
+	Override(&cfg)
+
+	r := NewReloader(p)
+	r.SetResolver(resolve)
+
+	// the file's port differs from the running one, but the flag wins
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "server": {"http": {"host": "0.0.0.0", "port": 6006}}}`)
+
+	result, err := r.Reload()
+	require.NoError(t, err)
+	require.Empty(t, result.RestartRequired)
+
+	// fields the flags don't set are still compared with the file's
+	writeConfig(t, p, `{"base_url": "http://localhost:5005", "server": {"http": {"host": "0.0.0.0", "port": 6006, "worker_port": 5006}}}`)
+
+	result, err = r.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"server.http.worker_port"}, result.RestartRequired)
+
+	cfg, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", cfg.Server.HTTP.Host)
+	require.Equal(t, uint32(8080), cfg.Server.HTTP.Port)
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secretFiles are the sensitive fields that can be read from a file, e.g.
+// a mounted secret, instead of being written into the config. Each is
+// paired with the field holding the path of its file.
+var secretFiles = []struct {
+	name   string
+	fields func(c *Configuration) (value, file *string)
+}{
+	{name: "database.dsn", fields: func(c *Configuration) (*string, *string) { return &c.Database.Dsn, &c.Database.DsnFile }},
+	{name: "queue.redis.dsn", fields: func(c *Configuration) (*string, *string) { return &c.Queue.Redis.Dsn, &c.Queue.Redis.DsnFile }},
+	{name: "cache.redis.dsn", fields: func(c *Configuration) (*string, *string) { return &c.Cache.Redis.Dsn, &c.Cache.Redis.DsnFile }},
+	{name: "cache.redis.password", fields: func(c *Configuration) (*string, *string) { return &c.Cache.Redis.Password, &c.Cache.Redis.PasswordFile }},
+	{name: "limiter.redis.dsn", fields: func(c *Configuration) (*string, *string) { return &c.Limiter.Redis.Dsn, &c.Limiter.Redis.DsnFile }},
+	{name: "smtp.password", fields: func(c *Configuration) (*string, *string) { return &c.SMTP.Password, &c.SMTP.PasswordFile }},
+}
+
+// readSecretFiles sets each sensitive field of c that has a file, see
+// secretFiles, to the contents of the file. It is called on every load,
+// so a reload picks up secrets rotated since.
+func (c *Configuration) readSecretFiles() error {
+	var errs ValidationError
+
+	for _, s := range secretFiles {
+		value, file := s.fields(c)
+		errs.add(readSecretFile(s.name, value, *file))
+	}
+
+	for i := range c.Auth.File.Basic {
+		b := &c.Auth.File.Basic[i]
+		errs.add(readSecretFile(fmt.Sprintf("auth.file.basic[%d].password", i), &b.Password, b.PasswordFile))
+	}
+
+	return errs.err()
+}
+
+func readSecretFile(name string, value *string, file string) error {
+	if file == "" {
+		return nil
+	}
+
+	if *value != "" {
+		return fmt.Errorf("both %s and %s_file are set, please set only one", name, name)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s_file: %v", name, err)
+	}
+
+	*value = strings.TrimRight(string(data), "\r\n")
+	return nil
+}
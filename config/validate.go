@@ -0,0 +1,173 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const maxPort = 65535
+
+// ValidationError is every problem found in a config, they're reported
+// together so they can all be fixed before the next start.
+type ValidationError []error
+
+func (v ValidationError) Error() string {
+	msgs := make([]string, len(v))
+	for i, err := range v {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (v *ValidationError) add(err error) {
+	if err != nil {
+		*v = append(*v, err)
+	}
+}
+
+func (v ValidationError) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return v
+}
+
+// Validate checks the values set in c, e.g. enums, port ranges and
+// durations, returning a ValidationError with every problem found.
+// Sections that aren't set are left alone since cli flags can still fill
+// them in, SetServerConfigDefaults requires them before the server starts.
+func (c Configuration) Validate() error {
+	return c.validate(false).err()
+}
+
+// validate checks c, when required is true the sections the server can't
+// run without are checked even when they're empty.
+func (c *Configuration) validate(required bool) ValidationError {
+	var errs ValidationError
+
+	errs.add(ensureDatabaseConfig(c.Database))
+
+	switch c.Database.Type {
+	case "", MongodbDatabaseProvider, InMemoryDatabaseProvider, BadgerDatabaseProvider:
+	default:
+		errs.add(fmt.Errorf("unsupported database type: %s", c.Database.Type))
+	}
+
+	_, err := c.Database.GetDeletedDocumentTTL()
+	errs.add(err)
+
+	_, err = c.Database.GetQueryTimeout()
+	errs.add(err)
+
+	_, err = c.Database.GetBulkWriteTimeout()
+	errs.add(err)
+
+	errs.add(ensureSSL(c.Server))
+
+	errs.add(ensurePort("http port", c.Server.HTTP.Port))
+	errs.add(ensurePort("worker port", c.Server.HTTP.WorkerPort))
+	errs.add(ensurePort("smtp port", c.SMTP.Port))
+
+	if c.Server.HTTP.APIRateLimit < 0 {
+		errs.add(errors.New("api rate limit cannot be negative"))
+	}
+
+	_, err = c.Server.HTTP.GetReadTimeout()
+	errs.add(err)
+
+	_, err = c.Server.HTTP.GetWriteTimeout()
+	errs.add(err)
+
+	_, err = c.Server.HTTP.GetIdleTimeout()
+	errs.add(err)
+
+	_, err = c.Server.HTTP.GetDrainPeriod()
+	errs.add(err)
+
+	_, err = c.Server.HTTP.GetPreStopDelay()
+	errs.add(err)
+
+	if required || c.GroupConfig.Signature.Hash != "" {
+		errs.add(ensureSignature(c.GroupConfig.Signature))
+	}
+
+	if required || c.GroupConfig.Strategy.Type != "" {
+		errs.add(ensureStrategyConfig(c.GroupConfig.Strategy))
+	}
+
+	if required || c.Queue.Type != "" {
+		errs.add(ensureQueueConfig(c.Queue))
+	}
+
+	errs.add(ensureAuthConfig(c.Auth))
+	errs.add(ensureArchiveConfig(c.Archive))
+	errs.add(ensureSourceConfig(&c.Source))
+
+	switch c.Cache.Type {
+	case "", RedisCacheProvider, InMemoryCacheProvider:
+	default:
+		errs.add(fmt.Errorf("unsupported cache type: %s", c.Cache.Type))
+	}
+
+	switch c.Limiter.Type {
+	case "", RedisLimiterProvider, InMemoryLimiterProvider:
+	default:
+		errs.add(fmt.Errorf("unsupported limiter type: %s", c.Limiter.Type))
+	}
+
+	switch c.Logger.Type {
+	case "", ConsoleLoggerProvider:
+	default:
+		errs.add(fmt.Errorf("unsupported logger type: %s", c.Logger.Type))
+	}
+
+	if c.Logger.ServerLog.Level != "" {
+		_, err = log.ParseLevel(c.Logger.ServerLog.Level)
+		if err != nil {
+			errs.add(fmt.Errorf("invalid logger level %q, must be one of %v", c.Logger.ServerLog.Level, log.AllLevels))
+		}
+	}
+
+	switch c.Tracer.Type {
+	case "", NewRelicTracerProvider:
+	default:
+		errs.add(fmt.Errorf("unsupported tracer type: %s", c.Tracer.Type))
+	}
+
+	flags := make([]string, 0, len(c.Server.FeatureFlags))
+	for flag := range c.Server.FeatureFlags {
+		flags = append(flags, flag)
+	}
+
+	sort.Strings(flags)
+	for _, flag := range flags {
+		errs.add(ensureFeatureFlag(flag))
+	}
+
+	_, err = c.Worker.GetGracefulShutdownTimeout()
+	errs.add(err)
+
+	return errs
+}
+
+func ensurePort(name string, port uint32) error {
+	if port > maxPort {
+		return fmt.Errorf("%s %d is out of range, must be between 1 and %d", name, port, maxPort)
+	}
+	return nil
+}
+
+func ensureFeatureFlag(flag string) error {
+	for _, f := range featureFlags {
+		if flag == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown feature flag %q, must be one of %v", flag, featureFlags)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigChangeListener is called with the new configuration each time
+// the config file is reloaded.
+type ConfigChangeListener func(cfg Configuration)
+
+// Watcher reloads the config file with a Reloader when it changes, so
+// settings can be updated without restarting convoy.
+type Watcher struct {
+	path     string
+	watcher  *fsnotify.Watcher
+	reloader *Reloader
+
+	done chan struct{}
+}
+
+func NewWatcher(r *Reloader) (*Watcher, error) {
+	p, err := filepath.Abs(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// editors usually save by replacing the file, which drops a watch
+	// on the file itself, so its directory is watched instead.
+	err = fw.Add(filepath.Dir(p))
+	if err != nil {
+		_ = fw.Close()
+		return nil, err
+	}
+
+	return &Watcher{path: p, watcher: fw, reloader: r, done: make(chan struct{})}, nil
+}
+
+// Start watches the config file in the background until Stop is called.
+func (w *Watcher) Start() {
+	go func() {
+		for {
+			select {
+			case event, ok := <-w.watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != w.path {
+					continue
+				}
+
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					// a bad edit leaves the running config in place,
+					// Reload logs why
+					_, _ = w.reloader.Reload()
+				}
+			case err, ok := <-w.watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.WithError(err).Error("config watcher error")
+			case <-w.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the watch started by Start.
+func (w *Watcher) Stop() error {
+	close(w.done)
+	return w.watcher.Close()
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWatcher_ReloadsOnFileChange(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "convoy.json")
+
+	err := os.WriteFile(p, []byte(`{"base_url": "http://localhost:5005", "limiter": {"type": "redis", "redis": {"dsn": "redis://localhost:6379"}}}`), 0o644)
+	require.NoError(t, err)
+
+	err = LoadConfig(p)
+	require.NoError(t, err)
+
+	r := NewReloader(p)
+	w, err := NewWatcher(r)
+	require.NoError(t, err)
+
+	reloaded := make(chan Configuration, 10)
+	r.AddListener(func(cfg Configuration) { reloaded <- cfg })
+	w.Start()
+	defer w.Stop()
+
+	err = os.WriteFile(p, []byte(`{"base_url": "https://convoy.example.com", "limiter": {"type": "redis", "redis": {"dsn": "redis://redis:6380"}}}`), 0o644)
+	require.NoError(t, err)
+
+	var cfg Configuration
+	for {
+		select {
+		case cfg = <-reloaded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("config was not reloaded")
+		}
+
+		// a write can surface as several events, wait for the one
+		// that saw all of it.
+		if cfg.BaseUrl == "https://convoy.example.com" {
+			break
+		}
+	}
+
+	require.Equal(t, "redis://redis:6380", cfg.Limiter.Redis.Dsn)
+
+	c, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "https://convoy.example.com", c.BaseUrl)
+	require.Equal(t, LimiterConfiguration{Type: RedisLimiterProvider, Redis: RedisLimiterConfiguration{Dsn: "redis://redis:6380"}}, c.Limiter)
+}
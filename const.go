@@ -1,5 +1,7 @@
 package convoy
 
+import "time"
+
 const (
 	HttpPost HttpMethod = "POST"
 )
@@ -8,4 +10,8 @@ const (
 	RATE_LIMIT          = 5000
 	RATE_LIMIT_DURATION = "1m"
 	HTTP_TIMEOUT        = "30s"
+
+	// MAX_HTTP_TIMEOUT is the longest an endpoint's http timeout can be,
+	// longer ones set before it was enforced are cut to it.
+	MAX_HTTP_TIMEOUT = 2 * time.Minute
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/timshannon/badgerhold/v4"
@@ -67,6 +68,18 @@ func (a *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, hash string) (*datast
 }
 
 func (a *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	return a.loadAPIKeysPaged(func() *badgerhold.Query { return &badgerhold.Query{} }, pageable)
+}
+
+func (a *apiKeyRepo) FindAPIKeysByGroupID(ctx context.Context, groupID string, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	return a.loadAPIKeysPaged(func() *badgerhold.Query {
+		return badgerhold.Where("Role.Groups").Contains(groupID)
+	}, pageable)
+}
+
+// loadAPIKeysPaged pages through the api keys matching the queries built
+// by newQuery, which is called for the page and again for the count.
+func (a *apiKeyRepo) loadAPIKeysPaged(newQuery func() *badgerhold.Query, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
 	var apiKeys []datastore.APIKey = make([]datastore.APIKey, 0)
 
 	page := pageable.Page
@@ -84,7 +97,7 @@ func (a *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.P
 	prevPage := page - 1
 	lowerBound := perPage * prevPage
 
-	q := &badgerhold.Query{}
+	q := newQuery()
 	q.SortBy("CreatedAt")
 	if pageable.Sort == -1 {
 		q.Reverse()
@@ -95,7 +108,7 @@ func (a *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.P
 		return nil, datastore.PaginationData{}, err
 	}
 
-	total, err := a.db.Count(&datastore.APIKey{}, &badgerhold.Query{})
+	total, err := a.db.Count(&datastore.APIKey{}, newQuery())
 
 	if err != nil {
 		return nil, datastore.PaginationData{}, err
@@ -110,3 +123,20 @@ func (a *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.P
 
 	return apiKeys, data, err
 }
+
+func (a *apiKeyRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var keys []datastore.APIKey
+	err := a.db.Find(&keys, deletedBeforeQuery(deletedBefore, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range keys {
+		err = a.db.Delete(keys[i].UID, &datastore.APIKey{})
+		if err != nil {
+			return int64(i), err
+		}
+	}
+
+	return int64(len(keys)), nil
+}
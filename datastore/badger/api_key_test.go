@@ -8,12 +8,14 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/pbkdf2"
 )
 
@@ -317,3 +319,77 @@ func generateAPIKey() (string, string, string, error) {
 	return maskID, salt, encodedKey, nil
 
 }
+
+func Test_FindAPIKeysByGroupID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	apiKeyRepo := NewApiRoleRepo(db)
+
+	groupID := uuid.NewString()
+	roles := []auth.Role{
+		{Type: auth.RoleAdmin, Groups: []string{groupID}},
+		{Type: auth.RoleAdmin, Groups: []string{uuid.NewString(), groupID}},
+		{Type: auth.RoleAdmin, Groups: []string{groupID}},
+		{Type: auth.RoleAdmin, Groups: []string{uuid.NewString()}},
+		{Type: auth.RoleSuperUser},
+	}
+
+	for _, role := range roles {
+		maskID, salt, encodedKey, err := generateAPIKey()
+		require.NoError(t, err)
+
+		require.NoError(t, apiKeyRepo.CreateAPIKey(context.Background(), &datastore.APIKey{
+			UID:    uuid.NewString(),
+			MaskID: maskID,
+			Name:   "key",
+			Role:   role,
+			Hash:   encodedKey,
+			Salt:   salt,
+		}))
+	}
+
+	apiKeys, data, err := apiKeyRepo.FindAPIKeysByGroupID(context.Background(), groupID, &datastore.Pageable{Page: 1, PerPage: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(apiKeys))
+	require.Equal(t, int64(3), data.Total)
+	require.Equal(t, int64(2), data.TotalPage)
+
+	apiKeys, _, err = apiKeyRepo.FindAPIKeysByGroupID(context.Background(), groupID, &datastore.Pageable{Page: 2, PerPage: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(apiKeys))
+	require.Contains(t, apiKeys[0].Role.Groups, groupID)
+}
+
+func TestAPIKeyRepository_PurgeDeleted(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	apiKeyRepo := NewApiRoleRepo(db)
+	now := time.Now()
+
+	keys := []*datastore.APIKey{
+		{UID: uuid.NewString(), MaskID: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-48 * time.Hour))},
+		{UID: uuid.NewString(), MaskID: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-time.Hour))},
+		{UID: uuid.NewString(), MaskID: uuid.NewString()},
+	}
+
+	for _, k := range keys {
+		require.NoError(t, apiKeyRepo.CreateAPIKey(context.Background(), k))
+	}
+
+	purged, err := apiKeyRepo.PurgeDeleted(context.Background(), now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = apiKeyRepo.FindAPIKeyByID(context.Background(), keys[0].UID)
+	require.ErrorIs(t, err, datastore.ErrAPIKeyNotFound)
+
+	// recently revoked and active keys are kept
+	for _, k := range keys[1:] {
+		_, err = apiKeyRepo.FindAPIKeyByID(context.Background(), k.UID)
+		require.NoError(t, err)
+	}
+}
@@ -30,7 +30,7 @@ func (a *appRepo) UpdateApplication(ctx context.Context, app *datastore.Applicat
 	return a.db.Update(app.UID, app)
 }
 
-func (a *appRepo) LoadApplicationsPaged(ctx context.Context, gid, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+func (a *appRepo) LoadApplicationsPaged(ctx context.Context, gid string, f *datastore.ApplicationFilter, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
 	var apps []datastore.Application = make([]datastore.Application, 0)
 
 	page := pageable.Page
@@ -49,13 +49,22 @@ func (a *appRepo) LoadApplicationsPaged(ctx context.Context, gid, q string, page
 	lowerBound := perPage * prevPage
 
 	af := &appFilter{
-		hasTitle:   !util.IsStringEmpty(q),
-		hasGroupId: !util.IsStringEmpty(gid),
-		title:      q,
-		groupId:    gid,
+		hasTitle:      !util.IsStringEmpty(f.Query),
+		hasGroupId:    !util.IsStringEmpty(gid),
+		hasOwnerId:    !util.IsStringEmpty(f.OwnerID),
+		hasIsDisabled: f.IsDisabled != nil,
+		title:         f.Query,
+		groupId:       gid,
+		ownerId:       f.OwnerID,
+		isDisabled:    f.IsDisabled,
 	}
 
-	qry := a.generateQuery(af).Skip(lowerBound).Limit(perPage).SortBy("CreatedAt")
+	sortBy := "CreatedAt"
+	if f.SortBy == datastore.ApplicationSortTitle {
+		sortBy = "Title"
+	}
+
+	qry := a.generateQuery(af).Skip(lowerBound).Limit(perPage).SortBy(sortBy)
 	if pageable.Sort == -1 {
 		qry.Reverse()
 	}
@@ -96,7 +105,7 @@ func (a *appRepo) LoadApplicationsPaged(ctx context.Context, gid, q string, page
 }
 
 func (a *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, gid string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
-	return a.LoadApplicationsPaged(ctx, gid, "", pageable)
+	return a.LoadApplicationsPaged(ctx, gid, &datastore.ApplicationFilter{}, pageable)
 }
 
 func (a *appRepo) SearchApplicationsByGroupId(ctx context.Context, gid string, searchParams datastore.SearchParams) ([]datastore.Application, error) {
@@ -127,6 +136,28 @@ func (a *appRepo) FindApplicationByID(ctx context.Context, aid string) (*datasto
 	return application, err
 }
 
+func (a *appRepo) FindApplicationsByOwnerID(ctx context.Context, groupID, ownerID string) ([]datastore.Application, error) {
+	apps := make([]datastore.Application, 0)
+
+	err := a.db.Find(&apps, badgerhold.Where("GroupID").Eq(groupID).And("OwnerID").Eq(ownerID))
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+func (a *appRepo) FindApplicationsByIDs(ctx context.Context, ids []string) ([]datastore.Application, error) {
+	apps := make([]datastore.Application, 0)
+
+	err := a.db.Find(&apps, badgerhold.Where("UID").In(badgerhold.Slice(ids)...))
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
 func (a *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string, endpointID string) (*datastore.Endpoint, error) {
 	var endpoint *datastore.Endpoint
 	var application *datastore.Application
@@ -171,6 +202,11 @@ func (a *appRepo) UpdateApplicationEndpointsStatus(ctx context.Context, aid stri
 	for i := 0; i < len(application.Endpoints); i++ {
 		if _, ok := endpointMap[application.Endpoints[i].UID]; ok {
 			application.Endpoints[i].Status = status
+
+			if status == datastore.ActiveEndpointStatus {
+				application.Endpoints[i].DisabledReason = ""
+				application.Endpoints[i].DisabledAt = 0
+			}
 		}
 	}
 
@@ -179,6 +215,84 @@ func (a *appRepo) UpdateApplicationEndpointsStatus(ctx context.Context, aid stri
 	return err
 }
 
+func (a *appRepo) DisableApplicationEndpoint(ctx context.Context, aid, endpointID, reason string) error {
+	var application *datastore.Application
+
+	err := a.db.Get(aid, &application)
+	if err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return datastore.ErrApplicationNotFound
+		}
+		return err
+	}
+
+	for i := 0; i < len(application.Endpoints); i++ {
+		if application.Endpoints[i].UID == endpointID {
+			application.Endpoints[i].Status = datastore.InactiveEndpointStatus
+			application.Endpoints[i].DisabledReason = reason
+			application.Endpoints[i].DisabledAt = primitive.NewDateTimeFromTime(time.Now())
+
+			return a.UpdateApplication(ctx, application)
+		}
+	}
+
+	return datastore.ErrEndpointNotFound
+}
+
+func (a *appRepo) UpdateApplicationHealth(ctx context.Context, aid string, score float64, status datastore.ApplicationHealthStatus) error {
+	var application *datastore.Application
+
+	err := a.db.Get(aid, &application)
+	if err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return datastore.ErrApplicationNotFound
+		}
+		return err
+	}
+
+	application.HealthScore = score
+	application.HealthStatus = status
+	application.LastHealthCheck = primitive.NewDateTimeFromTime(time.Now())
+
+	return a.db.Update(application.UID, application)
+}
+
+func (a *appRepo) FindGroupsWithInactiveEndpoints(ctx context.Context) ([]string, error) {
+	var apps []datastore.Application
+	err := a.db.Find(&apps, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, app := range apps {
+		if seen[app.GroupID] {
+			continue
+		}
+
+		for _, endpoint := range app.Endpoints {
+			if endpoint.Status == datastore.InactiveEndpointStatus && endpoint.DeletedAt == 0 {
+				seen[app.GroupID] = true
+				ids = append(ids, app.GroupID)
+				break
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// FindDeletedApplicationByID never finds an app, badger deletes apps
+// outright rather than soft-deleting them.
+func (a *appRepo) FindDeletedApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	return nil, datastore.ErrApplicationNotFound
+}
+
+func (a *appRepo) RestoreApplication(ctx context.Context, app *datastore.Application) error {
+	return datastore.ErrApplicationNotFound
+}
+
 func (a *appRepo) DeleteGroupApps(ctx context.Context, gid string) error {
 	return a.db.DeleteMatching(&datastore.Application{}, badgerhold.Where("GroupID").Eq(gid))
 }
@@ -192,13 +306,17 @@ func (a *appRepo) CountGroupApplications(ctx context.Context, gid string) (int64
 }
 
 type appFilter struct {
-	hasTitle     bool
-	hasGroupId   bool
-	hasStartDate bool
-	hasEndDate   bool
-	title        string
-	groupId      string
-	searchParams datastore.SearchParams
+	hasTitle      bool
+	hasGroupId    bool
+	hasOwnerId    bool
+	hasIsDisabled bool
+	hasStartDate  bool
+	hasEndDate    bool
+	title         string
+	groupId       string
+	ownerId       string
+	isDisabled    *bool
+	searchParams  datastore.SearchParams
 }
 
 func (a *appRepo) generateQuery(f *appFilter) *badgerhold.Query {
@@ -220,6 +338,14 @@ func (a *appRepo) generateQuery(f *appFilter) *badgerhold.Query {
 		qFunc = qFunc("GroupID").Eq(f.groupId).And
 	}
 
+	if f.hasOwnerId {
+		qFunc = qFunc("OwnerID").Eq(f.ownerId).And
+	}
+
+	if f.hasIsDisabled {
+		qFunc = qFunc("IsDisabled").Eq(*f.isDisabled).And
+	}
+
 	if f.hasStartDate {
 		createdStart := primitive.NewDateTimeFromTime(time.Unix(f.searchParams.CreatedAtStart, 0))
 		qFunc = qFunc("CreatedAt").Ge(createdStart).And
@@ -232,3 +358,20 @@ func (a *appRepo) generateQuery(f *appFilter) *badgerhold.Query {
 
 	return qFunc("UID").Ne("")
 }
+
+func (a *appRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var apps []datastore.Application
+	err := a.db.Find(&apps, deletedBeforeQuery(deletedBefore, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range apps {
+		err = a.db.Delete(apps[i].UID, &datastore.Application{})
+		if err != nil {
+			return int64(i), err
+		}
+	}
+
+	return int64(len(apps)), nil
+}
@@ -226,7 +226,7 @@ func Test_LoadApplicationsPaged(t *testing.T) {
 			}
 			require.NoError(t, appRepo.CreateApplication(context.Background(), b))
 
-			apps, data, err := appRepo.LoadApplicationsPaged(context.Background(), tc.gid, tc.q, tc.pageData)
+			apps, data, err := appRepo.LoadApplicationsPaged(context.Background(), tc.gid, &datastore.ApplicationFilter{Query: tc.q}, tc.pageData)
 
 			require.NoError(t, err)
 
@@ -244,6 +244,61 @@ func Test_LoadApplicationsPaged(t *testing.T) {
 	}
 }
 
+func Test_LoadApplicationsPaged_Filters(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := NewApplicationRepo(db)
+
+	apps := []*datastore.Application{
+		{Title: "Billing (EU)", OwnerID: "cus_1"},
+		{Title: "billing us", OwnerID: "cus_1", IsDisabled: true},
+		{Title: "Shipping", OwnerID: "cus_2"},
+		{Title: "Billing", OwnerID: "cus_1", GroupID: "uid-2"},
+	}
+
+	for _, app := range apps {
+		app.UID = uuid.NewString()
+		if app.GroupID == "" {
+			app.GroupID = "uid-1"
+		}
+		require.NoError(t, appRepo.CreateApplication(context.Background(), app))
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 10, Sort: 1}
+	isDisabled := false
+
+	found, data, err := appRepo.LoadApplicationsPaged(context.Background(), "uid-1", &datastore.ApplicationFilter{
+		Query: "BILLING", OwnerID: "cus_1", IsDisabled: &isDisabled,
+	}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+	require.Equal(t, int64(1), data.Total)
+	require.Equal(t, "Billing (EU)", found[0].Title)
+
+	isDisabled = true
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), "uid-1", &datastore.ApplicationFilter{IsDisabled: &isDisabled}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+	require.Equal(t, "billing us", found[0].Title)
+
+	// the query is a plain substring, not a pattern
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), "uid-1", &datastore.ApplicationFilter{Query: "(EU)"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), "uid-1", &datastore.ApplicationFilter{Query: ".*"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(found))
+
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), "uid-1", &datastore.ApplicationFilter{SortBy: datastore.ApplicationSortTitle}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(found))
+	require.Equal(t, "Billing (EU)", found[0].Title)
+	require.Equal(t, "Shipping", found[1].Title)
+	require.Equal(t, "billing us", found[2].Title)
+}
+
 func Test_CreateApplication(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
@@ -333,6 +388,38 @@ func Test_FindApplicationByID(t *testing.T) {
 	require.NoError(t, e)
 }
 
+func Test_FindApplicationsByOwnerID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := NewApplicationRepo(db)
+
+	groupID := uuid.NewString()
+	apps := []*datastore.Application{
+		{UID: uuid.NewString(), GroupID: groupID, OwnerID: "customer-1", Title: "Application 1"},
+		{UID: uuid.NewString(), GroupID: groupID, OwnerID: "customer-1", Title: "Application 2"},
+		{UID: uuid.NewString(), GroupID: groupID, OwnerID: "customer-2", Title: "Application 3"},
+		{UID: uuid.NewString(), GroupID: uuid.NewString(), OwnerID: "customer-1", Title: "Application 4"},
+	}
+
+	for _, app := range apps {
+		require.NoError(t, appRepo.CreateApplication(context.Background(), app))
+	}
+
+	found, err := appRepo.FindApplicationsByOwnerID(context.Background(), groupID, "customer-1")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	for _, app := range found {
+		require.Equal(t, groupID, app.GroupID)
+		require.Equal(t, "customer-1", app.OwnerID)
+	}
+
+	found, err = appRepo.FindApplicationsByOwnerID(context.Background(), groupID, "customer-3")
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
+
 func Test_SearchApplicationsByGroupId(t *testing.T) {
 	type Args struct {
 		uid      string
@@ -670,3 +757,60 @@ func Test_UpdateApplicationEndpointsStatus(t *testing.T) {
 	require.Equal(t, datastore.InactiveEndpointStatus, app.Endpoints[1].Status)
 
 }
+
+func Test_DisableApplicationEndpoint(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := NewApplicationRepo(db)
+
+	endpoints := []datastore.Endpoint{
+		{
+			UID:       uuid.NewString(),
+			TargetURL: "sample-delivery-url-1",
+			Status:    datastore.ActiveEndpointStatus,
+		},
+
+		{
+			UID:       uuid.NewString(),
+			TargetURL: "sample-delivery-url-2",
+			Status:    datastore.ActiveEndpointStatus,
+		},
+	}
+
+	app := &datastore.Application{
+		Title:     "Application 11",
+		GroupID:   uuid.NewString(),
+		UID:       uuid.NewString(),
+		Endpoints: endpoints,
+	}
+
+	err := appRepo.CreateApplication(context.Background(), app)
+	require.NoError(t, err)
+
+	err = appRepo.DisableApplicationEndpoint(context.Background(), app.UID, endpoints[0].UID, "retry limit was hit")
+	require.NoError(t, err)
+
+	app, err = appRepo.FindApplicationByID(context.Background(), app.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.InactiveEndpointStatus, app.Endpoints[0].Status)
+	require.Equal(t, "retry limit was hit", app.Endpoints[0].DisabledReason)
+	require.NotZero(t, app.Endpoints[0].DisabledAt)
+	require.Equal(t, datastore.ActiveEndpointStatus, app.Endpoints[1].Status)
+	require.Empty(t, app.Endpoints[1].DisabledReason)
+
+	// re-enabling the endpoint clears why it was disabled
+	err = appRepo.UpdateApplicationEndpointsStatus(context.Background(), app.UID, []string{endpoints[0].UID}, datastore.ActiveEndpointStatus)
+	require.NoError(t, err)
+
+	app, err = appRepo.FindApplicationByID(context.Background(), app.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.ActiveEndpointStatus, app.Endpoints[0].Status)
+	require.Empty(t, app.Endpoints[0].DisabledReason)
+	require.Zero(t, app.Endpoints[0].DisabledAt)
+
+	err = appRepo.DisableApplicationEndpoint(context.Background(), app.UID, uuid.NewString(), "retry limit was hit")
+	require.True(t, errors.Is(err, datastore.ErrEndpointNotFound))
+}
@@ -0,0 +1,88 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+type archiveRepo struct {
+	db *badgerhold.Store
+}
+
+func NewArchiveRepo(db *badgerhold.Store) datastore.ArchiveRepository {
+	return &archiveRepo{db: db}
+}
+
+func (a *archiveRepo) CreateArchiveManifest(ctx context.Context, manifest *datastore.ArchiveManifest) error {
+	if util.IsStringEmpty(manifest.UID) {
+		manifest.UID = uuid.New().String()
+	}
+
+	return a.db.Insert(manifest.UID, manifest)
+}
+
+func (a *archiveRepo) UpdateArchiveManifest(ctx context.Context, manifest *datastore.ArchiveManifest) error {
+	return a.db.Update(manifest.UID, manifest)
+}
+
+func (a *archiveRepo) FindArchiveManifestByID(ctx context.Context, uid string) (*datastore.ArchiveManifest, error) {
+	var manifest datastore.ArchiveManifest
+
+	err := a.db.Get(uid, &manifest)
+	if err != nil && errors.Is(err, badgerhold.ErrNotFound) {
+		return &manifest, datastore.ErrArchiveManifestNotFound
+	}
+
+	return &manifest, err
+}
+
+func (a *archiveRepo) LoadArchiveManifestsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ArchiveManifest, datastore.PaginationData, error) {
+	var manifests = make([]datastore.ArchiveManifest, 0)
+
+	page := pageable.Page
+	perPage := pageable.PerPage
+	data := datastore.PaginationData{}
+
+	if pageable.Page < 1 {
+		page = 1
+	}
+
+	if pageable.PerPage < 1 {
+		perPage = 10
+	}
+
+	prevPage := page - 1
+	lowerBound := perPage * prevPage
+
+	q := badgerhold.Where("GroupID").Eq(groupID)
+
+	total, err := a.db.Count(&datastore.ArchiveManifest{}, q)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	q.SortBy("CreatedAt")
+	if pageable.Sort == -1 {
+		q.Reverse()
+	}
+
+	err = a.db.Find(&manifests, q.Skip(lowerBound).Limit(perPage))
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	data.Total = int64(total)
+	data.TotalPage = int64(math.Ceil(float64(total) / float64(perPage)))
+	data.PerPage = int64(perPage)
+	data.Next = int64(page + 1)
+	data.Page = int64(page)
+	data.Prev = int64(prevPage)
+
+	return manifests, data, nil
+}
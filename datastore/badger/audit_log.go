@@ -0,0 +1,75 @@
+package badger
+
+import (
+	"context"
+	"math"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+type auditLogRepo struct {
+	db *badgerhold.Store
+}
+
+func NewAuditLogRepo(db *badgerhold.Store) datastore.AuditLogRepository {
+	return &auditLogRepo{db: db}
+}
+
+func (a *auditLogRepo) Append(ctx context.Context, entry *datastore.AuditLog) error {
+	if util.IsStringEmpty(entry.UID) {
+		entry.UID = uuid.New().String()
+	}
+
+	return a.db.Insert(entry.UID, entry)
+}
+
+func (a *auditLogRepo) LoadAuditLogsPaged(ctx context.Context, resourceType string, pageable datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	var logs = make([]datastore.AuditLog, 0)
+
+	page := pageable.Page
+	perPage := pageable.PerPage
+	data := datastore.PaginationData{}
+
+	if pageable.Page < 1 {
+		page = 1
+	}
+
+	if pageable.PerPage < 1 {
+		perPage = 10
+	}
+
+	prevPage := page - 1
+	lowerBound := perPage * prevPage
+
+	q := &badgerhold.Query{}
+	if !util.IsStringEmpty(resourceType) {
+		q = badgerhold.Where("ResourceType").Eq(resourceType)
+	}
+
+	total, err := a.db.Count(&datastore.AuditLog{}, q)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	q.SortBy("Timestamp")
+	if pageable.Sort == -1 {
+		q.Reverse()
+	}
+
+	err = a.db.Find(&logs, q.Skip(lowerBound).Limit(perPage))
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	data.Total = int64(total)
+	data.TotalPage = int64(math.Ceil(float64(total) / float64(perPage)))
+	data.PerPage = int64(perPage)
+	data.Next = int64(page + 1)
+	data.Page = int64(page)
+	data.Prev = int64(prevPage)
+
+	return logs, data, nil
+}
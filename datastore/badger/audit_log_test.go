@@ -0,0 +1,45 @@
+//go:build integration
+// +build integration
+
+package badger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_LoadAuditLogsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	auditLogRepo := NewAuditLogRepo(db)
+	now := time.Now()
+
+	entries := []*datastore.AuditLog{
+		{Action: datastore.AuditActionCreate, ResourceType: datastore.APIKeyAuditResourceType, ResourceID: "1", Timestamp: primitive.NewDateTimeFromTime(now.Add(-2 * time.Minute))},
+		{Action: datastore.AuditActionUpdate, ResourceType: datastore.APIKeyAuditResourceType, ResourceID: "1", Timestamp: primitive.NewDateTimeFromTime(now.Add(-time.Minute))},
+		{Action: datastore.AuditActionCreate, ResourceType: "group", ResourceID: "2", Timestamp: primitive.NewDateTimeFromTime(now)},
+	}
+
+	for _, entry := range entries {
+		require.NoError(t, auditLogRepo.Append(context.Background(), entry))
+		require.NotEmpty(t, entry.UID)
+	}
+
+	logs, data, err := auditLogRepo.LoadAuditLogsPaged(context.Background(), datastore.APIKeyAuditResourceType, datastore.Pageable{Page: 1, PerPage: 10, Sort: -1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), data.Total)
+	require.Len(t, logs, 2)
+	require.Equal(t, datastore.AuditActionUpdate, logs[0].Action)
+	require.Equal(t, datastore.AuditActionCreate, logs[1].Action)
+
+	logs, data, err = auditLogRepo.LoadAuditLogsPaged(context.Background(), "", datastore.Pageable{Page: 1, PerPage: 10, Sort: -1})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), data.Total)
+	require.Len(t, logs, 3)
+}
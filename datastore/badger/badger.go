@@ -16,10 +16,16 @@ import (
 type Client struct {
 	store             *badgerhold.Store
 	apiKeyRepo        datastore.APIKeyRepository
+	auditLogRepo      datastore.AuditLogRepository
+	archiveRepo       datastore.ArchiveRepository
 	groupRepo         datastore.GroupRepository
 	eventRepo         datastore.EventRepository
 	applicationRepo   datastore.ApplicationRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventBridgeRepo   datastore.EventBridgeRepository
+	exportJobRepo     datastore.ExportJobRepository
+	eventTypeRepo     datastore.EventTypeRepository
+	settingsRepo      datastore.GlobalSettingsRepository
 }
 
 func New(cfg config.Configuration) (datastore.DatabaseClient, error) {
@@ -45,8 +51,14 @@ func New(cfg config.Configuration) (datastore.DatabaseClient, error) {
 		groupRepo:         NewGroupRepo(st),
 		eventRepo:         NewEventRepo(st),
 		apiKeyRepo:        NewApiRoleRepo(st),
+		auditLogRepo:      NewAuditLogRepo(st),
+		archiveRepo:       NewArchiveRepo(st),
 		applicationRepo:   NewApplicationRepo(st),
 		eventDeliveryRepo: NewEventDeliveryRepository(st),
+		eventBridgeRepo:   NewEventBridgeRepo(st),
+		exportJobRepo:     NewExportJobRepo(st),
+		eventTypeRepo:     NewEventTypeRepo(st),
+		settingsRepo:      NewGlobalSettingsRepo(st),
 	}
 
 	return c, nil
@@ -83,3 +95,70 @@ func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
 func (c *Client) APIRepo() datastore.APIKeyRepository {
 	return c.apiKeyRepo
 }
+
+func (c *Client) AuditLogRepo() datastore.AuditLogRepository {
+	return c.auditLogRepo
+}
+
+func (c *Client) ArchiveRepo() datastore.ArchiveRepository {
+	return c.archiveRepo
+}
+
+func (c *Client) EventBridgeRepo() datastore.EventBridgeRepository {
+	return c.eventBridgeRepo
+}
+
+func (c *Client) ExportJobRepo() datastore.ExportJobRepository {
+	return c.exportJobRepo
+}
+
+func (c *Client) EventTypeRepo() datastore.EventTypeRepository {
+	return c.eventTypeRepo
+}
+
+func (c *Client) GlobalSettingsRepo() datastore.GlobalSettingsRepository {
+	return c.settingsRepo
+}
+
+func (c *Client) Stats(ctx context.Context) (*datastore.DatastoreStats, error) {
+	// badger keeps every type in one keyspace, so the types are reported
+	// under the names of the mongo collections they're stored in there
+	collections := []struct {
+		name     string
+		dataType interface{}
+	}{
+		{"apiKeys", &datastore.APIKey{}},
+		{"applications", &datastore.Application{}},
+		{"archives", &datastore.ArchiveManifest{}},
+		{"auditLogs", &datastore.AuditLog{}},
+		{"event_bridge_rules", &datastore.EventBridgeRule{}},
+		{"event_types", &datastore.EventTypeDefinition{}},
+		{"eventdeliveries", &datastore.EventDelivery{}},
+		{"events", &datastore.Event{}},
+		{"export_jobs", &datastore.ExportJob{}},
+		{"global_settings", &datastore.GlobalSettings{}},
+		{"groups", &datastore.Group{}},
+	}
+
+	lsm, vlog := c.store.Badger().Size()
+	stats := &datastore.DatastoreStats{
+		Name:        c.GetName(),
+		StorageSize: lsm + vlog,
+		Collections: make([]datastore.CollectionStats, 0, len(collections)),
+	}
+
+	for _, coll := range collections {
+		count, err := c.store.Count(coll.dataType, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Collections = append(stats.Collections, datastore.CollectionStats{
+			Name:      coll.name,
+			Documents: int64(count),
+			Indexes:   []string{},
+		})
+	}
+
+	return stats, nil
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/timshannon/badgerhold/v4"
 )
@@ -17,17 +19,24 @@ func getDSN() string {
 	return os.Getenv("TEST_BADGER_DSN")
 }
 
-func getConfig() config.Configuration {
+// getConfig opens the store at TEST_BADGER_DSN, or in a fresh temporary
+// directory so tests never write to the working tree.
+func getConfig(t testing.TB) config.Configuration {
+	dsn := getDSN()
+	if dsn == "" {
+		dsn = t.TempDir()
+	}
+
 	return config.Configuration{
 		Database: config.DatabaseConfiguration{
 			Type: config.InMemoryDatabaseProvider,
-			Dsn:  getDSN(),
+			Dsn:  dsn,
 		},
 	}
 }
 
-func getDB(t *testing.T) (*badgerhold.Store, func()) {
-	db, err := New(getConfig())
+func getDB(t testing.TB) (*badgerhold.Store, func()) {
+	db, err := New(getConfig(t))
 
 	require.NoError(t, err)
 
@@ -39,3 +48,29 @@ func getDB(t *testing.T) (*badgerhold.Store, func()) {
 		require.NoError(t, db.Disconnect(context.Background()))
 	}
 }
+
+func TestClient_Stats(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), &datastore.Group{UID: uuid.NewString()}))
+	}
+
+	client := &Client{store: db}
+	stats, err := client.Stats(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "badger", stats.Name)
+	require.Nil(t, stats.Pool)
+	require.Nil(t, stats.ReplicationLag)
+
+	counts := map[string]int64{}
+	for _, c := range stats.Collections {
+		counts[c.Name] = c.Documents
+	}
+
+	require.Equal(t, int64(3), counts["groups"])
+	require.Equal(t, int64(0), counts["events"])
+}
@@ -0,0 +1,52 @@
+package badger
+
+import (
+	"sort"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// cursorBounds returns the bounds of the page of up to perPage items past
+// c among n items sorted newest first, key giving the created_at and uid of
+// the item at i. Without a cursor the page is the first one.
+func cursorBounds(n int, key func(i int) (primitive.DateTime, string), c *datastore.Cursor, perPage int) (lo, hi int) {
+	if c == nil {
+		return 0, min(n, perPage)
+	}
+
+	// the items after c form a suffix of a newest first list
+	start := sort.Search(n, func(i int) bool {
+		return c.After(key(i))
+	})
+
+	if !c.Backward {
+		return start, min(n, start+perPage)
+	}
+
+	// pages before the cursor end at the cursor, skipping the item it
+	// points at
+	end := start
+	if end > 0 {
+		createdAt, uid := key(end - 1)
+		if createdAt == c.CreatedAt && uid == c.UID {
+			end--
+		}
+	}
+
+	return max(0, end-perPage), end
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -2,11 +2,15 @@ package badger
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/dgraph-io/badger/v3"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
 	"github.com/timshannon/badgerhold/v4"
@@ -39,10 +43,80 @@ func (e *eventRepo) CountGroupMessages(ctx context.Context, gid string) (int64,
 	return int64(count), err
 }
 
+func (e *eventRepo) CountGroupMessagesByType(ctx context.Context, gid string) (map[string]int64, error) {
+	var events []datastore.Event
+	err := e.db.Find(&events, badgerhold.Where("AppMetadata.GroupID").Eq(gid))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, event := range events {
+		counts[string(event.EventType)]++
+	}
+
+	return counts, nil
+}
+
 func (e *eventRepo) DeleteGroupEvents(ctx context.Context, gid string) error {
 	return e.db.DeleteMatching(&datastore.Event{}, badgerhold.Where("AppMetadata.GroupID").Eq(gid))
 }
 
+func (e *eventRepo) DeleteEvents(ctx context.Context, uids []string) error {
+	s := make([]interface{}, len(uids))
+	for i, uid := range uids {
+		s[i] = uid
+	}
+
+	return e.db.DeleteMatching(&datastore.Event{}, badgerhold.Where("UID").In(s...))
+}
+
+func (e *eventRepo) UpdateEventsGroupID(ctx context.Context, appID, groupID string) error {
+	return e.db.UpdateMatching(&datastore.Event{}, badgerhold.Where("AppMetadata.UID").Eq(appID), func(record interface{}) error {
+		event, ok := record.(*datastore.Event)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted event, got %t", record)
+		}
+
+		event.AppMetadata.GroupID = groupID
+		event.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		return nil
+	})
+}
+
+func (e *eventRepo) MarkEventBridged(ctx context.Context, id string, bridgedAt time.Time) (bool, error) {
+	marked := false
+	err := e.db.Badger().Update(func(tx *badger.Txn) error {
+		var event datastore.Event
+		err := e.db.TxGet(tx, id, &event)
+		if err != nil {
+			return err
+		}
+
+		if event.BridgedAt != 0 {
+			return nil
+		}
+
+		event.BridgedAt = primitive.NewDateTimeFromTime(bridgedAt)
+		event.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		marked = true
+		return e.db.TxUpdate(tx, id, event)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return marked, nil
+}
+
+// EnsureRetentionIndex is a no-op, badger has no expiring indexes so the
+// events of an in-memory database are kept until it is dropped.
+func (e *eventRepo) EnsureRetentionIndex(ctx context.Context, groupID string, retentionDays int) error {
+	return nil
+}
+
 func (e *eventRepo) FindEventByID(ctx context.Context, eid string) (*datastore.Event, error) {
 	var event datastore.Event
 	err := e.db.Get(eid, &event)
@@ -50,9 +124,12 @@ func (e *eventRepo) FindEventByID(ctx context.Context, eid string) (*datastore.E
 	return &event, err
 }
 
+// LoadEventIntervals buckets events the way the mongo aggregation does, by
+// their formatted date and the period's component of it divided by
+// interval, so dashboards look the same on either datastore.
 func (e *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
 	eventsIntervals := make([]datastore.EventInterval, 0)
-	eventsIntervalsMap := make(map[string]int)
+	eventsIntervalsMap := make(map[datastore.EventIntervalData]uint64)
 
 	start := searchParams.CreatedAtStart
 	end := searchParams.CreatedAtEnd
@@ -65,21 +142,19 @@ func (e *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, sear
 		end = endDay.Unix()
 	}
 
-	timeDur, timeFormat, fErr := getFormat(period)
+	_, timeFormat, fErr := getFormat(period)
 	if fErr != nil {
 		return nil, fErr
 	}
 
-	// set end date to the end of the year so the loop would count it
+	// set end date to the end of the year so the year is counted
 	if period == datastore.Yearly {
 		endDay = endDay.Add(time.Hour * 23 * 365)
 		end = endDay.Unix()
 	}
 
-	for i := startDay; i.Unix() <= endDay.Unix(); i = i.AddDate(timeDur.year, timeDur.month, timeDur.day) {
-		if _, ok := eventsIntervalsMap[i.Format(timeFormat)]; !ok {
-			eventsIntervalsMap[i.Format(timeFormat)] = 0
-		}
+	if interval < 1 {
+		interval = 1
 	}
 
 	var events []datastore.Event
@@ -93,29 +168,29 @@ func (e *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, sear
 	}
 
 	for _, event := range events {
-		format := event.CreatedAt.Time().Format(timeFormat)
-
-		if _, ok := eventsIntervalsMap[format]; ok {
-			eventsIntervalsMap[format]++
-		}
-	}
-
-	for date, count := range eventsIntervalsMap {
-		interval, err := getInterval(date, timeFormat, period)
+		t := event.CreatedAt.Time().UTC()
 
+		component, err := getInterval(t, period)
 		if err != nil {
 			return nil, err
 		}
 
-		if count > 0 {
-			eventsIntervals = append(eventsIntervals, datastore.EventInterval{
-				Data:  datastore.EventIntervalData{Interval: interval, Time: date},
-				Count: uint64(count)})
-		}
+		eventsIntervalsMap[datastore.EventIntervalData{
+			Interval: component / int64(interval),
+			Time:     t.Format(timeFormat),
+		}]++
+	}
+
+	for data, count := range eventsIntervalsMap {
+		eventsIntervals = append(eventsIntervals, datastore.EventInterval{Data: data, Count: count})
 	}
 
 	sort.SliceStable(eventsIntervals, func(i, j int) bool {
-		return eventsIntervals[i].Data.Time < eventsIntervals[j].Data.Time
+		if eventsIntervals[i].Data.Time != eventsIntervals[j].Data.Time {
+			return eventsIntervals[i].Data.Time < eventsIntervals[j].Data.Time
+		}
+
+		return eventsIntervals[i].Data.Interval < eventsIntervals[j].Data.Interval
 	})
 
 	return eventsIntervals, nil
@@ -149,22 +224,19 @@ func getFormat(period datastore.Period) (TimeDuration, string, error) {
 	return timeDur, timeFormat, nil
 }
 
-func getInterval(date, timeFormat string, period datastore.Period) (int64, error) {
-	t, err := time.Parse(timeFormat, date)
-
-	if err != nil {
-		return 0, err
-	}
-
-	year, month, day := t.Date()
-	_, week := t.ISOWeek()
+// getInterval returns the component of t mongo groups events of period
+// by: the day of the year, the week, the month or the year.
+func getInterval(t time.Time, period datastore.Period) (int64, error) {
+	year, month, _ := t.Date()
 
 	var interval int
 	switch period {
 	case datastore.Daily:
-		interval = day
+		interval = t.YearDay()
 	case datastore.Weekly:
-		interval = week
+		// mongo's $week, weeks start on sunday and the days before the
+		// first sunday of the year are in week 0
+		interval = (t.YearDay() - 1 + 7 - int(t.Weekday())) / 7
 	case datastore.Monthly:
 		interval = int(month)
 	case datastore.Yearly:
@@ -177,17 +249,79 @@ func getInterval(date, timeFormat string, period datastore.Period) (int64, error
 }
 
 func (e *eventRepo) LoadEventsPaged(ctx context.Context, groupId string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	return e.loadEventsPaged(eventsFilter(groupId, appId, searchParams), pageable)
+}
+
+func (e *eventRepo) LoadEventsCursored(ctx context.Context, groupId string, appId string, query string, metadata map[string]string, searchParams datastore.SearchParams, cursor *datastore.Cursor, perPage int) ([]datastore.Event, error) {
+	events := make([]datastore.Event, 0)
+
+	f := eventsFilter(groupId, appId, searchParams)
+	f.query, f.hasQueryFilter = query, !util.IsStringEmpty(query)
+	f.metadata, f.hasMetadataFilter = metadata, len(metadata) > 0
+
+	err := e.db.Find(&events, e.generateQuery(f))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].CreatedAt != events[j].CreatedAt {
+			return events[i].CreatedAt > events[j].CreatedAt
+		}
+		return events[i].UID > events[j].UID
+	})
+
+	lo, hi := cursorBounds(len(events), func(i int) (primitive.DateTime, string) {
+		return events[i].CreatedAt, events[i].UID
+	}, cursor, perPage)
+
+	return events[lo:hi], nil
+}
+
+func (e *eventRepo) CountEvents(ctx context.Context, groupId string, appId string, searchParams datastore.SearchParams) (int64, error) {
+	count, err := e.db.Count(&datastore.Event{}, e.generateQuery(eventsFilter(groupId, appId, searchParams)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func eventsFilter(groupId string, appId string, searchParams datastore.SearchParams) *filter {
+	return &filter{
+		appID:        appId,
+		groupID:      groupId,
+		searchParams: searchParams,
+
+		hasAppFilter:       !util.IsStringEmpty(appId),
+		hasGroupFilter:     !util.IsStringEmpty(groupId),
+		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+		hasStartDateFilter: searchParams.CreatedAtStart > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
+	}
+}
+
+func (e *eventRepo) SearchEventsPaged(ctx context.Context, groupId string, appId string, query string, metadata map[string]string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
 	f := &filter{
 		appID:        appId,
 		groupID:      groupId,
+		query:        query,
+		metadata:     metadata,
 		searchParams: searchParams,
 
 		hasAppFilter:       !util.IsStringEmpty(appId),
 		hasGroupFilter:     !util.IsStringEmpty(groupId),
+		hasQueryFilter:     !util.IsStringEmpty(query),
+		hasMetadataFilter:  len(metadata) > 0,
 		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
 		hasStartDateFilter: searchParams.CreatedAtStart > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
 	}
 
+	return e.loadEventsPaged(f, pageable)
+}
+
+func (e *eventRepo) loadEventsPaged(f *filter, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
 	if pageable.Page < 1 {
 		pageable.Page = 1
 	}
@@ -250,6 +384,59 @@ func (e *eventRepo) generateQuery(f *filter) *badgerhold.Query {
 		qFunc = qFunc("CreatedAt").Le(createdEnd).And
 	}
 
+	if f.hasQueryFilter {
+		query := strings.ToLower(f.query)
+		qFunc = qFunc("Data").MatchFunc(func(ra *badgerhold.RecordAccess) (bool, error) {
+			data, ok := ra.Field().(json.RawMessage)
+			if !ok {
+				return false, nil
+			}
+			return strings.Contains(strings.ToLower(string(data)), query), nil
+		}).And
+	}
+
+	if f.hasMetadataFilter {
+		qFunc = qFunc("Metadata").MatchFunc(func(ra *badgerhold.RecordAccess) (bool, error) {
+			metadata, ok := ra.Field().(map[string]string)
+			if !ok {
+				return false, nil
+			}
+
+			for k, v := range f.metadata {
+				if mv, ok := metadata[k]; !ok || mv != v {
+					return false, nil
+				}
+			}
+			return true, nil
+		}).And
+	}
+
+	if f.hasTagFilter {
+		qFunc = qFunc("Tags").Contains(f.searchParams.Tag).And
+	}
+
 	// this is a play-safe workaround, uid will never be empty so use it to get the query object
 	return qFunc("UID").Ne("")
 }
+
+func (e *eventRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var events []datastore.Event
+	err := e.db.Find(&events, deletedBeforeQuery(deletedBefore, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range events {
+		err = e.db.DeleteMatching(&datastore.EventDelivery{}, badgerhold.Where("EventMetadata.UID").Eq(events[i].UID))
+		if err != nil {
+			return int64(i), err
+		}
+
+		err = e.db.Delete(events[i].UID, &datastore.Event{})
+		if err != nil {
+			return int64(i), err
+		}
+	}
+
+	return int64(len(events)), nil
+}
@@ -0,0 +1,58 @@
+package badger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+type eventBridgeRepo struct {
+	db *badgerhold.Store
+}
+
+func NewEventBridgeRepo(db *badgerhold.Store) datastore.EventBridgeRepository {
+	return &eventBridgeRepo{db: db}
+}
+
+func (e *eventBridgeRepo) CreateEventBridgeRule(ctx context.Context, rule *datastore.EventBridgeRule) error {
+	if util.IsStringEmpty(rule.UID) {
+		rule.UID = uuid.New().String()
+	}
+
+	return e.db.Insert(rule.UID, rule)
+}
+
+func (e *eventBridgeRepo) FindEventBridgeRuleByID(ctx context.Context, uid string) (*datastore.EventBridgeRule, error) {
+	var rule datastore.EventBridgeRule
+
+	err := e.db.Get(uid, &rule)
+	if err != nil && errors.Is(err, badgerhold.ErrNotFound) {
+		return &rule, datastore.ErrEventBridgeRuleNotFound
+	}
+
+	return &rule, err
+}
+
+func (e *eventBridgeRepo) LoadEventBridgeRules(ctx context.Context, sourceGroupID string) ([]datastore.EventBridgeRule, error) {
+	var rules = make([]datastore.EventBridgeRule, 0)
+
+	err := e.db.Find(&rules, badgerhold.Where("SourceGroupID").Eq(sourceGroupID).SortBy("CreatedAt"))
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (e *eventBridgeRepo) DeleteEventBridgeRule(ctx context.Context, uid string) error {
+	err := e.db.Delete(uid, &datastore.EventBridgeRule{})
+	if errors.Is(err, badgerhold.ErrNotFound) {
+		return datastore.ErrEventBridgeRuleNotFound
+	}
+
+	return err
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
+	"github.com/dgraph-io/badger/v3"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
 	"github.com/timshannon/badgerhold/v4"
@@ -24,20 +26,41 @@ func (e *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, delivery *d
 	return e.db.Upsert(delivery.UID, delivery)
 }
 
-func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+func (e *eventDeliveryRepo) CreateEventDeliveries(ctx context.Context, deliveries []*datastore.EventDelivery) error {
+	var failed []*datastore.EventDelivery
+	var lastErr error
+
+	for _, d := range deliveries {
+		if err := e.db.Upsert(d.UID, d); err != nil {
+			failed = append(failed, d)
+			lastErr = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return &datastore.DeliveriesWriteError{Failed: failed, Err: lastErr}
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
 	f := &filter{
 		groupID:      groupID,
 		appID:        appID,
 		eventID:      eventID,
+		endpointID:   endpointID,
 		status:       status,
 		searchParams: searchParams,
 
 		hasAppFilter:       !util.IsStringEmpty(appID),
 		hasGroupFilter:     !util.IsStringEmpty(groupID),
 		hasEventFilter:     !util.IsStringEmpty(eventID),
+		hasEndpointFilter:  !util.IsStringEmpty(endpointID),
 		hasStatusFilter:    len(status) > 0,
 		hasStartDateFilter: searchParams.CreatedAtStart > 0,
 		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
 	}
 
 	var count uint64
@@ -49,6 +72,49 @@ func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID,
 	return int64(count), nil
 }
 
+func (db *eventDeliveryRepo) LoadDeliveryStatistics(ctx context.Context, groupID, appID string, searchParams datastore.SearchParams) (*datastore.DeliveryStatistics, error) {
+	f := &filter{
+		groupID:      groupID,
+		appID:        appID,
+		searchParams: searchParams,
+
+		hasAppFilter:       !util.IsStringEmpty(appID),
+		hasGroupFilter:     !util.IsStringEmpty(groupID),
+		hasStartDateFilter: searchParams.CreatedAtStart > 0,
+		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
+	}
+
+	var deliveries []datastore.EventDelivery
+	err := db.db.Find(&deliveries, db.generateQuery(f))
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := &datastore.DeliveryStatistics{}
+	var latency int64
+
+	for _, d := range deliveries {
+		if len(d.DeliveryAttempts) > 0 {
+			statistics.Attempted++
+		}
+
+		switch d.Status {
+		case datastore.SuccessEventStatus:
+			statistics.Successful++
+			latency += int64(d.UpdatedAt - d.CreatedAt)
+		case datastore.FailureEventStatus:
+			statistics.Failed++
+		}
+	}
+
+	if statistics.Successful > 0 {
+		statistics.AverageLatencyMs = latency / statistics.Successful
+	}
+
+	return statistics, nil
+}
+
 func (e *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, uid string) (*datastore.EventDelivery, error) {
 	var delivery datastore.EventDelivery
 	err := e.db.Get(uid, &delivery)
@@ -85,6 +151,7 @@ func (e *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status
 		hasStatusFilter:    len(status) > 0,
 		hasStartDateFilter: searchParams.CreatedAtStart > 0,
 		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
 	}
 
 	q := e.generateQuery(f)
@@ -95,6 +162,55 @@ func (e *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status
 	return int64(count), err
 }
 
+func (e *eventDeliveryRepo) CountPendingDeliveriesByGroup(ctx context.Context, groupID string) (int64, error) {
+	s := make([]interface{}, len(datastore.PendingEventStatuses))
+	for i, status := range datastore.PendingEventStatuses {
+		s[i] = status
+	}
+
+	count, err := e.db.Count(&datastore.EventDelivery{}, badgerhold.Where("AppMetadata.GroupID").Eq(groupID).And("Status").In(s...))
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func (e *eventDeliveryRepo) FindGroupsWithHighFailureRate(ctx context.Context, threshold float64) ([]string, error) {
+	since := primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))
+
+	var deliveries []datastore.EventDelivery
+	err := e.db.Find(&deliveries, badgerhold.Where("CreatedAt").Ge(since).
+		And("Status").In(datastore.SuccessEventStatus, datastore.FailureEventStatus))
+	if err != nil {
+		return nil, err
+	}
+
+	total := make(map[string]int)
+	failed := make(map[string]int)
+	ids := make([]string, 0)
+	for _, d := range deliveries {
+		groupID := d.AppMetadata.GroupID
+		if total[groupID] == 0 {
+			ids = append(ids, groupID)
+		}
+
+		total[groupID]++
+		if d.Status == datastore.FailureEventStatus {
+			failed[groupID]++
+		}
+	}
+
+	troubled := ids[:0]
+	for _, id := range ids {
+		if float64(failed[id])/float64(total[id]) >= threshold {
+			troubled = append(troubled, id)
+		}
+	}
+
+	return troubled, nil
+}
+
 func (e *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, delivery datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
 	delivery.Status = status
 	delivery.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
@@ -102,6 +218,36 @@ func (e *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, del
 	return e.db.Update(delivery.UID, delivery)
 }
 
+func (e *eventDeliveryRepo) TransitionStatus(ctx context.Context, id string, from, to datastore.EventDeliveryStatus) (bool, error) {
+	if !from.CanTransitionTo(to) {
+		return false, datastore.ErrIllegalStatusTransition
+	}
+
+	moved := false
+	err := e.db.Badger().Update(func(tx *badger.Txn) error {
+		var delivery datastore.EventDelivery
+		err := e.db.TxGet(tx, id, &delivery)
+		if err != nil {
+			return err
+		}
+
+		if delivery.Status != from {
+			return nil
+		}
+
+		delivery.Status = to
+		delivery.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		moved = true
+		return e.db.TxUpdate(tx, id, delivery)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return moved, nil
+}
+
 func (e *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, uids []string, status datastore.EventDeliveryStatus) error {
 	s := make([]interface{}, len(uids))
 	for i, uid := range uids {
@@ -124,28 +270,119 @@ func (e *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, u
 	return nil
 }
 
-func (e *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context, delivery datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
-	delivery.DeliveryAttempts = append(delivery.DeliveryAttempts, attempt)
+func (e *eventDeliveryRepo) RetryAllFailed(ctx context.Context, groupID string) (int64, error) {
+	var count int64
+	now := primitive.NewDateTimeFromTime(time.Now())
 
-	return e.db.Update(delivery.UID, delivery)
+	query := badgerhold.Where("AppMetadata.GroupID").Eq(groupID).And("Status").Eq(datastore.FailureEventStatus)
+	err := e.db.UpdateMatching(&datastore.EventDelivery{}, query, func(record interface{}) error {
+		delivery, ok := record.(*datastore.EventDelivery)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted eventDelivery, got %t", record)
+		}
+
+		delivery.Status = datastore.ScheduledEventStatus
+		if delivery.Metadata != nil {
+			delivery.Metadata.NextSendTime = now
+		}
+		delivery.UpdatedAt = now
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
 }
 
-func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
-	f := &filter{
-		groupID:      groupID,
-		appID:        appID,
-		eventID:      eventID,
-		status:       status,
-		searchParams: searchParams,
+func (e *eventDeliveryRepo) ForceCompleteStuckDeliveries(ctx context.Context, olderThan time.Duration) (int64, error) {
+	var count int64
+	now := time.Now()
 
-		hasAppFilter:       !util.IsStringEmpty(appID),
-		hasGroupFilter:     !util.IsStringEmpty(groupID),
-		hasEventFilter:     !util.IsStringEmpty(eventID),
-		hasStatusFilter:    len(status) > 0,
-		hasStartDateFilter: searchParams.CreatedAtStart > 0,
-		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+	query := badgerhold.Where("Status").Eq(datastore.ProcessingEventStatus).
+		And("UpdatedAt").Lt(primitive.NewDateTimeFromTime(now.Add(-olderThan)))
+	err := e.db.UpdateMatching(&datastore.EventDelivery{}, query, func(record interface{}) error {
+		delivery, ok := record.(*datastore.EventDelivery)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted eventDelivery, got %t", record)
+		}
+
+		delivery.Status = datastore.FailureEventStatus
+		delivery.Description = datastore.StuckDeliveryDescription
+		delivery.UpdatedAt = primitive.NewDateTimeFromTime(now)
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (e *eventDeliveryRepo) UpdateEventDeliveriesGroupID(ctx context.Context, appID, groupID string, excluded []datastore.EventDeliveryStatus) error {
+	query := badgerhold.Where("AppMetadata.UID").Eq(appID)
+	for _, status := range excluded {
+		query = query.And("Status").Ne(status)
 	}
 
+	return e.db.UpdateMatching(&datastore.EventDelivery{}, query, func(record interface{}) error {
+		delivery, ok := record.(*datastore.EventDelivery)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted eventDelivery, got %t", record)
+		}
+
+		delivery.AppMetadata.GroupID = groupID
+		delivery.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		return nil
+	})
+}
+
+func (e *eventDeliveryRepo) DeleteEventDeliveries(ctx context.Context, uids []string) error {
+	s := make([]interface{}, len(uids))
+	for i, uid := range uids {
+		s[i] = uid
+	}
+
+	return e.db.DeleteMatching(&datastore.EventDelivery{}, badgerhold.Where("UID").In(s...))
+}
+
+func (e *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context, delivery datastore.EventDelivery, attempt datastore.DeliveryAttempt) (bool, error) {
+	updated := false
+	err := e.db.Badger().Update(func(tx *badger.Txn) error {
+		var current datastore.EventDelivery
+		err := e.db.TxGet(tx, delivery.UID, &current)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != datastore.ProcessingEventStatus {
+			return nil
+		}
+
+		current.Status = delivery.Status
+		current.Description = delivery.Description
+		current.Metadata = delivery.Metadata
+		current.DeliveryAttempts = append(current.DeliveryAttempts, attempt)
+		current.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		updated = true
+		return e.db.TxUpdate(tx, delivery.UID, current)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return updated, nil
+}
+
+func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	f := deliveriesFilter(groupID, appID, eventID, endpointID, status, searchParams)
+
 	if pageable.Page < 1 {
 		pageable.Page = 1
 	}
@@ -170,6 +407,10 @@ func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupI
 		return nil, datastore.PaginationData{}, err
 	}
 
+	if pageable.SkipTotal {
+		return deliveries, datastore.UncountedPaginationData(int64(pageable.Page), int64(pageable.PerPage), len(deliveries)), nil
+	}
+
 	total, err := e.db.Count(&datastore.EventDelivery{}, e.generateQuery(f))
 	if err != nil {
 		return nil, datastore.PaginationData{}, err
@@ -187,17 +428,94 @@ func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupI
 	return deliveries, pg, err
 }
 
+func (e *eventDeliveryRepo) LoadEventDeliveriesCursored(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, cursor *datastore.Cursor, perPage int) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := e.db.Find(&deliveries, e.generateQuery(deliveriesFilter(groupID, appID, eventID, endpointID, status, searchParams)))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].CreatedAt != deliveries[j].CreatedAt {
+			return deliveries[i].CreatedAt > deliveries[j].CreatedAt
+		}
+		return deliveries[i].UID > deliveries[j].UID
+	})
+
+	lo, hi := cursorBounds(len(deliveries), func(i int) (primitive.DateTime, string) {
+		return deliveries[i].CreatedAt, deliveries[i].UID
+	}, cursor, perPage)
+
+	return deliveries[lo:hi], nil
+}
+
+func deliveriesFilter(groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) *filter {
+	return &filter{
+		groupID:      groupID,
+		appID:        appID,
+		eventID:      eventID,
+		endpointID:   endpointID,
+		status:       status,
+		searchParams: searchParams,
+
+		hasAppFilter:       !util.IsStringEmpty(appID),
+		hasGroupFilter:     !util.IsStringEmpty(groupID),
+		hasEventFilter:     !util.IsStringEmpty(eventID),
+		hasEndpointFilter:  !util.IsStringEmpty(endpointID),
+		hasStatusFilter:    len(status) > 0,
+		hasStartDateFilter: searchParams.CreatedAtStart > 0,
+		hasEndDateFilter:   searchParams.CreatedAtEnd > 0,
+		hasTagFilter:       !util.IsStringEmpty(searchParams.Tag),
+	}
+}
+
+func (e *eventDeliveryRepo) FindDeliveriesByAppID(ctx context.Context, appID string, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	return e.LoadEventDeliveriesPaged(ctx, "", appID, "", "", nil, datastore.SearchParams{}, pageable)
+}
+
+func (e *eventDeliveryRepo) FindRecentAttemptsByApp(ctx context.Context, appID string, n int) ([]datastore.DeliveryAttempt, error) {
+	var deliveries []datastore.EventDelivery
+
+	err := e.db.Find(&deliveries, badgerhold.Where("AppMetadata.UID").Eq(appID))
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]datastore.DeliveryAttempt, 0)
+	for _, delivery := range deliveries {
+		attempts = append(attempts, delivery.DeliveryAttempts...)
+	}
+
+	sort.SliceStable(attempts, func(i, j int) bool {
+		return attempts[i].CreatedAt > attempts[j].CreatedAt
+	})
+
+	if len(attempts) > n {
+		attempts = attempts[:n]
+	}
+
+	return attempts, nil
+}
+
 type filter struct {
 	groupID      string
 	appID        string
 	eventID      string
+	endpointID   string
 	status       []datastore.EventDeliveryStatus
+	query        string
+	metadata     map[string]string
 	searchParams datastore.SearchParams
 
 	hasAppFilter       bool
 	hasGroupFilter     bool
 	hasEventFilter     bool
+	hasEndpointFilter  bool
 	hasStatusFilter    bool
+	hasQueryFilter     bool
+	hasMetadataFilter  bool
+	hasTagFilter       bool
 	hasStartDateFilter bool
 	hasEndDateFilter   bool
 }
@@ -217,6 +535,10 @@ func (e *eventDeliveryRepo) generateQuery(f *filter) *badgerhold.Query {
 		qFunc = qFunc("EventMetadata.UID").Eq(f.eventID).And
 	}
 
+	if f.hasEndpointFilter {
+		qFunc = qFunc("EndpointMetadata.UID").Eq(f.endpointID).And
+	}
+
 	if f.hasStatusFilter {
 		qFunc = qFunc("Status").In(badgerhold.Slice(f.status)...).And
 	}
@@ -231,6 +553,10 @@ func (e *eventDeliveryRepo) generateQuery(f *filter) *badgerhold.Query {
 		qFunc = qFunc("CreatedAt").Le(createdEnd).And
 	}
 
+	if f.hasTagFilter {
+		qFunc = qFunc("EventMetadata.Tags").Contains(f.searchParams.Tag).And
+	}
+
 	// this is a play-safe workaround, uid will never be empty so use it to get the query object
 	return qFunc("UID").Ne("")
 }
@@ -227,6 +227,60 @@ func Test_eventDeliveryRepo_FindEventDeliveriesByEventID(t *testing.T) {
 	}
 }
 
+func Test_eventDeliveryRepo_UpdateEventDeliveriesGroupID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	e := NewEventDeliveryRepository(db)
+
+	appID := uuid.NewString()
+	statuses := []datastore.EventDeliveryStatus{
+		datastore.ScheduledEventStatus,
+		datastore.ProcessingEventStatus,
+		datastore.RetryEventStatus,
+		datastore.SuccessEventStatus,
+	}
+
+	uids := make(map[datastore.EventDeliveryStatus]string)
+	for _, status := range statuses {
+		delivery := &datastore.EventDelivery{
+			UID:         uuid.NewString(),
+			AppMetadata: &datastore.AppMetadata{UID: appID, GroupID: "group-a"},
+			Status:      status,
+		}
+		require.NoError(t, e.CreateEventDelivery(context.Background(), delivery))
+
+		uids[status] = delivery.UID
+	}
+
+	other := &datastore.EventDelivery{
+		UID:         uuid.NewString(),
+		AppMetadata: &datastore.AppMetadata{UID: uuid.NewString(), GroupID: "group-a"},
+		Status:      datastore.ScheduledEventStatus,
+	}
+	require.NoError(t, e.CreateEventDelivery(context.Background(), other))
+
+	err := e.UpdateEventDeliveriesGroupID(context.Background(), appID, "group-b", []datastore.EventDeliveryStatus{datastore.ProcessingEventStatus})
+	require.NoError(t, err)
+
+	for status, uid := range uids {
+		delivery, err := e.FindEventDeliveryByID(context.Background(), uid)
+		require.NoError(t, err)
+
+		// in flight deliveries stay with the old group
+		want := "group-b"
+		if status == datastore.ProcessingEventStatus {
+			want = "group-a"
+		}
+
+		require.Equal(t, want, delivery.AppMetadata.GroupID, status)
+	}
+
+	delivery, err := e.FindEventDeliveryByID(context.Background(), other.UID)
+	require.NoError(t, err)
+	require.Equal(t, "group-a", delivery.AppMetadata.GroupID)
+}
+
 func Test_eventDeliveryRepo_UpdateStatusOfEventDelivery(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
@@ -333,6 +387,7 @@ func Test_eventDeliveryRepo_LoadEventDeliveriesPaged(t *testing.T) {
 		groupID      string
 		appID        string
 		eventID      string
+		endpointID   string
 		status       []datastore.EventDeliveryStatus
 		searchParams datastore.SearchParams
 		pageable     datastore.Pageable
@@ -988,6 +1043,95 @@ func Test_eventDeliveryRepo_LoadEventDeliveriesPaged(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_filter_event_deliveries_by_endpoint_id_and_statuses_successfully",
+			args: args{
+				endpointID: "endpoint-1",
+				status:     []datastore.EventDeliveryStatus{datastore.FailureEventStatus, datastore.RetryEventStatus},
+				pageable: datastore.Pageable{
+					Page:    1,
+					PerPage: 10,
+					Sort:    -1,
+				},
+			},
+			eventDeliveries: []datastore.EventDelivery{
+				{
+					UID:              uuid.NewString(),
+					EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-1"},
+					Status:           datastore.FailureEventStatus,
+					CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				},
+				{
+					UID:              uuid.NewString(),
+					EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-1"},
+					Status:           datastore.RetryEventStatus,
+					CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				},
+				{
+					UID:              uuid.NewString(),
+					EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-1"},
+					Status:           datastore.SuccessEventStatus,
+					CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				},
+				{
+					UID:              uuid.NewString(),
+					EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-2"},
+					Status:           datastore.FailureEventStatus,
+					CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+			wantCount: 2,
+			wantPaginationData: datastore.PaginationData{
+				Total:     2,
+				Page:      1,
+				PerPage:   10,
+				Prev:      0,
+				Next:      2,
+				TotalPage: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_filter_event_deliveries_by_tag_successfully",
+			args: args{
+				searchParams: datastore.SearchParams{Tag: "backfill-2024"},
+				pageable: datastore.Pageable{
+					Page:    1,
+					PerPage: 10,
+					Sort:    -1,
+				},
+			},
+			eventDeliveries: []datastore.EventDelivery{
+				{
+					UID:           uuid.NewString(),
+					EventMetadata: &datastore.EventMetadata{UID: "event-1", Tags: []string{"replay", "backfill-2024"}},
+					Status:        datastore.FailureEventStatus,
+					CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+				},
+				{
+					UID:           uuid.NewString(),
+					EventMetadata: &datastore.EventMetadata{UID: "event-2", Tags: []string{"replay"}},
+					Status:        datastore.FailureEventStatus,
+					CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+				},
+				{
+					UID:           uuid.NewString(),
+					EventMetadata: &datastore.EventMetadata{UID: "event-3"},
+					Status:        datastore.FailureEventStatus,
+					CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+			wantCount: 1,
+			wantPaginationData: datastore.PaginationData{
+				Total:     1,
+				Page:      1,
+				PerPage:   10,
+				Prev:      0,
+				Next:      2,
+				TotalPage: 1,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1002,7 +1146,7 @@ func Test_eventDeliveryRepo_LoadEventDeliveriesPaged(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			eventDeliveries, paginationData, err := e.LoadEventDeliveriesPaged(ctx, tt.args.groupID, tt.args.appID, tt.args.eventID, tt.args.status, tt.args.searchParams, tt.args.pageable)
+			eventDeliveries, paginationData, err := e.LoadEventDeliveriesPaged(ctx, tt.args.groupID, tt.args.appID, tt.args.eventID, tt.args.endpointID, tt.args.status, tt.args.searchParams, tt.args.pageable)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -1014,3 +1158,608 @@ func Test_eventDeliveryRepo_LoadEventDeliveriesPaged(t *testing.T) {
 		})
 	}
 }
+
+func Test_eventDeliveryRepo_LoadDeliveryStatistics(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	now := time.Now()
+	deliveries := []struct {
+		appID    string
+		status   datastore.EventDeliveryStatus
+		attempts int
+		latency  time.Duration
+	}{
+		{appID: "app-1", status: datastore.SuccessEventStatus, attempts: 1, latency: 100 * time.Millisecond},
+		{appID: "app-1", status: datastore.SuccessEventStatus, attempts: 2, latency: 300 * time.Millisecond},
+		{appID: "app-1", status: datastore.FailureEventStatus, attempts: 3},
+		{appID: "app-1", status: datastore.ScheduledEventStatus},
+		{appID: "app-2", status: datastore.SuccessEventStatus, attempts: 1, latency: time.Second},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:              uuid.NewString(),
+			Status:           d.status,
+			DeliveryAttempts: make([]datastore.DeliveryAttempt, d.attempts),
+			AppMetadata:      &datastore.AppMetadata{UID: d.appID, GroupID: "group-1"},
+			CreatedAt:        primitive.NewDateTimeFromTime(now),
+			UpdatedAt:        primitive.NewDateTimeFromTime(now.Add(d.latency)),
+			DocumentStatus:   datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	statistics, err := edRepo.LoadDeliveryStatistics(context.Background(), "group-1", "app-1", datastore.SearchParams{})
+	require.NoError(t, err)
+	require.Equal(t, &datastore.DeliveryStatistics{
+		Attempted:        3,
+		Successful:       2,
+		Failed:           1,
+		AverageLatencyMs: 200,
+	}, statistics)
+}
+
+func Test_eventDeliveryRepo_FindGroupsWithHighFailureRate(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	now := time.Now()
+	deliveries := []struct {
+		groupID   string
+		status    datastore.EventDeliveryStatus
+		createdAt time.Time
+	}{
+		{groupID: "group-1", status: datastore.FailureEventStatus, createdAt: now},
+		{groupID: "group-1", status: datastore.FailureEventStatus, createdAt: now},
+		{groupID: "group-1", status: datastore.SuccessEventStatus, createdAt: now},
+		{groupID: "group-2", status: datastore.FailureEventStatus, createdAt: now},
+		{groupID: "group-2", status: datastore.SuccessEventStatus, createdAt: now},
+		{groupID: "group-2", status: datastore.SuccessEventStatus, createdAt: now},
+		{groupID: "group-2", status: datastore.ScheduledEventStatus, createdAt: now},
+		// failures from before the last hour don't count
+		{groupID: "group-3", status: datastore.FailureEventStatus, createdAt: now.Add(-2 * time.Hour)},
+		{groupID: "group-3", status: datastore.SuccessEventStatus, createdAt: now},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         d.status,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: d.groupID},
+			CreatedAt:      primitive.NewDateTimeFromTime(d.createdAt),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	groupIDs, err := edRepo.FindGroupsWithHighFailureRate(context.Background(), 0.5)
+	require.NoError(t, err)
+	require.Equal(t, []string{"group-1"}, groupIDs)
+}
+
+func TestEventDeliveryRepository_FindDeliveriesByAppID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			EventMetadata:  &datastore.EventMetadata{UID: uuid.NewString()},
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			CreatedAt:      primitive.NewDateTimeFromTime(now.Add(time.Duration(i) * time.Second)),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+		UID:            uuid.NewString(),
+		EventMetadata:  &datastore.EventMetadata{UID: uuid.NewString()},
+		AppMetadata:    &datastore.AppMetadata{UID: "app-2", GroupID: "group-1"},
+		CreatedAt:      primitive.NewDateTimeFromTime(now),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}))
+
+	tests := []struct {
+		name           string
+		pageable       datastore.Pageable
+		wantCount      int
+		paginationData datastore.PaginationData
+	}{
+		{
+			name:           "first page",
+			pageable:       datastore.Pageable{Page: 1, PerPage: 2, Sort: -1},
+			wantCount:      2,
+			paginationData: datastore.PaginationData{Total: 5, Page: 1, PerPage: 2, Prev: 0, Next: 2, TotalPage: 3},
+		},
+		{
+			name:           "last page",
+			pageable:       datastore.Pageable{Page: 3, PerPage: 2, Sort: -1},
+			wantCount:      1,
+			paginationData: datastore.PaginationData{Total: 5, Page: 3, PerPage: 2, Prev: 2, Next: 4, TotalPage: 3},
+		},
+		{
+			name:           "past the last page",
+			pageable:       datastore.Pageable{Page: 4, PerPage: 2, Sort: -1},
+			wantCount:      0,
+			paginationData: datastore.PaginationData{Total: 5, Page: 4, PerPage: 2, Prev: 3, Next: 5, TotalPage: 3},
+		},
+		{
+			name:           "single page",
+			pageable:       datastore.Pageable{Page: 1, PerPage: 5, Sort: -1},
+			wantCount:      5,
+			paginationData: datastore.PaginationData{Total: 5, Page: 1, PerPage: 5, Prev: 0, Next: 2, TotalPage: 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deliveries, data, err := edRepo.FindDeliveriesByAppID(context.Background(), "app-1", tc.pageable)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantCount, len(deliveries))
+			require.Equal(t, tc.paginationData, data)
+
+			for _, d := range deliveries {
+				require.Equal(t, "app-1", d.AppMetadata.UID)
+			}
+		})
+	}
+
+	deliveries, _, err := edRepo.FindDeliveriesByAppID(context.Background(), "app-1", datastore.Pageable{Page: 1, PerPage: 5, Sort: -1})
+	require.NoError(t, err)
+
+	for i := 1; i < len(deliveries); i++ {
+		require.True(t, deliveries[i-1].CreatedAt >= deliveries[i].CreatedAt)
+	}
+}
+
+func Test_eventDeliveryRepo_FindRecentAttemptsByApp(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	now := time.Now()
+	attemptAt := func(minutes int, status bool) datastore.DeliveryAttempt {
+		return datastore.DeliveryAttempt{
+			UID:       uuid.NewString(),
+			Status:    status,
+			CreatedAt: primitive.NewDateTimeFromTime(now.Add(time.Duration(minutes) * time.Minute)),
+		}
+	}
+
+	deliveries := []struct {
+		appID    string
+		attempts []datastore.DeliveryAttempt
+	}{
+		{appID: "app-1", attempts: []datastore.DeliveryAttempt{attemptAt(1, false), attemptAt(4, true)}},
+		{appID: "app-1", attempts: []datastore.DeliveryAttempt{attemptAt(2, false), attemptAt(3, false)}},
+		{appID: "app-2", attempts: []datastore.DeliveryAttempt{attemptAt(5, true)}},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:              uuid.NewString(),
+			DeliveryAttempts: d.attempts,
+			AppMetadata:      &datastore.AppMetadata{UID: d.appID, GroupID: "group-1"},
+			DocumentStatus:   datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	attempts, err := edRepo.FindRecentAttemptsByApp(context.Background(), "app-1", 3)
+	require.NoError(t, err)
+
+	require.Len(t, attempts, 3)
+	require.Equal(t, deliveries[0].attempts[1].UID, attempts[0].UID)
+	require.Equal(t, deliveries[1].attempts[1].UID, attempts[1].UID)
+	require.Equal(t, deliveries[1].attempts[0].UID, attempts[2].UID)
+}
+
+func Test_eventDeliveryRepo_CountPendingDeliveriesByGroup(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	deliveries := []struct {
+		groupID string
+		status  datastore.EventDeliveryStatus
+	}{
+		{groupID: "group-1", status: datastore.ScheduledEventStatus},
+		{groupID: "group-1", status: datastore.ProcessingEventStatus},
+		{groupID: "group-1", status: datastore.RetryEventStatus},
+		{groupID: "group-1", status: datastore.PostponedEventStatus},
+		{groupID: "group-1", status: datastore.HeldEventStatus},
+		{groupID: "group-1", status: datastore.SuccessEventStatus},
+		{groupID: "group-1", status: datastore.FailureEventStatus},
+		{groupID: "group-2", status: datastore.ScheduledEventStatus},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         d.status,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: d.groupID},
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	count, err := edRepo.CountPendingDeliveriesByGroup(context.Background(), "group-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count)
+
+	count, err = edRepo.CountPendingDeliveriesByGroup(context.Background(), "group-3")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+func TestEventDeliveryRepository_RetryAllFailed_UpdatesOnlyFailedStatus(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	deliveries := []struct {
+		uid     string
+		groupID string
+		status  datastore.EventDeliveryStatus
+		want    datastore.EventDeliveryStatus
+	}{
+		{uid: uuid.NewString(), groupID: "group-1", status: datastore.FailureEventStatus, want: datastore.ScheduledEventStatus},
+		{uid: uuid.NewString(), groupID: "group-1", status: datastore.FailureEventStatus, want: datastore.ScheduledEventStatus},
+		{uid: uuid.NewString(), groupID: "group-1", status: datastore.SuccessEventStatus, want: datastore.SuccessEventStatus},
+		{uid: uuid.NewString(), groupID: "group-1", status: datastore.DiscardedEventStatus, want: datastore.DiscardedEventStatus},
+		{uid: uuid.NewString(), groupID: "group-1", status: datastore.RetryEventStatus, want: datastore.RetryEventStatus},
+		{uid: uuid.NewString(), groupID: "group-2", status: datastore.FailureEventStatus, want: datastore.FailureEventStatus},
+	}
+
+	past := primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            d.uid,
+			Status:         d.status,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: d.groupID},
+			Metadata:       &datastore.Metadata{NextSendTime: past},
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	count, err := edRepo.RetryAllFailed(context.Background(), "group-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	for _, d := range deliveries {
+		delivery, err := edRepo.FindEventDeliveryByID(context.Background(), d.uid)
+		require.NoError(t, err)
+		require.Equal(t, d.want, delivery.Status)
+
+		if d.status != d.want {
+			require.Greater(t, int64(delivery.Metadata.NextSendTime), int64(past))
+		} else {
+			require.Equal(t, past, delivery.Metadata.NextSendTime)
+		}
+	}
+}
+
+func TestForceCompleteStuck_MarksOldProcessingAsFailed(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	old := primitive.NewDateTimeFromTime(time.Now().Add(-2 * time.Hour))
+	recent := primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute))
+
+	deliveries := []struct {
+		uid       string
+		status    datastore.EventDeliveryStatus
+		updatedAt primitive.DateTime
+		want      datastore.EventDeliveryStatus
+	}{
+		{uid: uuid.NewString(), status: datastore.ProcessingEventStatus, updatedAt: old, want: datastore.FailureEventStatus},
+		{uid: uuid.NewString(), status: datastore.ProcessingEventStatus, updatedAt: old, want: datastore.FailureEventStatus},
+		{uid: uuid.NewString(), status: datastore.ProcessingEventStatus, updatedAt: recent, want: datastore.ProcessingEventStatus},
+		{uid: uuid.NewString(), status: datastore.RetryEventStatus, updatedAt: old, want: datastore.RetryEventStatus},
+		{uid: uuid.NewString(), status: datastore.SuccessEventStatus, updatedAt: old, want: datastore.SuccessEventStatus},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            d.uid,
+			Status:         d.status,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata:       &datastore.Metadata{},
+			UpdatedAt:      d.updatedAt,
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	count, err := edRepo.ForceCompleteStuckDeliveries(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	for _, d := range deliveries {
+		delivery, err := edRepo.FindEventDeliveryByID(context.Background(), d.uid)
+		require.NoError(t, err)
+		require.Equal(t, d.want, delivery.Status)
+
+		if d.status != d.want {
+			require.Equal(t, datastore.StuckDeliveryDescription, delivery.Description)
+			require.Greater(t, int64(delivery.UpdatedAt), int64(recent))
+		} else {
+			require.Equal(t, d.updatedAt, delivery.UpdatedAt)
+		}
+	}
+}
+
+func TestForceCompleteStuck_WorkerFinishingAfterwardsKeepsFailure(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	delivery := datastore.EventDelivery{
+		UID:            uuid.NewString(),
+		Status:         datastore.ProcessingEventStatus,
+		AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+		Metadata:       &datastore.Metadata{RetryLimit: 3},
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now().Add(-2 * time.Hour)),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, edRepo.CreateEventDelivery(ctx, &delivery))
+
+	count, err := edRepo.ForceCompleteStuckDeliveries(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// the worker that was sending it only finishes now, and succeeds
+	delivery.Status = datastore.SuccessEventStatus
+	updated, err := edRepo.UpdateEventDeliveryWithAttempt(ctx, delivery, datastore.DeliveryAttempt{UID: uuid.NewString()})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	d, err := edRepo.FindEventDeliveryByID(ctx, delivery.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.FailureEventStatus, d.Status)
+	require.Equal(t, datastore.StuckDeliveryDescription, d.Description)
+	require.Empty(t, d.DeliveryAttempts)
+}
+
+func TestEventDeliveryRepository_LoadEventDeliveriesCursored(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	start := time.Now().Add(-time.Hour)
+
+	// deliveries 0 and 1 share a created_at, every third one failed
+	var failed []string
+	for i := 0; i < 9; i++ {
+		status := datastore.SuccessEventStatus
+		if i%3 == 0 {
+			status = datastore.FailureEventStatus
+		}
+
+		d := &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         status,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			CreatedAt:      primitive.NewDateTimeFromTime(start.Add(time.Duration(i/2) * time.Second)),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), d))
+
+		if status == datastore.FailureEventStatus {
+			failed = append([]string{d.UID}, failed...)
+		}
+	}
+
+	status := []datastore.EventDeliveryStatus{datastore.FailureEventStatus}
+
+	page, err := edRepo.LoadEventDeliveriesCursored(context.Background(), "group-1", "", "", "", status, datastore.SearchParams{}, nil, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page))
+	require.Equal(t, failed[:2], []string{page[0].UID, page[1].UID})
+
+	next := &datastore.Cursor{CreatedAt: page[1].CreatedAt, UID: page[1].UID}
+	page, err = edRepo.LoadEventDeliveriesCursored(context.Background(), "group-1", "", "", "", status, datastore.SearchParams{}, next, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page))
+	require.Equal(t, failed[2], page[0].UID)
+
+	prev := &datastore.Cursor{CreatedAt: page[0].CreatedAt, UID: page[0].UID, Backward: true}
+	page, err = edRepo.LoadEventDeliveriesCursored(context.Background(), "group-1", "", "", "", status, datastore.SearchParams{}, prev, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page))
+	require.Equal(t, failed[1], page[0].UID)
+}
+
+func TestEventDeliveryRepository_LoadEventDeliveriesPaged_SkipsTotal(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         datastore.SuccessEventStatus,
+			AppMetadata:    &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	deliveries, paginationData, err := edRepo.LoadEventDeliveriesPaged(context.Background(), "group-1", "", "", "", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 2, Sort: -1, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deliveries))
+	require.Equal(t, datastore.PaginationData{Total: -1, Page: 1, PerPage: 2, Next: 2, TotalPage: -1}, paginationData)
+
+	deliveries, paginationData, err = edRepo.LoadEventDeliveriesPaged(context.Background(), "group-1", "", "", "", nil, datastore.SearchParams{}, datastore.Pageable{Page: 3, PerPage: 2, Sort: -1, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deliveries))
+	require.Equal(t, datastore.PaginationData{Total: -1, Page: 3, PerPage: 2, Prev: 2, TotalPage: -1}, paginationData)
+
+	// the total is counted unless it is skipped
+	_, paginationData, err = edRepo.LoadEventDeliveriesPaged(context.Background(), "group-1", "", "", "", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 2, Sort: -1})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), paginationData.Total)
+	require.Equal(t, int64(3), paginationData.TotalPage)
+}
+
+func TestEventDeliveryRepository_CreateEventDeliveries(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	deliveries := benchDeliveries(3)
+
+	require.NoError(t, edRepo.CreateEventDeliveries(context.Background(), deliveries))
+
+	created, err := edRepo.FindEventDeliveriesByEventID(context.Background(), deliveries[0].EventMetadata.UID)
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+}
+
+// benchDeliveries builds the deliveries of an event fanned out to n endpoints.
+func benchDeliveries(n int) []*datastore.EventDelivery {
+	eventID := uuid.NewString()
+	deliveries := make([]*datastore.EventDelivery, n)
+	for i := range deliveries {
+		deliveries[i] = &datastore.EventDelivery{
+			UID:              uuid.NewString(),
+			EventMetadata:    &datastore.EventMetadata{UID: eventID},
+			EndpointMetadata: &datastore.EndpointMetadata{UID: uuid.NewString()},
+			AppMetadata:      &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+			Status:           datastore.ScheduledEventStatus,
+			CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus:   datastore.ActiveDocumentStatus,
+		}
+	}
+
+	return deliveries
+}
+
+func BenchmarkEventDeliveryRepository_CreateEventDelivery(b *testing.B) {
+	db, closeFn := getDB(b)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		deliveries := benchDeliveries(30)
+		b.StartTimer()
+
+		for _, d := range deliveries {
+			if err := edRepo.CreateEventDelivery(context.Background(), d); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkEventDeliveryRepository_CreateEventDeliveries(b *testing.B) {
+	db, closeFn := getDB(b)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		deliveries := benchDeliveries(30)
+		b.StartTimer()
+
+		if err := edRepo.CreateEventDeliveries(context.Background(), deliveries); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEventDelivery_RejectsIllegalTransitionFromSuccess(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	e := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	delivery := datastore.EventDelivery{
+		UID: uuid.NewString(),
+		EventMetadata: &datastore.EventMetadata{
+			UID:       uuid.NewString(),
+			EventType: "*",
+		},
+		AppMetadata: &datastore.AppMetadata{UID: uuid.NewString()},
+		Status:      datastore.ScheduledEventStatus,
+	}
+	require.NoError(t, e.CreateEventDelivery(ctx, &delivery))
+
+	moved, err := e.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	moved, err = e.TransitionStatus(ctx, delivery.UID, datastore.ProcessingEventStatus, datastore.SuccessEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	// a worker that loaded the delivery before it succeeded
+	moved, err = e.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.False(t, moved)
+
+	_, err = e.TransitionStatus(ctx, delivery.UID, datastore.SuccessEventStatus, datastore.RetryEventStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalStatusTransition)
+
+	d, err := e.FindEventDeliveryByID(ctx, delivery.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.SuccessEventStatus, d.Status)
+}
+
+func TestEventDelivery_UpdateWithAttemptOnlyUpdatesProcessing(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	e := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	delivery := datastore.EventDelivery{
+		UID: uuid.NewString(),
+		EventMetadata: &datastore.EventMetadata{
+			UID:       uuid.NewString(),
+			EventType: "*",
+		},
+		AppMetadata: &datastore.AppMetadata{UID: uuid.NewString()},
+		Metadata:    &datastore.Metadata{RetryLimit: 3},
+		Status:      datastore.ScheduledEventStatus,
+	}
+	require.NoError(t, e.CreateEventDelivery(ctx, &delivery))
+
+	moved, err := e.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	delivery.Status = datastore.SuccessEventStatus
+	updated, err := e.UpdateEventDeliveryWithAttempt(ctx, delivery, datastore.DeliveryAttempt{UID: uuid.NewString()})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// a worker finishing after the delivery was force completed
+	delivery.Status = datastore.RetryEventStatus
+	updated, err = e.UpdateEventDeliveryWithAttempt(ctx, delivery, datastore.DeliveryAttempt{UID: uuid.NewString()})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	d, err := e.FindEventDeliveryByID(ctx, delivery.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.SuccessEventStatus, d.Status)
+	require.Len(t, d.DeliveryAttempts, 1)
+}
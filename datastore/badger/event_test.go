@@ -225,6 +225,39 @@ func Test_CountGroupMessages(t *testing.T) {
 	}
 }
 
+func Test_CountGroupMessagesByType(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	eventTypes := []datastore.EventType{"king.maker", "king.taker", "king.taker"}
+	for _, gid := range []string{"gid-1", "gid-2"} {
+		for _, eventType := range eventTypes {
+			event := &datastore.Event{
+				UID:              uuid.NewString(),
+				EventType:        eventType,
+				MatchedEndpoints: 1,
+				Data:             []byte("{\"key\":\"value\"}"),
+				CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+				AppMetadata: &datastore.AppMetadata{
+					UID:     uuid.NewString(),
+					GroupID: gid,
+				},
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			}
+
+			require.NoError(t, eventRepo.CreateEvent(context.Background(), event))
+		}
+	}
+
+	counts, err := eventRepo.CountGroupMessagesByType(context.Background(), "gid-1")
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int64{"king.maker": 1, "king.taker": 2}, counts)
+}
+
 func Test_LoadEventIntervals(t *testing.T) {
 	type Group struct {
 		UID  string
@@ -285,8 +318,8 @@ func Test_LoadEventIntervals(t *testing.T) {
 			params: Params{start: "2021-11-01T00:00:00", end: "2022-02-01T00:00:00"},
 			period: datastore.Daily,
 			expected: []datastore.EventInterval{
-				{Data: datastore.EventIntervalData{Interval: int64(1), Time: "2021-11-01"}, Count: uint64(2)},
-				{Data: datastore.EventIntervalData{Interval: int64(12), Time: "2021-12-12"}, Count: uint64(1)},
+				{Data: datastore.EventIntervalData{Interval: int64(305), Time: "2021-11-01"}, Count: uint64(2)},
+				{Data: datastore.EventIntervalData{Interval: int64(346), Time: "2021-12-12"}, Count: uint64(1)},
 				{Data: datastore.EventIntervalData{Interval: int64(1), Time: "2022-01-01"}, Count: uint64(2)},
 				{Data: datastore.EventIntervalData{Interval: int64(12), Time: "2022-01-12"}, Count: uint64(2)},
 			},
@@ -310,8 +343,9 @@ func Test_LoadEventIntervals(t *testing.T) {
 			period: datastore.Weekly,
 			expected: []datastore.EventInterval{
 				{Data: datastore.EventIntervalData{Interval: int64(44), Time: "2021-11"}, Count: uint64(2)},
-				{Data: datastore.EventIntervalData{Interval: int64(48), Time: "2021-12"}, Count: uint64(1)},
-				{Data: datastore.EventIntervalData{Interval: int64(52), Time: "2022-01"}, Count: uint64(4)},
+				{Data: datastore.EventIntervalData{Interval: int64(50), Time: "2021-12"}, Count: uint64(1)},
+				{Data: datastore.EventIntervalData{Interval: int64(0), Time: "2022-01"}, Count: uint64(2)},
+				{Data: datastore.EventIntervalData{Interval: int64(2), Time: "2022-01"}, Count: uint64(2)},
 			},
 		},
 		{
@@ -354,7 +388,7 @@ func Test_LoadEventIntervals(t *testing.T) {
 			params: Params{start: "2021-11-01T00:00:00"},
 			period: datastore.Daily,
 			expected: []datastore.EventInterval{
-				{Data: datastore.EventIntervalData{Interval: int64(1), Time: "2021-11-01"}, Count: uint64(2)},
+				{Data: datastore.EventIntervalData{Interval: int64(305), Time: "2021-11-01"}, Count: uint64(2)},
 			},
 		},
 	}
@@ -631,3 +665,422 @@ func Test_LoadEventsPaged(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchEventsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	payloads := []string{
+		`{"order_id":48211,"status":"paid"}`,
+		`{"order_id":48212,"status":"paid"}`,
+		`{"order_id":10001,"status":"REFUNDED"}`,
+	}
+
+	for _, p := range payloads {
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), &datastore.Event{
+			UID:            uuid.NewString(),
+			EventType:      "order.updated",
+			Data:           []byte(p),
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			AppMetadata: &datastore.AppMetadata{
+				UID:     "aid-1",
+				GroupID: "gid-1",
+			},
+		}))
+	}
+
+	events, pageData, err := eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "48211", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, int64(1), pageData.Total)
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "refunded", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(events))
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-2", "", "48211", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(events))
+}
+
+func TestEventRepository_FiltersByMetadata(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	metadata := []map[string]string{
+		{"customer_id": "cus_1", "region": "eu"},
+		{"customer_id": "cus_1", "region": "us"},
+		{"customer_id": "cus_2", "region": "eu"},
+		nil,
+	}
+
+	for _, m := range metadata {
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), &datastore.Event{
+			UID:            uuid.NewString(),
+			EventType:      "order.updated",
+			Data:           []byte(`{}`),
+			Metadata:       m,
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			AppMetadata: &datastore.AppMetadata{
+				UID:     "aid-1",
+				GroupID: "gid-1",
+			},
+		}))
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 10}
+
+	events, pageData, err := eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "", map[string]string{"customer_id": "cus_1"}, datastore.SearchParams{}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, int64(2), pageData.Total)
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "", map[string]string{"customer_id": "cus_1", "region": "us"}, datastore.SearchParams{}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "us", events[0].Metadata["region"])
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "", map[string]string{"customer_id": "cus_3"}, datastore.SearchParams{}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(events))
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-2", "", "", map[string]string{"customer_id": "cus_1"}, datastore.SearchParams{}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(events))
+}
+
+func TestEventRepository_FiltersByTag(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	tags := [][]string{
+		{"replay", "backfill-2024"},
+		{"backfill-2024"},
+		{"replay"},
+		nil,
+	}
+
+	for _, tt := range tags {
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), &datastore.Event{
+			UID:            uuid.NewString(),
+			EventType:      "order.updated",
+			Data:           []byte(`{}`),
+			Tags:           tt,
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			AppMetadata: &datastore.AppMetadata{
+				UID:     "aid-1",
+				GroupID: "gid-1",
+			},
+		}))
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 10}
+
+	events, pageData, err := eventRepo.LoadEventsPaged(context.Background(), "gid-1", "", datastore.SearchParams{Tag: "backfill-2024"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, int64(2), pageData.Total)
+
+	events, _, err = eventRepo.SearchEventsPaged(context.Background(), "gid-1", "", "", nil, datastore.SearchParams{Tag: "replay"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+
+	events, _, err = eventRepo.LoadEventsPaged(context.Background(), "gid-1", "", datastore.SearchParams{Tag: "backfill"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(events))
+}
+
+func Test_CountEvents(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	events := []struct {
+		appID string
+		tags  []string
+	}{
+		{appID: "aid-1", tags: []string{"replay"}},
+		{appID: "aid-1"},
+		{appID: "aid-2", tags: []string{"replay"}},
+	}
+
+	for _, ev := range events {
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), &datastore.Event{
+			UID:            uuid.NewString(),
+			EventType:      "order.updated",
+			Data:           []byte(`{}`),
+			Tags:           ev.tags,
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			AppMetadata: &datastore.AppMetadata{
+				UID:     ev.appID,
+				GroupID: "gid-1",
+			},
+		}))
+	}
+
+	count, err := eventRepo.CountEvents(context.Background(), "gid-1", "", datastore.SearchParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	count, err = eventRepo.CountEvents(context.Background(), "gid-1", "aid-1", datastore.SearchParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = eventRepo.CountEvents(context.Background(), "gid-1", "", datastore.SearchParams{Tag: "replay"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = eventRepo.CountEvents(context.Background(), "gid-2", "", datastore.SearchParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+func Test_UpdateEventsGroupID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	appID := uuid.NewString()
+	moved := &datastore.Event{
+		UID:         uuid.NewString(),
+		AppMetadata: &datastore.AppMetadata{UID: appID, GroupID: "group-a"},
+	}
+	require.NoError(t, eventRepo.CreateEvent(context.Background(), moved))
+
+	other := &datastore.Event{
+		UID:         uuid.NewString(),
+		AppMetadata: &datastore.AppMetadata{UID: uuid.NewString(), GroupID: "group-a"},
+	}
+	require.NoError(t, eventRepo.CreateEvent(context.Background(), other))
+
+	err := eventRepo.UpdateEventsGroupID(context.Background(), appID, "group-b")
+	require.NoError(t, err)
+
+	event, err := eventRepo.FindEventByID(context.Background(), moved.UID)
+	require.NoError(t, err)
+	require.Equal(t, "group-b", event.AppMetadata.GroupID)
+
+	event, err = eventRepo.FindEventByID(context.Background(), other.UID)
+	require.NoError(t, err)
+	require.Equal(t, "group-a", event.AppMetadata.GroupID)
+}
+
+func Test_MarkEventBridged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	event := &datastore.Event{
+		UID:         uuid.NewString(),
+		AppMetadata: &datastore.AppMetadata{UID: uuid.NewString(), GroupID: "group-a"},
+	}
+	require.NoError(t, eventRepo.CreateEvent(context.Background(), event))
+
+	bridgedAt := time.Now()
+	marked, err := eventRepo.MarkEventBridged(context.Background(), event.UID, bridgedAt)
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	// only the first caller gets to forward the event
+	marked, err = eventRepo.MarkEventBridged(context.Background(), event.UID, bridgedAt.Add(time.Second))
+	require.NoError(t, err)
+	require.False(t, marked)
+
+	found, err := eventRepo.FindEventByID(context.Background(), event.UID)
+	require.NoError(t, err)
+	require.Equal(t, primitive.NewDateTimeFromTime(bridgedAt), found.BridgedAt)
+}
+
+func TestEventRepository_LoadEventsCursored_StableUnderConcurrentInserts(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+	start := time.Now().Add(-time.Hour)
+
+	// every three events share a created_at so pages split on uid ties
+	var want []string
+	for i := 0; i < 30; i++ {
+		e := &datastore.Event{
+			UID:            uuid.NewString(),
+			EventType:      "order.updated",
+			Data:           []byte(`{}`),
+			CreatedAt:      primitive.NewDateTimeFromTime(start.Add(time.Duration(i/3) * time.Second)),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			AppMetadata:    &datastore.AppMetadata{UID: "aid-1", GroupID: "gid-1"},
+		}
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), e))
+		want = append(want, e.UID)
+	}
+
+	all, err := eventRepo.LoadEventsCursored(context.Background(), "gid-1", "", "", nil, datastore.SearchParams{}, nil, 100)
+	require.NoError(t, err)
+	want = want[:0]
+	for _, e := range all {
+		want = append(want, e.UID)
+	}
+
+	done := make(chan struct{})
+	inserted := make(chan error, 1)
+	go func() {
+		defer close(inserted)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			err := eventRepo.CreateEvent(context.Background(), &datastore.Event{
+				UID:            uuid.NewString(),
+				EventType:      "order.created",
+				Data:           []byte(`{}`),
+				CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+				DocumentStatus: datastore.ActiveDocumentStatus,
+				AppMetadata:    &datastore.AppMetadata{UID: "aid-1", GroupID: "gid-1"},
+			})
+			if err != nil {
+				inserted <- err
+				return
+			}
+		}
+	}()
+
+	// the first page is read before the paging starts, the events
+	// inserted since are newer than it and must not show up further on
+	var got []string
+	cursor := &datastore.Cursor{CreatedAt: all[0].CreatedAt, UID: all[0].UID}
+	got = append(got, all[0].UID)
+	for {
+		page, err := eventRepo.LoadEventsCursored(context.Background(), "gid-1", "", "", nil, datastore.SearchParams{}, cursor, 4)
+		require.NoError(t, err)
+
+		if len(page) == 0 {
+			break
+		}
+
+		for _, e := range page {
+			got = append(got, e.UID)
+		}
+
+		last := page[len(page)-1]
+		cursor = &datastore.Cursor{CreatedAt: last.CreatedAt, UID: last.UID}
+	}
+
+	close(done)
+	require.NoError(t, <-inserted)
+	require.Equal(t, want, got)
+
+	// paging back from the oldest event walks the same list in reverse,
+	// running into the events inserted meanwhile at the top
+	var back []string
+	cursor = &datastore.Cursor{CreatedAt: all[len(all)-1].CreatedAt, UID: all[len(all)-1].UID, Backward: true}
+	back = append(back, all[len(all)-1].UID)
+	for {
+		page, err := eventRepo.LoadEventsCursored(context.Background(), "gid-1", "", "", nil, datastore.SearchParams{}, cursor, 4)
+		require.NoError(t, err)
+
+		for i := len(page) - 1; i >= 0; i-- {
+			back = append(back, page[i].UID)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		cursor = &datastore.Cursor{CreatedAt: page[0].CreatedAt, UID: page[0].UID, Backward: true}
+	}
+
+	for i, j := 0, len(back)-1; i < j; i, j = i+1, j-1 {
+		back[i], back[j] = back[j], back[i]
+	}
+
+	seen := make(map[string]bool)
+	for _, uid := range back {
+		require.False(t, seen[uid], "event %s was paged twice", uid)
+		seen[uid] = true
+	}
+
+	require.GreaterOrEqual(t, len(back), len(want))
+	require.Equal(t, want, back[len(back)-len(want):])
+}
+
+func TestEventRepository_LoadEventsCursored_FiltersBySearch(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+
+	events := []*datastore.Event{
+		{UID: "e1", Data: []byte(`{"order":"48211"}`), Metadata: map[string]string{"tenant": "acme"}},
+		{UID: "e2", Data: []byte(`{"order":"48211"}`), Metadata: map[string]string{"tenant": "other"}},
+		{UID: "e3", Data: []byte(`{"refund":"1"}`), Metadata: map[string]string{"tenant": "acme"}},
+	}
+	for _, e := range events {
+		e.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+		e.DocumentStatus = datastore.ActiveDocumentStatus
+		e.AppMetadata = &datastore.AppMetadata{UID: "aid-1", GroupID: "gid-1"}
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), e))
+	}
+
+	found, err := eventRepo.LoadEventsCursored(context.Background(), "gid-1", "", "48211", map[string]string{"tenant": "acme"}, datastore.SearchParams{}, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+	require.Equal(t, "e1", found[0].UID)
+}
+
+func TestEventRepository_PurgeDeleted(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+	eventDeliveryRepo := NewEventDeliveryRepository(db)
+	now := time.Now()
+
+	events := []*datastore.Event{
+		{UID: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-48 * time.Hour))},
+		{UID: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-time.Hour))},
+		{UID: uuid.NewString()},
+	}
+
+	deliveries := make([]*datastore.EventDelivery, len(events))
+	for i, e := range events {
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), e))
+
+		deliveries[i] = &datastore.EventDelivery{UID: uuid.NewString(), EventMetadata: &datastore.EventMetadata{UID: e.UID}}
+		require.NoError(t, eventDeliveryRepo.CreateEventDelivery(context.Background(), deliveries[i]))
+	}
+
+	purged, err := eventRepo.PurgeDeleted(context.Background(), now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = eventRepo.FindEventByID(context.Background(), events[0].UID)
+	require.Error(t, err)
+
+	// the purged event's deliveries go with it
+	_, err = eventDeliveryRepo.FindEventDeliveryByID(context.Background(), deliveries[0].UID)
+	require.Error(t, err)
+
+	// recently deleted and active events are kept with their deliveries
+	for i := range events[1:] {
+		_, err = eventRepo.FindEventByID(context.Background(), events[i+1].UID)
+		require.NoError(t, err)
+
+		_, err = eventDeliveryRepo.FindEventDeliveryByID(context.Background(), deliveries[i+1].UID)
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,67 @@
+package badger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+type eventTypeRepo struct {
+	db *badgerhold.Store
+}
+
+func NewEventTypeRepo(db *badgerhold.Store) datastore.EventTypeRepository {
+	return &eventTypeRepo{db: db}
+}
+
+func (e *eventTypeRepo) CreateEventType(ctx context.Context, eventType *datastore.EventTypeDefinition) error {
+	if util.IsStringEmpty(eventType.UID) {
+		eventType.UID = uuid.New().String()
+	}
+
+	return e.db.Insert(eventType.UID, eventType)
+}
+
+func (e *eventTypeRepo) FindEventTypeByName(ctx context.Context, groupID, name string) (*datastore.EventTypeDefinition, error) {
+	var eventTypes []datastore.EventTypeDefinition
+
+	err := e.db.Find(&eventTypes, badgerhold.Where("GroupID").Eq(groupID).And("Name").Eq(name))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, datastore.ErrEventTypeNotFound
+	}
+
+	return &eventTypes[0], nil
+}
+
+func (e *eventTypeRepo) LoadEventTypes(ctx context.Context, groupID string) ([]datastore.EventTypeDefinition, error) {
+	var eventTypes = make([]datastore.EventTypeDefinition, 0)
+
+	err := e.db.Find(&eventTypes, badgerhold.Where("GroupID").Eq(groupID).SortBy("Name"))
+	if err != nil {
+		return nil, err
+	}
+
+	return eventTypes, nil
+}
+
+func (e *eventTypeRepo) DeleteEventType(ctx context.Context, groupID, name string) error {
+	eventType, err := e.FindEventTypeByName(ctx, groupID, name)
+	if err != nil {
+		return err
+	}
+
+	err = e.db.Delete(eventType.UID, &datastore.EventTypeDefinition{})
+	if errors.Is(err, badgerhold.ErrNotFound) {
+		return datastore.ErrEventTypeNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,51 @@
+//go:build integration
+// +build integration
+
+package badger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_eventTypeRepo_LoadAndDeleteEventTypes(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventTypeRepo := NewEventTypeRepo(db)
+
+	for _, e := range []datastore.EventTypeDefinition{
+		{GroupID: "group-1", Name: "payment.failed"},
+		{GroupID: "group-1", Name: "payment.created", Schema: []byte(`{"type":"object"}`)},
+		{GroupID: "group-2", Name: "payment.created"},
+	} {
+		eventType := e
+		require.NoError(t, eventTypeRepo.CreateEventType(context.Background(), &eventType))
+	}
+
+	eventTypes, err := eventTypeRepo.LoadEventTypes(context.Background(), "group-1")
+	require.NoError(t, err)
+	require.Len(t, eventTypes, 2)
+	require.Equal(t, "payment.created", eventTypes[0].Name)
+	require.JSONEq(t, `{"type":"object"}`, string(eventTypes[0].Schema))
+	require.Equal(t, "payment.failed", eventTypes[1].Name)
+
+	eventType, err := eventTypeRepo.FindEventTypeByName(context.Background(), "group-2", "payment.created")
+	require.NoError(t, err)
+	require.Equal(t, "group-2", eventType.GroupID)
+
+	require.NoError(t, eventTypeRepo.DeleteEventType(context.Background(), "group-1", "payment.created"))
+
+	_, err = eventTypeRepo.FindEventTypeByName(context.Background(), "group-1", "payment.created")
+	require.ErrorIs(t, err, datastore.ErrEventTypeNotFound)
+
+	err = eventTypeRepo.DeleteEventType(context.Background(), "group-1", "payment.created")
+	require.ErrorIs(t, err, datastore.ErrEventTypeNotFound)
+
+	// the other group's event type of the same name is kept
+	_, err = eventTypeRepo.FindEventTypeByName(context.Background(), "group-2", "payment.created")
+	require.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"github.com/timshannon/badgerhold/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type exportJobRepo struct {
+	db *badgerhold.Store
+}
+
+func NewExportJobRepo(db *badgerhold.Store) datastore.ExportJobRepository {
+	return &exportJobRepo{db: db}
+}
+
+func (e *exportJobRepo) CreateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	if util.IsStringEmpty(job.UID) {
+		job.UID = uuid.New().String()
+	}
+
+	return e.db.Insert(job.UID, job)
+}
+
+func (e *exportJobRepo) UpdateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	return e.db.Update(job.UID, job)
+}
+
+func (e *exportJobRepo) FindExportJobByID(ctx context.Context, uid string) (*datastore.ExportJob, error) {
+	var job datastore.ExportJob
+
+	err := e.db.Get(uid, &job)
+	if err != nil && errors.Is(err, badgerhold.ErrNotFound) {
+		return &job, datastore.ErrExportJobNotFound
+	}
+
+	return &job, err
+}
+
+func (e *exportJobRepo) LoadExpiredExportJobs(ctx context.Context, expiredBefore time.Time, limit int) ([]datastore.ExportJob, error) {
+	var jobs = make([]datastore.ExportJob, 0)
+
+	query := badgerhold.Where("ExpiresAt").Lt(primitive.NewDateTimeFromTime(expiredBefore)).SortBy("ExpiresAt").Limit(limit)
+	err := e.db.Find(&jobs, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (e *exportJobRepo) DeleteExportJob(ctx context.Context, uid string) error {
+	err := e.db.Delete(uid, &datastore.ExportJob{})
+	if errors.Is(err, badgerhold.ErrNotFound) {
+		return datastore.ErrExportJobNotFound
+	}
+
+	return err
+}
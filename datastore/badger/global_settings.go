@@ -0,0 +1,40 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/timshannon/badgerhold/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// globalSettingsUID is the key of the only global settings document.
+const globalSettingsUID = "global"
+
+type globalSettingsRepo struct {
+	db *badgerhold.Store
+}
+
+func NewGlobalSettingsRepo(db *badgerhold.Store) datastore.GlobalSettingsRepository {
+	return &globalSettingsRepo{db: db}
+}
+
+func (g *globalSettingsRepo) Get(ctx context.Context) (*datastore.GlobalSettings, error) {
+	var settings datastore.GlobalSettings
+
+	err := g.db.Get(globalSettingsUID, &settings)
+	if errors.Is(err, badgerhold.ErrNotFound) {
+		return &settings, datastore.ErrGlobalSettingsNotFound
+	}
+
+	return &settings, err
+}
+
+func (g *globalSettingsRepo) Update(ctx context.Context, settings *datastore.GlobalSettings) error {
+	settings.UID = globalSettingsUID
+	settings.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	return g.db.Upsert(settings.UID, settings)
+}
@@ -0,0 +1,36 @@
+//go:build integration
+// +build integration
+
+package badger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalSettingsRepository_GetAndUpdate(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	settingsRepo := NewGlobalSettingsRepo(db)
+
+	_, err := settingsRepo.Get(context.Background())
+	require.ErrorIs(t, err, datastore.ErrGlobalSettingsNotFound)
+
+	require.NoError(t, settingsRepo.Update(context.Background(), &datastore.GlobalSettings{RateLimit: 200, RateLimitDuration: "10m"}))
+	require.NoError(t, settingsRepo.Update(context.Background(), &datastore.GlobalSettings{RateLimit: 300, RateLimitDuration: "1m", EventRetentionDays: 7}))
+
+	settings, err := settingsRepo.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 300, settings.RateLimit)
+	require.Equal(t, "1m", settings.RateLimitDuration)
+	require.Equal(t, 7, settings.EventRetentionDays)
+
+	// updates overwrite the one document instead of adding another
+	count, err := db.Count(&datastore.GlobalSettings{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
@@ -3,10 +3,13 @@ package badger
 import (
 	"context"
 	"errors"
-	_ "fmt"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/timshannon/badgerhold/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type groupRepo struct {
@@ -21,8 +24,31 @@ func (g *groupRepo) LoadGroups(ctx context.Context, filter *datastore.GroupFilte
 	var groups []*datastore.Group
 
 	err := g.db.Find(&groups, badgerhold.Where("Name").In(badgerhold.Slice(filter.Names)...).Or(&badgerhold.Query{}))
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Cursor == nil && filter.PerPage <= 0 {
+		return groups, nil
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].CreatedAt != groups[j].CreatedAt {
+			return groups[i].CreatedAt > groups[j].CreatedAt
+		}
+		return groups[i].UID > groups[j].UID
+	})
+
+	perPage := filter.PerPage
+	if perPage <= 0 {
+		perPage = len(groups)
+	}
 
-	return groups, err
+	lo, hi := cursorBounds(len(groups), func(i int) (primitive.DateTime, string) {
+		return groups[i].CreatedAt, groups[i].UID
+	}, filter.Cursor, perPage)
+
+	return groups[lo:hi], nil
 }
 
 func (g *groupRepo) CreateGroup(ctx context.Context, group *datastore.Group) error {
@@ -33,6 +59,72 @@ func (g *groupRepo) UpdateGroup(_ context.Context, group *datastore.Group) error
 	return g.db.Update(group.UID, group)
 }
 
+func (g *groupRepo) UpdateGroupConfig(ctx context.Context, groupID string, patch *datastore.GroupConfigPatch) error {
+	var matched bool
+
+	err := g.db.UpdateMatching(&datastore.Group{}, badgerhold.Where("UID").Eq(groupID), func(record interface{}) error {
+		group, ok := record.(*datastore.Group)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted group, got %t", record)
+		}
+
+		var cfg datastore.GroupConfig
+		if group.Config != nil {
+			cfg = *group.Config
+		}
+
+		cfg = patch.Apply(cfg)
+		group.Config = &cfg
+		group.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		matched = true
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !matched {
+		return datastore.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (g *groupRepo) UpdateGroupStatus(ctx context.Context, id string, status datastore.GroupStatus) error {
+	var matched bool
+
+	err := g.db.UpdateMatching(&datastore.Group{}, badgerhold.Where("UID").Eq(id), func(record interface{}) error {
+		group, ok := record.(*datastore.Group)
+		if !ok {
+			return fmt.Errorf("record isn't the correct type!  wanted group, got %t", record)
+		}
+
+		matched = true
+		if !group.Status.CanTransitionTo(status) {
+			return datastore.ErrIllegalGroupStatusTransition
+		}
+
+		group.Status = status
+		group.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		if status == datastore.DeletedGroupStatus {
+			group.DeletedAt = group.UpdatedAt
+			group.DocumentStatus = datastore.DeletedDocumentStatus
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !matched {
+		return datastore.ErrGroupNotFound
+	}
+
+	return nil
+}
+
 func (g *groupRepo) FetchGroupByID(ctx context.Context, gid string) (*datastore.Group, error) {
 	var group *datastore.Group
 
@@ -49,10 +141,107 @@ func (g *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]datas
 	var groups []datastore.Group
 
 	err := g.db.Find(&groups, badgerhold.Where("UID").In(badgerhold.Slice(ids)...))
+	if err != nil {
+		return nil, err
+	}
+
+	position := make(map[string]int, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		position[ids[i]] = i
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return position[groups[i].UID] < position[groups[j].UID]
+	})
 
-	return groups, err
+	return groups, nil
 }
 
 func (g *groupRepo) DeleteGroup(ctx context.Context, gid string) error {
 	return g.db.DeleteMatching(&datastore.Group{}, badgerhold.Where("UID").Eq(gid))
 }
+
+// WithTransaction calls fn directly, writes made to badger are not
+// rolled back when fn fails.
+func (g *groupRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// DeleteGroupCascade deletes the group's documents one collection after
+// the other, events first like the mongo cascade. They are not restored
+// when a later delete fails.
+func (g *groupRepo) DeleteGroupCascade(ctx context.Context, groupID string) error {
+	err := NewEventRepo(g.db).DeleteGroupEvents(ctx, groupID)
+	if err != nil {
+		return &datastore.GroupCascadeError{Step: "delete group events", Err: err}
+	}
+
+	err = g.DeleteGroup(ctx, groupID)
+	if err != nil {
+		return &datastore.GroupCascadeError{Step: "delete group", Err: err}
+	}
+
+	err = NewApplicationRepo(g.db).DeleteGroupApps(ctx, groupID)
+	if err != nil {
+		return &datastore.GroupCascadeError{Step: "delete group apps", Err: err}
+	}
+
+	err = revokeGroupAPIKeys(g.db, groupID)
+	if err != nil {
+		return &datastore.GroupCascadeError{Step: "revoke group api keys", Err: err}
+	}
+
+	return nil
+}
+
+// revokeGroupAPIKeys revokes the api keys scoped to only the group and
+// removes the group from the keys scoped to others too.
+func revokeGroupAPIKeys(db *badgerhold.Store, groupID string) error {
+	var apiKeys []datastore.APIKey
+	err := db.Find(&apiKeys, badgerhold.Where("Role.Groups").Contains(groupID))
+	if err != nil {
+		return err
+	}
+
+	for _, apiKey := range apiKeys {
+		if len(apiKey.Role.Groups) == 1 {
+			err = db.Delete(apiKey.UID, &datastore.APIKey{})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		groups := make([]string, 0, len(apiKey.Role.Groups)-1)
+		for _, g := range apiKey.Role.Groups {
+			if g != groupID {
+				groups = append(groups, g)
+			}
+		}
+
+		apiKey.Role.Groups = groups
+		err = db.Update(apiKey.UID, apiKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *groupRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var groups []datastore.Group
+	err := g.db.Find(&groups, deletedBeforeQuery(deletedBefore, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range groups {
+		err = g.db.Delete(groups[i].UID, &datastore.Group{})
+		if err != nil {
+			return int64(i), err
+		}
+	}
+
+	return int64(len(groups)), nil
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func Test_FetchGroupByID(t *testing.T) {
@@ -121,3 +124,249 @@ func Test_DeleteGroup(t *testing.T) {
 
 	require.Equal(t, len(orgs), 1)
 }
+
+func TestGroupRepository_FetchGroupsByIDs(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	ids := make([]string, 5)
+	for i := range ids {
+		group := &datastore.Group{
+			Name:           uuid.NewString(),
+			UID:            uuid.NewString(),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+		ids[i] = group.UID
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantIDs []string
+	}{
+		{
+			name:    "should_return_groups_in_the_order_of_ids",
+			ids:     []string{ids[3], ids[0], ids[4]},
+			wantIDs: []string{ids[3], ids[0], ids[4]},
+		},
+		{
+			name:    "should_skip_ids_without_a_group",
+			ids:     []string{ids[2], uuid.NewString(), ids[1]},
+			wantIDs: []string{ids[2], ids[1]},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, err := groupRepo.FetchGroupsByIDs(context.Background(), tc.ids)
+			require.NoError(t, err)
+			require.Len(t, groups, len(tc.wantIDs))
+
+			for i, group := range groups {
+				require.Equal(t, tc.wantIDs[i], group.UID)
+			}
+		})
+	}
+}
+
+func seedGroupsForCursorPagination(t *testing.T, groupRepo datastore.GroupRepository) []*datastore.Group {
+	createdAt := time.Now().Add(-time.Hour)
+
+	// newest first, the last two share created_at so uid breaks the tie
+	groups := make([]*datastore.Group, 5)
+	for i := range groups {
+		at := createdAt.Add(time.Duration(len(groups)-i) * time.Second)
+		if i == len(groups)-1 {
+			at = createdAt.Add(2 * time.Second)
+		}
+
+		groups[i] = &datastore.Group{
+			Name:           uuid.NewString(),
+			UID:            uuid.NewString(),
+			CreatedAt:      primitive.NewDateTimeFromTime(at),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+	}
+
+	if groups[3].UID < groups[4].UID {
+		groups[3], groups[4] = groups[4], groups[3]
+	}
+
+	for _, g := range groups {
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), g))
+	}
+
+	return groups
+}
+
+func groupNames(groups []*datastore.Group) []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+func groupUIDs(groups []*datastore.Group) []string {
+	uids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		uids = append(uids, g.UID)
+	}
+	return uids
+}
+
+func TestGroupRepository_CursorPaginationReturnsCorrectPage(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	groups := seedGroupsForCursorPagination(t, groupRepo)
+	names := groupNames(groups)
+
+	firstPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[:2]), groupUIDs(firstPage))
+
+	cursor := &datastore.Cursor{CreatedAt: firstPage[1].CreatedAt, UID: firstPage[1].UID}
+	secondPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[2:4]), groupUIDs(secondPage))
+
+	cursor = &datastore.Cursor{CreatedAt: secondPage[0].CreatedAt, UID: secondPage[0].UID, Backward: true}
+	prevPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[:2]), groupUIDs(prevPage))
+}
+
+func TestGroupRepository_CursorPaginationHandlesLastPage(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	groups := seedGroupsForCursorPagination(t, groupRepo)
+	names := groupNames(groups)
+
+	cursor := &datastore.Cursor{CreatedAt: groups[2].CreatedAt, UID: groups[2].UID}
+	lastPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 3, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[3:]), groupUIDs(lastPage))
+
+	cursor = &datastore.Cursor{CreatedAt: groups[4].CreatedAt, UID: groups[4].UID}
+	pastLastPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 3, Cursor: cursor})
+	require.NoError(t, err)
+	require.Empty(t, pastLastPage)
+}
+
+func TestGroupRepository_UpdateGroupConfig(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	group := &datastore.Group{
+		Name: "group-config",
+		UID:  uuid.NewString(),
+		Config: &datastore.GroupConfig{
+			Strategy:            datastore.StrategyConfiguration{Type: "default", Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3}},
+			Signature:           datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			DisableEndpoint:     true,
+			DeduplicationWindow: "5m",
+		},
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+
+	replayAttacks := true
+	retentionPolicy := "720h"
+	err := groupRepo.UpdateGroupConfig(context.Background(), group.UID, &datastore.GroupConfigPatch{
+		Signature:       &datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"},
+		ReplayAttacks:   &replayAttacks,
+		RetentionPolicy: &retentionPolicy,
+	})
+	require.NoError(t, err)
+
+	updated, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, group.Config.Strategy, updated.Config.Strategy)
+	require.Equal(t, datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"}, updated.Config.Signature)
+	require.True(t, updated.Config.DisableEndpoint)
+	require.True(t, updated.Config.ReplayAttacks)
+	require.Equal(t, "5m", updated.Config.DeduplicationWindow)
+	require.Equal(t, "720h", updated.Config.RetentionPolicy)
+
+	err = groupRepo.UpdateGroupConfig(context.Background(), uuid.NewString(), &datastore.GroupConfigPatch{ReplayAttacks: &replayAttacks})
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+}
+
+func TestGroupRepository_UpdateGroupStatus_RejectsInvalidTransition(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	group := &datastore.Group{
+		Name:           "group-status",
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+
+	// an active group can't skip straight to archived
+	err := groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ArchivedGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalGroupStatusTransition)
+
+	require.NoError(t, groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.InactiveGroupStatus))
+	require.NoError(t, groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ArchivedGroupStatus))
+
+	// nor can an archived group be brought back
+	err = groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ActiveGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalGroupStatusTransition)
+
+	updated, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ArchivedGroupStatus, updated.Status)
+
+	err = groupRepo.UpdateGroupStatus(context.Background(), uuid.NewString(), datastore.InactiveGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+}
+
+func TestGroupRepository_DeleteGroupCascade(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	ctx := context.Background()
+	groupRepo := NewGroupRepo(db)
+	appRepo := NewApplicationRepo(db)
+	apiKeyRepo := NewApiRoleRepo(db)
+
+	group := &datastore.Group{UID: uuid.NewString(), Name: "cascade"}
+	require.NoError(t, groupRepo.CreateGroup(ctx, group))
+
+	app := &datastore.Application{UID: uuid.NewString(), GroupID: group.UID, Title: "cascade-app"}
+	require.NoError(t, appRepo.CreateApplication(ctx, app))
+
+	groupKey := &datastore.APIKey{UID: uuid.NewString(), Role: auth.Role{Type: auth.RoleAdmin, Groups: []string{group.UID}}}
+	require.NoError(t, apiKeyRepo.CreateAPIKey(ctx, groupKey))
+
+	sharedKey := &datastore.APIKey{UID: uuid.NewString(), Role: auth.Role{Type: auth.RoleAdmin, Groups: []string{group.UID, "other-group"}}}
+	require.NoError(t, apiKeyRepo.CreateAPIKey(ctx, sharedKey))
+
+	require.NoError(t, groupRepo.DeleteGroupCascade(ctx, group.UID))
+
+	_, err := groupRepo.FetchGroupByID(ctx, group.UID)
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+
+	_, err = appRepo.FindApplicationByID(ctx, app.UID)
+	require.ErrorIs(t, err, datastore.ErrApplicationNotFound)
+
+	_, err = apiKeyRepo.FindAPIKeyByID(ctx, groupKey.UID)
+	require.Error(t, err)
+
+	key, err := apiKeyRepo.FindAPIKeyByID(ctx, sharedKey.UID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"other-group"}, key.Role.Groups)
+}
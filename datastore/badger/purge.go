@@ -0,0 +1,17 @@
+package badger
+
+import (
+	"time"
+
+	"github.com/timshannon/badgerhold/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// deletedBeforeQuery matches up to limit records soft-deleted before
+// deletedBefore. Badger removes most records outright when they are
+// deleted, so there is usually little left to purge.
+func deletedBeforeQuery(deletedBefore time.Time, limit int) *badgerhold.Query {
+	return badgerhold.Where("DeletedAt").Gt(primitive.DateTime(0)).
+		And("DeletedAt").Lt(primitive.NewDateTimeFromTime(deletedBefore)).
+		Limit(limit)
+}
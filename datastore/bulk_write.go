@@ -0,0 +1,34 @@
+package datastore
+
+import "fmt"
+
+// DeliveriesWriteError is returned by a bulk write of event deliveries when
+// some of them could not be written. Failed holds those, so that the caller
+// can retry just them; the rest were written.
+type DeliveriesWriteError struct {
+	Failed []*EventDelivery
+	Err    error
+}
+
+func (e *DeliveriesWriteError) Error() string {
+	return fmt.Sprintf("failed to write %d event deliveries: %v", len(e.Failed), e.Err)
+}
+
+func (e *DeliveriesWriteError) Unwrap() error {
+	return e.Err
+}
+
+// GroupCascadeError is returned by DeleteGroupCascade when one of its
+// steps fails. Step names the failed step, e.g. "delete group apps".
+type GroupCascadeError struct {
+	Step string
+	Err  error
+}
+
+func (e *GroupCascadeError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.Step, e.Err)
+}
+
+func (e *GroupCascadeError) Unwrap() error {
+	return e.Err
+}
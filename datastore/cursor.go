@@ -0,0 +1,77 @@
+package datastore
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a position in a list ordered newest first, by created_at then
+// uid. Unlike offsets, cursors stay cheap to seek to deep into a list.
+type Cursor struct {
+	CreatedAt primitive.DateTime
+	UID       string
+
+	// Backward cursors point at the page before the position rather
+	// than the one after it.
+	Backward bool
+}
+
+// CursorPaginationData describes a page of a cursor paginated list, a
+// cursor is empty when there is no page in its direction.
+type CursorPaginationData struct {
+	PerPage    int64  `json:"per_page"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Encode returns the opaque form of c handed out to clients.
+func (c *Cursor) Encode() string {
+	direction := "n"
+	if c.Backward {
+		direction = "p"
+	}
+
+	raw := fmt.Sprintf("%s:%d:%s", direction, int64(c.CreatedAt), c.UID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// After reports whether a document created at createdAt with uid uid comes
+// after c in newest first order.
+func (c *Cursor) After(createdAt primitive.DateTime, uid string) bool {
+	if createdAt != c.CreatedAt {
+		return createdAt < c.CreatedAt
+	}
+
+	return uid < c.UID
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 || (parts[0] != "n" && parts[0] != "p") || parts[2] == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		CreatedAt: primitive.DateTime(createdAt),
+		UID:       parts[2],
+		Backward:  parts[0] == "p",
+	}, nil
+}
@@ -0,0 +1,25 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	for _, c := range []*Cursor{
+		{CreatedAt: 1656000000000, UID: "5d2a1c0e-group"},
+		{CreatedAt: 1656000000000, UID: "5d2a1c0e-group", Backward: true},
+	} {
+		decoded, err := DecodeCursor(c.Encode())
+		require.NoError(t, err)
+		require.Equal(t, c, decoded)
+	}
+}
+
+func TestDecodeCursor_RejectsInvalidCursors(t *testing.T) {
+	for _, s := range []string{"", "not base64!", "bjoxMjM", "eDoxMjM6YWJj", "bjphYmM6YWJj"} {
+		_, err := DecodeCursor(s)
+		require.ErrorIs(t, err, ErrInvalidCursor, s)
+	}
+}
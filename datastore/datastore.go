@@ -8,10 +8,17 @@ type DatabaseClient interface {
 	GetName() string
 	Client() interface{}
 	Disconnect(context.Context) error
+	Stats(context.Context) (*DatastoreStats, error)
 
 	APIRepo() APIKeyRepository
+	AuditLogRepo() AuditLogRepository
+	ArchiveRepo() ArchiveRepository
 	GroupRepo() GroupRepository
 	EventRepo() EventRepository
 	AppRepo() ApplicationRepository
 	EventDeliveryRepo() EventDeliveryRepository
+	EventBridgeRepo() EventBridgeRepository
+	ExportJobRepo() ExportJobRepository
+	EventTypeRepo() EventTypeRepository
+	GlobalSettingsRepo() GlobalSettingsRepository
 }
@@ -4,7 +4,14 @@ type Filter struct {
 	Group        *Group
 	AppID        string
 	EventID      string
+	EndpointID   string
+	Query        string
+	Metadata     map[string]string
 	Pageable     Pageable
 	Status       []EventDeliveryStatus
 	SearchParams SearchParams
+
+	// Cursor is the position a cursor paginated list is read from, the
+	// first page is read without one.
+	Cursor *Cursor
 }
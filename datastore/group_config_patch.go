@@ -0,0 +1,91 @@
+package datastore
+
+// GroupConfigPatch is a partial update of a GroupConfig, only the fields it
+// sets are changed. A sub struct that is set replaces the group's whole.
+type GroupConfigPatch struct {
+	Strategy                  *StrategyConfiguration  `json:"strategy,omitempty"`
+	Signature                 *SignatureConfiguration `json:"signature,omitempty"`
+	DisableEndpoint           *bool                   `json:"disable_endpoint,omitempty"`
+	ReplayAttacks             *bool                   `json:"replay_attacks,omitempty"`
+	RetryBudget               *RetryBudgetConfig      `json:"retry_budget,omitempty"`
+	CompressPayload           *bool                   `json:"compress_payload,omitempty"`
+	CompressThresholdBytes    *int                    `json:"compress_threshold_bytes,omitempty"`
+	MetaEvent                 *MetaEventConfiguration `json:"meta_event,omitempty"`
+	MaxEventPayloadSizeBytes  *int                    `json:"max_event_payload_size_bytes,omitempty"`
+	RetentionPolicy           *string                 `json:"retention_policy,omitempty"`
+	EventRetentionDays        *int                    `json:"event_retention_days,omitempty"`
+	OutboundProxy             *ProxyConfig            `json:"outbound_proxy,omitempty"`
+	DeduplicationWindow       *string                 `json:"deduplication_window,omitempty"`
+	IdempotencyWindowDuration *string                 `json:"idempotency_window_duration,omitempty"`
+	CustomHeaders             map[string]string       `json:"custom_headers,omitempty"`
+	EnforceEventTypes         *bool                   `json:"enforce_event_types,omitempty"`
+}
+
+// Apply returns a copy of c with the fields set in p changed.
+func (p *GroupConfigPatch) Apply(c GroupConfig) GroupConfig {
+	if p.Strategy != nil {
+		c.Strategy = *p.Strategy
+	}
+
+	if p.Signature != nil {
+		c.Signature = *p.Signature
+	}
+
+	if p.DisableEndpoint != nil {
+		c.DisableEndpoint = *p.DisableEndpoint
+	}
+
+	if p.ReplayAttacks != nil {
+		c.ReplayAttacks = *p.ReplayAttacks
+	}
+
+	if p.RetryBudget != nil {
+		c.RetryBudget = p.RetryBudget
+	}
+
+	if p.CompressPayload != nil {
+		c.CompressPayload = *p.CompressPayload
+	}
+
+	if p.CompressThresholdBytes != nil {
+		c.CompressThresholdBytes = *p.CompressThresholdBytes
+	}
+
+	if p.MetaEvent != nil {
+		c.MetaEvent = p.MetaEvent
+	}
+
+	if p.MaxEventPayloadSizeBytes != nil {
+		c.MaxEventPayloadSizeBytes = *p.MaxEventPayloadSizeBytes
+	}
+
+	if p.RetentionPolicy != nil {
+		c.RetentionPolicy = *p.RetentionPolicy
+	}
+
+	if p.EventRetentionDays != nil {
+		c.EventRetentionDays = *p.EventRetentionDays
+	}
+
+	if p.OutboundProxy != nil {
+		c.OutboundProxy = p.OutboundProxy
+	}
+
+	if p.DeduplicationWindow != nil {
+		c.DeduplicationWindow = *p.DeduplicationWindow
+	}
+
+	if p.IdempotencyWindowDuration != nil {
+		c.IdempotencyWindowDuration = *p.IdempotencyWindowDuration
+	}
+
+	if p.CustomHeaders != nil {
+		c.CustomHeaders = p.CustomHeaders
+	}
+
+	if p.EnforceEventTypes != nil {
+		c.EnforceEventTypes = *p.EnforceEventTypes
+	}
+
+	return c
+}
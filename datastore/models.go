@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config"
@@ -16,6 +17,10 @@ type Pageable struct {
 	Page    int `json:"page" bson:"page"`
 	PerPage int `json:"per_page" bson:"per_page"`
 	Sort    int `json:"sort" bson:"sort"`
+
+	// SkipTotal spares counting the whole list, which can take longer
+	// than loading a page of it. Its total comes back as -1.
+	SkipTotal bool `json:"skip_total,omitempty" bson:"skip_total,omitempty"`
 }
 
 type PaginationData struct {
@@ -25,6 +30,27 @@ type PaginationData struct {
 	Prev      int64 `json:"prev"`
 	Next      int64 `json:"next"`
 	TotalPage int64 `json:"totalPage"`
+
+	// NextCursor and PrevCursor are only set on cursor paginated lists
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// UncountedPaginationData describes page page of a list that was not
+// counted, holding n of up to perPage items. Total and TotalPage are -1
+// and there is only a next page after a full one.
+func UncountedPaginationData(page, perPage int64, n int) PaginationData {
+	data := PaginationData{Total: -1, Page: page, PerPage: perPage, TotalPage: -1}
+
+	if page > 1 {
+		data.Prev = page - 1
+	}
+
+	if int64(n) == perPage {
+		data.Next = page + 1
+	}
+
+	return data
 }
 
 type Period int
@@ -53,6 +79,9 @@ type DocumentStatus string
 type SearchParams struct {
 	CreatedAtStart int64 `json:"created_at_start" bson:"created_at_start"`
 	CreatedAtEnd   int64 `json:"created_at_end" bson:"created_at_end"`
+
+	// Tag, when set, only matches events and event deliveries carrying it
+	Tag string `json:"tag,omitempty" bson:"tag,omitempty"`
 }
 
 const (
@@ -72,14 +101,44 @@ const (
 	PendingEndpointStatus  EndpointStatus = "pending"
 )
 
+type ApplicationHealthStatus string
+
+const (
+	HealthyApplicationStatus   ApplicationHealthStatus = "healthy"
+	UnhealthyApplicationStatus ApplicationHealthStatus = "unhealthy"
+)
+
 type Application struct {
 	ID              primitive.ObjectID `json:"-" bson:"_id"`
 	UID             string             `json:"uid" bson:"uid"`
 	GroupID         string             `json:"group_id" bson:"group_id"`
+	OwnerID         string             `json:"owner_id,omitempty" bson:"owner_id,omitempty"`
 	Title           string             `json:"name" bson:"title"`
 	SupportEmail    string             `json:"support_email" bson:"support_email"`
 	SlackWebhookURL string             `json:"slack_webhook_url,omitempty" bson:"slack_webhook_url"`
 	IsDisabled      bool               `json:"is_disabled" bson:"is_disabled"`
+	CustomHeaders   map[string]string  `json:"custom_headers,omitempty" bson:"custom_headers,omitempty"`
+
+	// IsPaused holds back deliveries to the app's endpoints until it is
+	// resumed, PausedAt records when it was paused.
+	IsPaused bool               `json:"is_paused" bson:"is_paused"`
+	PausedAt primitive.DateTime `json:"paused_at,omitempty" bson:"paused_at,omitempty" swaggertype:"string"`
+
+	// UseVersionedEndpoints sends each version of an event to its own
+	// path under the endpoint's url, e.g. {url}/v2.
+	UseVersionedEndpoints bool `json:"use_versioned_endpoints" bson:"use_versioned_endpoints"`
+
+	// HealthScore is the share of the app's recent delivery attempts that
+	// succeeded, apps scoring under half of them are unhealthy.
+	HealthScore     float64                 `json:"health_score" bson:"health_score"`
+	HealthStatus    ApplicationHealthStatus `json:"health_status,omitempty" bson:"health_status,omitempty"`
+	LastHealthCheck primitive.DateTime      `json:"last_health_check,omitempty" bson:"last_health_check,omitempty" swaggertype:"string"`
+
+	// Secret is the default secret of the app's endpoints.
+	//
+	// Deprecated: webhooks are signed with the secret of their endpoint,
+	// this is only read to fill in endpoints that have none.
+	Secret string `json:"-" bson:"secret,omitempty"`
 
 	Endpoints []Endpoint         `json:"endpoints" bson:"endpoints"`
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
@@ -104,7 +163,11 @@ type Endpoint struct {
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
 
-	Events []string `json:"events" bson:"events"`
+	Events   []string          `json:"events" bson:"events"`
+	Metadata map[string]string `json:"metadata,omitempty" bson:"metadata,omitempty"`
+
+	DisabledReason string             `json:"disabled_reason,omitempty" bson:"disabled_reason,omitempty"`
+	DisabledAt     primitive.DateTime `json:"disabled_at,omitempty" bson:"disabled_at,omitempty" swaggertype:"string"`
 
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
@@ -113,7 +176,49 @@ type Endpoint struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
-var ErrGroupNotFound = errors.New("group not found")
+var (
+	ErrGroupNotFound                = errors.New("group not found")
+	ErrIllegalGroupStatusTransition = errors.New("illegal group status transition")
+)
+
+// GroupStatus is where a group is in its lifecycle, which runs from
+// active to inactive to archived to deleted.
+type GroupStatus string
+
+const (
+	ActiveGroupStatus   GroupStatus = "active"
+	InactiveGroupStatus GroupStatus = "inactive"
+	ArchivedGroupStatus GroupStatus = "archived"
+	DeletedGroupStatus  GroupStatus = "deleted"
+)
+
+// CanTransitionTo reports whether a group in status s may be moved to
+// status to, the next status of its lifecycle. An inactive group can also
+// be made active again. Groups without a status are active.
+func (s GroupStatus) CanTransitionTo(to GroupStatus) bool {
+	if s == "" {
+		s = ActiveGroupStatus
+	}
+
+	switch s {
+	case ActiveGroupStatus:
+		return to == InactiveGroupStatus
+	case InactiveGroupStatus:
+		return to == ActiveGroupStatus || to == ArchivedGroupStatus
+	case ArchivedGroupStatus:
+		return to == DeletedGroupStatus
+	default:
+		return false
+	}
+}
+
+// GroupAlertReason is why a group is listed as needing attention.
+type GroupAlertReason string
+
+const (
+	HighFailureRateAlertReason   GroupAlertReason = "high_failure_rate"
+	InactiveEndpointsAlertReason GroupAlertReason = "inactive_endpoints"
+)
 
 type Group struct {
 	ID                primitive.ObjectID `json:"-" bson:"_id"`
@@ -125,6 +230,18 @@ type Group struct {
 	RateLimit         int                `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string             `json:"rate_limit_duration" bson:"rate_limit_duration"`
 
+	// Status is the group's lifecycle status, only active groups take in
+	// events. Groups created before it was added have none and are active.
+	Status GroupStatus `json:"status,omitempty" bson:"status,omitempty"`
+
+	// Regions are where the group's data is meant to be replicated to,
+	// PrimaryRegion, one of them, is where it is written.
+	Regions       []string `json:"regions,omitempty" bson:"regions,omitempty"`
+	PrimaryRegion string   `json:"primary_region,omitempty" bson:"primary_region,omitempty"`
+
+	// AlertReasons is only set on groups listed as needing attention.
+	AlertReasons []GroupAlertReason `json:"alert_reasons,omitempty" bson:"-"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -137,25 +254,162 @@ type GroupConfig struct {
 	Signature       SignatureConfiguration `json:"signature"`
 	DisableEndpoint bool                   `json:"disable_endpoint"`
 	ReplayAttacks   bool                   `json:"replay_attacks"`
+	RetryBudget     *RetryBudgetConfig     `json:"retry_budget,omitempty"`
+
+	// CompressPayload gzips webhook bodies larger than CompressThresholdBytes,
+	// which defaults to DefaultCompressThresholdBytes when unset.
+	CompressPayload        bool `json:"compress_payload"`
+	CompressThresholdBytes int  `json:"compress_threshold_bytes,omitempty"`
+
+	MetaEvent *MetaEventConfiguration `json:"meta_event,omitempty"`
+
+	// MaxEventPayloadSizeBytes caps the request body of the events sent to
+	// this group at up to 5MB, larger ones are rejected before they are
+	// decoded. The server's event payload size limit still applies.
+	MaxEventPayloadSizeBytes int `json:"max_event_payload_size_bytes,omitempty"`
+
+	// RetentionPolicy is how long events are kept, e.g. "720h", before they
+	// are archived and deleted. Events are kept forever when it is unset.
+	RetentionPolicy string `json:"retention_policy,omitempty"`
+
+	// EventRetentionDays is how many days events are kept before the
+	// database expires them, unlike RetentionPolicy they aren't archived
+	// first. Events are kept forever when it is zero. Changing it moves the
+	// expiry of the group's existing events too. It is ignored when the
+	// group has a RetentionPolicy, so events are archived before they are
+	// deleted.
+	EventRetentionDays int `json:"event_retention_days,omitempty"`
+
+	OutboundProxy *ProxyConfig `json:"outbound_proxy,omitempty"`
+
+	// DeduplicationWindow is how long, e.g. "5m", an event is remembered
+	// so that an identical one sent for the same app is dropped as its
+	// duplicate. Duplicates are let through when it is unset.
+	DeduplicationWindow string `json:"deduplication_window,omitempty"`
+
+	// IdempotencyWindowDuration is how long, between 1m and 72h, an event's
+	// idempotency key is remembered, so that an event sent again with the
+	// same key returns the first one. It defaults to
+	// DefaultIdempotencyWindowDuration.
+	IdempotencyWindowDuration string `json:"idempotency_window_duration,omitempty"`
+
+	// CustomHeaders are added to every webhook sent for the group, the
+	// custom headers of an app take precedence over them.
+	CustomHeaders map[string]string `json:"custom_headers,omitempty"`
+
+	// EnforceEventTypes rejects events whose type is not registered for
+	// the group, and those whose data does not match the type's schema.
+	EnforceEventTypes bool `json:"enforce_event_types"`
 }
+
+const DefaultCompressThresholdBytes = 4096
+
+// DefaultIdempotencyWindowDuration is the idempotency window of groups
+// that don't set one.
+const DefaultIdempotencyWindowDuration = "24h"
+
+// ProxyConfig routes a group's webhook requests through an HTTP proxy,
+// save for those to hosts in NoProxyHosts. A host there may start with a
+// wildcard, e.g. *.internal. Password is stored encrypted.
+type ProxyConfig struct {
+	URL          string   `json:"url"`
+	Username     string   `json:"username,omitempty"`
+	Password     string   `json:"password,omitempty"`
+	NoProxyHosts []string `json:"no_proxy_hosts,omitempty"`
+}
+
+// RetryBudgetConfig caps the total number of delivery attempts a group
+// may make in a single hour window, across all of its endpoints.
+type RetryBudgetConfig struct {
+	MaxAttemptsPerHour uint `json:"max_attempts_per_hour"`
+}
+
+type MetaEventType string
+
+const (
+	DeliverySuccessMetaEvent      MetaEventType = "eventdelivery.success"
+	DeliveryDeadLetteredMetaEvent MetaEventType = "eventdelivery.dead_lettered"
+	EndpointDisabledMetaEvent     MetaEventType = "endpoint.disabled"
+	ApplicationUnhealthyMetaEvent MetaEventType = "application.unhealthy"
+)
+
+func (m MetaEventType) IsValid() bool {
+	switch m {
+	case DeliverySuccessMetaEvent,
+		DeliveryDeadLetteredMetaEvent,
+		EndpointDisabledMetaEvent,
+		ApplicationUnhealthyMetaEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaskedSecret is returned in place of the secrets of a group's config,
+// sending it back keeps the stored secret.
+const MaskedSecret = "********"
+
+// MetaEventConfiguration tells convoy where to report delivery lifecycle
+// changes of a group. Meta events are signed with Secret using the group's
+// signature config, it is stored encrypted and masked in responses.
+type MetaEventConfiguration struct {
+	URL        string          `json:"url" valid:"required~please provide a meta event url,url~please provide a valid meta event url"`
+	Secret     string          `json:"secret" valid:"required~please provide a meta event secret"`
+	EventTypes []MetaEventType `json:"event_types"`
+}
+
+func (m *MetaEventConfiguration) IsSubscribed(eventType MetaEventType) bool {
+	for _, t := range m.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
 type StrategyConfiguration struct {
-	Type               config.StrategyProvider                 `json:"type" valid:"required~please provide a valid strategy type, in(default)~unsupported strategy type"`
+	Type               config.StrategyProvider                 `json:"type"`
 	Default            DefaultStrategyConfiguration            `json:"default"`
 	ExponentialBackoff ExponentialBackoffStrategyConfiguration `json:"exponentialBackoff,omitempty"`
+	Linear             LinearStrategyConfiguration             `json:"linear,omitempty"`
 }
 
 type DefaultStrategyConfiguration struct {
-	IntervalSeconds uint64 `json:"intervalSeconds" valid:"required~please provide a valid interval seconds,int"`
-	RetryLimit      uint64 `json:"retryLimit" valid:"required~please provide a valid interval seconds,int"`
+	IntervalSeconds uint64 `json:"intervalSeconds"`
+	RetryLimit      uint64 `json:"retryLimit"`
 }
 
 type ExponentialBackoffStrategyConfiguration struct {
 	RetryLimit uint64 `json:"retryLimit"`
 }
 
+// LinearStrategyConfiguration waits InitialIntervalSeconds before the
+// first retry and StepSeconds longer before each one after it.
+type LinearStrategyConfiguration struct {
+	InitialIntervalSeconds uint64 `json:"initialIntervalSeconds"`
+	StepSeconds            uint64 `json:"stepSeconds"`
+	RetryLimit             uint64 `json:"retryLimit"`
+}
+
 type SignatureConfiguration struct {
 	Header config.SignatureHeaderProvider `json:"header" valid:"required~please provide a valid signature header"`
 	Hash   string                         `json:"hash" valid:"required~please provide a valid hash,supported_hash~unsupported hash type"`
+
+	// Algorithms are hashes webhooks are also signed with, e.g. while
+	// consumers migrate to a stricter one. Each signature is sent in the
+	// header named by AlgorithmSignatureHeader.
+	Algorithms []string `json:"algorithms,omitempty"`
+}
+
+// MaxSignatureAlgorithms is how many additional signatures a webhook may
+// carry.
+const MaxSignatureAlgorithms = 2
+
+// AlgorithmSignatureHeader returns the header the signature computed with
+// algorithm is sent in, e.g. X-Convoy-Signature-SHA512.
+func AlgorithmSignatureHeader(header config.SignatureHeaderProvider, algorithm string) string {
+	return string(header) + "-" + strings.ToUpper(algorithm)
 }
 
 type SignatureValues struct {
@@ -164,16 +418,91 @@ type SignatureValues struct {
 }
 
 type GroupStatistics struct {
-	MessagesSent int64 `json:"messages_sent"`
-	TotalApps    int64 `json:"total_apps"`
+	MessagesSent      int64 `json:"messages_sent"`
+	TotalApps         int64 `json:"total_apps"`
+	PendingDeliveries int64 `json:"pending_deliveries"`
+
+	// MessagesByType breaks MessagesSent down by event type, it is only
+	// filled when asked for
+	MessagesByType map[string]int64 `json:"messages_by_type,omitempty"`
+}
+
+// DeliveryStatistics summarises the event deliveries of an app. Latency
+// is the time taken for a delivery to succeed, from when it was created.
+type DeliveryStatistics struct {
+	Attempted        int64 `json:"attempted"`
+	Successful       int64 `json:"successful"`
+	Failed           int64 `json:"failed"`
+	AverageLatencyMs int64 `json:"average_latency_ms"`
+}
+
+const (
+	ApplicationSortCreatedAt = "created_at"
+	ApplicationSortTitle     = "title"
+)
+
+const (
+	ApplicationStatusActive   = "active"
+	ApplicationStatusInactive = "inactive"
+	ApplicationStatusAll      = "all"
+)
+
+// ApplicationFilter narrows down a listing of applications. Query matches
+// a substring of the title regardless of case, IsDisabled is ignored when
+// nil and SortBy is one of the ApplicationSort fields. Status is one of the
+// ApplicationStatus values, only active apps are listed when it is empty.
+// Soft-deleted apps are listed along with the others when ShowDeleted is set.
+type ApplicationFilter struct {
+	Query       string
+	OwnerID     string
+	IsDisabled  *bool
+	SortBy      string
+	Status      string
+	ShowDeleted bool
+}
+
+// DocumentStatuses returns the document statuses of the apps f lists.
+func (f *ApplicationFilter) DocumentStatuses() []DocumentStatus {
+	var statuses []DocumentStatus
+	switch f.Status {
+	case ApplicationStatusInactive:
+		statuses = []DocumentStatus{InactiveDocumentStatus}
+	case ApplicationStatusAll:
+		statuses = []DocumentStatus{ActiveDocumentStatus, InactiveDocumentStatus}
+	default:
+		statuses = []DocumentStatus{ActiveDocumentStatus}
+	}
+
+	if f.ShowDeleted {
+		statuses = append(statuses, DeletedDocumentStatus)
+	}
+
+	return statuses
+}
+
+func (f *ApplicationFilter) WithQueryTrimmed() *ApplicationFilter {
+	af := *f
+	af.Query = strings.TrimSpace(f.Query)
+	af.OwnerID = strings.TrimSpace(f.OwnerID)
+
+	if af.SortBy == "" {
+		af.SortBy = ApplicationSortCreatedAt
+	}
+
+	return &af
 }
 
 type GroupFilter struct {
 	Names []string `json:"name" bson:"name"`
+
+	// Cursor and PerPage page through the groups newest first, a zero
+	// PerPage loads every group.
+	Cursor  *Cursor `json:"-" bson:"-"`
+	PerPage int     `json:"-" bson:"-"`
 }
 
 func (g *GroupFilter) WithNamesTrimmed() *GroupFilter {
-	f := GroupFilter{[]string{}}
+	f := GroupFilter{Names: []string{}, Cursor: g.Cursor, PerPage: g.PerPage}
 
 	for _, s := range g.Names {
 		f.Names = append(f.Names, strings.TrimSpace(s))
@@ -184,8 +513,61 @@ func (g *GroupFilter) WithNamesTrimmed() *GroupFilter {
 
 func (o *Group) IsDeleted() bool { return o.DeletedAt > 0 }
 
+func (o *Group) IsActive() bool { return o.Status == "" || o.Status == ActiveGroupStatus }
+
 func (o *Group) IsOwner(a *Application) bool { return o.UID == a.GroupID }
 
+// EventTTLDays is how many days the database keeps the group's events
+// before it expires them, zero when it never does. Groups with a
+// RetentionPolicy are left to the retention job, which archives their
+// events before deleting them.
+func (o *Group) EventTTLDays() int {
+	if o.Config == nil || o.Config.EventRetentionDays <= 0 || strings.TrimSpace(o.Config.RetentionPolicy) != "" {
+		return 0
+	}
+
+	return o.Config.EventRetentionDays
+}
+
+// EventExpiry returns when an event of the group created at createdAt, and
+// its deliveries, expire. It is zero, they never expire, when the group has
+// no EventTTLDays.
+func (o *Group) EventExpiry(createdAt time.Time) primitive.DateTime {
+	days := o.EventTTLDays()
+	if days == 0 {
+		return 0
+	}
+
+	return primitive.NewDateTimeFromTime(createdAt.AddDate(0, 0, days))
+}
+
+// DeliveryRateLimit returns the tighter of the endpoint's and g's rate
+// limits, the one allowing fewer requests per second. Limits that aren't
+// set or don't parse are ignored.
+func (e *Endpoint) DeliveryRateLimit(g *Group) (int, string) {
+	endpointDuration, err := time.ParseDuration(e.RateLimitDuration)
+	hasEndpointLimit := err == nil && e.RateLimit > 0 && endpointDuration > 0
+
+	if g == nil || g.RateLimit <= 0 {
+		return e.RateLimit, e.RateLimitDuration
+	}
+
+	groupDuration, err := time.ParseDuration(g.RateLimitDuration)
+	if err != nil || groupDuration <= 0 {
+		return e.RateLimit, e.RateLimitDuration
+	}
+
+	if !hasEndpointLimit {
+		return g.RateLimit, g.RateLimitDuration
+	}
+
+	if float64(g.RateLimit)/groupDuration.Seconds() < float64(e.RateLimit)/endpointDuration.Seconds() {
+		return g.RateLimit, g.RateLimitDuration
+	}
+
+	return e.RateLimit, e.RateLimitDuration
+}
+
 var (
 	ErrEventNotFound = errors.New("event not found")
 )
@@ -203,6 +585,9 @@ type AppMetadata struct {
 // Makes it easy to filter by a list of events
 type EventType string
 
+// DefaultEventVersion is the version of events sent without one.
+const DefaultEventVersion = "1"
+
 //Event defines a payload to be sent to an application
 type Event struct {
 	ID               primitive.ObjectID `json:"-" bson:"_id"`
@@ -210,18 +595,56 @@ type Event struct {
 	EventType        EventType          `json:"event_type" bson:"event_type"`
 	MatchedEndpoints int                `json:"matched_endpoints" bson:"matched_enpoints"`
 
+	// EventVersion is the version of the event's schema, apps using
+	// versioned endpoints get each version at its own path
+	EventVersion string `json:"event_version" bson:"event_version,omitempty"`
+
 	// ProviderID is a custom ID that can be used to reconcile this Event
 	// with your internal systems.
 	// This is optional
 	// If not provided, we will generate one for you
 	ProviderID string `json:"provider_id" bson:"provider_id"`
 
+	// Metadata holds key-value pairs set by the producer of the event,
+	// events can be filtered by them
+	Metadata map[string]string `json:"metadata,omitempty" bson:"metadata,omitempty"`
+
+	// Tags label an event so it can be found, and its deliveries retried,
+	// later on. They are copied onto the event's deliveries
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data" bson:"data"`
 
+	// SearchText holds the distinct lowercase words of Data's keys and
+	// values, separated by spaces, used for payload search since Data
+	// is persisted as binary
+	SearchText string `json:"-" bson:"search_text,omitempty"`
+
 	AppMetadata *AppMetadata `json:"app_metadata,omitempty" bson:"app_metadata"`
 
+	// Duplicate marks an event returned in place of an identical one sent
+	// within its group's deduplication window. It is never stored
+	Duplicate bool `json:"duplicate,omitempty" bson:"-"`
+
+	// BridgedFrom is the ID of the event this one was forwarded from by an
+	// event bridge rule. Bridged events are not forwarded again
+	BridgedFrom string `json:"bridged_from,omitempty" bson:"bridged_from,omitempty"`
+
+	// BridgedAt is when the event was forwarded by the event bridge rules
+	// of its group, it is only ever forwarded once
+	BridgedAt primitive.DateTime `json:"bridged_at,omitempty" bson:"bridged_at,omitempty" swaggertype:"string"`
+
+	// TraceParent and TraceState are the W3C trace context of the request
+	// that created the event.
+	TraceParent string `json:"trace_parent,omitempty" bson:"trace_parent,omitempty"`
+	TraceState  string `json:"trace_state,omitempty" bson:"trace_state,omitempty"`
+
+	// ExpiresAt is when the database deletes the event, it is set from its
+	// group's EventRetentionDays when the event is created.
+	ExpiresAt primitive.DateTime `json:"-" bson:"expires_at,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -235,6 +658,8 @@ type HttpHeader map[string]string
 var (
 	ErrEventDeliveryNotFound        = errors.New("event not found")
 	ErrEventDeliveryAttemptNotFound = errors.New("delivery attempt not found")
+	ErrIllegalStatusTransition      = errors.New("illegal event delivery status transition")
+	ErrEventSearchTimeout           = errors.New("event search took too long")
 )
 
 const (
@@ -245,8 +670,28 @@ const (
 	FailureEventStatus    EventDeliveryStatus = "Failure"
 	SuccessEventStatus    EventDeliveryStatus = "Success"
 	RetryEventStatus      EventDeliveryStatus = "Retry"
+	// PostponedEventStatus : when the group's retry budget has been exhausted
+	// and the delivery has been pushed to the next window
+	PostponedEventStatus EventDeliveryStatus = "Postponed"
+	// HeldEventStatus : when the delivery's app is paused, it is released
+	// to the queue once the app is resumed
+	HeldEventStatus EventDeliveryStatus = "Held"
 )
 
+// StuckDeliveryDescription is the description of a delivery that was
+// failed after being stuck in processing.
+const StuckDeliveryDescription = "Delivery was stuck in processing"
+
+// PendingEventStatuses are the statuses of deliveries that are still
+// waiting in the queue to be sent. Held deliveries are left out, they are
+// not queued until their app is resumed.
+var PendingEventStatuses = []EventDeliveryStatus{
+	ScheduledEventStatus,
+	ProcessingEventStatus,
+	RetryEventStatus,
+	PostponedEventStatus,
+}
+
 func (e EventDeliveryStatus) IsValid() bool {
 	switch e {
 	case ScheduledEventStatus,
@@ -254,13 +699,22 @@ func (e EventDeliveryStatus) IsValid() bool {
 		DiscardedEventStatus,
 		FailureEventStatus,
 		SuccessEventStatus,
-		RetryEventStatus:
+		RetryEventStatus,
+		PostponedEventStatus,
+		HeldEventStatus:
 		return true
 	default:
 		return false
 	}
 }
 
+// CanTransitionTo reports whether a delivery in status e may be moved to
+// status to. A successful delivery is never moved back, it is only sent
+// again by a force resend which replaces its status outright.
+func (e EventDeliveryStatus) CanTransitionTo(to EventDeliveryStatus) bool {
+	return e != SuccessEventStatus && e != to && to.IsValid()
+}
+
 type Metadata struct {
 	// Data to be sent to endpoint.
 	Data     json.RawMessage         `json:"data" bson:"data"`
@@ -275,6 +729,10 @@ type Metadata struct {
 
 	IntervalSeconds uint64 `json:"interval_seconds" bson:"interval_seconds"`
 
+	// StepSeconds is how much longer each retry of the linear strategy
+	// waits than the one before it.
+	StepSeconds uint64 `json:"step_seconds,omitempty" bson:"step_seconds,omitempty"`
+
 	RetryLimit uint64 `json:"retry_limit" bson:"retry_limit"`
 }
 
@@ -311,8 +769,15 @@ type EventInterval struct {
 }
 
 type EventMetadata struct {
-	UID       string    `json:"uid" bson:"uid"`
-	EventType EventType `json:"name" bson:"name"`
+	UID          string    `json:"uid" bson:"uid"`
+	EventType    EventType `json:"name" bson:"name"`
+	EventVersion string    `json:"event_version,omitempty" bson:"event_version,omitempty"`
+	Tags         []string  `json:"tags,omitempty" bson:"tags,omitempty"`
+
+	// TraceParent and TraceState are the W3C trace context of the event,
+	// they are sent along with its webhooks.
+	TraceParent string `json:"trace_parent,omitempty" bson:"trace_parent,omitempty"`
+	TraceState  string `json:"trace_state,omitempty" bson:"trace_state,omitempty"`
 }
 
 type DeliveryAttempt struct {
@@ -325,6 +790,9 @@ type DeliveryAttempt struct {
 	APIVersion string             `json:"api_version" bson:"api_version"`
 
 	IPAddress        string     `json:"ip_address,omitempty" bson:"ip_address,omitempty"`
+	DNSLookupMs      int64      `json:"dns_lookup_ms,omitempty" bson:"dns_lookup_ms,omitempty"`
+	ConnectMs        int64      `json:"connect_ms,omitempty" bson:"connect_ms,omitempty"`
+	TLSHandshakeMs   int64      `json:"tls_handshake_ms,omitempty" bson:"tls_handshake_ms,omitempty"`
 	RequestHeader    HttpHeader `json:"request_http_header,omitempty" bson:"request_http_header,omitempty"`
 	ResponseHeader   HttpHeader `json:"response_http_header,omitempty" bson:"response_http_header,omitempty"`
 	HttpResponseCode string     `json:"http_status,omitempty" bson:"http_status,omitempty"`
@@ -332,6 +800,10 @@ type DeliveryAttempt struct {
 	Error            string     `json:"error,omitempty" bson:"error,omitempty"`
 	Status           bool       `json:"status,omitempty" bson:"status,omitempty"`
 
+	// Region is where the attempt was sent from, its latency depends on
+	// it.
+	Region string `json:"region,omitempty" bson:"region,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -352,6 +824,14 @@ type EventDelivery struct {
 	Status           EventDeliveryStatus `json:"status" bson:"status"`
 	DeliveryAttempts []DeliveryAttempt   `json:"-" bson:"attempts"`
 
+	// IsMetaEvent marks the deliveries of a group's meta events, they are
+	// sent to its meta event url rather than to an app's endpoint.
+	IsMetaEvent bool `json:"is_meta_event,omitempty" bson:"is_meta_event,omitempty"`
+
+	// ExpiresAt is when the database deletes the delivery, like the
+	// ExpiresAt of its event.
+	ExpiresAt primitive.DateTime `json:"-" bson:"expires_at,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -375,9 +855,143 @@ type APIKey struct {
 	Salt      string             `json:"salt,omitempty" bson:"salt"`
 	Type      KeyType            `json:"key_type" bson:"key_type"`
 	ExpiresAt primitive.DateTime `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+
+	// DailyQuota is how many events the key can send a day, zero is
+	// unlimited.
+	DailyQuota int64 `json:"daily_quota,omitempty" bson:"daily_quota,omitempty"`
+
+	// RateLimit is how many api requests the key can make every api rate
+	// limit duration, zero uses the server's api rate limit.
+	RateLimit int `json:"rate_limit,omitempty" bson:"rate_limit,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at"`
 	DeletedAt primitive.DateTime `json:"delted_at,omitempty" bson:"deleted_at"`
 
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionRevoke = "revoke"
+)
+
+const APIKeyAuditResourceType = "api_key"
+
+// AuditLog records an operation performed on a resource and who performed it.
+type AuditLog struct {
+	ID           primitive.ObjectID `json:"-" bson:"_id"`
+	UID          string             `json:"uid" bson:"uid"`
+	Action       string             `json:"action" bson:"action"`
+	ActorID      string             `json:"actor_id" bson:"actor_id"`
+	ResourceType string             `json:"resource_type" bson:"resource_type"`
+	ResourceID   string             `json:"resource_id" bson:"resource_id"`
+	Timestamp    primitive.DateTime `json:"timestamp" bson:"timestamp" swaggertype:"string"`
+	Metadata     map[string]string  `json:"metadata,omitempty" bson:"metadata,omitempty"`
+}
+
+var ErrArchiveManifestNotFound = errors.New("archive manifest not found")
+
+// ArchiveManifest describes a batch of events, and their deliveries, that
+// was uploaded to object storage before being deleted. ObjectKey is relative
+// to the archive prefix in the server config.
+type ArchiveManifest struct {
+	ID                 primitive.ObjectID `json:"-" bson:"_id"`
+	UID                string             `json:"uid" bson:"uid"`
+	GroupID            string             `json:"group_id" bson:"group_id"`
+	Bucket             string             `json:"bucket" bson:"bucket"`
+	ObjectKey          string             `json:"object_key" bson:"object_key"`
+	EventCount         int                `json:"event_count" bson:"event_count"`
+	EventDeliveryCount int                `json:"event_delivery_count" bson:"event_delivery_count"`
+	OldestEventAt      primitive.DateTime `json:"oldest_event_at" bson:"oldest_event_at" swaggertype:"string"`
+	NewestEventAt      primitive.DateTime `json:"newest_event_at" bson:"newest_event_at" swaggertype:"string"`
+	CreatedAt          primitive.DateTime `json:"created_at" bson:"created_at" swaggertype:"string"`
+	RestoredAt         primitive.DateTime `json:"restored_at,omitempty" bson:"restored_at,omitempty" swaggertype:"string"`
+}
+
+var ErrExportJobNotFound = errors.New("export job not found")
+
+type ExportJobStatus string
+
+const (
+	PendingExportJobStatus   ExportJobStatus = "pending"
+	CompletedExportJobStatus ExportJobStatus = "completed"
+	FailedExportJobStatus    ExportJobStatus = "failed"
+)
+
+// ExportJob is an export run in the background. Its file is uploaded to
+// ObjectKey when object storage is configured, and kept in the export
+// directory of the server that ran it otherwise. The job and its file are
+// removed once ExpiresAt passes.
+type ExportJob struct {
+	ID        primitive.ObjectID `json:"-" bson:"_id"`
+	UID       string             `json:"uid" bson:"uid"`
+	GroupID   string             `json:"group_id" bson:"group_id"`
+	Type      string             `json:"type" bson:"type"`
+	Format    string             `json:"format" bson:"format"`
+	Status    ExportJobStatus    `json:"status" bson:"status"`
+	Rows      int64              `json:"rows" bson:"rows"`
+	Error     string             `json:"error,omitempty" bson:"error,omitempty"`
+	ObjectKey string             `json:"-" bson:"object_key,omitempty"`
+	CreatedAt primitive.DateTime `json:"created_at" bson:"created_at" swaggertype:"string"`
+	ExpiresAt primitive.DateTime `json:"expires_at" bson:"expires_at" swaggertype:"string"`
+}
+
+var ErrEventBridgeRuleNotFound = errors.New("event bridge rule not found")
+
+// EventBridgeRule forwards the events of a group whose type matches
+// EventTypeFilter to the apps of another group once they are delivered.
+// TransformTemplate, when set, is a text/template executed with the event
+// data to produce the data of the forwarded event.
+type EventBridgeRule struct {
+	ID                primitive.ObjectID `json:"-" bson:"_id"`
+	UID               string             `json:"uid" bson:"uid"`
+	SourceGroupID     string             `json:"source_group_id" bson:"source_group_id"`
+	DestGroupID       string             `json:"dest_group_id" bson:"dest_group_id"`
+	EventTypeFilter   []string           `json:"event_type_filter" bson:"event_type_filter"`
+	TransformTemplate string             `json:"transform_template,omitempty" bson:"transform_template,omitempty"`
+	CreatedAt         primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt         primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+}
+
+// Matches reports whether events of type eventType are forwarded by r.
+// A filter of "*" matches every type.
+func (r *EventBridgeRule) Matches(eventType EventType) bool {
+	for _, v := range r.EventTypeFilter {
+		if v == string(eventType) || v == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+var ErrEventTypeNotFound = errors.New("event type not found")
+
+// EventTypeDefinition registers an event type consumers of a group can
+// expect. Schema, when set, is the JSON Schema the data of events of the
+// type has to match once the group enforces its event types.
+type EventTypeDefinition struct {
+	ID          primitive.ObjectID `json:"-" bson:"_id"`
+	UID         string             `json:"uid" bson:"uid"`
+	GroupID     string             `json:"group_id" bson:"group_id"`
+	Name        string             `json:"name" bson:"name"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Schema      json.RawMessage    `json:"schema,omitempty" bson:"schema,omitempty" swaggertype:"object"`
+	CreatedAt   primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt   primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+}
+
+var ErrGlobalSettingsNotFound = errors.New("global settings not found")
+
+// GlobalSettings holds the defaults new groups are created with when they
+// don't set their own. There is only ever one document.
+type GlobalSettings struct {
+	ID                 primitive.ObjectID `json:"-" bson:"_id"`
+	UID                string             `json:"-" bson:"uid"`
+	RateLimit          int                `json:"rate_limit" bson:"rate_limit"`
+	RateLimitDuration  string             `json:"rate_limit_duration" bson:"rate_limit_duration"`
+	EventRetentionDays int                `json:"event_retention_days" bson:"event_retention_days"`
+	UpdatedAt          primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+}
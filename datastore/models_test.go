@@ -70,3 +70,155 @@ func TestGroup_IsOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupStatus_CanTransitionTo(t *testing.T) {
+	tt := []struct {
+		from, to GroupStatus
+		allowed  bool
+	}{
+		{from: "", to: InactiveGroupStatus, allowed: true},
+		{from: ActiveGroupStatus, to: InactiveGroupStatus, allowed: true},
+		{from: InactiveGroupStatus, to: ActiveGroupStatus, allowed: true},
+		{from: InactiveGroupStatus, to: ArchivedGroupStatus, allowed: true},
+		{from: ArchivedGroupStatus, to: DeletedGroupStatus, allowed: true},
+		{from: ActiveGroupStatus, to: ArchivedGroupStatus},
+		{from: ActiveGroupStatus, to: ActiveGroupStatus},
+		{from: ArchivedGroupStatus, to: ActiveGroupStatus},
+		{from: DeletedGroupStatus, to: ActiveGroupStatus},
+		{from: InactiveGroupStatus, to: "paused"},
+	}
+
+	for _, tc := range tt {
+		t.Run(string(tc.from)+"_to_"+string(tc.to), func(t *testing.T) {
+			require.Equal(t, tc.allowed, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestEndpoint_DeliveryRateLimit(t *testing.T) {
+	tt := []struct {
+		name         string
+		endpoint     *Endpoint
+		group        *Group
+		wantLimit    int
+		wantDuration string
+	}{
+		{
+			name:         "endpoint limit is tighter",
+			endpoint:     &Endpoint{RateLimit: 60, RateLimitDuration: "1m0s"},
+			group:        &Group{RateLimit: 5000, RateLimitDuration: "1m"},
+			wantLimit:    60,
+			wantDuration: "1m0s",
+		},
+		{
+			name:         "group limit is tighter",
+			endpoint:     &Endpoint{RateLimit: 100, RateLimitDuration: "1s"},
+			group:        &Group{RateLimit: 5000, RateLimitDuration: "1m"},
+			wantLimit:    5000,
+			wantDuration: "1m",
+		},
+		{
+			name:         "compares rates across durations",
+			endpoint:     &Endpoint{RateLimit: 2, RateLimitDuration: "1s"},
+			group:        &Group{RateLimit: 100, RateLimitDuration: "1m"},
+			wantLimit:    100,
+			wantDuration: "1m",
+		},
+		{
+			name:         "endpoint without a limit",
+			endpoint:     &Endpoint{},
+			group:        &Group{RateLimit: 5000, RateLimitDuration: "1m"},
+			wantLimit:    5000,
+			wantDuration: "1m",
+		},
+		{
+			name:         "group without a limit",
+			endpoint:     &Endpoint{RateLimit: 60, RateLimitDuration: "1m0s"},
+			group:        &Group{},
+			wantLimit:    60,
+			wantDuration: "1m0s",
+		},
+		{
+			name:         "no group",
+			endpoint:     &Endpoint{RateLimit: 60, RateLimitDuration: "1m0s"},
+			wantLimit:    60,
+			wantDuration: "1m0s",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, duration := tc.endpoint.DeliveryRateLimit(tc.group)
+			require.Equal(t, tc.wantLimit, limit)
+			require.Equal(t, tc.wantDuration, duration)
+		})
+	}
+}
+
+func TestGroup_EventExpiry(t *testing.T) {
+	createdAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name  string
+		group *Group
+		want  primitive.DateTime
+	}{
+		{
+			name:  "no config",
+			group: &Group{UID: "123456"},
+		},
+		{
+			name:  "no retention",
+			group: &Group{UID: "123456", Config: &GroupConfig{}},
+		},
+		{
+			name:  "retention days",
+			group: &Group{UID: "123456", Config: &GroupConfig{EventRetentionDays: 7}},
+			want:  primitive.NewDateTimeFromTime(time.Date(2022, 3, 8, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "retention days with retention policy",
+			group: &Group{UID: "123456", Config: &GroupConfig{EventRetentionDays: 60, RetentionPolicy: "720h"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.group.EventExpiry(createdAt))
+		})
+	}
+}
+
+func TestUncountedPaginationData(t *testing.T) {
+	tt := []struct {
+		name string
+		page int64
+		n    int
+		want PaginationData
+	}{
+		{
+			name: "first full page",
+			page: 1,
+			n:    10,
+			want: PaginationData{Total: -1, Page: 1, PerPage: 10, Prev: 0, Next: 2, TotalPage: -1},
+		},
+		{
+			name: "middle page",
+			page: 3,
+			n:    10,
+			want: PaginationData{Total: -1, Page: 3, PerPage: 10, Prev: 2, Next: 4, TotalPage: -1},
+		},
+		{
+			name: "last page",
+			page: 4,
+			n:    3,
+			want: PaginationData{Total: -1, Page: 4, PerPage: 10, Prev: 3, Next: 0, TotalPage: -1},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, UncountedPaginationData(tc.page, 10, tc.n))
+		})
+	}
+}
@@ -90,12 +90,25 @@ func (db *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, hash string) (*datas
 }
 
 func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
-	var apiKeys []datastore.APIKey
-
 	filter := bson.M{"$or": bson.A{
 		bson.M{"document_status": datastore.ActiveDocumentStatus},
 	}}
 
+	return db.loadAPIKeysPaged(ctx, filter, pageable)
+}
+
+func (db *apiKeyRepo) FindAPIKeysByGroupID(ctx context.Context, groupID string, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	filter := bson.M{
+		"role.groups":     groupID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	return db.loadAPIKeysPaged(ctx, filter, pageable)
+}
+
+func (db *apiKeyRepo) loadAPIKeysPaged(ctx context.Context, filter bson.M, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	var apiKeys []datastore.APIKey
+
 	paginatedData, err := pager.
 		New(db.client).
 		Context(ctx).
@@ -110,5 +123,9 @@ func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.
 		return nil, datastore.PaginationData{}, err
 	}
 
-	return apiKeys, datastore.PaginationData(paginatedData.Pagination), nil
+	return apiKeys, toPaginationData(paginatedData.Pagination), nil
+}
+
+func (db *apiKeyRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return purgeDeleted(ctx, db.client, deletedBefore, limit, nil)
 }
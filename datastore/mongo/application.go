@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -36,19 +37,21 @@ func (db *appRepo) CreateApplication(ctx context.Context,
 	return err
 }
 
-func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID string, f *datastore.ApplicationFilter, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	filter := getAppsFilter(groupID, f)
 
-	filter := bson.M{"document_status": datastore.ActiveDocumentStatus}
-	if !util.IsStringEmpty(groupID) {
-		filter = bson.M{"group_id": groupID, "document_status": datastore.ActiveDocumentStatus}
+	sortBy := "created_at"
+	if f.SortBy == datastore.ApplicationSortTitle {
+		sortBy = "title"
 	}
 
-	if !util.IsStringEmpty(q) {
-		filter = bson.M{"group_id": groupID, "document_status": datastore.ActiveDocumentStatus, "title": bson.M{"$regex": primitive.Regex{Pattern: q, Options: "i"}}}
+	order := pageable.Sort
+	if order == 0 {
+		order = -1
 	}
 
 	var apps []datastore.Application
-	paginatedData, err := pager.New(db.client).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", -1).Filter(filter).Decode(&apps).Find()
+	paginatedData, err := pager.New(regional(ctx, db.client)).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort(sortBy, order).Filter(filter).Decode(&apps).Find()
 	if err != nil {
 		return apps, datastore.PaginationData{}, err
 	}
@@ -68,7 +71,30 @@ func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string,
 		apps[i].Events = count
 	}
 
-	return apps, datastore.PaginationData(paginatedData.Pagination), nil
+	return apps, toPaginationData(paginatedData.Pagination), nil
+}
+
+func getAppsFilter(groupID string, f *datastore.ApplicationFilter) bson.M {
+	filter := bson.M{"document_status": bson.M{"$in": f.DocumentStatuses()}}
+
+	if !util.IsStringEmpty(groupID) {
+		filter["group_id"] = groupID
+	}
+
+	if !util.IsStringEmpty(f.Query) {
+		// the query is matched literally, never as a pattern
+		filter["title"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(f.Query), Options: "i"}}
+	}
+
+	if !util.IsStringEmpty(f.OwnerID) {
+		filter["owner_id"] = f.OwnerID
+	}
+
+	if f.IsDisabled != nil {
+		filter["is_disabled"] = *f.IsDisabled
+	}
+
+	return filter
 }
 
 func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
@@ -99,7 +125,7 @@ func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID s
 		applications[i].Events = count
 	}
 
-	return applications, datastore.PaginationData(paginatedData.Pagination), nil
+	return applications, toPaginationData(paginatedData.Pagination), nil
 }
 
 func (db *appRepo) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
@@ -177,7 +203,7 @@ func (db *appRepo) FindApplicationByID(ctx context.Context,
 
 	filter := bson.M{"uid": id, "document_status": datastore.ActiveDocumentStatus}
 
-	err := db.client.FindOne(ctx, filter).
+	err := regional(ctx, db.client).FindOne(ctx, filter).
 		Decode(&app)
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		err = datastore.ErrApplicationNotFound
@@ -198,7 +224,7 @@ func (db *appRepo) FindApplicationByID(ctx context.Context,
 
 func (db *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string, endpointID string) (*datastore.Endpoint, error) {
 
-	app, err := db.FindApplicationByID(context.Background(), appID)
+	app, err := db.FindApplicationByID(ctx, appID)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +241,49 @@ func findEndpoint(endpoints *[]datastore.Endpoint, id string) (*datastore.Endpoi
 	return nil, datastore.ErrEndpointNotFound
 }
 
+func (db *appRepo) FindApplicationsByOwnerID(ctx context.Context, groupID, ownerID string) ([]datastore.Application, error) {
+	filter := bson.M{
+		"group_id":        groupID,
+		"owner_id":        ownerID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	cursor, err := db.client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]datastore.Application, 0)
+	err = cursor.All(ctx, &apps)
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+func (db *appRepo) FindApplicationsByIDs(ctx context.Context, ids []string) ([]datastore.Application, error) {
+	filter := bson.M{
+		"uid": bson.M{
+			"$in": ids,
+		},
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	cursor, err := db.client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]datastore.Application, 0)
+	err = cursor.All(ctx, &apps)
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
 func (db *appRepo) UpdateApplication(ctx context.Context,
 	app *datastore.Application) error {
 
@@ -226,8 +295,11 @@ func (db *appRepo) UpdateApplication(ctx context.Context,
 		primitive.E{Key: "endpoints", Value: app.Endpoints},
 		primitive.E{Key: "updated_at", Value: app.UpdatedAt},
 		primitive.E{Key: "title", Value: app.Title},
+		primitive.E{Key: "owner_id", Value: app.OwnerID},
 		primitive.E{Key: "support_email", Value: app.SupportEmail},
 		primitive.E{Key: "is_disabled", Value: app.IsDisabled},
+		primitive.E{Key: "is_paused", Value: app.IsPaused},
+		primitive.E{Key: "paused_at", Value: app.PausedAt},
 	}}}
 
 	_, err := db.client.UpdateOne(ctx, filter, update)
@@ -239,11 +311,11 @@ func (db *appRepo) DeleteGroupApps(ctx context.Context, groupID string) error {
 	update := bson.M{
 		"$set": bson.M{
 			"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
-			"document_status": datastore.ActiveDocumentStatus,
+			"document_status": datastore.DeletedDocumentStatus,
 		},
 	}
 
-	_, err := db.client.UpdateMany(ctx, bson.M{"group_id": groupID}, update)
+	_, err := db.client.UpdateMany(ctx, bson.M{"group_id": groupID, "document_status": datastore.ActiveDocumentStatus}, update)
 	if err != nil {
 		return err
 	}
@@ -254,9 +326,27 @@ func (db *appRepo) DeleteGroupApps(ctx context.Context, groupID string) error {
 func (db *appRepo) DeleteApplication(ctx context.Context,
 	app *datastore.Application) error {
 
+	deletedAt := primitive.NewDateTimeFromTime(time.Now())
+
 	updateAsDeleted := bson.D{primitive.E{Key: "$set", Value: bson.D{
-		primitive.E{Key: "deleted_at", Value: primitive.NewDateTimeFromTime(time.Now())},
-		primitive.E{Key: "document_status", Value: datastore.ActiveDocumentStatus},
+		primitive.E{Key: "deleted_at", Value: deletedAt},
+		primitive.E{Key: "document_status", Value: datastore.DeletedDocumentStatus},
+	}}}
+
+	// the endpoints are deleted along with the app, those deleted before
+	// keep their deletion date so a restore leaves them deleted.
+	endpoints := make([]datastore.Endpoint, len(app.Endpoints))
+	copy(endpoints, app.Endpoints)
+	for i := range endpoints {
+		if endpoints[i].DeletedAt == 0 {
+			endpoints[i].DeletedAt = deletedAt
+		}
+	}
+
+	deleteAppUpdate := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "deleted_at", Value: deletedAt},
+		primitive.E{Key: "document_status", Value: datastore.DeletedDocumentStatus},
+		primitive.E{Key: "endpoints", Value: endpoints},
 	}}}
 
 	err := db.updateMessagesInApp(ctx, app, updateAsDeleted)
@@ -264,7 +354,7 @@ func (db *appRepo) DeleteApplication(ctx context.Context,
 		return err
 	}
 
-	err = db.deleteApp(ctx, app, updateAsDeleted)
+	err = db.deleteApp(ctx, app, deleteAppUpdate)
 	if err != nil {
 		log.Errorf("%s an error has occurred while deleting app - %s", app.UID, err)
 
@@ -282,6 +372,63 @@ func (db *appRepo) DeleteApplication(ctx context.Context,
 	return nil
 }
 
+func (db *appRepo) FindDeletedApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	filter := bson.M{"uid": id, "document_status": datastore.DeletedDocumentStatus}
+
+	err := db.client.FindOne(ctx, filter).Decode(&app)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, datastore.ErrApplicationNotFound
+	}
+
+	return app, err
+}
+
+func (db *appRepo) RestoreApplication(ctx context.Context, app *datastore.Application) error {
+	endpoints := make([]datastore.Endpoint, len(app.Endpoints))
+	copy(endpoints, app.Endpoints)
+	for i := range endpoints {
+		if endpoints[i].DeletedAt == app.DeletedAt {
+			endpoints[i].DeletedAt = 0
+		}
+	}
+
+	restore := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "document_status", Value: datastore.ActiveDocumentStatus},
+			primitive.E{Key: "endpoints", Value: endpoints},
+			primitive.E{Key: "updated_at", Value: primitive.NewDateTimeFromTime(time.Now())},
+		}},
+		primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "deleted_at", Value: ""},
+		}},
+	}
+
+	filter := bson.M{"uid": app.UID, "document_status": datastore.DeletedDocumentStatus}
+	res, err := db.client.UpdateOne(ctx, filter, restore)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return datastore.ErrApplicationNotFound
+	}
+
+	restoreMessages := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "document_status", Value: datastore.ActiveDocumentStatus},
+		}},
+		primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "deleted_at", Value: ""},
+		}},
+	}
+
+	msgFilter := bson.M{"app_metadata.uid": app.UID, "document_status": datastore.DeletedDocumentStatus}
+	_, err = db.innerDB.Collection(EventCollection).UpdateMany(ctx, msgFilter, restoreMessages)
+	return err
+}
+
 func (db *appRepo) updateMessagesInApp(ctx context.Context, app *datastore.Application, update bson.D) error {
 	var msgOperations []mongo.WriteModel
 
@@ -334,6 +481,11 @@ func (db *appRepo) UpdateApplicationEndpointsStatus(ctx context.Context, appId s
 	for i := 0; i < len(app.Endpoints); i++ {
 		if _, ok := m[app.Endpoints[i].UID]; ok {
 			app.Endpoints[i].Status = status
+
+			if status == datastore.ActiveEndpointStatus {
+				app.Endpoints[i].DisabledReason = ""
+				app.Endpoints[i].DisabledAt = 0
+			}
 		}
 	}
 
@@ -346,6 +498,53 @@ func (db *appRepo) UpdateApplicationEndpointsStatus(ctx context.Context, appId s
 	return err
 }
 
+func (db *appRepo) DisableApplicationEndpoint(ctx context.Context, appID, endpointID, reason string) error {
+	filter := bson.M{
+		"uid":             appID,
+		"endpoints.uid":   endpointID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{"$set": bson.M{
+		"endpoints.$.status":          datastore.InactiveEndpointStatus,
+		"endpoints.$.disabled_reason": reason,
+		"endpoints.$.disabled_at":     now,
+		"updated_at":                  now,
+	}}
+
+	res, err := db.client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return datastore.ErrEndpointNotFound
+	}
+
+	return nil
+}
+
+func (db *appRepo) UpdateApplicationHealth(ctx context.Context, appID string, score float64, status datastore.ApplicationHealthStatus) error {
+	filter := bson.M{"uid": appID, "document_status": datastore.ActiveDocumentStatus}
+	update := bson.M{"$set": bson.M{
+		"health_score":      score,
+		"health_status":     status,
+		"last_health_check": primitive.NewDateTimeFromTime(time.Now()),
+	}}
+
+	res, err := db.client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return datastore.ErrApplicationNotFound
+	}
+
+	return nil
+}
+
 func parseMapOfUIDs(ids []string) map[string]bool {
 	elementMap := make(map[string]bool)
 	for i := 0; i < len(ids); i++ {
@@ -353,3 +552,32 @@ func parseMapOfUIDs(ids []string) map[string]bool {
 	}
 	return elementMap
 }
+
+func (db *appRepo) FindGroupsWithInactiveEndpoints(ctx context.Context) ([]string, error) {
+	filter := bson.M{
+		"document_status": datastore.ActiveDocumentStatus,
+		"endpoints": bson.M{"$elemMatch": bson.M{
+			"status":          datastore.InactiveEndpointStatus,
+			"document_status": datastore.ActiveDocumentStatus,
+		}},
+	}
+
+	values, err := db.client.Distinct(ctx, "group_id", filter)
+	if err != nil {
+		log.WithError(err).Error("failed to find groups with inactive endpoints")
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (db *appRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return purgeDeleted(ctx, db.client, deletedBefore, limit, nil)
+}
@@ -76,7 +76,7 @@ func Test_LoadApplicationsPaged(t *testing.T) {
 
 	appRepo := NewApplicationRepo(db)
 
-	apps, _, err := appRepo.LoadApplicationsPaged(context.Background(), "", "", datastore.Pageable{
+	apps, _, err := appRepo.LoadApplicationsPaged(context.Background(), "", &datastore.ApplicationFilter{}, datastore.Pageable{
 		Page:    1,
 		PerPage: 10,
 	})
@@ -85,6 +85,94 @@ func Test_LoadApplicationsPaged(t *testing.T) {
 	require.True(t, len(apps) > 0)
 }
 
+func Test_LoadApplicationsPaged_Filters(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := NewApplicationRepo(db)
+	groupID := uuid.NewString()
+
+	apps := []*datastore.Application{
+		{Title: "Billing (EU)", OwnerID: "cus_1"},
+		{Title: "billing us", OwnerID: "cus_1", IsDisabled: true},
+		{Title: "Shipping", OwnerID: "cus_2"},
+	}
+
+	for _, app := range apps {
+		app.UID = uuid.NewString()
+		app.GroupID = groupID
+		app.DocumentStatus = datastore.ActiveDocumentStatus
+		require.NoError(t, appRepo.CreateApplication(context.Background(), app))
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 10, Sort: 1}
+	isDisabled := false
+
+	found, _, err := appRepo.LoadApplicationsPaged(context.Background(), groupID, &datastore.ApplicationFilter{
+		Query: "BILLING", OwnerID: "cus_1", IsDisabled: &isDisabled,
+	}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+	require.Equal(t, "Billing (EU)", found[0].Title)
+
+	// regex metacharacters in the query are matched literally
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), groupID, &datastore.ApplicationFilter{Query: "(EU)"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(found))
+
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), groupID, &datastore.ApplicationFilter{Query: ".*"}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(found))
+
+	found, _, err = appRepo.LoadApplicationsPaged(context.Background(), groupID, &datastore.ApplicationFilter{SortBy: datastore.ApplicationSortTitle}, pageable)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(found))
+	require.Equal(t, "Billing (EU)", found[0].Title)
+	require.Equal(t, "Shipping", found[1].Title)
+	require.Equal(t, "billing us", found[2].Title)
+}
+
+func Test_LoadApplicationsPaged_FiltersByStatus(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := NewApplicationRepo(db)
+	groupID := uuid.NewString()
+
+	for _, status := range []datastore.DocumentStatus{
+		datastore.ActiveDocumentStatus,
+		datastore.ActiveDocumentStatus,
+		datastore.InactiveDocumentStatus,
+		datastore.DeletedDocumentStatus,
+	} {
+		require.NoError(t, appRepo.CreateApplication(context.Background(), &datastore.Application{
+			UID:            uuid.NewString(),
+			GroupID:        groupID,
+			Title:          string(status),
+			DocumentStatus: status,
+		}))
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 10, Sort: 1}
+
+	tests := []struct {
+		filter *datastore.ApplicationFilter
+		want   int
+	}{
+		{filter: &datastore.ApplicationFilter{}, want: 2},
+		{filter: &datastore.ApplicationFilter{Status: datastore.ApplicationStatusActive}, want: 2},
+		{filter: &datastore.ApplicationFilter{Status: datastore.ApplicationStatusInactive}, want: 1},
+		{filter: &datastore.ApplicationFilter{Status: datastore.ApplicationStatusAll}, want: 3},
+		{filter: &datastore.ApplicationFilter{Status: datastore.ApplicationStatusAll, ShowDeleted: true}, want: 4},
+	}
+
+	for _, tc := range tests {
+		found, _, err := appRepo.LoadApplicationsPaged(context.Background(), groupID, tc.filter, pageable)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, len(found), "status %q", tc.filter.Status)
+	}
+}
+
 func Test_FindApplicationByID(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
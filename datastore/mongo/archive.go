@@ -0,0 +1,82 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type archiveRepo struct {
+	client *mongo.Collection
+}
+
+const ArchiveCollection = "archives"
+
+func NewArchiveRepo(client *mongo.Database) datastore.ArchiveRepository {
+	return &archiveRepo{
+		client: client.Collection(ArchiveCollection, nil),
+	}
+}
+
+func (db *archiveRepo) CreateArchiveManifest(ctx context.Context, manifest *datastore.ArchiveManifest) error {
+	manifest.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(manifest.UID) {
+		manifest.UID = uuid.New().String()
+	}
+
+	_, err := db.client.InsertOne(ctx, manifest)
+	return err
+}
+
+func (db *archiveRepo) UpdateArchiveManifest(ctx context.Context, manifest *datastore.ArchiveManifest) error {
+	filter := bson.M{"uid": manifest.UID}
+
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "restored_at", Value: manifest.RestoredAt},
+	}}}
+
+	_, err := db.client.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (db *archiveRepo) FindArchiveManifestByID(ctx context.Context, id string) (*datastore.ArchiveManifest, error) {
+	manifest := new(datastore.ArchiveManifest)
+
+	err := db.client.FindOne(ctx, bson.M{"uid": id}).Decode(&manifest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrArchiveManifestNotFound
+	}
+
+	return manifest, err
+}
+
+func (db *archiveRepo) LoadArchiveManifestsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ArchiveManifest, datastore.PaginationData, error) {
+	var manifests []datastore.ArchiveManifest
+
+	paginatedData, err := pager.
+		New(db.client).
+		Context(ctx).
+		Limit(int64(pageable.PerPage)).
+		Page(int64(pageable.Page)).
+		Sort("created_at", pageable.Sort).
+		Filter(bson.M{"group_id": groupID}).
+		Decode(&manifests).
+		Find()
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	if manifests == nil {
+		manifests = make([]datastore.ArchiveManifest, 0)
+	}
+
+	return manifests, toPaginationData(paginatedData.Pagination), nil
+}
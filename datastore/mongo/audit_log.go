@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type auditLogRepo struct {
+	client *mongo.Collection
+}
+
+const AuditLogCollection = "auditLogs"
+
+func NewAuditLogRepo(client *mongo.Database) datastore.AuditLogRepository {
+	return &auditLogRepo{
+		client: client.Collection(AuditLogCollection, nil),
+	}
+}
+
+func (db *auditLogRepo) Append(ctx context.Context, entry *datastore.AuditLog) error {
+	entry.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(entry.UID) {
+		entry.UID = uuid.New().String()
+	}
+
+	_, err := db.client.InsertOne(ctx, entry)
+	return err
+}
+
+func (db *auditLogRepo) LoadAuditLogsPaged(ctx context.Context, resourceType string, pageable datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	var logs []datastore.AuditLog
+
+	filter := bson.M{}
+	if !util.IsStringEmpty(resourceType) {
+		filter["resource_type"] = resourceType
+	}
+
+	paginatedData, err := pager.
+		New(db.client).
+		Context(ctx).
+		Limit(int64(pageable.PerPage)).
+		Page(int64(pageable.Page)).
+		Sort("timestamp", pageable.Sort).
+		Filter(filter).
+		Decode(&logs).
+		Find()
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	if logs == nil {
+		logs = make([]datastore.AuditLog, 0)
+	}
+
+	return logs, toPaginationData(paginatedData.Pagination), nil
+}
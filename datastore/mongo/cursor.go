@@ -0,0 +1,49 @@
+package mongo
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// applyCursor narrows filter to the documents past c and returns the order
+// to read them in. Lists are paged newest first, ties on created_at broken
+// by uid; pages before a cursor are read in reverse, reversed tells the
+// caller to flip them back.
+func applyCursor(filter bson.M, c *datastore.Cursor) (sort bson.D, reversed bool) {
+	order := -1
+	if c != nil && c.Backward {
+		order = 1
+	}
+
+	if c != nil {
+		op := "$lt"
+		if c.Backward {
+			op = "$gt"
+		}
+
+		filter["$or"] = []bson.M{
+			{"created_at": bson.M{op: c.CreatedAt}},
+			{"created_at": c.CreatedAt, "uid": bson.M{op: c.UID}},
+		}
+	}
+
+	return bson.D{
+		primitive.E{Key: "created_at", Value: order},
+		primitive.E{Key: "uid", Value: order},
+	}, order == 1
+}
+
+// toPaginationData converts the pagination data of pager, which has no
+// cursors, to the datastore's.
+func toPaginationData(p pager.PaginationData) datastore.PaginationData {
+	return datastore.PaginationData{
+		Total:     p.Total,
+		Page:      p.Page,
+		PerPage:   p.PerPage,
+		Prev:      p.Prev,
+		Next:      p.Next,
+		TotalPage: p.TotalPage,
+	}
+}
@@ -1,9 +1,16 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"math"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
@@ -13,15 +20,18 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type eventRepo struct {
-	inner *mongo.Collection
+	inner      *mongo.Collection
+	deliveries *mongo.Collection
 }
 
 func NewEventRepository(db *mongo.Database) datastore.EventRepository {
 	return &eventRepo{
-		inner: db.Collection(EventCollection),
+		inner:      db.Collection(EventCollection),
+		deliveries: db.Collection(EventDeliveryCollection),
 	}
 }
 
@@ -30,6 +40,14 @@ var weeklyIntervalFormat = "%Y-%m"   // 1 week
 var monthlyIntervalFormat = "%Y-%m"  // 1 month
 var yearlyIntervalFormat = "%Y"      // 1 month
 
+// eventSearchMaxTime caps how long the server may spend scanning
+// events for a single payload search.
+var eventSearchMaxTime = 5 * time.Second
+
+// deleteGroupEventsBatchSize is how many events DeleteGroupEvents soft
+// deletes per write.
+var deleteGroupEventsBatchSize int64 = 1000
+
 func (db *eventRepo) CreateEvent(ctx context.Context,
 	message *datastore.Event) error {
 
@@ -42,10 +60,93 @@ func (db *eventRepo) CreateEvent(ctx context.Context,
 		message.UID = uuid.New().String()
 	}
 
+	message.SearchText = searchText(message.Data)
+
 	_, err := db.inner.InsertOne(ctx, message)
 	return err
 }
 
+// searchText extracts the distinct lowercase words of the keys and
+// values in data, so events store their search terms rather than a
+// second copy of the payload.
+func searchText(data json.RawMessage) string {
+	seen := map[string]bool{}
+	var words []string
+
+	add := func(s string) {
+		for _, w := range searchWords(s) {
+			if !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// not valid JSON, index the raw bytes instead
+			add(string(data))
+			break
+		}
+
+		switch v := tok.(type) {
+		case string:
+			add(v)
+		case json.Number:
+			add(v.String())
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// searchWords splits s into lowercase runs of letters and digits.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// EnsureRetentionIndex makes mongo expire the events of the group groupID,
+// and their deliveries, retentionDays after they were created. They expire
+// at their expires_at through a TTL index, so the group's existing events
+// and deliveries have it recomputed from their created_at, or unset when
+// retentionDays is zero.
+func (db *eventRepo) EnsureRetentionIndex(ctx context.Context, groupID string, retentionDays int) error {
+	deliveries := db.inner.Database().Collection(EventDeliveryCollection)
+
+	for _, c := range []*mongo.Collection{db.inner, deliveries} {
+		_, err := c.Indexes().CreateOne(ctx, expiresAtIndex())
+		if err != nil {
+			return err
+		}
+	}
+
+	var update interface{} = bson.M{"$unset": bson.M{"expires_at": ""}}
+	if retentionDays > 0 {
+		retention := int64(retentionDays) * int64(24*time.Hour/time.Millisecond)
+		update = mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"expires_at": bson.M{"$add": bson.A{"$created_at", retention}}}}},
+		}
+	}
+
+	filter := bson.M{"app_metadata.group_id": groupID}
+	for _, c := range []*mongo.Collection{db.inner, deliveries} {
+		_, err := c.UpdateMany(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
 	filter := bson.M{
 		"app_metadata.group_id": groupID,
@@ -60,6 +161,43 @@ func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (in
 	return count, nil
 }
 
+func (db *eventRepo) CountGroupMessagesByType(ctx context.Context, groupID string) (map[string]int64, error) {
+	matchStage := bson.D{{Key: "$match", Value: bson.D{
+		{Key: "app_metadata.group_id", Value: groupID},
+		{Key: "document_status", Value: datastore.ActiveDocumentStatus},
+	}}}
+
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: "$event_type"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+
+	cur, err := db.inner.Aggregate(ctx, mongo.Pipeline{matchStage, groupStage})
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events by type in group %s", groupID)
+		return nil, err
+	}
+
+	var results []struct {
+		EventType string `bson:"_id"`
+		Count     int64  `bson:"count"`
+	}
+
+	if err = cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, r := range results {
+		counts[r.EventType] = r.Count
+	}
+
+	return counts, nil
+}
+
+// DeleteGroupEvents soft deletes the group's events in batches of
+// deleteGroupEventsBatchSize, so deleting a large group never makes a
+// single write big enough to run into mongo's limits.
 func (db *eventRepo) DeleteGroupEvents(ctx context.Context, groupID string) error {
 	update := bson.M{
 		"$set": bson.M{
@@ -68,13 +206,74 @@ func (db *eventRepo) DeleteGroupEvents(ctx context.Context, groupID string) erro
 		},
 	}
 
-	filter := bson.M{"app_metadata.group_id": groupID}
+	filter := bson.M{"app_metadata.group_id": groupID, "deleted_at": nil}
+	opts := options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(deleteGroupEventsBatchSize)
+
+	for {
+		cur, err := db.inner.Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+
+		var batch []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err = cur.All(ctx, &batch); err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		ids := make([]primitive.ObjectID, len(batch))
+		for i := range batch {
+			ids[i] = batch[i].ID
+		}
+
+		_, err = db.inner.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, update)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (db *eventRepo) DeleteEvents(ctx context.Context, ids []string) error {
+	filter := bson.M{"uid": bson.M{"$in": ids}}
+
+	_, err := db.inner.DeleteMany(ctx, filter)
+	return err
+}
+
+func (db *eventRepo) UpdateEventsGroupID(ctx context.Context, appID, groupID string) error {
+	filter := bson.M{"app_metadata.uid": appID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"app_metadata.group_id": groupID,
+			"updated_at":            primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+
 	_, err := db.inner.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (db *eventRepo) MarkEventBridged(ctx context.Context, id string, bridgedAt time.Time) (bool, error) {
+	filter := bson.M{"uid": id, "bridged_at": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set": bson.M{
+			"bridged_at": primitive.NewDateTimeFromTime(bridgedAt),
+			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+
+	result, err := db.inner.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return result.MatchedCount == 1, nil
 }
 
 func (db *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
@@ -164,21 +363,7 @@ func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.E
 }
 
 func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
-	filter := bson.M{"document_status": datastore.ActiveDocumentStatus, "created_at": getCreatedDateFilter(searchParams)}
-
-	hasAppFilter := !util.IsStringEmpty(appId)
-	hasGroupFilter := !util.IsStringEmpty(groupID)
-
-	if hasAppFilter && hasGroupFilter {
-		filter = bson.M{"app_metadata.group_id": groupID, "app_metadata.uid": appId, "document_status": datastore.ActiveDocumentStatus,
-			"created_at": getCreatedDateFilter(searchParams)}
-	} else if hasAppFilter {
-		filter = bson.M{"app_metadata.uid": appId, "document_status": datastore.ActiveDocumentStatus,
-			"created_at": getCreatedDateFilter(searchParams)}
-	} else if hasGroupFilter {
-		filter = bson.M{"app_metadata.group_id": groupID, "document_status": datastore.ActiveDocumentStatus,
-			"created_at": getCreatedDateFilter(searchParams)}
-	}
+	filter := getEventsFilter(groupID, appId, searchParams)
 
 	var messages []datastore.Event
 	paginatedData, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Filter(filter).Decode(&messages).Find()
@@ -190,9 +375,224 @@ func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId
 		messages = make([]datastore.Event, 0)
 	}
 
-	return messages, datastore.PaginationData(paginatedData.Pagination), nil
+	return messages, toPaginationData(paginatedData.Pagination), nil
+}
+
+func (db *eventRepo) LoadEventsCursored(ctx context.Context, groupID string, appID string, query string, metadata map[string]string, searchParams datastore.SearchParams, cursor *datastore.Cursor, perPage int) ([]datastore.Event, error) {
+	filter := getEventSearchFilter(groupID, appID, metadata, searchParams)
+	order, reversed := applyCursor(filter, cursor)
+
+	events := make([]datastore.Event, 0)
+	err := searchEvents(filter, query, func(filter bson.M) error {
+		cur, err := db.inner.Find(ctx, filter, options.Find().SetSort(order).SetLimit(int64(perPage)))
+		if err != nil {
+			return err
+		}
+
+		return cur.All(ctx, &events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reversed {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	return events, nil
+}
+
+func (db *eventRepo) CountEvents(ctx context.Context, groupID string, appID string, searchParams datastore.SearchParams) (int64, error) {
+	filter := getEventsFilter(groupID, appID, searchParams)
+
+	count, err := db.inner.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// getEventSearchFilter is getEventsFilter narrowed to the events with
+// every metadata key set to its value.
+func getEventSearchFilter(groupID string, appID string, metadata map[string]string, searchParams datastore.SearchParams) bson.M {
+	filter := getEventsFilter(groupID, appID, searchParams)
+	for k, v := range metadata {
+		filter["metadata."+k] = v
+	}
+
+	return filter
+}
+
+// searchEvents calls find with filter narrowed to the events whose
+// payload has every word of query, through the text index or a regex
+// scan of the search text when the index is missing. Without a query
+// filter is used as it is.
+func searchEvents(filter bson.M, query string, find func(filter bson.M) error) error {
+	if util.IsStringEmpty(query) {
+		return find(filter)
+	}
+
+	words := searchWords(query)
+	if len(words) == 0 {
+		// a query without words matches nothing
+		filter["search_text"] = bson.M{"$in": bson.A{}}
+		return find(filter)
+	}
+
+	textFilter := bson.M{"$text": bson.M{"$search": textSearch(words)}}
+	for k, v := range filter {
+		textFilter[k] = v
+	}
+
+	err := find(textFilter)
+	if !isTextIndexMissing(err) {
+		return err
+	}
+
+	// fall back to a regex scan when the text index is unavailable
+	var and bson.A
+	for _, w := range words {
+		and = append(and, bson.M{"search_text": bson.M{"$regex": regexp.QuoteMeta(w), "$options": "i"}})
+	}
+	filter["$and"] = and
+
+	return find(filter)
+}
+
+// textSearch is the $text search of words. Each word is quoted, so the
+// text index only matches the events with all of them, as the regex
+// fallback does, rather than those with any.
+func textSearch(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = `"` + w + `"`
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func getEventsFilter(groupID string, appID string, searchParams datastore.SearchParams) bson.M {
+	filter := bson.M{"document_status": datastore.ActiveDocumentStatus, "created_at": getCreatedDateFilter(searchParams)}
+
+	if !util.IsStringEmpty(groupID) {
+		filter["app_metadata.group_id"] = groupID
+	}
+
+	if !util.IsStringEmpty(appID) {
+		filter["app_metadata.uid"] = appID
+	}
+
+	if !util.IsStringEmpty(searchParams.Tag) {
+		filter["tags"] = searchParams.Tag
+	}
+
+	return filter
+}
+
+func (db *eventRepo) SearchEventsPaged(ctx context.Context, groupID string, appID string, query string, metadata map[string]string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	filter := getEventSearchFilter(groupID, appID, metadata, searchParams)
+
+	var events []datastore.Event
+	var pagination datastore.PaginationData
+
+	err := searchEvents(filter, query, func(filter bson.M) error {
+		var err error
+		events, pagination, err = db.findEventsPaged(ctx, filter, pageable)
+		return err
+	})
+	if err != nil {
+		if isMaxTimeExpired(err) {
+			return nil, datastore.PaginationData{}, datastore.ErrEventSearchTimeout
+		}
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return events, pagination, nil
+}
+
+// findEventsPaged is like the pager but bounds both the count and the
+// find with eventSearchMaxTime.
+func (db *eventRepo) findEventsPaged(ctx context.Context, filter bson.M, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	page := int64(pageable.Page)
+	if page < 1 {
+		page = 1
+	}
+
+	limit := int64(pageable.PerPage)
+	if limit < 1 {
+		limit = 10
+	}
+
+	count, err := db.inner.CountDocuments(ctx, filter, options.Count().SetMaxTime(eventSearchMaxTime))
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	opts := options.Find().
+		SetMaxTime(eventSearchMaxTime).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit).
+		SetSort(bson.D{{Key: "created_at", Value: pageable.Sort}})
+
+	cursor, err := db.inner.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	events := make([]datastore.Event, 0)
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	paginator := pager.Paginator{
+		TotalRecord: count,
+		TotalPage:   int64(math.Ceil(float64(count) / float64(limit))),
+		Limit:       limit,
+		Page:        page,
+		PrevPage:    page,
+		NextPage:    page + 1,
+	}
+
+	if page > 1 {
+		paginator.PrevPage = page - 1
+	}
+
+	if page == paginator.TotalPage {
+		paginator.NextPage = page
+	}
+
+	return events, toPaginationData(*paginator.PaginationData()), nil
+}
+
+func isTextIndexMissing(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == 27 // IndexNotFound
+	}
+	return false
+}
+
+func isMaxTimeExpired(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == 50 // MaxTimeMSExpired
+	}
+	return mongo.IsTimeout(err)
 }
 
 func getCreatedDateFilter(searchParams datastore.SearchParams) bson.M {
 	return bson.M{"$gte": primitive.NewDateTimeFromTime(time.Unix(searchParams.CreatedAtStart, 0)), "$lte": primitive.NewDateTimeFromTime(time.Unix(searchParams.CreatedAtEnd, 0))}
 }
+
+// PurgeDeleted purges the deliveries of each batch of events before the
+// events themselves, deliveries are never soft-deleted on their own and
+// would otherwise outlive their events forever.
+func (db *eventRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return purgeDeleted(ctx, db.inner, deletedBefore, limit, func(ctx context.Context, uids []string) error {
+		_, err := db.deliveries.DeleteMany(ctx, bson.M{"event_metadata.uid": bson.M{"$in": uids}})
+		return err
+	})
+}
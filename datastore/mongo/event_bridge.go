@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eventBridgeRepo struct {
+	client *mongo.Collection
+}
+
+const EventBridgeCollection = "event_bridge_rules"
+
+func NewEventBridgeRepo(client *mongo.Database) datastore.EventBridgeRepository {
+	return &eventBridgeRepo{
+		client: client.Collection(EventBridgeCollection, nil),
+	}
+}
+
+func (db *eventBridgeRepo) CreateEventBridgeRule(ctx context.Context, rule *datastore.EventBridgeRule) error {
+	rule.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(rule.UID) {
+		rule.UID = uuid.New().String()
+	}
+
+	_, err := db.client.InsertOne(ctx, rule)
+	return err
+}
+
+func (db *eventBridgeRepo) FindEventBridgeRuleByID(ctx context.Context, id string) (*datastore.EventBridgeRule, error) {
+	rule := new(datastore.EventBridgeRule)
+
+	err := db.client.FindOne(ctx, bson.M{"uid": id}).Decode(&rule)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrEventBridgeRuleNotFound
+	}
+
+	return rule, err
+}
+
+func (db *eventBridgeRepo) LoadEventBridgeRules(ctx context.Context, sourceGroupID string) ([]datastore.EventBridgeRule, error) {
+	rules := make([]datastore.EventBridgeRule, 0)
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	cur, err := db.client.Find(ctx, bson.M{"source_group_id": sourceGroupID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (db *eventBridgeRepo) DeleteEventBridgeRule(ctx context.Context, id string) error {
+	res, err := db.client.DeleteOne(ctx, bson.M{"uid": id})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return datastore.ErrEventBridgeRuleNotFound
+	}
+
+	return nil
+}
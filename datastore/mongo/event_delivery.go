@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -13,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type eventDeliveryRepo struct {
@@ -41,6 +43,40 @@ func (db *eventDeliveryRepo) CreateEventDelivery(ctx context.Context,
 	return err
 }
 
+func (db *eventDeliveryRepo) CreateEventDeliveries(ctx context.Context,
+	deliveries []*datastore.EventDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(deliveries))
+	for i, d := range deliveries {
+		d.ID = primitive.NewObjectID()
+		if util.IsStringEmpty(d.UID) {
+			d.UID = uuid.New().String()
+		}
+		docs[i] = d
+	}
+
+	// unordered, so a delivery that fails doesn't stop the rest
+	_, err := db.inner.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) == 0 {
+		return &datastore.DeliveriesWriteError{Failed: deliveries, Err: err}
+	}
+
+	failed := make([]*datastore.EventDelivery, 0, len(bulkErr.WriteErrors))
+	for _, we := range bulkErr.WriteErrors {
+		failed = append(failed, deliveries[we.Index])
+	}
+
+	return &datastore.DeliveriesWriteError{Failed: failed, Err: err}
+}
+
 func (db *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context,
 	id string) (*datastore.EventDelivery, error) {
 	e := new(datastore.EventDelivery)
@@ -88,7 +124,7 @@ func (db *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context,
 func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context,
 	eventID string) ([]datastore.EventDelivery, error) {
 
-	filter := bson.M{"event_id": eventID, "document_status": datastore.ActiveDocumentStatus}
+	filter := bson.M{"event_metadata.uid": eventID, "document_status": datastore.ActiveDocumentStatus}
 
 	deliveries := make([]datastore.EventDelivery, 0)
 
@@ -134,6 +170,22 @@ func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context,
 	return count, nil
 }
 
+func (db *eventDeliveryRepo) CountPendingDeliveriesByGroup(ctx context.Context, groupID string) (int64, error) {
+	filter := bson.M{
+		"app_metadata.group_id": groupID,
+		"document_status":       datastore.ActiveDocumentStatus,
+		"status":                bson.M{"$in": datastore.PendingEventStatuses},
+	}
+
+	count, err := db.inner.CountDocuments(ctx, filter)
+	if err != nil {
+		log.WithError(err).Errorf("failed to count pending deliveries in group %s", groupID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context,
 	e datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
 
@@ -155,6 +207,28 @@ func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context,
 	return nil
 }
 
+func (db *eventDeliveryRepo) TransitionStatus(ctx context.Context, id string, from, to datastore.EventDeliveryStatus) (bool, error) {
+	if !from.CanTransitionTo(to) {
+		return false, datastore.ErrIllegalStatusTransition
+	}
+
+	filter := bson.M{"uid": id, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     to,
+			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+
+	result, err := db.inner.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.WithError(err).Errorf("failed to move event delivery %s from %s to %s", id, from, to)
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
 func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, ids []string, status datastore.EventDeliveryStatus) error {
 
 	filter := bson.M{
@@ -177,10 +251,84 @@ func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context,
 	return nil
 }
 
+func (db *eventDeliveryRepo) RetryAllFailed(ctx context.Context, groupID string) (int64, error) {
+	filter := bson.M{
+		"app_metadata.group_id": groupID,
+		"document_status":       datastore.ActiveDocumentStatus,
+		"status":                datastore.FailureEventStatus,
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"$set": bson.M{
+			"status":                  datastore.ScheduledEventStatus,
+			"metadata.next_send_time": now,
+			"updated_at":              now,
+		},
+	}
+
+	result, err := db.inner.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.WithError(err).Errorf("failed to retry failed deliveries in group %s", groupID)
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (db *eventDeliveryRepo) ForceCompleteStuckDeliveries(ctx context.Context, olderThan time.Duration) (int64, error) {
+	now := time.Now()
+	filter := bson.M{
+		"document_status": datastore.ActiveDocumentStatus,
+		"status":          datastore.ProcessingEventStatus,
+		"updated_at":      bson.M{"$lt": primitive.NewDateTimeFromTime(now.Add(-olderThan))},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":      datastore.FailureEventStatus,
+			"description": datastore.StuckDeliveryDescription,
+			"updated_at":  primitive.NewDateTimeFromTime(now),
+		},
+	}
+
+	result, err := db.inner.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.WithError(err).Error("failed to force complete stuck deliveries")
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (db *eventDeliveryRepo) UpdateEventDeliveriesGroupID(ctx context.Context, appID, groupID string, excluded []datastore.EventDeliveryStatus) error {
+	filter := bson.M{"app_metadata.uid": appID}
+	if len(excluded) > 0 {
+		filter["status"] = bson.M{"$nin": excluded}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"app_metadata.group_id": groupID,
+			"updated_at":            primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+
+	_, err := db.inner.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (db *eventDeliveryRepo) DeleteEventDeliveries(ctx context.Context, ids []string) error {
+	filter := bson.M{"uid": bson.M{"$in": ids}}
+
+	_, err := db.inner.DeleteMany(ctx, filter)
+	return err
+}
+
 func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
-	e datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
+	e datastore.EventDelivery, attempt datastore.DeliveryAttempt) (bool, error) {
 
-	filter := bson.M{"uid": e.UID}
+	filter := bson.M{"uid": e.UID, "status": datastore.ProcessingEventStatus}
 	update := bson.M{
 		"$set": bson.M{
 			"status":      e.Status,
@@ -193,33 +341,119 @@ func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
 		},
 	}
 
-	_, err := db.inner.UpdateOne(ctx, filter, update)
+	result, err := db.inner.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.WithError(err).Errorf("error updating an event delivery %s - %s\n", e.UID, err.Error())
-		return err
+		return false, err
 	}
 
-	return nil
+	return result.MatchedCount == 1, nil
 }
 
-func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
-	filter := getFilter(groupID, appID, eventID, status, searchParams)
+func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	filter := getFilter(groupID, appID, eventID, endpointID, status, searchParams)
 
-	var eventDeliveries []datastore.EventDelivery
-	paginatedData, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Filter(filter).Decode(&eventDeliveries).Find()
+	// a listing of the whole collection is counted from its metadata
+	unfiltered := util.IsStringEmpty(groupID) && util.IsStringEmpty(appID) && util.IsStringEmpty(eventID) &&
+		util.IsStringEmpty(endpointID) && len(status) == 0 && util.IsStringEmpty(searchParams.Tag) && searchParams.CreatedAtStart <= 0
+
+	return db.loadDeliveriesPage(ctx, filter, unfiltered, pageable)
+}
+
+func (db *eventDeliveryRepo) LoadEventDeliveriesCursored(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, cursor *datastore.Cursor, perPage int) ([]datastore.EventDelivery, error) {
+	filter := getFilter(groupID, appID, eventID, endpointID, status, searchParams)
+	order, reversed := applyCursor(filter, cursor)
+
+	cur, err := db.inner.Find(ctx, filter, options.Find().SetSort(order).SetLimit(int64(perPage)))
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err = cur.All(ctx, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	if reversed {
+		for i, j := 0, len(deliveries)-1; i < j; i, j = i+1, j-1 {
+			deliveries[i], deliveries[j] = deliveries[j], deliveries[i]
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (db *eventDeliveryRepo) FindDeliveriesByAppID(ctx context.Context, appID string, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	filter := bson.M{"app_metadata.uid": appID, "document_status": datastore.ActiveDocumentStatus}
+	return db.loadDeliveriesPage(ctx, filter, false, pageable)
+}
+
+// loadDeliveriesPage loads the page of pageable of the deliveries matching
+// filter. Counting them exactly can take longer than loading the page on a
+// large collection, so the count of an unfiltered listing is estimated,
+// and it is skipped altogether when pageable asks for it.
+func (db *eventDeliveryRepo) loadDeliveriesPage(ctx context.Context, filter bson.M, unfiltered bool, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	if !unfiltered && !pageable.SkipTotal {
+		var eventDeliveries []datastore.EventDelivery
+		paginatedData, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Filter(filter).Decode(&eventDeliveries).Find()
+		if err != nil {
+			return eventDeliveries, datastore.PaginationData{}, err
+		}
+
+		if eventDeliveries == nil {
+			eventDeliveries = make([]datastore.EventDelivery, 0)
+		}
+
+		return eventDeliveries, toPaginationData(paginatedData.Pagination), nil
+	}
+
+	if pageable.PerPage < 1 {
+		return nil, datastore.PaginationData{}, errors.New("perPage must be greater than zero")
+	}
+
+	page, perPage := int64(pageable.Page), int64(pageable.PerPage)
+	if page < 1 {
+		page = 1
+	}
+
+	opts := options.Find().
+		SetSkip((page - 1) * perPage).
+		SetLimit(perPage).
+		SetSort(bson.D{primitive.E{Key: "created_at", Value: pageable.Sort}})
+
+	cur, err := db.inner.Find(ctx, filter, opts)
 	if err != nil {
-		return eventDeliveries, datastore.PaginationData{}, err
+		return nil, datastore.PaginationData{}, err
 	}
 
-	if eventDeliveries == nil {
-		eventDeliveries = make([]datastore.EventDelivery, 0)
+	eventDeliveries := make([]datastore.EventDelivery, 0)
+	err = cur.All(ctx, &eventDeliveries)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	paginationData := datastore.UncountedPaginationData(page, perPage, len(eventDeliveries))
+	if pageable.SkipTotal {
+		return eventDeliveries, paginationData, nil
+	}
+
+	total, err := db.inner.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	paginationData.Total = total
+	paginationData.TotalPage = int64(math.Ceil(float64(total) / float64(perPage)))
+	if page >= paginationData.TotalPage {
+		paginationData.Next = 0
 	}
 
-	return eventDeliveries, datastore.PaginationData(paginatedData.Pagination), nil
+	return eventDeliveries, paginationData, nil
 }
 
-func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
-	filter := getFilter(groupID, appID, eventID, status, searchParams)
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	filter := getFilter(groupID, appID, eventID, endpointID, status, searchParams)
 
 	var count int64
 	count, err := db.inner.CountDocuments(ctx, filter)
@@ -230,7 +464,116 @@ func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID,
 	return count, nil
 }
 
-func getFilter(groupID string, appID string, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) bson.M {
+func (db *eventDeliveryRepo) LoadDeliveryStatistics(ctx context.Context, groupID, appID string, searchParams datastore.SearchParams) (*datastore.DeliveryStatistics, error) {
+	matchStage := bson.D{{Key: "$match", Value: getFilter(groupID, appID, "", "", nil, searchParams)}}
+
+	isSuccess := bson.D{{Key: "$eq", Value: bson.A{"$status", datastore.SuccessEventStatus}}}
+	isFailure := bson.D{{Key: "$eq", Value: bson.A{"$status", datastore.FailureEventStatus}}}
+	hasAttempts := bson.D{{Key: "$gt", Value: bson.A{bson.D{{Key: "$size", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$attempts", bson.A{}}}}}}, 0}}}
+
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: nil},
+		{Key: "attempted", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{hasAttempts, 1, 0}}}}}},
+		{Key: "successful", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{isSuccess, 1, 0}}}}}},
+		{Key: "failed", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{isFailure, 1, 0}}}}}},
+		// $avg skips the nulls of deliveries that did not succeed
+		{Key: "average_latency", Value: bson.D{{Key: "$avg", Value: bson.D{{Key: "$cond", Value: bson.A{
+			isSuccess, bson.D{{Key: "$subtract", Value: bson.A{"$updated_at", "$created_at"}}}, nil,
+		}}}}}},
+	}}}
+
+	cur, err := db.inner.Aggregate(ctx, mongo.Pipeline{matchStage, groupStage})
+	if err != nil {
+		log.WithError(err).Errorf("failed to load delivery statistics of app %s", appID)
+		return nil, err
+	}
+
+	var results []struct {
+		Attempted      int64   `bson:"attempted"`
+		Successful     int64   `bson:"successful"`
+		Failed         int64   `bson:"failed"`
+		AverageLatency float64 `bson:"average_latency"`
+	}
+
+	if err = cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	statistics := &datastore.DeliveryStatistics{}
+	if len(results) > 0 {
+		statistics.Attempted = results[0].Attempted
+		statistics.Successful = results[0].Successful
+		statistics.Failed = results[0].Failed
+		statistics.AverageLatencyMs = int64(results[0].AverageLatency)
+	}
+
+	return statistics, nil
+}
+
+func (db *eventDeliveryRepo) FindRecentAttemptsByApp(ctx context.Context, appID string, n int) ([]datastore.DeliveryAttempt, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"app_metadata.uid": appID, "document_status": datastore.ActiveDocumentStatus}}},
+		{{Key: "$unwind", Value: "$attempts"}},
+		{{Key: "$sort", Value: bson.M{"attempts.created_at": -1}}},
+		{{Key: "$limit", Value: n}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$attempts"}}},
+	}
+
+	cur, err := db.inner.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]datastore.DeliveryAttempt, 0)
+	if err = cur.All(ctx, &attempts); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+func (db *eventDeliveryRepo) FindGroupsWithHighFailureRate(ctx context.Context, threshold float64) ([]string, error) {
+	isFailure := bson.D{{Key: "$eq", Value: bson.A{"$status", datastore.FailureEventStatus}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"document_status": datastore.ActiveDocumentStatus,
+			"status":          bson.M{"$in": []datastore.EventDeliveryStatus{datastore.SuccessEventStatus, datastore.FailureEventStatus}},
+			"created_at":      bson.M{"$gte": primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$app_metadata.group_id"},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "failed", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{isFailure, 1, 0}}}}}},
+		}}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{
+			"$gte": bson.A{bson.M{"$divide": bson.A{"$failed", "$total"}}, threshold},
+		}}}},
+	}
+
+	cur, err := db.inner.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.WithError(err).Error("failed to find groups with a high failure rate")
+		return nil, err
+	}
+
+	var results []struct {
+		GroupID string `bson:"_id"`
+	}
+
+	if err = cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.GroupID)
+	}
+
+	return ids, nil
+}
+
+func getFilter(groupID string, appID string, eventID string, endpointID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) bson.M {
 
 	filter := bson.M{
 		"document_status": datastore.ActiveDocumentStatus,
@@ -240,6 +583,7 @@ func getFilter(groupID string, appID string, eventID string, status []datastore.
 	hasAppFilter := !util.IsStringEmpty(appID)
 	hasGroupFilter := !util.IsStringEmpty(groupID)
 	hasEventFilter := !util.IsStringEmpty(eventID)
+	hasEndpointFilter := !util.IsStringEmpty(endpointID)
 	hasStatusFilter := len(status) > 0
 
 	if hasAppFilter {
@@ -254,9 +598,17 @@ func getFilter(groupID string, appID string, eventID string, status []datastore.
 		filter["event_metadata.uid"] = eventID
 	}
 
+	if hasEndpointFilter {
+		filter["endpoint.uid"] = endpointID
+	}
+
 	if hasStatusFilter {
 		filter["status"] = bson.M{"$in": status}
 	}
 
+	if !util.IsStringEmpty(searchParams.Tag) {
+		filter["event_metadata.tags"] = searchParams.Tag
+	}
+
 	return filter
 }
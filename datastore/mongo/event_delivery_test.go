@@ -0,0 +1,274 @@
+//go:build integration
+// +build integration
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventDeliveryRepository_LoadEventDeliveriesPaged_Totals(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	groupID := uuid.NewString()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         datastore.SuccessEventStatus,
+			AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: groupID},
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	searchParams := datastore.SearchParams{CreatedAtEnd: time.Now().Add(time.Minute).Unix()}
+
+	// filtered listings are counted exactly unless asked not to
+	_, paginationData, err := edRepo.LoadEventDeliveriesPaged(context.Background(), groupID, "", "", "", nil, searchParams, datastore.Pageable{Page: 1, PerPage: 2, Sort: -1})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), paginationData.Total)
+	require.Equal(t, int64(3), paginationData.TotalPage)
+
+	deliveries, paginationData, err := edRepo.LoadEventDeliveriesPaged(context.Background(), groupID, "", "", "", nil, searchParams, datastore.Pageable{Page: 1, PerPage: 2, Sort: -1, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deliveries))
+	require.Equal(t, datastore.PaginationData{Total: -1, Page: 1, PerPage: 2, Next: 2, TotalPage: -1}, paginationData)
+
+	// the whole collection is counted from its metadata
+	_, paginationData, err = edRepo.LoadEventDeliveriesPaged(context.Background(), "", "", "", "", nil, searchParams, datastore.Pageable{Page: 1, PerPage: 2, Sort: -1})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, paginationData.Total, int64(5))
+}
+
+func TestEventDeliveryRepository_FiltersByMultipleStatuses(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	groupID := uuid.NewString()
+
+	statuses := []datastore.EventDeliveryStatus{
+		datastore.SuccessEventStatus,
+		datastore.FailureEventStatus,
+		datastore.RetryEventStatus,
+		datastore.FailureEventStatus,
+	}
+
+	for _, status := range statuses {
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), &datastore.EventDelivery{
+			UID:            uuid.NewString(),
+			Status:         status,
+			AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: groupID},
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	searchParams := datastore.SearchParams{CreatedAtEnd: time.Now().Add(time.Minute).Unix()}
+	pageable := datastore.Pageable{Page: 1, PerPage: 10, Sort: -1}
+
+	deliveries, _, err := edRepo.LoadEventDeliveriesPaged(context.Background(), groupID, "", "", "",
+		[]datastore.EventDeliveryStatus{datastore.FailureEventStatus, datastore.RetryEventStatus}, searchParams, pageable)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+
+	for _, d := range deliveries {
+		require.NotEqual(t, datastore.SuccessEventStatus, d.Status)
+	}
+
+	// a single status still narrows the listing to it alone
+	deliveries, _, err = edRepo.LoadEventDeliveriesPaged(context.Background(), groupID, "", "", "",
+		[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus}, searchParams, pageable)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+}
+
+// benchDeliveries builds the deliveries of an event fanned out to n endpoints.
+func benchDeliveries(n int) []*datastore.EventDelivery {
+	eventID := uuid.NewString()
+	deliveries := make([]*datastore.EventDelivery, n)
+	for i := range deliveries {
+		deliveries[i] = &datastore.EventDelivery{
+			UID:              uuid.NewString(),
+			EventMetadata:    &datastore.EventMetadata{UID: eventID},
+			EndpointMetadata: &datastore.EndpointMetadata{UID: uuid.NewString()},
+			AppMetadata:      &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+			Status:           datastore.ScheduledEventStatus,
+			CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus:   datastore.ActiveDocumentStatus,
+		}
+	}
+
+	return deliveries
+}
+
+func BenchmarkEventDeliveryRepository_CreateEventDelivery(b *testing.B) {
+	db, closeFn := getDB(b)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		deliveries := benchDeliveries(30)
+		b.StartTimer()
+
+		for _, d := range deliveries {
+			if err := edRepo.CreateEventDelivery(context.Background(), d); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkEventDeliveryRepository_CreateEventDeliveries(b *testing.B) {
+	db, closeFn := getDB(b)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		deliveries := benchDeliveries(30)
+		b.StartTimer()
+
+		if err := edRepo.CreateEventDeliveries(context.Background(), deliveries); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEventDelivery_RejectsIllegalTransitionFromSuccess(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	delivery := &datastore.EventDelivery{
+		UID:            uuid.NewString(),
+		Status:         datastore.ScheduledEventStatus,
+		AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, edRepo.CreateEventDelivery(ctx, delivery))
+
+	moved, err := edRepo.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	moved, err = edRepo.TransitionStatus(ctx, delivery.UID, datastore.ProcessingEventStatus, datastore.SuccessEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	// a worker that loaded the delivery before it succeeded
+	moved, err = edRepo.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.False(t, moved)
+
+	_, err = edRepo.TransitionStatus(ctx, delivery.UID, datastore.SuccessEventStatus, datastore.RetryEventStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalStatusTransition)
+
+	d, err := edRepo.FindEventDeliveryByID(ctx, delivery.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.SuccessEventStatus, d.Status)
+}
+
+func TestEventDelivery_UpdateWithAttemptOnlyUpdatesProcessing(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	delivery := &datastore.EventDelivery{
+		UID:            uuid.NewString(),
+		Status:         datastore.ScheduledEventStatus,
+		AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+		Metadata:       &datastore.Metadata{RetryLimit: 3},
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, edRepo.CreateEventDelivery(ctx, delivery))
+
+	moved, err := edRepo.TransitionStatus(ctx, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	delivery.Status = datastore.SuccessEventStatus
+	updated, err := edRepo.UpdateEventDeliveryWithAttempt(ctx, *delivery, datastore.DeliveryAttempt{UID: uuid.NewString()})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// a worker finishing after the delivery was force completed
+	delivery.Status = datastore.RetryEventStatus
+	updated, err = edRepo.UpdateEventDeliveryWithAttempt(ctx, *delivery, datastore.DeliveryAttempt{UID: uuid.NewString()})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	d, err := edRepo.FindEventDeliveryByID(ctx, delivery.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.SuccessEventStatus, d.Status)
+	require.Len(t, d.DeliveryAttempts, 1)
+}
+
+func TestForceCompleteStuck_MarksOldProcessingAsFailed(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	edRepo := NewEventDeliveryRepository(db)
+	ctx := context.Background()
+
+	old := primitive.NewDateTimeFromTime(time.Now().Add(-2 * time.Hour))
+	recent := primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute))
+
+	deliveries := []struct {
+		uid       string
+		status    datastore.EventDeliveryStatus
+		updatedAt primitive.DateTime
+		want      datastore.EventDeliveryStatus
+	}{
+		{uid: uuid.NewString(), status: datastore.ProcessingEventStatus, updatedAt: old, want: datastore.FailureEventStatus},
+		{uid: uuid.NewString(), status: datastore.ProcessingEventStatus, updatedAt: recent, want: datastore.ProcessingEventStatus},
+		{uid: uuid.NewString(), status: datastore.RetryEventStatus, updatedAt: old, want: datastore.RetryEventStatus},
+	}
+
+	for _, d := range deliveries {
+		require.NoError(t, edRepo.CreateEventDelivery(ctx, &datastore.EventDelivery{
+			UID:            d.uid,
+			Status:         d.status,
+			AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+			CreatedAt:      d.updatedAt,
+			UpdatedAt:      d.updatedAt,
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}))
+	}
+
+	// the collection is shared, other tests may have left deliveries stuck
+	count, err := edRepo.ForceCompleteStuckDeliveries(ctx, time.Hour)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(1))
+
+	for _, d := range deliveries {
+		delivery, err := edRepo.FindEventDeliveryByID(ctx, d.uid)
+		require.NoError(t, err)
+		require.Equal(t, d.want, delivery.Status)
+
+		if d.status != d.want {
+			require.Equal(t, datastore.StuckDeliveryDescription, delivery.Description)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+//go:build integration
+// +build integration
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func findIndex(t *testing.T, db *mongo.Database, collection, name string) bson.M {
+	cursor, err := db.Collection(collection).Indexes().List(context.Background())
+	require.NoError(t, err)
+
+	var indexes []bson.M
+	require.NoError(t, cursor.All(context.Background(), &indexes))
+
+	for _, index := range indexes {
+		if index["name"] == name {
+			return index
+		}
+	}
+
+	return nil
+}
+
+func findExpiresAt(t *testing.T, db *mongo.Database, collection, uid string) interface{} {
+	var doc bson.M
+	require.NoError(t, db.Collection(collection).FindOne(context.Background(), bson.M{"uid": uid}).Decode(&doc))
+
+	return doc["expires_at"]
+}
+
+func TestEventRepository_EnsureRetentionIndex_CreatesIndex(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	ctx := context.Background()
+	eventRepo := NewEventRepository(db)
+	eventDeliveryRepo := NewEventDeliveryRepository(db)
+
+	groupID := uuid.NewString()
+	createdAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &datastore.Event{
+		UID:            uuid.NewString(),
+		AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: groupID},
+		CreatedAt:      primitive.NewDateTimeFromTime(createdAt),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, eventRepo.CreateEvent(ctx, event))
+
+	delivery := &datastore.EventDelivery{
+		UID:            uuid.NewString(),
+		EventMetadata:  &datastore.EventMetadata{UID: event.UID},
+		AppMetadata:    event.AppMetadata,
+		CreatedAt:      primitive.NewDateTimeFromTime(createdAt),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, eventDeliveryRepo.CreateEventDelivery(ctx, delivery))
+
+	other := &datastore.Event{
+		UID:            uuid.NewString(),
+		AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: uuid.NewString()},
+		CreatedAt:      primitive.NewDateTimeFromTime(createdAt),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, eventRepo.CreateEvent(ctx, other))
+
+	require.NoError(t, eventRepo.EnsureRetentionIndex(ctx, groupID, 7))
+
+	for _, collection := range []string{EventCollection, EventDeliveryCollection} {
+		index := findIndex(t, db, collection, "expires_at_1")
+		require.NotNilf(t, index, "%s has no expires_at index", collection)
+		require.EqualValues(t, 0, index["expireAfterSeconds"])
+	}
+
+	// the group's existing events and deliveries expire a week after
+	// they were created
+	want := primitive.NewDateTimeFromTime(createdAt.AddDate(0, 0, 7))
+	require.Equal(t, want, findExpiresAt(t, db, EventCollection, event.UID))
+	require.Equal(t, want, findExpiresAt(t, db, EventDeliveryCollection, delivery.UID))
+	require.Nil(t, findExpiresAt(t, db, EventCollection, other.UID))
+
+	// changing the retention moves their expiry
+	require.NoError(t, eventRepo.EnsureRetentionIndex(ctx, groupID, 30))
+	require.Equal(t, primitive.NewDateTimeFromTime(createdAt.AddDate(0, 0, 30)), findExpiresAt(t, db, EventCollection, event.UID))
+
+	// a retention of zero keeps them forever
+	require.NoError(t, eventRepo.EnsureRetentionIndex(ctx, groupID, 0))
+	require.Nil(t, findExpiresAt(t, db, EventCollection, event.UID))
+	require.Nil(t, findExpiresAt(t, db, EventDeliveryCollection, delivery.UID))
+}
+
+func TestSearchText(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "keys_and_values",
+			data: `{"Order": {"id": 48211, "status": "Paid-Out"}, "paid": true}`,
+			want: "order id 48211 status paid out",
+		},
+		{
+			name: "repeated_words",
+			data: `["ok", "OK", {"ok": "done"}]`,
+			want: "ok done",
+		},
+		{
+			name: "invalid_json",
+			data: `not {json`,
+			want: "not json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, searchText([]byte(tt.data)))
+		})
+	}
+}
+
+func TestEventRepository_SearchEventsPaged_MatchesAllWords(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	ctx := context.Background()
+	eventRepo := NewEventRepository(db)
+	groupID := uuid.NewString()
+
+	payloads := []string{
+		`{"order": "48211", "status": "paid"}`,
+		`{"order": "48211", "status": "failed"}`,
+		`{"order": "90000", "status": "paid"}`,
+	}
+
+	var want string
+	for i, payload := range payloads {
+		event := &datastore.Event{
+			UID:            uuid.NewString(),
+			Data:           []byte(payload),
+			AppMetadata:    &datastore.AppMetadata{UID: uuid.NewString(), GroupID: groupID},
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, eventRepo.CreateEvent(ctx, event))
+
+		if i == 0 {
+			want = event.UID
+		}
+	}
+
+	search := func() []datastore.Event {
+		events, _, err := eventRepo.SearchEventsPaged(ctx, groupID, "", "48211 paid", nil, datastore.SearchParams{}, datastore.Pageable{Page: 1, PerPage: 10, Sort: -1})
+		require.NoError(t, err)
+		return events
+	}
+
+	// the text index only matches events with both words
+	events := search()
+	require.Len(t, events, 1)
+	require.Equal(t, want, events[0].UID)
+
+	// as does the regex fallback once the index is gone
+	_, err := db.Collection(EventCollection).Indexes().DropOne(ctx, "search_text_text")
+	require.NoError(t, err)
+	defer func() {
+		_, err := db.Collection(EventCollection).Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "search_text", Value: "text"}}})
+		require.NoError(t, err)
+	}()
+
+	events = search()
+	require.Len(t, events, 1)
+	require.Equal(t, want, events[0].UID)
+}
+
+func TestTextSearch(t *testing.T) {
+	require.Equal(t, `"order" "48211"`, textSearch(searchWords("Order #48211")))
+}
@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eventTypeRepo struct {
+	client *mongo.Collection
+}
+
+const EventTypeCollection = "event_types"
+
+func NewEventTypeRepo(client *mongo.Database) datastore.EventTypeRepository {
+	return &eventTypeRepo{
+		client: client.Collection(EventTypeCollection, nil),
+	}
+}
+
+func (db *eventTypeRepo) CreateEventType(ctx context.Context, eventType *datastore.EventTypeDefinition) error {
+	eventType.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(eventType.UID) {
+		eventType.UID = uuid.New().String()
+	}
+
+	_, err := db.client.InsertOne(ctx, eventType)
+	return err
+}
+
+func (db *eventTypeRepo) FindEventTypeByName(ctx context.Context, groupID, name string) (*datastore.EventTypeDefinition, error) {
+	eventType := new(datastore.EventTypeDefinition)
+
+	err := db.client.FindOne(ctx, bson.M{"group_id": groupID, "name": name}).Decode(&eventType)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrEventTypeNotFound
+	}
+
+	return eventType, err
+}
+
+func (db *eventTypeRepo) LoadEventTypes(ctx context.Context, groupID string) ([]datastore.EventTypeDefinition, error) {
+	eventTypes := make([]datastore.EventTypeDefinition, 0)
+
+	opts := options.Find().SetSort(bson.M{"name": 1})
+	cur, err := db.client.Find(ctx, bson.M{"group_id": groupID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventTypes, nil
+}
+
+func (db *eventTypeRepo) DeleteEventType(ctx context.Context, groupID, name string) error {
+	res, err := db.client.DeleteOne(ctx, bson.M{"group_id": groupID, "name": name})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return datastore.ErrEventTypeNotFound
+	}
+
+	return nil
+}
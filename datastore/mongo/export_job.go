@@ -0,0 +1,95 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exportJobRepo struct {
+	client *mongo.Collection
+}
+
+const ExportJobCollection = "export_jobs"
+
+func NewExportJobRepo(client *mongo.Database) datastore.ExportJobRepository {
+	return &exportJobRepo{
+		client: client.Collection(ExportJobCollection, nil),
+	}
+}
+
+func (db *exportJobRepo) CreateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	job.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(job.UID) {
+		job.UID = uuid.New().String()
+	}
+
+	_, err := db.client.InsertOne(ctx, job)
+	return err
+}
+
+func (db *exportJobRepo) UpdateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	filter := bson.M{"uid": job.UID}
+
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "status", Value: job.Status},
+		primitive.E{Key: "rows", Value: job.Rows},
+		primitive.E{Key: "error", Value: job.Error},
+		primitive.E{Key: "object_key", Value: job.ObjectKey},
+	}}}
+
+	_, err := db.client.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (db *exportJobRepo) FindExportJobByID(ctx context.Context, id string) (*datastore.ExportJob, error) {
+	job := new(datastore.ExportJob)
+
+	err := db.client.FindOne(ctx, bson.M{"uid": id}).Decode(&job)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrExportJobNotFound
+	}
+
+	return job, err
+}
+
+func (db *exportJobRepo) LoadExpiredExportJobs(ctx context.Context, expiredBefore time.Time, limit int) ([]datastore.ExportJob, error) {
+	jobs := make([]datastore.ExportJob, 0)
+
+	filter := bson.M{"expires_at": bson.M{"$lt": primitive.NewDateTimeFromTime(expiredBefore)}}
+	opts := options.Find().SetSort(bson.M{"expires_at": 1}).SetLimit(int64(limit))
+
+	cur, err := db.client.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (db *exportJobRepo) DeleteExportJob(ctx context.Context, id string) error {
+	res, err := db.client.DeleteOne(ctx, bson.M{"uid": id})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return datastore.ErrExportJobNotFound
+	}
+
+	return nil
+}
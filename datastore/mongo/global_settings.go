@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const GlobalSettingsCollection = "global_settings"
+
+// globalSettingsUID is the uid of the only global settings document.
+const globalSettingsUID = "global"
+
+type globalSettingsRepo struct {
+	inner *mongo.Collection
+}
+
+func NewGlobalSettingsRepo(db *mongo.Database) datastore.GlobalSettingsRepository {
+	return &globalSettingsRepo{
+		inner: db.Collection(GlobalSettingsCollection),
+	}
+}
+
+func (db *globalSettingsRepo) Get(ctx context.Context) (*datastore.GlobalSettings, error) {
+	settings := new(datastore.GlobalSettings)
+
+	err := db.inner.FindOne(ctx, bson.M{"uid": globalSettingsUID}).Decode(settings)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrGlobalSettingsNotFound
+	}
+
+	return settings, err
+}
+
+func (db *globalSettingsRepo) Update(ctx context.Context, settings *datastore.GlobalSettings) error {
+	settings.UID = globalSettingsUID
+	settings.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"$set": bson.M{
+			"rate_limit":           settings.RateLimit,
+			"rate_limit_duration":  settings.RateLimitDuration,
+			"event_retention_days": settings.EventRetentionDays,
+			"updated_at":           settings.UpdatedAt,
+		},
+	}
+
+	_, err := db.inner.UpdateOne(ctx, bson.M{"uid": globalSettingsUID}, update, options.Update().SetUpsert(true))
+	return err
+}
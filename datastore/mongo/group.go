@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -10,17 +11,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type groupRepo struct {
 	innerDB *mongo.Database
 	inner   *mongo.Collection
+
+	// transactions is false against a standalone deployment, which
+	// doesn't support them
+	transactions bool
 }
 
 func NewGroupRepo(db *mongo.Database) datastore.GroupRepository {
+	return newGroupRepo(db, true)
+}
+
+func newGroupRepo(db *mongo.Database, transactions bool) *groupRepo {
 	return &groupRepo{
-		innerDB: db,
-		inner:   db.Collection(GroupCollection),
+		innerDB:      db,
+		inner:        db.Collection(GroupCollection),
+		transactions: transactions,
 	}
 }
 
@@ -34,7 +45,16 @@ func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) (
 		filter["name"] = bson.M{"$in": f.Names}
 	}
 
-	cur, err := db.inner.Find(ctx, filter, opts)
+	order, reversed := applyCursor(filter, f.Cursor)
+	if f.Cursor != nil || f.PerPage > 0 {
+		opts.SetSort(order)
+	}
+
+	if f.PerPage > 0 {
+		opts.SetLimit(int64(f.PerPage))
+	}
+
+	cur, err := regional(ctx, db.inner).Find(ctx, filter, opts)
 	if err != nil {
 		return groups, err
 	}
@@ -56,6 +76,12 @@ func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) (
 		return groups, err
 	}
 
+	if reversed {
+		for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+			groups[i], groups[j] = groups[j], groups[i]
+		}
+	}
+
 	return groups, nil
 }
 
@@ -86,6 +112,87 @@ func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error
 	return err
 }
 
+func (db *groupRepo) UpdateGroupConfig(ctx context.Context, groupID string, patch *datastore.GroupConfigPatch) error {
+	set := bson.M{"updated_at": primitive.NewDateTimeFromTime(time.Now())}
+
+	// only the fields in the patch are set, by their path in the config
+	setField := func(field string, ok bool, value interface{}) {
+		if ok {
+			set["config."+field] = value
+		}
+	}
+
+	setField("strategy", patch.Strategy != nil, patch.Strategy)
+	setField("signature", patch.Signature != nil, patch.Signature)
+	setField("disableendpoint", patch.DisableEndpoint != nil, patch.DisableEndpoint)
+	setField("replayattacks", patch.ReplayAttacks != nil, patch.ReplayAttacks)
+	setField("retrybudget", patch.RetryBudget != nil, patch.RetryBudget)
+	setField("compresspayload", patch.CompressPayload != nil, patch.CompressPayload)
+	setField("compressthresholdbytes", patch.CompressThresholdBytes != nil, patch.CompressThresholdBytes)
+	setField("metaevent", patch.MetaEvent != nil, patch.MetaEvent)
+	setField("maxeventpayloadsizebytes", patch.MaxEventPayloadSizeBytes != nil, patch.MaxEventPayloadSizeBytes)
+	setField("retentionpolicy", patch.RetentionPolicy != nil, patch.RetentionPolicy)
+	setField("eventretentiondays", patch.EventRetentionDays != nil, patch.EventRetentionDays)
+	setField("outboundproxy", patch.OutboundProxy != nil, patch.OutboundProxy)
+	setField("deduplicationwindow", patch.DeduplicationWindow != nil, patch.DeduplicationWindow)
+	setField("idempotencywindowduration", patch.IdempotencyWindowDuration != nil, patch.IdempotencyWindowDuration)
+	setField("customheaders", patch.CustomHeaders != nil, patch.CustomHeaders)
+	setField("enforceeventtypes", patch.EnforceEventTypes != nil, patch.EnforceEventTypes)
+
+	filter := bson.M{"uid": groupID, "document_status": datastore.ActiveDocumentStatus}
+	result, err := db.inner.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return datastore.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (db *groupRepo) UpdateGroupStatus(ctx context.Context, id string, status datastore.GroupStatus) error {
+	group, err := db.FetchGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !group.Status.CanTransitionTo(status) {
+		return datastore.ErrIllegalGroupStatusTransition
+	}
+
+	// the status is only changed if it wasn't changed since it was read,
+	// groups without one are matched by their missing status
+	filter := bson.M{"uid": id, "status": group.Status}
+	if group.Status == "" {
+		filter["status"] = bson.M{"$exists": false}
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+
+	// deleted groups are soft deleted, like DeleteGroup does
+	if status == datastore.DeletedGroupStatus {
+		set["deleted_at"] = now
+		set["document_status"] = datastore.DeletedDocumentStatus
+	}
+
+	result, err := db.inner.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return datastore.ErrIllegalGroupStatusTransition
+	}
+
+	return nil
+}
+
 func (db *groupRepo) FetchGroupByID(ctx context.Context,
 	id string) (*datastore.Group, error) {
 	org := new(datastore.Group)
@@ -97,7 +204,7 @@ func (db *groupRepo) FetchGroupByID(ctx context.Context,
 		},
 	}
 
-	err := db.inner.FindOne(ctx, filter).
+	err := regional(ctx, db.inner).FindOne(ctx, filter).
 		Decode(&org)
 
 	if errors.Is(err, mongo.ErrNoDocuments) {
@@ -147,5 +254,98 @@ func (db *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]data
 		groups = append(groups, group)
 	}
 
-	return groups, err
+	if err := cur.Err(); err != nil {
+		return groups, err
+	}
+
+	position := make(map[string]int, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		position[ids[i]] = i
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return position[groups[i].UID] < position[groups[j].UID]
+	})
+
+	return groups, nil
+}
+
+// WithTransaction runs fn in a multi-document transaction, which needs
+// mongo to be deployed as a replica set or sharded cluster. Against a
+// standalone deployment fn is called directly, so its writes are not
+// rolled back when it fails.
+func (db *groupRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if !db.transactions {
+		return fn(ctx)
+	}
+
+	session, err := db.innerDB.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// reads in a transaction have to go to the primary, whatever the
+	// client prefers
+	opts := options.Transaction().SetReadPreference(readpref.Primary())
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}, opts)
+
+	return err
+}
+
+// DeleteGroupCascade deletes the group's events first, in batches and
+// outside the transaction since a group can have more events than a
+// transaction may write. If that fails the group and its apps are left
+// in place, and deleting the group again picks up the remaining events.
+func (db *groupRepo) DeleteGroupCascade(ctx context.Context, groupID string) error {
+	appRepo := NewApplicationRepo(db.innerDB)
+	eventRepo := NewEventRepository(db.innerDB)
+
+	err := eventRepo.DeleteGroupEvents(ctx, groupID)
+	if err != nil {
+		return &datastore.GroupCascadeError{Step: "delete group events", Err: err}
+	}
+
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		err := db.DeleteGroup(ctx, groupID)
+		if err != nil {
+			return &datastore.GroupCascadeError{Step: "delete group", Err: err}
+		}
+
+		err = appRepo.DeleteGroupApps(ctx, groupID)
+		if err != nil {
+			return &datastore.GroupCascadeError{Step: "delete group apps", Err: err}
+		}
+
+		err = revokeGroupAPIKeys(ctx, db.innerDB.Collection(APIKeyCollection), groupID)
+		if err != nil {
+			return &datastore.GroupCascadeError{Step: "revoke group api keys", Err: err}
+		}
+
+		return nil
+	})
+}
+
+// revokeGroupAPIKeys revokes the api keys scoped to only the group and
+// removes the group from the keys scoped to others too.
+func revokeGroupAPIKeys(ctx context.Context, c *mongo.Collection, groupID string) error {
+	// revoked as RevokeAPIKeys does
+	revoke := bson.M{"$set": bson.M{
+		"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+		"document_status": datastore.ActiveDocumentStatus,
+	}}
+
+	_, err := c.UpdateMany(ctx, bson.M{"role.groups": bson.A{groupID}}, revoke)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.UpdateMany(ctx, bson.M{"role.groups": groupID}, bson.M{"$pull": bson.M{"role.groups": groupID}})
+	return err
+}
+
+func (db *groupRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return purgeDeleted(ctx, db.inner, deletedBefore, limit, nil)
 }
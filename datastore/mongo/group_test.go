@@ -6,10 +6,13 @@ package mongo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func Test_FetchGroupByID(t *testing.T) {
@@ -132,3 +135,353 @@ func Test_LoadGroups(t *testing.T) {
 
 	require.True(t, len(orgs) > 0)
 }
+
+func TestGroupRepository_FetchGroupsByIDs(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	ids := make([]string, 5)
+	for i := range ids {
+		group := &datastore.Group{
+			Name:           uuid.NewString(),
+			UID:            uuid.NewString(),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+		ids[i] = group.UID
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantIDs []string
+	}{
+		{
+			name:    "should_return_groups_in_the_order_of_ids",
+			ids:     []string{ids[3], ids[0], ids[4]},
+			wantIDs: []string{ids[3], ids[0], ids[4]},
+		},
+		{
+			name:    "should_skip_ids_without_a_group",
+			ids:     []string{ids[2], uuid.NewString(), ids[1]},
+			wantIDs: []string{ids[2], ids[1]},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, err := groupRepo.FetchGroupsByIDs(context.Background(), tc.ids)
+			require.NoError(t, err)
+			require.Len(t, groups, len(tc.wantIDs))
+
+			for i, group := range groups {
+				require.Equal(t, tc.wantIDs[i], group.UID)
+			}
+		})
+	}
+}
+
+func seedGroupsForCursorPagination(t *testing.T, groupRepo datastore.GroupRepository) []*datastore.Group {
+	createdAt := time.Now().Add(-time.Hour)
+
+	// newest first, the last two share created_at so uid breaks the tie
+	groups := make([]*datastore.Group, 5)
+	for i := range groups {
+		at := createdAt.Add(time.Duration(len(groups)-i) * time.Second)
+		if i == len(groups)-1 {
+			at = createdAt.Add(2 * time.Second)
+		}
+
+		groups[i] = &datastore.Group{
+			Name:           uuid.NewString(),
+			UID:            uuid.NewString(),
+			CreatedAt:      primitive.NewDateTimeFromTime(at),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+	}
+
+	if groups[3].UID < groups[4].UID {
+		groups[3], groups[4] = groups[4], groups[3]
+	}
+
+	for _, g := range groups {
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), g))
+	}
+
+	return groups
+}
+
+func groupNames(groups []*datastore.Group) []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+func groupUIDs(groups []*datastore.Group) []string {
+	uids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		uids = append(uids, g.UID)
+	}
+	return uids
+}
+
+func TestGroupRepository_CursorPaginationReturnsCorrectPage(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	groups := seedGroupsForCursorPagination(t, groupRepo)
+	names := groupNames(groups)
+
+	firstPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[:2]), groupUIDs(firstPage))
+
+	cursor := &datastore.Cursor{CreatedAt: firstPage[1].CreatedAt, UID: firstPage[1].UID}
+	secondPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[2:4]), groupUIDs(secondPage))
+
+	cursor = &datastore.Cursor{CreatedAt: secondPage[0].CreatedAt, UID: secondPage[0].UID, Backward: true}
+	prevPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 2, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[:2]), groupUIDs(prevPage))
+}
+
+func TestGroupRepository_CursorPaginationHandlesLastPage(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	groups := seedGroupsForCursorPagination(t, groupRepo)
+	names := groupNames(groups)
+
+	cursor := &datastore.Cursor{CreatedAt: groups[2].CreatedAt, UID: groups[2].UID}
+	lastPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 3, Cursor: cursor})
+	require.NoError(t, err)
+	require.Equal(t, groupUIDs(groups[3:]), groupUIDs(lastPage))
+
+	cursor = &datastore.Cursor{CreatedAt: groups[4].CreatedAt, UID: groups[4].UID}
+	pastLastPage, err := groupRepo.LoadGroups(context.Background(), &datastore.GroupFilter{Names: names, PerPage: 3, Cursor: cursor})
+	require.NoError(t, err)
+	require.Empty(t, pastLastPage)
+}
+
+func TestGroupRepository_UpdateGroupConfig(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	group := &datastore.Group{
+		Name: "group-config",
+		UID:  uuid.NewString(),
+		Config: &datastore.GroupConfig{
+			Strategy:            datastore.StrategyConfiguration{Type: "default", Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3}},
+			Signature:           datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			DisableEndpoint:     true,
+			DeduplicationWindow: "5m",
+		},
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+
+	replayAttacks := true
+	retentionPolicy := "720h"
+	err := groupRepo.UpdateGroupConfig(context.Background(), group.UID, &datastore.GroupConfigPatch{
+		Signature:       &datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"},
+		ReplayAttacks:   &replayAttacks,
+		RetentionPolicy: &retentionPolicy,
+	})
+	require.NoError(t, err)
+
+	updated, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, group.Config.Strategy, updated.Config.Strategy)
+	require.Equal(t, datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"}, updated.Config.Signature)
+	require.True(t, updated.Config.DisableEndpoint)
+	require.True(t, updated.Config.ReplayAttacks)
+	require.Equal(t, "5m", updated.Config.DeduplicationWindow)
+	require.Equal(t, "720h", updated.Config.RetentionPolicy)
+
+	err = groupRepo.UpdateGroupConfig(context.Background(), uuid.NewString(), &datastore.GroupConfigPatch{ReplayAttacks: &replayAttacks})
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+}
+
+func TestGroupRepository_UpdateGroupStatus_RejectsInvalidTransition(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+
+	group := &datastore.Group{
+		Name:           "group-status",
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+
+	// an active group can't skip straight to archived
+	err := groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ArchivedGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalGroupStatusTransition)
+
+	require.NoError(t, groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.InactiveGroupStatus))
+	require.NoError(t, groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ArchivedGroupStatus))
+
+	// nor can an archived group be brought back
+	err = groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.ActiveGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrIllegalGroupStatusTransition)
+
+	updated, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ArchivedGroupStatus, updated.Status)
+	require.Zero(t, updated.DeletedAt)
+
+	// deleting an archived group soft deletes it
+	require.NoError(t, groupRepo.UpdateGroupStatus(context.Background(), group.UID, datastore.DeletedGroupStatus))
+
+	deleted, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.DeletedGroupStatus, deleted.Status)
+	require.NotZero(t, deleted.DeletedAt)
+	require.Equal(t, datastore.DeletedDocumentStatus, deleted.DocumentStatus)
+
+	err = groupRepo.UpdateGroupStatus(context.Background(), uuid.NewString(), datastore.InactiveGroupStatus)
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+}
+
+func TestGroupRepository_PurgeDeleted(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := NewGroupRepo(db)
+	now := time.Now()
+
+	groups := []*datastore.Group{
+		{UID: uuid.NewString(), Name: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-48 * time.Hour))},
+		{UID: uuid.NewString(), Name: uuid.NewString(), DeletedAt: primitive.NewDateTimeFromTime(now.Add(-time.Hour))},
+		{UID: uuid.NewString(), Name: uuid.NewString()},
+	}
+
+	for _, g := range groups {
+		g.DocumentStatus = datastore.ActiveDocumentStatus
+		require.NoError(t, groupRepo.CreateGroup(context.Background(), g))
+	}
+
+	purged, err := groupRepo.PurgeDeleted(context.Background(), now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = groupRepo.FetchGroupByID(context.Background(), groups[0].UID)
+	require.ErrorIs(t, err, datastore.ErrGroupNotFound)
+
+	// recently deleted and active groups are kept
+	for _, g := range groups[1:] {
+		_, err = groupRepo.FetchGroupByID(context.Background(), g.UID)
+		require.NoError(t, err)
+	}
+}
+
+func TestGroupRepository_DeleteGroupCascade_IsAllOrNothing(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	ctx := context.Background()
+
+	transactions, err := supportsTransactions(ctx, db)
+	require.NoError(t, err)
+	if !transactions {
+		t.Skip("transactions need mongo to be deployed as a replica set")
+	}
+
+	groupRepo := newGroupRepo(db, true)
+	appRepo := NewApplicationRepo(db)
+	eventRepo := NewEventRepository(db)
+
+	group := &datastore.Group{UID: uuid.NewString(), Name: "cascade-" + uuid.NewString(), DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, groupRepo.CreateGroup(ctx, group))
+
+	app := &datastore.Application{UID: uuid.NewString(), GroupID: group.UID, Title: "cascade-app", DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, appRepo.CreateApplication(ctx, app))
+
+	event := &datastore.Event{UID: uuid.NewString(), AppMetadata: &datastore.AppMetadata{UID: app.UID, GroupID: group.UID}, DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, eventRepo.CreateEvent(ctx, event))
+
+	// reject updates to the group's apps so the transaction fails after
+	// the group has been deleted
+	validator := bson.M{"$or": bson.A{
+		bson.M{"group_id": bson.M{"$ne": group.UID}},
+		bson.M{"deleted_at": bson.M{"$exists": false}},
+	}}
+	require.NoError(t, db.RunCommand(ctx, bson.D{{Key: "collMod", Value: AppCollections}, {Key: "validator", Value: validator}}).Err())
+
+	err = groupRepo.DeleteGroupCascade(ctx, group.UID)
+
+	var cascadeErr *datastore.GroupCascadeError
+	require.ErrorAs(t, err, &cascadeErr)
+	require.Equal(t, "delete group apps", cascadeErr.Step)
+
+	g, err := groupRepo.FetchGroupByID(ctx, group.UID)
+	require.NoError(t, err)
+	require.Zero(t, g.DeletedAt)
+
+	_, err = appRepo.FindApplicationByID(ctx, app.UID)
+	require.NoError(t, err)
+
+	require.NoError(t, db.RunCommand(ctx, bson.D{{Key: "collMod", Value: AppCollections}, {Key: "validator", Value: bson.M{}}}).Err())
+
+	require.NoError(t, groupRepo.DeleteGroupCascade(ctx, group.UID))
+
+	g, err = groupRepo.FetchGroupByID(ctx, group.UID)
+	require.NoError(t, err)
+	require.NotZero(t, g.DeletedAt)
+
+	_, err = appRepo.FindApplicationByID(ctx, app.UID)
+	require.ErrorIs(t, err, datastore.ErrApplicationNotFound)
+}
+
+func TestGroupRepository_DeleteGroupCascade_DeletesEventsInBatches(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	ctx := context.Background()
+
+	batchSize := deleteGroupEventsBatchSize
+	deleteGroupEventsBatchSize = 2
+	defer func() { deleteGroupEventsBatchSize = batchSize }()
+
+	// without transactions, so it runs against any deployment
+	groupRepo := newGroupRepo(db, false)
+	appRepo := NewApplicationRepo(db)
+	eventRepo := NewEventRepository(db)
+
+	group := &datastore.Group{UID: uuid.NewString(), Name: "cascade-" + uuid.NewString(), DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, groupRepo.CreateGroup(ctx, group))
+
+	app := &datastore.Application{UID: uuid.NewString(), GroupID: group.UID, Title: "cascade-app", DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, appRepo.CreateApplication(ctx, app))
+
+	events := make([]string, 5)
+	for i := range events {
+		event := &datastore.Event{UID: uuid.NewString(), AppMetadata: &datastore.AppMetadata{UID: app.UID, GroupID: group.UID}, DocumentStatus: datastore.ActiveDocumentStatus}
+		require.NoError(t, eventRepo.CreateEvent(ctx, event))
+		events[i] = event.UID
+	}
+
+	require.NoError(t, groupRepo.DeleteGroupCascade(ctx, group.UID))
+
+	for _, id := range events {
+		event, err := eventRepo.FindEventByID(ctx, id)
+		require.NoError(t, err)
+		require.NotZero(t, event.DeletedAt)
+	}
+
+	g, err := groupRepo.FetchGroupByID(ctx, group.UID)
+	require.NoError(t, err)
+	require.NotZero(t, g.DeletedAt)
+}
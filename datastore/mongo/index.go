@@ -0,0 +1,183 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// requiredIndexes declares every index convoy's queries rely on, by
+// collection.
+func requiredIndexes() map[string][]mongo.IndexModel {
+	indexes := map[string][]mongo.IndexModel{
+		GroupCollection: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{
+				Keys: bson.D{{Key: "name", Value: 1}},
+				Options: options.Index().SetUnique(true).
+					SetPartialFilterExpression(bson.M{"document_status": datastore.ActiveDocumentStatus}),
+			},
+			deletedAtIndex(),
+		},
+		AppCollections: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "group_id", Value: 1}}},
+			deletedAtIndex(),
+		},
+		EventCollection: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "event_type", Value: 1}}},
+			{Keys: bson.D{{Key: "app_metadata.uid", Value: 1}}},
+			{Keys: bson.D{{Key: "metadata.$**", Value: 1}}},
+			{Keys: bson.D{{Key: "tags", Value: 1}}},
+			{Keys: bson.D{{Key: "search_text", Value: "text"}}},
+			expiresAtIndex(),
+			deletedAtIndex(),
+		},
+		EventDeliveryCollection: {
+			{Keys: bson.D{{Key: "event_metadata.tags", Value: 1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "metadata.next_send_time", Value: 1}}},
+			expiresAtIndex(),
+		},
+		APIKeyCollection: {
+			{Keys: bson.D{{Key: "mask_id", Value: 1}}},
+			{Keys: bson.D{{Key: "hash", Value: 1}}},
+			deletedAtIndex(),
+		},
+		ArchiveCollection: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		EventBridgeCollection: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "source_group_id", Value: 1}}},
+		},
+		ExportJobCollection: {
+			{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+		},
+	}
+
+	for collection, compound := range compoundIndices() {
+		indexes[collection] = append(indexes[collection], compound...)
+	}
+
+	return indexes
+}
+
+// expiresAtIndex makes mongo delete the documents of a collection once
+// their expires_at has passed, those without one are kept.
+func expiresAtIndex() mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+}
+
+// deletedAtIndex lets the purge job find the soft-deleted documents of a
+// collection without scanning it. Only deleted documents are indexed,
+// the rest have no deleted_at, or a zero one for api keys.
+func deletedAtIndex() mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys: bson.D{{Key: "deleted_at", Value: 1}},
+		Options: options.Index().
+			SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$gt": primitive.DateTime(0)}}),
+	}
+}
+
+// ensureIndexes creates the required indexes that are missing. Creating an
+// index that already exists is a no-op, so this is safe on every start.
+func (c *Client) ensureIndexes() {
+	for collection, indexes := range requiredIndexes() {
+		created, present, err := c.ensureCollectionIndexes(collection, indexes)
+		if err != nil {
+			log.WithError(err).Errorf("failed to create indexes on collection %s", collection)
+			continue
+		}
+
+		for _, name := range created {
+			log.Infof("created index %s on collection %s", name, collection)
+		}
+
+		for _, name := range present {
+			log.Debugf("index %s on collection %s already exists", name, collection)
+		}
+	}
+}
+
+func (c *Client) ensureCollectionIndexes(collection string, indexes []mongo.IndexModel) ([]string, []string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	view := c.db.Collection(collection).Indexes()
+
+	cursor, err := view.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var existing []bson.M
+	err = cursor.All(ctx, &existing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make(map[string]bool, len(existing))
+	for _, index := range existing {
+		if name, ok := index["name"].(string); ok {
+			names[name] = true
+		}
+	}
+
+	var created, present []string
+	var missing []mongo.IndexModel
+	for _, index := range indexes {
+		name := indexName(index)
+		if names[name] {
+			present = append(present, name)
+			continue
+		}
+
+		created = append(created, name)
+		missing = append(missing, index)
+	}
+
+	if len(missing) == 0 {
+		return nil, present, nil
+	}
+
+	_, err = view.CreateMany(ctx, missing)
+	if err != nil {
+		return nil, present, err
+	}
+
+	return created, present, nil
+}
+
+// indexName returns the name of index, mongo's default of its keys and
+// their values joined by underscores unless it was given one.
+func indexName(index mongo.IndexModel) string {
+	if index.Options != nil && index.Options.Name != nil {
+		return *index.Options.Name
+	}
+
+	keys, ok := index.Keys.(bson.D)
+	if !ok {
+		return ""
+	}
+
+	parts := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		parts = append(parts, key.Key, fmt.Sprint(key.Value))
+	}
+
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,98 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func Test_indexName(t *testing.T) {
+	tests := []struct {
+		name  string
+		index mongo.IndexModel
+		want  string
+	}{
+		{
+			name:  "single_field",
+			index: mongo.IndexModel{Keys: bson.D{{Key: "uid", Value: 1}}},
+			want:  "uid_1",
+		},
+		{
+			name: "compound",
+			index: mongo.IndexModel{Keys: bson.D{
+				{Key: "app_metadata.group_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			}},
+			want: "app_metadata.group_id_1_created_at_-1",
+		},
+		{
+			name:  "text",
+			index: mongo.IndexModel{Keys: bson.D{{Key: "search_text", Value: "text"}}},
+			want:  "search_text_text",
+		},
+		{
+			name: "named",
+			index: mongo.IndexModel{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("created_at_ttl"),
+			},
+			want: "created_at_ttl",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, indexName(tc.index))
+		})
+	}
+}
+
+func Test_requiredIndexes(t *testing.T) {
+	indexes := requiredIndexes()
+
+	names := func(collection string) []string {
+		var n []string
+		for _, index := range indexes[collection] {
+			n = append(n, indexName(index))
+		}
+		return n
+	}
+
+	require.Contains(t, names(EventCollection), "app_metadata.group_id_1_document_status_1_created_at_-1")
+	require.Contains(t, names(EventDeliveryCollection), "status_1_metadata.next_send_time_1")
+	require.Contains(t, names(APIKeyCollection), "mask_id_1")
+	require.Contains(t, names(GroupCollection), "name_1")
+
+	// events and deliveries expire at their own expires_at
+	for _, collection := range []string{EventCollection, EventDeliveryCollection} {
+		var ttl *mongo.IndexModel
+		for i, index := range indexes[collection] {
+			if indexName(index) == "expires_at_1" {
+				ttl = &indexes[collection][i]
+			}
+		}
+
+		require.NotNilf(t, ttl, "%s has no expires_at index", collection)
+		require.NotNil(t, ttl.Options.ExpireAfterSeconds)
+		require.Equal(t, int32(0), *ttl.Options.ExpireAfterSeconds)
+	}
+
+	// the purge job looks up soft-deleted documents by deleted_at
+	for _, collection := range []string{GroupCollection, AppCollections, EventCollection, APIKeyCollection} {
+		require.Containsf(t, names(collection), "deleted_at_1", "%s has no deleted_at index", collection)
+	}
+
+	// a collection declaring the same index twice would have it created
+	// under one name and reported missing on every start
+	for collection := range indexes {
+		n := names(collection)
+		seen := map[string]bool{}
+		for _, name := range n {
+			require.Falsef(t, seen[name], "index %s is declared twice on %s", name, collection)
+			seen[name] = true
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/url"
 	"strings"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,27 +23,70 @@ const (
 
 type Client struct {
 	db                *mongo.Database
+	pool              *poolMonitor
 	apiKeyRepo        datastore.APIKeyRepository
+	auditLogRepo      datastore.AuditLogRepository
+	archiveRepo       datastore.ArchiveRepository
 	groupRepo         datastore.GroupRepository
 	eventRepo         datastore.EventRepository
 	applicationRepo   datastore.ApplicationRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventBridgeRepo   datastore.EventBridgeRepository
+	exportJobRepo     datastore.ExportJobRepository
+	eventTypeRepo     datastore.EventTypeRepository
+	settingsRepo      datastore.GlobalSettingsRepository
 }
 
 func New(cfg config.Configuration) (datastore.DatabaseClient, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	connectTimeout, err := cfg.Database.GetConnectTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 	opts := options.Client()
 	newRelicMonitor := nrmongo.NewCommandMonitor(nil)
 	opts.SetMonitor(newRelicMonitor)
 	opts.ApplyURI(cfg.Database.Dsn)
 
+	// the read preference only routes reads, so the repositories' list,
+	// fetch and count methods follow it while writes go to the primary
+	if cfg.Database.ReadPreference != "" || cfg.Region.Name != "" {
+		rp, err := readPreference(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	if cfg.Database.ConnectTimeout != "" {
+		opts.SetConnectTimeout(connectTimeout)
+	}
+
+	if cfg.Database.MaxPoolSize != 0 {
+		opts.SetMaxPoolSize(cfg.Database.MaxPoolSize)
+	}
+
+	if cfg.Database.MinPoolSize != 0 {
+		opts.SetMinPoolSize(cfg.Database.MinPoolSize)
+	}
+
+	// the pool size may come from the config or the dsn
+	var maxPoolSize uint64
+	if opts.MaxPoolSize != nil {
+		maxPoolSize = *opts.MaxPoolSize
+	}
+
+	pool := newPoolMonitor(maxPoolSize)
+	opts.SetPoolMonitor(pool.monitor())
+
 	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel = context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
 	if err := client.Ping(ctx, nil); err != nil {
@@ -59,20 +101,59 @@ func New(cfg config.Configuration) (datastore.DatabaseClient, error) {
 	dbName := strings.TrimPrefix(u.Path, "/")
 	conn := client.Database(dbName, nil)
 
+	ctx, cancel = context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	transactions, err := supportsTransactions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transactions {
+		log.Warn("mongo is deployed standalone, which doesn't support transactions, a failure part way through deleting a group can leave some of its documents behind")
+	}
+
 	c := &Client{
 		db:                conn,
+		pool:              pool,
 		apiKeyRepo:        NewApiKeyRepo(conn),
-		groupRepo:         NewGroupRepo(conn),
+		auditLogRepo:      NewAuditLogRepo(conn),
+		archiveRepo:       NewArchiveRepo(conn),
+		groupRepo:         newGroupRepo(conn, transactions),
 		applicationRepo:   NewApplicationRepo(conn),
 		eventRepo:         NewEventRepository(conn),
 		eventDeliveryRepo: NewEventDeliveryRepository(conn),
+		eventBridgeRepo:   NewEventBridgeRepo(conn),
+		exportJobRepo:     NewExportJobRepo(conn),
+		eventTypeRepo:     NewEventTypeRepo(conn),
+		settingsRepo:      NewGlobalSettingsRepo(conn),
 	}
 
-	c.ensureMongoIndices()
+	if cfg.Database.SkipIndexCreation {
+		log.Warn("skipping index creation, the indexes convoy's queries rely on must be created manually")
+	} else {
+		c.ensureIndexes()
+	}
 
 	return c, nil
 }
 
+// supportsTransactions reports whether db is deployed as a replica set or
+// sharded cluster, standalone deployments don't support transactions.
+func supportsTransactions(ctx context.Context, db *mongo.Database) (bool, error) {
+	var result struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+
+	err := db.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&result)
+	if err != nil {
+		return false, err
+	}
+
+	return result.SetName != "" || result.Msg == "isdbgrid", nil
+}
+
 func (c *Client) Disconnect(ctx context.Context) error {
 	return c.db.Client().Disconnect(ctx)
 }
@@ -89,6 +170,14 @@ func (c *Client) APIRepo() datastore.APIKeyRepository {
 	return c.apiKeyRepo
 }
 
+func (c *Client) AuditLogRepo() datastore.AuditLogRepository {
+	return c.auditLogRepo
+}
+
+func (c *Client) ArchiveRepo() datastore.ArchiveRepository {
+	return c.archiveRepo
+}
+
 func (c *Client) GroupRepo() datastore.GroupRepository {
 	return c.groupRepo
 }
@@ -105,69 +194,20 @@ func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
 	return c.eventDeliveryRepo
 }
 
-func (c *Client) ensureMongoIndices() {
-	c.ensureIndex(GroupCollection, "uid", true, nil)
-	c.ensureIndex(GroupCollection, "name", true, bson.M{"document_status": datastore.ActiveDocumentStatus})
-	c.ensureIndex(AppCollections, "uid", true, nil)
-	c.ensureIndex(EventCollection, "uid", true, nil)
-	c.ensureIndex(EventCollection, "event_type", false, nil)
-	c.ensureIndex(EventCollection, "app_metadata.uid", false, nil)
-	c.ensureIndex(AppCollections, "group_id", false, nil)
-	c.ensureIndex(EventDeliveryCollection, "status", false, nil)
-	c.ensureCompoundIndex(AppCollections)
-	c.ensureCompoundIndex(EventCollection)
-	c.ensureCompoundIndex(EventDeliveryCollection)
+func (c *Client) EventBridgeRepo() datastore.EventBridgeRepository {
+	return c.eventBridgeRepo
 }
 
-// ensureIndex - ensures an index is created for a specific field in a collection
-func (c *Client) ensureIndex(collectionName string, field string, unique bool, partialFilterExpression interface{}) bool {
-	createIndexOpts := &options.IndexOptions{Unique: &unique}
-
-	if partialFilterExpression != nil {
-		createIndexOpts.SetPartialFilterExpression(partialFilterExpression)
-	}
-
-	mod := mongo.IndexModel{
-		Keys:    bson.M{field: 1}, // index in ascending order or -1 for descending order
-		Options: createIndexOpts,
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	collection := c.db.Collection(collectionName)
-
-	_, err := collection.Indexes().CreateOne(ctx, mod)
-	if err != nil {
-		log.WithError(err).Errorf("failed to create index on field %s in %s", field, collectionName)
-		return false
-	}
-
-	return true
+func (c *Client) ExportJobRepo() datastore.ExportJobRepository {
+	return c.exportJobRepo
 }
 
-func (c *Client) ensureCompoundIndex(collectionName string) bool {
-	collection := c.db.Collection(collectionName)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	compoundIndices := compoundIndices()
-
-	compoundIndex, ok := compoundIndices[collectionName]
-
-	if !ok {
-		return false
-	}
-
-	_, err := collection.Indexes().CreateMany(ctx, compoundIndex)
-
-	if err != nil {
-		log.WithError(err).Errorf("failed to create index on collection %s", collectionName)
-		return false
-	}
+func (c *Client) EventTypeRepo() datastore.EventTypeRepository {
+	return c.eventTypeRepo
+}
 
-	return true
+func (c *Client) GlobalSettingsRepo() datastore.GlobalSettingsRepository {
+	return c.settingsRepo
 }
 
 func compoundIndices() map[string][]mongo.IndexModel {
@@ -302,6 +342,16 @@ func compoundIndices() map[string][]mongo.IndexModel {
 					{Key: "created_at", Value: -1},
 				},
 			},
+
+			{
+				Keys: bson.D{
+					{Key: "app_metadata.group_id", Value: 1},
+					{Key: "endpoint.uid", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "status", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
 		},
 
 		AppCollections: {
@@ -312,6 +362,51 @@ func compoundIndices() map[string][]mongo.IndexModel {
 					{Key: "created_at", Value: 1},
 				},
 			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "title", Value: 1},
+				},
+			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "owner_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: 1},
+				},
+			},
+		},
+
+		AuditLogCollection: {
+			{
+				Keys: bson.D{
+					{Key: "resource_type", Value: 1},
+					{Key: "timestamp", Value: -1},
+				},
+			},
+		},
+
+		ArchiveCollection: {
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+		},
+
+		EventTypeCollection: {
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "name", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
 		},
 	}
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -27,7 +28,7 @@ func getConfig() config.Configuration {
 	}
 }
 
-func getDB(t *testing.T) (*mongo.Database, func()) {
+func getDB(t testing.TB) (*mongo.Database, func()) {
 
 	db, err := New(getConfig())
 	require.NoError(t, err)
@@ -36,3 +37,45 @@ func getDB(t *testing.T) (*mongo.Database, func()) {
 		require.NoError(t, db.Disconnect(context.Background()))
 	}
 }
+
+func Test_EnsureIndexes(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	c := &Client{db: db}
+	indexes := requiredIndexes()[EventDeliveryCollection]
+
+	// the first run may find indexes left by New, a second finds them all
+	_, _, err := c.ensureCollectionIndexes(EventDeliveryCollection, indexes)
+	require.NoError(t, err)
+
+	created, present, err := c.ensureCollectionIndexes(EventDeliveryCollection, indexes)
+	require.NoError(t, err)
+	require.Empty(t, created)
+	require.Len(t, present, len(indexes))
+}
+
+func TestClient_Stats(t *testing.T) {
+	db, err := New(getConfig())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Disconnect(context.Background()))
+	}()
+
+	stats, err := db.Stats(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "mongo", stats.Name)
+	require.NotNil(t, stats.Pool)
+	require.Positive(t, stats.Pool.Open)
+
+	var groups *datastore.CollectionStats
+	for i := range stats.Collections {
+		if stats.Collections[i].Name == GroupCollection {
+			groups = &stats.Collections[i]
+		}
+	}
+
+	require.NotNil(t, groups)
+	require.Contains(t, groups.Indexes, "_id_")
+}
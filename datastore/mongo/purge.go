@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// purgeDeleted hard-deletes up to limit documents of collection whose
+// deleted_at is before deletedBefore. Documents that were never deleted
+// have no deleted_at, or a zero one for api keys, and are left alone.
+// If beforeDelete is set it's called with the uids of the batch before
+// they are deleted, so their dependents can be purged first.
+func purgeDeleted(ctx context.Context, collection *mongo.Collection, deletedBefore time.Time, limit int, beforeDelete func(ctx context.Context, uids []string) error) (int64, error) {
+	filter := bson.M{
+		"deleted_at": bson.M{
+			"$gt": primitive.DateTime(0),
+			"$lt": primitive.NewDateTimeFromTime(deletedBefore),
+		},
+	}
+
+	opts := options.Find().SetLimit(int64(limit)).SetProjection(bson.M{"_id": 1, "uid": 1})
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	var docs []struct {
+		ID  primitive.ObjectID `bson:"_id"`
+		UID string             `bson:"uid"`
+	}
+
+	err = cur.All(ctx, &docs)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(docs))
+	uids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = docs[i].ID
+		uids[i] = docs[i].UID
+	}
+
+	if beforeDelete != nil {
+		err = beforeDelete(ctx, uids)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// readPreference is where the client sends reads, preferring members of
+// this instance's region when the configured mode allows secondaries.
+// It defaults to the nearest member when only a region is set.
+func readPreference(cfg config.Configuration) (*readpref.ReadPref, error) {
+	region := cfg.Region.Name
+
+	mode := readpref.PrimaryMode
+	if region != "" {
+		mode = readpref.NearestMode
+	}
+
+	if cfg.Database.ReadPreference != "" {
+		var err error
+		mode, err = readpref.ModeFromString(string(cfg.Database.ReadPreference))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if mode == readpref.PrimaryMode || region == "" {
+		return readpref.New(mode)
+	}
+
+	return readpref.New(mode, readpref.WithTagSets(tag.Set{{Name: "region", Value: region}}, tag.Set{}))
+}
+
+// nearestInRegion reads from the nearest member tagged with region,
+// falling back to any member when none is.
+func nearestInRegion(region string) *readpref.ReadPref {
+	return readpref.Nearest(readpref.WithTagSets(tag.Set{{Name: "region", Value: region}}, tag.Set{}))
+}
+
+// regional returns c with the reads made for ctx going to the nearest
+// member of the region ctx is tagged with. Writes always go to the
+// primary, and so do reads in a transaction.
+func regional(ctx context.Context, c *mongo.Collection) *mongo.Collection {
+	region := datastore.RegionFromContext(ctx)
+	if region == "" || mongo.SessionFromContext(ctx) != nil {
+		return c
+	}
+
+	clone, err := c.Clone(options.Collection().SetReadPreference(nearestInRegion(region)))
+	if err != nil {
+		return c
+	}
+
+	return clone
+}
@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func Test_readPreference(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Configuration
+		wantMode readpref.Mode
+		wantTags bool
+	}{
+		{
+			name:     "should_use_configured_mode",
+			cfg:      config.Configuration{Database: config.DatabaseConfiguration{ReadPreference: config.SecondaryPreferredReadPreference}},
+			wantMode: readpref.SecondaryPreferredMode,
+		},
+		{
+			name:     "should_default_to_nearest_in_region",
+			cfg:      config.Configuration{Region: config.RegionConfiguration{Name: "eu-west"}},
+			wantMode: readpref.NearestMode,
+			wantTags: true,
+		},
+		{
+			name: "should_prefer_region_for_configured_mode",
+			cfg: config.Configuration{
+				Database: config.DatabaseConfiguration{ReadPreference: config.SecondaryReadPreference},
+				Region:   config.RegionConfiguration{Name: "eu-west"},
+			},
+			wantMode: readpref.SecondaryMode,
+			wantTags: true,
+		},
+		{
+			name: "should_not_tag_primary",
+			cfg: config.Configuration{
+				Database: config.DatabaseConfiguration{ReadPreference: config.PrimaryReadPreference},
+				Region:   config.RegionConfiguration{Name: "eu-west"},
+			},
+			wantMode: readpref.PrimaryMode,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rp, err := readPreference(tc.cfg)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantMode, rp.Mode())
+			require.Equal(t, tc.wantTags, len(rp.TagSets()) > 0)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultMaxPoolSize is the driver's pool size when none is configured.
+const defaultMaxPoolSize = 100
+
+// poolMonitor keeps a running count of the driver's connections, the
+// driver doesn't expose its pool so it's tracked from the pool events.
+type poolMonitor struct {
+	open    int64
+	inUse   int64
+	maxSize uint64
+}
+
+func newPoolMonitor(maxSize uint64) *poolMonitor {
+	if maxSize == 0 {
+		maxSize = defaultMaxPoolSize
+	}
+
+	return &poolMonitor{maxSize: maxSize}
+}
+
+func (p *poolMonitor) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				atomic.AddInt64(&p.open, 1)
+			case event.ConnectionClosed:
+				atomic.AddInt64(&p.open, -1)
+			case event.GetSucceeded:
+				atomic.AddInt64(&p.inUse, 1)
+			case event.ConnectionReturned:
+				atomic.AddInt64(&p.inUse, -1)
+			}
+		},
+	}
+}
+
+func (p *poolMonitor) stats() *datastore.PoolStats {
+	return &datastore.PoolStats{
+		Open:    atomic.LoadInt64(&p.open),
+		InUse:   atomic.LoadInt64(&p.inUse),
+		MaxSize: p.maxSize,
+	}
+}
+
+func (c *Client) Stats(ctx context.Context) (*datastore.DatastoreStats, error) {
+	names, err := c.db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	stats := &datastore.DatastoreStats{
+		Name:        c.GetName(),
+		Collections: make([]datastore.CollectionStats, 0, len(names)),
+		Pool:        c.pool.stats(),
+	}
+
+	for _, name := range names {
+		cs, err := collectionStats(ctx, c.db.Collection(name))
+		if err != nil {
+			return nil, err
+		}
+
+		stats.StorageSize += cs.StorageSize
+		stats.Collections = append(stats.Collections, *cs)
+	}
+
+	lag, err := replicationLag(ctx, c.db.Client())
+	if err != nil {
+		return nil, err
+	}
+	stats.ReplicationLag = lag
+
+	return stats, nil
+}
+
+func collectionStats(ctx context.Context, coll *mongo.Collection) (*datastore.CollectionStats, error) {
+	documents, err := coll.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		StorageSize int64 `bson:"storageSize"`
+	}
+
+	err = coll.Database().RunCommand(ctx, bson.D{{Key: "collStats", Value: coll.Name()}}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := coll.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		indexes = append(indexes, spec.Name)
+	}
+
+	return &datastore.CollectionStats{
+		Name:        coll.Name(),
+		Documents:   documents,
+		StorageSize: result.StorageSize,
+		Indexes:     indexes,
+	}, nil
+}
+
+// replicationLag returns how far the slowest secondary trails the primary
+// in milliseconds, or nil when mongo isn't deployed as a replica set.
+func replicationLag(ctx context.Context, client *mongo.Client) (*int64, error) {
+	var status struct {
+		Members []struct {
+			StateStr   string    `bson:"stateStr"`
+			OptimeDate time.Time `bson:"optimeDate"`
+		} `bson:"members"`
+	}
+
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "replSetGetStatus", Value: 1}}).Decode(&status)
+	if err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) {
+			// standalone deployments and mongos don't have a replica set
+			// status, neither has any lag to report
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var primary time.Time
+	for _, m := range status.Members {
+		if m.StateStr == "PRIMARY" {
+			primary = m.OptimeDate
+		}
+	}
+
+	if primary.IsZero() {
+		return nil, nil
+	}
+
+	var lag int64
+	for _, m := range status.Members {
+		if m.StateStr != "SECONDARY" {
+			continue
+		}
+
+		if l := primary.Sub(m.OptimeDate).Milliseconds(); l > lag {
+			lag = l
+		}
+	}
+
+	return &lag, nil
+}
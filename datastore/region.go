@@ -0,0 +1,17 @@
+package datastore
+
+import "context"
+
+type regionCtxKey struct{}
+
+// WithRegion returns a copy of ctx tagged with region, reads made with
+// it prefer the database members of that region.
+func WithRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, regionCtxKey{}, region)
+}
+
+// RegionFromContext returns the region ctx is tagged with, if any.
+func RegionFromContext(ctx context.Context) string {
+	region, _ := ctx.Value(regionCtxKey{}).(string)
+	return region
+}
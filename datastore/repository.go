@@ -2,6 +2,7 @@ package datastore
 
 import (
 	"context"
+	"time"
 )
 
 type APIKeyRepository interface {
@@ -12,20 +13,94 @@ type APIKeyRepository interface {
 	FindAPIKeyByHash(context.Context, string) (*APIKey, error)
 	RevokeAPIKeys(context.Context, []string) error
 	LoadAPIKeysPaged(context.Context, *Pageable) ([]APIKey, PaginationData, error)
+
+	// FindAPIKeysByGroupID pages through the api keys whose role includes
+	// the group.
+	FindAPIKeysByGroupID(ctx context.Context, groupID string, pageable *Pageable) ([]APIKey, PaginationData, error)
+
+	// PurgeDeleted hard-deletes up to limit api keys revoked before
+	// deletedBefore and returns how many it deleted.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+}
+
+type AuditLogRepository interface {
+	Append(context.Context, *AuditLog) error
+	LoadAuditLogsPaged(context.Context, string, Pageable) ([]AuditLog, PaginationData, error)
+}
+
+type ArchiveRepository interface {
+	CreateArchiveManifest(context.Context, *ArchiveManifest) error
+	UpdateArchiveManifest(context.Context, *ArchiveManifest) error
+	FindArchiveManifestByID(context.Context, string) (*ArchiveManifest, error)
+	LoadArchiveManifestsPaged(context.Context, string, Pageable) ([]ArchiveManifest, PaginationData, error)
+}
+
+type ExportJobRepository interface {
+	CreateExportJob(context.Context, *ExportJob) error
+	UpdateExportJob(context.Context, *ExportJob) error
+	FindExportJobByID(context.Context, string) (*ExportJob, error)
+
+	// LoadExpiredExportJobs loads up to limit jobs that expired before
+	// expiredBefore.
+	LoadExpiredExportJobs(ctx context.Context, expiredBefore time.Time, limit int) ([]ExportJob, error)
+	DeleteExportJob(context.Context, string) error
 }
 
 type EventDeliveryRepository interface {
 	CreateEventDelivery(context.Context, *EventDelivery) error
+
+	// CreateEventDeliveries writes deliveries in one bulk write. When only
+	// some are written the error is a *DeliveriesWriteError.
+	CreateEventDeliveries(context.Context, []*EventDelivery) error
 	FindEventDeliveryByID(context.Context, string) (*EventDelivery, error)
 	FindEventDeliveriesByIDs(context.Context, []string) ([]EventDelivery, error)
 	FindEventDeliveriesByEventID(context.Context, string) ([]EventDelivery, error)
 	CountDeliveriesByStatus(context.Context, EventDeliveryStatus, SearchParams) (int64, error)
+
+	// CountPendingDeliveriesByGroup counts the deliveries of a group that
+	// are in one of the PendingEventStatuses.
+	CountPendingDeliveriesByGroup(ctx context.Context, groupID string) (int64, error)
 	UpdateStatusOfEventDelivery(context.Context, EventDelivery, EventDeliveryStatus) error
 	UpdateStatusOfEventDeliveries(context.Context, []string, EventDeliveryStatus) error
 
-	UpdateEventDeliveryWithAttempt(context.Context, EventDelivery, DeliveryAttempt) error
-	CountEventDeliveries(context.Context, string, string, string, []EventDeliveryStatus, SearchParams) (int64, error)
-	LoadEventDeliveriesPaged(context.Context, string, string, string, []EventDeliveryStatus, SearchParams, Pageable) ([]EventDelivery, PaginationData, error)
+	// TransitionStatus moves the delivery id from status from to status
+	// to. It reports false when the delivery is no longer in status from,
+	// e.g because another worker got to it first, and returns
+	// ErrIllegalStatusTransition for moves CanTransitionTo refuses.
+	TransitionStatus(ctx context.Context, id string, from, to EventDeliveryStatus) (bool, error)
+	RetryAllFailed(ctx context.Context, groupID string) (int64, error)
+
+	// ForceCompleteStuckDeliveries fails the deliveries that have been in
+	// processing, untouched, for longer than olderThan, e.g because the
+	// worker sending them crashed. It returns how many were failed.
+	ForceCompleteStuckDeliveries(ctx context.Context, olderThan time.Duration) (int64, error)
+	DeleteEventDeliveries(context.Context, []string) error
+
+	// UpdateEventDeliveryWithAttempt saves the status, description and
+	// metadata of the delivery with attempt added to its attempts. It only
+	// updates a delivery that is still processing, and reports false when
+	// it isn't, e.g because it was force completed while being sent.
+	UpdateEventDeliveryWithAttempt(context.Context, EventDelivery, DeliveryAttempt) (bool, error)
+	CountEventDeliveries(context.Context, string, string, string, string, []EventDeliveryStatus, SearchParams) (int64, error)
+	LoadEventDeliveriesPaged(context.Context, string, string, string, string, []EventDeliveryStatus, SearchParams, Pageable) ([]EventDelivery, PaginationData, error)
+
+	// LoadEventDeliveriesCursored loads up to perPage deliveries past
+	// cursor, newest first, or the first ones without a cursor.
+	LoadEventDeliveriesCursored(ctx context.Context, groupID, appID, eventID, endpointID string, status []EventDeliveryStatus, searchParams SearchParams, cursor *Cursor, perPage int) ([]EventDelivery, error)
+	LoadDeliveryStatistics(ctx context.Context, groupID, appID string, searchParams SearchParams) (*DeliveryStatistics, error)
+	FindDeliveriesByAppID(ctx context.Context, appID string, pageable Pageable) ([]EventDelivery, PaginationData, error)
+
+	// UpdateEventDeliveriesGroupID moves the deliveries of an app to
+	// another group, save for those in one of the excluded statuses.
+	UpdateEventDeliveriesGroupID(ctx context.Context, appID, groupID string, excluded []EventDeliveryStatus) error
+
+	// FindRecentAttemptsByApp returns the last n delivery attempts made to
+	// the app's endpoints, newest first.
+	FindRecentAttemptsByApp(ctx context.Context, appID string, n int) ([]DeliveryAttempt, error)
+
+	// FindGroupsWithHighFailureRate returns the ids of groups whose share
+	// of failed deliveries over the last hour is at least threshold.
+	FindGroupsWithHighFailureRate(ctx context.Context, threshold float64) ([]string, error)
 }
 
 type EventRepository interface {
@@ -33,23 +108,105 @@ type EventRepository interface {
 	LoadEventIntervals(context.Context, string, SearchParams, Period, int) ([]EventInterval, error)
 	FindEventByID(ctx context.Context, id string) (*Event, error)
 	CountGroupMessages(ctx context.Context, groupID string) (int64, error)
+	CountGroupMessagesByType(ctx context.Context, groupID string) (map[string]int64, error)
 	LoadEventsPaged(context.Context, string, string, SearchParams, Pageable) ([]Event, PaginationData, error)
+
+	// LoadEventsCursored loads up to perPage events past cursor, newest
+	// first, or the first ones without a cursor. query and metadata
+	// narrow the events like they do for SearchEventsPaged.
+	LoadEventsCursored(ctx context.Context, groupID, appID, query string, metadata map[string]string, searchParams SearchParams, cursor *Cursor, perPage int) ([]Event, error)
+	CountEvents(context.Context, string, string, SearchParams) (int64, error)
+	SearchEventsPaged(context.Context, string, string, string, map[string]string, SearchParams, Pageable) ([]Event, PaginationData, error)
 	DeleteGroupEvents(context.Context, string) error
+	DeleteEvents(context.Context, []string) error
+	UpdateEventsGroupID(ctx context.Context, appID, groupID string) error
+
+	// MarkEventBridged sets the BridgedAt of the event id to bridgedAt,
+	// unless it is already set. It reports whether it was this call that
+	// set it, so that only one caller forwards the event.
+	MarkEventBridged(ctx context.Context, id string, bridgedAt time.Time) (bool, error)
+
+	// EnsureRetentionIndex makes the database expire the group's events,
+	// and their deliveries, retentionDays after they were created, or keep
+	// them when it is zero. It applies to the events that already exist.
+	EnsureRetentionIndex(ctx context.Context, groupID string, retentionDays int) error
+
+	// PurgeDeleted hard-deletes up to limit events soft-deleted before
+	// deletedBefore, along with their deliveries, and returns how many
+	// events it deleted.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+}
+
+type EventBridgeRepository interface {
+	CreateEventBridgeRule(context.Context, *EventBridgeRule) error
+	FindEventBridgeRuleByID(context.Context, string) (*EventBridgeRule, error)
+	LoadEventBridgeRules(ctx context.Context, sourceGroupID string) ([]EventBridgeRule, error)
+	DeleteEventBridgeRule(context.Context, string) error
+}
+
+type EventTypeRepository interface {
+	CreateEventType(context.Context, *EventTypeDefinition) error
+	FindEventTypeByName(ctx context.Context, groupID, name string) (*EventTypeDefinition, error)
+	LoadEventTypes(ctx context.Context, groupID string) ([]EventTypeDefinition, error)
+	DeleteEventType(ctx context.Context, groupID, name string) error
+}
+
+// GlobalSettingsRepository stores the single GlobalSettings document.
+type GlobalSettingsRepository interface {
+	Get(context.Context) (*GlobalSettings, error)
+	Update(context.Context, *GlobalSettings) error
 }
 
 type GroupRepository interface {
 	LoadGroups(context.Context, *GroupFilter) ([]*Group, error)
 	CreateGroup(context.Context, *Group) error
 	UpdateGroup(context.Context, *Group) error
+
+	// UpdateGroupConfig changes the fields of the group's config set in
+	// patch, leaving the others as they are.
+	UpdateGroupConfig(ctx context.Context, groupID string, patch *GroupConfigPatch) error
 	DeleteGroup(ctx context.Context, uid string) error
+
+	// UpdateGroupStatus moves the group id to status, returning
+	// ErrIllegalGroupStatusTransition for moves CanTransitionTo refuses.
+	// Moving it to DeletedGroupStatus soft deletes it.
+	UpdateGroupStatus(ctx context.Context, id string, status GroupStatus) error
 	FetchGroupByID(context.Context, string) (*Group, error)
+
+	// FetchGroupsByIDs returns the groups found for ids, in the order of
+	// ids. Ids with no group are skipped, so fewer groups than ids may be
+	// returned.
 	FetchGroupsByIDs(context.Context, []string) ([]Group, error)
+
+	// DeleteGroupCascade deletes the group's events, then deletes the
+	// group along with its apps and revokes the api keys scoped to it, all
+	// together where the database supports transactions. A failed step is
+	// reported as a *GroupCascadeError.
+	DeleteGroupCascade(ctx context.Context, groupID string) error
+
+	// WithTransaction runs fn so that the writes it makes through any
+	// repository with the context it is given are applied together or
+	// not at all. The changes are discarded when fn returns an error.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+
+	// PurgeDeleted hard-deletes up to limit groups deleted before
+	// deletedBefore and returns how many it deleted.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
 }
 
 type ApplicationRepository interface {
 	CreateApplication(context.Context, *Application) error
-	LoadApplicationsPaged(context.Context, string, string, Pageable) ([]Application, PaginationData, error)
+	LoadApplicationsPaged(context.Context, string, *ApplicationFilter, Pageable) ([]Application, PaginationData, error)
 	FindApplicationByID(context.Context, string) (*Application, error)
+
+	// FindApplicationsByOwnerID returns the apps of a group that belong
+	// to ownerID.
+	FindApplicationsByOwnerID(ctx context.Context, groupID, ownerID string) ([]Application, error)
+
+	// FindApplicationsByIDs returns the apps found for ids. Ids with no app
+	// are skipped, so fewer apps than ids may be returned.
+	FindApplicationsByIDs(ctx context.Context, ids []string) ([]Application, error)
+
 	UpdateApplication(context.Context, *Application) error
 	DeleteApplication(context.Context, *Application) error
 	CountGroupApplications(ctx context.Context, groupID string) (int64, error)
@@ -58,4 +215,27 @@ type ApplicationRepository interface {
 	SearchApplicationsByGroupId(context.Context, string, SearchParams) ([]Application, error)
 	FindApplicationEndpointByID(context.Context, string, string) (*Endpoint, error)
 	UpdateApplicationEndpointsStatus(context.Context, string, []string, EndpointStatus) error
+
+	// DisableApplicationEndpoint marks an endpoint of an app inactive,
+	// recording why and when it was disabled.
+	DisableApplicationEndpoint(ctx context.Context, appID, endpointID, reason string) error
+
+	// UpdateApplicationHealth stores the health score of an app and the
+	// status it implies, stamping when it was checked.
+	UpdateApplicationHealth(ctx context.Context, appID string, score float64, status ApplicationHealthStatus) error
+
+	// FindGroupsWithInactiveEndpoints returns the ids of groups with at
+	// least one endpoint disabled after failing repeatedly.
+	FindGroupsWithInactiveEndpoints(ctx context.Context) ([]string, error)
+
+	// FindDeletedApplicationByID returns a soft-deleted app.
+	FindDeletedApplicationByID(ctx context.Context, id string) (*Application, error)
+
+	// RestoreApplication undoes the soft delete of app, bringing back the
+	// endpoints and events that were deleted along with it.
+	RestoreApplication(ctx context.Context, app *Application) error
+
+	// PurgeDeleted hard-deletes up to limit apps soft-deleted before
+	// deletedBefore and returns how many it deleted.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
 }
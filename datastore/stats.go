@@ -0,0 +1,28 @@
+package datastore
+
+type DatastoreStats struct {
+	Name        string            `json:"name"`
+	StorageSize int64             `json:"storage_size"`
+	Collections []CollectionStats `json:"collections"`
+	Pool        *PoolStats        `json:"pool,omitempty"`
+
+	// ReplicationLag is how far, in milliseconds, the slowest secondary
+	// trails the primary, it is nil when the datastore isn't replicated.
+	ReplicationLag *int64 `json:"replication_lag,omitempty"`
+}
+
+type CollectionStats struct {
+	Name string `json:"name"`
+
+	// Documents is the datastore's estimate, it's read from metadata
+	// instead of counting the collection.
+	Documents   int64    `json:"documents"`
+	StorageSize int64    `json:"storage_size"`
+	Indexes     []string `json:"indexes"`
+}
+
+type PoolStats struct {
+	Open    int64  `json:"open"`
+	InUse   int64  `json:"in_use"`
+	MaxSize uint64 `json:"max_size"`
+}
@@ -6,3 +6,5 @@ package convoy
 //go:generate mockgen --source limiter/limiter.go --destination mocks/limiter.go -package mocks
 //go:generate mockgen --source notification/notification.go --destination mocks/notification.go -package mocks
 //go:generate mockgen --source cache/cache.go --destination mocks/cache.go -package mocks
+//go:generate mockgen --source objectstore/objectstore.go --destination mocks/objectstore.go -package mocks
+//go:generate go run ./v3gen
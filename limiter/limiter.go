@@ -2,27 +2,96 @@ package limiter
 
 import (
 	"context"
+	"sync"
 
 	"github.com/frain-dev/convoy/config"
+	mlimiter "github.com/frain-dev/convoy/limiter/memory"
 	nlimiter "github.com/frain-dev/convoy/limiter/noop"
 	rlimiter "github.com/frain-dev/convoy/limiter/redis"
 	"github.com/go-redis/redis_rate/v9"
+	log "github.com/sirupsen/logrus"
 )
 
 type RateLimiter interface {
 	Allow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error)
 	ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error)
+
+	// Inspect reports how much of the limit is left for key without
+	// taking from it or creating state for a key that hasn't been used.
+	Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error)
 }
 
 func NewLimiter(cfg config.LimiterConfiguration) (RateLimiter, error) {
-	if cfg.Type == config.RedisLimiterProvider {
+	switch cfg.Type {
+	case config.RedisLimiterProvider:
 		ra, err := rlimiter.NewRedisLimiter(cfg.Redis.Dsn)
 		if err != nil {
 			return nil, err
 		}
 
 		return ra, nil
+	case config.InMemoryLimiterProvider:
+		return mlimiter.NewMemoryLimiter(), nil
+	default:
+		return nlimiter.NewNoopLimiter(), nil
+	}
+}
+
+// ReloadableLimiter is a RateLimiter whose backing limiter is rebuilt when
+// the limiter configuration changes.
+type ReloadableLimiter struct {
+	mu      sync.RWMutex
+	cfg     config.LimiterConfiguration
+	limiter RateLimiter
+}
+
+func NewReloadableLimiter(cfg config.LimiterConfiguration) (*ReloadableLimiter, error) {
+	li, err := NewLimiter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadableLimiter{cfg: cfg, limiter: li}, nil
+}
+
+func (r *ReloadableLimiter) Allow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return r.current().Allow(ctx, key, limit, duration)
+}
+
+func (r *ReloadableLimiter) ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return r.current().ShouldAllow(ctx, key, limit, duration)
+}
+
+func (r *ReloadableLimiter) Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return r.current().Inspect(ctx, key, limit, duration)
+}
+
+// OnConfigChange is a config.ConfigChangeListener that swaps in a limiter
+// built from the new configuration. The current limiter is kept if the
+// new one can't be built.
+func (r *ReloadableLimiter) OnConfigChange(cfg config.Configuration) {
+	r.mu.RLock()
+	unchanged := r.cfg == cfg.Limiter
+	r.mu.RUnlock()
+
+	if unchanged {
+		return
 	}
 
-	return nlimiter.NewNoopLimiter(), nil
+	li, err := NewLimiter(cfg.Limiter)
+	if err != nil {
+		log.WithError(err).Error("failed to reload rate limiter")
+		return
+	}
+
+	r.mu.Lock()
+	r.cfg, r.limiter = cfg.Limiter, li
+	r.mu.Unlock()
+}
+
+func (r *ReloadableLimiter) current() RateLimiter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.limiter
 }
@@ -0,0 +1,159 @@
+package mlimiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis_rate/v9"
+)
+
+// sweepInterval is how often buckets that have refilled are dropped.
+const sweepInterval = time.Minute
+
+// bucket is the token bucket of one key, tokens are only topped up when
+// the key is used again.
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  redis_rate.Limit
+}
+
+// MemoryLimiter is a token bucket limiter that keeps its buckets in the
+// process, it only limits correctly when a single instance is running.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (m *MemoryLimiter) Allow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return m.take(key, newLimit(limit, duration), 1), nil
+}
+
+func (m *MemoryLimiter) ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return m.take(key, newLimit(limit, duration), 0), nil
+}
+
+// Inspect reports the key's bucket the way ShouldAllow does, but works
+// on a copy of it so an unused key doesn't get a bucket.
+func (m *MemoryLimiter) Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	l := newLimit(limit, duration)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	b := bucket{tokens: float64(l.Burst), last: now}
+	if existing, ok := m.buckets[key]; ok {
+		b = *existing
+	}
+
+	b.limit = l
+	b.refill(now)
+
+	return &redis_rate.Result{
+		Limit:      l,
+		Remaining:  int(math.Floor(b.tokens)),
+		RetryAfter: -1,
+		ResetAfter: b.durationUntil(float64(l.Burst)),
+	}, nil
+}
+
+// newLimit builds the limit the same way the redis limiter does, a burst
+// of limit+1 that refills over duration, but keeps the duration as it is
+// instead of rounding it to a second, minute or hour.
+func newLimit(limit, duration int) redis_rate.Limit {
+	period := time.Duration(duration)
+	if period <= 0 {
+		period = time.Second
+	}
+
+	return redis_rate.Limit{
+		Period: period,
+		Rate:   limit + 1,
+		Burst:  limit + 1,
+	}
+}
+
+func (m *MemoryLimiter) take(key string, limit redis_rate.Limit, n int) *redis_rate.Result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		m.sweep(now)
+	}
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now, limit: limit}
+		m.buckets[key] = b
+	}
+
+	// if the group or endpoint's limit was changed, the tokens left
+	// carry over up to the new burst
+	b.limit = limit
+	b.refill(now)
+
+	res := &redis_rate.Result{Limit: limit, RetryAfter: -1}
+
+	if b.tokens < float64(n) {
+		res.RetryAfter = b.durationUntil(float64(n))
+		res.ResetAfter = b.durationUntil(float64(limit.Burst))
+		return res
+	}
+
+	b.tokens -= float64(n)
+	res.Allowed = n
+	res.Remaining = int(math.Floor(b.tokens))
+	res.ResetAfter = b.durationUntil(float64(limit.Burst))
+
+	return res
+}
+
+// sweep drops the buckets that would be full by now, they're no different
+// from the bucket that is created on the key's next use.
+func (m *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if b.durationUntil(float64(b.limit.Burst)) <= now.Sub(b.last) {
+			delete(m.buckets, key)
+		}
+	}
+
+	m.lastSweep = now
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed > 0 {
+		b.tokens += b.rate() * float64(elapsed)
+		b.last = now
+	}
+
+	b.tokens = math.Min(b.tokens, float64(b.limit.Burst))
+}
+
+// rate is the number of tokens added every nanosecond.
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Rate) / float64(b.limit.Period)
+}
+
+// durationUntil is how long after the last refill the bucket holds tokens.
+func (b *bucket) durationUntil(tokens float64) time.Duration {
+	if b.tokens >= tokens {
+		return 0
+	}
+
+	return time.Duration(math.Ceil((tokens - b.tokens) / b.rate()))
+}
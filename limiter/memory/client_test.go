@@ -0,0 +1,227 @@
+package mlimiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter() (*MemoryLimiter, *clock) {
+	c := &clock{now: time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+	l := NewMemoryLimiter()
+	l.now = c.Now
+	l.lastSweep = c.Now()
+
+	return l, c
+}
+
+func TestMemoryLimiter_Burst(t *testing.T) {
+	ctx := context.Background()
+	l, _ := newTestLimiter()
+
+	// like the redis limiter, a limit of 10 is a burst of 11
+	for i := 10; i >= 0; i-- {
+		res, err := l.Allow(ctx, "group-1", 10, int(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Allowed)
+		require.Equal(t, i, res.Remaining)
+		require.Equal(t, time.Duration(-1), res.RetryAfter)
+	}
+
+	res, err := l.Allow(ctx, "group-1", 10, int(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 0, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+	require.InDelta(t, time.Minute/11, res.RetryAfter, float64(time.Millisecond))
+	require.InDelta(t, time.Minute, res.ResetAfter, float64(time.Millisecond))
+}
+
+func TestMemoryLimiter_ShouldAllowDoesNotTakeTokens(t *testing.T) {
+	ctx := context.Background()
+	l, _ := newTestLimiter()
+
+	for i := 0; i < 5; i++ {
+		res, err := l.ShouldAllow(ctx, "endpoint", 2, int(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, 3, res.Remaining)
+	}
+
+	_, err := l.Allow(ctx, "endpoint", 2, int(time.Second))
+	require.NoError(t, err)
+
+	res, err := l.ShouldAllow(ctx, "endpoint", 2, int(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryLimiter_Inspect(t *testing.T) {
+	ctx := context.Background()
+	l, c := newTestLimiter()
+
+	res, err := l.Inspect(ctx, "group-1", 10, int(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 11, res.Remaining)
+	require.Equal(t, time.Duration(0), res.ResetAfter)
+	require.Empty(t, l.buckets)
+
+	for i := 0; i < 5; i++ {
+		_, err = l.Allow(ctx, "group-1", 10, int(time.Minute))
+		require.NoError(t, err)
+	}
+
+	// a token back, and a little more so it isn't lost to rounding
+	c.Advance(time.Minute/11 + time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		res, err = l.Inspect(ctx, "group-1", 10, int(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 7, res.Remaining)
+		require.Equal(t, 0, res.Allowed)
+		require.Equal(t, time.Duration(-1), res.RetryAfter)
+		require.InDelta(t, 4*time.Minute/11, res.ResetAfter, float64(2*time.Millisecond))
+	}
+}
+
+func TestMemoryLimiter_SteadyStateThroughput(t *testing.T) {
+	ctx := context.Background()
+	l, c := newTestLimiter()
+
+	// 59 a minute refills a token every second
+	duration, err := time.ParseDuration("1m")
+	require.NoError(t, err)
+
+	for i := 0; i < 60; i++ {
+		res, err := l.Allow(ctx, "endpoint", 59, int(duration))
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Allowed)
+	}
+
+	var allowed int
+	for i := 0; i < 10*60*4; i++ {
+		c.Advance(250 * time.Millisecond)
+
+		res, err := l.Allow(ctx, "endpoint", 59, int(duration))
+		require.NoError(t, err)
+		allowed += res.Allowed
+	}
+
+	require.Equal(t, 10*60, allowed)
+}
+
+func TestMemoryLimiter_HonoursUncommonDurations(t *testing.T) {
+	ctx := context.Background()
+	l, c := newTestLimiter()
+
+	duration, err := time.ParseDuration("10s")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		res, err := l.Allow(ctx, "endpoint", 1, int(duration))
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Allowed)
+	}
+
+	c.Advance(4 * time.Second)
+	res, err := l.Allow(ctx, "endpoint", 1, int(duration))
+	require.NoError(t, err)
+	require.Equal(t, 0, res.Allowed)
+
+	c.Advance(time.Second)
+	res, err = l.Allow(ctx, "endpoint", 1, int(duration))
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Allowed)
+}
+
+func TestMemoryLimiter_KeysDoNotInterfere(t *testing.T) {
+	ctx := context.Background()
+	l, _ := newTestLimiter()
+
+	for i := 0; i < 3; i++ {
+		_, err := l.Allow(ctx, "https://a.example.com", 2, int(time.Minute))
+		require.NoError(t, err)
+	}
+
+	res, err := l.ShouldAllow(ctx, "https://a.example.com", 2, int(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 0, res.Remaining)
+
+	res, err = l.Allow(ctx, "https://b.example.com", 2, int(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Allowed)
+	require.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryLimiter_ConcurrentKeys(t *testing.T) {
+	ctx := context.Background()
+	l, _ := newTestLimiter()
+
+	keys := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	allowed := make([]int64, len(keys))
+
+	var wg sync.WaitGroup
+	for i := range keys {
+		for j := 0; j < 20; j++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				for k := 0; k < 10; k++ {
+					res, err := l.Allow(ctx, keys[i], 49, int(time.Hour))
+					require.NoError(t, err)
+					atomic.AddInt64(&allowed[i], int64(res.Allowed))
+				}
+			}(i)
+		}
+	}
+	wg.Wait()
+
+	for i, key := range keys {
+		require.Equal(t, int64(50), allowed[i], fmt.Sprintf("allowed for %s", key))
+	}
+}
+
+func TestMemoryLimiter_IdleBucketsAreDropped(t *testing.T) {
+	ctx := context.Background()
+	l, c := newTestLimiter()
+
+	_, err := l.Allow(ctx, "idle", 10, int(time.Second))
+	require.NoError(t, err)
+	_, err = l.Allow(ctx, "busy", 10, int(time.Hour))
+	require.NoError(t, err)
+
+	c.Advance(sweepInterval)
+	_, err = l.ShouldAllow(ctx, "other", 10, int(time.Second))
+	require.NoError(t, err)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	require.NotContains(t, l.buckets, "idle")
+	require.Contains(t, l.buckets, "busy")
+	require.Contains(t, l.buckets, "other")
+}
@@ -35,3 +35,17 @@ func (n NoopLimiter) ShouldAllow(ctx context.Context, key string, limit, duratio
 		},
 		nil
 }
+
+// Inspect reports a full bucket for limit, nothing is ever taken from it.
+func (n NoopLimiter) Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return &redis_rate.Result{
+			Limit: redis_rate.Limit{
+				Period: time.Duration(duration),
+				Rate:   limit + 1,
+				Burst:  limit + 1,
+			},
+			Remaining:  limit + 1,
+			RetryAfter: -1,
+		},
+		nil
+}
@@ -29,23 +29,20 @@ func NewRedisLimiter(dsn string) (*RedisLimiter, error) {
 }
 
 func (r *RedisLimiter) Allow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
-	var d time.Duration
+	l := newLimit(limit, duration)
 
-	if duration == int(time.Hour) {
-		d = time.Hour
-	} else if duration == int(time.Minute) {
-		d = time.Minute
-	} else {
-		d = time.Second
+	result, err := r.limiter.Allow(ctx, key, l)
+	if err != nil {
+		return nil, err
 	}
 
-	l := redis_rate.Limit{
-		Period: d,
-		Rate:   limit + 1,
-		Burst:  limit + 1,
-	}
+	return result, nil
+}
 
-	result, err := r.limiter.Allow(ctx, key, l)
+func (r *RedisLimiter) ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	l := newLimit(limit, duration)
+
+	result, err := r.limiter.AllowN(ctx, key, l, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +50,13 @@ func (r *RedisLimiter) Allow(ctx context.Context, key string, limit, duration in
 	return result, nil
 }
 
-func (r *RedisLimiter) ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+// Inspect is ShouldAllow, a zero cost request leaves the key's state as
+// it is.
+func (r *RedisLimiter) Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	return r.limiter.AllowN(ctx, key, newLimit(limit, duration), 0)
+}
+
+func newLimit(limit, duration int) redis_rate.Limit {
 	var d time.Duration
 
 	if duration == int(time.Hour) {
@@ -64,16 +67,9 @@ func (r *RedisLimiter) ShouldAllow(ctx context.Context, key string, limit, durat
 		d = time.Second
 	}
 
-	l := redis_rate.Limit{
+	return redis_rate.Limit{
 		Period: d,
 		Rate:   limit + 1,
 		Burst:  limit + 1,
 	}
-
-	result, err := r.limiter.AllowN(ctx, key, l, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
 }
@@ -77,3 +77,30 @@ func Test_RateLimitAllow(t *testing.T) {
 		})
 	}
 }
+
+func Test_RateLimitInspect(t *testing.T) {
+	dsn := getDSN()
+
+	err := flushRedis(dsn)
+	require.NoError(t, err)
+
+	limiter, err := NewRedisLimiter(dsn)
+	require.NoError(t, err)
+
+	res, err := limiter.Inspect(context.Background(), "UID", 2, int(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Remaining)
+
+	_, err = limiter.Allow(context.Background(), "UID", 2, int(time.Minute))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		res, err = limiter.Inspect(context.Background(), "UID", 2, int(time.Minute))
+		require.NoError(t, err)
+
+		require.Equal(t, 3, res.Limit.Rate)
+		require.Equal(t, 2, res.Remaining)
+		require.LessOrEqual(t, int(res.ResetAfter), int(time.Minute/3))
+		require.Greater(t, int(res.ResetAfter), int(time.Duration(0)))
+	}
+}
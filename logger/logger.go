@@ -66,3 +66,17 @@ func NewLogger(cfg config.LoggerConfiguration) (Logger, error) {
 func CanLogHttpRequest(log Logger) bool {
 	return log.WithLogger().IsLevelEnabled(logrus.InfoLevel)
 }
+
+// NewConfigChangeListener returns a config.ConfigChangeListener that sets
+// lo's level to the new config's, so it can be changed without a restart.
+func NewConfigChangeListener(lo Logger) config.ConfigChangeListener {
+	return func(cfg config.Configuration) {
+		level, err := logrus.ParseLevel(DefaultLogLevel(cfg.Logger.ServerLog.Level))
+		if err != nil {
+			logrus.WithError(err).Error("failed to reload log level")
+			return
+		}
+
+		lo.WithLogger().SetLevel(level)
+	}
+}
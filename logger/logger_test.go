@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigChangeListener_SetsLevel(t *testing.T) {
+	lo := setup("info", t)
+
+	cfg := config.Configuration{}
+	cfg.Logger.ServerLog.Level = "debug"
+	NewConfigChangeListener(lo)(cfg)
+
+	require.Equal(t, logrus.DebugLevel, lo.WithLogger().GetLevel())
+}
@@ -50,6 +50,21 @@ func (mr *MockRateLimiterMockRecorder) Allow(ctx, key, limit, duration interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimiter)(nil).Allow), ctx, key, limit, duration)
 }
 
+// Inspect mocks base method.
+func (m *MockRateLimiter) Inspect(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", ctx, key, limit, duration)
+	ret0, _ := ret[0].(*redis_rate.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockRateLimiterMockRecorder) Inspect(ctx, key, limit, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockRateLimiter)(nil).Inspect), ctx, key, limit, duration)
+}
+
 // ShouldAllow mocks base method.
 func (m *MockRateLimiter) ShouldAllow(ctx context.Context, key string, limit, duration int) (*redis_rate.Result, error) {
 	m.ctrl.T.Helper()
@@ -48,3 +48,40 @@ func (mr *MockSenderMockRecorder) SendNotification(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNotification", reflect.TypeOf((*MockSender)(nil).SendNotification), arg0, arg1)
 }
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyEndpointDisabled mocks base method.
+func (m *MockNotifier) NotifyEndpointDisabled(arg0 context.Context, arg1 *notification.EndpointDisabled) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyEndpointDisabled", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyEndpointDisabled indicates an expected call of NotifyEndpointDisabled.
+func (mr *MockNotifierMockRecorder) NotifyEndpointDisabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyEndpointDisabled", reflect.TypeOf((*MockNotifier)(nil).NotifyEndpointDisabled), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	datastore "github.com/frain-dev/convoy/datastore"
 	gomock "github.com/golang/mock/gomock"
@@ -94,6 +95,22 @@ func (mr *MockAPIKeyRepositoryMockRecorder) FindAPIKeyByMaskID(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeyByMaskID", reflect.TypeOf((*MockAPIKeyRepository)(nil).FindAPIKeyByMaskID), arg0, arg1)
 }
 
+// FindAPIKeysByGroupID mocks base method.
+func (m *MockAPIKeyRepository) FindAPIKeysByGroupID(ctx context.Context, groupID string, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAPIKeysByGroupID", ctx, groupID, pageable)
+	ret0, _ := ret[0].([]datastore.APIKey)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAPIKeysByGroupID indicates an expected call of FindAPIKeysByGroupID.
+func (mr *MockAPIKeyRepositoryMockRecorder) FindAPIKeysByGroupID(ctx, groupID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeysByGroupID", reflect.TypeOf((*MockAPIKeyRepository)(nil).FindAPIKeysByGroupID), ctx, groupID, pageable)
+}
+
 // LoadAPIKeysPaged mocks base method.
 func (m *MockAPIKeyRepository) LoadAPIKeysPaged(arg0 context.Context, arg1 *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +127,21 @@ func (mr *MockAPIKeyRepositoryMockRecorder) LoadAPIKeysPaged(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAPIKeysPaged", reflect.TypeOf((*MockAPIKeyRepository)(nil).LoadAPIKeysPaged), arg0, arg1)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockAPIKeyRepository) PurgeDeleted(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockAPIKeyRepositoryMockRecorder) PurgeDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockAPIKeyRepository)(nil).PurgeDeleted), arg0, arg1, arg2)
+}
+
 // RevokeAPIKeys mocks base method.
 func (m *MockAPIKeyRepository) RevokeAPIKeys(arg0 context.Context, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +170,236 @@ func (mr *MockAPIKeyRepositoryMockRecorder) UpdateAPIKey(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).UpdateAPIKey), arg0, arg1)
 }
 
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockAuditLogRepository) Append(arg0 context.Context, arg1 *datastore.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockAuditLogRepositoryMockRecorder) Append(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockAuditLogRepository)(nil).Append), arg0, arg1)
+}
+
+// LoadAuditLogsPaged mocks base method.
+func (m *MockAuditLogRepository) LoadAuditLogsPaged(arg0 context.Context, arg1 string, arg2 datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuditLogsPaged", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.AuditLog)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadAuditLogsPaged indicates an expected call of LoadAuditLogsPaged.
+func (mr *MockAuditLogRepositoryMockRecorder) LoadAuditLogsPaged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuditLogsPaged", reflect.TypeOf((*MockAuditLogRepository)(nil).LoadAuditLogsPaged), arg0, arg1, arg2)
+}
+
+// MockArchiveRepository is a mock of ArchiveRepository interface.
+type MockArchiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveRepositoryMockRecorder
+}
+
+// MockArchiveRepositoryMockRecorder is the mock recorder for MockArchiveRepository.
+type MockArchiveRepositoryMockRecorder struct {
+	mock *MockArchiveRepository
+}
+
+// NewMockArchiveRepository creates a new mock instance.
+func NewMockArchiveRepository(ctrl *gomock.Controller) *MockArchiveRepository {
+	mock := &MockArchiveRepository{ctrl: ctrl}
+	mock.recorder = &MockArchiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveRepository) EXPECT() *MockArchiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateArchiveManifest mocks base method.
+func (m *MockArchiveRepository) CreateArchiveManifest(arg0 context.Context, arg1 *datastore.ArchiveManifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArchiveManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateArchiveManifest indicates an expected call of CreateArchiveManifest.
+func (mr *MockArchiveRepositoryMockRecorder) CreateArchiveManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArchiveManifest", reflect.TypeOf((*MockArchiveRepository)(nil).CreateArchiveManifest), arg0, arg1)
+}
+
+// FindArchiveManifestByID mocks base method.
+func (m *MockArchiveRepository) FindArchiveManifestByID(arg0 context.Context, arg1 string) (*datastore.ArchiveManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindArchiveManifestByID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ArchiveManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindArchiveManifestByID indicates an expected call of FindArchiveManifestByID.
+func (mr *MockArchiveRepositoryMockRecorder) FindArchiveManifestByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArchiveManifestByID", reflect.TypeOf((*MockArchiveRepository)(nil).FindArchiveManifestByID), arg0, arg1)
+}
+
+// LoadArchiveManifestsPaged mocks base method.
+func (m *MockArchiveRepository) LoadArchiveManifestsPaged(arg0 context.Context, arg1 string, arg2 datastore.Pageable) ([]datastore.ArchiveManifest, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadArchiveManifestsPaged", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.ArchiveManifest)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadArchiveManifestsPaged indicates an expected call of LoadArchiveManifestsPaged.
+func (mr *MockArchiveRepositoryMockRecorder) LoadArchiveManifestsPaged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadArchiveManifestsPaged", reflect.TypeOf((*MockArchiveRepository)(nil).LoadArchiveManifestsPaged), arg0, arg1, arg2)
+}
+
+// UpdateArchiveManifest mocks base method.
+func (m *MockArchiveRepository) UpdateArchiveManifest(arg0 context.Context, arg1 *datastore.ArchiveManifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArchiveManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArchiveManifest indicates an expected call of UpdateArchiveManifest.
+func (mr *MockArchiveRepositoryMockRecorder) UpdateArchiveManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArchiveManifest", reflect.TypeOf((*MockArchiveRepository)(nil).UpdateArchiveManifest), arg0, arg1)
+}
+
+// MockExportJobRepository is a mock of ExportJobRepository interface.
+type MockExportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportJobRepositoryMockRecorder
+}
+
+// MockExportJobRepositoryMockRecorder is the mock recorder for MockExportJobRepository.
+type MockExportJobRepositoryMockRecorder struct {
+	mock *MockExportJobRepository
+}
+
+// NewMockExportJobRepository creates a new mock instance.
+func NewMockExportJobRepository(ctrl *gomock.Controller) *MockExportJobRepository {
+	mock := &MockExportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockExportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportJobRepository) EXPECT() *MockExportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateExportJob mocks base method.
+func (m *MockExportJobRepository) CreateExportJob(arg0 context.Context, arg1 *datastore.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExportJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExportJob indicates an expected call of CreateExportJob.
+func (mr *MockExportJobRepositoryMockRecorder) CreateExportJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExportJob", reflect.TypeOf((*MockExportJobRepository)(nil).CreateExportJob), arg0, arg1)
+}
+
+// DeleteExportJob mocks base method.
+func (m *MockExportJobRepository) DeleteExportJob(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExportJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExportJob indicates an expected call of DeleteExportJob.
+func (mr *MockExportJobRepositoryMockRecorder) DeleteExportJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExportJob", reflect.TypeOf((*MockExportJobRepository)(nil).DeleteExportJob), arg0, arg1)
+}
+
+// FindExportJobByID mocks base method.
+func (m *MockExportJobRepository) FindExportJobByID(arg0 context.Context, arg1 string) (*datastore.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExportJobByID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExportJobByID indicates an expected call of FindExportJobByID.
+func (mr *MockExportJobRepositoryMockRecorder) FindExportJobByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExportJobByID", reflect.TypeOf((*MockExportJobRepository)(nil).FindExportJobByID), arg0, arg1)
+}
+
+// LoadExpiredExportJobs mocks base method.
+func (m *MockExportJobRepository) LoadExpiredExportJobs(arg0 context.Context, arg1 time.Time, arg2 int) ([]datastore.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadExpiredExportJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadExpiredExportJobs indicates an expected call of LoadExpiredExportJobs.
+func (mr *MockExportJobRepositoryMockRecorder) LoadExpiredExportJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadExpiredExportJobs", reflect.TypeOf((*MockExportJobRepository)(nil).LoadExpiredExportJobs), arg0, arg1, arg2)
+}
+
+// UpdateExportJob mocks base method.
+func (m *MockExportJobRepository) UpdateExportJob(arg0 context.Context, arg1 *datastore.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExportJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExportJob indicates an expected call of UpdateExportJob.
+func (mr *MockExportJobRepositoryMockRecorder) UpdateExportJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExportJob", reflect.TypeOf((*MockExportJobRepository)(nil).UpdateExportJob), arg0, arg1)
+}
+
 // MockEventDeliveryRepository is a mock of EventDeliveryRepository interface.
 type MockEventDeliveryRepository struct {
 	ctrl     *gomock.Controller
@@ -177,18 +439,47 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CountDeliveriesByStatus(arg0,
 }
 
 // CountEventDeliveries mocks base method.
-func (m *MockEventDeliveryRepository) CountEventDeliveries(arg0 context.Context, arg1, arg2, arg3 string, arg4 []datastore.EventDeliveryStatus, arg5 datastore.SearchParams) (int64, error) {
+func (m *MockEventDeliveryRepository) CountEventDeliveries(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 []datastore.EventDeliveryStatus, arg6 datastore.SearchParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountEventDeliveries", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret := m.ctrl.Call(m, "CountEventDeliveries", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountEventDeliveries indicates an expected call of CountEventDeliveries.
-func (mr *MockEventDeliveryRepositoryMockRecorder) CountEventDeliveries(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+func (mr *MockEventDeliveryRepositoryMockRecorder) CountEventDeliveries(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountEventDeliveries), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountEventDeliveries), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// CountPendingDeliveriesByGroup mocks base method.
+func (m *MockEventDeliveryRepository) CountPendingDeliveriesByGroup(ctx context.Context, groupID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingDeliveriesByGroup", ctx, groupID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingDeliveriesByGroup indicates an expected call of CountPendingDeliveriesByGroup.
+func (mr *MockEventDeliveryRepositoryMockRecorder) CountPendingDeliveriesByGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingDeliveriesByGroup", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountPendingDeliveriesByGroup), ctx, groupID)
+}
+
+// CreateEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) CreateEventDeliveries(arg0 context.Context, arg1 []*datastore.EventDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventDeliveries indicates an expected call of CreateEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) CreateEventDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CreateEventDeliveries), arg0, arg1)
 }
 
 // CreateEventDelivery mocks base method.
@@ -205,6 +496,36 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CreateEventDelivery(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CreateEventDelivery), arg0, arg1)
 }
 
+// DeleteEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) DeleteEventDeliveries(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventDeliveries indicates an expected call of DeleteEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) DeleteEventDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).DeleteEventDeliveries), arg0, arg1)
+}
+
+// FindDeliveriesByAppID mocks base method.
+func (m *MockEventDeliveryRepository) FindDeliveriesByAppID(ctx context.Context, appID string, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveriesByAppID", ctx, appID, pageable)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindDeliveriesByAppID indicates an expected call of FindDeliveriesByAppID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindDeliveriesByAppID(ctx, appID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveriesByAppID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindDeliveriesByAppID), ctx, appID, pageable)
+}
+
 // FindEventDeliveriesByEventID mocks base method.
 func (m *MockEventDeliveryRepository) FindEventDeliveriesByEventID(arg0 context.Context, arg1 string) ([]datastore.EventDelivery, error) {
 	m.ctrl.T.Helper()
@@ -214,210 +535,677 @@ func (m *MockEventDeliveryRepository) FindEventDeliveriesByEventID(arg0 context.
 	return ret0, ret1
 }
 
-// FindEventDeliveriesByEventID indicates an expected call of FindEventDeliveriesByEventID.
-func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByEventID(arg0, arg1 interface{}) *gomock.Call {
+// FindEventDeliveriesByEventID indicates an expected call of FindEventDeliveriesByEventID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByEventID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByEventID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByEventID), arg0, arg1)
+}
+
+// FindEventDeliveriesByIDs mocks base method.
+func (m *MockEventDeliveryRepository) FindEventDeliveriesByIDs(arg0 context.Context, arg1 []string) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventDeliveriesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventDeliveriesByIDs indicates an expected call of FindEventDeliveriesByIDs.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByIDs", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByIDs), arg0, arg1)
+}
+
+// FindEventDeliveryByID mocks base method.
+func (m *MockEventDeliveryRepository) FindEventDeliveryByID(arg0 context.Context, arg1 string) (*datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventDeliveryByID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventDeliveryByID indicates an expected call of FindEventDeliveryByID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveryByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveryByID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveryByID), arg0, arg1)
+}
+
+// FindGroupsWithHighFailureRate mocks base method.
+func (m *MockEventDeliveryRepository) FindGroupsWithHighFailureRate(arg0 context.Context, arg1 float64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGroupsWithHighFailureRate", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGroupsWithHighFailureRate indicates an expected call of FindGroupsWithHighFailureRate.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindGroupsWithHighFailureRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGroupsWithHighFailureRate", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindGroupsWithHighFailureRate), arg0, arg1)
+}
+
+// FindRecentAttemptsByApp mocks base method.
+func (m *MockEventDeliveryRepository) FindRecentAttemptsByApp(ctx context.Context, appID string, n int) ([]datastore.DeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecentAttemptsByApp", ctx, appID, n)
+	ret0, _ := ret[0].([]datastore.DeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecentAttemptsByApp indicates an expected call of FindRecentAttemptsByApp.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindRecentAttemptsByApp(ctx, appID, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecentAttemptsByApp", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindRecentAttemptsByApp), ctx, appID, n)
+}
+
+// ForceCompleteStuckDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) ForceCompleteStuckDeliveries(ctx context.Context, olderThan time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceCompleteStuckDeliveries", ctx, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceCompleteStuckDeliveries indicates an expected call of ForceCompleteStuckDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) ForceCompleteStuckDeliveries(ctx, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceCompleteStuckDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).ForceCompleteStuckDeliveries), ctx, olderThan)
+}
+
+// LoadDeliveryStatistics mocks base method.
+func (m *MockEventDeliveryRepository) LoadDeliveryStatistics(ctx context.Context, groupID, appID string, searchParams datastore.SearchParams) (*datastore.DeliveryStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDeliveryStatistics", ctx, groupID, appID, searchParams)
+	ret0, _ := ret[0].(*datastore.DeliveryStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDeliveryStatistics indicates an expected call of LoadDeliveryStatistics.
+func (mr *MockEventDeliveryRepositoryMockRecorder) LoadDeliveryStatistics(ctx, groupID, appID, searchParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDeliveryStatistics", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadDeliveryStatistics), ctx, groupID, appID, searchParams)
+}
+
+// LoadEventDeliveriesCursored mocks base method.
+func (m *MockEventDeliveryRepository) LoadEventDeliveriesCursored(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 []datastore.EventDeliveryStatus, arg6 datastore.SearchParams, arg7 *datastore.Cursor, arg8 int) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventDeliveriesCursored", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventDeliveriesCursored indicates an expected call of LoadEventDeliveriesCursored.
+func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesCursored(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesCursored", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesCursored), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// LoadEventDeliveriesPaged mocks base method.
+func (m *MockEventDeliveryRepository) LoadEventDeliveriesPaged(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 []datastore.EventDeliveryStatus, arg6 datastore.SearchParams, arg7 datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventDeliveriesPaged", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadEventDeliveriesPaged indicates an expected call of LoadEventDeliveriesPaged.
+func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesPaged(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesPaged", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesPaged), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// RetryAllFailed mocks base method.
+func (m *MockEventDeliveryRepository) RetryAllFailed(ctx context.Context, groupID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryAllFailed", ctx, groupID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryAllFailed indicates an expected call of RetryAllFailed.
+func (mr *MockEventDeliveryRepositoryMockRecorder) RetryAllFailed(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryAllFailed", reflect.TypeOf((*MockEventDeliveryRepository)(nil).RetryAllFailed), ctx, groupID)
+}
+
+// TransitionStatus mocks base method.
+func (m *MockEventDeliveryRepository) TransitionStatus(ctx context.Context, id string, from, to datastore.EventDeliveryStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionStatus indicates an expected call of TransitionStatus.
+func (mr *MockEventDeliveryRepositoryMockRecorder) TransitionStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionStatus", reflect.TypeOf((*MockEventDeliveryRepository)(nil).TransitionStatus), ctx, id, from, to)
+}
+
+// UpdateEventDeliveriesGroupID mocks base method.
+func (m *MockEventDeliveryRepository) UpdateEventDeliveriesGroupID(ctx context.Context, appID, groupID string, excluded []datastore.EventDeliveryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventDeliveriesGroupID", ctx, appID, groupID, excluded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventDeliveriesGroupID indicates an expected call of UpdateEventDeliveriesGroupID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDeliveriesGroupID(ctx, appID, groupID, excluded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDeliveriesGroupID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDeliveriesGroupID), ctx, appID, groupID, excluded)
+}
+
+// UpdateEventDeliveryWithAttempt mocks base method.
+func (m *MockEventDeliveryRepository) UpdateEventDeliveryWithAttempt(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.DeliveryAttempt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventDeliveryWithAttempt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEventDeliveryWithAttempt indicates an expected call of UpdateEventDeliveryWithAttempt.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDeliveryWithAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDeliveryWithAttempt", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDeliveryWithAttempt), arg0, arg1, arg2)
+}
+
+// UpdateStatusOfEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) UpdateStatusOfEventDeliveries(arg0 context.Context, arg1 []string, arg2 datastore.EventDeliveryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusOfEventDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusOfEventDeliveries indicates an expected call of UpdateStatusOfEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateStatusOfEventDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusOfEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateStatusOfEventDeliveries), arg0, arg1, arg2)
+}
+
+// UpdateStatusOfEventDelivery mocks base method.
+func (m *MockEventDeliveryRepository) UpdateStatusOfEventDelivery(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.EventDeliveryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusOfEventDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusOfEventDelivery indicates an expected call of UpdateStatusOfEventDelivery.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateStatusOfEventDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusOfEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateStatusOfEventDelivery), arg0, arg1, arg2)
+}
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountEvents mocks base method.
+func (m *MockEventRepository) CountEvents(arg0 context.Context, arg1, arg2 string, arg3 datastore.SearchParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEvents indicates an expected call of CountEvents.
+func (mr *MockEventRepositoryMockRecorder) CountEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEvents", reflect.TypeOf((*MockEventRepository)(nil).CountEvents), arg0, arg1, arg2, arg3)
+}
+
+// CountGroupMessages mocks base method.
+func (m *MockEventRepository) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGroupMessages", ctx, groupID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGroupMessages indicates an expected call of CountGroupMessages.
+func (mr *MockEventRepositoryMockRecorder) CountGroupMessages(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupMessages", reflect.TypeOf((*MockEventRepository)(nil).CountGroupMessages), ctx, groupID)
+}
+
+// CountGroupMessagesByType mocks base method.
+func (m *MockEventRepository) CountGroupMessagesByType(ctx context.Context, groupID string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGroupMessagesByType", ctx, groupID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGroupMessagesByType indicates an expected call of CountGroupMessagesByType.
+func (mr *MockEventRepositoryMockRecorder) CountGroupMessagesByType(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupMessagesByType", reflect.TypeOf((*MockEventRepository)(nil).CountGroupMessagesByType), ctx, groupID)
+}
+
+// CreateEvent mocks base method.
+func (m *MockEventRepository) CreateEvent(arg0 context.Context, arg1 *datastore.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockEventRepositoryMockRecorder) CreateEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventRepository)(nil).CreateEvent), arg0, arg1)
+}
+
+// DeleteEvents mocks base method.
+func (m *MockEventRepository) DeleteEvents(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvents indicates an expected call of DeleteEvents.
+func (mr *MockEventRepositoryMockRecorder) DeleteEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvents", reflect.TypeOf((*MockEventRepository)(nil).DeleteEvents), arg0, arg1)
+}
+
+// DeleteGroupEvents mocks base method.
+func (m *MockEventRepository) DeleteGroupEvents(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupEvents indicates an expected call of DeleteGroupEvents.
+func (mr *MockEventRepositoryMockRecorder) DeleteGroupEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupEvents", reflect.TypeOf((*MockEventRepository)(nil).DeleteGroupEvents), arg0, arg1)
+}
+
+// EnsureRetentionIndex mocks base method.
+func (m *MockEventRepository) EnsureRetentionIndex(ctx context.Context, groupID string, retentionDays int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureRetentionIndex", ctx, groupID, retentionDays)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureRetentionIndex indicates an expected call of EnsureRetentionIndex.
+func (mr *MockEventRepositoryMockRecorder) EnsureRetentionIndex(ctx, groupID, retentionDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRetentionIndex", reflect.TypeOf((*MockEventRepository)(nil).EnsureRetentionIndex), ctx, groupID, retentionDays)
+}
+
+// FindEventByID mocks base method.
+func (m *MockEventRepository) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventByID", ctx, id)
+	ret0, _ := ret[0].(*datastore.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventByID indicates an expected call of FindEventByID.
+func (mr *MockEventRepositoryMockRecorder) FindEventByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByID", reflect.TypeOf((*MockEventRepository)(nil).FindEventByID), ctx, id)
+}
+
+// LoadEventIntervals mocks base method.
+func (m *MockEventRepository) LoadEventIntervals(arg0 context.Context, arg1 string, arg2 datastore.SearchParams, arg3 datastore.Period, arg4 int) ([]datastore.EventInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventIntervals", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]datastore.EventInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventIntervals indicates an expected call of LoadEventIntervals.
+func (mr *MockEventRepositoryMockRecorder) LoadEventIntervals(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventIntervals", reflect.TypeOf((*MockEventRepository)(nil).LoadEventIntervals), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadEventsCursored mocks base method.
+func (m *MockEventRepository) LoadEventsCursored(arg0 context.Context, arg1, arg2, arg3 string, arg4 map[string]string, arg5 datastore.SearchParams, arg6 *datastore.Cursor, arg7 int) ([]datastore.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventsCursored", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].([]datastore.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventsCursored indicates an expected call of LoadEventsCursored.
+func (mr *MockEventRepositoryMockRecorder) LoadEventsCursored(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsCursored", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsCursored), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// LoadEventsPaged mocks base method.
+func (m *MockEventRepository) LoadEventsPaged(arg0 context.Context, arg1, arg2 string, arg3 datastore.SearchParams, arg4 datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventsPaged", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]datastore.Event)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadEventsPaged indicates an expected call of LoadEventsPaged.
+func (mr *MockEventRepositoryMockRecorder) LoadEventsPaged(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByEventID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByEventID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsPaged), arg0, arg1, arg2, arg3, arg4)
 }
 
-// FindEventDeliveriesByIDs mocks base method.
-func (m *MockEventDeliveryRepository) FindEventDeliveriesByIDs(arg0 context.Context, arg1 []string) ([]datastore.EventDelivery, error) {
+// MarkEventBridged mocks base method.
+func (m *MockEventRepository) MarkEventBridged(arg0 context.Context, arg1 string, arg2 time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindEventDeliveriesByIDs", arg0, arg1)
-	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret := m.ctrl.Call(m, "MarkEventBridged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindEventDeliveriesByIDs indicates an expected call of FindEventDeliveriesByIDs.
-func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByIDs(arg0, arg1 interface{}) *gomock.Call {
+// MarkEventBridged indicates an expected call of MarkEventBridged.
+func (mr *MockEventRepositoryMockRecorder) MarkEventBridged(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByIDs", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByIDs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventBridged", reflect.TypeOf((*MockEventRepository)(nil).MarkEventBridged), arg0, arg1, arg2)
 }
 
-// FindEventDeliveryByID mocks base method.
-func (m *MockEventDeliveryRepository) FindEventDeliveryByID(arg0 context.Context, arg1 string) (*datastore.EventDelivery, error) {
+// PurgeDeleted mocks base method.
+func (m *MockEventRepository) PurgeDeleted(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindEventDeliveryByID", arg0, arg1)
-	ret0, _ := ret[0].(*datastore.EventDelivery)
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindEventDeliveryByID indicates an expected call of FindEventDeliveryByID.
-func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveryByID(arg0, arg1 interface{}) *gomock.Call {
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockEventRepositoryMockRecorder) PurgeDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveryByID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveryByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockEventRepository)(nil).PurgeDeleted), arg0, arg1, arg2)
 }
 
-// LoadEventDeliveriesPaged mocks base method.
-func (m *MockEventDeliveryRepository) LoadEventDeliveriesPaged(arg0 context.Context, arg1, arg2, arg3 string, arg4 []datastore.EventDeliveryStatus, arg5 datastore.SearchParams, arg6 datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+// SearchEventsPaged mocks base method.
+func (m *MockEventRepository) SearchEventsPaged(arg0 context.Context, arg1, arg2, arg3 string, arg4 map[string]string, arg5 datastore.SearchParams, arg6 datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadEventDeliveriesPaged", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
-	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret := m.ctrl.Call(m, "SearchEventsPaged", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]datastore.Event)
 	ret1, _ := ret[1].(datastore.PaginationData)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// LoadEventDeliveriesPaged indicates an expected call of LoadEventDeliveriesPaged.
-func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesPaged(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+// SearchEventsPaged indicates an expected call of SearchEventsPaged.
+func (mr *MockEventRepositoryMockRecorder) SearchEventsPaged(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesPaged", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesPaged), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).SearchEventsPaged), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
-// UpdateEventDeliveryWithAttempt mocks base method.
-func (m *MockEventDeliveryRepository) UpdateEventDeliveryWithAttempt(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.DeliveryAttempt) error {
+// UpdateEventsGroupID mocks base method.
+func (m *MockEventRepository) UpdateEventsGroupID(ctx context.Context, appID, groupID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEventDeliveryWithAttempt", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateEventsGroupID", ctx, appID, groupID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateEventDeliveryWithAttempt indicates an expected call of UpdateEventDeliveryWithAttempt.
-func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDeliveryWithAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+// UpdateEventsGroupID indicates an expected call of UpdateEventsGroupID.
+func (mr *MockEventRepositoryMockRecorder) UpdateEventsGroupID(ctx, appID, groupID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDeliveryWithAttempt", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDeliveryWithAttempt), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventsGroupID", reflect.TypeOf((*MockEventRepository)(nil).UpdateEventsGroupID), ctx, appID, groupID)
 }
 
-// UpdateStatusOfEventDeliveries mocks base method.
-func (m *MockEventDeliveryRepository) UpdateStatusOfEventDeliveries(arg0 context.Context, arg1 []string, arg2 datastore.EventDeliveryStatus) error {
+// MockEventBridgeRepository is a mock of EventBridgeRepository interface.
+type MockEventBridgeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBridgeRepositoryMockRecorder
+}
+
+// MockEventBridgeRepositoryMockRecorder is the mock recorder for MockEventBridgeRepository.
+type MockEventBridgeRepositoryMockRecorder struct {
+	mock *MockEventBridgeRepository
+}
+
+// NewMockEventBridgeRepository creates a new mock instance.
+func NewMockEventBridgeRepository(ctrl *gomock.Controller) *MockEventBridgeRepository {
+	mock := &MockEventBridgeRepository{ctrl: ctrl}
+	mock.recorder = &MockEventBridgeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBridgeRepository) EXPECT() *MockEventBridgeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEventBridgeRule mocks base method.
+func (m *MockEventBridgeRepository) CreateEventBridgeRule(arg0 context.Context, arg1 *datastore.EventBridgeRule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusOfEventDeliveries", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateEventBridgeRule", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateStatusOfEventDeliveries indicates an expected call of UpdateStatusOfEventDeliveries.
-func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateStatusOfEventDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+// CreateEventBridgeRule indicates an expected call of CreateEventBridgeRule.
+func (mr *MockEventBridgeRepositoryMockRecorder) CreateEventBridgeRule(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusOfEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateStatusOfEventDeliveries), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventBridgeRule", reflect.TypeOf((*MockEventBridgeRepository)(nil).CreateEventBridgeRule), arg0, arg1)
 }
 
-// UpdateStatusOfEventDelivery mocks base method.
-func (m *MockEventDeliveryRepository) UpdateStatusOfEventDelivery(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.EventDeliveryStatus) error {
+// DeleteEventBridgeRule mocks base method.
+func (m *MockEventBridgeRepository) DeleteEventBridgeRule(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusOfEventDelivery", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteEventBridgeRule", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateStatusOfEventDelivery indicates an expected call of UpdateStatusOfEventDelivery.
-func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateStatusOfEventDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+// DeleteEventBridgeRule indicates an expected call of DeleteEventBridgeRule.
+func (mr *MockEventBridgeRepositoryMockRecorder) DeleteEventBridgeRule(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusOfEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateStatusOfEventDelivery), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventBridgeRule", reflect.TypeOf((*MockEventBridgeRepository)(nil).DeleteEventBridgeRule), arg0, arg1)
 }
 
-// MockEventRepository is a mock of EventRepository interface.
-type MockEventRepository struct {
+// FindEventBridgeRuleByID mocks base method.
+func (m *MockEventBridgeRepository) FindEventBridgeRuleByID(arg0 context.Context, arg1 string) (*datastore.EventBridgeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventBridgeRuleByID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.EventBridgeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventBridgeRuleByID indicates an expected call of FindEventBridgeRuleByID.
+func (mr *MockEventBridgeRepositoryMockRecorder) FindEventBridgeRuleByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventBridgeRuleByID", reflect.TypeOf((*MockEventBridgeRepository)(nil).FindEventBridgeRuleByID), arg0, arg1)
+}
+
+// LoadEventBridgeRules mocks base method.
+func (m *MockEventBridgeRepository) LoadEventBridgeRules(ctx context.Context, sourceGroupID string) ([]datastore.EventBridgeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventBridgeRules", ctx, sourceGroupID)
+	ret0, _ := ret[0].([]datastore.EventBridgeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventBridgeRules indicates an expected call of LoadEventBridgeRules.
+func (mr *MockEventBridgeRepositoryMockRecorder) LoadEventBridgeRules(ctx, sourceGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventBridgeRules", reflect.TypeOf((*MockEventBridgeRepository)(nil).LoadEventBridgeRules), ctx, sourceGroupID)
+}
+
+// MockEventTypeRepository is a mock of EventTypeRepository interface.
+type MockEventTypeRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockEventRepositoryMockRecorder
+	recorder *MockEventTypeRepositoryMockRecorder
 }
 
-// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
-type MockEventRepositoryMockRecorder struct {
-	mock *MockEventRepository
+// MockEventTypeRepositoryMockRecorder is the mock recorder for MockEventTypeRepository.
+type MockEventTypeRepositoryMockRecorder struct {
+	mock *MockEventTypeRepository
 }
 
-// NewMockEventRepository creates a new mock instance.
-func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
-	mock := &MockEventRepository{ctrl: ctrl}
-	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+// NewMockEventTypeRepository creates a new mock instance.
+func NewMockEventTypeRepository(ctrl *gomock.Controller) *MockEventTypeRepository {
+	mock := &MockEventTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockEventTypeRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+func (m *MockEventTypeRepository) EXPECT() *MockEventTypeRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CountGroupMessages mocks base method.
-func (m *MockEventRepository) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+// CreateEventType mocks base method.
+func (m *MockEventTypeRepository) CreateEventType(arg0 context.Context, arg1 *datastore.EventTypeDefinition) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountGroupMessages", ctx, groupID)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CreateEventType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CountGroupMessages indicates an expected call of CountGroupMessages.
-func (mr *MockEventRepositoryMockRecorder) CountGroupMessages(ctx, groupID interface{}) *gomock.Call {
+// CreateEventType indicates an expected call of CreateEventType.
+func (mr *MockEventTypeRepositoryMockRecorder) CreateEventType(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupMessages", reflect.TypeOf((*MockEventRepository)(nil).CountGroupMessages), ctx, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventType", reflect.TypeOf((*MockEventTypeRepository)(nil).CreateEventType), arg0, arg1)
 }
 
-// CreateEvent mocks base method.
-func (m *MockEventRepository) CreateEvent(arg0 context.Context, arg1 *datastore.Event) error {
+// DeleteEventType mocks base method.
+func (m *MockEventTypeRepository) DeleteEventType(ctx context.Context, groupID, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvent", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteEventType", ctx, groupID, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateEvent indicates an expected call of CreateEvent.
-func (mr *MockEventRepositoryMockRecorder) CreateEvent(arg0, arg1 interface{}) *gomock.Call {
+// DeleteEventType indicates an expected call of DeleteEventType.
+func (mr *MockEventTypeRepositoryMockRecorder) DeleteEventType(ctx, groupID, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventRepository)(nil).CreateEvent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventType", reflect.TypeOf((*MockEventTypeRepository)(nil).DeleteEventType), ctx, groupID, name)
 }
 
-// DeleteGroupEvents mocks base method.
-func (m *MockEventRepository) DeleteGroupEvents(arg0 context.Context, arg1 string) error {
+// FindEventTypeByName mocks base method.
+func (m *MockEventTypeRepository) FindEventTypeByName(ctx context.Context, groupID, name string) (*datastore.EventTypeDefinition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGroupEvents", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindEventTypeByName", ctx, groupID, name)
+	ret0, _ := ret[0].(*datastore.EventTypeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteGroupEvents indicates an expected call of DeleteGroupEvents.
-func (mr *MockEventRepositoryMockRecorder) DeleteGroupEvents(arg0, arg1 interface{}) *gomock.Call {
+// FindEventTypeByName indicates an expected call of FindEventTypeByName.
+func (mr *MockEventTypeRepositoryMockRecorder) FindEventTypeByName(ctx, groupID, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupEvents", reflect.TypeOf((*MockEventRepository)(nil).DeleteGroupEvents), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventTypeByName", reflect.TypeOf((*MockEventTypeRepository)(nil).FindEventTypeByName), ctx, groupID, name)
 }
 
-// FindEventByID mocks base method.
-func (m *MockEventRepository) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+// LoadEventTypes mocks base method.
+func (m *MockEventTypeRepository) LoadEventTypes(ctx context.Context, groupID string) ([]datastore.EventTypeDefinition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindEventByID", ctx, id)
-	ret0, _ := ret[0].(*datastore.Event)
+	ret := m.ctrl.Call(m, "LoadEventTypes", ctx, groupID)
+	ret0, _ := ret[0].([]datastore.EventTypeDefinition)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindEventByID indicates an expected call of FindEventByID.
-func (mr *MockEventRepositoryMockRecorder) FindEventByID(ctx, id interface{}) *gomock.Call {
+// LoadEventTypes indicates an expected call of LoadEventTypes.
+func (mr *MockEventTypeRepositoryMockRecorder) LoadEventTypes(ctx, groupID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByID", reflect.TypeOf((*MockEventRepository)(nil).FindEventByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventTypes", reflect.TypeOf((*MockEventTypeRepository)(nil).LoadEventTypes), ctx, groupID)
 }
 
-// LoadEventIntervals mocks base method.
-func (m *MockEventRepository) LoadEventIntervals(arg0 context.Context, arg1 string, arg2 datastore.SearchParams, arg3 datastore.Period, arg4 int) ([]datastore.EventInterval, error) {
+// MockGlobalSettingsRepository is a mock of GlobalSettingsRepository interface.
+type MockGlobalSettingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGlobalSettingsRepositoryMockRecorder
+}
+
+// MockGlobalSettingsRepositoryMockRecorder is the mock recorder for MockGlobalSettingsRepository.
+type MockGlobalSettingsRepositoryMockRecorder struct {
+	mock *MockGlobalSettingsRepository
+}
+
+// NewMockGlobalSettingsRepository creates a new mock instance.
+func NewMockGlobalSettingsRepository(ctrl *gomock.Controller) *MockGlobalSettingsRepository {
+	mock := &MockGlobalSettingsRepository{ctrl: ctrl}
+	mock.recorder = &MockGlobalSettingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGlobalSettingsRepository) EXPECT() *MockGlobalSettingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGlobalSettingsRepository) Get(arg0 context.Context) (*datastore.GlobalSettings, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadEventIntervals", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].([]datastore.EventInterval)
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*datastore.GlobalSettings)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LoadEventIntervals indicates an expected call of LoadEventIntervals.
-func (mr *MockEventRepositoryMockRecorder) LoadEventIntervals(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockGlobalSettingsRepositoryMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventIntervals", reflect.TypeOf((*MockEventRepository)(nil).LoadEventIntervals), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGlobalSettingsRepository)(nil).Get), arg0)
 }
 
-// LoadEventsPaged mocks base method.
-func (m *MockEventRepository) LoadEventsPaged(arg0 context.Context, arg1, arg2 string, arg3 datastore.SearchParams, arg4 datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+// Update mocks base method.
+func (m *MockGlobalSettingsRepository) Update(arg0 context.Context, arg1 *datastore.GlobalSettings) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadEventsPaged", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].([]datastore.Event)
-	ret1, _ := ret[1].(datastore.PaginationData)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// LoadEventsPaged indicates an expected call of LoadEventsPaged.
-func (mr *MockEventRepositoryMockRecorder) LoadEventsPaged(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockGlobalSettingsRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsPaged), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGlobalSettingsRepository)(nil).Update), arg0, arg1)
 }
 
 // MockGroupRepository is a mock of GroupRepository interface.
@@ -471,6 +1259,20 @@ func (mr *MockGroupRepositoryMockRecorder) DeleteGroup(ctx, uid interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupRepository)(nil).DeleteGroup), ctx, uid)
 }
 
+// DeleteGroupCascade mocks base method.
+func (m *MockGroupRepository) DeleteGroupCascade(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupCascade", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupCascade indicates an expected call of DeleteGroupCascade.
+func (mr *MockGroupRepositoryMockRecorder) DeleteGroupCascade(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupCascade", reflect.TypeOf((*MockGroupRepository)(nil).DeleteGroupCascade), arg0, arg1)
+}
+
 // FetchGroupByID mocks base method.
 func (m *MockGroupRepository) FetchGroupByID(arg0 context.Context, arg1 string) (*datastore.Group, error) {
 	m.ctrl.T.Helper()
@@ -516,6 +1318,21 @@ func (mr *MockGroupRepositoryMockRecorder) LoadGroups(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadGroups", reflect.TypeOf((*MockGroupRepository)(nil).LoadGroups), arg0, arg1)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockGroupRepository) PurgeDeleted(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockGroupRepositoryMockRecorder) PurgeDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockGroupRepository)(nil).PurgeDeleted), arg0, arg1, arg2)
+}
+
 // UpdateGroup mocks base method.
 func (m *MockGroupRepository) UpdateGroup(arg0 context.Context, arg1 *datastore.Group) error {
 	m.ctrl.T.Helper()
@@ -530,6 +1347,48 @@ func (mr *MockGroupRepositoryMockRecorder) UpdateGroup(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupRepository)(nil).UpdateGroup), arg0, arg1)
 }
 
+// UpdateGroupConfig mocks base method.
+func (m *MockGroupRepository) UpdateGroupConfig(arg0 context.Context, arg1 string, arg2 *datastore.GroupConfigPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupConfig indicates an expected call of UpdateGroupConfig.
+func (mr *MockGroupRepositoryMockRecorder) UpdateGroupConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupConfig", reflect.TypeOf((*MockGroupRepository)(nil).UpdateGroupConfig), arg0, arg1, arg2)
+}
+
+// UpdateGroupStatus mocks base method.
+func (m *MockGroupRepository) UpdateGroupStatus(arg0 context.Context, arg1 string, arg2 datastore.GroupStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupStatus indicates an expected call of UpdateGroupStatus.
+func (mr *MockGroupRepositoryMockRecorder) UpdateGroupStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupStatus", reflect.TypeOf((*MockGroupRepository)(nil).UpdateGroupStatus), arg0, arg1, arg2)
+}
+
+// WithTransaction mocks base method.
+func (m *MockGroupRepository) WithTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockGroupRepositoryMockRecorder) WithTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockGroupRepository)(nil).WithTransaction), arg0, arg1)
+}
+
 // MockApplicationRepository is a mock of ApplicationRepository interface.
 type MockApplicationRepository struct {
 	ctrl     *gomock.Controller
@@ -610,6 +1469,20 @@ func (mr *MockApplicationRepositoryMockRecorder) DeleteGroupApps(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupApps", reflect.TypeOf((*MockApplicationRepository)(nil).DeleteGroupApps), arg0, arg1)
 }
 
+// DisableApplicationEndpoint mocks base method.
+func (m *MockApplicationRepository) DisableApplicationEndpoint(ctx context.Context, appID, endpointID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableApplicationEndpoint", ctx, appID, endpointID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableApplicationEndpoint indicates an expected call of DisableApplicationEndpoint.
+func (mr *MockApplicationRepositoryMockRecorder) DisableApplicationEndpoint(ctx, appID, endpointID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableApplicationEndpoint", reflect.TypeOf((*MockApplicationRepository)(nil).DisableApplicationEndpoint), ctx, appID, endpointID, reason)
+}
+
 // FindApplicationByID mocks base method.
 func (m *MockApplicationRepository) FindApplicationByID(arg0 context.Context, arg1 string) (*datastore.Application, error) {
 	m.ctrl.T.Helper()
@@ -640,8 +1513,68 @@ func (mr *MockApplicationRepositoryMockRecorder) FindApplicationEndpointByID(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationEndpointByID", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationEndpointByID), arg0, arg1, arg2)
 }
 
+// FindApplicationsByIDs mocks base method.
+func (m *MockApplicationRepository) FindApplicationsByIDs(arg0 context.Context, arg1 []string) ([]datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicationsByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicationsByIDs indicates an expected call of FindApplicationsByIDs.
+func (mr *MockApplicationRepositoryMockRecorder) FindApplicationsByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationsByIDs", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationsByIDs), arg0, arg1)
+}
+
+// FindApplicationsByOwnerID mocks base method.
+func (m *MockApplicationRepository) FindApplicationsByOwnerID(ctx context.Context, groupID, ownerID string) ([]datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicationsByOwnerID", ctx, groupID, ownerID)
+	ret0, _ := ret[0].([]datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicationsByOwnerID indicates an expected call of FindApplicationsByOwnerID.
+func (mr *MockApplicationRepositoryMockRecorder) FindApplicationsByOwnerID(ctx, groupID, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationsByOwnerID", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationsByOwnerID), ctx, groupID, ownerID)
+}
+
+// FindDeletedApplicationByID mocks base method.
+func (m *MockApplicationRepository) FindDeletedApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedApplicationByID", ctx, id)
+	ret0, _ := ret[0].(*datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedApplicationByID indicates an expected call of FindDeletedApplicationByID.
+func (mr *MockApplicationRepositoryMockRecorder) FindDeletedApplicationByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedApplicationByID", reflect.TypeOf((*MockApplicationRepository)(nil).FindDeletedApplicationByID), ctx, id)
+}
+
+// FindGroupsWithInactiveEndpoints mocks base method.
+func (m *MockApplicationRepository) FindGroupsWithInactiveEndpoints(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGroupsWithInactiveEndpoints", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGroupsWithInactiveEndpoints indicates an expected call of FindGroupsWithInactiveEndpoints.
+func (mr *MockApplicationRepositoryMockRecorder) FindGroupsWithInactiveEndpoints(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGroupsWithInactiveEndpoints", reflect.TypeOf((*MockApplicationRepository)(nil).FindGroupsWithInactiveEndpoints), arg0)
+}
+
 // LoadApplicationsPaged mocks base method.
-func (m *MockApplicationRepository) LoadApplicationsPaged(arg0 context.Context, arg1, arg2 string, arg3 datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+func (m *MockApplicationRepository) LoadApplicationsPaged(arg0 context.Context, arg1 string, arg2 *datastore.ApplicationFilter, arg3 datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "LoadApplicationsPaged", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]datastore.Application)
@@ -672,6 +1605,35 @@ func (mr *MockApplicationRepositoryMockRecorder) LoadApplicationsPagedByGroupId(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadApplicationsPagedByGroupId", reflect.TypeOf((*MockApplicationRepository)(nil).LoadApplicationsPagedByGroupId), arg0, arg1, arg2)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockApplicationRepository) PurgeDeleted(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockApplicationRepositoryMockRecorder) PurgeDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockApplicationRepository)(nil).PurgeDeleted), arg0, arg1, arg2)
+}
+
+// RestoreApplication mocks base method.
+func (m *MockApplicationRepository) RestoreApplication(ctx context.Context, app *datastore.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreApplication", ctx, app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreApplication indicates an expected call of RestoreApplication.
+func (mr *MockApplicationRepositoryMockRecorder) RestoreApplication(ctx, app interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreApplication", reflect.TypeOf((*MockApplicationRepository)(nil).RestoreApplication), ctx, app)
+}
+
 // SearchApplicationsByGroupId mocks base method.
 func (m *MockApplicationRepository) SearchApplicationsByGroupId(arg0 context.Context, arg1 string, arg2 datastore.SearchParams) ([]datastore.Application, error) {
 	m.ctrl.T.Helper()
@@ -714,3 +1676,17 @@ func (mr *MockApplicationRepositoryMockRecorder) UpdateApplicationEndpointsStatu
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationEndpointsStatus", reflect.TypeOf((*MockApplicationRepository)(nil).UpdateApplicationEndpointsStatus), arg0, arg1, arg2, arg3)
 }
+
+// UpdateApplicationHealth mocks base method.
+func (m *MockApplicationRepository) UpdateApplicationHealth(ctx context.Context, appID string, score float64, status datastore.ApplicationHealthStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplicationHealth", ctx, appID, score, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplicationHealth indicates an expected call of UpdateApplicationHealth.
+func (mr *MockApplicationRepositoryMockRecorder) UpdateApplicationHealth(ctx, appID, score, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationHealth", reflect.TypeOf((*MockApplicationRepository)(nil).UpdateApplicationHealth), ctx, appID, score, status)
+}
@@ -2,6 +2,8 @@ package net
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"io"
@@ -27,7 +29,11 @@ func NewDispatcher(timeout time.Duration) *Dispatcher {
 	}
 }
 
-func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, g *datastore.Group, hmac string, timestamp string, maxResponseSize int64) (*Response, error) {
+// SendRequest sends jsonData to endpoint signed with hmac. headers are
+// added to the request first, so they cannot replace the signature or any
+// other header convoy sets, and hop-by-hop headers among them are skipped.
+func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, g *datastore.Group, headers map[string]string, hmac string, timestamp string, maxResponseSize int64) (*Response, error) {
+	var err error
 	r := &Response{}
 	signatureHeader := g.Config.Signature.Header.String()
 	if util.IsStringEmpty(signatureHeader) || util.IsStringEmpty(hmac) {
@@ -37,12 +43,33 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 		return r, err
 	}
 
+	compressed := shouldCompressPayload(g, jsonData)
+	if compressed {
+		jsonData, err = compressPayload(jsonData)
+		if err != nil {
+			log.WithError(err).Error("error occurred while compressing payload")
+			r.Error = err.Error()
+			return r, err
+		}
+	}
+
 	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		log.WithError(err).Error("error occurred while creating request")
 		return r, err
 	}
 
+	for name, value := range headers {
+		if IsHopByHopHeader(name) {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
 	req.Header.Set(signatureHeader, hmac)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("User-Agent", defaultUserAgent())
@@ -60,7 +87,29 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 	r.URL = req.URL
 	r.Method = req.Method
 
+	// the phase timings stay zero when the transport reuses a connection
+	var dnsStart, connectStart, tlsStart time.Time
 	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			r.DNSLookup = time.Since(dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				r.Connect = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			r.TLSHandshake = time.Since(tlsStart)
+		},
 		GotConn: func(connInfo httptrace.GotConnInfo) {
 			r.IP = connInfo.Conn.RemoteAddr().String()
 			log.Infof("IP address resolved to: %s", connInfo.Conn.RemoteAddr())
@@ -95,6 +144,32 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 	return r, nil
 }
 
+// hopByHopHeaders only apply to a single connection, so they are managed
+// by the transport and cannot be set as custom headers.
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// IsHopByHopHeader reports whether name is a hop-by-hop header, ignoring case.
+func IsHopByHopHeader(name string) bool {
+	name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+	for _, h := range hopByHopHeaders {
+		if name == h {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Response struct {
 	Status         string
 	StatusCode     int
@@ -104,9 +179,42 @@ type Response struct {
 	ResponseHeader http.Header
 	Body           []byte
 	IP             string
+	DNSLookup      time.Duration
+	Connect        time.Duration
+	TLSHandshake   time.Duration
 	Error          string
 }
 
+func shouldCompressPayload(g *datastore.Group, payload []byte) bool {
+	if !g.Config.CompressPayload {
+		return false
+	}
+
+	threshold := g.Config.CompressThresholdBytes
+	if threshold <= 0 {
+		threshold = datastore.DefaultCompressThresholdBytes
+	}
+
+	return len(payload) > threshold
+}
+
+func compressPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+
+	_, err := zw.Write(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func updateDispatchHeaders(r *Response, res *http.Response) {
 	r.Status = res.Status
 	r.StatusCode = res.StatusCode
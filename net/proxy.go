@@ -0,0 +1,88 @@
+package net
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// proxyTransports holds the transport of each group that sends its
+// requests through a proxy, so connections to the proxy are reused
+// across deliveries.
+var proxyTransports sync.Map
+
+type proxyTransport struct {
+	fingerprint string
+	transport   *http.Transport
+}
+
+// NewProxyDispatcher returns a Dispatcher that sends requests through
+// proxyURL, save for requests to hosts matching noProxyHosts. Requests to
+// https endpoints are tunnelled through the proxy with CONNECT. Credentials
+// for the proxy are taken from proxyURL's user info.
+func NewProxyDispatcher(timeout time.Duration, groupID string, proxyURL *url.URL, noProxyHosts []string) *Dispatcher {
+	return &Dispatcher{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: groupProxyTransport(groupID, proxyURL, noProxyHosts),
+		},
+	}
+}
+
+func groupProxyTransport(groupID string, proxyURL *url.URL, noProxyHosts []string) *http.Transport {
+	fingerprint := proxyURL.String() + " " + strings.Join(noProxyHosts, ",")
+
+	if v, ok := proxyTransports.Load(groupID); ok {
+		pt := v.(*proxyTransport)
+		if pt.fingerprint == fingerprint {
+			return pt.transport
+		}
+
+		// the group's proxy changed, connections to the old one are dropped
+		pt.transport.CloseIdleConnections()
+	}
+
+	// the default transport is swapped out by http mocks in tests
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		base = &http.Transport{}
+	}
+
+	transport := base.Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if MatchesNoProxyHosts(req.URL.Hostname(), noProxyHosts) {
+			return nil, nil
+		}
+
+		return proxyURL, nil
+	}
+
+	proxyTransports.Store(groupID, &proxyTransport{fingerprint: fingerprint, transport: transport})
+	return transport
+}
+
+// MatchesNoProxyHosts reports whether host is one of hosts, which may
+// start with a wildcard label: *.internal matches api.internal and
+// a.b.internal but not internal itself.
+func MatchesNoProxyHosts(host string, hosts []string) bool {
+	host = strings.ToLower(host)
+
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+
+		if strings.HasPrefix(h, "*.") {
+			if strings.HasSuffix(host, h[1:]) {
+				return true
+			}
+			continue
+		}
+
+		if host == h {
+			return true
+		}
+	}
+
+	return false
+}
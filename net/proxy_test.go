@@ -0,0 +1,30 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesNoProxyHosts(t *testing.T) {
+	hosts := []string{"*.internal", "Metrics.Example.com"}
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "api.internal", want: true},
+		{host: "a.b.internal", want: true},
+		{host: "internal", want: false},
+		{host: "notinternal", want: false},
+		{host: "metrics.example.com", want: true},
+		{host: "api.metrics.example.com", want: false},
+		{host: "example.com", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			require.Equal(t, tc.want, MatchesNoProxyHosts(tc.host, hosts))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/notification"
 	"github.com/frain-dev/convoy/smtp"
+	"github.com/frain-dev/convoy/util"
 )
 
 type Email struct {
@@ -22,6 +23,26 @@ func NewEmailNotificationSender(smtpCfg *config.SMTPConfiguration) (notification
 	return &Email{s: s}, nil
 }
 
+// NewEmailNotifier returns a notifier emailing the support email of an
+// app when one of its endpoints is disabled.
+func NewEmailNotifier(smtpCfg *config.SMTPConfiguration) (notification.Notifier, error) {
+	s, err := smtp.New(smtpCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize smtp client: %v", err)
+	}
+
+	return &Email{s: s}, nil
+}
+
 func (e *Email) SendNotification(ctx context.Context, n *notification.Notification) error {
 	return e.s.SendEmailNotification(n.Email, n.LogoURL, n.TargetURL, n.EndpointStatus)
 }
+
+// NotifyEndpointDisabled does nothing for apps without a support email.
+func (e *Email) NotifyEndpointDisabled(ctx context.Context, n *notification.EndpointDisabled) error {
+	if util.IsStringEmpty(n.Email) {
+		return nil
+	}
+
+	return e.s.SendEndpointDisabledEmail(n.Email, n.LogoURL, n.TargetURL, n.Reason, n.DisabledAt)
+}
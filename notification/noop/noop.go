@@ -12,6 +12,14 @@ func NewNoopNotificationSender() notification.Sender {
 	return Noop{}
 }
 
+func NewNoopNotifier() notification.Notifier {
+	return Noop{}
+}
+
 func (s Noop) SendNotification(ctx context.Context, nc *notification.Notification) error {
 	return nil
 }
+
+func (s Noop) NotifyEndpointDisabled(ctx context.Context, n *notification.EndpointDisabled) error {
+	return nil
+}
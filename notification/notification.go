@@ -1,6 +1,9 @@
 package notification
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Notification struct {
 	Text           string
@@ -13,3 +16,22 @@ type Notification struct {
 type Sender interface {
 	SendNotification(context.Context, *Notification) error
 }
+
+// EndpointDisabled describes an endpoint that was disabled after failing
+// to receive events.
+type EndpointDisabled struct {
+	Email           string
+	LogoURL         string
+	AppID           string
+	AppTitle        string
+	EndpointID      string
+	TargetURL       string
+	EventDeliveryID string
+	Reason          string
+	DisabledAt      time.Time
+}
+
+// Notifier tells the owner of an app about changes to its endpoints.
+type Notifier interface {
+	NotifyEndpointDisabled(context.Context, *EndpointDisabled) error
+}
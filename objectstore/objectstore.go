@@ -0,0 +1,29 @@
+package objectstore
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/config"
+	s3store "github.com/frain-dev/convoy/objectstore/s3"
+)
+
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	Bucket() string
+}
+
+// NewObjectStore returns nil when no archive storage is configured.
+func NewObjectStore(cfg config.ArchiveConfiguration) (ObjectStore, error) {
+	if cfg.Type == config.S3ArchiveProvider {
+		st, err := s3store.NewS3Store(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.S3.Prefix, cfg.S3.AccessKey, cfg.S3.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return st, nil
+	}
+
+	return nil, nil
+}
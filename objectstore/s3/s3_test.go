@@ -0,0 +1,88 @@
+package s3store
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	objects := map[string][]byte{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access-key/20220501/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, hashHex(body), r.Header.Get("X-Amz-Content-Sha256"))
+
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("NoSuchKey"))
+				return
+			}
+
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestS3Store_PutAndGet(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	st, err := NewS3Store(srv.URL, "", "convoy", "/archives/", "access-key", "secret-key")
+	require.NoError(t, err)
+	st.now = func() time.Time { return time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC) }
+
+	err = st.Put(context.Background(), "group-1/batch.ndjson", []byte(`{"kind":"event"}`))
+	require.NoError(t, err)
+
+	data, err := st.Get(context.Background(), "group-1/batch.ndjson")
+	require.NoError(t, err)
+	require.Equal(t, `{"kind":"event"}`, string(data))
+
+	other, err := NewS3Store(srv.URL, "", "convoy", "", "access-key", "secret-key")
+	require.NoError(t, err)
+	other.now = st.now
+
+	data, err = other.Get(context.Background(), "archives/group-1/batch.ndjson")
+	require.NoError(t, err)
+	require.Equal(t, `{"kind":"event"}`, string(data))
+
+	_, err = st.Get(context.Background(), "group-1/missing.ndjson")
+	require.EqualError(t, err, "s3 responded with status 404 Not Found: NoSuchKey")
+
+	err = st.Delete(context.Background(), "group-1/batch.ndjson")
+	require.NoError(t, err)
+
+	_, err = st.Get(context.Background(), "group-1/batch.ndjson")
+	require.Error(t, err)
+}
+
+func TestNewS3Store_InvalidEndpoint(t *testing.T) {
+	_, err := NewS3Store("localhost", "", "convoy", "", "access-key", "secret-key")
+	require.EqualError(t, err, "invalid s3 endpoint: localhost")
+}
+
+func Test_escapePath(t *testing.T) {
+	require.Equal(t, "/convoy/archives/a%20b%2Bc~.ndjson", escapePath("/convoy/archives/a b+c~.ndjson"))
+}
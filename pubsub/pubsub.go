@@ -0,0 +1,94 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// subscriberBufferSize is the number of updates a subscriber can fall
+// behind by before further updates are dropped for it.
+const subscriberBufferSize = 64
+
+// PubSub carries event delivery updates from the workers that make them
+// to the streams that serve them.
+type PubSub interface {
+	Subscribe(groupID string) (<-chan datastore.EventDelivery, func())
+	Publish(delivery datastore.EventDelivery)
+}
+
+var defaultBroker PubSub = NewBroker()
+
+// Broker fans out event delivery updates to subscribers of a group.
+// It only sees updates published within the same process, so streams
+// opened against a server without in-process workers stay quiet.
+type Broker struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan datastore.EventDelivery]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: map[string]map[chan datastore.EventDelivery]struct{}{}}
+}
+
+// Subscribe registers a subscriber for the deliveries of groupID. The
+// returned func must be called to release the subscription.
+func (b *Broker) Subscribe(groupID string) (<-chan datastore.EventDelivery, func()) {
+	ch := make(chan datastore.EventDelivery, subscriberBufferSize)
+
+	b.mu.Lock()
+	if _, ok := b.subs[groupID]; !ok {
+		b.subs[groupID] = map[chan datastore.EventDelivery]struct{}{}
+	}
+	b.subs[groupID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subs[groupID], ch)
+			if len(b.subs[groupID]) == 0 {
+				delete(b.subs, groupID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends the delivery to every subscriber of its group. It never
+// blocks; subscribers whose buffer is full miss the update.
+func (b *Broker) Publish(delivery datastore.EventDelivery) {
+	if delivery.AppMetadata == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subs[delivery.AppMetadata.GroupID] {
+		select {
+		case ch <- delivery:
+		default:
+		}
+	}
+}
+
+// Default returns the process wide PubSub.
+func Default() PubSub {
+	return defaultBroker
+}
+
+// Subscribe registers a subscriber on the process wide broker.
+func Subscribe(groupID string) (<-chan datastore.EventDelivery, func()) {
+	return defaultBroker.Subscribe(groupID)
+}
+
+// Publish sends the delivery to subscribers on the process wide broker.
+func Publish(delivery datastore.EventDelivery) {
+	defaultBroker.Publish(delivery)
+}
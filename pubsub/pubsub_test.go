@@ -0,0 +1,42 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	b := NewBroker()
+
+	updates, unsubscribe := b.Subscribe("group-1")
+	other, unsubscribeOther := b.Subscribe("group-2")
+	defer unsubscribeOther()
+
+	b.Publish(datastore.EventDelivery{UID: "1", AppMetadata: &datastore.AppMetadata{GroupID: "group-1"}})
+	b.Publish(datastore.EventDelivery{UID: "2"})
+
+	require.Equal(t, "1", (<-updates).UID)
+	require.Len(t, other, 0)
+
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-updates
+	require.False(t, ok)
+	require.NotContains(t, b.subs, "group-1")
+}
+
+func TestBroker_PublishDropsForSlowSubscriber(t *testing.T) {
+	b := NewBroker()
+
+	updates, unsubscribe := b.Subscribe("group-1")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		b.Publish(datastore.EventDelivery{AppMetadata: &datastore.AppMetadata{GroupID: "group-1"}})
+	}
+
+	require.Len(t, updates, subscriberBufferSize)
+}
@@ -0,0 +1,42 @@
+package mquota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type counter struct {
+	value     int64
+	expiresAt time.Time
+}
+
+type MemoryCounter struct {
+	mu       sync.Mutex
+	counters map[string]*counter
+}
+
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{counters: map[string]*counter{}}
+}
+
+func (m *MemoryCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for k, c := range m.counters {
+		if now.After(c.expiresAt) {
+			delete(m.counters, k)
+		}
+	}
+
+	c, ok := m.counters[key]
+	if !ok {
+		c = &counter{expiresAt: now.Add(ttl)}
+		m.counters[key] = c
+	}
+
+	c.value++
+	return c.value, nil
+}
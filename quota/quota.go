@@ -0,0 +1,33 @@
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	mquota "github.com/frain-dev/convoy/quota/memory"
+	rquota "github.com/frain-dev/convoy/quota/redis"
+)
+
+// Counter counts usage against quotas.
+type Counter interface {
+	// Incr adds one to the counter at key and returns its new value.
+	// A counter that did not exist expires after ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// NewCounter returns a Counter stored in redis when the limiter uses
+// redis, so every instance counts against the same quota, and one kept in
+// memory otherwise.
+func NewCounter(cfg config.LimiterConfiguration) (Counter, error) {
+	if cfg.Type == config.RedisLimiterProvider {
+		c, err := rquota.NewRedisCounter(cfg.Redis.Dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	return mquota.NewMemoryCounter(), nil
+}
@@ -0,0 +1,37 @@
+package rquota
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type RedisCounter struct {
+	client *redis.Client
+}
+
+func NewRedisCounter(dsn string) (*RedisCounter, error) {
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisCounter{client: redis.NewClient(opts)}, nil
+}
+
+func (r *RedisCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	n, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 1 {
+		err = r.client.Expire(ctx, key, ttl).Err()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,25 @@
+package retrystrategies
+
+import (
+	"time"
+)
+
+type LinearRetryStrategy struct {
+	initialIntervalSeconds uint64
+	stepSeconds            uint64
+}
+
+// NextDuration waits the initial interval before the first retry, then a
+// step longer before each retry after it.
+func (r *LinearRetryStrategy) NextDuration(attempts uint64) time.Duration {
+	return time.Duration(r.initialIntervalSeconds+attempts*r.stepSeconds) * time.Second
+}
+
+func NewLinear(initialIntervalSeconds, stepSeconds uint64) *LinearRetryStrategy {
+	return &LinearRetryStrategy{
+		initialIntervalSeconds: initialIntervalSeconds,
+		stepSeconds:            stepSeconds,
+	}
+}
+
+var _ RetryStrategy = (*LinearRetryStrategy)(nil)
@@ -0,0 +1,48 @@
+package retrystrategies
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLinearRetryStrategy(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedDuration time.Duration
+		attempts         uint64
+		initialInterval  uint64
+		step             uint64
+	}{
+		{
+			name:             "initial-interval-for-first-retry",
+			expectedDuration: time.Duration(10) * time.Second,
+			attempts:         0,
+			initialInterval:  10,
+			step:             5,
+		},
+		{
+			name:             "one-step-for-second-retry",
+			expectedDuration: time.Duration(15) * time.Second,
+			attempts:         1,
+			initialInterval:  10,
+			step:             5,
+		},
+		{
+			name:             "step-per-attempt",
+			expectedDuration: time.Duration(110) * time.Second,
+			attempts:         20,
+			initialInterval:  10,
+			step:             5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			retry := NewLinear(tc.initialInterval, tc.step)
+			got := retry.NextDuration(tc.attempts)
+			if got != tc.expectedDuration {
+				t.Errorf("Want duration '%v' for attempts '%d', got '%v'", tc.expectedDuration, tc.attempts, got)
+			}
+		})
+	}
+}
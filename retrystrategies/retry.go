@@ -18,5 +18,9 @@ func NewRetryStrategyFromMetadata(m datastore.Metadata) RetryStrategy {
 		return NewExponential([]uint{0, 10, 10, 100, 100, 500, 500, 3000, 3000, 5000})
 	}
 
+	if string(m.Strategy) == string(config.LinearStrategyProvider) {
+		return NewLinear(m.IntervalSeconds, m.StepSeconds)
+	}
+
 	return NewDefault(m.IntervalSeconds)
 }
@@ -2,6 +2,7 @@ package retrystrategies
 
 import (
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +41,17 @@ func TestRetry_FallsBackToDefault(t *testing.T) {
 	_, isDefault := r.(*DefaultRetryStrategy)
 	assert.True(t, isDefault)
 }
+
+func TestRetry_CreatesLinear(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:        "linear",
+		RetryLimit:      20,
+		IntervalSeconds: 5,
+		StepSeconds:     10,
+	}
+
+	var r RetryStrategy = NewRetryStrategyFromMetadata(m)
+	linear, isLinear := r.(*LinearRetryStrategy)
+	assert.True(t, isLinear)
+	assert.Equal(t, 15*time.Second, linear.NextDuration(1))
+}
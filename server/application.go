@@ -1,16 +1,26 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/frain-dev/convoy/services"
 
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	limiter "github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/logger"
+	"github.com/frain-dev/convoy/objectstore"
+	"github.com/frain-dev/convoy/pubsub"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/quota"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/tracer"
 	"github.com/frain-dev/convoy/util"
@@ -21,61 +31,135 @@ import (
 )
 
 type applicationHandler struct {
-	appService        *services.AppService
-	eventService      *services.EventService
-	groupService      *services.GroupService
-	securityService   *services.SecurityService
-	appRepo           datastore.ApplicationRepository
-	eventRepo         datastore.EventRepository
-	eventDeliveryRepo datastore.EventDeliveryRepository
-	groupRepo         datastore.GroupRepository
-	apiKeyRepo        datastore.APIKeyRepository
-	eventQueue        queue.Queuer
-	createEventQueue  queue.Queuer
-	logger            logger.Logger
-	tracer            tracer.Tracer
-	cache             cache.Cache
-	limiter           limiter.RateLimiter
+	appService         *services.AppService
+	eventService       *services.EventService
+	groupService       *services.GroupService
+	securityService    *services.SecurityService
+	exportService      *services.ExportService
+	archiveService     *services.ArchiveService
+	eventBridgeService *services.EventBridgeService
+	eventTypeService   *services.EventTypeService
+	settingsService    *services.SettingsService
+	appRepo            datastore.ApplicationRepository
+	eventRepo          datastore.EventRepository
+	eventDeliveryRepo  datastore.EventDeliveryRepository
+	groupRepo          datastore.GroupRepository
+	apiKeyRepo         datastore.APIKeyRepository
+	auditLogRepo       datastore.AuditLogRepository
+	archiveRepo        datastore.ArchiveRepository
+	exportJobRepo      datastore.ExportJobRepository
+	eventBridgeRepo    datastore.EventBridgeRepository
+	eventTypeRepo      datastore.EventTypeRepository
+	settingsRepo       datastore.GlobalSettingsRepository
+	eventQueue         queue.Queuer
+	createEventQueue   queue.Queuer
+	logger             logger.Logger
+	tracer             tracer.Tracer
+	cache              cache.Cache
+	limiter            limiter.RateLimiter
+	quota              quota.Counter
+	objectStore        objectstore.ObjectStore
+	database           datastore.DatabaseClient
+	configReloader     *config.Reloader
+	readiness          *Readiness
+
+	// shutdown is done once the server starts shutting down, open event
+	// streams are closed then rather than holding up the drain.
+	shutdown context.Context
+
+	deliveryUpdates         pubsub.PubSub
+	streamHeartbeatInterval time.Duration
+	streamMaxDuration       time.Duration
+	maxCountDateRange       time.Duration
 }
 
+// defaultStreamHeartbeatInterval is how often a keep-alive comment is
+// written to open event streams when none is configured.
+const defaultStreamHeartbeatInterval = 30 * time.Second
+
+// defaultStreamMaxDuration is how long an event stream is kept open when
+// no limit is configured. It matches the lifetime of app portal keys.
+const defaultStreamMaxDuration = 30 * time.Minute
+
+// defaultMaxCountDateRange is the widest date range the count endpoints
+// accept when none is configured.
+const defaultMaxCountDateRange = 90 * 24 * time.Hour
+
 type pagedResponse struct {
 	Content    interface{}               `json:"content,omitempty"`
 	Pagination *datastore.PaginationData `json:"pagination,omitempty"`
 }
 
+type cursorPagedResponse struct {
+	Content    interface{}                     `json:"content,omitempty"`
+	Pagination *datastore.CursorPaginationData `json:"pagination,omitempty"`
+}
+
 func newApplicationHandler(
 	eventRepo datastore.EventRepository,
 	eventDeliveryRepo datastore.EventDeliveryRepository,
 	appRepo datastore.ApplicationRepository,
 	groupRepo datastore.GroupRepository,
 	apiKeyRepo datastore.APIKeyRepository,
+	auditLogRepo datastore.AuditLogRepository,
+	archiveRepo datastore.ArchiveRepository,
+	exportJobRepo datastore.ExportJobRepository,
+	eventBridgeRepo datastore.EventBridgeRepository,
+	eventTypeRepo datastore.EventTypeRepository,
+	settingsRepo datastore.GlobalSettingsRepository,
 	eventQueue queue.Queuer,
 	createEventQueue queue.Queuer,
 	logger logger.Logger,
 	tracer tracer.Tracer,
 	cache cache.Cache,
-	limiter limiter.RateLimiter) *applicationHandler {
-	as := services.NewAppService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, cache)
-	es := services.NewEventService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, createEventQueue, cache)
-	gs := services.NewGroupService(appRepo, groupRepo, eventRepo, eventDeliveryRepo, limiter)
-	ss := services.NewSecurityService(groupRepo, apiKeyRepo)
+	limiter limiter.RateLimiter,
+	quota quota.Counter,
+	objectStore objectstore.ObjectStore) *applicationHandler {
+	as := services.NewAppService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, cache, limiter)
+	es := services.NewEventService(appRepo, eventRepo, eventDeliveryRepo, eventTypeRepo, eventQueue, createEventQueue, cache)
+	gs := services.NewGroupService(appRepo, groupRepo, eventRepo, eventDeliveryRepo, settingsRepo, limiter, cache)
+	ss := services.NewSecurityService(groupRepo, appRepo, apiKeyRepo, auditLogRepo)
+	exs := services.NewExportService(eventRepo, eventDeliveryRepo, exportJobRepo, objectStore)
+	ars := services.NewArchiveService(groupRepo, eventRepo, eventDeliveryRepo, archiveRepo, objectStore)
+	ebs := services.NewEventBridgeService(eventBridgeRepo)
+	ets := services.NewEventTypeService(eventTypeRepo, cache)
+	sts := services.NewSettingsService(settingsRepo)
 
 	return &applicationHandler{
-		appService:        as,
-		eventService:      es,
-		groupService:      gs,
-		securityService:   ss,
-		eventRepo:         eventRepo,
-		eventDeliveryRepo: eventDeliveryRepo,
-		apiKeyRepo:        apiKeyRepo,
-		appRepo:           appRepo,
-		groupRepo:         groupRepo,
-		eventQueue:        eventQueue,
-		createEventQueue:  createEventQueue,
-		logger:            logger,
-		tracer:            tracer,
-		cache:             cache,
-		limiter:           limiter,
+		appService:         as,
+		eventService:       es,
+		groupService:       gs,
+		securityService:    ss,
+		exportService:      exs,
+		archiveService:     ars,
+		eventBridgeService: ebs,
+		eventTypeService:   ets,
+		settingsService:    sts,
+		eventRepo:          eventRepo,
+		eventDeliveryRepo:  eventDeliveryRepo,
+		apiKeyRepo:         apiKeyRepo,
+		auditLogRepo:       auditLogRepo,
+		archiveRepo:        archiveRepo,
+		exportJobRepo:      exportJobRepo,
+		eventBridgeRepo:    eventBridgeRepo,
+		eventTypeRepo:      eventTypeRepo,
+		settingsRepo:       settingsRepo,
+		appRepo:            appRepo,
+		groupRepo:          groupRepo,
+		eventQueue:         eventQueue,
+		createEventQueue:   createEventQueue,
+		logger:             logger,
+		tracer:             tracer,
+		cache:              cache,
+		limiter:            limiter,
+		quota:              quota,
+		objectStore:        objectStore,
+		shutdown:           context.Background(),
+
+		deliveryUpdates:         pubsub.Default(),
+		streamHeartbeatInterval: defaultStreamHeartbeatInterval,
+		streamMaxDuration:       defaultStreamMaxDuration,
+		maxCountDateRange:       defaultMaxCountDateRange,
 	}
 }
 
@@ -105,8 +189,12 @@ func (a *applicationHandler) GetApp(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
-// @Param sort query string false "sort order"
-// @Param q query string false "app title"
+// @Param sort query string false "sort order, asc or desc, or the field to sort by, title or created_at, optionally followed by :asc or :desc"
+// @Param q query string false "text to search for in the app title, case insensitive"
+// @Param ownerId query string false "owner id"
+// @Param is_disabled query boolean false "disabled status"
+// @Param status query string false "status of the applications listed, active, inactive or all, defaults to active"
+// @Param show_deleted query boolean false "list deleted applications too"
 // @Param groupId query string true "group id"
 // @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.Application}}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
@@ -115,9 +203,52 @@ func (a *applicationHandler) GetApp(w http.ResponseWriter, r *http.Request) {
 func (a *applicationHandler) GetApps(w http.ResponseWriter, r *http.Request) {
 	pageable := getPageableFromContext(r.Context())
 	group := getGroupFromContext(r.Context())
-	q := r.URL.Query().Get("q")
 
-	apps, paginationData, err := a.appRepo.LoadApplicationsPaged(r.Context(), group.UID, q, pageable)
+	filter := &datastore.ApplicationFilter{
+		Query:   r.URL.Query().Get("q"),
+		OwnerID: r.URL.Query().Get("ownerId"),
+	}
+
+	if util.IsStringEmpty(filter.OwnerID) {
+		filter.OwnerID = r.URL.Query().Get("owner_id")
+	}
+
+	if rawIsDisabled := r.URL.Query().Get("is_disabled"); !util.IsStringEmpty(rawIsDisabled) {
+		isDisabled, err := strconv.ParseBool(rawIsDisabled)
+		if err != nil {
+			_ = render.Render(w, r, newErrorResponse("is_disabled must be true or false", http.StatusBadRequest))
+			return
+		}
+		filter.IsDisabled = &isDisabled
+	}
+
+	if status := r.URL.Query().Get("status"); !util.IsStringEmpty(status) {
+		switch status {
+		case datastore.ApplicationStatusActive, datastore.ApplicationStatusInactive, datastore.ApplicationStatusAll:
+			filter.Status = status
+		default:
+			_ = render.Render(w, r, newErrorResponse("status must be one of active, inactive or all", http.StatusBadRequest))
+			return
+		}
+	}
+
+	if rawShowDeleted := r.URL.Query().Get("show_deleted"); !util.IsStringEmpty(rawShowDeleted) {
+		showDeleted, err := strconv.ParseBool(rawShowDeleted)
+		if err != nil {
+			_ = render.Render(w, r, newErrorResponse("show_deleted must be true or false", http.StatusBadRequest))
+			return
+		}
+		filter.ShowDeleted = showDeleted
+	}
+
+	var err error
+	filter.SortBy, pageable.Sort, err = getAppSortFromQuery(r, pageable.Sort)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	apps, paginationData, err := a.appRepo.LoadApplicationsPaged(r.Context(), group.UID, filter.WithQueryTrimmed(), pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to load apps")
 		_ = render.Render(w, r, newErrorResponse("an error occurred while fetching apps. Error: "+err.Error(), http.StatusBadRequest))
@@ -128,6 +259,45 @@ func (a *applicationHandler) GetApps(w http.ResponseWriter, r *http.Request) {
 		pagedResponse{Content: &apps, Pagination: &paginationData}, http.StatusOK))
 }
 
+// getAppSortFromQuery reads the field apps are sorted by and the order
+// from the sort query, e.g. title:asc. A bare asc or desc keeps sorting by
+// creation date, the order the pagination middleware already read. Titles
+// are sorted in ascending order and creation dates in descending order
+// unless an order is given.
+func getAppSortFromQuery(r *http.Request, order int) (string, int, error) {
+	rawSort := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sort")))
+
+	switch rawSort {
+	case "", "asc", "desc":
+		return datastore.ApplicationSortCreatedAt, order, nil
+	}
+
+	parts := strings.SplitN(rawSort, ":", 2)
+	field := parts[0]
+
+	switch field {
+	case datastore.ApplicationSortTitle:
+		order = 1
+	case datastore.ApplicationSortCreatedAt:
+		order = -1
+	default:
+		return "", 0, errors.New("sort must be title or created_at, optionally followed by :asc or :desc")
+	}
+
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			order = 1
+		case "desc":
+			order = -1
+		default:
+			return "", 0, errors.New("sort must be title or created_at, optionally followed by :asc or :desc")
+		}
+	}
+
+	return field, order, nil
+}
+
 // CreateApp
 // @Summary Create an application
 // @Description This endpoint creates an application
@@ -167,6 +337,7 @@ func (a *applicationHandler) CreateApp(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param groupId query string true "group id"
 // @Param appID path string true "application id"
+// @Param confirm_owner_change query boolean false "allow changing the owner id of the app"
 // @Param application body models.Application true "Application Details"
 // @Success 200 {object} serverResponse{data=datastore.Application}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
@@ -180,9 +351,18 @@ func (a *applicationHandler) UpdateApp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var confirmOwnerChange bool
+	if rawConfirm := r.URL.Query().Get("confirm_owner_change"); !util.IsStringEmpty(rawConfirm) {
+		confirmOwnerChange, err = strconv.ParseBool(rawConfirm)
+		if err != nil {
+			_ = render.Render(w, r, newErrorResponse("confirm_owner_change must be true or false", http.StatusBadRequest))
+			return
+		}
+	}
+
 	app := getApplicationFromContext(r.Context())
 
-	err = a.appService.UpdateApplication(r.Context(), &appUpdate, app)
+	err = a.appService.UpdateApplication(r.Context(), &appUpdate, app, confirmOwnerChange)
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
@@ -215,6 +395,195 @@ func (a *applicationHandler) DeleteApp(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, newServerResponse("App deleted successfully", nil, http.StatusOK))
 }
 
+// RestoreApp
+// @Summary Restore a deleted app
+// @Description This endpoint restores a deleted app along with the endpoints and events deleted with it
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Success 200 {object} serverResponse{data=datastore.Application}
+// @Failure 400,401,404,409,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/restore [put]
+func (a *applicationHandler) RestoreApp(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	app, err := a.appService.RestoreApplication(r.Context(), chi.URLParam(r, "appID"), group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App restored successfully", app, http.StatusOK))
+}
+
+// TransferApp
+// @Summary Move an application to another group
+// @Description This endpoint moves an application, its endpoints, events and deliveries to another group. Deliveries being processed complete under the old group's signature config
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param transfer body models.TransferApplication true "Destination group"
+// @Success 200 {object} serverResponse{data=datastore.Application}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/transfer [post]
+func (a *applicationHandler) TransferApp(w http.ResponseWriter, r *http.Request) {
+	var transfer models.TransferApplication
+	err := util.ReadJSON(r, &transfer)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = services.Validate(transfer)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	app := getApplicationFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	// access to the app's group was checked on the way in, the app has to
+	// be in it
+	if app.GroupID != group.UID {
+		_ = render.Render(w, r, newErrorResponse(datastore.ErrApplicationNotFound.Error(), http.StatusNotFound))
+		return
+	}
+
+	destination, err := a.groupRepo.FetchGroupByID(r.Context(), transfer.GroupID)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse("failed to fetch group by id", http.StatusNotFound))
+		return
+	}
+
+	// the role was checked against the app's group by the router, the
+	// caller needs the same access to the destination group
+	authUser := getAuthUserFromContext(r.Context())
+	err = authorizeGroupAccess(authUser, destination, authUser.Role.Type)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusUnauthorized))
+		return
+	}
+
+	err = a.appService.TransferApplication(r.Context(), app, destination)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App transferred successfully", app, http.StatusOK))
+}
+
+// PauseApp
+// @Summary Pause an application
+// @Description This endpoint pauses an application, deliveries to its endpoints are held until it is resumed
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Success 200 {object} serverResponse{data=datastore.Application}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/pause [put]
+func (a *applicationHandler) PauseApp(w http.ResponseWriter, r *http.Request) {
+	app := getApplicationFromContext(r.Context())
+
+	err := a.appService.PauseApplication(r.Context(), app)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App paused successfully", app, http.StatusOK))
+}
+
+// ResumeApp
+// @Summary Resume an application
+// @Description This endpoint resumes a paused application and releases its held deliveries to the queue
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Success 200 {object} serverResponse{data=datastore.Application}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/resume [put]
+func (a *applicationHandler) ResumeApp(w http.ResponseWriter, r *http.Request) {
+	app := getApplicationFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	released, err := a.appService.ResumeApplication(r.Context(), app, group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse(fmt.Sprintf("App resumed successfully, %d held event deliveries released", released), app, http.StatusOK))
+}
+
+// GetAppStatistics
+// @Summary Get application statistics
+// @Description This endpoint fetches the events sent to an application and the outcome of their deliveries over a period
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param period query string false "one of daily, weekly, monthly or yearly, defaults to daily"
+// @Success 200 {object} serverResponse{data=models.AppStatistics}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/stats [get]
+func (a *applicationHandler) GetAppStatistics(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if util.IsStringEmpty(period) {
+		period = "daily"
+	}
+
+	statistics, err := a.appService.GetAppStatistics(r.Context(), getApplicationFromContext(r.Context()), period)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App statistics fetched successfully", statistics, http.StatusOK))
+}
+
+// GetAppEventDeliveries
+// @Summary Get application event deliveries
+// @Description This endpoint fetches the event deliveries of an application across all its events
+// @Tags Application
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.EventDelivery{data=Stub}}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/deliveries [get]
+func (a *applicationHandler) GetAppEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, paginationData, err := a.appService.LoadAppEventDeliveriesPaged(r.Context(),
+		getApplicationFromContext(r.Context()), getPageableFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App event deliveries fetched successfully",
+		pagedResponse{Content: &deliveries, Pagination: &paginationData}, http.StatusOK))
+}
+
 // CreateAppEndpoint
 // @Summary Create an application endpoint
 // @Description This endpoint creates an application endpoint
@@ -256,14 +625,48 @@ func (a *applicationHandler) CreateAppEndpoint(w http.ResponseWriter, r *http.Re
 // @Param groupId query string true "group id"
 // @Param appID path string true "application id"
 // @Param endpointID path string true "endpoint id"
-// @Success 200 {object} serverResponse{data=datastore.Endpoint}
+// @Success 200 {object} serverResponse{data=models.ExpandedEndpoint}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /applications/{appID}/endpoints/{endpointID} [get]
 func (a *applicationHandler) GetAppEndpoint(w http.ResponseWriter, r *http.Request) {
+	endpoint := getApplicationEndpointFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	usage, err := a.appService.GetEndpointRateLimitUsage(r.Context(), endpoint, group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
 
 	_ = render.Render(w, r, newServerResponse("App endpoint fetched successfully",
-		*getApplicationEndpointFromContext(r.Context()), http.StatusOK))
+		models.ExpandedEndpoint{Endpoint: *endpoint, RateLimitUsage: usage}, http.StatusOK))
+}
+
+// GetAppEndpointRateLimits
+// @Summary Get application endpoint rate limit usage
+// @Description This endpoint fetches how much of the endpoint's delivery rate limit has been used in the current window
+// @Tags Application Endpoints
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Success 200 {object} serverResponse{data=models.RateLimitUsage}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/ratelimits [get]
+func (a *applicationHandler) GetAppEndpointRateLimits(w http.ResponseWriter, r *http.Request) {
+	endpoint := getApplicationEndpointFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	usage, err := a.appService.GetEndpointRateLimitUsage(r.Context(), endpoint, group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App endpoint rate limits fetched successfully", usage, http.StatusOK))
 }
 
 // GetAppEndpoints
@@ -345,6 +748,35 @@ func (a *applicationHandler) DeleteAppEndpoint(w http.ResponseWriter, r *http.Re
 	_ = render.Render(w, r, newServerResponse("App endpoint deleted successfully", nil, http.StatusOK))
 }
 
+// PingAppEndpoint
+// @Summary Ping an application endpoint
+// @Description This endpoint sends a signed test payload to an application endpoint and returns how it answered. The ping is only saved as an event when record is true
+// @Tags Application Endpoints
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Param record query bool false "save the ping as an event"
+// @Success 200 {object} serverResponse{data=models.EndpointPing}
+// @Failure 400,401,429 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/ping [post]
+func (a *applicationHandler) PingAppEndpoint(w http.ResponseWriter, r *http.Request) {
+	app := getApplicationFromContext(r.Context())
+	e := getApplicationEndpointFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+	record := r.URL.Query().Get("record") == "true"
+
+	ping, err := a.appService.PingAppEndpoint(r.Context(), group, app, e, record)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App endpoint pinged successfully", ping, http.StatusOK))
+}
+
 func (a *applicationHandler) GetPaginatedApps(w http.ResponseWriter, r *http.Request) {
 
 	_ = render.Render(w, r, newServerResponse("Apps fetched successfully",
@@ -17,6 +17,7 @@ import (
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	nooplimiter "github.com/frain-dev/convoy/limiter/noop"
 	"github.com/frain-dev/convoy/logger"
+	mquota "github.com/frain-dev/convoy/quota/memory"
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
@@ -70,7 +71,7 @@ func stripTimestamp(t *testing.T, obj string, b *bytes.Buffer) *bytes.Buffer {
 		}
 
 		a.UID = ""
-		a.CreatedAt, a.UpdatedAt, a.DeletedAt = 0, 0, 0
+		a.CreatedAt, a.UpdatedAt, a.DeletedAt, a.PausedAt = 0, 0, 0, 0
 
 		jsonData, err := json.Marshal(a)
 		if err != nil {
@@ -148,9 +149,17 @@ func provideApplication(ctrl *gomock.Controller) *applicationHandler {
 	logger := logger.NewNoopLogger()
 	tracer := mocks.NewMockTracer(ctrl)
 	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	auditLogRepo := mocks.NewMockAuditLogRepository(ctrl)
+	archiveRepo := mocks.NewMockArchiveRepository(ctrl)
+	exportJobRepo := mocks.NewMockExportJobRepository(ctrl)
+	eventBridgeRepo := mocks.NewMockEventBridgeRepository(ctrl)
+	eventTypeRepo := mocks.NewMockEventTypeRepository(ctrl)
+	settingsRepo := mocks.NewMockGlobalSettingsRepository(ctrl)
 	cache := mocks.NewMockCache(ctrl)
 	limiter := nooplimiter.NewNoopLimiter()
-	return newApplicationHandler(eventRepo, eventDeliveryRepo, appRepo, groupRepo, apiKeyRepo, eventQueue, createEventQueue, logger, tracer, cache, limiter)
+	quota := mquota.NewMemoryCounter()
+	objectStore := mocks.NewMockObjectStore(ctrl)
+	return newApplicationHandler(eventRepo, eventDeliveryRepo, appRepo, groupRepo, apiKeyRepo, auditLogRepo, archiveRepo, exportJobRepo, eventBridgeRepo, eventTypeRepo, settingsRepo, eventQueue, createEventQueue, logger, tracer, cache, limiter, quota, objectStore)
 }
 
 func TestApplicationHandler_GetApp(t *testing.T) {
@@ -377,6 +386,229 @@ func TestApplicationHandler_GetApps(t *testing.T) {
 
 }
 
+func TestApplicationHandler_GetApps_Filters(t *testing.T) {
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+	isDisabled := false
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		url        string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_combine_filters",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/applications?q=%20Billing%20&owner_id=cus_1&is_disabled=false&sort=title",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					LoadApplicationsPaged(gomock.Any(), groupID, &datastore.ApplicationFilter{
+						Query:      "Billing",
+						OwnerID:    "cus_1",
+						IsDisabled: &isDisabled,
+						SortBy:     datastore.ApplicationSortTitle,
+					}, datastore.Pageable{Page: 0, PerPage: 20, Sort: 1}).Times(1).
+					Return([]datastore.Application{
+						{
+							UID:       "123456789",
+							GroupID:   groupID,
+							OwnerID:   "cus_1",
+							Title:     "Billing",
+							Endpoints: []datastore.Endpoint{},
+						},
+					}, datastore.PaginationData{}, nil)
+			},
+		},
+		{
+			name:       "should_sort_by_creation_date_in_the_given_order",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/applications?sort=created_at:asc",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					LoadApplicationsPaged(gomock.Any(), groupID, &datastore.ApplicationFilter{SortBy: datastore.ApplicationSortCreatedAt},
+						datastore.Pageable{Page: 0, PerPage: 20, Sort: 1}).Times(1).
+					Return([]datastore.Application{}, datastore.PaginationData{}, nil)
+			},
+		},
+		{
+			name:       "should_fail_for_invalid_is_disabled",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/applications?is_disabled=maybe",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_fail_for_invalid_sort_field",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/applications?sort=support_email:asc",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.SetBasicAuth("test", "test")
+			w := httptest.NewRecorder()
+
+			// Arrange Expectations.
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestApplicationHandler_GetApplications_FiltersByStatus(t *testing.T) {
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+
+	tt := []struct {
+		name       string
+		url        string
+		status     string
+		statusCode int
+	}{
+		{
+			name:       "should_list_active_apps",
+			url:        "/api/v1/applications?status=active",
+			status:     datastore.ApplicationStatusActive,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "should_list_inactive_apps",
+			url:        "/api/v1/applications?status=inactive",
+			status:     datastore.ApplicationStatusInactive,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "should_list_all_apps",
+			url:        "/api/v1/applications?status=all",
+			status:     datastore.ApplicationStatusAll,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "should_fail_for_invalid_status",
+			url:        "/api/v1/applications?status=paused",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.SetBasicAuth("test", "test")
+			w := httptest.NewRecorder()
+
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+			o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			o.EXPECT().
+				LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+				Return([]*datastore.Group{group}, nil)
+
+			if tc.statusCode == http.StatusOK {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					LoadApplicationsPaged(gomock.Any(), groupID, &datastore.ApplicationFilter{
+						SortBy: datastore.ApplicationSortCreatedAt,
+						Status: tc.status,
+					}, gomock.Any()).Times(1).
+					Return([]datastore.Application{}, datastore.PaginationData{}, nil)
+			}
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
 func TestApplicationHandler_CreateApp(t *testing.T) {
 
 	groupID := "1234567890"
@@ -590,7 +822,8 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a.EXPECT().
 					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
@@ -624,7 +857,8 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a.EXPECT().
 					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
@@ -658,7 +892,8 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a.EXPECT().
 					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
@@ -691,60 +926,419 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				c, _ := app.cache.(*mocks.MockCache)
 
-				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
+
+				a.EXPECT().
+					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.Application{
+						UID:        appId,
+						GroupID:    groupID,
+						Title:      "Valid application update",
+						Endpoints:  []datastore.Endpoint{},
+						IsDisabled: false,
+					}, nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "valid request - enable disabled application",
+			cfgPath:    "/testdata/Auth_Config/no-auth-convoy.json",
+			method:     http.MethodPut,
+			statusCode: http.StatusAccepted,
+			appId:      appId,
+			body:       strings.NewReader(`{"name": "ABC", "is_disabled": false }`),
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				c, _ := app.cache.(*mocks.MockCache)
+
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
+
+				a.EXPECT().
+					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.Application{
+						UID:        appId,
+						GroupID:    groupID,
+						Title:      "Valid application update",
+						Endpoints:  []datastore.Endpoint{},
+						IsDisabled: true,
+					}, nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var app *applicationHandler
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app = provideApplication(ctrl)
+
+			url := fmt.Sprintf("/api/v1/applications/%s", tc.appId)
+			req := httptest.NewRequest(tc.method, url, tc.body)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("appID", tc.appId)
+
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(context.WithValue(req.Context(), appCtx, &datastore.Application{
+				UID:       appId,
+				GroupID:   groupID,
+				Title:     "Valid application update",
+				Endpoints: []datastore.Endpoint{},
+			}))
+
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+
+}
+
+func Test_applicationHandler_DeleteApp(t *testing.T) {
+
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+
+	appId := "12345"
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		statusCode int
+		appId      string
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_delete_app",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusOK,
+			appId:      appId,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(6)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				obj := &datastore.Application{
+					UID:       appId,
+					GroupID:   groupID,
+					Title:     "Valid application delete",
+					Endpoints: []datastore.Endpoint{},
+				}
+
+				a.EXPECT().
+					DeleteApplication(gomock.Any(), obj).Times(1).
+					Return(nil)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(obj, nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_delete_app",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusBadRequest,
+			appId:      appId,
+			dbFn: func(app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+
+				obj := &datastore.Application{
+					UID:       appId,
+					GroupID:   groupID,
+					Title:     "Valid application update",
+					Endpoints: []datastore.Endpoint{},
+				}
+
+				a.EXPECT().
+					DeleteApplication(gomock.Any(), obj).Times(1).
+					Return(errors.New("failed to delete app endpoint"))
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(obj, nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var app *applicationHandler
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app = provideApplication(ctrl)
+
+			url := fmt.Sprintf("/api/v1/applications/%s", tc.appId)
+			req := httptest.NewRequest(http.MethodDelete, url, &bytes.Buffer{})
+			req.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+
+}
+
+func Test_applicationHandler_PauseApp(t *testing.T) {
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+
+	appId := "12345"
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		statusCode int
+		isPaused   bool
+		dbFn       func(app *applicationHandler, obj *datastore.Application)
+	}{
+		{
+			name:       "should_pause_app",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(obj, nil)
+
+				a.EXPECT().
+					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_pause_paused_app",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusBadRequest,
+			isPaused:   true,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(obj, nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var app *applicationHandler
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app = provideApplication(ctrl)
+
+			url := fmt.Sprintf("/api/v1/applications/%s/pause", appId)
+			req := httptest.NewRequest(http.MethodPut, url, &bytes.Buffer{})
+			req.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			obj := &datastore.Application{
+				UID:       appId,
+				GroupID:   groupID,
+				Title:     "Valid application pause",
+				IsPaused:  tc.isPaused,
+				Endpoints: []datastore.Endpoint{},
+			}
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(app, obj)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			w.Body = stripTimestamp(t, "application", w.Body)
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func Test_applicationHandler_GetAppStatistics(t *testing.T) {
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+
+	appId := "12345"
 
-				a.EXPECT().
-					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
-					Return(nil)
+	tt := []struct {
+		name       string
+		cfgPath    string
+		query      string
+		statusCode int
+		dbFn       func(app *applicationHandler, obj *datastore.Application)
+	}{
+		{
+			name:       "should_fetch_app_statistics",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			query:      "period=weekly",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
 					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
-					Return(&datastore.Application{
-						UID:        appId,
-						GroupID:    groupID,
-						Title:      "Valid application update",
-						Endpoints:  []datastore.Endpoint{},
-						IsDisabled: false,
-					}, nil)
+					Return(obj, nil)
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
-
 				o.EXPECT().
 					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
 					Return([]*datastore.Group{group}, nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					CountEvents(gomock.Any(), groupID, appId, gomock.Any()).Times(1).
+					Return(int64(10000), nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					LoadDeliveryStatistics(gomock.Any(), groupID, appId, gomock.Any()).Times(1).
+					Return(&datastore.DeliveryStatistics{Attempted: 10000, Successful: 9870, Failed: 130, AverageLatencyMs: 245}, nil)
 			},
 		},
 		{
-			name:       "valid request - enable disabled application",
-			cfgPath:    "/testdata/Auth_Config/no-auth-convoy.json",
-			method:     http.MethodPut,
-			statusCode: http.StatusAccepted,
-			appId:      appId,
-			body:       strings.NewReader(`{"name": "ABC", "is_disabled": false }`),
-			dbFn: func(app *applicationHandler) {
-				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+			name:       "should_fail_for_invalid_period",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			query:      "period=hourly",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
 				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
-
-				a.EXPECT().
-					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
-					Return(nil)
-
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
 					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
-					Return(&datastore.Application{
-						UID:        appId,
-						GroupID:    groupID,
-						Title:      "Valid application update",
-						Endpoints:  []datastore.Endpoint{},
-						IsDisabled: true,
-					}, nil)
+					Return(obj, nil)
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
-
 				o.EXPECT().
 					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
 					Return([]*datastore.Group{group}, nil)
@@ -761,25 +1355,22 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 			defer ctrl.Finish()
 			app = provideApplication(ctrl)
 
-			url := fmt.Sprintf("/api/v1/applications/%s", tc.appId)
-			req := httptest.NewRequest(tc.method, url, tc.body)
-			req.SetBasicAuth("test", "test")
+			url := fmt.Sprintf("/api/v1/applications/%s/stats?%s", appId, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
 			req.Header.Add("Content-Type", "application/json")
 
 			w := httptest.NewRecorder()
-			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("appID", tc.appId)
 
-			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-			req = req.WithContext(context.WithValue(req.Context(), appCtx, &datastore.Application{
+			obj := &datastore.Application{
 				UID:       appId,
 				GroupID:   groupID,
-				Title:     "Valid application update",
+				Title:     "Valid application",
 				Endpoints: []datastore.Endpoint{},
-			}))
+			}
 
+			// Arrange Expectations
 			if tc.dbFn != nil {
-				tc.dbFn(app)
+				tc.dbFn(app, obj)
 			}
 
 			err := config.LoadConfig(tc.cfgPath)
@@ -791,7 +1382,7 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 
 			router := buildRoutes(app)
 
-			// Act.
+			// Act
 			router.ServeHTTP(w, req)
 
 			if w.Code != tc.statusCode {
@@ -801,11 +1392,9 @@ func TestApplicationHandler_UpdateApp(t *testing.T) {
 			verifyMatch(t, *w)
 		})
 	}
-
 }
 
-func Test_applicationHandler_DeleteApp(t *testing.T) {
-
+func Test_applicationHandler_GetAppEventDeliveries(t *testing.T) {
 	groupID := "1234567890"
 	group := &datastore.Group{UID: groupID}
 
@@ -815,75 +1404,62 @@ func Test_applicationHandler_DeleteApp(t *testing.T) {
 		name       string
 		cfgPath    string
 		statusCode int
-		appId      string
-		dbFn       func(app *applicationHandler)
+		dbFn       func(app *applicationHandler, obj *datastore.Application)
 	}{
 		{
-			name:       "should_delete_app",
+			name:       "should_fetch_app_event_deliveries",
 			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
 			statusCode: http.StatusOK,
-			appId:      appId,
-			dbFn: func(app *applicationHandler) {
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
 				c, _ := app.cache.(*mocks.MockCache)
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
-				c.EXPECT().Delete(gomock.Any(), gomock.Any())
 
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-				obj := &datastore.Application{
-					UID:       appId,
-					GroupID:   groupID,
-					Title:     "Valid application delete",
-					Endpoints: []datastore.Endpoint{},
-				}
-
-				a.EXPECT().
-					DeleteApplication(gomock.Any(), obj).Times(1).
-					Return(nil)
-
 				a.EXPECT().
 					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
 					Return(obj, nil)
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
-
 				o.EXPECT().
 					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
 					Return([]*datastore.Group{group}, nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					FindDeliveriesByAppID(gomock.Any(), appId, datastore.Pageable{Page: 2, PerPage: 1, Sort: -1}).Times(1).
+					Return([]datastore.EventDelivery{
+						{
+							UID:         "delivery-1",
+							Status:      datastore.SuccessEventStatus,
+							AppMetadata: &datastore.AppMetadata{UID: appId, GroupID: groupID},
+						},
+					}, datastore.PaginationData{Total: 2, Page: 2, PerPage: 1, Prev: 1, Next: 3, TotalPage: 2}, nil)
 			},
 		},
 		{
-			name:       "should_fail_to_delete_app",
+			name:       "should_fail_to_fetch_app_event_deliveries",
 			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
-			statusCode: http.StatusBadRequest,
-			appId:      appId,
-			dbFn: func(app *applicationHandler) {
+			statusCode: http.StatusInternalServerError,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
 				c, _ := app.cache.(*mocks.MockCache)
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-
-				obj := &datastore.Application{
-					UID:       appId,
-					GroupID:   groupID,
-					Title:     "Valid application update",
-					Endpoints: []datastore.Endpoint{},
-				}
-
-				a.EXPECT().
-					DeleteApplication(gomock.Any(), obj).Times(1).
-					Return(errors.New("failed to delete app endpoint"))
-
 				a.EXPECT().
 					FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
 					Return(obj, nil)
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
-
 				o.EXPECT().
 					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
 					Return([]*datastore.Group{group}, nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					FindDeliveriesByAppID(gomock.Any(), appId, gomock.Any()).Times(1).
+					Return(nil, datastore.PaginationData{}, errors.New("failed"))
 			},
 		},
 	}
@@ -897,15 +1473,22 @@ func Test_applicationHandler_DeleteApp(t *testing.T) {
 			defer ctrl.Finish()
 			app = provideApplication(ctrl)
 
-			url := fmt.Sprintf("/api/v1/applications/%s", tc.appId)
-			req := httptest.NewRequest(http.MethodDelete, url, &bytes.Buffer{})
+			url := fmt.Sprintf("/api/v1/applications/%s/deliveries?page=2&perPage=1", appId)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
 			req.Header.Add("Content-Type", "application/json")
 
 			w := httptest.NewRecorder()
 
+			obj := &datastore.Application{
+				UID:       appId,
+				GroupID:   groupID,
+				Title:     "Valid application",
+				Endpoints: []datastore.Endpoint{},
+			}
+
 			// Arrange Expectations
 			if tc.dbFn != nil {
-				tc.dbFn(app)
+				tc.dbFn(app, obj)
 			}
 
 			err := config.LoadConfig(tc.cfgPath)
@@ -927,7 +1510,6 @@ func Test_applicationHandler_DeleteApp(t *testing.T) {
 			verifyMatch(t, *w)
 		})
 	}
-
 }
 
 func TestApplicationHandler_CreateAppEndpoint(t *testing.T) {
@@ -964,7 +1546,8 @@ func TestApplicationHandler_CreateAppEndpoint(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a.EXPECT().
 					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
@@ -1117,7 +1700,8 @@ func TestApplicationHandler_UpdateAppEndpoint(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
@@ -1565,7 +2149,8 @@ func Test_applicationHandler_DeleteAppEndpoint(t *testing.T) {
 			dbFn: func(app *applicationHandler) {
 				c, _ := app.cache.(*mocks.MockCache)
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId).Times(1)
 
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
@@ -1788,3 +2373,205 @@ func Test_applicationHandler_GetPaginatedApps(t *testing.T) {
 	}
 
 }
+
+func Test_applicationHandler_TransferApp(t *testing.T) {
+	sourceGroup := &datastore.Group{UID: "1234567890", Name: "sendcash-pay"}
+	appId := "12345"
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		username   string
+		body       string
+		statusCode int
+		dbFn       func(app *applicationHandler, obj *datastore.Application)
+	}{
+		{
+			name:       "should_transfer_app",
+			cfgPath:    "./testdata/Auth_Config/basic-convoy.json",
+			username:   "testx",
+			body:       `{"group_id": "abcdef"}`,
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Delete(gomock.Any(), "applications:"+appId)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:"+sourceGroup.UID)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:abcdef")
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(4)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), sourceGroup.UID).Times(1).
+					Return(sourceGroup, nil)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), "abcdef").Times(1).
+					Return(&datastore.Group{UID: "abcdef", Name: "buycoins-api"}, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), appId).Times(1).
+					Return(obj, nil)
+				a.EXPECT().
+					UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					UpdateEventsGroupID(gomock.Any(), appId, "abcdef").Times(1).
+					Return(nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					UpdateEventDeliveriesGroupID(gomock.Any(), appId, "abcdef", []datastore.EventDeliveryStatus{datastore.ProcessingEventStatus}).Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name:       "should_fail_without_access_to_destination_group",
+			cfgPath:    "./testdata/Auth_Config/basic-convoy.json",
+			username:   "testx",
+			body:       `{"group_id": "abcdef"}`,
+			statusCode: http.StatusUnauthorized,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), sourceGroup.UID).Times(1).
+					Return(sourceGroup, nil)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), "abcdef").Times(1).
+					Return(&datastore.Group{UID: "abcdef", Name: "paystack"}, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), appId).Times(1).
+					Return(obj, nil)
+			},
+		},
+		{
+			name:       "should_fail_for_unknown_destination_group",
+			cfgPath:    "./testdata/Auth_Config/basic-convoy.json",
+			username:   "testx",
+			body:       `{"group_id": "abcdef"}`,
+			statusCode: http.StatusNotFound,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), sourceGroup.UID).Times(1).
+					Return(sourceGroup, nil)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), "abcdef").Times(1).
+					Return(nil, datastore.ErrGroupNotFound)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), appId).Times(1).
+					Return(obj, nil)
+			},
+		},
+		{
+			name:       "should_fail_for_app_in_another_group",
+			cfgPath:    "./testdata/Auth_Config/basic-convoy.json",
+			username:   "testx",
+			body:       `{"group_id": "abcdef"}`,
+			statusCode: http.StatusNotFound,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), sourceGroup.UID).Times(1).
+					Return(sourceGroup, nil)
+
+				obj.GroupID = "another-group"
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), appId).Times(1).
+					Return(obj, nil)
+			},
+		},
+		{
+			name:       "should_fail_without_group_id",
+			cfgPath:    "./testdata/Auth_Config/basic-convoy.json",
+			username:   "testx",
+			body:       `{}`,
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler, obj *datastore.Application) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), sourceGroup.UID).Times(1).
+					Return(sourceGroup, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), appId).Times(1).
+					Return(obj, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var app *applicationHandler
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app = provideApplication(ctrl)
+
+			url := fmt.Sprintf("/api/v1/applications/%s/transfer?groupId=%s", appId, sourceGroup.UID)
+			req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(tc.body))
+			req.SetBasicAuth(tc.username, "test")
+			req.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			obj := &datastore.Application{
+				UID:       appId,
+				GroupID:   sourceGroup.UID,
+				Title:     "Valid application",
+				Endpoints: []datastore.Endpoint{},
+			}
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(app, obj)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			w.Body = stripTimestamp(t, "application", w.Body)
+
+			verifyMatch(t, *w)
+		})
+	}
+}
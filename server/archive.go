@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetArchives
+// @Summary Fetch a group's archives
+// @Description This endpoint fetches the manifests of the event archives of a group, most recent first unless sort is asc
+// @Tags Archives
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.ArchiveManifest}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/archives [get]
+func (a *applicationHandler) GetArchives(w http.ResponseWriter, r *http.Request) {
+	pageable := getPageableFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	manifests, paginationData, err := a.archiveService.LoadArchiveManifestsPaged(r.Context(), group, pageable)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Archives fetched successfully",
+		pagedResponse{Content: &manifests, Pagination: &paginationData}, http.StatusOK))
+}
+
+// RestoreArchive
+// @Summary Restore an archive
+// @Description This endpoint re-imports the events and event deliveries of an archive for investigation, it is best effort
+// @Tags Archives
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param archiveID path string true "archive id"
+// @Success 200 {object} serverResponse{data=services.ArchiveRestore}
+// @Failure 400,401,404,502 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /archives/{archiveID}/restore [post]
+func (a *applicationHandler) RestoreArchive(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	manifest, err := a.archiveService.FindArchiveManifestByID(r.Context(), group, chi.URLParam(r, "archiveID"))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	restore, err := a.archiveService.RestoreArchive(r.Context(), group, manifest)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Archive restored successfully", restore, http.StatusOK))
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestApplicationHandler_GetArchives(t *testing.T) {
+	groupID := "1234567890"
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC))
+
+	tt := []struct {
+		name       string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_load_archives",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().
+					LoadArchiveManifestsPaged(gomock.Any(), groupID, gomock.Any()).Times(1).
+					Return([]datastore.ArchiveManifest{
+						{
+							UID:                "archive-1",
+							GroupID:            groupID,
+							Bucket:             "convoy",
+							ObjectKey:          groupID + "/archive-1.ndjson",
+							EventCount:         2,
+							EventDeliveryCount: 3,
+							OldestEventAt:      createdAt,
+							NewestEventAt:      createdAt,
+							CreatedAt:          createdAt,
+						},
+					}, datastore.PaginationData{Total: 1, Page: 1, PerPage: 20, TotalPage: 1}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_load_archives",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().
+					LoadArchiveManifestsPaged(gomock.Any(), groupID, gomock.Any()).Times(1).
+					Return(nil, datastore.PaginationData{}, fmt.Errorf("failed"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().FetchGroupByID(gomock.Any(), groupID).Times(1).Return(&datastore.Group{UID: groupID}, nil)
+
+			tc.dbFn(app)
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/groups/%s/archives", groupID), nil)
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestApplicationHandler_RestoreArchive(t *testing.T) {
+	groupID := "1234567890"
+	manifest := &datastore.ArchiveManifest{UID: "archive-1", GroupID: groupID, ObjectKey: groupID + "/archive-1.ndjson"}
+
+	tt := []struct {
+		name       string
+		archiveID  string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_restore_archive",
+			archiveID:  "archive-1",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().FindArchiveManifestByID(gomock.Any(), "archive-1").Times(1).Return(manifest, nil)
+				a.EXPECT().UpdateArchiveManifest(gomock.Any(), manifest).Times(1).Return(nil)
+
+				s, _ := app.objectStore.(*mocks.MockObjectStore)
+				s.EXPECT().Get(gomock.Any(), manifest.ObjectKey).Times(1).
+					Return([]byte(`{"kind":"event","event":{"uid":"event-1"}}`+"\n"), nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:       "should_fail_to_find_archive",
+			archiveID:  "archive-2",
+			statusCode: http.StatusNotFound,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().FindArchiveManifestByID(gomock.Any(), "archive-2").Times(1).
+					Return(nil, datastore.ErrArchiveManifestNotFound)
+			},
+		},
+		{
+			name:       "should_fail_to_download_archive",
+			archiveID:  "archive-1",
+			statusCode: http.StatusBadGateway,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().FindArchiveManifestByID(gomock.Any(), "archive-1").Times(1).Return(manifest, nil)
+
+				s, _ := app.objectStore.(*mocks.MockObjectStore)
+				s.EXPECT().Get(gomock.Any(), manifest.ObjectKey).Times(1).Return(nil, fmt.Errorf("access denied"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().FetchGroupByID(gomock.Any(), groupID).Times(1).Return(&datastore.Group{UID: groupID}, nil)
+
+			tc.dbFn(app)
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			url := fmt.Sprintf("/api/v1/archives/%s/restore?groupId=%s", tc.archiveID, groupID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	mcache "github.com/frain-dev/convoy/cache/memory"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	badgerStore "github.com/frain-dev/convoy/datastore/badger"
 	mongoStore "github.com/frain-dev/convoy/datastore/mongo"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -34,11 +35,10 @@ func TestGetDashboardSummary(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
 
-	app.groupRepo = mongoStore.NewGroupRepo(db)
-	app.appRepo = mongoStore.NewApplicationRepo(db)
-	app.eventRepo = mongoStore.NewEventRepository(db)
-	app.groupRepo = mongoStore.NewGroupRepo(db)
-	app.cache = mcache.NewMemoryCache()
+	app.groupRepo = db.GroupRepo()
+	app.appRepo = db.AppRepo()
+	app.eventRepo = db.EventRepo()
+	app.cache = mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
 
 	group := &datastore.Group{
 		UID:               uuid.New().String(),
@@ -311,15 +311,30 @@ func getConfig() config.Configuration {
 	}
 }
 
-func getDB(t *testing.T) (*mongo.Database, func()) {
-
-	db, err := mongoStore.New(getConfig())
+// getDB connects to the mongo database at TEST_MONGO_DSN, or to a fresh
+// embedded badger store when TEST_DATASTORE is badger.
+func getDB(t *testing.T) (datastore.DatabaseClient, func()) {
+	err := os.Setenv("TZ", "") // Use UTC by default :)
 	require.NoError(t, err)
 
-	err = os.Setenv("TZ", "") // Use UTC by default :)
+	if config.DatabaseProvider(os.Getenv("TEST_DATASTORE")) == config.BadgerDatabaseProvider {
+		db, err := badgerStore.New(config.Configuration{
+			Database: config.DatabaseConfiguration{
+				Type: config.BadgerDatabaseProvider,
+				Dsn:  t.TempDir(),
+			},
+		})
+		require.NoError(t, err)
+
+		return db, func() {
+			require.NoError(t, db.Disconnect(context.Background()))
+		}
+	}
+
+	db, err := mongoStore.New(getConfig())
 	require.NoError(t, err)
 
-	return db.Client().(*mongo.Database), func() {
+	return db, func() {
 		require.NoError(t, db.Client().(*mongo.Database).Drop(context.Background()))
 		require.NoError(t, db.Disconnect(context.Background()))
 	}
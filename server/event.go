@@ -1,40 +1,76 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	log "github.com/sirupsen/logrus"
 )
 
 // CreateAppEvent
 // @Summary Create app event
-// @Description This endpoint creates an app event
+// @Description This endpoint creates an app event. When the group has a deduplication window, an identical event sent within it returns the existing event marked as a duplicate. So does an event sent again with the same idempotency key within the group's idempotency window
 // @Tags Events
 // @Accept  json
 // @Produce  json
 // @Param groupId query string true "group id"
 // @Param event body models.Event true "Event Details"
 // @Success 200 {object} serverResponse{data=datastore.Event{data=Stub}}
-// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Failure 400,401,413,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /events [post]
 func (a *applicationHandler) CreateAppEvent(w http.ResponseWriter, r *http.Request) {
+	g := getGroupFromContext(r.Context())
+
+	// the group's limit is checked before the body is decoded, so an
+	// oversized payload is never read past it
+	if g.Config != nil && g.Config.MaxEventPayloadSizeBytes > 0 {
+		limit := int64(g.Config.MaxEventPayloadSizeBytes)
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+		if err != nil {
+			_ = render.Render(w, r, newErrorResponse(err.Error(), readErrorStatus(err)))
+			return
+		}
+
+		if int64(len(body)) > limit {
+			_ = render.Render(w, r, newErrorResponse("payload exceeds group limit", http.StatusRequestEntityTooLarge))
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
 	var newMessage models.Event
 	err := util.ReadJSON(r, &newMessage)
 	if err != nil {
-		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		_ = render.Render(w, r, newErrorResponse(err.Error(), readErrorStatus(err)))
 		return
 	}
 
-	g := getGroupFromContext(r.Context())
+	limit, err := getEventPayloadSizeLimit()
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusInternalServerError))
+		return
+	}
+
+	if len(newMessage.Data) > limit {
+		msg := fmt.Sprintf("event payload of %d bytes is larger than the limit of %d bytes", len(newMessage.Data), limit)
+		_ = render.Render(w, r, newErrorResponse(msg, http.StatusRequestEntityTooLarge))
+		return
+	}
 
 	event, err := a.eventService.CreateAppEvent(r.Context(), &newMessage, g)
 	if err != nil {
@@ -42,6 +78,11 @@ func (a *applicationHandler) CreateAppEvent(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if event.Duplicate {
+		_ = render.Render(w, r, newServerResponse("Duplicate of an existing event, no event was created", event, http.StatusOK))
+		return
+	}
+
 	_ = render.Render(w, r, newServerResponse("App event created successfully", event, http.StatusCreated))
 }
 
@@ -114,18 +155,21 @@ func (a *applicationHandler) ResendEventDelivery(w http.ResponseWriter, r *http.
 // @Accept json
 // @Produce json
 // @Param groupId query string true "group id"
-// @Param delivery ids body Stub{ids=[]string} true "event delivery ids"
+// @Param appId query string false "application id"
+// @Param eventId query string false "event id"
+// @Param endpointId query string false "endpoint id"
+// @Param status query []string false "status, repeated or comma separated"
+// @Param tag query string false "tag"
+// @Param deliveryIds body Stub{ids=[]string} true "event delivery ids"
 // @Success 200 {object} serverResponse{data=Stub}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /eventdeliveries/batchretry [post]
 func (a *applicationHandler) BatchRetryEventDelivery(w http.ResponseWriter, r *http.Request) {
-	status := make([]datastore.EventDeliveryStatus, 0)
-
-	for _, s := range r.URL.Query()["status"] {
-		if !util.IsStringEmpty(s) {
-			status = append(status, datastore.EventDeliveryStatus(s))
-		}
+	status, err := getEventDeliveryStatusFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
 	}
 
 	searchParams, err := getSearchParams(r)
@@ -135,10 +179,11 @@ func (a *applicationHandler) BatchRetryEventDelivery(w http.ResponseWriter, r *h
 	}
 
 	f := &datastore.Filter{
-		Group:   getGroupFromContext(r.Context()),
-		AppID:   r.URL.Query().Get("appId"),
-		EventID: r.URL.Query().Get("eventId"),
-		Status:  status,
+		Group:      getGroupFromContext(r.Context()),
+		AppID:      r.URL.Query().Get("appId"),
+		EventID:    r.URL.Query().Get("eventId"),
+		EndpointID: r.URL.Query().Get("endpointId"),
+		Status:     status,
 		Pageable: datastore.Pageable{
 			Page:    0,
 			PerPage: 1000000000000, // large number so we get everything in most cases
@@ -156,6 +201,61 @@ func (a *applicationHandler) BatchRetryEventDelivery(w http.ResponseWriter, r *h
 	_ = render.Render(w, r, newServerResponse(fmt.Sprintf("%d successful, %d failed", successes, failures), nil, http.StatusOK))
 }
 
+// RetryAllFailedEventDeliveries
+// @Summary Retry all failed event deliveries
+// @Description This endpoint schedules every failed event delivery in a group to be sent again
+// @Tags EventDelivery
+// @Accept json
+// @Produce json
+// @Param groupID path string true "group id"
+// @Success 200 {object} serverResponse{data=Stub{num=integer}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/event-deliveries/retry-all [post]
+func (a *applicationHandler) RetryAllFailedEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	count, err := a.eventService.RetryAllFailed(r.Context(), getGroupFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse(fmt.Sprintf("%d event deliveries scheduled for retry", count), map[string]interface{}{"num": count}, http.StatusOK))
+}
+
+// ForceCompleteStuckEventDeliveries
+// @Summary Force complete stuck event deliveries
+// @Description This endpoint fails the event deliveries of every group that have been processing for longer than max_age, e.g because the worker sending them crashed
+// @Tags EventDelivery
+// @Accept json
+// @Produce json
+// @Param body body models.ForceCompleteStuckDeliveries true "max age of the deliveries, e.g 30m"
+// @Success 200 {object} serverResponse{data=Stub{num=integer}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /admin/event-deliveries/force-complete-stuck [post]
+func (a *applicationHandler) ForceCompleteStuckEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	var body models.ForceCompleteStuckDeliveries
+	err := util.ReadJSON(r, &body)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	maxAge, err := time.ParseDuration(body.MaxAge)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse("please provide a valid max age", http.StatusBadRequest))
+		return
+	}
+
+	count, err := a.eventService.ForceCompleteStuckDeliveries(r.Context(), maxAge)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse(fmt.Sprintf("%d stuck event deliveries failed", count), map[string]interface{}{"num": count}, http.StatusOK))
+}
+
 // CountAffectedEventDeliveries
 // @Summary Count affected eventDeliveries
 // @Description This endpoint counts app events that will be affected by a batch retry operation
@@ -164,8 +264,12 @@ func (a *applicationHandler) BatchRetryEventDelivery(w http.ResponseWriter, r *h
 // @Produce  json
 // @Param appId query string false "application id"
 // @Param groupId query string true "group Id"
+// @Param eventId query string false "event id"
+// @Param endpointId query string false "endpoint id"
+// @Param status query []string false "status, repeated or comma separated"
 // @Param startDate query string false "start date"
 // @Param endDate query string false "end date"
+// @Param tag query string false "tag"
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
 // @Param sort query string false "sort order"
@@ -174,11 +278,10 @@ func (a *applicationHandler) BatchRetryEventDelivery(w http.ResponseWriter, r *h
 // @Security ApiKeyAuth
 // @Router /eventdeliveries/countbatchretryevents [get]
 func (a *applicationHandler) CountAffectedEventDeliveries(w http.ResponseWriter, r *http.Request) {
-	status := make([]datastore.EventDeliveryStatus, 0)
-	for _, s := range r.URL.Query()["status"] {
-		if !util.IsStringEmpty(s) {
-			status = append(status, datastore.EventDeliveryStatus(s))
-		}
+	status, err := getEventDeliveryStatusFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
 	}
 
 	searchParams, err := getSearchParams(r)
@@ -191,6 +294,7 @@ func (a *applicationHandler) CountAffectedEventDeliveries(w http.ResponseWriter,
 		Group:        getGroupFromContext(r.Context()),
 		AppID:        r.URL.Query().Get("appId"),
 		EventID:      r.URL.Query().Get("eventId"),
+		EndpointID:   r.URL.Query().Get("endpointId"),
 		Status:       status,
 		SearchParams: searchParams,
 	}
@@ -211,7 +315,7 @@ func (a *applicationHandler) CountAffectedEventDeliveries(w http.ResponseWriter,
 // @Accept json
 // @Produce json
 // @Param groupId query string true "group Id"
-// @Param delivery ids body Stub{ids=[]string} true "event delivery ids"
+// @Param deliveryIds body Stub{ids=[]string} true "event delivery ids"
 // @Success 200 {object} serverResponse{data=Stub}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
@@ -242,13 +346,18 @@ func (a *applicationHandler) ForceResendEventDeliveries(w http.ResponseWriter, r
 // @Produce  json
 // @Param appId query string false "application id"
 // @Param groupId query string true "group id"
+// @Param query query string false "text to search for in the event payload"
+// @Param metadata query object false "metadata to filter by, passed as metadata[key]=value"
 // @Param startDate query string false "start date"
 // @Param endDate query string false "end date"
+// @Param tag query string false "tag"
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
 // @Param sort query string false "sort order"
+// @Param cursor query string false "cursor to page from, replaces page"
+// @Param direction query string false "direction to page from the cursor in, next or prev"
 // @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.Event{data=Stub}}}
-// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Failure 400,401,408,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /events [get]
 func (a *applicationHandler) GetEventsPaged(w http.ResponseWriter, r *http.Request) {
@@ -261,13 +370,28 @@ func (a *applicationHandler) GetEventsPaged(w http.ResponseWriter, r *http.Reque
 	f := &datastore.Filter{
 		Group:        getGroupFromContext(r.Context()),
 		AppID:        r.URL.Query().Get("appId"),
+		Query:        r.URL.Query().Get("query"),
+		Metadata:     getEventMetadataFromQuery(r),
 		Pageable:     getPageableFromContext(r.Context()),
 		SearchParams: searchParams,
 	}
 
-	m, paginationData, err := a.eventService.GetEventsPaged(r.Context(), f)
+	cursor, cursored, err := getCursorFromQuery(r)
 	if err != nil {
-		_ = render.Render(w, r, newErrorResponse("an error occurred while fetching app events", http.StatusInternalServerError))
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var m []datastore.Event
+	var paginationData datastore.PaginationData
+	if cursored {
+		f.Cursor = cursor
+		m, paginationData, err = a.eventService.GetEventsCursored(r.Context(), f)
+	} else {
+		m, paginationData, err = a.eventService.GetEventsPaged(r.Context(), f)
+	}
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
 	}
 
@@ -284,22 +408,26 @@ func (a *applicationHandler) GetEventsPaged(w http.ResponseWriter, r *http.Reque
 // @Param appId query string false "application id"
 // @Param groupId query string true "group id"
 // @Param eventId query string false "event id"
+// @Param endpointId query string false "endpoint id"
 // @Param startDate query string false "start date"
 // @Param endDate query string false "end date"
+// @Param tag query string false "tag"
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
 // @Param sort query string false "sort order"
-// @Param status query []string false "status"
+// @Param status query []string false "status, repeated or comma separated"
+// @Param skip_count query boolean false "skip counting the deliveries, total and totalPage come back as -1"
+// @Param cursor query string false "cursor to page from, replaces page"
+// @Param direction query string false "direction to page from the cursor in, next or prev"
 // @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.EventDelivery{data=Stub}}}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /eventdeliveries [get]
 func (a *applicationHandler) GetEventDeliveriesPaged(w http.ResponseWriter, r *http.Request) {
-	status := make([]datastore.EventDeliveryStatus, 0)
-	for _, s := range r.URL.Query()["status"] {
-		if !util.IsStringEmpty(s) {
-			status = append(status, datastore.EventDeliveryStatus(s))
-		}
+	status, err := getEventDeliveryStatusFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
 	}
 
 	searchParams, err := getSearchParams(r)
@@ -312,12 +440,26 @@ func (a *applicationHandler) GetEventDeliveriesPaged(w http.ResponseWriter, r *h
 		Group:        getGroupFromContext(r.Context()),
 		AppID:        r.URL.Query().Get("appId"),
 		EventID:      r.URL.Query().Get("eventId"),
+		EndpointID:   r.URL.Query().Get("endpointId"),
 		Status:       status,
 		Pageable:     getPageableFromContext(r.Context()),
 		SearchParams: searchParams,
 	}
 
-	ed, paginationData, err := a.eventService.GetEventDeliveriesPaged(r.Context(), f)
+	cursor, cursored, err := getCursorFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var ed []datastore.EventDelivery
+	var paginationData datastore.PaginationData
+	if cursored {
+		f.Cursor = cursor
+		ed, paginationData, err = a.eventService.GetEventDeliveriesCursored(r.Context(), f)
+	} else {
+		ed, paginationData, err = a.eventService.GetEventDeliveriesPaged(r.Context(), f)
+	}
 	if err != nil {
 		_ = render.Render(w, r, newErrorResponse("an error occurred while fetching event deliveries", http.StatusInternalServerError))
 		return
@@ -327,6 +469,308 @@ func (a *applicationHandler) GetEventDeliveriesPaged(w http.ResponseWriter, r *h
 		pagedResponse{Content: &ed, Pagination: &paginationData}, http.StatusOK))
 }
 
+// GetEventsCount
+// @Summary Count app events
+// @Description This endpoint counts the app events matching the same filters as the list endpoint, the date range must not be wider than the configured maximum
+// @Tags Events
+// @Accept  json
+// @Produce  json
+// @Param appId query string false "application id"
+// @Param groupId query string true "group id"
+// @Param startDate query string true "start date"
+// @Param endDate query string false "end date"
+// @Param tag query string false "tag"
+// @Success 200 {object} serverResponse{data=Stub{count=integer}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /events/count [get]
+func (a *applicationHandler) GetEventsCount(w http.ResponseWriter, r *http.Request) {
+	searchParams, err := a.getCountSearchParams(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	f := &datastore.Filter{
+		Group:        getGroupFromContext(r.Context()),
+		AppID:        r.URL.Query().Get("appId"),
+		SearchParams: searchParams,
+	}
+
+	count, err := a.eventService.CountEvents(r.Context(), f)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("App events counted successfully", map[string]interface{}{"count": count}, http.StatusOK))
+}
+
+// GetEventDeliveriesCount
+// @Summary Count event deliveries
+// @Description This endpoint counts the event deliveries matching the same filters as the list endpoint, the date range must not be wider than the configured maximum
+// @Tags EventDelivery
+// @Accept json
+// @Produce json
+// @Param appId query string false "application id"
+// @Param groupId query string true "group id"
+// @Param eventId query string false "event id"
+// @Param endpointId query string false "endpoint id"
+// @Param startDate query string true "start date"
+// @Param endDate query string false "end date"
+// @Param tag query string false "tag"
+// @Param status query []string false "status, repeated or comma separated"
+// @Success 200 {object} serverResponse{data=Stub{count=integer}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /eventdeliveries/count [get]
+func (a *applicationHandler) GetEventDeliveriesCount(w http.ResponseWriter, r *http.Request) {
+	status, err := getEventDeliveryStatusFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	searchParams, err := a.getCountSearchParams(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	f := &datastore.Filter{
+		Group:        getGroupFromContext(r.Context()),
+		AppID:        r.URL.Query().Get("appId"),
+		EventID:      r.URL.Query().Get("eventId"),
+		EndpointID:   r.URL.Query().Get("endpointId"),
+		Status:       status,
+		SearchParams: searchParams,
+	}
+
+	count, err := a.eventService.CountAffectedEventDeliveries(r.Context(), f)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event deliveries counted successfully", map[string]interface{}{"count": count}, http.StatusOK))
+}
+
+// GetEventDeliveryTree
+// @Summary Get an event with its deliveries
+// @Description This endpoint fetches an event along with a page of its deliveries and the current status of their endpoints, delivery attempts are included when expand is attempts
+// @Tags EventDelivery
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param eventID path string true "event id"
+// @Param expand query string false "set to attempts to include delivery attempts"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Success 200 {object} serverResponse{data=models.EventDeliveryTree}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/deliveries [get]
+func (a *applicationHandler) GetEventDeliveryTree(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Get()
+	if err != nil {
+		log.WithError(err).Error("failed to load configuration")
+		_ = render.Render(w, r, newErrorResponse("failed to load configuration", http.StatusInternalServerError))
+		return
+	}
+
+	expandAttempts := r.URL.Query().Get("expand") == "attempts"
+
+	tree, err := a.eventService.GetEventDeliveryTree(r.Context(), getEventFromContext(r.Context()),
+		getGroupFromContext(r.Context()), getPageableFromContext(r.Context()), expandAttempts, int(cfg.MaxResponseSize))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event deliveries fetched successfully", tree, http.StatusOK))
+}
+
+// StreamEventDeliveries
+// @Summary Stream event delivery updates
+// @Description This endpoint streams event deliveries of a group as server-sent events whenever they change to Success, Failure or Retry
+// @Tags EventDelivery
+// @Produce text/event-stream
+// @Param groupID path string true "group id"
+// @Success 200 {string} string
+// @Failure 401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/events/stream [get]
+func (a *applicationHandler) StreamEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	a.streamEventDeliveries(w, r, func(delivery datastore.EventDelivery) bool {
+		switch delivery.Status {
+		case datastore.SuccessEventStatus, datastore.FailureEventStatus, datastore.RetryEventStatus:
+			return true
+		default:
+			return false
+		}
+	}, false)
+}
+
+// StreamAppEventDeliveries
+// @Summary Stream event delivery updates of an app
+// @Description This endpoint streams the event deliveries of an app as server-sent events named after the transition they went through: created, attempted (an attempt failed and will be retried), success or failure
+// @Tags EventDelivery
+// @Produce text/event-stream
+// @Param appID path string true "application id"
+// @Success 200 {string} string
+// @Failure 401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /portal/apps/{appID}/eventdeliveries/stream [get]
+func (a *applicationHandler) StreamAppEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	app := getApplicationFromContext(r.Context())
+
+	// the app in context is the one the portal key is scoped to
+	if chi.URLParam(r, "appID") != app.UID {
+		_ = render.Render(w, r, newErrorResponse("unauthorized access", http.StatusUnauthorized))
+		return
+	}
+
+	a.streamEventDeliveries(w, r, func(delivery datastore.EventDelivery) bool {
+		return delivery.AppMetadata.UID == app.UID
+	}, true)
+}
+
+// streamEventDeliveries writes the updates to deliveries of the group in
+// context that satisfy include as server-sent events until the client
+// goes away, the server shuts down or the stream has been open for
+// streamMaxDuration. Each event is named after its delivery transition
+// when named is set. The server write timeout applies to the whole
+// stream, so it has to be longer than streamMaxDuration.
+func (a *applicationHandler) streamEventDeliveries(w http.ResponseWriter, r *http.Request, include func(datastore.EventDelivery) bool, named bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		_ = render.Render(w, r, newErrorResponse("streaming is not supported", http.StatusInternalServerError))
+		return
+	}
+
+	updates, unsubscribe := a.deliveryUpdates.Subscribe(getGroupFromContext(r.Context()).UID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(a.streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	expiry := time.NewTimer(a.streamMaxDuration)
+	defer expiry.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-a.shutdown.Done():
+			return
+		case <-expiry.C:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case delivery := <-updates:
+			if !include(delivery) {
+				continue
+			}
+
+			data, err := json.Marshal(delivery)
+			if err != nil {
+				log.WithError(err).Error("failed to marshal event delivery update")
+				continue
+			}
+
+			if named {
+				if _, err := fmt.Fprintf(w, "event: %s\n", deliveryTransition(delivery.Status)); err != nil {
+					return
+				}
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+// deliveryTransition names the transition a delivery in status went
+// through to get there.
+func deliveryTransition(status datastore.EventDeliveryStatus) string {
+	switch status {
+	case datastore.RetryEventStatus:
+		return "attempted"
+	case datastore.SuccessEventStatus:
+		return "success"
+	case datastore.FailureEventStatus:
+		return "failure"
+	default:
+		return "created"
+	}
+}
+
+// getEventDeliveryStatusFromQuery collects the statuses passed either as
+// repeated status params or as a comma separated list.
+func getEventDeliveryStatusFromQuery(r *http.Request) ([]datastore.EventDeliveryStatus, error) {
+	status := make([]datastore.EventDeliveryStatus, 0)
+	for _, s := range r.URL.Query()["status"] {
+		for _, v := range strings.Split(s, ",") {
+			v = strings.TrimSpace(v)
+			if util.IsStringEmpty(v) {
+				continue
+			}
+
+			st := datastore.EventDeliveryStatus(v)
+			if !st.IsValid() {
+				return nil, fmt.Errorf("invalid event delivery status: %s", v)
+			}
+
+			status = append(status, st)
+		}
+	}
+
+	return status, nil
+}
+
+// getCursorFromQuery reads the cursor and direction of a cursor paginated
+// list, cursored reports whether either was given. A direction alone asks
+// for the first page.
+func getCursorFromQuery(r *http.Request) (cursor *datastore.Cursor, cursored bool, err error) {
+	rawCursor := r.URL.Query().Get("cursor")
+	direction := r.URL.Query().Get("direction")
+
+	if util.IsStringEmpty(rawCursor) && util.IsStringEmpty(direction) {
+		return nil, false, nil
+	}
+
+	if !util.IsStringEmpty(direction) && direction != "next" && direction != "prev" {
+		return nil, false, errors.New("direction must be either next or prev")
+	}
+
+	if util.IsStringEmpty(rawCursor) {
+		return nil, true, nil
+	}
+
+	cursor, err = datastore.DecodeCursor(rawCursor)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !util.IsStringEmpty(direction) {
+		cursor.Backward = direction == "prev"
+	}
+
+	return cursor, true, nil
+}
+
 func getSearchParams(r *http.Request) (datastore.SearchParams, error) {
 	var searchParams datastore.SearchParams
 	format := "2006-01-02T15:04:05"
@@ -363,6 +807,24 @@ func getSearchParams(r *http.Request) (datastore.SearchParams, error) {
 	searchParams = datastore.SearchParams{
 		CreatedAtStart: startT.Unix(),
 		CreatedAtEnd:   endT.Unix(),
+		Tag:            r.URL.Query().Get("tag"),
+	}
+
+	return searchParams, nil
+}
+
+// getCountSearchParams is getSearchParams for the count endpoints, which
+// refuse date ranges wider than maxCountDateRange. A missing startDate
+// makes the range unbounded, so it is refused too.
+func (a *applicationHandler) getCountSearchParams(r *http.Request) (datastore.SearchParams, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return searchParams, err
+	}
+
+	days := int64(a.maxCountDateRange / (24 * time.Hour))
+	if searchParams.CreatedAtEnd-searchParams.CreatedAtStart > int64(a.maxCountDateRange/time.Second) {
+		return searchParams, fmt.Errorf("the date range cannot be wider than %d days, provide a startDate and endDate", days)
 	}
 
 	return searchParams, nil
@@ -389,3 +851,44 @@ func findMessageDeliveryAttempt(attempts *[]datastore.DeliveryAttempt, id string
 	}
 	return nil, datastore.ErrEventDeliveryAttemptNotFound
 }
+
+// getEventMetadataFromQuery collects the metadata[key]=value params.
+func getEventMetadataFromQuery(r *http.Request) map[string]string {
+	metadata := map[string]string{}
+	for k, v := range r.URL.Query() {
+		if !strings.HasPrefix(k, "metadata[") || !strings.HasSuffix(k, "]") {
+			continue
+		}
+
+		key := strings.TrimSuffix(strings.TrimPrefix(k, "metadata["), "]")
+		metadata[key] = v[0]
+	}
+
+	return metadata
+}
+
+// readErrorStatus is the status a failure to read a request body is
+// answered with, 413 when the body is too large and 400 otherwise.
+func readErrorStatus(err error) int {
+	if errors.Is(err, util.ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+// getEventPayloadSizeLimit returns the largest event payload, in bytes,
+// the server accepts.
+func getEventPayloadSizeLimit() (int, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return 0, err
+	}
+
+	limit := int(cfg.MaxEventPayloadSize)
+	if limit == 0 {
+		limit = config.DefaultMaxEventPayloadSize
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// CreateEventBridgeRule
+// @Summary Create an event bridge rule
+// @Description This endpoint creates a rule forwarding the events of a group to the apps of another group once they are delivered
+// @Tags EventBridge
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param rule body models.EventBridgeRule true "Event bridge rule details"
+// @Success 201 {object} serverResponse{data=datastore.EventBridgeRule}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /event-bridges [post]
+func (a *applicationHandler) CreateEventBridgeRule(w http.ResponseWriter, r *http.Request) {
+	var newRule models.EventBridgeRule
+	err := util.ReadJSON(r, &newRule)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = services.Validate(newRule)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	group := getGroupFromContext(r.Context())
+
+	dest, err := a.groupRepo.FetchGroupByID(r.Context(), newRule.DestGroupID)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse("failed to fetch group by id", http.StatusNotFound))
+		return
+	}
+
+	// the role was checked against the source group by the router, the
+	// caller needs the same access to the group events are forwarded to
+	authUser := getAuthUserFromContext(r.Context())
+	err = authorizeGroupAccess(authUser, dest, authUser.Role.Type)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusUnauthorized))
+		return
+	}
+
+	rule, err := a.eventBridgeService.CreateEventBridgeRule(r.Context(), group, dest, &newRule)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event bridge rule created successfully", rule, http.StatusCreated))
+}
+
+// GetEventBridgeRules
+// @Summary Fetch a group's event bridge rules
+// @Description This endpoint fetches the rules forwarding the events of a group
+// @Tags EventBridge
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Success 200 {object} serverResponse{data=[]datastore.EventBridgeRule}
+// @Failure 400,401 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /event-bridges [get]
+func (a *applicationHandler) GetEventBridgeRules(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	rules, err := a.eventBridgeService.LoadEventBridgeRules(r.Context(), group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event bridge rules fetched successfully", rules, http.StatusOK))
+}
+
+// DeleteEventBridgeRule
+// @Summary Delete an event bridge rule
+// @Description This endpoint deletes an event bridge rule, events are no longer forwarded by it
+// @Tags EventBridge
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param ruleID path string true "event bridge rule id"
+// @Success 200 {object} serverResponse{data=Stub}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /event-bridges/{ruleID} [delete]
+func (a *applicationHandler) DeleteEventBridgeRule(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	err := a.eventBridgeService.DeleteEventBridgeRule(r.Context(), group, chi.URLParam(r, "ruleID"))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event bridge rule deleted successfully", nil, http.StatusOK))
+}
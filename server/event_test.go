@@ -1,20 +1,28 @@
 package server
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pubsub"
+	"github.com/stretchr/testify/require"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestApplicationHandler_CreateAppEvent(t *testing.T) {
@@ -452,6 +460,118 @@ func TestApplicationHandler_CreateAppEvent(t *testing.T) {
 	}
 }
 
+// payloadLimitedGroup returns a group whose events are limited to limit bytes.
+func payloadLimitedGroup(limit int) *datastore.Group {
+	return &datastore.Group{
+		UID: "1234567890",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type: config.StrategyProvider("default"),
+				Default: datastore.DefaultStrategyConfiguration{
+					IntervalSeconds: 60,
+					RetryLimit:      1,
+				},
+			},
+			MaxEventPayloadSizeBytes: limit,
+		},
+	}
+}
+
+func TestEventIngestHandler_RejectsOversizedPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	body := `{"app_id": "12345", "event_type": "test", "data": {"name": "` + strings.Repeat("a", 32) + `"}}`
+	group := payloadLimitedGroup(len(body) - 1)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	if err != nil {
+		t.Errorf("Failed to load config file: %v", err)
+	}
+	initRealmChain(t, app.apiKeyRepo)
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	o.EXPECT().
+		LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+		Return([]*datastore.Group{group}, nil)
+
+	// the service is never reached, so no app lookup is expected
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", strings.NewReader(body))
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router := buildRoutes(app)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Want status '%d', got '%d'", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	verifyMatch(t, *w)
+}
+
+func TestEventIngestHandler_AcceptsPayloadAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	body := `{"app_id": "12345", "event_type": "test", "data": {"name": "` + strings.Repeat("a", 32) + `"}}`
+	group := payloadLimitedGroup(len(body))
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	if err != nil {
+		t.Errorf("Failed to load config file: %v", err)
+	}
+	initRealmChain(t, app.apiKeyRepo)
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	o.EXPECT().
+		LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+		Return([]*datastore.Group{group}, nil)
+
+	a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().
+		FindApplicationByID(gomock.Any(), gomock.Any()).Times(1).
+		Return(&datastore.Application{
+			UID:     "12345",
+			GroupID: group.UID,
+			Title:   "Valid application",
+			Endpoints: []datastore.Endpoint{
+				{
+					TargetURL: "http://localhost",
+					Status:    datastore.ActiveEndpointStatus,
+				},
+			},
+		}, nil)
+
+	q, _ := app.createEventQueue.(*mocks.MockQueuer)
+	q.EXPECT().WriteEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", strings.NewReader(body))
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router := buildRoutes(app)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Want status '%d', got '%d'", http.StatusCreated, w.Code)
+	}
+}
+
 func Test_resendEventDelivery(t *testing.T) {
 
 	var app *applicationHandler
@@ -838,7 +958,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -930,7 +1050,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -1009,7 +1129,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -1088,7 +1208,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -1162,7 +1282,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -1245,7 +1365,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(msg, datastore.PaginationData{
 						Total:     5,
 						Page:      1,
@@ -1295,7 +1415,7 @@ func TestApplicationHandler_BatchRetryEventDelivery(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(nil, datastore.PaginationData{}, errors.New("failed to load events"))
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
@@ -1375,7 +1495,7 @@ func TestApplicationHandler_CountAffectedEventDeliveries(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(int64(10), nil)
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
@@ -1404,9 +1524,63 @@ func TestApplicationHandler_CountAffectedEventDeliveries(t *testing.T) {
 
 				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				e.EXPECT().
-					CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 					Return(int64(0), errors.New("failed to count deliveries"))
 
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_count_affected_event_deliveries_by_endpoint_and_statuses",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			urlQuery:   "?endpointId=ep-1&status=Failure,Retry",
+			method:     http.MethodGet,
+			statusCode: http.StatusOK,
+			body:       strings.NewReader(``),
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				ctx := r.Context()
+
+				ctx = setGroupInContext(ctx, group)
+				*r = *r.WithContext(ctx)
+
+				c, _ := app.cache.(*mocks.MockCache)
+
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				e.EXPECT().
+					CountEventDeliveries(gomock.Any(), group.UID, "", "", "ep-1",
+						[]datastore.EventDeliveryStatus{datastore.FailureEventStatus, datastore.RetryEventStatus}, gomock.Any()).Times(1).
+					Return(int64(2), nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_count_with_invalid_status",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			urlQuery:   "?status=Failure,Unknown",
+			method:     http.MethodGet,
+			statusCode: http.StatusBadRequest,
+			body:       strings.NewReader(``),
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				ctx := r.Context()
+
+				ctx = setGroupInContext(ctx, group)
+				*r = *r.WithContext(ctx)
+
+				c, _ := app.cache.(*mocks.MockCache)
+
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
 				o.EXPECT().
 					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
@@ -1447,6 +1621,132 @@ func TestApplicationHandler_CountAffectedEventDeliveries(t *testing.T) {
 	}
 }
 
+func TestApplicationHandler_GetEventsCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	group := &datastore.Group{Name: "default-group", UID: "1234567890"}
+	searchParams := datastore.SearchParams{
+		CreatedAtStart: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		CreatedAtEnd:   time.Date(2022, time.January, 31, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	tests := []struct {
+		name       string
+		cfgPath    string
+		url        string
+		statusCode int
+		dbFn       func(*http.Request, *applicationHandler)
+	}{
+		{
+			name:       "should_count_events_successfully",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/events/count?appId=123&startDate=2022-01-01T00:00:00&endDate=2022-01-31T00:00:00",
+			statusCode: http.StatusOK,
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					CountEvents(gomock.Any(), group.UID, "123", searchParams).Times(1).
+					Return(int64(42), nil)
+			},
+		},
+		{
+			name:       "should_fail_to_count_events_without_start_date",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/events/count?appId=123",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+		{
+			name:       "should_count_event_deliveries_successfully",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/eventdeliveries/count?endpointId=ep-1&status=Failure&startDate=2022-01-01T00:00:00&endDate=2022-01-31T00:00:00",
+			statusCode: http.StatusOK,
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+
+				e, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				e.EXPECT().
+					CountEventDeliveries(gomock.Any(), group.UID, "", "", "ep-1",
+						[]datastore.EventDeliveryStatus{datastore.FailureEventStatus}, searchParams).Times(1).
+					Return(int64(7), nil)
+			},
+		},
+		{
+			name:       "should_fail_to_count_event_deliveries_over_a_wide_date_range",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			url:        "/api/v1/eventdeliveries/count?startDate=2021-01-01T00:00:00&endDate=2022-01-31T00:00:00",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(r *http.Request, app *applicationHandler) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				o.EXPECT().
+					LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+					Return([]*datastore.Group{group}, nil)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			if tc.dbFn != nil {
+				tc.dbFn(req, app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
 func TestApplicationHandler_ForceResendEventDelivery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1936,3 +2236,328 @@ func TestApplicationHandler_ForceResendEventDelivery(t *testing.T) {
 		})
 	}
 }
+
+func TestEventStreamHandler_ReceivesDeliveryUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := provideApplication(ctrl)
+	app.streamHeartbeatInterval = 10 * time.Millisecond
+
+	groupID := "1234567890"
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().
+		FetchGroupByID(gomock.Any(), groupID).Times(1).
+		Return(&datastore.Group{UID: groupID, Name: "sendcash-pay"}, nil)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	srv := httptest.NewServer(buildRoutes(app))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/groups/%s/events/stream", srv.URL, groupID), nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// the handler subscribes before writing the headers, so the update
+	// can't be published ahead of the subscription
+	go func() {
+		pubsub.Publish(datastore.EventDelivery{UID: "other", Status: datastore.SuccessEventStatus, AppMetadata: &datastore.AppMetadata{GroupID: "other-group"}})
+		pubsub.Publish(datastore.EventDelivery{UID: "delivery-1", Status: datastore.SuccessEventStatus, AppMetadata: &datastore.AppMetadata{GroupID: groupID}})
+	}()
+
+	var sawPing, sawUpdate bool
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == ": ping":
+			sawPing = true
+		case strings.HasPrefix(line, "data: "):
+			var delivery datastore.EventDelivery
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &delivery))
+			require.Equal(t, "delivery-1", delivery.UID)
+			require.Equal(t, datastore.SuccessEventStatus, delivery.Status)
+			sawUpdate = true
+		}
+
+		if sawPing && sawUpdate {
+			return
+		}
+	}
+
+	t.Fatalf("stream ended before a delivery update and heartbeat were received: %v", scanner.Err())
+}
+
+func Test_getEventMetadataFromQuery(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?groupId=123&metadata[customer_id]=cus_123&metadata[region]=eu&metadata=x&query=abc", nil)
+
+	metadata := getEventMetadataFromQuery(req)
+	require.Equal(t, map[string]string{"customer_id": "cus_123", "region": "eu"}, metadata)
+}
+
+func Test_getEventDeliveryStatusFromQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []datastore.EventDeliveryStatus
+		wantErr bool
+	}{
+		{name: "no_status", query: "", want: []datastore.EventDeliveryStatus{}},
+		{name: "single_status", query: "status=Failure", want: []datastore.EventDeliveryStatus{datastore.FailureEventStatus}},
+		{name: "comma_separated", query: "status=Failure,Retry", want: []datastore.EventDeliveryStatus{datastore.FailureEventStatus, datastore.RetryEventStatus}},
+		{name: "repeated", query: "status=Failure&status=Retry", want: []datastore.EventDeliveryStatus{datastore.FailureEventStatus, datastore.RetryEventStatus}},
+		{name: "invalid_status", query: "status=Failure,Lost", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/eventdeliveries?"+tc.query, nil)
+
+			status, err := getEventDeliveryStatusFromQuery(req)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, status)
+		})
+	}
+}
+
+func Test_getCursorFromQuery(t *testing.T) {
+	c := (&datastore.Cursor{CreatedAt: 1000, UID: "e1"}).Encode()
+
+	tests := []struct {
+		name         string
+		query        string
+		wantCursor   *datastore.Cursor
+		wantCursored bool
+		wantErr      bool
+	}{
+		{name: "page_paginated", query: "page=2"},
+		{name: "first_page", query: "direction=next", wantCursored: true},
+		{name: "next_page", query: "cursor=" + c, wantCursor: &datastore.Cursor{CreatedAt: 1000, UID: "e1"}, wantCursored: true},
+		{name: "prev_page", query: "cursor=" + c + "&direction=prev", wantCursor: &datastore.Cursor{CreatedAt: 1000, UID: "e1", Backward: true}, wantCursored: true},
+		{name: "invalid_direction", query: "cursor=" + c + "&direction=up", wantErr: true},
+		{name: "invalid_cursor", query: "cursor=abc", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events?"+tc.query, nil)
+
+			cursor, cursored, err := getCursorFromQuery(req)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCursor, cursor)
+			require.Equal(t, tc.wantCursored, cursored)
+		})
+	}
+}
+
+func TestApplicationHandler_GetEventDeliveryTree(t *testing.T) {
+	groupID := "1234567890"
+	eventID := "event-1"
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC))
+
+	tt := []struct {
+		name       string
+		urlQuery   string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_fetch_deliveries_with_attempts",
+			urlQuery:   "&expand=attempts",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), eventID).Times(1).
+					Return(&datastore.Event{UID: eventID, EventType: "payment.created", CreatedAt: createdAt}, nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadEventDeliveriesPaged(gomock.Any(), groupID, "", eventID, "", nil, gomock.Any(), gomock.Any()).Times(1).
+					Return([]datastore.EventDelivery{
+						{
+							UID:              "delivery-1",
+							AppMetadata:      &datastore.AppMetadata{UID: "app-1"},
+							EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus},
+							Status:           datastore.FailureEventStatus,
+							DeliveryAttempts: []datastore.DeliveryAttempt{{UID: "attempt-1", HttpResponseCode: "500 Internal Server Error", CreatedAt: createdAt}},
+							CreatedAt:        createdAt,
+						},
+					}, datastore.PaginationData{Total: 1, Page: 1, PerPage: 20, TotalPage: 1}, nil)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).
+					Return(&datastore.Application{Endpoints: []datastore.Endpoint{
+						{UID: "endpoint-1", Status: datastore.InactiveEndpointStatus},
+					}}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_find_event",
+			statusCode: http.StatusNotFound,
+			dbFn: func(app *applicationHandler) {
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), eventID).Times(1).Return(nil, datastore.ErrEventNotFound)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().FetchGroupByID(gomock.Any(), groupID).Times(1).Return(&datastore.Group{UID: groupID}, nil)
+
+			tc.dbFn(app)
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			url := fmt.Sprintf("/api/v1/events/%s/deliveries?groupId=%s%s", eventID, groupID, tc.urlQuery)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestEventStreamHandler_ClosesAfterMaxDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := provideApplication(ctrl)
+	app.streamMaxDuration = 50 * time.Millisecond
+
+	groupID := "1234567890"
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().
+		FetchGroupByID(gomock.Any(), groupID).Times(1).
+		Return(&datastore.Group{UID: groupID, Name: "sendcash-pay"}, nil)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	srv := httptest.NewServer(buildRoutes(app))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/groups/%s/events/stream", srv.URL, groupID), nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the body only ends once the server closes the stream
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, ctx.Err())
+}
+
+func TestEventStreamHandler_ClosesOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := provideApplication(ctrl)
+
+	var closeStreams context.CancelFunc
+	app.shutdown, closeStreams = context.WithCancel(context.Background())
+
+	groupID := "1234567890"
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().
+		FetchGroupByID(gomock.Any(), groupID).Times(1).
+		Return(&datastore.Group{UID: groupID, Name: "sendcash-pay"}, nil)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	srv := httptest.NewUnstartedServer(buildRoutes(app))
+	srv.Config.RegisterOnShutdown(closeStreams)
+	srv.Start()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/groups/%s/events/stream", srv.URL, groupID), nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// shutting down doesn't wait out the open stream
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- srv.Config.Shutdown(ctx) }()
+
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, <-shutdownErr)
+	require.NoError(t, ctx.Err())
+}
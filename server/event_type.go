@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// CreateEventType
+// @Summary Register an event type
+// @Description This endpoint registers an event type consumers of a group can expect, with an optional JSON Schema for its data
+// @Tags EventTypes
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param eventType body models.EventType true "Event type details"
+// @Success 201 {object} serverResponse{data=datastore.EventTypeDefinition}
+// @Failure 400,401,404,409 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/event-types [post]
+func (a *applicationHandler) CreateEventType(w http.ResponseWriter, r *http.Request) {
+	var newEventType models.EventType
+	err := util.ReadJSON(r, &newEventType)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	group := getGroupFromContext(r.Context())
+
+	eventType, err := a.eventTypeService.CreateEventType(r.Context(), group, &newEventType)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event type created successfully", eventType, http.StatusCreated))
+}
+
+// GetEventTypes
+// @Summary Fetch a group's event types
+// @Description This endpoint fetches the event types registered for a group
+// @Tags EventTypes
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Success 200 {object} serverResponse{data=[]datastore.EventTypeDefinition}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/event-types [get]
+func (a *applicationHandler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	eventTypes, err := a.eventTypeService.LoadEventTypes(r.Context(), group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event types fetched successfully", eventTypes, http.StatusOK))
+}
+
+// DeleteEventType
+// @Summary Delete an event type
+// @Description This endpoint removes an event type from a group's registry
+// @Tags EventTypes
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param eventType path string true "event type name"
+// @Success 200 {object} serverResponse{data=Stub}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/event-types/{eventType} [delete]
+func (a *applicationHandler) DeleteEventType(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	err := a.eventTypeService.DeleteEventType(r.Context(), group, chi.URLParam(r, "eventType"))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Event type deleted successfully", nil, http.StatusOK))
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportEvents
+// @Summary Export app events
+// @Description This endpoint streams app events matching the filter as csv or ndjson
+// @Tags Events
+// @Produce text/csv,application/x-ndjson,json
+// @Param appId query string false "application id"
+// @Param groupId query string true "group id"
+// @Param query query string false "text to search for in the event payload"
+// @Param metadata query object false "metadata to filter by, passed as metadata[key]=value"
+// @Param startDate query string false "start date"
+// @Param endDate query string false "end date"
+// @Param tag query string false "tag"
+// @Param format query string false "export format, csv or ndjson"
+// @Param async query bool false "run the export in the background"
+// @Success 200 {string} string
+// @Success 202 {object} serverResponse{data=datastore.ExportJob}
+// @Failure 400,401,413,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /events/export [get]
+func (a *applicationHandler) ExportEvents(w http.ResponseWriter, r *http.Request) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	f := &datastore.Filter{
+		Group:        getGroupFromContext(r.Context()),
+		AppID:        r.URL.Query().Get("appId"),
+		Query:        r.URL.Query().Get("query"),
+		Metadata:     getEventMetadataFromQuery(r),
+		Pageable:     datastore.Pageable{Sort: -1},
+		SearchParams: searchParams,
+	}
+
+	a.export(w, r, services.EventsExportType, f)
+}
+
+// ExportEventDeliveries
+// @Summary Export event deliveries
+// @Description This endpoint streams event deliveries matching the filter as csv or ndjson
+// @Tags EventDelivery
+// @Produce text/csv,application/x-ndjson,json
+// @Param appId query string false "application id"
+// @Param groupId query string true "group id"
+// @Param eventId query string false "event id"
+// @Param endpointId query string false "endpoint id"
+// @Param status query []string false "status, repeated or comma separated"
+// @Param startDate query string false "start date"
+// @Param endDate query string false "end date"
+// @Param tag query string false "tag"
+// @Param format query string false "export format, csv or ndjson"
+// @Param async query bool false "run the export in the background"
+// @Success 200 {string} string
+// @Success 202 {object} serverResponse{data=datastore.ExportJob}
+// @Failure 400,401,413,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /eventdeliveries/export [get]
+func (a *applicationHandler) ExportEventDeliveries(w http.ResponseWriter, r *http.Request) {
+	status, err := getEventDeliveryStatusFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	f := &datastore.Filter{
+		Group:        getGroupFromContext(r.Context()),
+		AppID:        r.URL.Query().Get("appId"),
+		EventID:      r.URL.Query().Get("eventId"),
+		EndpointID:   r.URL.Query().Get("endpointId"),
+		Status:       status,
+		Pageable:     datastore.Pageable{Sort: -1},
+		SearchParams: searchParams,
+	}
+
+	a.export(w, r, services.EventDeliveriesExportType, f)
+}
+
+// GetExportJob
+// @Summary Get an export job
+// @Description This endpoint fetches the status of an async export job
+// @Tags Exports
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param exportID path string true "export id"
+// @Success 200 {object} serverResponse{data=datastore.ExportJob}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /exports/{exportID} [get]
+func (a *applicationHandler) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	job, err := a.exportService.GetExportJob(r.Context(), getGroupFromContext(r.Context()).UID, chi.URLParam(r, "exportID"))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Export job fetched successfully", job, http.StatusOK))
+}
+
+// DownloadExport
+// @Summary Download an export
+// @Description This endpoint downloads the file of a completed export job
+// @Tags Exports
+// @Produce text/csv,application/x-ndjson,json
+// @Param groupId query string true "group id"
+// @Param exportID path string true "export id"
+// @Success 200 {string} string
+// @Failure 400,401,404,409 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /exports/{exportID}/download [get]
+func (a *applicationHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	job, f, err := a.exportService.OpenExportFile(r.Context(), getGroupFromContext(r.Context()).UID, chi.URLParam(r, "exportID"))
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+	defer f.Close()
+
+	setExportHeaders(w, services.ExportType(job.Type), services.ExportFormat(job.Format))
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		log.WithError(err).Error("failed to write export file")
+	}
+}
+
+func (a *applicationHandler) export(w http.ResponseWriter, r *http.Request, exportType services.ExportType, f *datastore.Filter) {
+	format := services.ExportFormat(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		format = services.CSVExportFormat
+	}
+
+	if !format.IsValid() {
+		_ = render.Render(w, r, newErrorResponse("format must be one of csv or ndjson", http.StatusBadRequest))
+		return
+	}
+
+	if r.URL.Query().Get("async") == "true" {
+		job, err := a.exportService.CreateExportJob(r.Context(), exportType, f, format)
+		if err != nil {
+			_ = render.Render(w, r, newServiceErrResponse(err))
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/exports/%s/download?groupId=%s", job.UID, f.Group.UID))
+		_ = render.Render(w, r, newServerResponse("Export job created successfully", job, http.StatusAccepted))
+		return
+	}
+
+	ew := &exportResponseWriter{ResponseWriter: w, exportType: exportType, format: format}
+	_, err := a.exportService.Export(r.Context(), exportType, f, format, ew, services.MaxExportRows)
+	if err != nil {
+		if !ew.wroteHeader {
+			_ = render.Render(w, r, newServiceErrResponse(err))
+			return
+		}
+		log.WithError(err).Error("export stopped before completion")
+		return
+	}
+
+	// an ndjson export of no records writes nothing, it is still sent as
+	// an empty file rather than a bare 200
+	ew.writeHeader()
+}
+
+// exportResponseWriter defers setting the export headers until the first
+// write, so errors found before any row is written can still be rendered
+// as a json response.
+type exportResponseWriter struct {
+	http.ResponseWriter
+	exportType  services.ExportType
+	format      services.ExportFormat
+	wroteHeader bool
+}
+
+func (e *exportResponseWriter) Write(b []byte) (int, error) {
+	e.writeHeader()
+	return e.ResponseWriter.Write(b)
+}
+
+func (e *exportResponseWriter) writeHeader() {
+	if e.wroteHeader {
+		return
+	}
+
+	setExportHeaders(e.ResponseWriter, e.exportType, e.format)
+	e.ResponseWriter.WriteHeader(http.StatusOK)
+	e.wroteHeader = true
+}
+
+func setExportHeaders(w http.ResponseWriter, exportType services.ExportType, format services.ExportFormat) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", exportType, format))
+}
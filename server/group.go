@@ -2,21 +2,28 @@ package server
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/render"
 )
 
+// defaultGroupsPerPage is the page size of cursor paginated group listings
+// that don't set perPage.
+const defaultGroupsPerPage = 20
+
 // GetGroup
 // @Summary Get a group
-// @Description This endpoint fetches a group by its id
+// @Description This endpoint fetches a group by its id, its messages are broken down by event type when expand is messages_by_type
 // @Tags Group
 // @Accept  json
 // @Produce  json
 // @Param groupID path string true "group id"
+// @Param expand query string false "set to messages_by_type to include the per event type message counts"
 // @Success 200 {object} serverResponse{data=datastore.Group}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
@@ -25,14 +32,39 @@ func (a *applicationHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
 
 	group := getGroupFromContext(r.Context())
 
-	err := a.groupService.FillGroupStatistics(r.Context(), group)
+	withMessagesByType := r.URL.Query().Get("expand") == "messages_by_type"
+
+	err := a.groupService.LoadGroupStatistics(r.Context(), group, withMessagesByType)
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
 	}
 
 	_ = render.Render(w, r, newServerResponse("Group fetched successfully",
-		group, http.StatusOK))
+		maskGroupSecrets(group), http.StatusOK))
+}
+
+// GetGroupRateLimits
+// @Summary Get a group's rate limit usage
+// @Description This endpoint fetches how much of the group's api rate limit has been used in the current window
+// @Tags Group
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Success 200 {object} serverResponse{data=models.RateLimitUsage}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/ratelimits [get]
+func (a *applicationHandler) GetGroupRateLimits(w http.ResponseWriter, r *http.Request) {
+	group := getGroupFromContext(r.Context())
+
+	usage, err := a.groupService.GetRateLimitUsage(r.Context(), group)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Group rate limits fetched successfully", usage, http.StatusOK))
 }
 
 // DeleteGroup
@@ -85,7 +117,37 @@ func (a *applicationHandler) CreateGroup(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	_ = render.Render(w, r, newServerResponse("Group created successfully", group, http.StatusCreated))
+	_ = render.Render(w, r, newServerResponse("Group created successfully", maskGroupSecrets(group), http.StatusCreated))
+}
+
+type groupConfigValidation struct {
+	Valid  bool                       `json:"valid"`
+	Errors []services.ValidationError `json:"errors,omitempty"`
+}
+
+// ValidateGroupConfig
+// @Summary Validate a group
+// @Description This endpoint runs the group creation checks against a group without creating it
+// @Tags Group
+// @Accept  json
+// @Produce  json
+// @Param group body models.Group true "Group Details"
+// @Success 200 {object} serverResponse{data=groupConfigValidation}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/validate-config [post]
+func (a *applicationHandler) ValidateGroupConfig(w http.ResponseWriter, r *http.Request) {
+	var group models.Group
+	err := util.ReadJSON(r, &group)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	errs := a.groupService.ValidateGroupConfig(&group)
+	resp := groupConfigValidation{Valid: len(errs) == 0, Errors: errs}
+
+	_ = render.Render(w, r, newServerResponse("Group validated successfully", resp, http.StatusOK))
 }
 
 // UpdateGroup
@@ -115,7 +177,73 @@ func (a *applicationHandler) UpdateGroup(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	_ = render.Render(w, r, newServerResponse("Group updated successfully", group, http.StatusAccepted))
+	_ = render.Render(w, r, newServerResponse("Group updated successfully", maskGroupSecrets(group), http.StatusAccepted))
+}
+
+// UpdateGroupConfig
+// @Summary Update a group's config
+// @Description This endpoint updates the fields of a group's config given in the body, the others are left as they are
+// @Tags Group
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param config body datastore.GroupConfigPatch true "Config fields to update"
+// @Success 200 {object} serverResponse{data=datastore.Group}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/config [patch]
+func (a *applicationHandler) UpdateGroupConfig(w http.ResponseWriter, r *http.Request) {
+	var patch datastore.GroupConfigPatch
+	err := util.ReadJSON(r, &patch)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	g := getGroupFromContext(r.Context())
+	group, err := a.groupService.UpdateGroupConfig(r.Context(), g.UID, &patch)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Group config updated successfully", maskGroupSecrets(group), http.StatusAccepted))
+}
+
+// UpdateGroupStatus
+// @Summary Update a group's status
+// @Description This endpoint moves a group through its lifecycle, from active to inactive, then archived and deleted. Inactive groups can be made active again. Deleting a group deletes its apps and events and revokes its api keys
+// @Tags Group
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param status body models.GroupStatus true "Group status"
+// @Success 200 {object} serverResponse{data=datastore.Group}
+// @Failure 400,401,404,409,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/status [put]
+func (a *applicationHandler) UpdateGroupStatus(w http.ResponseWriter, r *http.Request) {
+	var update models.GroupStatus
+	err := util.ReadJSON(r, &update)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = services.Validate(update)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	g := getGroupFromContext(r.Context())
+	group, err := a.groupService.UpdateGroupStatus(r.Context(), g, update.Status)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Group status updated successfully", maskGroupSecrets(group), http.StatusOK))
 }
 
 // GetGroups
@@ -125,6 +253,8 @@ func (a *applicationHandler) UpdateGroup(w http.ResponseWriter, r *http.Request)
 // @Accept  json
 // @Produce  json
 // @Param name query string false "group name"
+// @Param cursor query string false "next_cursor or prev_cursor of a page, pages the groups when set"
+// @Param perPage query string false "results per page, pages the groups when set"
 // @Success 200 {object} serverResponse{data=[]datastore.Group}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
@@ -154,11 +284,103 @@ func (a *applicationHandler) GetGroups(w http.ResponseWriter, r *http.Request) {
 		filter = &datastore.GroupFilter{Names: userGroups}
 	}
 
+	rawCursor := r.URL.Query().Get("cursor")
+	rawPerPage := r.URL.Query().Get("perPage")
+	if !util.IsStringEmpty(rawCursor) || !util.IsStringEmpty(rawPerPage) {
+		a.getGroupsPaged(w, r, filter, rawCursor, rawPerPage)
+		return
+	}
+
 	groups, err := a.groupService.GetGroups(r.Context(), filter)
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
 	}
 
-	_ = render.Render(w, r, newServerResponse("Groups fetched successfully", groups, http.StatusOK))
+	_ = render.Render(w, r, newServerResponse("Groups fetched successfully", maskGroupsSecrets(groups), http.StatusOK))
+}
+
+// getGroupsPaged renders a page of the groups matching filter, cursor
+// paginated requests get the pagination data along with the groups.
+func (a *applicationHandler) getGroupsPaged(w http.ResponseWriter, r *http.Request, filter *datastore.GroupFilter, rawCursor, rawPerPage string) {
+	perPage := defaultGroupsPerPage
+	if !util.IsStringEmpty(rawPerPage) {
+		n, err := strconv.Atoi(rawPerPage)
+		if err != nil || n < 1 {
+			_ = render.Render(w, r, newErrorResponse("perPage must be a positive number", http.StatusBadRequest))
+			return
+		}
+		perPage = n
+	}
+
+	if !util.IsStringEmpty(rawCursor) {
+		cursor, err := datastore.DecodeCursor(rawCursor)
+		if err != nil {
+			_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+		filter.Cursor = cursor
+	}
+
+	groups, paginationData, err := a.groupService.GetGroupsPaged(r.Context(), filter, perPage)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	groups = maskGroupsSecrets(groups)
+	_ = render.Render(w, r, newServerResponse("Groups fetched successfully",
+		cursorPagedResponse{Content: &groups, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetGroupsWithActiveAlerts
+// @Summary Get groups with active alerts
+// @Description This endpoint fetches the groups failing most of their deliveries over the last hour or with disabled endpoints
+// @Tags Group
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} serverResponse{data=[]datastore.Group}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /admin/groups/alerts [get]
+func (a *applicationHandler) GetGroupsWithActiveAlerts(w http.ResponseWriter, r *http.Request) {
+	groups, err := a.groupService.ListGroupsWithActiveAlerts(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Groups fetched successfully", maskGroupsSecrets(groups), http.StatusOK))
+}
+
+// maskGroupSecrets returns a copy of g to respond with, its meta event
+// secret is replaced with datastore.MaskedSecret. g may be the cached
+// group, so it is left as it is.
+func maskGroupSecrets(g *datastore.Group) *datastore.Group {
+	if g == nil || g.Config == nil || g.Config.MetaEvent == nil {
+		return g
+	}
+
+	masked := *g
+	cfg := *g.Config
+	metaEvent := *g.Config.MetaEvent
+
+	metaEvent.Secret = datastore.MaskedSecret
+	cfg.MetaEvent = &metaEvent
+	masked.Config = &cfg
+
+	return &masked
+}
+
+func maskGroupsSecrets(groups []*datastore.Group) []*datastore.Group {
+	if groups == nil {
+		return nil
+	}
+
+	masked := make([]*datastore.Group, len(groups))
+	for i, g := range groups {
+		masked[i] = maskGroupSecrets(g)
+	}
+
+	return masked
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,10 +12,14 @@ import (
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	mlimiter "github.com/frain-dev/convoy/limiter/memory"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/services"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestApplicationHandler_GetGroup(t *testing.T) {
@@ -31,6 +36,7 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 		method     string
 		statusCode int
 		id         string
+		query      string
 		dbFn       func(app *applicationHandler)
 	}{
 		{
@@ -57,7 +63,7 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 			id:         fakeOrgID,
 			dbFn: func(app *applicationHandler) {
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
@@ -73,6 +79,12 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 				a.EXPECT().
 					CountGroupApplications(gomock.Any(), gomock.Any()).Times(1).
 					Return(int64(0), errors.New("failed to count group apps"))
+
+				// the messages are counted at the same time
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					CountGroupMessages(gomock.Any(), gomock.Any()).MaxTimes(1).
+					Return(int64(0), nil)
 			},
 		},
 		{
@@ -83,7 +95,7 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 			id:         fakeOrgID,
 			dbFn: func(app *applicationHandler) {
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
@@ -116,14 +128,14 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
 				c, _ := app.cache.(*mocks.MockCache)
 
-				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
 				o.EXPECT().
 					FetchGroupByID(gomock.Any(), gomock.Any()).Times(1).
 					Return(&datastore.Group{
 						UID:  realOrgID,
 						Name: "sendcash-pay",
 					}, nil)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
@@ -134,6 +146,77 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 				e.EXPECT().
 					CountGroupMessages(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
 					Return(int64(1), nil)
+
+				d, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().
+					CountPendingDeliveriesByGroup(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
+					Return(int64(0), nil)
+			},
+		},
+		{
+			name:       "should_read_statistics_from_cache",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			method:     http.MethodGet,
+			statusCode: http.StatusOK,
+			id:         realOrgID,
+			dbFn: func(app *applicationHandler) {
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				c, _ := app.cache.(*mocks.MockCache)
+
+				c.EXPECT().Get(gomock.Any(), "groups:"+realOrgID, gomock.Any()).Return(nil)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.Group{
+						UID:  realOrgID,
+						Name: "sendcash-pay",
+					}, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+				c.EXPECT().Get(gomock.Any(), "group_statistics:"+realOrgID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						statistics := data.(**datastore.GroupStatistics)
+						*statistics = &datastore.GroupStatistics{MessagesSent: 50, TotalApps: 3}
+						return nil
+					})
+			},
+		},
+		{
+			name:       "should_break_messages_down_by_type",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			method:     http.MethodGet,
+			statusCode: http.StatusOK,
+			id:         realOrgID,
+			query:      "expand=messages_by_type",
+			dbFn: func(app *applicationHandler) {
+				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				c, _ := app.cache.(*mocks.MockCache)
+
+				c.EXPECT().Get(gomock.Any(), "groups:"+realOrgID, gomock.Any()).Return(nil)
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.Group{
+						UID:  realOrgID,
+						Name: "sendcash-pay",
+					}, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					CountGroupApplications(gomock.Any(), realOrgID).Times(1).
+					Return(int64(1), nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					CountGroupMessages(gomock.Any(), realOrgID).Times(1).
+					Return(int64(3), nil)
+				e.EXPECT().
+					CountGroupMessagesByType(gomock.Any(), realOrgID).Times(1).
+					Return(map[string]int64{"payment.created": 2, "payment.failed": 1}, nil)
+
+				d, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().
+					CountPendingDeliveriesByGroup(gomock.Any(), realOrgID).Times(1).
+					Return(int64(0), nil)
 			},
 		},
 	}
@@ -147,7 +230,7 @@ func TestApplicationHandler_GetGroup(t *testing.T) {
 			app = provideApplication(ctrl)
 
 			// Arrange
-			url := fmt.Sprintf("/api/v1/groups/%s", tc.id)
+			url := fmt.Sprintf("/api/v1/groups/%s?%s", tc.id, tc.query)
 			req := httptest.NewRequest(tc.method, url, nil)
 			req.SetBasicAuth("test", "test")
 			req.Header.Add("Content-Type", "application/json")
@@ -206,6 +289,9 @@ func TestApplicationHandler_CreateGroup(t *testing.T) {
 			body: bodyReader,
 			dbFn: func(app *applicationHandler) {
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := app.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
 				o.EXPECT().
 					CreateGroup(gomock.Any(), gomock.Any()).Times(1).
 					Return(nil)
@@ -219,6 +305,9 @@ func TestApplicationHandler_CreateGroup(t *testing.T) {
 			body:       strings.NewReader(`{"name": "ABC_DEF_TEST_UPDATE_2","rate_limit": 3000,"rate_limit_duration": "1m", "config": {"strategy": {"type": "default", "default": {"intervalSeconds": 10, "retryLimit": 3 }}, "signature": { "header": "X-Company-Signature", "hash": "SHA1" }}}`),
 			dbFn: func(app *applicationHandler) {
 				o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := app.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
 				o.EXPECT().
 					CreateGroup(gomock.Any(), gomock.Any()).Times(1).
 					Return(errors.New("failed"))
@@ -332,6 +421,136 @@ func TestApplicationHandler_CreateGroup(t *testing.T) {
 	}
 }
 
+func TestApplicationHandler_CreateGroup_SetsDisableEndpointField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := provideApplication(ctrl)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	router := buildRoutes(app)
+
+	var created *datastore.Group
+	o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	st, _ := app.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+	st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+	o.EXPECT().
+		CreateGroup(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, g *datastore.Group) error {
+			created = g
+			return nil
+		})
+
+	body := strings.NewReader(`{"name": "disable-endpoint-group", "config": {"disable_endpoint": true, "strategy": {"type": "default", "default": {"intervalSeconds": 10, "retryLimit": 3 }}, "signature": { "header": "X-Company-Signature", "hash": "SHA1" }}}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/groups", body)
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NotNil(t, created)
+	require.True(t, created.Config.DisableEndpoint)
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	o.EXPECT().
+		FetchGroupByID(gomock.Any(), created.UID).Times(1).
+		Return(created, nil)
+
+	a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().
+		CountGroupApplications(gomock.Any(), created.UID).Times(1).
+		Return(int64(0), nil)
+
+	e, _ := app.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().
+		CountGroupMessages(gomock.Any(), created.UID).Times(1).
+		Return(int64(0), nil)
+
+	d, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().
+		CountPendingDeliveriesByGroup(gomock.Any(), created.UID).Times(1).
+		Return(int64(0), nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/groups/"+created.UID, nil)
+	req.Header.Add("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res serverResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+	var group datastore.Group
+	require.NoError(t, json.Unmarshal(res.Data, &group))
+	require.True(t, group.Config.DisableEndpoint)
+}
+
+func TestApplicationHandler_ValidateGroupConfig(t *testing.T) {
+	tt := []struct {
+		name       string
+		statusCode int
+		body       *strings.Reader
+	}{
+		{
+			name:       "valid group config",
+			statusCode: http.StatusOK,
+			body:       strings.NewReader(`{"name": "ABC_DEF_TEST", "config": {"strategy": {"type": "default", "default": {"intervalSeconds": 10, "retryLimit": 3 }}, "signature": { "header": "X-Company-Signature", "hash": "SHA1" }}}`),
+		},
+		{
+			name:       "invalid group config",
+			statusCode: http.StatusOK,
+			body:       strings.NewReader(`{"config": {"strategy": {"type": "unsupported", "default": {"intervalSeconds": 10, "retryLimit": 3 }}, "signature": { "header": "X-Company-Signature", "hash": "unsupported" }}}`),
+		},
+		{
+			name:       "invalid request body",
+			statusCode: http.StatusBadRequest,
+			body:       strings.NewReader(`{"name": `),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// the group repo mock has no expectations, any call fails the test
+			app := provideApplication(ctrl)
+
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/groups/validate-config", tc.body)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			err := config.LoadConfig("./testdata/Auth_Config/basic-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			// Assert.
+			if w.Code != tc.statusCode {
+				t.Errorf("want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
 func TestApplicationHandler_UpdateGroup(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
@@ -362,6 +581,7 @@ func TestApplicationHandler_UpdateGroup(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "groups:1234567890").Times(1)
 
 				g.EXPECT().
 					UpdateGroup(gomock.Any(), gomock.Any()).Times(1).
@@ -530,6 +750,11 @@ func TestApplicationHandler_GetGroups(t *testing.T) {
 				e.EXPECT().
 					CountGroupMessages(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
 					Return(int64(1), nil)
+
+				d, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().
+					CountPendingDeliveriesByGroup(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
+					Return(int64(0), nil)
 			},
 		},
 		{
@@ -569,6 +794,11 @@ func TestApplicationHandler_GetGroups(t *testing.T) {
 				e.EXPECT().
 					CountGroupMessages(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
 					Return(int64(1), nil)
+
+				d, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().
+					CountPendingDeliveriesByGroup(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
+					Return(int64(0), nil)
 			},
 		},
 		{
@@ -650,19 +880,11 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 						Name: "sendcash-pay",
 					}, nil)
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(2)
 
 				g.EXPECT().
-					DeleteGroup(gomock.Any(), gomock.Any()).Times(1).
+					DeleteGroupCascade(gomock.Any(), gomock.Any()).Times(1).
 					Return(nil)
-
-				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
-					Return(nil)
-
-				e, _ := app.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().DeleteGroupEvents(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
-					Return(nil)
-
 			},
 		},
 		{
@@ -686,7 +908,7 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
 				g.EXPECT().
-					DeleteGroup(gomock.Any(), gomock.Any()).Times(1).
+					DeleteGroupCascade(gomock.Any(), gomock.Any()).Times(1).
 					Return(errors.New("abc"))
 			},
 		},
@@ -711,12 +933,8 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 					}, nil)
 
 				g.EXPECT().
-					DeleteGroup(gomock.Any(), gomock.Any()).Times(1).
-					Return(nil)
-
-				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
-					Return(errors.New("failed"))
+					DeleteGroupCascade(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group apps", Err: errors.New("failed")})
 			},
 		},
 		{
@@ -730,7 +948,7 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 				c, _ := app.cache.(*mocks.MockCache)
 				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
 				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
-				
+
 				g, _ := app.groupRepo.(*mocks.MockGroupRepository)
 				g.EXPECT().
 					FetchGroupByID(gomock.Any(), gomock.Any()).Times(1).
@@ -740,17 +958,8 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 					}, nil)
 
 				g.EXPECT().
-					DeleteGroup(gomock.Any(), gomock.Any()).Times(1).
-					Return(nil)
-
-				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
-					Return(nil)
-
-				e, _ := app.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().DeleteGroupEvents(gomock.Any(), gomock.AssignableToTypeOf("")).Times(1).
-					Return(errors.New("failed"))
-
+					DeleteGroupCascade(gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group events", Err: errors.New("failed")})
 			},
 		},
 	}
@@ -799,3 +1008,151 @@ func TestApplicationHandler_DeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationHandler_UpdateGroupStatus(t *testing.T) {
+	realOrgID := "1234567890"
+
+	tt := []struct {
+		name       string
+		body       string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "valid group status update",
+			body:       `{"status": "inactive"}`,
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), realOrgID, datastore.InactiveGroupStatus).Times(1).Return(nil)
+				g.EXPECT().FetchGroupByID(gomock.Any(), realOrgID).Times(1).
+					Return(&datastore.Group{UID: realOrgID, Name: "sendcash-pay", Status: datastore.InactiveGroupStatus}, nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1)
+			},
+		},
+		{
+			name:       "illegal group status transition",
+			body:       `{"status": "archived"}`,
+			statusCode: http.StatusConflict,
+			dbFn: func(app *applicationHandler) {
+				g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), realOrgID, datastore.ArchivedGroupStatus).Times(1).
+					Return(datastore.ErrIllegalGroupStatusTransition)
+			},
+		},
+		{
+			name:       "missing group status",
+			body:       `{}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			app := provideApplication(ctrl)
+
+			url := fmt.Sprintf("/api/v1/groups/%s/status", realOrgID)
+			req := httptest.NewRequest(http.MethodPut, url, strings.NewReader(tc.body))
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// the group is loaded by requireGroup before the handler runs
+			g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			g.EXPECT().FetchGroupByID(gomock.Any(), realOrgID).Times(1).
+				Return(&datastore.Group{UID: realOrgID, Name: "sendcash-pay"}, nil)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestApplicationHandler_GetGroupRateLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := provideApplication(ctrl)
+	app.limiter = mlimiter.NewMemoryLimiter()
+	app.groupService = services.NewGroupService(app.appRepo, app.groupRepo, app.eventRepo, app.eventDeliveryRepo, app.settingsRepo, app.limiter, app.cache)
+
+	c, _ := app.cache.(*mocks.MockCache)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	o, _ := app.groupRepo.(*mocks.MockGroupRepository)
+	o.EXPECT().
+		FetchGroupByID(gomock.Any(), "1234567890").Times(2).
+		Return(&datastore.Group{UID: "1234567890", RateLimit: 100, RateLimitDuration: "1m"}, nil)
+
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	router := buildRoutes(app)
+
+	// the group's rate limit applies to the requests for its usage too
+	for want := 1; want <= 2; want++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/groups/1234567890/ratelimits", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res struct {
+			Data models.RateLimitUsage `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+		require.Equal(t, 100, res.Data.Limit)
+		require.Equal(t, "1m0s", res.Data.Duration)
+		require.Equal(t, want, res.Data.Used)
+		require.Equal(t, 100-want, res.Data.Remaining)
+	}
+}
+
+func TestMaskGroupSecrets(t *testing.T) {
+	group := &datastore.Group{
+		UID: "1234567890",
+		Config: &datastore.GroupConfig{
+			MetaEvent: &datastore.MetaEventConfiguration{
+				URL:    "https://example.com/meta",
+				Secret: "encrypted-secret",
+			},
+		},
+	}
+
+	masked := maskGroupSecrets(group)
+	require.Equal(t, datastore.MaskedSecret, masked.Config.MetaEvent.Secret)
+	require.Equal(t, "https://example.com/meta", masked.Config.MetaEvent.URL)
+
+	// the group itself may be cached, it must keep its secret
+	require.Equal(t, "encrypted-secret", group.Config.MetaEvent.Secret)
+
+	groups := maskGroupsSecrets([]*datastore.Group{group, {UID: "12345"}})
+	require.Equal(t, datastore.MaskedSecret, groups[0].Config.MetaEvent.Secret)
+	require.Nil(t, groups[1].Config)
+}
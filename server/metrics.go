@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/queue"
@@ -149,6 +150,19 @@ func RegisterDBMetrics(app *applicationHandler) {
 	}
 }
 
+// RegisterCacheMetrics registers the cache's counters when it keeps any.
+func RegisterCacheMetrics(c cache.Cache) {
+	collector, ok := c.(prometheus.Collector)
+	if !ok {
+		return
+	}
+
+	err := prometheus.Register(collector)
+	if err != nil {
+		log.Errorf("Error registering cache metrics: %v", err)
+	}
+}
+
 func queueLength(q queue.Queuer, cfg config.Configuration) (int, error) {
 	switch cfg.Queue.Type {
 	case config.RedisQueueProvider:
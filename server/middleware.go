@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -95,6 +97,75 @@ func writeRequestIDHeader(next http.Handler) http.Handler {
 	})
 }
 
+// limitRequestBody rejects request bodies larger than the configured limit
+// before any of it is read, and caps what can be read from bodies without
+// a content length.
+func limitRequestBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg, err := config.Get()
+		if err != nil {
+			log.WithError(err).Error("failed to load configuration")
+			_ = render.Render(w, r, newErrorResponse("failed to load configuration", http.StatusInternalServerError))
+			return
+		}
+
+		limit := int64(cfg.Server.HTTP.MaxRequestBodySize)
+		if limit == 0 {
+			limit = config.DefaultMaxRequestBodySize
+		}
+
+		if r.ContentLength > limit {
+			_ = render.Render(w, r, newErrorResponse(fmt.Sprintf("request body must not be larger than %d bytes", limit), http.StatusRequestEntityTooLarge))
+			return
+		}
+
+		r.Body = util.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// regionRouter tags the request context with the region nearest to the
+// client, so group reads are served from that region's replica.
+func regionRouter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg, err := config.Get()
+		if err != nil {
+			log.WithError(err).Error("failed to load configuration")
+			_ = render.Render(w, r, newErrorResponse("failed to load configuration", http.StatusInternalServerError))
+			return
+		}
+
+		region := nearestRegion(cfg.Region, r.Host)
+		if !util.IsStringEmpty(region) {
+			w.Header().Set("X-Convoy-Region", region)
+			r = r.WithContext(datastore.WithRegion(r.Context(), region))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// nearestRegion picks the region whose endpoint the request arrived on,
+// and otherwise the region this instance runs in.
+func nearestRegion(cfg config.RegionConfiguration, host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	for region, endpoint := range cfg.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(u.Hostname(), host) {
+			return region
+		}
+	}
+
+	return cfg.Name
+}
+
 func setupCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cfg, err := config.Get()
@@ -143,11 +214,22 @@ func requireApp(appRepo datastore.ApplicationRepository, cache cache.Cache) func
 			}
 
 			if app == nil {
+				missingKey := convoy.MissingAppsCacheKey.Get(appID).String()
+				if isRememberedMissing(r.Context(), cache, missingKey) {
+					_ = render.Render(w, r, newErrorResponse(datastore.ErrApplicationNotFound.Error(), http.StatusNotFound))
+					return
+				}
+
 				app, err = appRepo.FindApplicationByID(r.Context(), appID)
 				if err != nil {
 					if errors.Is(err, datastore.ErrApplicationNotFound) {
 						event = err.Error()
 						statusCode = http.StatusNotFound
+
+						rememberMissing(r.Context(), cache, missingKey, func() bool {
+							_, err := appRepo.FindApplicationByID(r.Context(), appID)
+							return !errors.Is(err, datastore.ErrApplicationNotFound)
+						})
 					}
 					_ = render.Render(w, r, newErrorResponse(event, statusCode))
 					return
@@ -166,6 +248,59 @@ func requireApp(appRepo datastore.ApplicationRepository, cache cache.Cache) func
 	}
 }
 
+// isRememberedMissing reports whether the entity under key was remembered
+// as missing by rememberMissing.
+func isRememberedMissing(ctx context.Context, c cache.Cache, key string) bool {
+	if notFoundTTL() == 0 {
+		return false
+	}
+
+	var missing bool
+	err := c.Get(ctx, key, &missing)
+	if err != nil {
+		log.WithError(err).Errorf("failed to read %s from cache", key)
+		return false
+	}
+
+	return missing
+}
+
+// rememberMissing caches that the entity under key wasn't found, for the
+// configured not found ttl. Creating the entity deletes the entry, but
+// that can happen between the lookup and the write here, so exists is
+// called once the entry is written and it is deleted again if the entity
+// turned up.
+func rememberMissing(ctx context.Context, c cache.Cache, key string, exists func() bool) {
+	ttl := notFoundTTL()
+	if ttl == 0 {
+		return
+	}
+
+	err := c.Set(ctx, key, true, ttl)
+	if err != nil {
+		log.WithError(err).Errorf("failed to write %s to cache", key)
+		return
+	}
+
+	if exists() {
+		err = c.Delete(ctx, key)
+		if err != nil {
+			log.WithError(err).Errorf("failed to delete %s from cache", key)
+		}
+	}
+}
+
+// notFoundTTL is how long missing entities are remembered, 0 when they
+// aren't.
+func notFoundTTL() time.Duration {
+	cfg, err := config.Get()
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(cfg.Cache.NotFoundTTL) * time.Second
+}
+
 func requireAppID() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 
@@ -261,6 +396,40 @@ func requireAppPortalPermission(role auth.RoleType) func(next http.Handler) http
 	}
 }
 
+// requireAppScope keeps keys restricted to specific apps, such as app
+// portal keys, from addressing any other app in their group. Requests
+// without an app are pinned to the key's app so listings stay scoped.
+func requireAppScope() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUser := getAuthUserFromContext(r.Context())
+			if len(authUser.Role.Apps) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			q := r.URL.Query()
+			for _, appID := range []string{chi.URLParam(r, "appID"), q.Get("appId"), q.Get("appID")} {
+				if util.IsStringEmpty(appID) {
+					continue
+				}
+
+				if err := authorizeAppAccess(authUser, appID); err != nil {
+					_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusForbidden))
+					return
+				}
+			}
+
+			if util.IsStringEmpty(q.Get("appId")) {
+				q.Set("appId", authUser.Role.Apps[0])
+				r.URL.RawQuery = q.Encode()
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func filterDeletedEndpoints(endpoints []datastore.Endpoint) []datastore.Endpoint {
 	activeEndpoints := make([]datastore.Endpoint, 0)
 	for _, endpoint := range endpoints {
@@ -333,6 +502,14 @@ func requireEvent(eventRepo datastore.EventRepository) func(next http.Handler) h
 				return
 			}
 
+			if event.AppMetadata != nil {
+				err = authorizeAppAccess(getAuthUserFromContext(r.Context()), event.AppMetadata.UID)
+				if err != nil {
+					_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusForbidden))
+					return
+				}
+			}
+
 			r = r.WithContext(setEventInContext(r.Context(), event))
 			next.ServeHTTP(w, r)
 		})
@@ -359,6 +536,14 @@ func requireEventDelivery(eventRepo datastore.EventDeliveryRepository) func(next
 				return
 			}
 
+			if eventDelivery.AppMetadata != nil {
+				err = authorizeAppAccess(getAuthUserFromContext(r.Context()), eventDelivery.AppMetadata.UID)
+				if err != nil {
+					_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusForbidden))
+					return
+				}
+			}
+
 			r = r.WithContext(setEventDeliveryInContext(r.Context(), eventDelivery))
 			next.ServeHTTP(w, r)
 		})
@@ -443,8 +628,21 @@ func requireGroup(groupRepo datastore.GroupRepository, cache cache.Cache) func(n
 				}
 
 				if group == nil {
+					missingKey := convoy.MissingGroupsCacheKey.Get(groupID).String()
+					if isRememberedMissing(r.Context(), cache, missingKey) {
+						_ = render.Render(w, r, newErrorResponse("failed to fetch group by id", http.StatusNotFound))
+						return
+					}
+
 					group, err = groupRepo.FetchGroupByID(r.Context(), groupID)
 					if err != nil {
+						if errors.Is(err, datastore.ErrGroupNotFound) {
+							rememberMissing(r.Context(), cache, missingKey, func() bool {
+								_, err := groupRepo.FetchGroupByID(r.Context(), groupID)
+								return !errors.Is(err, datastore.ErrGroupNotFound)
+							})
+						}
+
 						_ = render.Render(w, r, newErrorResponse("failed to fetch group by id", http.StatusNotFound))
 						return
 					}
@@ -538,6 +736,29 @@ func requireBaseUrl() func(next http.Handler) http.Handler {
 	}
 }
 
+// featureFlagMiddleware responds with 404 when the feature named flag is
+// turned off, as if the routes it guards don't exist. The flag is checked
+// on every request so reloading the config turns it on or off.
+func featureFlagMiddleware(flag string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg, err := config.Get()
+			if err != nil {
+				log.WithError(err).Error("failed to load configuration")
+				_ = render.Render(w, r, newErrorResponse("failed to load configuration", http.StatusInternalServerError))
+				return
+			}
+
+			if !cfg.Server.FeatureFlags.Enabled(flag) {
+				_ = render.Render(w, r, newErrorResponse("not found", http.StatusNotFound))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func requirePermission(role auth.RoleType) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -548,28 +769,56 @@ func requirePermission(role auth.RoleType) func(next http.Handler) http.Handler
 				return
 			}
 
-			if !authUser.Role.Type.Is(role) {
-				_ = render.Render(w, r, newErrorResponse("unauthorized role", http.StatusUnauthorized))
+			err := authorizeGroupAccess(authUser, getGroupFromContext(r.Context()), role)
+			if err != nil {
+				_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusUnauthorized))
 				return
 			}
 
-			group := getGroupFromContext(r.Context())
-			for _, v := range authUser.Role.Groups {
-				if group.Name == v || group.UID == v {
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-					if len(authUser.Role.Apps) > 0 { //we're dealing with an app portal token at this point
-						_ = render.Render(w, r, newErrorResponse("unauthorized to access group", http.StatusUnauthorized))
-						return
-					}
+// authorizeGroupAccess returns an error unless authUser has role on group.
+func authorizeGroupAccess(authUser *auth.AuthenticatedUser, group *datastore.Group, role auth.RoleType) error {
+	if authUser.Role.Type.Is(auth.RoleSuperUser) {
+		// superuser has access to everything
+		return nil
+	}
 
-					next.ServeHTTP(w, r)
-					return
-				}
+	if !authUser.Role.Type.Is(role) {
+		return errors.New("unauthorized role")
+	}
+
+	for _, v := range authUser.Role.Groups {
+		if group.Name == v || group.UID == v {
+
+			if len(authUser.Role.Apps) > 0 { //we're dealing with an app portal token at this point
+				return errors.New("unauthorized to access group")
 			}
 
-			_ = render.Render(w, r, newErrorResponse("unauthorized to access group", http.StatusUnauthorized))
-		})
+			return nil
+		}
+	}
+
+	return errors.New("unauthorized to access group")
+}
+
+// authorizeAppAccess returns an error if authUser is restricted to specific
+// apps and appID is not one of them.
+func authorizeAppAccess(authUser *auth.AuthenticatedUser, appID string) error {
+	if len(authUser.Role.Apps) == 0 {
+		return nil
 	}
+
+	for _, app := range authUser.Role.Apps {
+		if app == appID {
+			return nil
+		}
+	}
+
+	return errors.New("unauthorized to access app")
 }
 
 func getAuthFromRequest(r *http.Request) (*auth.Credential, error) {
@@ -656,10 +905,14 @@ func pagination(next http.Handler) http.Handler {
 		if page, err = strconv.Atoi(rawPage); err != nil {
 			page = 0
 		}
+		// an invalid skip_count counts the list as usual
+		skipTotal, _ := strconv.ParseBool(r.URL.Query().Get("skip_count"))
+
 		pageable := datastore.Pageable{
-			Page:    page,
-			PerPage: perPage,
-			Sort:    sort,
+			Page:      page,
+			PerPage:   perPage,
+			Sort:      sort,
+			SkipTotal: skipTotal,
 		}
 		r = r.WithContext(setPageableInContext(r.Context(), pageable))
 		next.ServeHTTP(w, r)
@@ -940,6 +1193,28 @@ func getAuthUserFromContext(ctx context.Context) *auth.AuthenticatedUser {
 	return ctx.Value(authUserCtx).(*auth.AuthenticatedUser)
 }
 
+// getActorID identifies the authenticated user for audit logs. API keys
+// are identified by their mask id so the key itself is never recorded.
+func getActorID(ctx context.Context) string {
+	authUser, ok := ctx.Value(authUserCtx).(*auth.AuthenticatedUser)
+	if !ok {
+		return ""
+	}
+
+	switch authUser.Credential.Type {
+	case auth.CredentialTypeAPIKey:
+		keySplit := strings.Split(authUser.Credential.APIKey, ".")
+		if len(keySplit) == 3 {
+			return keySplit[1]
+		}
+		return ""
+	case auth.CredentialTypeBasic:
+		return authUser.Credential.Username
+	default:
+		return authUser.AuthenticatedByRealm
+	}
+}
+
 func getAuthLoginFromContext(ctx context.Context) *AuthorizedLogin {
 	return ctx.Value(authLoginCtx).(*AuthorizedLogin)
 }
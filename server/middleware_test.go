@@ -1,12 +1,22 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/frain-dev/convoy"
+	mcache "github.com/frain-dev/convoy/cache/memory"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +58,18 @@ func TestRequirePermission_Basic(t *testing.T) {
 			credentials: "Basic dGVzdDp0ZXN0",
 			cfgPath:     "./testdata/Auth_Config/basic-convoy.json",
 		},
+		{
+			name:        "authorization failed with yaml config",
+			statusCode:  http.StatusUnauthorized,
+			credentials: "Basic YWRtaW46dGVzdA==",
+			cfgPath:     "./testdata/Auth_Config/basic-convoy.yaml",
+		},
+		{
+			name:        "valid credentials with yaml config",
+			statusCode:  http.StatusOK,
+			credentials: "Basic dGVzdDp0ZXN0",
+			cfgPath:     "./testdata/Auth_Config/basic-convoy.yaml",
+		},
 	}
 
 	for _, tc := range tt {
@@ -116,6 +138,11 @@ func TestRequirePermission_Noop(t *testing.T) {
 			credentials: "dGVzdDp0ZXN0",
 			cfgPath:     "./testdata/Auth_Config/none-convoy.json",
 		},
+		{
+			name:       "credentials not provided with yaml config",
+			statusCode: http.StatusOK,
+			cfgPath:    "./testdata/Auth_Config/none-convoy.yaml",
+		},
 	}
 
 	for _, tc := range tt {
@@ -153,3 +180,432 @@ func TestRequirePermission_Noop(t *testing.T) {
 		})
 	}
 }
+
+func TestLimitRequestBody(t *testing.T) {
+	tt := []struct {
+		name          string
+		body          string
+		contentLength int64
+		statusCode    int
+	}{
+		{
+			name:          "body within limit",
+			body:          `{"name":"convoy"}`,
+			contentLength: -1,
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:          "content length over limit",
+			body:          `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			contentLength: 75,
+			statusCode:    http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "body without content length over limit",
+			body:          `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			contentLength: -1,
+			statusCode:    http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// the limit is only converted from kilobytes by
+			// SetServerConfigDefaults, so it is in bytes here
+			setEnv(t, "CONVOY_MAX_REQUEST_BODY_SIZE", "64")
+
+			err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+			require.NoError(t, err)
+
+			fn := limitRequestBody(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				err := util.ReadJSON(r, &body)
+				if errors.Is(err, util.ErrBodyTooLarge) {
+					rw.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+
+				require.NoError(t, err)
+				rw.WriteHeader(http.StatusOK)
+			}))
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			request.ContentLength = tc.contentLength
+
+			fn.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.statusCode, recorder.Code)
+		})
+	}
+}
+
+func TestMaxBodySizeMiddleware_Rejects6MBBody(t *testing.T) {
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	fn := limitRequestBody(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		t.Error("the handler must not be called")
+	}))
+
+	body := `{"name":"` + strings.Repeat("a", 6*1024*1024) + `"}`
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	fn.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	require.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.JSONEq(t, fmt.Sprintf(`{"status":false,"code":"payload_too_large","message":"request body must not be larger than %d bytes"}`, config.DefaultMaxRequestBodySize), recorder.Body.String())
+}
+
+func TestMaxBodySizeMiddleware_Accepts4MBBody(t *testing.T) {
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	name := strings.Repeat("a", 4*1024*1024)
+	fn := limitRequestBody(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		err := util.ReadJSON(r, &body)
+		require.NoError(t, err)
+		require.Equal(t, name, body["name"])
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"`+name+`"}`))
+
+	fn.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRegionRouter_TagsRequestWithNearestRegion(t *testing.T) {
+	tt := []struct {
+		name   string
+		host   string
+		region string
+	}{
+		{
+			name:   "host of a region endpoint",
+			host:   "us.convoy.example.com",
+			region: "us-east",
+		},
+		{
+			name:   "host with port and different case",
+			host:   "EU.convoy.example.com:8443",
+			region: "eu-west",
+		},
+		{
+			name:   "unknown host falls back to the instance region",
+			host:   "localhost:5005",
+			region: "eu-west",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := config.LoadConfig("./testdata/Region_Config/region-convoy.json")
+			require.NoError(t, err)
+
+			var region string
+			fn := regionRouter(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				region = datastore.RegionFromContext(r.Context())
+			}))
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Host = tc.host
+
+			fn.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.region, region)
+			require.Equal(t, tc.region, recorder.Header().Get("X-Convoy-Region"))
+		})
+	}
+}
+
+func TestRequireAppScope_AppPortalKey(t *testing.T) {
+	group := &datastore.Group{UID: "1234567890", Name: "sendcash-pay"}
+
+	tt := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_reject_another_apps_events",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("/portal/events?groupId=%s&appId=app-2", group.UID),
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "should_reject_updating_another_apps_endpoint",
+			method:     http.MethodPut,
+			url:        fmt.Sprintf("/portal/apps/endpoints/endpoint-1?groupId=%s&appId=app-2", group.UID),
+			body:       `{"url": "https://google.com", "description": "test endpoint"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "should_reject_another_apps_event_deliveries",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("/portal/eventdeliveries?groupId=%s&appID=app-2", group.UID),
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "should_scope_events_to_the_keys_app",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("/portal/events?groupId=%s", group.UID),
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), "app-1").Times(1).
+					Return(&datastore.Application{UID: "app-1", GroupID: group.UID}, nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					LoadEventsPaged(gomock.Any(), group.UID, "app-1", gomock.Any(), gomock.Any()).Times(1).
+					Return([]datastore.Event{}, datastore.PaginationData{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := provideApplication(ctrl)
+
+			c, _ := app.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+			g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().
+				FetchGroupByID(gomock.Any(), group.UID).Times(1).
+				Return(group, nil)
+
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig("./testdata/Auth_Config/app-portal-convoy.json")
+			require.NoError(t, err)
+
+			initRealmChain(t, app.apiKeyRepo)
+
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			req.Header.Add("Authorization", "Bearer portal-api-key")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			buildRoutes(app).ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestRequireGroup_RemembersMissingGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setEnv(t, "CONVOY_CACHE_NOT_FOUND_TTL", "10")
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	c := mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	// the lookup and the check that follows the write of the tombstone
+	groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Times(2).Return(nil, datastore.ErrGroupNotFound)
+
+	fn := requireGroup(groupRepo, c)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		t.Error("the handler must not be called")
+	}))
+
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		fn.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?groupId=group-1", nil))
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	}
+}
+
+func TestRequireGroup_GroupCreatedRightAfterNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setEnv(t, "CONVOY_CACHE_NOT_FOUND_TTL", "10")
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	c := mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+	group := &datastore.Group{UID: "group-1", Name: "sendcash-pay"}
+
+	// the group is created, and its tombstone deleted, after the lookup
+	// missed it but before the tombstone is written
+	gomock.InOrder(
+		groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Times(1).Return(nil, datastore.ErrGroupNotFound),
+		groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Times(2).Return(group, nil),
+	)
+
+	fn := requireGroup(groupRepo, c)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		require.Equal(t, group.UID, getGroupFromContext(r.Context()).UID)
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	fn.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?groupId=group-1", nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	fn.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?groupId=group-1", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRequireApp_AppRestoredAfterNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setEnv(t, "CONVOY_CACHE_NOT_FOUND_TTL", "10")
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	c := mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+	app := &datastore.Application{UID: "app-1", GroupID: "group-1"}
+
+	gomock.InOrder(
+		appRepo.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(2).Return(nil, datastore.ErrApplicationNotFound),
+		appRepo.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).Return(app, nil),
+	)
+
+	router := chi.NewRouter()
+	router.With(requireApp(appRepo, c)).Get("/{appID}", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/app-1", nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// served from the tombstone
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/app-1", nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// restoring the app deletes its tombstone
+	err = c.Delete(context.Background(), convoy.MissingAppsCacheKey.Get(app.UID).String())
+	require.NoError(t, err)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/app-1", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestFeatureFlagMiddleware_Returns404WhenFlagDisabled(t *testing.T) {
+	setEnv(t, "CONVOY_FEATURE_FLAGS", "admin_api:false,app_portal:true")
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	fn := featureFlagMiddleware(config.AdminAPIFeatureFlag)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		t.Error("the handler must not be called")
+	}))
+
+	recorder := httptest.NewRecorder()
+	fn.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/admin/settings", nil))
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestFeatureFlagMiddleware_GatesRoutesBeforeLookups(t *testing.T) {
+	setEnv(t, "CONVOY_FEATURE_FLAGS", "admin_api:false,app_portal:false")
+	err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the mocks expect no calls, so a group or app lookup fails the test
+	app := provideApplication(ctrl)
+	initRealmChain(t, app.apiKeyRepo)
+	router := buildRoutes(app)
+
+	tests := []struct {
+		method string
+		url    string
+	}{
+		{method: http.MethodGet, url: "/api/v1/system/cache/stats"},
+		{method: http.MethodPost, url: "/api/v1/system/config/reload"},
+		{method: http.MethodPost, url: "/api/v1/security/applications/app-1/keys?groupID=group-1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			require.Equal(t, http.StatusNotFound, recorder.Code)
+		})
+	}
+}
+
+func TestFeatureFlagMiddleware_Returns200WhenFlagEnabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags string
+	}{
+		{
+			name:  "enabled",
+			flags: "admin_api:false,app_portal:true",
+		},
+		{
+			name: "not set",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setEnv(t, "CONVOY_FEATURE_FLAGS", tc.flags)
+			err := config.LoadConfig("./testdata/Auth_Config/none-convoy.json")
+			require.NoError(t, err)
+
+			fn := featureFlagMiddleware(config.AppPortalFeatureFlag)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}))
+
+			recorder := httptest.NewRecorder()
+			fn.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/portal/apps", nil))
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
+
+// setEnv sets the environment variable key for the duration of the test,
+// it restores the previous value on cleanup.
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+			return
+		}
+		_ = os.Unsetenv(key)
+	})
+}
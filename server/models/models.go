@@ -15,14 +15,27 @@ type Group struct {
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit" valid:"int~please provide a valid rate limit,optional"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration" valid:"alphanum~please provide a valid rate limit duration,optional"`
 
+	Regions       []string `json:"regions,omitempty"`
+	PrimaryRegion string   `json:"primary_region,omitempty"`
+
 	Config datastore.GroupConfig
 }
 
+// GroupStatus is the status a group is moved to.
+type GroupStatus struct {
+	Status datastore.GroupStatus `json:"status" valid:"required~please provide a status"`
+}
+
 type APIKey struct {
-	Name      string            `json:"name"`
-	Role      auth.Role         `json:"role"`
-	Type      datastore.KeyType `json:"key_type"`
-	ExpiresAt time.Time         `json:"expires_at"`
+	Name       string            `json:"name"`
+	Role       auth.Role         `json:"role"`
+	Type       datastore.KeyType `json:"key_type"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	DailyQuota int64             `json:"daily_quota"`
+	RateLimit  int               `json:"rate_limit"`
+
+	// NeverExpires creates a key without an expiry, ExpiresAt is ignored.
+	NeverExpires bool `json:"never_expires"`
 }
 
 type APIKeyByIDResponse struct {
@@ -54,16 +67,61 @@ type PortalAPIKeyResponse struct {
 
 type Application struct {
 	AppName         string `json:"name" bson:"name" valid:"required~please provide your appName"`
+	OwnerID         string `json:"owner_id"`
 	SupportEmail    string `json:"support_email" bson:"support_email" valid:"email~please provide a valid email"`
 	IsDisabled      bool   `json:"is_disabled"`
 	SlackWebhookURL string `json:"slack_webhook_url" bson:"slack_webhook_url"`
+
+	CustomHeaders map[string]string `json:"custom_headers"`
+
+	// UseVersionedEndpoints sends each version of an event to its own
+	// path under the endpoint's url, e.g. {url}/v2.
+	UseVersionedEndpoints bool `json:"use_versioned_endpoints"`
 }
 
 type UpdateApplication struct {
 	AppName         *string `json:"name" bson:"name" valid:"required~please provide your appName"`
+	OwnerID         *string `json:"owner_id"`
 	SupportEmail    *string `json:"support_email" bson:"support_email" valid:"email~please provide a valid email"`
 	IsDisabled      *bool   `json:"is_disabled"`
 	SlackWebhookURL *string `json:"slack_webhook_url" bson:"slack_webhook_url"`
+
+	// CustomHeaders replaces the app's custom headers when it is set, an
+	// empty object removes them.
+	CustomHeaders map[string]string `json:"custom_headers"`
+
+	UseVersionedEndpoints *bool `json:"use_versioned_endpoints"`
+}
+
+type TransferApplication struct {
+	GroupID string `json:"group_id" valid:"required~please provide the group id"`
+}
+
+// EndpointPing is how an endpoint answered a test payload. The ids are
+// only set when the ping was recorded.
+type EndpointPing struct {
+	StatusCode      int    `json:"status_code"`
+	Status          string `json:"status,omitempty"`
+	LatencyMs       int64  `json:"latency_ms"`
+	Body            string `json:"body,omitempty"`
+	Error           string `json:"error,omitempty"`
+	EventID         string `json:"event_id,omitempty"`
+	EventDeliveryID string `json:"event_delivery_id,omitempty"`
+}
+
+type EventBridgeRule struct {
+	DestGroupID       string   `json:"dest_group_id" valid:"required~please provide the destination group id"`
+	EventTypeFilter   []string `json:"event_type_filter"`
+	TransformTemplate string   `json:"transform_template"`
+}
+
+type EventType struct {
+	Name        string `json:"name" valid:"required~please provide the event type name"`
+	Description string `json:"description"`
+
+	// Schema is an optional JSON Schema the data of events of the type
+	// has to match
+	Schema json.RawMessage `json:"schema,omitempty" swaggertype:"object"`
 }
 
 type Event struct {
@@ -73,6 +131,21 @@ type Event struct {
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data" bson:"data" valid:"required~please provide your data"`
+
+	// Metadata holds key-value pairs events can later be filtered by
+	Metadata map[string]string `json:"metadata,omitempty" bson:"metadata,omitempty"`
+
+	// Tags are lowercase labels events and their deliveries can later be
+	// filtered by, at most 10 of at most 64 characters each
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+
+	// EventVersion is the version of the event's schema, it defaults to 1
+	EventVersion string `json:"event_version,omitempty" bson:"event_version,omitempty"`
+
+	// IdempotencyKey identifies the event across retries, an event sent
+	// again with the same key within the group's idempotency window returns
+	// the first one instead of creating another
+	IdempotencyKey string `json:"idempotency_key,omitempty" bson:"-"`
 }
 
 type IDs struct {
@@ -90,22 +163,59 @@ type DeliveryAttempt struct {
 	MessageResponse MessageResponse `json:"response" bson:"response"`
 }
 
+// EventDeliveryTree is an event along with a page of its deliveries.
+type EventDeliveryTree struct {
+	Event      *datastore.Event         `json:"event"`
+	Deliveries []ExpandedEventDelivery  `json:"deliveries"`
+	Pagination datastore.PaginationData `json:"pagination"`
+}
+
+// ExpandedEventDelivery is an event delivery with the current status of its
+// endpoint and, when asked for, its delivery attempts.
+type ExpandedEventDelivery struct {
+	datastore.EventDelivery
+	EndpointStatus   datastore.EndpointStatus    `json:"endpoint_status"`
+	DeliveryAttempts []datastore.DeliveryAttempt `json:"delivery_attempts,omitempty"`
+}
+
 type MessageResponse struct {
 	Status int             `json:"status" bson:"status"`
 	Data   json.RawMessage `json:"data" bson:"data"`
 }
 
 type Endpoint struct {
-	URL         string   `json:"url" bson:"url"`
-	Secret      string   `json:"secret" bson:"secret"`
-	Description string   `json:"description" bson:"description"`
-	Events      []string `json:"events" bson:"events"`
+	URL         string                   `json:"url" bson:"url"`
+	Secret      string                   `json:"secret" bson:"secret"`
+	Description string                   `json:"description" bson:"description"`
+	Status      datastore.EndpointStatus `json:"status" bson:"status"`
+	Events      []string                 `json:"events" bson:"events"`
+
+	// Metadata is free form context for operators, it is not sent with
+	// events. A nil map leaves an endpoint's metadata unchanged on update.
+	Metadata map[string]string `json:"metadata" bson:"metadata"`
 
 	HttpTimeout       string `json:"http_timeout" bson:"http_timeout"`
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
 }
 
+// RateLimitUsage is how many of the requests a group or endpoint's rate
+// limit allows per duration have been used, and how long until all of
+// them are available again.
+type RateLimitUsage struct {
+	Limit      int    `json:"limit"`
+	Duration   string `json:"duration"`
+	Used       int    `json:"used"`
+	Remaining  int    `json:"remaining"`
+	ResetAfter string `json:"reset_after"`
+}
+
+// ExpandedEndpoint is an endpoint along with the usage of its rate limit.
+type ExpandedEndpoint struct {
+	datastore.Endpoint
+	RateLimitUsage *RateLimitUsage `json:"rate_limit_usage,omitempty"`
+}
+
 type DashboardSummary struct {
 	EventsSent   uint64                     `json:"events_sent" bson:"events_sent"`
 	Applications int                        `json:"apps" bson:"apps"`
@@ -113,7 +223,29 @@ type DashboardSummary struct {
 	PeriodData   *[]datastore.EventInterval `json:"event_data,omitempty" bson:"event_data"`
 }
 
+type AppStatistics struct {
+	Period               string  `json:"period"`
+	EventsCreated        int64   `json:"events_created"`
+	DeliveriesAttempted  int64   `json:"deliveries_attempted"`
+	SuccessfulDeliveries int64   `json:"successful_deliveries"`
+	FailedDeliveries     int64   `json:"failed_deliveries"`
+	SuccessRate          float64 `json:"success_rate"`
+	AverageLatencyMs     int64   `json:"average_latency_ms"`
+}
+
 type WebhookRequest struct {
 	Event string          `json:"event" bson:"event"`
 	Data  json.RawMessage `json:"data" bson:"data"`
 }
+
+// ForceCompleteStuckDeliveries fails the deliveries that have been in
+// processing for longer than MaxAge, a duration like "30m".
+type ForceCompleteStuckDeliveries struct {
+	MaxAge string `json:"max_age"`
+}
+
+type GlobalSettings struct {
+	RateLimit          int    `json:"rate_limit"`
+	RateLimitDuration  string `json:"rate_limit_duration"`
+	EventRetentionDays int    `json:"event_retention_days"`
+}
@@ -0,0 +1,161 @@
+//go:build integration
+// +build integration
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pubsub"
+	"github.com/frain-dev/convoy/services"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type streamedDelivery struct {
+	Transition string
+	Delivery   datastore.EventDelivery
+}
+
+// streamClient reads the named events of an app event delivery stream.
+type streamClient struct {
+	scanner *bufio.Scanner
+}
+
+func (c *streamClient) next(t *testing.T) streamedDelivery {
+	var update streamedDelivery
+	for c.scanner.Scan() {
+		line := c.scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			update.Transition = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &update.Delivery))
+			return update
+		}
+	}
+
+	t.Fatalf("stream ended before a delivery update was received: %v", c.scanner.Err())
+	return update
+}
+
+func openAppStream(t *testing.T, ctx context.Context, srv *httptest.Server, appID, key string) *http.Response {
+	url := fmt.Sprintf("%s/portal/apps/%s/eventdeliveries/stream", srv.URL, appID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestStreamAppEventDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	app.groupRepo = db.GroupRepo()
+	app.appRepo = db.AppRepo()
+	app.apiKeyRepo = db.APIRepo()
+	app.cache = mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	ctx := context.Background()
+
+	group := &datastore.Group{
+		UID:            uuid.NewString(),
+		Name:           "test-group",
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, app.groupRepo.CreateGroup(ctx, group))
+
+	newApp := func(title string) *datastore.Application {
+		a := &datastore.Application{
+			UID:            uuid.NewString(),
+			GroupID:        group.UID,
+			Title:          title,
+			Endpoints:      []datastore.Endpoint{},
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, app.appRepo.CreateApplication(ctx, a))
+		return a
+	}
+
+	application := newApp("app-1")
+	otherApplication := newApp("app-2")
+
+	baseUrl := "https://app.convoy.test"
+	securityService := services.NewSecurityService(app.groupRepo, app.appRepo, app.apiKeyRepo, app.auditLogRepo)
+	_, key, err := securityService.CreateAppPortalAPIKey(ctx, group, application, &baseUrl)
+	require.NoError(t, err)
+
+	err = config.LoadConfig("./testdata/Auth_Config/native-convoy.json")
+	require.NoError(t, err)
+	initRealmChain(t, app.apiKeyRepo)
+
+	srv := httptest.NewServer(buildRoutes(app))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	t.Run("should_reject_another_apps_stream", func(t *testing.T) {
+		resp := openAppStream(t, ctx, srv, otherApplication.UID, key)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should_stream_the_apps_transitions_in_order", func(t *testing.T) {
+		resp := openAppStream(t, ctx, srv, application.UID, key)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		delivery := func(appID string, status datastore.EventDeliveryStatus) datastore.EventDelivery {
+			return datastore.EventDelivery{
+				UID:         "delivery-" + appID,
+				Status:      status,
+				AppMetadata: &datastore.AppMetadata{UID: appID, GroupID: group.UID},
+			}
+		}
+
+		go func() {
+			pubsub.Publish(delivery(application.UID, datastore.ScheduledEventStatus))
+			pubsub.Publish(delivery(otherApplication.UID, datastore.ScheduledEventStatus))
+			pubsub.Publish(delivery(application.UID, datastore.RetryEventStatus))
+			pubsub.Publish(delivery(otherApplication.UID, datastore.SuccessEventStatus))
+			pubsub.Publish(delivery(application.UID, datastore.SuccessEventStatus))
+		}()
+
+		client := &streamClient{scanner: bufio.NewScanner(resp.Body)}
+		for _, transition := range []string{"created", "attempted", "success"} {
+			update := client.next(t)
+			require.Equal(t, transition, update.Transition)
+			require.Equal(t, application.UID, update.Delivery.AppMetadata.UID)
+		}
+	})
+}
@@ -1,13 +1,21 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
 	limiter "github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/quota"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/httprate"
 	"github.com/go-chi/render"
@@ -74,3 +82,173 @@ func rateLimitByGroupID(limiter limiter.RateLimiter) func(next http.Handler) htt
 		})
 	}
 }
+
+// endpointPingLimit is how many times a minute an endpoint can be pinged.
+const endpointPingLimit = 10
+
+// rateLimitEndpointPings keeps pings from being used to flood an endpoint
+// with requests.
+func rateLimitEndpointPings(limiter limiter.RateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			endpoint := getApplicationEndpointFromContext(r.Context())
+			key := fmt.Sprintf("endpoint_ping:%s", endpoint.UID)
+
+			res, err := limiter.ShouldAllow(r.Context(), key, endpointPingLimit, int(time.Minute))
+			if err != nil {
+				message := "an error occured while getting rate limit"
+				log.WithError(err).Error(message)
+				_ = render.Render(w, r, newErrorResponse(message, http.StatusBadRequest))
+				return
+			}
+
+			if res.Remaining <= 0 {
+				w.Header().Set("Retry-After", fmt.Sprintf("%v", res.RetryAfter))
+				_ = render.Render(w, r, newErrorResponse("Too Many Requests", http.StatusTooManyRequests))
+				return
+			}
+
+			_, err = limiter.Allow(r.Context(), key, endpointPingLimit, int(time.Minute))
+			if err != nil {
+				message := "an error occured while getting rate limit"
+				log.WithError(err).Error(message)
+				_ = render.Render(w, r, newErrorResponse(message, http.StatusBadRequest))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitAPIRequests limits how many api requests each api key can make
+// every api rate limit duration. Requests that weren't made with a key,
+// including every request when auth isn't required, are limited by the
+// address they came from.
+func rateLimitAPIRequests(limiter limiter.RateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg, err := config.Get()
+			if err != nil {
+				log.WithError(err).Error("failed to load configuration")
+				_ = render.Render(w, r, newErrorResponse("internal server error", http.StatusInternalServerError))
+				return
+			}
+
+			authUser := getAuthUserFromContext(r.Context())
+
+			rateLimit := cfg.Server.HTTP.APIRateLimit
+			if apiKey, ok := authUser.Metadata.(*datastore.APIKey); ok && apiKey.RateLimit > 0 {
+				rateLimit = apiKey.RateLimit
+			}
+
+			if rateLimit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			duration := time.Duration(cfg.Server.HTTP.APIRateLimitDuration) * time.Second
+			key := fmt.Sprintf("api_rate_limit:%s", apiRateLimitKey(r, authUser))
+
+			res, err := limiter.Allow(r.Context(), key, rateLimit, int(duration))
+			if err != nil {
+				message := "an error occured while getting rate limit"
+				log.WithError(err).Error(message)
+				_ = render.Render(w, r, newErrorResponse(message, http.StatusBadRequest))
+				return
+			}
+
+			// the limiters allow one more request than the limit, the
+			// request that takes the last one is rejected
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rateLimit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(math.Max(0, float64(res.Remaining-1)))))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(seconds(res.ResetAfter)))
+
+			if res.Remaining == 0 {
+				retryAfter := res.RetryAfter
+				if retryAfter <= 0 {
+					// the limiter allowed this request so it has no retry
+					// after, it lets a request back in every
+					// duration/(limit+1)
+					retryAfter = duration / time.Duration(rateLimit+1)
+				}
+
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, float64(seconds(retryAfter))))))
+				_ = render.Render(w, r, newErrorResponse("Too Many Requests", http.StatusTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// apiRateLimitKey identifies who a request counts against. API keys are
+// identified by their id or, for the file realm's, a hash of the key so
+// the key itself isn't stored.
+func apiRateLimitKey(r *http.Request, authUser *auth.AuthenticatedUser) string {
+	if apiKey, ok := authUser.Metadata.(*datastore.APIKey); ok {
+		return "key:" + apiKey.UID
+	}
+
+	// the noop realm doesn't name itself on the users it authenticates
+	if authUser.AuthenticatedByRealm != "" {
+		switch authUser.Credential.Type {
+		case auth.CredentialTypeAPIKey:
+			sum := sha256.Sum256([]byte(authUser.Credential.APIKey))
+			return "key:" + hex.EncodeToString(sum[:])
+		case auth.CredentialTypeBasic:
+			return "user:" + authUser.Credential.Username
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "address:" + host
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// apiKeyQuotaTTL is how long the daily counter of an api key is kept, an
+// hour past the end of its day so requests straddling midnight are counted.
+const apiKeyQuotaTTL = 25 * time.Hour
+
+// enforceAPIKeyQuota rejects requests made with an api key once it has
+// sent its daily quota of events. Keys without a quota aren't counted.
+func enforceAPIKeyQuota(counter quota.Counter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUser := getAuthUserFromContext(r.Context())
+
+			apiKey, ok := authUser.Metadata.(*datastore.APIKey)
+			if !ok || apiKey.DailyQuota <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := fmt.Sprintf("quota:%s:%s", apiKey.UID, time.Now().UTC().Format("2006-01-02"))
+			used, err := counter.Incr(r.Context(), key, apiKeyQuotaTTL)
+			if err != nil {
+				message := "an error occured while getting api key quota"
+				log.WithError(err).Error(message)
+				_ = render.Render(w, r, newErrorResponse(message, http.StatusBadRequest))
+				return
+			}
+
+			w.Header().Set("X-Quota-Remaining", fmt.Sprintf("%d", int64(math.Max(0, float64(apiKey.DailyQuota-used)))))
+
+			if used > apiKey.DailyQuota {
+				_ = render.Render(w, r, newErrorResponse("daily quota exceeded", http.StatusTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -7,7 +7,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	mlimiter "github.com/frain-dev/convoy/limiter/memory"
+	"github.com/frain-dev/convoy/mocks"
+	mquota "github.com/frain-dev/convoy/quota/memory"
+	"github.com/go-redis/redis_rate/v9"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRateLimitByGroup(t *testing.T) {
@@ -59,3 +67,210 @@ func TestRateLimitByGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitEndpointPings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limiter := mocks.NewMockRateLimiter(ctrl)
+	gomock.InOrder(
+		limiter.EXPECT().ShouldAllow(gomock.Any(), "endpoint_ping:endpoint-1", endpointPingLimit, int(time.Minute)).
+			Return(&redis_rate.Result{Remaining: 1}, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "endpoint_ping:endpoint-1", endpointPingLimit, int(time.Minute)).
+			Return(&redis_rate.Result{Remaining: 0}, nil),
+		limiter.EXPECT().ShouldAllow(gomock.Any(), "endpoint_ping:endpoint-1", endpointPingLimit, int(time.Minute)).
+			Return(&redis_rate.Result{Remaining: 0, RetryAfter: time.Minute}, nil),
+	)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	router := rateLimitEndpointPings(limiter)(h)
+
+	for i, code := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest("POST", "/", nil)
+		req = req.Clone(setApplicationEndpointInContext(req.Context(), &datastore.Endpoint{UID: "endpoint-1"}))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if respCode := recorder.Result().StatusCode; respCode != code {
+			t.Errorf("resp.StatusCode(%v) = %v, want %v", i, respCode, code)
+		}
+	}
+}
+
+func sendWithAPIKeyQuota(h http.Handler, apiKey *datastore.APIKey) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/", nil)
+	req = req.Clone(setAuthUserInContext(req.Context(), &auth.AuthenticatedUser{
+		Credential: auth.Credential{Type: auth.CredentialTypeAPIKey},
+		Metadata:   apiKey,
+	}))
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestQuotaEnforcement_AllowsUpToLimit(t *testing.T) {
+	apiKey := &datastore.APIKey{UID: "key-1", DailyQuota: 3}
+	h := enforceAPIKeyQuota(mquota.NewMemoryCounter())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i, remaining := range []string{"2", "1", "0"} {
+		recorder := sendWithAPIKeyQuota(h, apiKey)
+		require.Equal(t, http.StatusOK, recorder.Code, "request %d", i)
+		require.Equal(t, remaining, recorder.Header().Get("X-Quota-Remaining"))
+	}
+
+	// keys don't share quotas
+	recorder := sendWithAPIKeyQuota(h, &datastore.APIKey{UID: "key-2", DailyQuota: 3})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get("X-Quota-Remaining"))
+
+	// nor are keys without a quota counted
+	recorder = sendWithAPIKeyQuota(h, &datastore.APIKey{UID: "key-3"})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, recorder.Header().Get("X-Quota-Remaining"))
+}
+
+func TestQuotaEnforcement_RejectsOverLimit(t *testing.T) {
+	apiKey := &datastore.APIKey{UID: "key-1", DailyQuota: 2}
+	h := enforceAPIKeyQuota(mquota.NewMemoryCounter())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, sendWithAPIKeyQuota(h, apiKey).Code)
+	}
+
+	recorder := sendWithAPIKeyQuota(h, apiKey)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "0", recorder.Header().Get("X-Quota-Remaining"))
+	require.Contains(t, recorder.Body.String(), "daily quota exceeded")
+}
+
+func sendAPIRequest(h http.Handler, remoteAddr string, authUser *auth.AuthenticatedUser) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = remoteAddr
+	req = req.Clone(setAuthUserInContext(req.Context(), authUser))
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimitAPIRequests_ByAddressWithoutAuth(t *testing.T) {
+	err := config.LoadConfig("./testdata/Auth_Config/api-rate-limit-convoy.json")
+	require.NoError(t, err)
+
+	h := rateLimitAPIRequests(mlimiter.NewMemoryLimiter())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	noopUser := &auth.AuthenticatedUser{Credential: auth.Credential{Type: auth.CredentialTypeBasic, Username: "default"}}
+
+	for i, remaining := range []string{"1", "0"} {
+		recorder := sendAPIRequest(h, "10.0.0.1:4000", noopUser)
+		require.Equal(t, http.StatusOK, recorder.Code, "request %d", i)
+		require.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+		require.Equal(t, remaining, recorder.Header().Get("X-RateLimit-Remaining"))
+		require.Empty(t, recorder.Header().Get("Retry-After"))
+	}
+
+	// the port doesn't make it another client
+	recorder := sendAPIRequest(h, "10.0.0.1:4001", noopUser)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	require.Equal(t, "60", recorder.Header().Get("X-RateLimit-Reset"))
+	require.Equal(t, "20", recorder.Header().Get("Retry-After"))
+
+	recorder = sendAPIRequest(h, "10.0.0.1:4000", noopUser)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "20", recorder.Header().Get("Retry-After"))
+
+	recorder = sendAPIRequest(h, "10.0.0.2:4000", noopUser)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "1", recorder.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestRateLimitAPIRequests_ByAPIKey(t *testing.T) {
+	err := config.LoadConfig("./testdata/Auth_Config/api-rate-limit-convoy.json")
+	require.NoError(t, err)
+
+	h := rateLimitAPIRequests(mlimiter.NewMemoryLimiter())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	keyUser := func(apiKey *datastore.APIKey) *auth.AuthenticatedUser {
+		return &auth.AuthenticatedUser{
+			AuthenticatedByRealm: "native_realm",
+			Credential:           auth.Credential{Type: auth.CredentialTypeAPIKey},
+			Metadata:             apiKey,
+		}
+	}
+
+	// the key's own rate limit overrides the server's
+	override := keyUser(&datastore.APIKey{UID: "key-1", RateLimit: 1})
+	recorder := sendAPIRequest(h, "10.0.0.1:4000", override)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "1", recorder.Header().Get("X-RateLimit-Limit"))
+	require.Equal(t, http.StatusTooManyRequests, sendAPIRequest(h, "10.0.0.1:4000", override).Code)
+
+	// requests made with another key from the same address aren't limited
+	// by the first key's
+	other := keyUser(&datastore.APIKey{UID: "key-2"})
+	for i := 0; i < 2; i++ {
+		recorder = sendAPIRequest(h, "10.0.0.1:4000", other)
+		require.Equal(t, http.StatusOK, recorder.Code, "request %d", i)
+		require.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, sendAPIRequest(h, "10.0.0.1:4000", other).Code)
+
+	// file realm keys are told apart by the key
+	fileUser := func(key string) *auth.AuthenticatedUser {
+		return &auth.AuthenticatedUser{
+			AuthenticatedByRealm: "file_realm",
+			Credential:           auth.Credential{Type: auth.CredentialTypeAPIKey, APIKey: key},
+		}
+	}
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, sendAPIRequest(h, "10.0.0.1:4000", fileUser("file-key-1")).Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, sendAPIRequest(h, "10.0.0.1:4000", fileUser("file-key-1")).Code)
+	require.Equal(t, http.StatusOK, sendAPIRequest(h, "10.0.0.1:4000", fileUser("file-key-2")).Code)
+}
+
+func TestRateLimitAPIRequests_OffByDefault(t *testing.T) {
+	err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the limiter isn't used at all
+	h := rateLimitAPIRequests(mocks.NewMockRateLimiter(ctrl))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	recorder := sendAPIRequest(h, "10.0.0.1:4000", &auth.AuthenticatedUser{})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, recorder.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimitAPIRequests_HealthAndMetricsAreExempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := config.LoadConfig("./testdata/Auth_Config/api-rate-limit-convoy.json")
+	require.NoError(t, err)
+
+	app := provideApplication(ctrl)
+	app.limiter = mlimiter.NewMemoryLimiter()
+	initRealmChain(t, app.apiKeyRepo)
+	router := buildRoutes(app)
+
+	for i := 0; i < 5; i++ {
+		for _, path := range []string{"/health", "/v1/metrics"} {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, http.StatusOK, recorder.Code, "%s request %d", path, i)
+			require.Empty(t, recorder.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+
+	// while the api is limited
+	for _, code := range []int{http.StatusNotFound, http.StatusNotFound, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/system/cache/stats", nil)
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, code, recorder.Code)
+	}
+}
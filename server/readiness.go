@@ -0,0 +1,20 @@
+package server
+
+import "sync/atomic"
+
+// Readiness reports whether the server should be sent requests. It is
+// turned off when the server starts draining on shutdown, so load
+// balancers stop routing to it while the requests in flight finish.
+type Readiness struct {
+	draining int32
+}
+
+// Drain marks the server as not ready.
+func (r *Readiness) Drain() {
+	atomic.StoreInt32(&r.draining, 1)
+}
+
+// IsReady reports whether the server hasn't started draining.
+func (r *Readiness) IsReady() bool {
+	return atomic.LoadInt32(&r.draining) == 0
+}
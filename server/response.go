@@ -22,6 +22,7 @@ func (res Response) Render(w http.ResponseWriter, r *http.Request) error {
 func newErrorResponse(msg string, statusCode int) serverResponse {
 	return serverResponse{
 		Status:  false,
+		Code:    services.ErrorCodeFor(statusCode),
 		Message: msg,
 		Response: Response{
 			StatusCode: statusCode,
@@ -32,17 +33,23 @@ func newErrorResponse(msg string, statusCode int) serverResponse {
 func newServiceErrResponse(err error) serverResponse {
 	msg := ""
 	statusCode := http.StatusBadRequest
+	code := services.BadRequestErrorCode
+	var fields map[string]string
 	switch v := err.(type) {
 	case *services.ServiceError:
 		msg = v.Error()
 		statusCode = v.ErrCode()
+		code = v.Code()
+		fields = v.Fields()
 	case error:
 		msg = v.Error()
 	}
 
 	return serverResponse{
 		Status:  false,
+		Code:    code,
 		Message: msg,
+		Fields:  fields,
 		Response: Response{
 			StatusCode: statusCode,
 		},
@@ -51,9 +58,15 @@ func newServiceErrResponse(err error) serverResponse {
 
 type serverResponse struct {
 	Response
-	Status  bool            `json:"status"`
-	Message string          `json:"message"`
-	Data    json.RawMessage `json:"data,omitempty"`
+	Status bool `json:"status"`
+
+	// Code and Fields are only set on errors, Code names the error for
+	// clients and Fields holds the messages of the fields that failed
+	// validation.
+	Code    services.ErrorCode `json:"code,omitempty"`
+	Message string             `json:"message"`
+	Fields  map[string]string  `json:"fields,omitempty"`
+	Data    json.RawMessage    `json:"data,omitempty"`
 }
 
 func newServerResponse(msg string, object interface{}, statusCode int) serverResponse {
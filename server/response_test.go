@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frain-dev/convoy/services"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceErrResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "should_render_code_of_service_error",
+			err:        services.NewServiceErrorWithCode(services.GroupNameConflictErrorCode, errors.New("group name is reserved")),
+			statusCode: http.StatusConflict,
+			body:       `{"status":false,"code":"group_name_conflict","message":"group name is reserved"}`,
+		},
+		{
+			name:       "should_render_fields_of_validation_error",
+			err:        services.NewServiceErrorWithCode(services.ValidationFailedErrorCode, errors.New("name:please provide a valid name")).WithFields(map[string]string{"name": "please provide a valid name"}),
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":false,"code":"validation_failed","message":"name:please provide a valid name","fields":{"name":"please provide a valid name"}}`,
+		},
+		{
+			name:       "should_render_generic_code_of_plain_error",
+			err:        errors.New("failed"),
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":false,"code":"bad_request","message":"failed"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			require.NoError(t, render.Render(w, r, newServiceErrResponse(tt.err)))
+
+			require.Equal(t, tt.statusCode, w.Code)
+			require.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
@@ -1,17 +1,20 @@
 package server
 
 import (
+	"context"
 	"embed"
-	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/logger"
+	"github.com/frain-dev/convoy/objectstore"
 	"github.com/frain-dev/convoy/tracer"
 	"github.com/frain-dev/convoy/worker"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	limiter "github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/quota"
 	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/go-chi/render"
@@ -58,7 +62,10 @@ func buildRoutes(app *applicationHandler) http.Handler {
 	router.Use(middleware.RequestID)
 	router.Use(writeRequestIDHeader)
 	router.Use(instrumentRequests(app.tracer))
+	router.Use(tracer.TraceContextMiddleware)
 	router.Use(logHttpRequest(app.logger))
+	router.Use(limitRequestBody)
+	router.Use(regionRouter)
 
 	// Public API.
 	router.Route("/api", func(v1Router chi.Router) {
@@ -67,10 +74,12 @@ func buildRoutes(app *applicationHandler) http.Handler {
 			r.Use(middleware.AllowContentType("application/json"))
 			r.Use(jsonResponse)
 			r.Use(requireAuth())
+			r.Use(rateLimitAPIRequests(app.limiter))
 
 			r.Route("/groups", func(groupRouter chi.Router) {
 				groupRouter.Get("/", app.GetGroups)
 				groupRouter.With(requirePermission(auth.RoleSuperUser)).Post("/", app.CreateGroup)
+				groupRouter.With(requirePermission(auth.RoleSuperUser)).Post("/validate-config", app.ValidateGroupConfig)
 
 				groupRouter.Route("/{groupID}", func(groupSubRouter chi.Router) {
 					groupSubRouter.Use(requireGroup(app.groupRepo, app.cache))
@@ -78,7 +87,23 @@ func buildRoutes(app *applicationHandler) http.Handler {
 
 					groupSubRouter.With(requirePermission(auth.RoleAdmin)).Get("/", app.GetGroup)
 					groupSubRouter.With(requirePermission(auth.RoleSuperUser)).Put("/", app.UpdateGroup)
+					groupSubRouter.With(requirePermission(auth.RoleSuperUser)).Patch("/config", app.UpdateGroupConfig)
+					groupSubRouter.With(requirePermission(auth.RoleSuperUser)).Put("/status", app.UpdateGroupStatus)
 					groupSubRouter.With(requirePermission(auth.RoleSuperUser)).Delete("/", app.DeleteGroup)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin)).Get("/events/stream", app.StreamEventDeliveries)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin)).Get("/ratelimits", app.GetGroupRateLimits)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin), pagination).Get("/archives", app.GetArchives)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin), pagination).Get("/security/keys", app.GetGroupAPIKeys)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin), pagination).Get("/apps", app.GetApps)
+					groupSubRouter.With(requirePermission(auth.RoleAdmin)).Post("/event-deliveries/retry-all", app.RetryAllFailedEventDeliveries)
+
+					groupSubRouter.Route("/event-types", func(eventTypeRouter chi.Router) {
+						eventTypeRouter.Use(requirePermission(auth.RoleAdmin))
+
+						eventTypeRouter.Get("/", app.GetEventTypes)
+						eventTypeRouter.Post("/", app.CreateEventType)
+						eventTypeRouter.Delete("/{eventType}", app.DeleteEventType)
+					})
 				})
 			})
 
@@ -92,12 +117,20 @@ func buildRoutes(app *applicationHandler) http.Handler {
 					appRouter.With(pagination).Get("/", app.GetApps)
 				})
 
+				appRouter.With(requireAppScope()).Put("/{appID}/restore", app.RestoreApp)
+
 				appRouter.Route("/{appID}", func(appSubRouter chi.Router) {
+					appSubRouter.Use(requireAppScope())
 					appSubRouter.Use(requireApp(app.appRepo, app.cache))
 
 					appSubRouter.Get("/", app.GetApp)
 					appSubRouter.Put("/", app.UpdateApp)
 					appSubRouter.Delete("/", app.DeleteApp)
+					appSubRouter.Put("/pause", app.PauseApp)
+					appSubRouter.Put("/resume", app.ResumeApp)
+					appSubRouter.Post("/transfer", app.TransferApp)
+					appSubRouter.Get("/stats", app.GetAppStatistics)
+					appSubRouter.With(pagination).Get("/deliveries", app.GetAppEventDeliveries)
 
 					appSubRouter.Route("/endpoints", func(endpointAppSubRouter chi.Router) {
 						endpointAppSubRouter.Post("/", app.CreateAppEndpoint)
@@ -107,8 +140,10 @@ func buildRoutes(app *applicationHandler) http.Handler {
 							e.Use(requireAppEndpoint())
 
 							e.Get("/", app.GetAppEndpoint)
+							e.Get("/ratelimits", app.GetAppEndpointRateLimits)
 							e.Put("/", app.UpdateAppEndpoint)
 							e.Delete("/", app.DeleteAppEndpoint)
+							e.With(rateLimitEndpointPings(app.limiter)).Post("/ping", app.PingAppEndpoint)
 						})
 					})
 				})
@@ -119,12 +154,15 @@ func buildRoutes(app *applicationHandler) http.Handler {
 				eventRouter.Use(rateLimitByGroupID(app.limiter))
 				eventRouter.Use(requirePermission(auth.RoleAdmin))
 
-				eventRouter.With(instrumentPath("/events")).Post("/", app.CreateAppEvent)
+				eventRouter.With(instrumentPath("/events"), enforceAPIKeyQuota(app.quota)).Post("/", app.CreateAppEvent)
 				eventRouter.With(pagination).Get("/", app.GetEventsPaged)
+				eventRouter.Get("/count", app.GetEventsCount)
+				eventRouter.Get("/export", app.ExportEvents)
 
 				eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 					eventSubRouter.Use(requireEvent(app.eventRepo))
 					eventSubRouter.Get("/", app.GetAppEvent)
+					eventSubRouter.With(pagination).Get("/deliveries", app.GetEventDeliveryTree)
 				})
 			})
 
@@ -133,9 +171,11 @@ func buildRoutes(app *applicationHandler) http.Handler {
 				eventDeliveryRouter.Use(requirePermission(auth.RoleAdmin))
 
 				eventDeliveryRouter.With(pagination).Get("/", app.GetEventDeliveriesPaged)
+				eventDeliveryRouter.Get("/count", app.GetEventDeliveriesCount)
 				eventDeliveryRouter.Post("/forceresend", app.ForceResendEventDeliveries)
-				eventDeliveryRouter.Post("/batchretry", app.BatchRetryEventDelivery)
-				eventDeliveryRouter.Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
+				eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Post("/batchretry", app.BatchRetryEventDelivery)
+				eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
+				eventDeliveryRouter.Get("/export", app.ExportEventDeliveries)
 
 				eventDeliveryRouter.Route("/{eventDeliveryID}", func(eventDeliverySubRouter chi.Router) {
 					eventDeliverySubRouter.Use(requireEventDelivery(app.eventDeliveryRepo))
@@ -152,6 +192,50 @@ func buildRoutes(app *applicationHandler) http.Handler {
 				})
 			})
 
+			r.Route("/exports", func(exportRouter chi.Router) {
+				exportRouter.Use(requireGroup(app.groupRepo, app.cache))
+				exportRouter.Use(requirePermission(auth.RoleAdmin))
+
+				exportRouter.Get("/{exportID}", app.GetExportJob)
+				exportRouter.Get("/{exportID}/download", app.DownloadExport)
+			})
+
+			r.Route("/archives", func(archiveRouter chi.Router) {
+				archiveRouter.Use(requireGroup(app.groupRepo, app.cache))
+				archiveRouter.Use(requirePermission(auth.RoleAdmin))
+
+				archiveRouter.Post("/{archiveID}/restore", app.RestoreArchive)
+			})
+
+			r.Route("/event-bridges", func(eventBridgeRouter chi.Router) {
+				eventBridgeRouter.Use(requireGroup(app.groupRepo, app.cache))
+				eventBridgeRouter.Use(requirePermission(auth.RoleAdmin))
+
+				eventBridgeRouter.Get("/", app.GetEventBridgeRules)
+				eventBridgeRouter.Post("/", app.CreateEventBridgeRule)
+				eventBridgeRouter.Delete("/{ruleID}", app.DeleteEventBridgeRule)
+			})
+
+			r.Route("/admin", func(adminRouter chi.Router) {
+				adminRouter.Use(featureFlagMiddleware(config.AdminAPIFeatureFlag))
+				adminRouter.Use(requirePermission(auth.RoleSuperUser))
+
+				adminRouter.With(pagination).Get("/audit-log", app.GetAuditLogs)
+				adminRouter.Get("/groups/alerts", app.GetGroupsWithActiveAlerts)
+				adminRouter.Post("/event-deliveries/force-complete-stuck", app.ForceCompleteStuckEventDeliveries)
+				adminRouter.Get("/settings", app.GetGlobalSettings)
+				adminRouter.Put("/settings", app.UpdateGlobalSettings)
+			})
+
+			r.Route("/system", func(systemRouter chi.Router) {
+				systemRouter.Use(featureFlagMiddleware(config.AdminAPIFeatureFlag))
+				systemRouter.Use(requirePermission(auth.RoleSuperUser))
+
+				systemRouter.Get("/datastore/stats", app.GetDatastoreStats)
+				systemRouter.Get("/cache/stats", app.GetCacheStats)
+				systemRouter.Post("/config/reload", app.ReloadConfig)
+			})
+
 			r.Route("/security", func(securityRouter chi.Router) {
 				securityRouter.Route("/", func(securitySubRouter chi.Router) {
 					securitySubRouter.Use(requirePermission(auth.RoleSuperUser))
@@ -164,8 +248,10 @@ func buildRoutes(app *applicationHandler) http.Handler {
 				})
 
 				securityRouter.Route("/applications/{appID}/keys", func(securitySubRouter chi.Router) {
+					securitySubRouter.Use(featureFlagMiddleware(config.AppPortalFeatureFlag))
 					securitySubRouter.Use(requirePermission(auth.RoleAdmin))
 					securitySubRouter.Use(requireGroup(app.groupRepo, app.cache))
+					securitySubRouter.Use(requireAppScope())
 					securitySubRouter.Use(requireApp(app.appRepo, app.cache))
 					securitySubRouter.Use(requireBaseUrl())
 					securitySubRouter.Post("/", app.CreateAppPortalAPIKey)
@@ -179,10 +265,12 @@ func buildRoutes(app *applicationHandler) http.Handler {
 		uiRouter.Use(jsonResponse)
 		uiRouter.Use(setupCORS)
 		uiRouter.Use(requireAuth())
+		uiRouter.Use(rateLimitAPIRequests(app.limiter))
 
 		uiRouter.Route("/dashboard", func(dashboardRouter chi.Router) {
 			dashboardRouter.Use(requireGroup(app.groupRepo, app.cache))
 			dashboardRouter.Use(rateLimitByGroupID(app.limiter))
+			dashboardRouter.Use(requirePermission(auth.RoleUIAdmin))
 
 			dashboardRouter.Get("/summary", app.GetDashboardSummary)
 			dashboardRouter.Get("/config", app.GetAllConfigDetails)
@@ -210,13 +298,22 @@ func buildRoutes(app *applicationHandler) http.Handler {
 				appRouter.With(pagination).Get("/", app.GetApps)
 			})
 
+			appRouter.With(requireAppScope()).Put("/{appID}/restore", app.RestoreApp)
+
 			appRouter.Route("/{appID}", func(appSubRouter chi.Router) {
+				appSubRouter.Use(requireAppScope())
 				appSubRouter.Use(requireApp(app.appRepo, app.cache))
 				appSubRouter.Get("/", app.GetApp)
 				appSubRouter.Put("/", app.UpdateApp)
 				appSubRouter.Delete("/", app.DeleteApp)
+				appSubRouter.Put("/pause", app.PauseApp)
+				appSubRouter.Put("/resume", app.ResumeApp)
+				appSubRouter.Post("/transfer", app.TransferApp)
+				appSubRouter.Get("/stats", app.GetAppStatistics)
+				appSubRouter.With(pagination).Get("/deliveries", app.GetAppEventDeliveries)
 
 				appSubRouter.Route("/keys", func(keySubRouter chi.Router) {
+					keySubRouter.Use(featureFlagMiddleware(config.AppPortalFeatureFlag))
 					keySubRouter.Use(requireGroup(app.groupRepo, app.cache))
 					keySubRouter.Use(requireApp(app.appRepo, app.cache))
 					keySubRouter.Use(requireBaseUrl())
@@ -234,6 +331,7 @@ func buildRoutes(app *applicationHandler) http.Handler {
 						e.Get("/", app.GetAppEndpoint)
 						e.Put("/", app.UpdateAppEndpoint)
 						e.Delete("/", app.DeleteAppEndpoint)
+						e.With(rateLimitEndpointPings(app.limiter)).Post("/ping", app.PingAppEndpoint)
 					})
 				})
 			})
@@ -246,6 +344,7 @@ func buildRoutes(app *applicationHandler) http.Handler {
 
 			eventRouter.Post("/", app.CreateAppEvent)
 			eventRouter.With(pagination).Get("/", app.GetEventsPaged)
+			eventRouter.Get("/count", app.GetEventsCount)
 
 			eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 				eventSubRouter.Use(requireEvent(app.eventRepo))
@@ -259,9 +358,10 @@ func buildRoutes(app *applicationHandler) http.Handler {
 			eventDeliveryRouter.Use(requirePermission(auth.RoleUIAdmin))
 
 			eventDeliveryRouter.With(pagination).Get("/", app.GetEventDeliveriesPaged)
+			eventDeliveryRouter.Get("/count", app.GetEventDeliveriesCount)
 			eventDeliveryRouter.Post("/forceresend", app.ForceResendEventDeliveries)
-			eventDeliveryRouter.Post("/batchretry", app.BatchRetryEventDelivery)
-			eventDeliveryRouter.Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
+			eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Post("/batchretry", app.BatchRetryEventDelivery)
+			eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
 
 			eventDeliveryRouter.Route("/{eventDeliveryID}", func(eventDeliverySubRouter chi.Router) {
 				eventDeliverySubRouter.Use(requireEventDelivery(app.eventDeliveryRepo))
@@ -282,16 +382,19 @@ func buildRoutes(app *applicationHandler) http.Handler {
 	//App Portal API.
 	router.Route("/portal", func(portalRouter chi.Router) {
 		portalRouter.Use(jsonResponse)
+		portalRouter.Use(featureFlagMiddleware(config.AppPortalFeatureFlag))
 		portalRouter.Use(setupCORS)
 		portalRouter.Use(requireAuth())
 		portalRouter.Use(requireGroup(app.groupRepo, app.cache))
 		portalRouter.Use(requireAppID())
+		portalRouter.Use(requireAppScope())
 
 		portalRouter.Route("/apps", func(appRouter chi.Router) {
 			appRouter.Use(requireAppPortalApplication(app.appRepo))
 			appRouter.Use(requireAppPortalPermission(auth.RoleUIAdmin))
 
 			appRouter.Get("/", app.GetApp)
+			appRouter.With(requireAppScope()).Get("/{appID}/eventdeliveries/stream", app.StreamAppEventDeliveries)
 
 			appRouter.Route("/endpoints", func(endpointAppSubRouter chi.Router) {
 				endpointAppSubRouter.Get("/", app.GetAppEndpoints)
@@ -318,14 +421,21 @@ func buildRoutes(app *applicationHandler) http.Handler {
 			})
 		})
 
+		portalRouter.Route("/event-types", func(eventTypeRouter chi.Router) {
+			eventTypeRouter.Use(requireAppPortalApplication(app.appRepo))
+			eventTypeRouter.Use(requireAppPortalPermission(auth.RoleUIAdmin))
+
+			eventTypeRouter.Get("/", app.GetEventTypes)
+		})
+
 		portalRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
 			eventDeliveryRouter.Use(requireAppPortalApplication(app.appRepo))
 			eventDeliveryRouter.Use(requireAppPortalPermission(auth.RoleUIAdmin))
 
 			eventDeliveryRouter.With(pagination).Get("/", app.GetEventDeliveriesPaged)
 			eventDeliveryRouter.Post("/forceresend", app.ForceResendEventDeliveries)
-			eventDeliveryRouter.Post("/batchretry", app.BatchRetryEventDelivery)
-			eventDeliveryRouter.Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
+			eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Post("/batchretry", app.BatchRetryEventDelivery)
+			eventDeliveryRouter.With(featureFlagMiddleware(config.EventBatchingFeatureFlag)).Get("/countbatchretryevents", app.CountAffectedEventDeliveries)
 
 			eventDeliveryRouter.Route("/{eventDeliveryID}", func(eventDeliverySubRouter chi.Router) {
 				eventDeliverySubRouter.Use(requireEventDelivery(app.eventDeliveryRepo))
@@ -347,6 +457,7 @@ func buildRoutes(app *applicationHandler) http.Handler {
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newServerResponse("Convoy", nil, http.StatusOK))
 	})
+	router.HandleFunc("/ready", app.Ready)
 	router.HandleFunc("/*", reactRootHandler)
 
 	return router
@@ -357,13 +468,24 @@ func New(cfg config.Configuration,
 	eventDeliveryRepo datastore.EventDeliveryRepository,
 	appRepo datastore.ApplicationRepository,
 	apiKeyRepo datastore.APIKeyRepository,
+	auditLogRepo datastore.AuditLogRepository,
+	archiveRepo datastore.ArchiveRepository,
+	exportJobRepo datastore.ExportJobRepository,
+	eventBridgeRepo datastore.EventBridgeRepository,
+	eventTypeRepo datastore.EventTypeRepository,
+	settingsRepo datastore.GlobalSettingsRepository,
 	orgRepo datastore.GroupRepository,
+	database datastore.DatabaseClient,
+	configReloader *config.Reloader,
+	readiness *Readiness,
 	eventQueue queue.Queuer,
 	createEventQueue queue.Queuer,
 	logger logger.Logger,
 	tracer tracer.Tracer,
 	cache cache.Cache,
-	limiter limiter.RateLimiter) *http.Server {
+	limiter limiter.RateLimiter,
+	quota quota.Counter,
+	objectStore objectstore.ObjectStore) *http.Server {
 
 	app := newApplicationHandler(
 		eventRepo,
@@ -371,23 +493,73 @@ func New(cfg config.Configuration,
 		appRepo,
 		orgRepo,
 		apiKeyRepo,
+		auditLogRepo,
+		archiveRepo,
+		exportJobRepo,
+		eventBridgeRepo,
+		eventTypeRepo,
+		settingsRepo,
 		eventQueue,
 		createEventQueue,
 		logger,
 		tracer,
 		cache,
-		limiter)
+		limiter,
+		quota,
+		objectStore)
+
+	app.database = database
+	app.configReloader = configReloader
+	app.readiness = readiness
+
+	shutdown, closeStreams := context.WithCancel(context.Background())
+	app.shutdown = shutdown
+
+	if cfg.Server.HTTP.StreamHeartbeatInterval != 0 {
+		app.streamHeartbeatInterval = time.Duration(cfg.Server.HTTP.StreamHeartbeatInterval) * time.Second
+	}
+
+	if cfg.Server.HTTP.StreamMaxDuration != 0 {
+		app.streamMaxDuration = time.Duration(cfg.Server.HTTP.StreamMaxDuration) * time.Second
+	}
+
+	if cfg.Server.HTTP.MaxCountDateRange != 0 {
+		app.maxCountDateRange = time.Duration(cfg.Server.HTTP.MaxCountDateRange) * 24 * time.Hour
+	}
 
+	httpConfig := cfg.Server.HTTP
 	srv := &http.Server{
 		Handler:      buildRoutes(app),
-		ReadTimeout:  time.Second * 30,
-		WriteTimeout: time.Second * 30,
-		Addr:         fmt.Sprintf(":%d", cfg.Server.HTTP.Port),
+		ReadTimeout:  serverTimeout(httpConfig.GetReadTimeout, config.DefaultHTTPReadTimeout),
+		WriteTimeout: serverTimeout(httpConfig.GetWriteTimeout, config.DefaultHTTPWriteTimeout),
+		IdleTimeout:  serverTimeout(httpConfig.GetIdleTimeout, config.DefaultHTTPIdleTimeout),
+		Addr:         net.JoinHostPort(httpConfig.Host, strconv.FormatUint(uint64(httpConfig.Port), 10)),
+	}
+
+	// the write timeout covers the whole response, streams included
+	if srv.WriteTimeout > 0 && srv.WriteTimeout < app.streamMaxDuration {
+		log.Warnf("event streams are cut off by the %s http write timeout before their max duration of %s", srv.WriteTimeout, app.streamMaxDuration)
 	}
 
+	// Shutdown waits for connections to go idle, which open streams never do
+	srv.RegisterOnShutdown(closeStreams)
+
 	RegisterDBMetrics(app)
 	RegisterQueueMetrics(eventQueue, cfg)
+	RegisterCacheMetrics(cache)
 	worker.RegisterWorkerMetrics(eventQueue, cfg)
 	prometheus.MustRegister(requestDuration)
 	return srv
 }
+
+// serverTimeout returns the timeout get reads from the config, the config
+// has been validated by now so fallback is only used if that changes.
+func serverTimeout(get func() (time.Duration, error), fallback time.Duration) time.Duration {
+	timeout, err := get()
+	if err != nil {
+		log.WithError(err).Errorf("using the default timeout %s", fallback)
+		return fallback
+	}
+
+	return timeout
+}
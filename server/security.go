@@ -32,7 +32,7 @@ func (a *applicationHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	apiKey, keyString, err := a.securityService.CreateAPIKey(r.Context(), &newApiKey)
+	apiKey, keyString, err := a.securityService.CreateAPIKey(r.Context(), getActorID(r.Context()), &newApiKey)
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
@@ -100,7 +100,7 @@ func (a *applicationHandler) CreateAppPortalAPIKey(w http.ResponseWriter, r *htt
 // @Security ApiKeyAuth
 // @Router /security/keys/{keyID}/revoke [put]
 func (a *applicationHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
-	err := a.securityService.RevokeAPIKey(r.Context(), chi.URLParam(r, "keyID"))
+	err := a.securityService.RevokeAPIKey(r.Context(), getActorID(r.Context()), chi.URLParam(r, "keyID"))
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
@@ -161,7 +161,7 @@ func (a *applicationHandler) UpdateAPIKey(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	apiKey, err := a.securityService.UpdateAPIKey(r.Context(), chi.URLParam(r, "keyID"), &updateApiKey.Role)
+	apiKey, err := a.securityService.UpdateAPIKey(r.Context(), getActorID(r.Context()), chi.URLParam(r, "keyID"), &updateApiKey.Role)
 	if err != nil {
 		_ = render.Render(w, r, newServiceErrResponse(err))
 		return
@@ -208,6 +208,62 @@ func (a *applicationHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request)
 		pagedResponse{Content: &apiKeyByIDResponse, Pagination: &paginationData}, http.StatusOK))
 }
 
+// GetGroupAPIKeys
+// @Summary Fetch the api keys of a group
+// @Description This endpoint fetches the api keys scoped to a group
+// @Tags APIKey
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.APIKey}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /groups/{groupID}/security/keys [get]
+func (a *applicationHandler) GetGroupAPIKeys(w http.ResponseWriter, r *http.Request) {
+	pageable := getPageableFromContext(r.Context())
+	group := getGroupFromContext(r.Context())
+
+	apiKeys, paginationData, err := a.securityService.ListAPIKeysByGroup(r.Context(), group.UID, &pageable)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	apiKeyByIDResponse := apiKeyByIDResponse(apiKeys)
+	_ = render.Render(w, r, newServerResponse("api keys fetched successfully",
+		pagedResponse{Content: &apiKeyByIDResponse, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetAuditLogs
+// @Summary Fetch audit logs
+// @Description This endpoint fetches audit logs, most recent first unless sort is asc
+// @Tags AuditLog
+// @Accept  json
+// @Produce  json
+// @Param resourceType query string false "resource type e.g api_key"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.AuditLog}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /admin/audit-log [get]
+func (a *applicationHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	pageable := getPageableFromContext(r.Context())
+
+	logs, paginationData, err := a.securityService.GetAuditLogs(r.Context(), r.URL.Query().Get("resourceType"), pageable)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("audit logs fetched successfully",
+		pagedResponse{Content: &logs, Pagination: &paginationData}, http.StatusOK))
+}
+
 func apiKeyByIDResponse(apiKeys []datastore.APIKey) []models.APIKeyByIDResponse {
 	apiKeyByIDResponse := []models.APIKeyByIDResponse{}
 
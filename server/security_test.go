@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
@@ -72,6 +73,8 @@ func TestApplicationHandler_CreateAPIKey(t *testing.T) {
 					FetchGroupsByIDs(gomock.Any(), gomock.Any()).
 					Times(2).Return([]datastore.Group{*group}, nil)
 				a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().Append(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 		},
 		{
@@ -106,6 +109,8 @@ func TestApplicationHandler_CreateAPIKey(t *testing.T) {
 			dbFn: func(app *applicationHandler) {
 				a, _ := app.apiKeyRepo.(*mocks.MockAPIKeyRepository)
 				a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().Append(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 		},
 		{
@@ -314,6 +319,8 @@ func TestApplicationHandler_RevokeAPIKey(t *testing.T) {
 			dbFn: func(app *applicationHandler) {
 				a, _ := app.apiKeyRepo.(*mocks.MockAPIKeyRepository)
 				a.EXPECT().RevokeAPIKeys(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().Append(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 		},
 		{
@@ -529,6 +536,103 @@ func TestApplicationHandler_GetAPIKeys(t *testing.T) {
 	}
 }
 
+func TestApplicationHandler_GetGroupAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	groupID := "1234567890"
+	group := &datastore.Group{UID: groupID}
+
+	apiKey := &datastore.APIKey{
+		UID:  "12345",
+		Role: auth.Role{Type: auth.RoleAdmin, Groups: []string{groupID}},
+	}
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_load_group_api_keys",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				g.EXPECT().
+					FetchGroupByID(gomock.Any(), groupID).
+					Times(1).Return(group, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+				a, _ := app.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				a.EXPECT().
+					FindAPIKeysByGroupID(gomock.Any(), groupID, gomock.Any()).
+					Times(1).
+					Return(
+						[]datastore.APIKey{*apiKey},
+						datastore.PaginationData{Total: 1, Page: 1, PerPage: 10, TotalPage: 1}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_load_group_api_keys",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler) {
+				g, _ := app.groupRepo.(*mocks.MockGroupRepository)
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				g.EXPECT().
+					FetchGroupByID(gomock.Any(), groupID).
+					Times(1).Return(group, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+				a, _ := app.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				a.EXPECT().
+					FindAPIKeysByGroupID(gomock.Any(), groupID, gomock.Any()).
+					Times(1).Return(nil, datastore.PaginationData{}, errors.New("abc"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			url := fmt.Sprintf("/api/v1/groups/%s/security/keys?perPage=10&page=1", groupID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.SetBasicAuth("test", "test")
+			w := httptest.NewRecorder()
+			req.Header.Add("Content-Type", "application/json")
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
 func TestApplicationHandler_UpdateAPIKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -586,6 +690,8 @@ func TestApplicationHandler_UpdateAPIKey(t *testing.T) {
 					Times(1).Return([]datastore.Group{*group}, nil)
 				a.EXPECT().FindAPIKeyByID(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
 				a.EXPECT().UpdateAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().Append(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 		},
 		{
@@ -641,3 +747,91 @@ func TestApplicationHandler_UpdateAPIKey(t *testing.T) {
 	}
 
 }
+
+func TestApplicationHandler_GetAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app := provideApplication(ctrl)
+
+	page := &datastore.Pageable{
+		Page:    1,
+		PerPage: 100,
+		Sort:    -1,
+	}
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		statusCode int
+		dbFn       func(app *applicationHandler)
+	}{
+		{
+			name:       "should_load_audit_logs",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusOK,
+			dbFn: func(app *applicationHandler) {
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().
+					LoadAuditLogsPaged(gomock.Any(), datastore.APIKeyAuditResourceType, *page).
+					Times(1).
+					Return(
+						[]datastore.AuditLog{
+							{
+								UID:          "1234",
+								Action:       datastore.AuditActionCreate,
+								ActorID:      "test",
+								ResourceType: datastore.APIKeyAuditResourceType,
+								ResourceID:   "12345",
+								Metadata:     map[string]string{"name": "test_api_key"},
+							},
+						},
+						datastore.PaginationData{PerPage: int64(page.PerPage)}, nil)
+			},
+		},
+		{
+			name:       "should_fail_to_load_audit_logs",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusBadRequest,
+			dbFn: func(app *applicationHandler) {
+				l, _ := app.auditLogRepo.(*mocks.MockAuditLogRepository)
+				l.EXPECT().
+					LoadAuditLogsPaged(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil, datastore.PaginationData{}, errors.New("abc"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			url := fmt.Sprintf("/api/v1/admin/audit-log?resourceType=api_key&perPage=%d&page=%d", page.PerPage, page.Page)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(app)
+			}
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
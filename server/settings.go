@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/render"
+)
+
+// GetGlobalSettings
+// @Summary Get global settings
+// @Description This endpoint fetches the defaults new groups are created with
+// @Tags Settings
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} serverResponse{data=datastore.GlobalSettings}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /admin/settings [get]
+func (a *applicationHandler) GetGlobalSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := a.settingsService.GetGlobalSettings(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Global settings fetched successfully", settings, http.StatusOK))
+}
+
+// UpdateGlobalSettings
+// @Summary Update global settings
+// @Description This endpoint updates the defaults new groups are created with, existing groups keep theirs
+// @Tags Settings
+// @Accept  json
+// @Produce  json
+// @Param settings body models.GlobalSettings true "Global settings"
+// @Success 200 {object} serverResponse{data=datastore.GlobalSettings}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /admin/settings [put]
+func (a *applicationHandler) UpdateGlobalSettings(w http.ResponseWriter, r *http.Request) {
+	var update models.GlobalSettings
+	err := util.ReadJSON(r, &update)
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	settings, err := a.settingsService.UpdateGlobalSettings(r.Context(), &update)
+	if err != nil {
+		_ = render.Render(w, r, newServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Global settings updated successfully", settings, http.StatusOK))
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/go-chi/render"
+	log "github.com/sirupsen/logrus"
+)
+
+// Ready reports whether the server takes requests, it fails once the
+// server has started draining on shutdown.
+func (a *applicationHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if a.readiness != nil && !a.readiness.IsReady() {
+		_ = render.Render(w, r, newErrorResponse("convoy is shutting down", http.StatusServiceUnavailable))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Convoy is ready", nil, http.StatusOK))
+}
+
+// GetDatastoreStats
+// @Summary Get datastore statistics
+// @Description This endpoint fetches the datastore's collection sizes, indexes, connection pool and replication lag
+// @Tags System
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} serverResponse{data=datastore.DatastoreStats}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /system/datastore/stats [get]
+func (a *applicationHandler) GetDatastoreStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := a.database.Stats(r.Context())
+	if err != nil {
+		log.WithError(err).Error("failed to fetch datastore stats")
+		_ = render.Render(w, r, newErrorResponse("failed to fetch datastore stats", http.StatusInternalServerError))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Datastore stats fetched successfully", stats, http.StatusOK))
+}
+
+// GetCacheStats
+// @Summary Get cache statistics
+// @Description This endpoint fetches the cache's hits, misses, sets, deletes and errors by key prefix
+// @Tags System
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} serverResponse{data=cache.Stats}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /system/cache/stats [get]
+func (a *applicationHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	ic, ok := a.cache.(*cache.InstrumentedCache)
+	if !ok {
+		_ = render.Render(w, r, newErrorResponse("the cache doesn't keep stats", http.StatusNotFound))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Cache stats fetched successfully", ic.Stats(), http.StatusOK))
+}
+
+// ReloadConfig
+// @Summary Reload the config
+// @Description This endpoint reloads the config file and swaps it in for the running config, fields that need a restart to change keep their running values and are reported
+// @Tags System
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} serverResponse{data=config.ReloadResult}
+// @Failure 400,401,404 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /system/config/reload [post]
+func (a *applicationHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if a.configReloader == nil {
+		_ = render.Render(w, r, newErrorResponse("config reloading is not enabled", http.StatusNotFound))
+		return
+	}
+
+	result, err := a.configReloader.Reload()
+	if err != nil {
+		_ = render.Render(w, r, newErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	_ = render.Render(w, r, newServerResponse("Config reloaded successfully", result, http.StatusOK))
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/golang/mock/gomock"
+)
+
+type fakeDatabaseClient struct {
+	datastore.DatabaseClient
+	stats *datastore.DatastoreStats
+	err   error
+}
+
+func (f *fakeDatabaseClient) Stats(context.Context) (*datastore.DatastoreStats, error) {
+	return f.stats, f.err
+}
+
+func TestApplicationHandler_GetDatastoreStats(t *testing.T) {
+	lag := int64(1500)
+
+	tt := []struct {
+		name       string
+		cfgPath    string
+		statusCode int
+		database   *fakeDatabaseClient
+	}{
+		{
+			name:       "should_fetch_datastore_stats",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusOK,
+			database: &fakeDatabaseClient{
+				stats: &datastore.DatastoreStats{
+					Name:        "mongo",
+					StorageSize: 8192,
+					Collections: []datastore.CollectionStats{
+						{
+							Name:        "groups",
+							Documents:   2,
+							StorageSize: 4096,
+							Indexes:     []string{"_id_", "uid_1"},
+						},
+						{
+							Name:        "events",
+							Documents:   40,
+							StorageSize: 4096,
+							Indexes:     []string{"_id_"},
+						},
+					},
+					Pool:           &datastore.PoolStats{Open: 3, InUse: 1, MaxSize: 100},
+					ReplicationLag: &lag,
+				},
+			},
+		},
+		{
+			name:       "should_fail_to_fetch_datastore_stats",
+			cfgPath:    "./testdata/Auth_Config/no-auth-convoy.json",
+			statusCode: http.StatusInternalServerError,
+			database:   &fakeDatabaseClient{err: errors.New("connection refused")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := provideApplication(ctrl)
+			app.database = tc.database
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/system/datastore/stats", nil)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			err := config.LoadConfig(tc.cfgPath)
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestApplicationHandler_GetCacheStats(t *testing.T) {
+	ctx := context.Background()
+
+	tt := []struct {
+		name       string
+		statusCode int
+		cache      func() cache.Cache
+	}{
+		{
+			name:       "should_fetch_cache_stats",
+			statusCode: http.StatusOK,
+			cache: func() cache.Cache {
+				c := cache.NewInstrumentedCache(mcache.NewMemoryCache(config.MemoryCacheConfiguration{}))
+
+				var group datastore.Group
+				key := convoy.GroupsCacheKey.Get("group-1").String()
+				_ = c.Get(ctx, key, &group)
+				_ = c.Set(ctx, key, &datastore.Group{UID: "group-1"}, time.Minute)
+				_ = c.Get(ctx, key, &group)
+				_ = c.Delete(ctx, convoy.ApplicationsCacheKey.Get("app-1").String())
+
+				return c
+			},
+		},
+		{
+			name:       "should_fail_for_uninstrumented_cache",
+			statusCode: http.StatusNotFound,
+			cache: func() cache.Cache {
+				return mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := provideApplication(ctrl)
+			app.cache = tc.cache()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/system/cache/stats", nil)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
+
+func TestApplicationHandler_ReloadConfig(t *testing.T) {
+	invalidCfgPath := filepath.Join(t.TempDir(), "convoy.json")
+	err := os.WriteFile(invalidCfgPath, []byte(`{"server": {"http": {"port": 70000}}}`), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	tt := []struct {
+		name       string
+		statusCode int
+		reloader   *config.Reloader
+	}{
+		{
+			name:       "should_reload_config",
+			statusCode: http.StatusOK,
+			reloader:   config.NewReloader("./testdata/Auth_Config/no-auth-convoy.json"),
+		},
+		{
+			name:       "should_fail_to_reload_invalid_config",
+			statusCode: http.StatusBadRequest,
+			reloader:   config.NewReloader(invalidCfgPath),
+		},
+		{
+			name:       "should_fail_without_reloader",
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := provideApplication(ctrl)
+			app.configReloader = tc.reloader
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/system/config/reload", nil)
+			req.SetBasicAuth("test", "test")
+			req.Header.Add("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			version := config.Version()
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			if tc.statusCode != http.StatusOK {
+				if config.Version() != version {
+					t.Errorf("Want config version '%d' to be kept, got '%d'", version, config.Version())
+				}
+				return
+			}
+
+			var resp struct {
+				Data config.ReloadResult `json:"data"`
+			}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			if err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if resp.Data.Version != version+1 || resp.Data.Version != config.Version() {
+				t.Errorf("Want config version '%d', got '%d'", version+1, resp.Data.Version)
+			}
+
+			if len(resp.Data.RestartRequired) != 0 {
+				t.Errorf("Want no fields requiring a restart, got %v", resp.Data.RestartRequired)
+			}
+		})
+	}
+}
+
+func TestApplicationHandler_Ready(t *testing.T) {
+	tt := []struct {
+		name       string
+		draining   bool
+		statusCode int
+	}{
+		{
+			name:       "should_be_ready",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "should_not_be_ready_while_draining",
+			draining:   true,
+			statusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := provideApplication(ctrl)
+			app.readiness = &Readiness{}
+			if tc.draining {
+				app.readiness.Drain()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+			w := httptest.NewRecorder()
+
+			err := config.LoadConfig("./testdata/Auth_Config/no-auth-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+			initRealmChain(t, app.apiKeyRepo)
+
+			router := buildRoutes(app)
+
+			// Act.
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("Want status '%d', got '%d'", tc.statusCode, w.Code)
+			}
+
+			verifyMatch(t, *w)
+		})
+	}
+}
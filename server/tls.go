@@ -0,0 +1,61 @@
+package server
+
+import (
+	"crypto/tls"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CertificateReloader serves the server's TLS certificate and reloads it
+// from its files on demand, e.g. on SIGHUP, so a renewed certificate is
+// picked up without a restart.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewCertificateReloader loads the certificate in certFile and keyFile.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	c := &CertificateReloader{certFile: certFile, keyFile: keyFile}
+	err := c.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reload reads the certificate files again. A certificate that fails to
+// load leaves the one being served in place.
+func (c *CertificateReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		log.WithError(err).Errorf("failed to load tls certificate from %s", c.certFile)
+		return err
+	}
+
+	c.mu.Lock()
+	c.cert = &cert
+	c.mu.Unlock()
+
+	log.Infof("loaded tls certificate from %s", c.certFile)
+	return nil
+}
+
+// GetCertificate returns the certificate being served, it's meant for
+// tls.Config.GetCertificate.
+func (c *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cert, nil
+}
+
+// TLSConfig returns the tls config serving c's certificate.
+func (c *CertificateReloader) TLSConfig() *tls.Config {
+	return &tls.Config{GetCertificate: c.GetCertificate}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate for commonName to
+// certFile and keyFile.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+}
+
+func servedCommonName(t *testing.T, c *CertificateReloader) string {
+	cert, err := c.GetCertificate(nil)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestCertificateReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	writeCertificate(t, certFile, keyFile, "old.getconvoy.io")
+
+	certs, err := NewCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, "old.getconvoy.io", servedCommonName(t, certs))
+
+	writeCertificate(t, certFile, keyFile, "new.getconvoy.io")
+	require.NoError(t, certs.Reload())
+	require.Equal(t, "new.getconvoy.io", servedCommonName(t, certs))
+
+	// a broken certificate keeps the one being served
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+	require.Error(t, certs.Reload())
+	require.Equal(t, "new.getconvoy.io", servedCommonName(t, certs))
+}
+
+func TestNewCertificateReloader_FailsForMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewCertificateReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	require.Error(t, err)
+}
@@ -4,49 +4,104 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+	"github.com/go-redis/redis_rate/v9"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// heldDeliveriesBatchSize is the page size used to load the deliveries
+// held while an app was paused.
+const heldDeliveriesBatchSize = 500
+
+// endpointSecretsBatchSize is the page size used to load apps while
+// backfilling endpoint secrets.
+const endpointSecretsBatchSize = 100
+
+// maxCustomHeaders is the most custom headers a group or an app can set.
+const maxCustomHeaders = 10
+
+// Endpoint metadata is context for operators, so it is kept small.
+const (
+	maxEndpointMetadataKeys        = 10
+	maxEndpointMetadataKeyLength   = 64
+	maxEndpointMetadataValueLength = 256
+)
+
+// EndpointPingEventType is the event type of the payload sent to ping an
+// endpoint.
+const EndpointPingEventType = "endpoint.ping"
+
+// endpointPingMaxResponseSize is how much of the body of an endpoint's
+// answer to a ping is read and returned.
+const endpointPingMaxResponseSize = 1024
+
+var endpointPingPayload = []byte(`{"event_type":"endpoint.ping"}`)
+
+// appStatisticsCacheTTL is how long an app's statistics are cached,
+// dashboards poll them.
+const appStatisticsCacheTTL = time.Minute
+
+// appStatisticsPeriods are how far back app statistics go for each period.
+var appStatisticsPeriods = map[datastore.Period]time.Duration{
+	datastore.Daily:   24 * time.Hour,
+	datastore.Weekly:  7 * 24 * time.Hour,
+	datastore.Monthly: 30 * 24 * time.Hour,
+	datastore.Yearly:  365 * 24 * time.Hour,
+}
+
 type AppService struct {
 	appRepo           datastore.ApplicationRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
 	eventQueue        queue.Queuer
 	cache             cache.Cache
+	limiter           limiter.RateLimiter
 }
 
-func NewAppService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer, cache cache.Cache) *AppService {
-	return &AppService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, eventQueue: eventQueue, cache: cache}
+func NewAppService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer, cache cache.Cache, limiter limiter.RateLimiter) *AppService {
+	return &AppService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, eventQueue: eventQueue, cache: cache, limiter: limiter}
 }
 
 func (a *AppService) CreateApp(ctx context.Context, newApp *models.Application, g *datastore.Group) (*datastore.Application, error) {
-	if err := util.Validate(newApp); err != nil {
+	if err := Validate(newApp); err != nil {
+		return nil, err
+	}
+
+	if err := validateCustomHeaders(newApp.CustomHeaders); err != nil {
 		return nil, NewServiceError(http.StatusBadRequest, err)
 	}
 
 	app := &datastore.Application{
 		UID:             uuid.New().String(),
 		GroupID:         g.UID,
+		OwnerID:         strings.TrimSpace(newApp.OwnerID),
 		Title:           newApp.AppName,
 		SupportEmail:    newApp.SupportEmail,
 		SlackWebhookURL: newApp.SlackWebhookURL,
 		IsDisabled:      newApp.IsDisabled,
+		CustomHeaders:   newApp.CustomHeaders,
 		CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:       primitive.NewDateTimeFromTime(time.Now()),
 		Endpoints:       []datastore.Endpoint{},
 		DocumentStatus:  datastore.ActiveDocumentStatus,
+
+		UseVersionedEndpoints: newApp.UseVersionedEndpoints,
 	}
 
 	err := a.appRepo.CreateApplication(ctx, app)
@@ -64,22 +119,39 @@ func (a *AppService) CreateApp(ctx context.Context, newApp *models.Application,
 	return app, nil
 }
 
-func (a *AppService) LoadApplicationsPaged(ctx context.Context, uid string, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
-	apps, paginationData, err := a.appRepo.LoadApplicationsPaged(ctx, uid, strings.TrimSpace(q), pageable)
+func (a *AppService) LoadApplicationsPaged(ctx context.Context, uid string, filter *datastore.ApplicationFilter, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	apps, paginationData, err := a.appRepo.LoadApplicationsPaged(ctx, uid, filter.WithQueryTrimmed(), pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch apps")
-		return nil, datastore.PaginationData{}, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching apps"))
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching apps")
 	}
 
 	return apps, paginationData, nil
 }
 
-func (a *AppService) UpdateApplication(ctx context.Context, appUpdate *models.UpdateApplication, app *datastore.Application) error {
+// UpdateApplication applies appUpdate to app. An owner id, once set,
+// is only changed when confirmOwnerChange is true.
+func (a *AppService) UpdateApplication(ctx context.Context, appUpdate *models.UpdateApplication, app *datastore.Application, confirmOwnerChange bool) error {
 	appName := appUpdate.AppName
-	if err := util.Validate(appUpdate); err != nil {
+	if err := Validate(appUpdate); err != nil {
+		return err
+	}
+
+	if err := validateCustomHeaders(appUpdate.CustomHeaders); err != nil {
 		return NewServiceError(http.StatusBadRequest, err)
 	}
 
+	if appUpdate.OwnerID != nil {
+		ownerID := strings.TrimSpace(*appUpdate.OwnerID)
+		if !util.IsStringEmpty(app.OwnerID) && ownerID != app.OwnerID && !confirmOwnerChange {
+			return NewServiceError(http.StatusBadRequest, errors.New("owner_id cannot be changed without confirm_owner_change=true"))
+		}
+		app.OwnerID = ownerID
+	}
+
 	app.Title = *appName
 	if appUpdate.SupportEmail != nil {
 		app.SupportEmail = *appUpdate.SupportEmail
@@ -97,14 +169,21 @@ func (a *AppService) UpdateApplication(ctx context.Context, appUpdate *models.Up
 		app.SupportEmail = *appUpdate.SupportEmail
 	}
 
+	if appUpdate.CustomHeaders != nil {
+		app.CustomHeaders = appUpdate.CustomHeaders
+	}
+
+	if appUpdate.UseVersionedEndpoints != nil {
+		app.UseVersionedEndpoints = *appUpdate.UseVersionedEndpoints
+	}
+
 	err := a.appRepo.UpdateApplication(ctx, app)
 	if err != nil {
 		log.WithError(err).Error("failed to update application")
 		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app"))
 	}
 
-	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
-	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	err = a.invalidateAppCache(ctx, app)
 	if err != nil {
 		return NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
 	}
@@ -119,8 +198,7 @@ func (a *AppService) DeleteApplication(ctx context.Context, app *datastore.Appli
 		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while deleting app"))
 	}
 
-	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
-	err = a.cache.Delete(ctx, appCacheKey)
+	err = a.invalidateAppCache(ctx, app, appStatisticsCacheKeys(app)...)
 	if err != nil {
 		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete application cache"))
 	}
@@ -128,6 +206,400 @@ func (a *AppService) DeleteApplication(ctx context.Context, app *datastore.Appli
 	return nil
 }
 
+// invalidateAppCache drops the cached copy of app along with any other
+// keys derived from it. Mutations invalidate rather than write the new app
+// through, so a reader that loaded the app before the change cannot put
+// it back after the write.
+func (a *AppService) invalidateAppCache(ctx context.Context, app *datastore.Application, keys ...string) error {
+	keys = append([]string{convoy.ApplicationsCacheKey.Get(app.UID).String()}, keys...)
+
+	for _, key := range keys {
+		err := a.cache.Delete(ctx, key)
+		if err != nil {
+			log.WithError(err).Errorf("failed to delete %s from cache", key)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appStatisticsCacheKeys returns the keys of the cached statistics app
+// counts towards, its own and its group's.
+func appStatisticsCacheKeys(app *datastore.Application) []string {
+	keys := []string{convoy.GroupStatisticsCacheKey.Get(app.GroupID).String()}
+	for period := range datastore.PeriodValues {
+		keys = append(keys, convoy.AppStatisticsCacheKey.Get(app.UID).Get(period).String())
+	}
+
+	return keys
+}
+
+// RestoreApplication brings back the soft-deleted app appID of the group
+// g. It is refused when g has been deleted, or when another app of g has
+// since taken the app's title.
+func (a *AppService) RestoreApplication(ctx context.Context, appID string, g *datastore.Group) (*datastore.Application, error) {
+	app, err := a.appRepo.FindDeletedApplicationByID(ctx, appID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrApplicationNotFound) {
+			return nil, NewServiceError(http.StatusNotFound, errors.New("deleted application not found"))
+		}
+
+		log.WithError(err).Error("failed to find deleted application")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to find deleted application"))
+	}
+
+	if app.GroupID != g.UID {
+		return nil, NewServiceError(http.StatusNotFound, errors.New("deleted application not found"))
+	}
+
+	if g.IsDeleted() {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("cannot restore an application of a deleted group"))
+	}
+
+	taken, err := a.isAppTitleTaken(ctx, g, app.Title)
+	if err != nil {
+		log.WithError(err).Error("failed to load applications")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to restore application"))
+	}
+
+	if taken {
+		return nil, NewServiceErrorWithCode(AppTitleConflictErrorCode, fmt.Errorf("an application named %s already exists in the group", app.Title))
+	}
+
+	err = a.appRepo.RestoreApplication(ctx, app)
+	if err != nil {
+		log.WithError(err).Error("failed to restore application")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to restore application"))
+	}
+
+	restored, err := a.appRepo.FindApplicationByID(ctx, app.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to find restored application")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to find restored application"))
+	}
+
+	// the app could have been remembered as missing while it was deleted
+	keys := append(appStatisticsCacheKeys(restored), convoy.MissingAppsCacheKey.Get(restored.UID).String())
+
+	err = a.invalidateAppCache(ctx, restored, keys...)
+	if err != nil {
+		log.WithError(err).Errorf("failed to invalidate cache of restored app %s", restored.UID)
+	}
+
+	return restored, nil
+}
+
+// isAppTitleTaken reports whether an active app of g is named title.
+func (a *AppService) isAppTitleTaken(ctx context.Context, g *datastore.Group, title string) (bool, error) {
+	filter := &datastore.ApplicationFilter{Query: title, SortBy: datastore.ApplicationSortCreatedAt}
+	pageable := datastore.Pageable{Page: 1, PerPage: 100, Sort: -1}
+
+	for {
+		apps, paginationData, err := a.appRepo.LoadApplicationsPaged(ctx, g.UID, filter, pageable)
+		if err != nil {
+			return false, err
+		}
+
+		for _, app := range apps {
+			if app.Title == title {
+				return true, nil
+			}
+		}
+
+		if int64(pageable.Page) >= paginationData.TotalPage {
+			return false, nil
+		}
+		pageable.Page++
+	}
+}
+
+// PauseApplication holds back deliveries to the app's endpoints. Deliveries
+// created or retried while it is paused are held instead of being sent.
+func (a *AppService) PauseApplication(ctx context.Context, app *datastore.Application) error {
+	if app.IsPaused {
+		return NewServiceError(http.StatusBadRequest, errors.New("app is already paused"))
+	}
+
+	app.IsPaused = true
+	app.PausedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	return a.updatePauseState(ctx, app)
+}
+
+// TransferApplication moves app, its endpoints, its events and its
+// deliveries to the group g. Deliveries being processed when it is moved
+// are left with the old group, so they complete, and are retried, under
+// the old group's signature config.
+func (a *AppService) TransferApplication(ctx context.Context, app *datastore.Application, g *datastore.Group) error {
+	if app.GroupID == g.UID {
+		return NewServiceError(http.StatusBadRequest, errors.New("app already belongs to the group"))
+	}
+
+	// the app is moved last, so a transfer that fails part way can be retried
+	err := a.eventRepo.UpdateEventsGroupID(ctx, app.UID, g.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to move app events")
+		return NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while moving app events"))
+	}
+
+	err = a.eventDeliveryRepo.UpdateEventDeliveriesGroupID(ctx, app.UID, g.UID, []datastore.EventDeliveryStatus{datastore.ProcessingEventStatus})
+	if err != nil {
+		log.WithError(err).Error("failed to move app event deliveries")
+		return NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while moving app event deliveries"))
+	}
+
+	oldGroupID := app.GroupID
+	app.GroupID = g.UID
+	app.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err = a.appRepo.UpdateApplication(ctx, app)
+	if err != nil {
+		log.WithError(err).Error("failed to move application")
+		app.GroupID = oldGroupID
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while moving app"))
+	}
+
+	cacheKeys := append(appStatisticsCacheKeys(app),
+		convoy.ApplicationsCacheKey.Get(app.UID).String(),
+		convoy.GroupStatisticsCacheKey.Get(oldGroupID).String())
+
+	for _, key := range cacheKeys {
+		err = a.cache.Delete(ctx, key)
+		if err != nil {
+			log.WithError(err).Errorf("failed to delete %s from cache", key)
+		}
+	}
+
+	return nil
+}
+
+func (a *AppService) LoadAppEventDeliveriesPaged(ctx context.Context, app *datastore.Application, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	deliveries, paginationData, err := a.eventDeliveryRepo.FindDeliveriesByAppID(ctx, app.UID, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch app event deliveries")
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching event deliveries")
+	}
+
+	return deliveries, paginationData, nil
+}
+
+// GetAppStatistics returns the events sent to app and the outcome of their
+// deliveries over the period leading up to now.
+func (a *AppService) GetAppStatistics(ctx context.Context, app *datastore.Application, period string) (*models.AppStatistics, error) {
+	if !datastore.IsValidPeriod(period) {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("please specify a period in (daily, weekly, monthly, yearly)"))
+	}
+
+	var statistics *models.AppStatistics
+	statisticsCacheKey := convoy.AppStatisticsCacheKey.Get(app.UID).Get(period).String()
+
+	err := a.cache.Get(ctx, statisticsCacheKey, &statistics)
+	if err != nil {
+		log.WithError(err).Errorf("failed to load cached statistics of app %s", app.UID)
+	}
+
+	if statistics != nil {
+		return statistics, nil
+	}
+
+	now := time.Now()
+	searchParams := datastore.SearchParams{
+		CreatedAtStart: now.Add(-appStatisticsPeriods[datastore.PeriodValues[period]]).Unix(),
+		CreatedAtEnd:   now.Unix(),
+	}
+
+	eventsCreated, err := a.eventRepo.CountEvents(ctx, app.GroupID, app.UID, searchParams)
+	if err != nil {
+		log.WithError(err).Error("failed to count app events")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to load app statistics"))
+	}
+
+	deliveries, err := a.eventDeliveryRepo.LoadDeliveryStatistics(ctx, app.GroupID, app.UID, searchParams)
+	if err != nil {
+		log.WithError(err).Error("failed to load app delivery statistics")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to load app statistics"))
+	}
+
+	statistics = &models.AppStatistics{
+		Period:               period,
+		EventsCreated:        eventsCreated,
+		DeliveriesAttempted:  deliveries.Attempted,
+		SuccessfulDeliveries: deliveries.Successful,
+		FailedDeliveries:     deliveries.Failed,
+		AverageLatencyMs:     deliveries.AverageLatencyMs,
+	}
+
+	if deliveries.Attempted > 0 {
+		rate := float64(deliveries.Successful) / float64(deliveries.Attempted) * 100
+		statistics.SuccessRate = math.Round(rate*10) / 10
+	}
+
+	err = a.cache.Set(ctx, statisticsCacheKey, statistics, appStatisticsCacheTTL)
+	if err != nil {
+		log.WithError(err).Errorf("failed to cache statistics of app %s", app.UID)
+	}
+
+	return statistics, nil
+}
+
+// ResumeApplication resumes deliveries to the app's endpoints and releases
+// every delivery held while it was paused to the queue, oldest first.
+func (a *AppService) ResumeApplication(ctx context.Context, app *datastore.Application, g *datastore.Group) (int, error) {
+	if !app.IsPaused {
+		return 0, NewServiceError(http.StatusBadRequest, errors.New("app is not paused"))
+	}
+
+	app.IsPaused = false
+	app.PausedAt = 0
+
+	err := a.updatePauseState(ctx, app)
+	if err != nil {
+		return 0, err
+	}
+
+	held, err := a.loadHeldEventDeliveries(ctx, app)
+	if err != nil {
+		log.WithError(err).Error("failed to load held event deliveries")
+		return 0, NewServiceError(http.StatusInternalServerError, errors.New("app resumed but held deliveries could not be released"))
+	}
+
+	released := 0
+	taskName := convoy.EventProcessor.SetPrefix(g.Name)
+	for i := range held {
+		delivery := &held[i]
+
+		// a worker that held the delivery after the app was resumed
+		// releases it itself, only one of the two queues it
+		moved, err := a.eventDeliveryRepo.TransitionStatus(ctx, delivery.UID, datastore.HeldEventStatus, datastore.ScheduledEventStatus)
+		if err != nil {
+			log.WithError(err).Errorf("failed to release held event delivery %s", delivery.UID)
+			continue
+		}
+
+		if !moved {
+			continue
+		}
+
+		delivery.Status = datastore.ScheduledEventStatus
+
+		err = a.eventQueue.WriteEventDelivery(context.Background(), taskName, delivery, 1*time.Second)
+		if err != nil {
+			log.WithError(err).Errorf("failed to queue held event delivery %s", delivery.UID)
+			continue
+		}
+
+		released++
+	}
+
+	return released, nil
+}
+
+func (a *AppService) updatePauseState(ctx context.Context, app *datastore.Application) error {
+	err := a.appRepo.UpdateApplication(ctx, app)
+	if err != nil {
+		log.WithError(err).Error("failed to update application pause state")
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app"))
+	}
+
+	err = a.invalidateAppCache(ctx, app)
+	if err != nil {
+		return NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
+	}
+
+	return nil
+}
+
+// loadHeldEventDeliveries loads all of the app's held deliveries up front,
+// since releasing them while paging would shift the pages.
+func (a *AppService) loadHeldEventDeliveries(ctx context.Context, app *datastore.Application) ([]datastore.EventDelivery, error) {
+	var held []datastore.EventDelivery
+
+	status := []datastore.EventDeliveryStatus{datastore.HeldEventStatus}
+	searchParams := datastore.SearchParams{CreatedAtEnd: time.Now().Unix()}
+	pageable := datastore.Pageable{Page: 1, PerPage: heldDeliveriesBatchSize, Sort: 1}
+
+	for {
+		deliveries, paginationData, err := a.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, app.GroupID, app.UID, "", "", status, searchParams, pageable)
+		if err != nil {
+			return nil, err
+		}
+
+		held = append(held, deliveries...)
+		if len(deliveries) < heldDeliveriesBatchSize || int64(pageable.Page) >= paginationData.TotalPage {
+			return held, nil
+		}
+
+		pageable.Page++
+	}
+}
+
+// BackfillEndpointSecrets sets a secret on every endpoint that has none,
+// the app's deprecated secret when it has one. It returns the number of
+// endpoints that were updated.
+func (a *AppService) BackfillEndpointSecrets(ctx context.Context) (int, error) {
+	var updated int
+	pageable := datastore.Pageable{Page: 1, PerPage: endpointSecretsBatchSize, Sort: 1}
+
+	for {
+		apps, paginationData, err := a.appRepo.LoadApplicationsPaged(ctx, "", &datastore.ApplicationFilter{}, pageable)
+		if err != nil {
+			return updated, err
+		}
+
+		for i := range apps {
+			n, err := a.backfillAppEndpointSecrets(ctx, &apps[i])
+			if err != nil {
+				return updated, err
+			}
+
+			updated += n
+		}
+
+		if len(apps) < endpointSecretsBatchSize || int64(pageable.Page) >= paginationData.TotalPage {
+			return updated, nil
+		}
+
+		pageable.Page++
+	}
+}
+
+func (a *AppService) backfillAppEndpointSecrets(ctx context.Context, app *datastore.Application) (int, error) {
+	var updated int
+	for i := range app.Endpoints {
+		if !util.IsStringEmpty(app.Endpoints[i].Secret) {
+			continue
+		}
+
+		secret, err := defaultEndpointSecret(app)
+		if err != nil {
+			return 0, err
+		}
+
+		app.Endpoints[i].Secret = secret
+		updated++
+	}
+
+	if updated == 0 {
+		return 0, nil
+	}
+
+	err := a.appRepo.UpdateApplication(ctx, app)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update endpoints of app %s: %v", app.UID, err)
+	}
+
+	err = a.invalidateAppCache(ctx, app)
+	if err != nil {
+		log.WithError(err).Errorf("failed to delete app %s from cache", app.UID)
+	}
+
+	return updated, nil
+}
+
 func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, app *datastore.Application) (*datastore.Endpoint, error) {
 	// Events being nil means it wasn't passed at all, which automatically
 	// translates into a accept all scenario. This is quite different from
@@ -137,6 +609,22 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		e.Events = []string{"*"}
 	}
 
+	if err := validateEndpointRateLimit(e); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateEndpointStatus(datastore.ActiveEndpointStatus, e.Status); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateEndpointMetadata(e.Metadata); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if util.IsStringEmpty(string(e.Status)) {
+		e.Status = datastore.ActiveEndpointStatus
+	}
+
 	if e.RateLimit == 0 {
 		e.RateLimit = convoy.RATE_LIMIT
 	}
@@ -156,7 +644,8 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		Description:       e.Description,
 		Events:            e.Events,
 		Secret:            e.Secret,
-		Status:            datastore.ActiveEndpointStatus,
+		Status:            e.Status,
+		Metadata:          e.Metadata,
 		RateLimit:         e.RateLimit,
 		RateLimitDuration: duration.String(),
 		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
@@ -165,7 +654,7 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 	}
 
 	if util.IsStringEmpty(e.Secret) {
-		endpoint.Secret, err = util.GenerateSecret()
+		endpoint.Secret, err = defaultEndpointSecret(app)
 		if err != nil {
 			return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf(fmt.Sprintf("could not generate secret...%v", err.Error())))
 		}
@@ -179,8 +668,7 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("an error occurred while adding app endpoint"))
 	}
 
-	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
-	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	err = a.invalidateAppCache(ctx, app)
 	if err != nil {
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
 	}
@@ -189,6 +677,17 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 }
 
 func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, endPointId string, app *datastore.Application) (*datastore.Endpoint, error) {
+	if err := validateEndpointRateLimit(e); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateEndpointHttpTimeout(e.HttpTimeout); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateEndpointMetadata(e.Metadata); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
 
 	endpoints, endpoint, err := updateEndpointIfFound(&app.Endpoints, endPointId, e)
 	if err != nil {
@@ -201,8 +700,7 @@ func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, e
 		return endpoint, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app endpoints"))
 	}
 
-	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
-	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	err = a.invalidateAppCache(ctx, app)
 	if err != nil {
 		return endpoint, NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
 	}
@@ -210,6 +708,55 @@ func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, e
 	return endpoint, nil
 }
 
+// GetEndpointRateLimitUsage returns how much of its delivery rate limit
+// the endpoint has left, the limit being the tighter of the endpoint's
+// and g's.
+func (a *AppService) GetEndpointRateLimitUsage(ctx context.Context, e *datastore.Endpoint, g *datastore.Group) (*models.RateLimitUsage, error) {
+	limit, rawDuration := e.DeliveryRateLimit(g)
+	if limit <= 0 {
+		limit = convoy.RATE_LIMIT
+	}
+
+	if util.IsStringEmpty(rawDuration) {
+		rawDuration = convoy.RATE_LIMIT_DURATION
+	}
+
+	duration, err := time.ParseDuration(rawDuration)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("an error occurred parsing the rate limit duration: %v", err))
+	}
+
+	res, err := a.limiter.Inspect(ctx, e.TargetURL, limit, int(duration))
+	if err != nil {
+		log.WithError(err).Error("failed to check endpoint rate limit")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to fetch endpoint rate limit usage"))
+	}
+
+	return newRateLimitUsage(limit, duration, res), nil
+}
+
+// newRateLimitUsage builds the usage of a limit from what the limiter
+// reported for it. The limiters hold one more token than the limit and
+// requests are turned away once it is the only one left.
+func newRateLimitUsage(limit int, duration time.Duration, res *redis_rate.Result) *models.RateLimitUsage {
+	remaining := res.Remaining - 1
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if remaining > limit {
+		remaining = limit
+	}
+
+	return &models.RateLimitUsage{
+		Limit:      limit,
+		Duration:   duration.String(),
+		Used:       limit - remaining,
+		Remaining:  remaining,
+		ResetAfter: res.ResetAfter.String(),
+	}
+}
+
 func (a *AppService) DeleteAppEndpoint(ctx context.Context, e *datastore.Endpoint, app *datastore.Application) error {
 
 	for i, endpoint := range app.Endpoints {
@@ -225,8 +772,7 @@ func (a *AppService) DeleteAppEndpoint(ctx context.Context, e *datastore.Endpoin
 		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while deleting app endpoint"))
 	}
 
-	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
-	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	err = a.invalidateAppCache(ctx, app)
 	if err != nil {
 		return NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
 	}
@@ -234,6 +780,128 @@ func (a *AppService) DeleteAppEndpoint(ctx context.Context, e *datastore.Endpoin
 	return nil
 }
 
+// PingAppEndpoint sends a test payload to endpoint the way event
+// deliveries are sent and reports how it answered. Nothing is stored
+// unless record is set, then the ping is saved as an event with its
+// delivery.
+func (a *AppService) PingAppEndpoint(ctx context.Context, g *datastore.Group, app *datastore.Application, endpoint *datastore.Endpoint, record bool) (*models.EndpointPing, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		log.WithError(err).Error("failed to load config")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to ping endpoint"))
+	}
+
+	start := time.Now()
+	resp, err := task.SendToEndpoint(g, app, endpoint, endpointPingPayload, cfg.EncryptionKey, endpointPingMaxResponseSize)
+	latency := time.Since(start)
+
+	// the request is only built once it is signed, a response without a
+	// url was never sent
+	if resp == nil || resp.URL == nil {
+		log.WithError(err).Errorf("failed to ping endpoint %s", endpoint.UID)
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to ping endpoint"))
+	}
+
+	ping := &models.EndpointPing{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		LatencyMs:  latency.Milliseconds(),
+		Body:       string(resp.Body),
+		Error:      resp.Error,
+	}
+
+	if err != nil && util.IsStringEmpty(ping.Error) {
+		ping.Error = err.Error()
+	}
+
+	if record {
+		success := err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299
+		err = a.recordEndpointPing(ctx, g, app, endpoint, resp, success, ping)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ping, nil
+}
+
+// recordEndpointPing saves a ping of endpoint as an event with a delivery
+// holding the attempt, so it shows up with the app's other deliveries.
+func (a *AppService) recordEndpointPing(ctx context.Context, g *datastore.Group, app *datastore.Application, endpoint *datastore.Endpoint, resp *net.Response, success bool, ping *models.EndpointPing) error {
+	appMetadata := &datastore.AppMetadata{
+		UID:          app.UID,
+		Title:        app.Title,
+		GroupID:      app.GroupID,
+		SupportEmail: app.SupportEmail,
+	}
+
+	event := &datastore.Event{
+		UID:              uuid.New().String(),
+		EventType:        datastore.EventType(EndpointPingEventType),
+		EventVersion:     datastore.DefaultEventVersion,
+		MatchedEndpoints: 1,
+		Data:             endpointPingPayload,
+		AppMetadata:      appMetadata,
+		ExpiresAt:        g.EventExpiry(time.Now()),
+		CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus:   datastore.ActiveDocumentStatus,
+	}
+
+	err := a.eventRepo.CreateEvent(ctx, event)
+	if err != nil {
+		log.WithError(err).Error("failed to record endpoint ping event")
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while recording ping"))
+	}
+
+	status := datastore.FailureEventStatus
+	if success {
+		status = datastore.SuccessEventStatus
+	}
+
+	delivery := &datastore.EventDelivery{
+		UID: uuid.New().String(),
+		EventMetadata: &datastore.EventMetadata{
+			UID:          event.UID,
+			EventType:    event.EventType,
+			EventVersion: event.EventVersion,
+		},
+		EndpointMetadata: &datastore.EndpointMetadata{
+			UID:               endpoint.UID,
+			TargetURL:         endpoint.TargetURL,
+			Status:            endpoint.Status,
+			Secret:            endpoint.Secret,
+			Sent:              success,
+			RateLimit:         endpoint.RateLimit,
+			RateLimitDuration: endpoint.RateLimitDuration,
+			HttpTimeout:       endpoint.HttpTimeout,
+		},
+		AppMetadata: appMetadata,
+		Metadata: &datastore.Metadata{
+			Data:       event.Data,
+			Strategy:   g.Config.Strategy.Type,
+			NumTrials:  1,
+			RetryLimit: 1,
+		},
+		Status:         status,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+		ExpiresAt:      event.ExpiresAt,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+	}
+	delivery.DeliveryAttempts = []datastore.DeliveryAttempt{task.ParseAttemptFromResponse(delivery, delivery.EndpointMetadata, resp, success)}
+
+	err = a.eventDeliveryRepo.CreateEventDelivery(ctx, delivery)
+	if err != nil {
+		log.WithError(err).Error("failed to record endpoint ping delivery")
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while recording ping"))
+	}
+
+	ping.EventID = event.UID
+	ping.EventDeliveryID = delivery.UID
+	return nil
+}
+
 func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.Endpoint) (*[]datastore.Endpoint, *datastore.Endpoint, error) {
 	for i, endpoint := range *endpoints {
 		if endpoint.UID == id && endpoint.DeletedAt == 0 {
@@ -251,6 +919,15 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 				endpoint.Events = e.Events
 			}
 
+			err := validateEndpointStatus(endpoint.Status, e.Status)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if e.Metadata != nil {
+				endpoint.Metadata = e.Metadata
+			}
+
 			if e.RateLimit != 0 {
 				endpoint.RateLimit = e.RateLimit
 			}
@@ -268,7 +945,21 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 				endpoint.HttpTimeout = e.HttpTimeout
 			}
 
-			endpoint.Status = datastore.ActiveEndpointStatus
+			// updating an endpoint reactivates it unless it is being
+			// deactivated, or left pending, explicitly
+			switch e.Status {
+			case datastore.InactiveEndpointStatus:
+				if endpoint.Status != datastore.InactiveEndpointStatus {
+					endpoint.DisabledAt = primitive.NewDateTimeFromTime(time.Now())
+				}
+				endpoint.Status = datastore.InactiveEndpointStatus
+			case datastore.PendingEndpointStatus:
+			default:
+				endpoint.Status = datastore.ActiveEndpointStatus
+				endpoint.DisabledReason = ""
+				endpoint.DisabledAt = 0
+			}
+
 			endpoint.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 			(*endpoints)[i] = endpoint
 			return endpoints, &endpoint, nil
@@ -276,3 +967,105 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 	}
 	return endpoints, nil, datastore.ErrEndpointNotFound
 }
+
+// validateEndpointRateLimit rejects negative rate limits and durations.
+// A zero limit or empty duration leaves it unset, durations that don't
+// parse are reported where they are parsed.
+func validateEndpointRateLimit(e models.Endpoint) error {
+	if e.RateLimit < 0 {
+		return errors.New("please provide a valid rate limit")
+	}
+
+	duration, err := time.ParseDuration(e.RateLimitDuration)
+	if err == nil && duration <= 0 {
+		return errors.New("please provide a valid rate limit duration")
+	}
+
+	return nil
+}
+
+// validateEndpointHttpTimeout rejects http timeouts that aren't positive
+// durations of at most convoy.MAX_HTTP_TIMEOUT, an empty one is left unset.
+func validateEndpointHttpTimeout(timeout string) error {
+	if util.IsStringEmpty(timeout) {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration <= 0 {
+		return errors.New("please provide a valid http timeout")
+	}
+
+	if duration > convoy.MAX_HTTP_TIMEOUT {
+		return fmt.Errorf("http timeout cannot be longer than %s", convoy.MAX_HTTP_TIMEOUT)
+	}
+
+	return nil
+}
+
+// validateEndpointStatus checks an endpoint can move from current to next.
+// Pending is entered only while the worker retries a failing endpoint, so
+// it cannot be set through the API. An empty next status leaves it to the
+// caller's default.
+func validateEndpointStatus(current, next datastore.EndpointStatus) error {
+	switch next {
+	case "", datastore.ActiveEndpointStatus, datastore.InactiveEndpointStatus:
+		return nil
+	case datastore.PendingEndpointStatus:
+		if current == datastore.PendingEndpointStatus {
+			return nil
+		}
+		return errors.New("endpoint status cannot be set to pending")
+	default:
+		return fmt.Errorf("invalid endpoint status %q, status must be active or inactive", next)
+	}
+}
+
+// validateEndpointMetadata keeps endpoint metadata to a few short entries.
+func validateEndpointMetadata(metadata map[string]string) error {
+	if len(metadata) > maxEndpointMetadataKeys {
+		return fmt.Errorf("an endpoint can have at most %d metadata entries", maxEndpointMetadataKeys)
+	}
+
+	for k, v := range metadata {
+		if util.IsStringEmpty(strings.TrimSpace(k)) || len(k) > maxEndpointMetadataKeyLength {
+			return fmt.Errorf("invalid metadata key %q, keys must be between 1 and %d characters", k, maxEndpointMetadataKeyLength)
+		}
+
+		if len(v) > maxEndpointMetadataValueLength {
+			return fmt.Errorf("metadata value of %q is longer than %d characters", k, maxEndpointMetadataValueLength)
+		}
+	}
+
+	return nil
+}
+
+// validateCustomHeaders checks the custom headers of a group or an app.
+// Hop-by-hop headers are managed by the transport, so they cannot be set.
+func validateCustomHeaders(headers map[string]string) error {
+	if len(headers) > maxCustomHeaders {
+		return fmt.Errorf("please provide at most %d custom headers", maxCustomHeaders)
+	}
+
+	for name := range headers {
+		if util.IsStringEmpty(strings.TrimSpace(name)) {
+			return errors.New("custom header names cannot be empty")
+		}
+
+		if net.IsHopByHopHeader(name) {
+			return fmt.Errorf("%s cannot be set as a custom header", name)
+		}
+	}
+
+	return nil
+}
+
+// defaultEndpointSecret returns the secret of an endpoint of app created
+// without one, the app's deprecated secret when it has one.
+func defaultEndpointSecret(app *datastore.Application) (string, error) {
+	if !util.IsStringEmpty(app.Secret) {
+		return app.Secret, nil
+	}
+
+	return util.GenerateSecret()
+}
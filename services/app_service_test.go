@@ -3,14 +3,23 @@ package services
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-redis/redis_rate/v9"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func provideAppService(ctrl *gomock.Controller) *AppService {
@@ -19,7 +28,8 @@ func provideAppService(ctrl *gomock.Controller) *AppService {
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
 	eventQueue := mocks.NewMockQueuer(ctrl)
 	cache := mocks.NewMockCache(ctrl)
-	return NewAppService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, cache)
+	limiter := mocks.NewMockRateLimiter(ctrl)
+	return NewAppService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, cache, limiter)
 }
 
 func boolPtr(b bool) *bool {
@@ -61,7 +71,7 @@ func TestApplicationHandler_CreateApp(t *testing.T) {
 				g: group,
 			},
 			wantErr:    true,
-			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("name:please provide your appName")),
+			wantErrObj: NewServiceErrorWithCode(ValidationFailedErrorCode, errors.New("name:please provide your appName")).WithFields(map[string]string{"name": "please provide your appName"}),
 			dbFn:       func(app *AppService) {},
 		},
 		{
@@ -96,6 +106,66 @@ func TestApplicationHandler_CreateApp(t *testing.T) {
 				DocumentStatus:  datastore.ActiveDocumentStatus,
 			},
 		},
+		{
+			name: "should_create_application_with_custom_headers",
+			args: args{
+				ctx: ctx,
+				newApp: &models.Application{
+					AppName:       "test_app",
+					SupportEmail:  "app@test.com",
+					CustomHeaders: map[string]string{"X-Environment": "staging"},
+				},
+				g: group,
+			},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().
+					CreateApplication(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			wantApp: &datastore.Application{
+				GroupID:        groupID,
+				Title:          "test_app",
+				SupportEmail:   "app@test.com",
+				CustomHeaders:  map[string]string{"X-Environment": "staging"},
+				Endpoints:      []datastore.Endpoint{},
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			},
+		},
+		{
+			name: "should_error_for_hop_by_hop_custom_header",
+			args: args{
+				ctx: ctx,
+				newApp: &models.Application{
+					AppName:       "test_app",
+					SupportEmail:  "app@test.com",
+					CustomHeaders: map[string]string{"transfer-encoding": "chunked"},
+				},
+				g: group,
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("transfer-encoding cannot be set as a custom header")),
+		},
+		{
+			name: "should_error_for_too_many_custom_headers",
+			args: args{
+				ctx: ctx,
+				newApp: &models.Application{
+					AppName:      "test_app",
+					SupportEmail: "app@test.com",
+					CustomHeaders: map[string]string{
+						"X-1": "1", "X-2": "2", "X-3": "3", "X-4": "4", "X-5": "5", "X-6": "6",
+						"X-7": "7", "X-8": "8", "X-9": "9", "X-10": "10", "X-11": "11",
+					},
+				},
+				g: group,
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("please provide at most 10 custom headers")),
+		},
 		{
 			name: "should_fail_to_create_application",
 			args: args{
@@ -259,7 +329,7 @@ func TestAppService_LoadApplicationsPaged(t *testing.T) {
 			dbFn: func(app *AppService) {
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
-					LoadApplicationsPaged(gomock.Any(), gomock.Any(), "falsetto", gomock.Any()).Times(1).
+					LoadApplicationsPaged(gomock.Any(), gomock.Any(), &datastore.ApplicationFilter{Query: "falsetto", SortBy: datastore.ApplicationSortCreatedAt}, gomock.Any()).Times(1).
 					Return([]datastore.Application{
 						{UID: "123"},
 						{UID: "abc"},
@@ -301,7 +371,7 @@ func TestAppService_LoadApplicationsPaged(t *testing.T) {
 			dbFn: func(app *AppService) {
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
 				a.EXPECT().
-					LoadApplicationsPaged(gomock.Any(), gomock.Any(), "FalSetto", gomock.Any()).Times(1).
+					LoadApplicationsPaged(gomock.Any(), gomock.Any(), &datastore.ApplicationFilter{Query: "FalSetto", SortBy: datastore.ApplicationSortCreatedAt}, gomock.Any()).Times(1).
 					Return([]datastore.Application{
 						{UID: "123"},
 						{UID: "abc"},
@@ -329,7 +399,7 @@ func TestAppService_LoadApplicationsPaged(t *testing.T) {
 				tt.dbFn(as)
 			}
 
-			apps, paginationData, err := as.LoadApplicationsPaged(tt.args.ctx, tt.args.uid, tt.args.q, tt.args.pageable)
+			apps, paginationData, err := as.LoadApplicationsPaged(tt.args.ctx, tt.args.uid, &datastore.ApplicationFilter{Query: tt.args.q}, tt.args.pageable)
 			if tt.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tt.wantErrObj, err)
@@ -347,9 +417,10 @@ func TestAppService_UpdateApplication(t *testing.T) {
 	ctx := context.Background()
 
 	type args struct {
-		ctx       context.Context
-		appUpdate *models.UpdateApplication
-		app       *datastore.Application
+		ctx                context.Context
+		appUpdate          *models.UpdateApplication
+		app                *datastore.Application
+		confirmOwnerChange bool
 	}
 	tests := []struct {
 		name       string
@@ -387,7 +458,7 @@ func TestAppService_UpdateApplication(t *testing.T) {
 				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), gomock.Any())
 			},
 			wantErr: false,
 		},
@@ -407,7 +478,7 @@ func TestAppService_UpdateApplication(t *testing.T) {
 					SlackWebhookURL: "https://google.com",
 				},
 			},
-			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("name:please provide your appName")),
+			wantErrObj: NewServiceErrorWithCode(ValidationFailedErrorCode, errors.New("name:please provide your appName")).WithFields(map[string]string{"name": "please provide your appName"}),
 			wantErr:    true,
 		},
 		{
@@ -434,6 +505,49 @@ func TestAppService_UpdateApplication(t *testing.T) {
 			wantErr:    true,
 			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app")),
 		},
+		{
+			name: "should_set_owner_id_of_app_without_one",
+			args: args{
+				ctx:       ctx,
+				appUpdate: &models.UpdateApplication{AppName: stringPtr("test_app"), OwnerID: stringPtr(" customer-1 ")},
+				app:       &datastore.Application{Title: "test_app"},
+			},
+			wantApp: &datastore.Application{Title: "test_app", OwnerID: "customer-1"},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "should_refuse_to_change_owner_id_without_confirmation",
+			args: args{
+				ctx:       ctx,
+				appUpdate: &models.UpdateApplication{AppName: stringPtr("test_app"), OwnerID: stringPtr("customer-2")},
+				app:       &datastore.Application{Title: "test_app", OwnerID: "customer-1"},
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("owner_id cannot be changed without confirm_owner_change=true")),
+		},
+		{
+			name: "should_change_owner_id_with_confirmation",
+			args: args{
+				ctx:                ctx,
+				appUpdate:          &models.UpdateApplication{AppName: stringPtr("test_app"), OwnerID: stringPtr("customer-2")},
+				app:                &datastore.Application{Title: "test_app", OwnerID: "customer-1"},
+				confirmOwnerChange: true,
+			},
+			wantApp: &datastore.Application{Title: "test_app", OwnerID: "customer-2"},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any())
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -446,7 +560,7 @@ func TestAppService_UpdateApplication(t *testing.T) {
 				tt.dbFn(as)
 			}
 
-			err := as.UpdateApplication(tt.args.ctx, tt.args.appUpdate, tt.args.app)
+			err := as.UpdateApplication(tt.args.ctx, tt.args.appUpdate, tt.args.app, tt.args.confirmOwnerChange)
 			if tt.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tt.wantErrObj, err)
@@ -478,15 +592,21 @@ func TestAppService_DeleteApplication(t *testing.T) {
 			args: args{
 				ctx: ctx,
 				app: &datastore.Application{
-					UID: "12345",
+					UID:     "12345",
+					GroupID: "abc",
 				},
 			},
 			dbFn: func(app *AppService) {
 				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteApplication(gomock.Any(), &datastore.Application{UID: "12345"}).Times(1).Return(nil)
+				a.EXPECT().DeleteApplication(gomock.Any(), &datastore.Application{UID: "12345", GroupID: "abc"}).Times(1).Return(nil)
 
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Delete(gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "applications:12345").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:abc").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "app_statistics:12345:daily").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "app_statistics:12345:weekly").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "app_statistics:12345:monthly").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "app_statistics:12345:yearly").Times(1)
 			},
 			wantErr:    false,
 			wantErrObj: nil,
@@ -528,6 +648,556 @@ func TestAppService_DeleteApplication(t *testing.T) {
 	}
 }
 
+func TestAppService_RestoreApplication(t *testing.T) {
+	ctx := context.Background()
+	group := &datastore.Group{UID: "group-1"}
+	deletedApp := &datastore.Application{UID: "app-1", GroupID: "group-1", Title: "payments", DeletedAt: primitive.NewDateTimeFromTime(time.Now())}
+
+	type args struct {
+		ctx   context.Context
+		appID string
+		group *datastore.Group
+	}
+	tests := []struct {
+		name       string
+		args       args
+		dbFn       func(app *AppService)
+		wantApp    *datastore.Application
+		wantErr    bool
+		wantErrObj error
+	}{
+		{
+			name: "should_restore_application",
+			args: args{ctx: ctx, appID: "app-1", group: group},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(deletedApp, nil)
+				a.EXPECT().LoadApplicationsPaged(gomock.Any(), "group-1", gomock.Any(), gomock.Any()).Times(1).
+					Return([]datastore.Application{{UID: "app-2", Title: "payments-v2"}}, datastore.PaginationData{TotalPage: 1}, nil)
+				a.EXPECT().RestoreApplication(gomock.Any(), deletedApp).Times(1).Return(nil)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).
+					Return(&datastore.Application{UID: "app-1", GroupID: "group-1", Title: "payments"}, nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:app-1").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:group-1").Times(1)
+				for period := range datastore.PeriodValues {
+					c.EXPECT().Delete(gomock.Any(), "app_statistics:app-1:"+period).Times(1)
+				}
+				c.EXPECT().Delete(gomock.Any(), "missing_applications:app-1").Times(1)
+			},
+			wantApp: &datastore.Application{UID: "app-1", GroupID: "group-1", Title: "payments"},
+		},
+		{
+			name: "should_error_for_app_that_is_not_deleted",
+			args: args{ctx: ctx, appID: "app-1", group: group},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(nil, datastore.ErrApplicationNotFound)
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusNotFound, errors.New("deleted application not found")),
+		},
+		{
+			name: "should_error_for_app_of_another_group",
+			args: args{ctx: ctx, appID: "app-1", group: &datastore.Group{UID: "group-2"}},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(deletedApp, nil)
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusNotFound, errors.New("deleted application not found")),
+		},
+		{
+			name: "should_refuse_to_restore_app_deleted_with_its_group",
+			args: args{
+				ctx:   ctx,
+				appID: "app-1",
+				group: &datastore.Group{UID: "group-1", DeletedAt: deletedApp.DeletedAt},
+			},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(deletedApp, nil)
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("cannot restore an application of a deleted group")),
+		},
+		{
+			name: "should_refuse_to_restore_when_title_is_taken",
+			args: args{ctx: ctx, appID: "app-1", group: group},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(deletedApp, nil)
+				a.EXPECT().LoadApplicationsPaged(gomock.Any(), "group-1", gomock.Any(), gomock.Any()).Times(1).
+					Return([]datastore.Application{{UID: "app-3", Title: "payments"}}, datastore.PaginationData{TotalPage: 1}, nil)
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceErrorWithCode(AppTitleConflictErrorCode, errors.New("an application named payments already exists in the group")),
+		},
+		{
+			name: "should_fail_to_restore_application",
+			args: args{ctx: ctx, appID: "app-1", group: group},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindDeletedApplicationByID(gomock.Any(), "app-1").Times(1).Return(deletedApp, nil)
+				a.EXPECT().LoadApplicationsPaged(gomock.Any(), "group-1", gomock.Any(), gomock.Any()).Times(1).
+					Return([]datastore.Application{}, datastore.PaginationData{TotalPage: 0}, nil)
+				a.EXPECT().RestoreApplication(gomock.Any(), deletedApp).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("failed to restore application")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			// Arrange Expectations
+			if tt.dbFn != nil {
+				tt.dbFn(as)
+			}
+
+			app, err := as.RestoreApplication(tt.args.ctx, tt.args.appID, tt.args.group)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrObj, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.wantApp, app)
+		})
+	}
+}
+
+func TestAppService_PauseApplication(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		app        *datastore.Application
+		dbFn       func(app *AppService)
+		wantErr    bool
+		wantErrObj error
+	}{
+		{
+			name: "should_pause_application",
+			app:  &datastore.Application{UID: "12345"},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:12345").Times(1).Return(nil)
+			},
+		},
+		{
+			name:       "should_error_for_paused_application",
+			app:        &datastore.Application{UID: "12345", IsPaused: true},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("app is already paused")),
+		},
+		{
+			name: "should_fail_to_pause_application",
+			app:  &datastore.Application{UID: "12345"},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			// Arrange Expectations
+			if tt.dbFn != nil {
+				tt.dbFn(as)
+			}
+
+			err := as.PauseApplication(ctx, tt.app)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrObj, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.True(t, tt.app.IsPaused)
+			require.NotZero(t, tt.app.PausedAt)
+		})
+	}
+}
+
+func TestAppService_ResumeApplication(t *testing.T) {
+	ctx := context.Background()
+	group := &datastore.Group{UID: "group-1", Name: "test_group"}
+	pausedAt := primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name         string
+		app          *datastore.Application
+		dbFn         func(app *AppService)
+		wantReleased int
+		wantErr      bool
+		wantErrObj   error
+	}{
+		{
+			name: "should_release_deliveries_created_while_paused_in_order",
+			app:  &datastore.Application{UID: "12345", GroupID: "group-1", IsPaused: true, PausedAt: pausedAt},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:12345").Times(1).Return(nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					LoadEventDeliveriesPaged(gomock.Any(), "group-1", "12345", "", "", []datastore.EventDeliveryStatus{datastore.HeldEventStatus}, gomock.Any(), datastore.Pageable{Page: 1, PerPage: heldDeliveriesBatchSize, Sort: 1}).
+					Times(1).Return([]datastore.EventDelivery{
+					{UID: "d1", Status: datastore.HeldEventStatus},
+					{UID: "d2", Status: datastore.HeldEventStatus},
+				}, datastore.PaginationData{Total: 2, TotalPage: 1}, nil)
+
+				q, _ := app.eventQueue.(*mocks.MockQueuer)
+				gomock.InOrder(
+					ed.EXPECT().TransitionStatus(gomock.Any(), "d1", datastore.HeldEventStatus, datastore.ScheduledEventStatus).Return(true, nil),
+					q.EXPECT().WriteEventDelivery(gomock.Any(), gomock.Any(), eventDeliveryWithUID("d1"), gomock.Any()).Return(nil),
+					ed.EXPECT().TransitionStatus(gomock.Any(), "d2", datastore.HeldEventStatus, datastore.ScheduledEventStatus).Return(true, nil),
+					q.EXPECT().WriteEventDelivery(gomock.Any(), gomock.Any(), eventDeliveryWithUID("d2"), gomock.Any()).Return(nil),
+				)
+			},
+			wantReleased: 2,
+		},
+		{
+			name: "should_skip_deliveries_that_fail_to_release",
+			app:  &datastore.Application{UID: "12345", GroupID: "group-1", IsPaused: true, PausedAt: pausedAt},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:12345").Times(1).Return(nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return([]datastore.EventDelivery{
+					{UID: "d1", Status: datastore.HeldEventStatus},
+					{UID: "d2", Status: datastore.HeldEventStatus},
+				}, datastore.PaginationData{Total: 2, TotalPage: 1}, nil)
+
+				ed.EXPECT().TransitionStatus(gomock.Any(), "d1", gomock.Any(), gomock.Any()).Return(false, errors.New("failed"))
+				ed.EXPECT().TransitionStatus(gomock.Any(), "d2", gomock.Any(), gomock.Any()).Return(true, nil)
+
+				q, _ := app.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().WriteEventDelivery(gomock.Any(), gomock.Any(), eventDeliveryWithUID("d2"), gomock.Any()).Return(nil)
+			},
+			wantReleased: 1,
+		},
+		{
+			name: "should_not_queue_deliveries_a_worker_already_released",
+			app:  &datastore.Application{UID: "12345", GroupID: "group-1", IsPaused: true, PausedAt: pausedAt},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:12345").Times(1).Return(nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return([]datastore.EventDelivery{
+					{UID: "d1", Status: datastore.HeldEventStatus},
+				}, datastore.PaginationData{Total: 1, TotalPage: 1}, nil)
+
+				ed.EXPECT().TransitionStatus(gomock.Any(), "d1", datastore.HeldEventStatus, datastore.ScheduledEventStatus).Return(false, nil)
+			},
+			wantReleased: 0,
+		},
+		{
+			name:       "should_error_for_application_that_is_not_paused",
+			app:        &datastore.Application{UID: "12345"},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("app is not paused")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			// Arrange Expectations
+			if tt.dbFn != nil {
+				tt.dbFn(as)
+			}
+
+			released, err := as.ResumeApplication(ctx, tt.app, group)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrObj, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.wantReleased, released)
+			require.False(t, tt.app.IsPaused)
+			require.Zero(t, tt.app.PausedAt)
+		})
+	}
+}
+
+func TestAppService_GetAppStatistics(t *testing.T) {
+	ctx := context.Background()
+	app := &datastore.Application{UID: "12345", GroupID: "group-1"}
+
+	tests := []struct {
+		name       string
+		period     string
+		dbFn       func(app *AppService)
+		want       *models.AppStatistics
+		wantErr    bool
+		wantErrObj error
+	}{
+		{
+			name:   "should_compute_and_cache_app_statistics",
+			period: "weekly",
+			dbFn: func(app *AppService) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), "app_statistics:12345:weekly", gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), "app_statistics:12345:weekly", gomock.Any(), appStatisticsCacheTTL).Times(1).Return(nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountEvents(gomock.Any(), "group-1", "12345", gomock.Any()).Times(1).Return(int64(10000), nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadDeliveryStatistics(gomock.Any(), "group-1", "12345", gomock.Any()).Times(1).
+					Return(&datastore.DeliveryStatistics{Attempted: 10000, Successful: 9870, Failed: 130, AverageLatencyMs: 245}, nil)
+			},
+			want: &models.AppStatistics{
+				Period:               "weekly",
+				EventsCreated:        10000,
+				DeliveriesAttempted:  10000,
+				SuccessfulDeliveries: 9870,
+				FailedDeliveries:     130,
+				SuccessRate:          98.7,
+				AverageLatencyMs:     245,
+			},
+		},
+		{
+			name:   "should_return_cached_app_statistics",
+			period: "daily",
+			dbFn: func(app *AppService) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), "app_statistics:12345:daily", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**models.AppStatistics) = &models.AppStatistics{Period: "daily", EventsCreated: 3}
+						return nil
+					})
+			},
+			want: &models.AppStatistics{Period: "daily", EventsCreated: 3},
+		},
+		{
+			name:   "should_not_divide_by_zero_without_attempted_deliveries",
+			period: "daily",
+			dbFn: func(app *AppService) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadDeliveryStatistics(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Return(&datastore.DeliveryStatistics{}, nil)
+			},
+			want: &models.AppStatistics{Period: "daily"},
+		},
+		{
+			name:   "should_fail_to_load_delivery_statistics",
+			period: "monthly",
+			dbFn: func(app *AppService) {
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				e, _ := app.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(int64(5), nil)
+
+				ed, _ := app.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadDeliveryStatistics(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("failed to load app statistics")),
+		},
+		{
+			name:       "should_error_for_invalid_period",
+			period:     "hourly",
+			wantErr:    true,
+			wantErrObj: NewServiceError(http.StatusBadRequest, errors.New("please specify a period in (daily, weekly, monthly, yearly)")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			// Arrange Expectations
+			if tt.dbFn != nil {
+				tt.dbFn(as)
+			}
+
+			statistics, err := as.GetAppStatistics(ctx, app, tt.period)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrObj, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.want, statistics)
+		})
+	}
+}
+
+type eventDeliveryUIDMatcher struct{ uid string }
+
+func (m eventDeliveryUIDMatcher) Matches(x interface{}) bool {
+	switch d := x.(type) {
+	case datastore.EventDelivery:
+		return d.UID == m.uid
+	case *datastore.EventDelivery:
+		return d.UID == m.uid
+	}
+	return false
+}
+
+func (m eventDeliveryUIDMatcher) String() string {
+	return "is event delivery " + m.uid
+}
+
+func eventDeliveryWithUID(uid string) gomock.Matcher {
+	return eventDeliveryUIDMatcher{uid: uid}
+}
+
+func TestAppService_TransferApplication(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		app         *datastore.Application
+		group       *datastore.Group
+		dbFn        func(as *AppService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:  "should_transfer_application",
+			app:   &datastore.Application{UID: "abc", GroupID: "group-a"},
+			group: &datastore.Group{UID: "group-b"},
+			dbFn: func(as *AppService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().UpdateEventsGroupID(gomock.Any(), "abc", "group-b").Times(1).Return(nil)
+
+				ed, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					UpdateEventDeliveriesGroupID(gomock.Any(), "abc", "group-b", []datastore.EventDeliveryStatus{datastore.ProcessingEventStatus}).
+					Times(1).Return(nil)
+
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, app *datastore.Application) error {
+						require.Equal(t, "group-b", app.GroupID)
+						return nil
+					})
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:abc").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:group-a").Times(1)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:group-b").Times(1)
+				for period := range datastore.PeriodValues {
+					c.EXPECT().Delete(gomock.Any(), "app_statistics:abc:"+period).Times(1)
+				}
+			},
+		},
+		{
+			name:        "should_fail_for_the_same_group",
+			app:         &datastore.Application{UID: "abc", GroupID: "group-a"},
+			group:       &datastore.Group{UID: "group-a"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "app already belongs to the group",
+		},
+		{
+			name:  "should_not_move_app_when_events_fail_to_move",
+			app:   &datastore.Application{UID: "abc", GroupID: "group-a"},
+			group: &datastore.Group{UID: "group-b"},
+			dbFn: func(as *AppService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().UpdateEventsGroupID(gomock.Any(), "abc", "group-b").Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "an error occurred while moving app events",
+		},
+		{
+			name:  "should_keep_app_group_when_app_fails_to_move",
+			app:   &datastore.Application{UID: "abc", GroupID: "group-a"},
+			group: &datastore.Group{UID: "group-b"},
+			dbFn: func(as *AppService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().UpdateEventsGroupID(gomock.Any(), "abc", "group-b").Times(1).Return(nil)
+
+				ed, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().UpdateEventDeliveriesGroupID(gomock.Any(), "abc", "group-b", gomock.Any()).Times(1).Return(nil)
+
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "an error occurred while moving app",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(as)
+			}
+
+			groupID := tc.app.GroupID
+			err := as.TransferApplication(ctx, tc.app, tc.group)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				require.Equal(t, groupID, tc.app.GroupID)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.group.UID, tc.app.GroupID)
+		})
+	}
+}
+
 func TestAppService_CreateAppEndpoint(t *testing.T) {
 
 	ctx := context.Background()
@@ -547,10 +1217,97 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 		wantErrMsg   string
 	}{
 		{
-			name: "should_create_app_endpoint",
+			name: "should_create_app_endpoint",
+			args: args{
+				ctx: ctx,
+				e:   models.Endpoint{Secret: "1234", URL: "https://google.com", Description: "test_endpoint", Events: []string{"payment.created"}},
+				app: &datastore.Application{UID: "abc"},
+			},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:abc")
+			},
+			wantApp: &datastore.Application{
+				UID: "abc",
+				Endpoints: []datastore.Endpoint{
+					{
+						Secret:            "1234",
+						TargetURL:         "https://google.com",
+						Description:       "test_endpoint",
+						Status:            datastore.ActiveEndpointStatus,
+						RateLimit:         5000,
+						RateLimitDuration: "1m0s",
+						DocumentStatus:    datastore.ActiveDocumentStatus,
+						Events:            []string{"payment.created"},
+					},
+				},
+			},
+			wantEndpoint: &datastore.Endpoint{
+				Secret:            "1234",
+				TargetURL:         "https://google.com",
+				Description:       "test_endpoint",
+				Status:            datastore.ActiveEndpointStatus,
+				RateLimit:         5000,
+				RateLimitDuration: "1m0s",
+				DocumentStatus:    datastore.ActiveDocumentStatus,
+				Events:            []string{"payment.created"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_default_endpoint_secret_to_app_secret",
+			args: args{
+				ctx: ctx,
+				e:   models.Endpoint{URL: "https://google.com", Description: "test_endpoint", Events: []string{"payment.created"}},
+				app: &datastore.Application{UID: "abc", Secret: "app-secret"},
+			},
+			dbFn: func(app *AppService) {
+				a, _ := app.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:abc")
+			},
+			wantApp: &datastore.Application{
+				UID:    "abc",
+				Secret: "app-secret",
+				Endpoints: []datastore.Endpoint{
+					{
+						Secret:            "app-secret",
+						TargetURL:         "https://google.com",
+						Description:       "test_endpoint",
+						Status:            datastore.ActiveEndpointStatus,
+						RateLimit:         5000,
+						RateLimitDuration: "1m0s",
+						DocumentStatus:    datastore.ActiveDocumentStatus,
+						Events:            []string{"payment.created"},
+					},
+				},
+			},
+			wantEndpoint: &datastore.Endpoint{
+				Secret:            "app-secret",
+				TargetURL:         "https://google.com",
+				Description:       "test_endpoint",
+				Status:            datastore.ActiveEndpointStatus,
+				RateLimit:         5000,
+				RateLimitDuration: "1m0s",
+				DocumentStatus:    datastore.ActiveDocumentStatus,
+				Events:            []string{"payment.created"},
+			},
+		},
+		{
+			name: "should_create_inactive_app_endpoint_with_metadata",
 			args: args{
 				ctx: ctx,
-				e:   models.Endpoint{Secret: "1234", URL: "https://google.com", Description: "test_endpoint", Events: []string{"payment.created"}},
+				e: models.Endpoint{
+					Secret:   "1234",
+					URL:      "https://google.com",
+					Status:   datastore.InactiveEndpointStatus,
+					Metadata: map[string]string{"team": "payments"},
+				},
 				app: &datastore.Application{UID: "abc"},
 			},
 			dbFn: func(app *AppService) {
@@ -558,7 +1315,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "applications:abc")
 			},
 			wantApp: &datastore.Application{
 				UID: "abc",
@@ -566,26 +1323,36 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 					{
 						Secret:            "1234",
 						TargetURL:         "https://google.com",
-						Description:       "test_endpoint",
-						Status:            datastore.ActiveEndpointStatus,
+						Status:            datastore.InactiveEndpointStatus,
+						Metadata:          map[string]string{"team": "payments"},
 						RateLimit:         5000,
 						RateLimitDuration: "1m0s",
 						DocumentStatus:    datastore.ActiveDocumentStatus,
-						Events:            []string{"payment.created"},
+						Events:            []string{"*"},
 					},
 				},
 			},
 			wantEndpoint: &datastore.Endpoint{
 				Secret:            "1234",
 				TargetURL:         "https://google.com",
-				Description:       "test_endpoint",
-				Status:            datastore.ActiveEndpointStatus,
+				Status:            datastore.InactiveEndpointStatus,
+				Metadata:          map[string]string{"team": "payments"},
 				RateLimit:         5000,
 				RateLimitDuration: "1m0s",
 				DocumentStatus:    datastore.ActiveDocumentStatus,
-				Events:            []string{"payment.created"},
+				Events:            []string{"*"},
 			},
-			wantErr: false,
+		},
+		{
+			name: "should_error_for_pending_endpoint_status",
+			args: args{
+				ctx: ctx,
+				e:   models.Endpoint{URL: "https://google.com", Status: datastore.PendingEndpointStatus},
+				app: &datastore.Application{UID: "abc"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "endpoint status cannot be set to pending",
 		},
 		{
 			name: "should_create_app_endpoint_with_no_events",
@@ -605,7 +1372,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
 				c, _ := app.cache.(*mocks.MockCache)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "applications:abc")
 			},
 			wantApp: &datastore.Application{
 				UID: "abc",
@@ -710,6 +1477,55 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 	}
 }
 
+func TestAppService_BackfillEndpointSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	as := provideAppService(ctrl)
+
+	withAppSecret := datastore.Application{
+		UID:    "app-1",
+		Secret: "app-secret",
+		Endpoints: []datastore.Endpoint{
+			{UID: "endpoint-1", Secret: "endpoint-secret"},
+			{UID: "endpoint-2"},
+		},
+	}
+	withoutAppSecret := datastore.Application{
+		UID:       "app-2",
+		Endpoints: []datastore.Endpoint{{UID: "endpoint-3"}},
+	}
+	upToDate := datastore.Application{
+		UID:       "app-3",
+		Endpoints: []datastore.Endpoint{{UID: "endpoint-4", Secret: "endpoint-secret"}},
+	}
+
+	a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().
+		LoadApplicationsPaged(gomock.Any(), "", gomock.Any(), gomock.Any()).Times(1).
+		Return([]datastore.Application{withAppSecret, withoutAppSecret, upToDate}, datastore.PaginationData{TotalPage: 1}, nil)
+
+	updated := map[string]*datastore.Application{}
+	a.EXPECT().
+		UpdateApplication(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, app *datastore.Application) error {
+			updated[app.UID] = app
+			return nil
+		})
+
+	c, _ := as.cache.(*mocks.MockCache)
+	c.EXPECT().Delete(gomock.Any(), "applications:app-1").Times(1)
+	c.EXPECT().Delete(gomock.Any(), "applications:app-2").Times(1)
+
+	n, err := as.BackfillEndpointSecrets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.Equal(t, "endpoint-secret", updated["app-1"].Endpoints[0].Secret)
+	require.Equal(t, "app-secret", updated["app-1"].Endpoints[1].Secret)
+	require.NotEmpty(t, updated["app-2"].Endpoints[0].Secret)
+	require.NotContains(t, updated, "app-3")
+}
+
 func stripVariableFields(t *testing.T, obj string, v interface{}) {
 	switch obj {
 	case "application":
@@ -816,7 +1632,7 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 					Times(1).Return(nil)
 
 				c, _ := as.cache.(*mocks.MockCache)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "applications:1234")
 			},
 			wantErr: false,
 		},
@@ -845,6 +1661,62 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  `time: invalid duration "m"`,
 		},
+		{
+			name: "should_error_for_http_timeout_over_the_max",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL:         "https://fb.com",
+					HttpTimeout: "10m",
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com"}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "http timeout cannot be longer than 2m0s",
+		},
+		{
+			name: "should_error_for_negative_rate_limit",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL:               "https://fb.com",
+					RateLimit:         -1,
+					RateLimitDuration: "1m",
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com"}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide a valid rate limit",
+		},
+		{
+			name: "should_error_for_zero_rate_limit_duration",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL:               "https://fb.com",
+					RateLimit:         60,
+					RateLimitDuration: "0s",
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com"}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide a valid rate limit duration",
+		},
 		{
 			name: "should_fail_to_update_app_endpoint",
 			args: args{
@@ -875,6 +1747,104 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "an error occurred while updating app endpoints",
 		},
+		{
+			name: "should_keep_inactive_app_endpoint_inactive",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL:      "https://fb.com",
+					Status:   datastore.InactiveEndpointStatus,
+					Metadata: map[string]string{"owner": "ops"},
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID: "1234",
+					Endpoints: []datastore.Endpoint{
+						{
+							UID:        "endpoint1",
+							TargetURL:  "https://google.com",
+							Status:     datastore.InactiveEndpointStatus,
+							Metadata:   map[string]string{"owner": "payments"},
+							DisabledAt: 1000,
+						},
+					},
+				},
+			},
+			wantApp: &datastore.Application{
+				UID: "1234",
+				Endpoints: []datastore.Endpoint{
+					{
+						UID:        "endpoint1",
+						Events:     []string{"*"},
+						TargetURL:  "https://fb.com",
+						Status:     datastore.InactiveEndpointStatus,
+						Metadata:   map[string]string{"owner": "ops"},
+						DisabledAt: 1000,
+					},
+				},
+			},
+			wantEndpoint: &datastore.Endpoint{
+				UID:        "endpoint1",
+				Events:     []string{"*"},
+				TargetURL:  "https://fb.com",
+				Status:     datastore.InactiveEndpointStatus,
+				Metadata:   map[string]string{"owner": "ops"},
+				DisabledAt: 1000,
+			},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "applications:1234")
+			},
+		},
+		{
+			name: "should_error_for_pending_endpoint_status",
+			args: args{
+				ctx:        ctx,
+				e:          models.Endpoint{URL: "https://fb.com", Status: datastore.PendingEndpointStatus},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com", Status: datastore.InactiveEndpointStatus}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "endpoint status cannot be set to pending",
+		},
+		{
+			name: "should_error_for_unknown_endpoint_status",
+			args: args{
+				ctx:        ctx,
+				e:          models.Endpoint{URL: "https://fb.com", Status: "paused"},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com"}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  `invalid endpoint status "paused", status must be active or inactive`,
+		},
+		{
+			name: "should_error_for_too_long_metadata_value",
+			args: args{
+				ctx:        ctx,
+				e:          models.Endpoint{URL: "https://fb.com", Metadata: map[string]string{"owner": strings.Repeat("a", 257)}},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID:       "1234",
+					Endpoints: []datastore.Endpoint{{UID: "endpoint1", TargetURL: "https://google.com"}},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  `metadata value of "owner" is longer than 256 characters`,
+		},
 		{
 			name: "should_error_for_endpoint_not_found",
 			args: args{
@@ -936,6 +1906,131 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 	}
 }
 
+// mapCache is a cache.Cache that keeps values as they were set, so tests
+// can see what is left in the cache.
+type mapCache map[string]interface{}
+
+func (m mapCache) Set(_ context.Context, key string, data interface{}, _ time.Duration) error {
+	m[key] = data
+	return nil
+}
+
+func (m mapCache) Get(context.Context, string, interface{}) error {
+	return nil
+}
+
+func (m mapCache) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func TestAppService_UpdateAppEndpoint_DoesNotServeStaleURL(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	as := provideAppService(ctrl)
+	c := mapCache{}
+	as.cache = c
+
+	app := &datastore.Application{
+		UID:     "1234",
+		GroupID: "abc",
+		Endpoints: []datastore.Endpoint{
+			{UID: "endpoint1", TargetURL: "https://old.example.com", Status: datastore.ActiveEndpointStatus},
+		},
+	}
+
+	// a delivery read the app into the cache before the endpoint changed
+	cached := *app
+	cached.Endpoints = append([]datastore.Endpoint{}, app.Endpoints...)
+	cacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
+	require.NoError(t, c.Set(ctx, cacheKey, &cached, time.Minute))
+
+	a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	_, err := as.UpdateAppEndpoint(ctx, models.Endpoint{URL: "https://new.example.com"}, "endpoint1", app)
+	require.Nil(t, err)
+
+	// the next reader must miss the cache and load the app from the
+	// database rather than get the old url back
+	require.NotContains(t, c, cacheKey)
+}
+
+func TestAppService_GetEndpointRateLimitUsage(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		endpoint    *datastore.Endpoint
+		group       *datastore.Group
+		dbFn        func(as *AppService)
+		wantUsage   *models.RateLimitUsage
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:     "should_use_the_tighter_endpoint_limit",
+			endpoint: &datastore.Endpoint{TargetURL: "https://tiny.service", RateLimit: 60, RateLimitDuration: "1m0s"},
+			group:    &datastore.Group{RateLimit: 5000, RateLimitDuration: "1m"},
+			dbFn: func(as *AppService) {
+				l, _ := as.limiter.(*mocks.MockRateLimiter)
+				l.EXPECT().Inspect(gomock.Any(), "https://tiny.service", 60, int(time.Minute)).Times(1).
+					Return(&redis_rate.Result{Remaining: 43, ResetAfter: 17 * time.Second}, nil)
+			},
+			wantUsage: &models.RateLimitUsage{Limit: 60, Duration: "1m0s", Used: 18, Remaining: 42, ResetAfter: "17s"},
+		},
+		{
+			name:     "should_use_the_tighter_group_limit",
+			endpoint: &datastore.Endpoint{TargetURL: "https://google.com", RateLimit: 100, RateLimitDuration: "1s"},
+			group:    &datastore.Group{RateLimit: 5000, RateLimitDuration: "1m"},
+			dbFn: func(as *AppService) {
+				l, _ := as.limiter.(*mocks.MockRateLimiter)
+				l.EXPECT().Inspect(gomock.Any(), "https://google.com", 5000, int(time.Minute)).Times(1).
+					Return(&redis_rate.Result{Remaining: 5001}, nil)
+			},
+			wantUsage: &models.RateLimitUsage{Limit: 5000, Duration: "1m0s", Used: 0, Remaining: 5000, ResetAfter: "0s"},
+		},
+		{
+			name:     "should_fail_to_check_limiter",
+			endpoint: &datastore.Endpoint{TargetURL: "https://google.com", RateLimit: 60, RateLimitDuration: "1m0s"},
+			group:    &datastore.Group{},
+			dbFn: func(as *AppService) {
+				l, _ := as.limiter.(*mocks.MockRateLimiter)
+				l.EXPECT().Inspect(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to fetch endpoint rate limit usage",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(as)
+			}
+
+			usage, err := as.GetEndpointRateLimitUsage(ctx, tc.endpoint, tc.group)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantUsage, usage)
+		})
+	}
+}
+
 func TestAppService_DeleteAppEndpoint(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -993,7 +2088,7 @@ func TestAppService_DeleteAppEndpoint(t *testing.T) {
 				appRepo.EXPECT().UpdateApplication(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
 				c, _ := as.cache.(*mocks.MockCache)
-				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Delete(gomock.Any(), "applications:abc")
 			},
 			wantErr: false,
 		},
@@ -1053,3 +2148,144 @@ func TestAppService_DeleteAppEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestAppService_PingAppEndpoint(t *testing.T) {
+	require.NoError(t, config.LoadConfig(""))
+
+	var body []byte
+	var signature string
+	receive := func(status int, delay time.Duration) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			signature = r.Header.Get("X-Convoy-Signature")
+
+			time.Sleep(delay)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(strings.Repeat("a", 2000)))
+		}
+	}
+
+	tests := []struct {
+		name           string
+		newServer      func(http.Handler) *httptest.Server
+		handler        http.HandlerFunc
+		httpTimeout    string
+		wantStatusCode int
+		wantErr        string
+		wantSigned     bool
+	}{
+		{
+			name:           "should_report_2xx",
+			newServer:      httptest.NewServer,
+			handler:        receive(http.StatusOK, 0),
+			wantStatusCode: http.StatusOK,
+			wantSigned:     true,
+		},
+		{
+			name:           "should_report_5xx",
+			newServer:      httptest.NewServer,
+			handler:        receive(http.StatusInternalServerError, 0),
+			wantStatusCode: http.StatusInternalServerError,
+			wantSigned:     true,
+		},
+		{
+			name:        "should_report_timeout",
+			newServer:   httptest.NewServer,
+			handler:     receive(http.StatusOK, 500*time.Millisecond),
+			httpTimeout: "100ms",
+			wantErr:     "Client.Timeout exceeded",
+		},
+		{
+			name:      "should_report_tls_failure",
+			newServer: httptest.NewTLSServer,
+			handler:   receive(http.StatusOK, 0),
+			wantErr:   "certificate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			body, signature = nil, ""
+			srv := tc.newServer(tc.handler)
+			defer srv.Close()
+
+			g := &datastore.Group{UID: "group-1", Config: &datastore.GroupConfig{
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA256"},
+			}}
+			app := &datastore.Application{UID: "app-1", GroupID: "group-1"}
+			endpoint := &datastore.Endpoint{UID: "endpoint-1", TargetURL: srv.URL, Secret: "endpoint-secret", HttpTimeout: tc.httpTimeout}
+
+			ping, err := as.PingAppEndpoint(context.Background(), g, app, endpoint, false)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantStatusCode, ping.StatusCode)
+			require.Empty(t, ping.EventID)
+
+			if tc.wantErr != "" {
+				require.Contains(t, ping.Error, tc.wantErr)
+				return
+			}
+
+			require.Empty(t, ping.Error)
+			require.Len(t, ping.Body, endpointPingMaxResponseSize)
+
+			require.JSONEq(t, `{"event_type": "endpoint.ping"}`, string(body))
+			wantSignature, err := util.ComputeJSONHmac("SHA256", string(body), "endpoint-secret", false)
+			require.NoError(t, err)
+			require.Equal(t, wantSignature, signature)
+		})
+	}
+}
+
+func TestAppService_PingAppEndpoint_Record(t *testing.T) {
+	require.NoError(t, config.LoadConfig(""))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	as := provideAppService(ctrl)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	g := &datastore.Group{UID: "group-1", Config: &datastore.GroupConfig{
+		Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA256"},
+	}}
+	app := &datastore.Application{UID: "app-1", GroupID: "group-1"}
+	endpoint := &datastore.Endpoint{UID: "endpoint-1", TargetURL: srv.URL, Secret: "endpoint-secret"}
+
+	var event *datastore.Event
+	e, _ := as.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, ev *datastore.Event) error {
+			event = ev
+			return nil
+		})
+
+	var delivery *datastore.EventDelivery
+	d, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, ed *datastore.EventDelivery) error {
+			delivery = ed
+			return nil
+		})
+
+	ping, err := as.PingAppEndpoint(context.Background(), g, app, endpoint, true)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusServiceUnavailable, ping.StatusCode)
+	require.Equal(t, event.UID, ping.EventID)
+	require.Equal(t, delivery.UID, ping.EventDeliveryID)
+
+	require.Equal(t, datastore.EventType(EndpointPingEventType), event.EventType)
+	require.Equal(t, event.UID, delivery.EventMetadata.UID)
+	require.Equal(t, datastore.FailureEventStatus, delivery.Status)
+	require.Len(t, delivery.DeliveryAttempts, 1)
+	require.Equal(t, "503 Service Unavailable", delivery.DeliveryAttempts[0].HttpResponseCode)
+	require.False(t, delivery.DeliveryAttempts[0].Status)
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/objectstore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	archiveBatchSize = 500
+
+	eventArchiveRecord         = "event"
+	eventDeliveryArchiveRecord = "event_delivery"
+)
+
+// archiveRecord is a line of an archive. Delivery attempts are kept apart
+// since they are left out of an event delivery's json.
+type archiveRecord struct {
+	Kind             string                      `json:"kind"`
+	Event            *datastore.Event            `json:"event,omitempty"`
+	EventDelivery    *datastore.EventDelivery    `json:"event_delivery,omitempty"`
+	DeliveryAttempts []datastore.DeliveryAttempt `json:"delivery_attempts,omitempty"`
+}
+
+var ErrArchiveStorageNotConfigured = errors.New("archive storage is not configured")
+
+// ArchiveRestore reports what was re-imported from an archive.
+type ArchiveRestore struct {
+	Events          int `json:"events"`
+	EventDeliveries int `json:"event_deliveries"`
+	Failed          int `json:"failed"`
+}
+
+type ArchiveService struct {
+	groupRepo         datastore.GroupRepository
+	eventRepo         datastore.EventRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	archiveRepo       datastore.ArchiveRepository
+	store             objectstore.ObjectStore
+}
+
+func NewArchiveService(groupRepo datastore.GroupRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, archiveRepo datastore.ArchiveRepository, store objectstore.ObjectStore) *ArchiveService {
+	return &ArchiveService{
+		groupRepo:         groupRepo,
+		eventRepo:         eventRepo,
+		eventDeliveryRepo: eventDeliveryRepo,
+		archiveRepo:       archiveRepo,
+		store:             store,
+	}
+}
+
+// ApplyRetentionPolicies archives and deletes the events of every group
+// that are older than the group's retention policy.
+func (a *ArchiveService) ApplyRetentionPolicies(ctx context.Context) error {
+	if a.store == nil {
+		return ErrArchiveStorageNotConfigured
+	}
+
+	groups, err := a.groupRepo.LoadGroups(ctx, &datastore.GroupFilter{})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		if g.Config == nil || util.IsStringEmpty(g.Config.RetentionPolicy) {
+			continue
+		}
+
+		retention, err := time.ParseDuration(g.Config.RetentionPolicy)
+		if err != nil {
+			log.WithError(err).Errorf("invalid retention policy for group %s", g.UID)
+			continue
+		}
+
+		manifests, err := a.ArchiveGroupEvents(ctx, g, time.Now().Add(-retention))
+		if err != nil {
+			log.WithError(err).Errorf("failed to archive events of group %s", g.UID)
+		}
+
+		if len(manifests) > 0 {
+			log.Infof("archived %d batches of events of group %s", len(manifests), g.UID)
+		}
+	}
+
+	return nil
+}
+
+// ArchiveGroupEvents uploads g's events created before cutoff, along with
+// their deliveries, in batches and deletes a batch only once it has been
+// stored and its manifest recorded. A batch that fails to upload is kept
+// and ends the run.
+func (a *ArchiveService) ArchiveGroupEvents(ctx context.Context, g *datastore.Group, cutoff time.Time) ([]datastore.ArchiveManifest, error) {
+	manifests := make([]datastore.ArchiveManifest, 0)
+
+	searchParams := datastore.SearchParams{CreatedAtEnd: cutoff.Unix()}
+	pageable := datastore.Pageable{Page: 1, PerPage: archiveBatchSize, Sort: 1}
+
+	for {
+		// archived events are deleted, so the first page is always the next batch
+		events, _, err := a.eventRepo.LoadEventsPaged(ctx, g.UID, "", searchParams, pageable)
+		if err != nil {
+			return manifests, fmt.Errorf("failed to load events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return manifests, nil
+		}
+
+		manifest, err := a.archiveBatch(ctx, g, events)
+		if err != nil {
+			return manifests, err
+		}
+		manifests = append(manifests, *manifest)
+
+		if len(events) < archiveBatchSize {
+			return manifests, nil
+		}
+	}
+}
+
+func (a *ArchiveService) archiveBatch(ctx context.Context, g *datastore.Group, events []datastore.Event) (*datastore.ArchiveManifest, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	eventIDs := make([]string, 0, len(events))
+	deliveryIDs := make([]string, 0)
+
+	for i := range events {
+		event := &events[i]
+		err := enc.Encode(&archiveRecord{Kind: eventArchiveRecord, Event: event})
+		if err != nil {
+			return nil, err
+		}
+		eventIDs = append(eventIDs, event.UID)
+
+		deliveries, err := a.eventDeliveryRepo.FindEventDeliveriesByEventID(ctx, event.UID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load deliveries of event %s: %w", event.UID, err)
+		}
+
+		for j := range deliveries {
+			delivery := &deliveries[j]
+			err = enc.Encode(&archiveRecord{
+				Kind:             eventDeliveryArchiveRecord,
+				EventDelivery:    delivery,
+				DeliveryAttempts: delivery.DeliveryAttempts,
+			})
+			if err != nil {
+				return nil, err
+			}
+			deliveryIDs = append(deliveryIDs, delivery.UID)
+		}
+	}
+
+	uid := uuid.New().String()
+	manifest := &datastore.ArchiveManifest{
+		UID:                uid,
+		GroupID:            g.UID,
+		Bucket:             a.store.Bucket(),
+		ObjectKey:          path.Join(g.UID, uid+".ndjson"),
+		EventCount:         len(eventIDs),
+		EventDeliveryCount: len(deliveryIDs),
+		OldestEventAt:      events[0].CreatedAt,
+		NewestEventAt:      events[len(events)-1].CreatedAt,
+		CreatedAt:          primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := a.store.Put(ctx, manifest.ObjectKey, buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload archive %s: %w", manifest.ObjectKey, err)
+	}
+
+	err = a.archiveRepo.CreateArchiveManifest(ctx, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest for archive %s: %w", manifest.ObjectKey, err)
+	}
+
+	if len(deliveryIDs) > 0 {
+		err = a.eventDeliveryRepo.DeleteEventDeliveries(ctx, deliveryIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete archived event deliveries: %w", err)
+		}
+	}
+
+	err = a.eventRepo.DeleteEvents(ctx, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete archived events: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func (a *ArchiveService) LoadArchiveManifestsPaged(ctx context.Context, g *datastore.Group, pageable datastore.Pageable) ([]datastore.ArchiveManifest, datastore.PaginationData, error) {
+	manifests, paginationData, err := a.archiveRepo.LoadArchiveManifestsPaged(ctx, g.UID, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load archive manifests")
+		return nil, datastore.PaginationData{}, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching archives"))
+	}
+
+	return manifests, paginationData, nil
+}
+
+func (a *ArchiveService) FindArchiveManifestByID(ctx context.Context, g *datastore.Group, id string) (*datastore.ArchiveManifest, error) {
+	manifest, err := a.archiveRepo.FindArchiveManifestByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrArchiveManifestNotFound) {
+			return nil, NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to find archive manifest")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching archive"))
+	}
+
+	if manifest.GroupID != g.UID {
+		return nil, NewServiceError(http.StatusNotFound, datastore.ErrArchiveManifestNotFound)
+	}
+
+	return manifest, nil
+}
+
+// RestoreArchive re-imports the events and deliveries of an archive of g so
+// they can be looked into again. It is best effort, records that cannot be
+// imported, e.g. because they were restored before, are counted and skipped.
+// The restored records expire after g's event retention from now, rather
+// than from when they were first created.
+func (a *ArchiveService) RestoreArchive(ctx context.Context, g *datastore.Group, manifest *datastore.ArchiveManifest) (*ArchiveRestore, error) {
+	if a.store == nil {
+		return nil, NewServiceError(http.StatusBadRequest, ErrArchiveStorageNotConfigured)
+	}
+
+	data, err := a.store.Get(ctx, manifest.ObjectKey)
+	if err != nil {
+		log.WithError(err).Errorf("failed to download archive %s", manifest.ObjectKey)
+		return nil, NewServiceError(http.StatusBadGateway, errors.New("failed to download archive"))
+	}
+
+	restore := &ArchiveRestore{}
+	expiresAt := g.EventExpiry(time.Now())
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+
+	for scanner.Scan() {
+		var record archiveRecord
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			restore.Failed++
+			continue
+		}
+
+		switch {
+		case record.Kind == eventArchiveRecord && record.Event != nil:
+			record.Event.DocumentStatus = datastore.ActiveDocumentStatus
+			record.Event.ExpiresAt = expiresAt
+			err = a.eventRepo.CreateEvent(ctx, record.Event)
+			if err == nil {
+				restore.Events++
+			}
+		case record.Kind == eventDeliveryArchiveRecord && record.EventDelivery != nil:
+			record.EventDelivery.DocumentStatus = datastore.ActiveDocumentStatus
+			record.EventDelivery.DeliveryAttempts = record.DeliveryAttempts
+			record.EventDelivery.ExpiresAt = expiresAt
+			err = a.eventDeliveryRepo.CreateEventDelivery(ctx, record.EventDelivery)
+			if err == nil {
+				restore.EventDeliveries++
+			}
+		default:
+			err = fmt.Errorf("unknown archive record kind %q", record.Kind)
+		}
+
+		if err != nil {
+			log.WithError(err).Errorf("failed to restore a record of archive %s", manifest.UID)
+			restore.Failed++
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		log.WithError(err).Errorf("failed to read archive %s", manifest.ObjectKey)
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to read archive"))
+	}
+
+	manifest.RestoredAt = primitive.NewDateTimeFromTime(time.Now())
+	err = a.archiveRepo.UpdateArchiveManifest(ctx, manifest)
+	if err != nil {
+		log.WithError(err).Errorf("failed to update archive manifest %s", manifest.UID)
+	}
+
+	return restore, nil
+}
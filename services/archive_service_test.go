@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func provideArchiveService(ctrl *gomock.Controller) *ArchiveService {
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	archiveRepo := mocks.NewMockArchiveRepository(ctrl)
+	store := mocks.NewMockObjectStore(ctrl)
+	return NewArchiveService(groupRepo, eventRepo, eventDeliveryRepo, archiveRepo, store)
+}
+
+func TestArchiveService_ArchiveGroupEvents(t *testing.T) {
+	ctx := context.Background()
+	cutoff := time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)
+	group := &datastore.Group{UID: "12345"}
+
+	events := []datastore.Event{
+		{UID: "event-1", EventType: "payment.created", CreatedAt: primitive.NewDateTimeFromTime(cutoff.Add(-2 * time.Hour))},
+		{UID: "event-2", EventType: "payment.created", CreatedAt: primitive.NewDateTimeFromTime(cutoff.Add(-time.Hour))},
+	}
+
+	tests := []struct {
+		name          string
+		dbFn          func(as *ArchiveService)
+		wantManifests int
+		wantErr       bool
+		wantErrMsg    string
+	}{
+		{
+			name: "should_archive_and_delete_events",
+			dbFn: func(as *ArchiveService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsPaged(gomock.Any(), "12345", "", datastore.SearchParams{CreatedAtEnd: cutoff.Unix()}, gomock.Any()).
+					Times(1).Return(events, datastore.PaginationData{}, nil)
+
+				ed, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "event-1").Times(1).
+					Return([]datastore.EventDelivery{{UID: "delivery-1"}}, nil)
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "event-2").Times(1).
+					Return([]datastore.EventDelivery{}, nil)
+
+				s, _ := as.store.(*mocks.MockObjectStore)
+				s.EXPECT().Bucket().Return("convoy")
+				s.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, key string, data []byte) error {
+						require.True(t, strings.HasPrefix(key, "12345/"))
+						require.Equal(t, 3, strings.Count(string(data), "\n"))
+						return nil
+					})
+
+				a, _ := as.archiveRepo.(*mocks.MockArchiveRepository)
+				a.EXPECT().CreateArchiveManifest(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, m *datastore.ArchiveManifest) error {
+						require.Equal(t, "12345", m.GroupID)
+						require.Equal(t, "convoy", m.Bucket)
+						require.Equal(t, 2, m.EventCount)
+						require.Equal(t, 1, m.EventDeliveryCount)
+						return nil
+					})
+
+				ed.EXPECT().DeleteEventDeliveries(gomock.Any(), []string{"delivery-1"}).Times(1).Return(nil)
+				e.EXPECT().DeleteEvents(gomock.Any(), []string{"event-1", "event-2"}).Times(1).Return(nil)
+			},
+			wantManifests: 1,
+		},
+		{
+			name: "should_not_delete_events_when_upload_fails",
+			dbFn: func(as *ArchiveService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsPaged(gomock.Any(), "12345", "", gomock.Any(), gomock.Any()).
+					Times(1).Return(events[:1], datastore.PaginationData{}, nil)
+
+				ed, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "event-1").Times(1).
+					Return([]datastore.EventDelivery{{UID: "delivery-1"}}, nil)
+
+				s, _ := as.store.(*mocks.MockObjectStore)
+				s.EXPECT().Bucket().Return("convoy")
+				s.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.New("access denied"))
+			},
+			wantErr:    true,
+			wantErrMsg: "access denied",
+		},
+		{
+			name: "should_do_nothing_without_expired_events",
+			dbFn: func(as *ArchiveService) {
+				e, _ := as.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsPaged(gomock.Any(), "12345", "", gomock.Any(), gomock.Any()).
+					Times(1).Return([]datastore.Event{}, datastore.PaginationData{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			as := provideArchiveService(ctrl)
+			tc.dbFn(as)
+
+			manifests, err := as.ArchiveGroupEvents(ctx, group, cutoff)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErrMsg)
+				require.Empty(t, manifests)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, manifests, tc.wantManifests)
+		})
+	}
+}
+
+func TestArchiveService_RestoreArchive(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	as := provideArchiveService(ctrl)
+	group := &datastore.Group{UID: "12345", Config: &datastore.GroupConfig{EventRetentionDays: 7}}
+	manifest := &datastore.ArchiveManifest{UID: "archive-1", GroupID: "12345", ObjectKey: "12345/archive-1.ndjson"}
+
+	var lines []string
+	for _, record := range []archiveRecord{
+		{Kind: eventArchiveRecord, Event: &datastore.Event{UID: "event-1", Data: json.RawMessage(`{"amount":100}`)}},
+		{Kind: eventDeliveryArchiveRecord, EventDelivery: &datastore.EventDelivery{UID: "delivery-1"}, DeliveryAttempts: []datastore.DeliveryAttempt{{UID: "attempt-1"}}},
+		{Kind: eventArchiveRecord, Event: &datastore.Event{UID: "event-2"}},
+	} {
+		line, err := json.Marshal(record)
+		require.NoError(t, err)
+		lines = append(lines, string(line))
+	}
+	lines = append(lines, "not json")
+
+	s, _ := as.store.(*mocks.MockObjectStore)
+	s.EXPECT().Get(gomock.Any(), "12345/archive-1.ndjson").Times(1).Return([]byte(strings.Join(lines, "\n")+"\n"), nil)
+
+	e, _ := as.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, event *datastore.Event) error {
+			require.Equal(t, datastore.ActiveDocumentStatus, event.DocumentStatus)
+			require.JSONEq(t, `{"amount":100}`, string(event.Data))
+			require.NotZero(t, event.ExpiresAt)
+			return nil
+		})
+	e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("duplicate key"))
+
+	ed, _ := as.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	ed.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, delivery *datastore.EventDelivery) error {
+			require.Len(t, delivery.DeliveryAttempts, 1)
+			require.NotZero(t, delivery.ExpiresAt)
+			return nil
+		})
+
+	a, _ := as.archiveRepo.(*mocks.MockArchiveRepository)
+	a.EXPECT().UpdateArchiveManifest(gomock.Any(), manifest).Times(1).Return(nil)
+
+	restore, err := as.RestoreArchive(ctx, group, manifest)
+	require.NoError(t, err)
+	require.Equal(t, &ArchiveRestore{Events: 1, EventDeliveries: 1, Failed: 2}, restore)
+	require.NotZero(t, manifest.RestoredAt)
+}
+
+func TestArchiveService_FindArchiveManifestByID(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	as := provideArchiveService(ctrl)
+
+	a, _ := as.archiveRepo.(*mocks.MockArchiveRepository)
+	a.EXPECT().FindArchiveManifestByID(gomock.Any(), "archive-1").Times(1).
+		Return(&datastore.ArchiveManifest{UID: "archive-1", GroupID: "other-group"}, nil)
+
+	_, err := as.FindArchiveManifestByID(ctx, &datastore.Group{UID: "12345"}, "archive-1")
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, err.(*ServiceError).ErrCode())
+}
@@ -0,0 +1,38 @@
+package services
+
+import "github.com/frain-dev/convoy/datastore"
+
+// cursorPage trims the extra item loaded past a page of perPage items from
+// the n items read from cursor c, and returns the bounds of the page with
+// the cursors to the pages around it. key gives the position of item i.
+func cursorPage(n, perPage int, c *datastore.Cursor, key func(i int) datastore.Cursor) (lo, hi int, prevCursor, nextCursor string) {
+	backward := c != nil && c.Backward
+	hasMore := n > perPage
+
+	lo, hi = 0, n
+	if hasMore {
+		if backward {
+			lo = n - perPage
+		} else {
+			hi = perPage
+		}
+	}
+
+	if lo == hi {
+		return lo, hi, "", ""
+	}
+
+	if (backward && hasMore) || (!backward && c != nil) {
+		first := key(lo)
+		first.Backward = true
+		prevCursor = first.Encode()
+	}
+
+	if backward || hasMore {
+		last := key(hi - 1)
+		last.Backward = false
+		nextCursor = last.Encode()
+	}
+
+	return lo, hi, prevCursor, nextCursor
+}
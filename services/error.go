@@ -1,12 +1,131 @@
 package services
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/frain-dev/convoy/util"
+)
+
+// ErrorCode is a stable, machine-readable name for an error. Clients
+// branch on it rather than on the message, which may be reworded.
+type ErrorCode string
+
+const (
+	BadRequestErrorCode      ErrorCode = "bad_request"
+	UnauthorizedErrorCode    ErrorCode = "unauthorized"
+	ForbiddenErrorCode       ErrorCode = "forbidden"
+	NotFoundErrorCode        ErrorCode = "not_found"
+	ConflictErrorCode        ErrorCode = "conflict"
+	PayloadTooLargeErrorCode ErrorCode = "payload_too_large"
+	RateLimitedErrorCode     ErrorCode = "rate_limited"
+	InternalErrorCode        ErrorCode = "internal_error"
+	TimeoutErrorCode         ErrorCode = "timeout"
+	UnavailableErrorCode     ErrorCode = "unavailable"
+
+	ValidationFailedErrorCode    ErrorCode = "validation_failed"
+	GroupNameConflictErrorCode   ErrorCode = "group_name_conflict"
+	GroupNotActiveErrorCode      ErrorCode = "group_not_active"
+	AppTitleConflictErrorCode    ErrorCode = "app_title_conflict"
+	EventTypeConflictErrorCode   ErrorCode = "event_type_conflict"
+	DatabaseTimeoutErrorCode     ErrorCode = "database_timeout"
+	InvalidEventVersionErrorCode ErrorCode = "invalid_event_version"
+)
+
+// errorCodes is the registry of every code an error response can carry,
+// with the http status it is returned with. A code is never reused for a
+// different error once released.
+var errorCodes = map[ErrorCode]int{
+	BadRequestErrorCode:      http.StatusBadRequest,
+	UnauthorizedErrorCode:    http.StatusUnauthorized,
+	ForbiddenErrorCode:       http.StatusForbidden,
+	NotFoundErrorCode:        http.StatusNotFound,
+	ConflictErrorCode:        http.StatusConflict,
+	PayloadTooLargeErrorCode: http.StatusRequestEntityTooLarge,
+	RateLimitedErrorCode:     http.StatusTooManyRequests,
+	InternalErrorCode:        http.StatusInternalServerError,
+	TimeoutErrorCode:         http.StatusGatewayTimeout,
+	UnavailableErrorCode:     http.StatusServiceUnavailable,
+
+	ValidationFailedErrorCode:    http.StatusBadRequest,
+	GroupNameConflictErrorCode:   http.StatusConflict,
+	GroupNotActiveErrorCode:      http.StatusBadRequest,
+	AppTitleConflictErrorCode:    http.StatusConflict,
+	EventTypeConflictErrorCode:   http.StatusConflict,
+	DatabaseTimeoutErrorCode:     http.StatusGatewayTimeout,
+	InvalidEventVersionErrorCode: http.StatusBadRequest,
+}
+
+// ErrorCodes returns every registered error code, sorted.
+func ErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, 0, len(errorCodes))
+	for code := range errorCodes {
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+// ErrorCodeFor returns the generic code of the http status statusCode,
+// it is used for the errors that weren't given a more specific one.
+func ErrorCodeFor(statusCode int) ErrorCode {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return UnauthorizedErrorCode
+	case http.StatusForbidden:
+		return ForbiddenErrorCode
+	case http.StatusNotFound:
+		return NotFoundErrorCode
+	case http.StatusConflict:
+		return ConflictErrorCode
+	case http.StatusRequestEntityTooLarge:
+		return PayloadTooLargeErrorCode
+	case http.StatusTooManyRequests:
+		return RateLimitedErrorCode
+	case http.StatusGatewayTimeout:
+		return TimeoutErrorCode
+	case http.StatusServiceUnavailable:
+		return UnavailableErrorCode
+	}
+
+	if statusCode >= http.StatusInternalServerError {
+		return InternalErrorCode
+	}
+
+	return BadRequestErrorCode
+}
+
 type ServiceError struct {
 	errCode int
 	errMsg  error
+
+	code   ErrorCode
+	fields map[string]string
 }
 
 func NewServiceError(errCode int, errMsg error) *ServiceError {
-	return &ServiceError{errCode: errCode, errMsg: errMsg}
+	return &ServiceError{errCode: errCode, errMsg: errMsg, code: ErrorCodeFor(errCode)}
+}
+
+// NewServiceErrorWithCode is NewServiceError for errors that have their
+// own code in the registry, the http status is the one registered for it.
+func NewServiceErrorWithCode(code ErrorCode, errMsg error) *ServiceError {
+	errCode, ok := errorCodes[code]
+	if !ok {
+		panic(fmt.Sprintf("unregistered error code %q", code))
+	}
+
+	return &ServiceError{errCode: errCode, errMsg: errMsg, code: code}
+}
+
+// WithFields sets the per field messages of a validation error.
+func (s *ServiceError) WithFields(fields map[string]string) *ServiceError {
+	s.fields = fields
+	return s
 }
 
 func (s *ServiceError) Error() string {
@@ -16,3 +135,50 @@ func (s *ServiceError) Error() string {
 func (s *ServiceError) ErrCode() int {
 	return s.errCode
 }
+
+// Code returns the machine-readable code of the error.
+func (s *ServiceError) Code() ErrorCode {
+	return s.code
+}
+
+// Fields returns the messages of the request fields that failed
+// validation, keyed by field, or nil.
+func (s *ServiceError) Fields() map[string]string {
+	return s.fields
+}
+
+// Validate validates dst like util.Validate, the failures are also kept
+// by field so clients don't have to parse the message.
+func Validate(dst interface{}) error {
+	fields := util.ValidateByField(dst)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+
+	sort.Strings(names)
+
+	messages := make([]string, len(names))
+	for i, field := range names {
+		messages[i] = fmt.Sprintf("%s:%s", field, fields[field])
+	}
+
+	return NewServiceErrorWithCode(ValidationFailedErrorCode, errors.New(strings.Join(messages, ", "))).WithFields(fields)
+}
+
+// newValidationError is the ServiceError of the failures found by
+// ValidateGroupConfig.
+func newValidationError(errs []ValidationError) *ServiceError {
+	fields := make(map[string]string, len(errs))
+	for _, e := range errs {
+		if _, ok := fields[e.Field]; !ok {
+			fields[e.Field] = e.Message
+		}
+	}
+
+	return NewServiceErrorWithCode(ValidationFailedErrorCode, joinValidationErrors(errs)).WithFields(fields)
+}
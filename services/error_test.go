@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeFor(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       ErrorCode
+	}{
+		{statusCode: http.StatusBadRequest, want: BadRequestErrorCode},
+		{statusCode: http.StatusUnauthorized, want: UnauthorizedErrorCode},
+		{statusCode: http.StatusNotFound, want: NotFoundErrorCode},
+		{statusCode: http.StatusConflict, want: ConflictErrorCode},
+		{statusCode: http.StatusTooManyRequests, want: RateLimitedErrorCode},
+		{statusCode: http.StatusGatewayTimeout, want: TimeoutErrorCode},
+		{statusCode: http.StatusServiceUnavailable, want: UnavailableErrorCode},
+		{statusCode: http.StatusBadGateway, want: InternalErrorCode},
+		{statusCode: http.StatusUnprocessableEntity, want: BadRequestErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			require.Equal(t, tt.want, ErrorCodeFor(tt.statusCode))
+		})
+	}
+}
+
+func TestNewServiceErrorWithCode(t *testing.T) {
+	err := NewServiceErrorWithCode(GroupNameConflictErrorCode, errors.New("group name is reserved"))
+
+	require.Equal(t, http.StatusConflict, err.ErrCode())
+	require.Equal(t, GroupNameConflictErrorCode, err.Code())
+	require.Equal(t, "group name is reserved", err.Error())
+
+	require.Panics(t, func() {
+		NewServiceErrorWithCode("no_such_code", errors.New("failed"))
+	})
+}
+
+func TestErrorCodes_AreRegisteredOnce(t *testing.T) {
+	seen := map[ErrorCode]bool{}
+	for _, code := range ErrorCodes() {
+		require.False(t, seen[code], "duplicate error code %s", code)
+		seen[code] = true
+
+		require.NotZero(t, errorCodes[code])
+	}
+
+	require.Len(t, seen, len(errorCodes))
+}
+
+func TestValidate_KeepsFieldErrors(t *testing.T) {
+	type request struct {
+		Name  string `valid:"required~please provide a name"`
+		Email string `valid:"required~please provide an email"`
+	}
+
+	require.NoError(t, Validate(request{Name: "convoy", Email: "info@getconvoy.io"}))
+
+	err := Validate(request{})
+	require.Error(t, err)
+
+	var serviceErr *ServiceError
+	require.True(t, errors.As(err, &serviceErr))
+	require.Equal(t, http.StatusBadRequest, serviceErr.ErrCode())
+	require.Equal(t, ValidationFailedErrorCode, serviceErr.Code())
+	require.Equal(t, "Email:please provide an email, Name:please provide a name", serviceErr.Error())
+	require.Equal(t, map[string]string{"Name": "please provide a name", "Email": "please provide an email"}, serviceErr.Fields())
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EventBridgeService struct {
+	ruleRepo datastore.EventBridgeRepository
+}
+
+func NewEventBridgeService(ruleRepo datastore.EventBridgeRepository) *EventBridgeService {
+	return &EventBridgeService{ruleRepo: ruleRepo}
+}
+
+// CreateEventBridgeRule creates a rule forwarding the events of source to
+// the apps of dest.
+func (e *EventBridgeService) CreateEventBridgeRule(ctx context.Context, source, dest *datastore.Group, newRule *models.EventBridgeRule) (*datastore.EventBridgeRule, error) {
+	if err := Validate(newRule); err != nil {
+		return nil, err
+	}
+
+	if source.UID == dest.UID {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("events cannot be forwarded to the group they are sent in"))
+	}
+
+	if len(newRule.EventTypeFilter) == 0 {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("please provide the event types to forward"))
+	}
+
+	for _, eventType := range newRule.EventTypeFilter {
+		if util.IsStringEmpty(eventType) {
+			return nil, NewServiceError(http.StatusBadRequest, errors.New("event types cannot be empty"))
+		}
+	}
+
+	if !util.IsStringEmpty(newRule.TransformTemplate) {
+		_, err := task.ParseTransformTemplate(newRule.TransformTemplate)
+		if err != nil {
+			return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("invalid transform template: %v", err))
+		}
+	}
+
+	rule := &datastore.EventBridgeRule{
+		UID:               uuid.New().String(),
+		SourceGroupID:     source.UID,
+		DestGroupID:       dest.UID,
+		EventTypeFilter:   newRule.EventTypeFilter,
+		TransformTemplate: newRule.TransformTemplate,
+		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := e.ruleRepo.CreateEventBridgeRule(ctx, rule)
+	if err != nil {
+		log.WithError(err).Error("failed to create event bridge rule")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to create event bridge rule"))
+	}
+
+	return rule, nil
+}
+
+// LoadEventBridgeRules returns the rules forwarding the events of g.
+func (e *EventBridgeService) LoadEventBridgeRules(ctx context.Context, g *datastore.Group) ([]datastore.EventBridgeRule, error) {
+	rules, err := e.ruleRepo.LoadEventBridgeRules(ctx, g.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to load event bridge rules")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching event bridge rules"))
+	}
+
+	return rules, nil
+}
+
+// DeleteEventBridgeRule deletes the rule with the given id, it has to
+// forward the events of g.
+func (e *EventBridgeService) DeleteEventBridgeRule(ctx context.Context, g *datastore.Group, id string) error {
+	rule, err := e.ruleRepo.FindEventBridgeRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventBridgeRuleNotFound) {
+			return NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to find event bridge rule")
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching event bridge rule"))
+	}
+
+	if rule.SourceGroupID != g.UID {
+		return NewServiceError(http.StatusNotFound, datastore.ErrEventBridgeRuleNotFound)
+	}
+
+	err = e.ruleRepo.DeleteEventBridgeRule(ctx, rule.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to delete event bridge rule")
+		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete event bridge rule"))
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideEventBridgeService(ctrl *gomock.Controller) *EventBridgeService {
+	ruleRepo := mocks.NewMockEventBridgeRepository(ctrl)
+	return NewEventBridgeService(ruleRepo)
+}
+
+func TestEventBridgeService_CreateEventBridgeRule(t *testing.T) {
+	source := &datastore.Group{UID: "group-1"}
+	dest := &datastore.Group{UID: "group-2"}
+
+	tests := []struct {
+		name        string
+		dest        *datastore.Group
+		newRule     *models.EventBridgeRule
+		dbFn        func(es *EventBridgeService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_create_event_bridge_rule",
+			dest: dest,
+			newRule: &models.EventBridgeRule{
+				DestGroupID:       "group-2",
+				EventTypeFilter:   []string{"payment.created"},
+				TransformTemplate: `{"amount": {{json .amount}}}`,
+			},
+			dbFn: func(es *EventBridgeService) {
+				r, _ := es.ruleRepo.(*mocks.MockEventBridgeRepository)
+				r.EXPECT().CreateEventBridgeRule(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:        "should_fail_for_the_source_group",
+			dest:        source,
+			newRule:     &models.EventBridgeRule{DestGroupID: "group-1", EventTypeFilter: []string{"*"}},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "events cannot be forwarded to the group they are sent in",
+		},
+		{
+			name:        "should_fail_without_event_types",
+			dest:        dest,
+			newRule:     &models.EventBridgeRule{DestGroupID: "group-2"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide the event types to forward",
+		},
+		{
+			name:        "should_fail_for_invalid_template",
+			dest:        dest,
+			newRule:     &models.EventBridgeRule{DestGroupID: "group-2", EventTypeFilter: []string{"*"}, TransformTemplate: "{{.amount"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  `invalid transform template: template: transform:1: unclosed action`,
+		},
+		{
+			name:    "should_fail_to_create_event_bridge_rule",
+			dest:    dest,
+			newRule: &models.EventBridgeRule{DestGroupID: "group-2", EventTypeFilter: []string{"*"}},
+			dbFn: func(es *EventBridgeService) {
+				r, _ := es.ruleRepo.(*mocks.MockEventBridgeRepository)
+				r.EXPECT().CreateEventBridgeRule(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to create event bridge rule",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventBridgeService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			rule, err := es.CreateEventBridgeRule(context.Background(), source, tc.dest, tc.newRule)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.NotEmpty(t, rule.UID)
+			require.Equal(t, "group-1", rule.SourceGroupID)
+			require.Equal(t, "group-2", rule.DestGroupID)
+			require.Equal(t, tc.newRule.EventTypeFilter, rule.EventTypeFilter)
+		})
+	}
+}
+
+func TestEventBridgeService_DeleteEventBridgeRule(t *testing.T) {
+	group := &datastore.Group{UID: "group-1"}
+
+	tests := []struct {
+		name        string
+		dbFn        func(es *EventBridgeService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_delete_event_bridge_rule",
+			dbFn: func(es *EventBridgeService) {
+				r, _ := es.ruleRepo.(*mocks.MockEventBridgeRepository)
+				r.EXPECT().FindEventBridgeRuleByID(gomock.Any(), "rule-1").Times(1).
+					Return(&datastore.EventBridgeRule{UID: "rule-1", SourceGroupID: "group-1"}, nil)
+				r.EXPECT().DeleteEventBridgeRule(gomock.Any(), "rule-1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_fail_for_rule_of_another_group",
+			dbFn: func(es *EventBridgeService) {
+				r, _ := es.ruleRepo.(*mocks.MockEventBridgeRepository)
+				r.EXPECT().FindEventBridgeRuleByID(gomock.Any(), "rule-1").Times(1).
+					Return(&datastore.EventBridgeRule{UID: "rule-1", SourceGroupID: "group-2"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "event bridge rule not found",
+		},
+		{
+			name: "should_fail_to_find_rule",
+			dbFn: func(es *EventBridgeService) {
+				r, _ := es.ruleRepo.(*mocks.MockEventBridgeRepository)
+				r.EXPECT().FindEventBridgeRuleByID(gomock.Any(), "rule-1").Times(1).
+					Return(nil, datastore.ErrEventBridgeRuleNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "event bridge rule not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventBridgeService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			err := es.DeleteEventBridgeRule(context.Background(), group, "rule-1")
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
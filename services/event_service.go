@@ -2,9 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy"
@@ -13,24 +16,41 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/tracer"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// minEventSearchQueryLength is the shortest payload search query accepted,
+// anything shorter would match most events in a group.
+const minEventSearchQueryLength = 3
+
+const (
+	maxEventTags      = 10
+	maxEventTagLength = 64
+)
+
+// MinStuckDeliveryMaxAge is the shortest time a delivery must have been
+// in processing before it can be force completed. A delivery is in
+// processing while it is being sent, for up to its endpoint's http
+// timeout, so it is well past the longest of those.
+const MinStuckDeliveryMaxAge = convoy.MAX_HTTP_TIMEOUT + 3*time.Minute
+
 type EventService struct {
 	appRepo           datastore.ApplicationRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventTypeRepo     datastore.EventTypeRepository
 	eventQueue        queue.Queuer
 	createEventQueue  queue.Queuer
 	cache             cache.Cache
 }
 
 func NewEventService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository,
-	eventQueue queue.Queuer, createEventQueue queue.Queuer, cache cache.Cache) *EventService {
-	return &EventService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, eventQueue: eventQueue, createEventQueue: createEventQueue, cache: cache}
+	eventTypeRepo datastore.EventTypeRepository, eventQueue queue.Queuer, createEventQueue queue.Queuer, cache cache.Cache) *EventService {
+	return &EventService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, eventTypeRepo: eventTypeRepo, eventQueue: eventQueue, createEventQueue: createEventQueue, cache: cache}
 }
 
 func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Event, g *datastore.Group) (*datastore.Event, error) {
@@ -38,10 +58,36 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while creating event - invalid group"))
 	}
 
-	if err := util.Validate(newMessage); err != nil {
+	if !g.IsActive() {
+		return nil, NewServiceErrorWithCode(GroupNotActiveErrorCode, fmt.Errorf("group is %s, events can only be sent to active groups", g.Status))
+	}
+
+	if err := Validate(newMessage); err != nil {
+		return nil, err
+	}
+
+	if err := validateEventMetadata(newMessage.Metadata); err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateEventTags(newMessage.Tags); err != nil {
 		return nil, NewServiceError(http.StatusBadRequest, err)
 	}
 
+	if newMessage.EventVersion == "" {
+		newMessage.EventVersion = datastore.DefaultEventVersion
+	}
+
+	if util.IsStringEmpty(newMessage.EventVersion) {
+		return nil, NewServiceErrorWithCode(InvalidEventVersionErrorCode, errors.New("event version cannot be blank"))
+	}
+
+	if g.Config != nil && g.Config.EnforceEventTypes {
+		if err := e.enforceEventType(ctx, g, newMessage); err != nil {
+			return nil, err
+		}
+	}
+
 	var app *datastore.Application
 	appCacheKey := convoy.ApplicationsCacheKey.Get(newMessage.AppID).String()
 
@@ -81,11 +127,14 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 	}
 
 	event := &datastore.Event{
-		UID:       uuid.New().String(),
-		EventType: datastore.EventType(newMessage.EventType),
-		Data:      newMessage.Data,
-		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
-		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+		UID:          uuid.New().String(),
+		EventType:    datastore.EventType(newMessage.EventType),
+		EventVersion: newMessage.EventVersion,
+		Metadata:     newMessage.Metadata,
+		Tags:         newMessage.Tags,
+		Data:         newMessage.Data,
+		CreatedAt:    primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:    primitive.NewDateTimeFromTime(time.Now()),
 		AppMetadata: &datastore.AppMetadata{
 			Title:        app.Title,
 			UID:          app.UID,
@@ -95,10 +144,33 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 		DocumentStatus: datastore.ActiveDocumentStatus,
 	}
 
-	if g.Config.Strategy.Type != config.DefaultStrategyProvider && g.Config.Strategy.Type != config.ExponentialBackoffStrategyProvider {
+	if tc, ok := tracer.TraceContextFromContext(ctx); ok {
+		event.TraceParent = tc.TraceParent
+		event.TraceState = tc.TraceState
+	}
+
+	switch g.Config.Strategy.Type {
+	case config.DefaultStrategyProvider, config.ExponentialBackoffStrategyProvider, config.LinearStrategyProvider:
+	default:
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("retry strategy not defined in configuration"))
 	}
 
+	if !util.IsStringEmpty(newMessage.IdempotencyKey) {
+		original := e.idempotentEvent(ctx, event, g, newMessage.IdempotencyKey)
+		if original != nil {
+			original.Duplicate = true
+			return original, nil
+		}
+	}
+
+	if !util.IsStringEmpty(g.Config.DeduplicationWindow) {
+		original := e.deduplicateEvent(ctx, event, g.Config.DeduplicationWindow)
+		if original != nil {
+			original.Duplicate = true
+			return original, nil
+		}
+	}
+
 	taskName := convoy.CreateEventProcessor.SetPrefix(g.Name)
 	err = e.createEventQueue.WriteEvent(context.Background(), taskName, event, 1*time.Second)
 	if err != nil {
@@ -108,31 +180,116 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 	return event, nil
 }
 
+// fingerprintedEvent is what is cached under an event's fingerprint or
+// idempotency key. The in-memory cache keeps entries past their TTL, so
+// the expiry is kept alongside.
+type fingerprintedEvent struct {
+	Event     *datastore.Event
+	ExpiresAt time.Time
+}
+
+// deduplicateEvent returns the event an identical one to event was sent
+// as within window. When there is none, event is remembered for window
+// under its fingerprint so that later identical events resolve to it.
+// Failing to reach the cache lets the event through.
+func (e *EventService) deduplicateEvent(ctx context.Context, event *datastore.Event, window string) *datastore.Event {
+	ttl, err := time.ParseDuration(window)
+	if err != nil {
+		log.WithError(err).Error("failed to parse deduplication window")
+		return nil
+	}
+
+	fingerprintCacheKey := convoy.EventFingerprintsCacheKey.Get(fingerprintEvent(event)).String()
+	return e.rememberEvent(ctx, fingerprintCacheKey, event, ttl)
+}
+
+// idempotentEvent returns the event first sent with key to g within its
+// idempotency window, remembering event under key when there is none.
+// Failing to reach the cache lets the event through.
+func (e *EventService) idempotentEvent(ctx context.Context, event *datastore.Event, g *datastore.Group, key string) *datastore.Event {
+	window := g.Config.IdempotencyWindowDuration
+	if util.IsStringEmpty(window) {
+		window = datastore.DefaultIdempotencyWindowDuration
+	}
+
+	ttl, err := time.ParseDuration(window)
+	if err != nil {
+		log.WithError(err).Error("failed to parse idempotency window duration")
+		return nil
+	}
+
+	idempotencyCacheKey := convoy.EventIdempotencyCacheKey.Get(g.UID).Get(key).String()
+	return e.rememberEvent(ctx, idempotencyCacheKey, event, ttl)
+}
+
+// rememberEvent returns the event cached under cacheKey if it hasn't
+// expired, otherwise it caches event there for ttl.
+func (e *EventService) rememberEvent(ctx context.Context, cacheKey string, event *datastore.Event, ttl time.Duration) *datastore.Event {
+	var original *fingerprintedEvent
+	err := e.cache.Get(ctx, cacheKey, &original)
+	if err != nil {
+		log.WithError(err).Error("failed to look up remembered event")
+		return nil
+	}
+
+	if original != nil && original.Event != nil && time.Now().Before(original.ExpiresAt) {
+		return original.Event
+	}
+
+	err = e.cache.Set(ctx, cacheKey, &fingerprintedEvent{Event: event, ExpiresAt: time.Now().Add(ttl)}, ttl)
+	if err != nil {
+		log.WithError(err).Error("failed to remember event")
+	}
+
+	return nil
+}
+
+// fingerprintEvent identifies an event by its app, type and payload.
+func fingerprintEvent(event *datastore.Event) string {
+	h := sha256.New()
+	h.Write([]byte(event.AppMetadata.UID))
+	h.Write([]byte{0})
+	h.Write([]byte(event.EventType))
+	h.Write([]byte{0})
+	h.Write(event.Data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (e *EventService) GetAppEvent(ctx context.Context, id string) (*datastore.Event, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	event, err := e.eventRepo.FindEventByID(ctx, id)
 	if err != nil {
 		log.WithError(err).Error("failed to find event by id")
-		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to find event by id"))
+		return nil, datastoreError(err, http.StatusBadRequest, "failed to find event by id")
 	}
 
 	return event, nil
 }
 
 func (e *EventService) GetEventDelivery(ctx context.Context, id string) (*datastore.EventDelivery, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	eventDelivery, err := e.eventDeliveryRepo.FindEventDeliveryByID(ctx, id)
 	if err != nil {
 		log.WithError(err).Error("failed to find event delivery by id")
-		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to find event delivery by id"))
+		return nil, datastoreError(err, http.StatusBadRequest, "failed to find event delivery by id")
 	}
 
 	return eventDelivery, nil
 }
 
 func (e *EventService) BatchRetryEventDelivery(ctx context.Context, filter *datastore.Filter) (int, int, error) {
-	deliveries, _, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.Status, filter.SearchParams, filter.Pageable)
+	ctx, cancel := withBulkWriteTimeout(ctx)
+	defer cancel()
+
+	deliveries, _, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams, filter.Pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch event deliveries by ids")
-		return 0, 0, NewServiceError(http.StatusInternalServerError, errors.New("failed to fetch event deliveries"))
+		return 0, 0, datastoreError(err, http.StatusInternalServerError, "failed to fetch event deliveries")
 	}
 
 	failures := 0
@@ -148,22 +305,67 @@ func (e *EventService) BatchRetryEventDelivery(ctx context.Context, filter *data
 	return successes, failures, nil
 }
 
+// RetryAllFailed schedules every failed delivery in g to be sent again,
+// it returns the number of deliveries scheduled.
+func (e *EventService) RetryAllFailed(ctx context.Context, g *datastore.Group) (int64, error) {
+	ctx, cancel := withBulkWriteTimeout(ctx)
+	defer cancel()
+
+	count, err := e.eventDeliveryRepo.RetryAllFailed(ctx, g.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to retry failed event deliveries")
+		return 0, datastoreError(err, http.StatusBadRequest, "failed to retry failed event deliveries")
+	}
+
+	return count, nil
+}
+
+// ForceCompleteStuckDeliveries fails the deliveries of every group that
+// have been in processing for longer than maxAge, it returns the number
+// of deliveries failed.
+func (e *EventService) ForceCompleteStuckDeliveries(ctx context.Context, maxAge time.Duration) (int64, error) {
+	if maxAge < MinStuckDeliveryMaxAge {
+		return 0, NewServiceError(http.StatusBadRequest, fmt.Errorf("max age must be at least %s", MinStuckDeliveryMaxAge))
+	}
+
+	ctx, cancel := withBulkWriteTimeout(ctx)
+	defer cancel()
+
+	count, err := e.eventDeliveryRepo.ForceCompleteStuckDeliveries(ctx, maxAge)
+	if err != nil {
+		log.WithError(err).Error("failed to force complete stuck event deliveries")
+		return 0, datastoreError(err, http.StatusBadRequest, "failed to force complete stuck event deliveries")
+	}
+
+	if count > 0 {
+		log.Warnf("failed %d event deliveries stuck in processing for over %s", count, maxAge)
+	}
+
+	return count, nil
+}
+
 func (e *EventService) CountAffectedEventDeliveries(ctx context.Context, filter *datastore.Filter) (int64, error) {
-	count, err := e.eventDeliveryRepo.CountEventDeliveries(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.Status, filter.SearchParams)
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	count, err := e.eventDeliveryRepo.CountEventDeliveries(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams)
 	if err != nil {
 		log.WithError(err).Error("an error occurred while fetching event deliveries")
-		return 0, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching event deliveries"))
+		return 0, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching event deliveries")
 	}
 
 	return count, nil
 }
 
 func (e *EventService) ForceResendEventDeliveries(ctx context.Context, ids []string, g *datastore.Group) (int, int, error) {
+	ctx, cancel := withBulkWriteTimeout(ctx)
+	defer cancel()
+
 	var deliveries []datastore.EventDelivery
 	deliveries, err := e.eventDeliveryRepo.FindEventDeliveriesByIDs(ctx, ids)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch event deliveries by ids")
-		return 0, 0, NewServiceError(http.StatusInternalServerError, errors.New("failed to fetch event deliveries"))
+		return 0, 0, datastoreError(err, http.StatusInternalServerError, "failed to fetch event deliveries")
 	}
 
 	failures := 0
@@ -179,26 +381,197 @@ func (e *EventService) ForceResendEventDeliveries(ctx context.Context, ids []str
 	return successes, failures, nil
 }
 
+func (e *EventService) CountEvents(ctx context.Context, filter *datastore.Filter) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	count, err := e.eventRepo.CountEvents(ctx, filter.Group.UID, filter.AppID, filter.SearchParams)
+	if err != nil {
+		log.WithError(err).Error("failed to count events")
+		return 0, datastoreError(err, http.StatusInternalServerError, "an error occurred while counting events")
+	}
+
+	return count, nil
+}
+
 func (e *EventService) GetEventsPaged(ctx context.Context, filter *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	if !util.IsStringEmpty(filter.Query) || len(filter.Metadata) > 0 {
+		return e.searchEventsPaged(ctx, filter)
+	}
+
 	m, paginationData, err := e.eventRepo.LoadEventsPaged(ctx, filter.Group.UID, filter.AppID, filter.SearchParams, filter.Pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch events")
-		return nil, datastore.PaginationData{}, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching events"))
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching events")
+	}
+
+	return m, paginationData, nil
+}
+
+// GetEventsCursored loads a page of the events matching filter from the
+// position of filter.Cursor, or the first page without one.
+func (e *EventService) GetEventsCursored(ctx context.Context, filter *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+	if !util.IsStringEmpty(filter.Query) || len(filter.Metadata) > 0 {
+		return nil, datastore.PaginationData{}, NewServiceError(http.StatusBadRequest, errors.New("cursor pagination cannot be combined with a search"))
+	}
+
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	perPage := filter.Pageable.PerPage
+
+	// an extra event is loaded to tell whether there is a page beyond
+	// this one in the direction of the cursor
+	events, err := e.eventRepo.LoadEventsCursored(ctx, filter.Group.UID, filter.AppID, "", nil, filter.SearchParams, filter.Cursor, perPage+1)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch events")
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching events")
+	}
+
+	lo, hi, prevCursor, nextCursor := cursorPage(len(events), perPage, filter.Cursor, func(i int) datastore.Cursor {
+		return datastore.Cursor{CreatedAt: events[i].CreatedAt, UID: events[i].UID}
+	})
+
+	return events[lo:hi], datastore.PaginationData{PerPage: int64(perPage), PrevCursor: prevCursor, NextCursor: nextCursor}, nil
+}
+
+func (e *EventService) searchEventsPaged(ctx context.Context, filter *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+	query, err := validateEventSearch(filter)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	m, paginationData, err := e.eventRepo.SearchEventsPaged(ctx, filter.Group.UID, filter.AppID, query, filter.Metadata, filter.SearchParams, filter.Pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to search events")
+		if errors.Is(err, datastore.ErrEventSearchTimeout) {
+			return nil, datastore.PaginationData{}, NewServiceError(http.StatusRequestTimeout, errors.New("event search took too long, narrow the date range or filter by application"))
+		}
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while searching events")
 	}
 
 	return m, paginationData, nil
 }
 
+// validateEventSearch checks the query and metadata of filter, it
+// returns the query trimmed of surrounding spaces.
+func validateEventSearch(filter *datastore.Filter) (string, error) {
+	query := strings.TrimSpace(filter.Query)
+	if !util.IsStringEmpty(filter.Query) && len(query) < minEventSearchQueryLength {
+		return "", NewServiceError(http.StatusBadRequest, fmt.Errorf("search query is too broad, provide at least %d characters", minEventSearchQueryLength))
+	}
+
+	if err := validateEventMetadata(filter.Metadata); err != nil {
+		return "", NewServiceError(http.StatusBadRequest, err)
+	}
+
+	return query, nil
+}
+
 func (e *EventService) GetEventDeliveriesPaged(ctx context.Context, filter *datastore.Filter) ([]datastore.EventDelivery, datastore.PaginationData, error) {
-	ed, paginationData, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.Status, filter.SearchParams, filter.Pageable)
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	ed, paginationData, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams, filter.Pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch event deliveries")
-		return nil, datastore.PaginationData{}, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching event deliveries"))
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching event deliveries")
 	}
 
 	return ed, paginationData, nil
 }
 
+// GetEventDeliveriesCursored loads a page of the event deliveries matching
+// filter from the position of filter.Cursor, or the first page without one.
+func (e *EventService) GetEventDeliveriesCursored(ctx context.Context, filter *datastore.Filter) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	perPage := filter.Pageable.PerPage
+
+	deliveries, err := e.eventDeliveryRepo.LoadEventDeliveriesCursored(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams, filter.Cursor, perPage+1)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch event deliveries")
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching event deliveries")
+	}
+
+	lo, hi, prevCursor, nextCursor := cursorPage(len(deliveries), perPage, filter.Cursor, func(i int) datastore.Cursor {
+		return datastore.Cursor{CreatedAt: deliveries[i].CreatedAt, UID: deliveries[i].UID}
+	})
+
+	return deliveries[lo:hi], datastore.PaginationData{PerPage: int64(perPage), PrevCursor: prevCursor, NextCursor: nextCursor}, nil
+}
+
+// GetEventDeliveryTree loads a page of event's deliveries, each with the
+// current status of its endpoint. Delivery attempts are inlined when
+// expandAttempts is set, with response bodies cut to maxResponseSize bytes.
+func (e *EventService) GetEventDeliveryTree(ctx context.Context, event *datastore.Event, g *datastore.Group, pageable datastore.Pageable, expandAttempts bool, maxResponseSize int) (*models.EventDeliveryTree, error) {
+	searchParams := datastore.SearchParams{CreatedAtStart: event.CreatedAt.Time().Unix(), CreatedAtEnd: time.Now().Unix()}
+
+	deliveries, paginationData, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, g.UID, "", event.UID, "", nil, searchParams, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch event deliveries")
+		return nil, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching event deliveries"))
+	}
+
+	endpointStatus := make(map[string]datastore.EndpointStatus)
+	apps := make(map[string]bool)
+
+	tree := &models.EventDeliveryTree{
+		Event:      event,
+		Deliveries: make([]models.ExpandedEventDelivery, 0, len(deliveries)),
+		Pagination: paginationData,
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.AppMetadata != nil && !apps[delivery.AppMetadata.UID] {
+			apps[delivery.AppMetadata.UID] = true
+			e.loadEndpointStatus(ctx, delivery.AppMetadata.UID, endpointStatus)
+		}
+
+		expanded := models.ExpandedEventDelivery{EventDelivery: delivery}
+
+		if delivery.EndpointMetadata != nil {
+			// fall back to the status the endpoint had when the delivery
+			// was created if the app can no longer be found
+			status, ok := endpointStatus[delivery.EndpointMetadata.UID]
+			if !ok {
+				status = delivery.EndpointMetadata.Status
+			}
+			expanded.EndpointStatus = status
+		}
+
+		if expandAttempts {
+			expanded.DeliveryAttempts = make([]datastore.DeliveryAttempt, 0, len(delivery.DeliveryAttempts))
+			for _, attempt := range delivery.DeliveryAttempts {
+				if maxResponseSize > 0 && len(attempt.ResponseData) > maxResponseSize {
+					attempt.ResponseData = attempt.ResponseData[:maxResponseSize]
+				}
+				expanded.DeliveryAttempts = append(expanded.DeliveryAttempts, attempt)
+			}
+		}
+
+		tree.Deliveries = append(tree.Deliveries, expanded)
+	}
+
+	return tree, nil
+}
+
+func (e *EventService) loadEndpointStatus(ctx context.Context, appID string, endpointStatus map[string]datastore.EndpointStatus) {
+	app, err := e.appRepo.FindApplicationByID(ctx, appID)
+	if err != nil {
+		log.WithError(err).Errorf("failed to find application %s", appID)
+		return
+	}
+
+	for _, endpoint := range app.Endpoints {
+		endpointStatus[endpoint.UID] = endpoint.Status
+	}
+}
+
 func (e *EventService) ResendEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery, g *datastore.Group) error {
 	err := e.RetryEventDelivery(ctx, eventDelivery, g)
 	if err != nil {
@@ -215,12 +588,20 @@ func (e *EventService) RetryEventDelivery(ctx context.Context, eventDelivery *da
 		return errors.New("event already sent")
 	case datastore.ScheduledEventStatus,
 		datastore.ProcessingEventStatus,
-		datastore.RetryEventStatus:
+		datastore.RetryEventStatus,
+		datastore.PostponedEventStatus:
 		return errors.New("cannot resend event that did not fail previously")
+	case datastore.HeldEventStatus:
+		return errors.New("cannot resend event held for a paused app")
+	}
+
+	// meta events go to the group's meta event url, not to an endpoint
+	if eventDelivery.IsMetaEvent {
+		return e.requeueEventDelivery(ctx, eventDelivery, g)
 	}
 
 	em := eventDelivery.EndpointMetadata
-	endpoint, err := e.appRepo.FindApplicationEndpointByID(context.Background(), eventDelivery.AppMetadata.UID, em.UID)
+	endpoint, err := e.appRepo.FindApplicationEndpointByID(ctx, eventDelivery.AppMetadata.UID, em.UID)
 	if err != nil {
 		log.WithError(err).Error("failed to find endpoint")
 		return errors.New("cannot find endpoint")
@@ -233,7 +614,7 @@ func (e *EventService) RetryEventDelivery(ctx context.Context, eventDelivery *da
 	if endpoint.Status == datastore.InactiveEndpointStatus {
 		pendingEndpoints := []string{em.UID}
 
-		err = e.appRepo.UpdateApplicationEndpointsStatus(context.Background(), eventDelivery.AppMetadata.UID, pendingEndpoints, datastore.PendingEndpointStatus)
+		err = e.appRepo.UpdateApplicationEndpointsStatus(ctx, eventDelivery.AppMetadata.UID, pendingEndpoints, datastore.PendingEndpointStatus)
 		if err != nil {
 			return errors.New("failed to update endpoint status")
 		}
@@ -247,8 +628,12 @@ func (e *EventService) forceResendEventDelivery(ctx context.Context, eventDelive
 		return errors.New("only successful events can be force resent")
 	}
 
+	if eventDelivery.IsMetaEvent {
+		return e.requeueEventDelivery(ctx, eventDelivery, g)
+	}
+
 	em := eventDelivery.EndpointMetadata
-	endpoint, err := e.appRepo.FindApplicationEndpointByID(context.Background(), eventDelivery.AppMetadata.UID, em.UID)
+	endpoint, err := e.appRepo.FindApplicationEndpointByID(ctx, eventDelivery.AppMetadata.UID, em.UID)
 	if err != nil {
 		return errors.New("cannot find endpoint")
 	}
@@ -274,3 +659,60 @@ func (e *EventService) requeueEventDelivery(ctx context.Context, eventDelivery *
 	}
 	return nil
 }
+
+// validateEventMetadata rejects metadata keys that cannot be stored or
+// queried as field names.
+func validateEventMetadata(metadata map[string]string) error {
+	for k := range metadata {
+		if util.IsStringEmpty(k) || strings.Contains(k, ".") || strings.HasPrefix(k, "$") {
+			return fmt.Errorf("invalid metadata key %q, keys cannot be empty, contain '.' or start with '$'", k)
+		}
+	}
+
+	return nil
+}
+
+// enforceEventType rejects newMessage unless its type is registered for g
+// and its data matches the type's schema.
+func (e *EventService) enforceEventType(ctx context.Context, g *datastore.Group, newMessage *models.Event) error {
+	eventTypes, err := loadGroupEventTypes(ctx, e.eventTypeRepo, e.cache, g.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to load event types")
+		return NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching event types"))
+	}
+
+	for i := range eventTypes {
+		if eventTypes[i].Name != newMessage.EventType {
+			continue
+		}
+
+		err = validateEventData(ctx, &eventTypes[i], newMessage.Data)
+		if err != nil {
+			return NewServiceError(http.StatusUnprocessableEntity, err)
+		}
+
+		return nil
+	}
+
+	return NewServiceError(http.StatusUnprocessableEntity, fmt.Errorf("event type %s is not registered for the group", newMessage.EventType))
+}
+
+// validateEventTags keeps tags few, short and lowercase so they stay cheap
+// to index and match exactly.
+func validateEventTags(tags []string) error {
+	if len(tags) > maxEventTags {
+		return fmt.Errorf("an event can have at most %d tags", maxEventTags)
+	}
+
+	for _, tag := range tags {
+		if util.IsStringEmpty(tag) || len(tag) > maxEventTagLength {
+			return fmt.Errorf("invalid tag %q, tags must be between 1 and %d characters", tag, maxEventTagLength)
+		}
+
+		if tag != strings.ToLower(tag) {
+			return fmt.Errorf("invalid tag %q, tags must be lowercase", tag)
+		}
+	}
+
+	return nil
+}
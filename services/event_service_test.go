@@ -5,9 +5,13 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy"
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
@@ -19,10 +23,11 @@ func provideEventService(ctrl *gomock.Controller) *EventService {
 	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	eventRepo := mocks.NewMockEventRepository(ctrl)
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	eventTypeRepo := mocks.NewMockEventTypeRepository(ctrl)
 	eventQueue := mocks.NewMockQueuer(ctrl)
 	creatEventQueue := mocks.NewMockQueuer(ctrl)
 	cache := mocks.NewMockCache(ctrl)
-	return NewEventService(appRepo, eventRepo, eventDeliveryRepo, eventQueue, creatEventQueue, cache)
+	return NewEventService(appRepo, eventRepo, eventDeliveryRepo, eventTypeRepo, eventQueue, creatEventQueue, cache)
 }
 
 func TestEventService_CreateAppEvent(t *testing.T) {
@@ -79,6 +84,8 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 					AppID:     "123",
 					EventType: "payment.created",
 					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					Metadata:  map[string]string{"customer_id": "cus_123"},
+					Tags:      []string{"replay", "backfill-2024"},
 				},
 				g: &datastore.Group{
 					UID:  "abc",
@@ -100,7 +107,10 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 			},
 			wantEvent: &datastore.Event{
 				EventType:        datastore.EventType("payment.created"),
+				EventVersion:     datastore.DefaultEventVersion,
 				MatchedEndpoints: 0,
+				Metadata:         map[string]string{"customer_id": "cus_123"},
+				Tags:             []string{"replay", "backfill-2024"},
 				Data:             bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
 				AppMetadata: &datastore.AppMetadata{
 					Title:        "test_app",
@@ -165,6 +175,7 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 			},
 			wantEvent: &datastore.Event{
 				EventType:        datastore.EventType("payment.created"),
+				EventVersion:     datastore.DefaultEventVersion,
 				MatchedEndpoints: 0,
 				Data:             bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
 				AppMetadata: &datastore.AppMetadata{
@@ -236,6 +247,69 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "app_id:please provide an app id",
 		},
+		{
+			name: "should_error_for_too_many_tags",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:     "123",
+					EventType: "payment.created",
+					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					Tags:      []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+				},
+				g: &datastore.Group{},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "an event can have at most 10 tags",
+		},
+		{
+			name: "should_error_for_blank_event_version",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:        "123",
+					EventType:    "payment.created",
+					EventVersion: "  ",
+					Data:         bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				},
+				g: &datastore.Group{},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "event version cannot be blank",
+		},
+		{
+			name: "should_error_for_inactive_group",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:     "123",
+					EventType: "payment.created",
+					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				},
+				g: &datastore.Group{UID: "abc", Status: datastore.ArchivedGroupStatus},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "group is archived, events can only be sent to active groups",
+		},
+		{
+			name: "should_error_for_uppercase_tag",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:     "123",
+					EventType: "payment.created",
+					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					Tags:      []string{"Replay"},
+				},
+				g: &datastore.Group{},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid tag \"Replay\", tags must be lowercase",
+		},
 		{
 			name: "should_error_for_application_not_found",
 			dbFn: func(es *EventService) {
@@ -386,6 +460,189 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_CreateAppEvent_DeduplicatesWithinWindow(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideEventService(ctrl)
+	es.cache = mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationByID(gomock.Any(), "123").Times(1).Return(&datastore.Application{
+		Title:     "test_app",
+		UID:       "123",
+		GroupID:   "abc",
+		Endpoints: []datastore.Endpoint{{UID: "ref", Events: []string{"*"}, Status: datastore.ActiveEndpointStatus}},
+	}, nil)
+
+	// only the first event and the one with a different payload are queued
+	eq, _ := es.createEventQueue.(*mocks.MockQueuer)
+	eq.EXPECT().WriteEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+
+	g := &datastore.Group{
+		UID:  "abc",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			DeduplicationWindow: "100ms",
+		},
+	}
+
+	newEvent := func(data string) *models.Event {
+		return &models.Event{AppID: "123", EventType: "payment.created", Data: []byte(data)}
+	}
+
+	original, err := es.CreateAppEvent(ctx, newEvent(`{"amount":100}`), g)
+	require.NoError(t, err)
+	require.False(t, original.Duplicate)
+
+	duplicate, err := es.CreateAppEvent(ctx, newEvent(`{"amount":100}`), g)
+	require.NoError(t, err)
+	require.True(t, duplicate.Duplicate)
+	require.Equal(t, original.UID, duplicate.UID)
+
+	other, err := es.CreateAppEvent(ctx, newEvent(`{"amount":200}`), g)
+	require.NoError(t, err)
+	require.False(t, other.Duplicate)
+	require.NotEqual(t, original.UID, other.UID)
+
+	// once the window has passed the same payload is a new event again
+	time.Sleep(150 * time.Millisecond)
+
+	later, err := es.CreateAppEvent(ctx, newEvent(`{"amount":100}`), g)
+	require.NoError(t, err)
+	require.False(t, later.Duplicate)
+	require.NotEqual(t, original.UID, later.UID)
+}
+
+func TestEventService_IdempotencyWindowExpires(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideEventService(ctrl)
+	es.cache = mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationByID(gomock.Any(), "123").Times(1).Return(&datastore.Application{
+		Title:     "test_app",
+		UID:       "123",
+		GroupID:   "abc",
+		Endpoints: []datastore.Endpoint{{UID: "ref", Events: []string{"*"}, Status: datastore.ActiveEndpointStatus}},
+	}, nil)
+
+	// the event sent again within the window is not queued
+	eq, _ := es.createEventQueue.(*mocks.MockQueuer)
+	eq.EXPECT().WriteEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+
+	g := &datastore.Group{
+		UID:  "abc",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			IdempotencyWindowDuration: "100ms",
+		},
+	}
+
+	newEvent := func(key string) *models.Event {
+		return &models.Event{AppID: "123", EventType: "payment.created", Data: []byte(`{"amount":100}`), IdempotencyKey: key}
+	}
+
+	original, err := es.CreateAppEvent(ctx, newEvent("payment-1"), g)
+	require.NoError(t, err)
+	require.False(t, original.Duplicate)
+
+	resent, err := es.CreateAppEvent(ctx, newEvent("payment-1"), g)
+	require.NoError(t, err)
+	require.True(t, resent.Duplicate)
+	require.Equal(t, original.UID, resent.UID)
+
+	other, err := es.CreateAppEvent(ctx, newEvent("payment-2"), g)
+	require.NoError(t, err)
+	require.False(t, other.Duplicate)
+	require.NotEqual(t, original.UID, other.UID)
+
+	// once the window has passed the same key creates a new event
+	time.Sleep(150 * time.Millisecond)
+
+	later, err := es.CreateAppEvent(ctx, newEvent("payment-1"), g)
+	require.NoError(t, err)
+	require.False(t, later.Duplicate)
+	require.NotEqual(t, original.UID, later.UID)
+}
+
+func TestEventService_CreateAppEvent_EnforcesEventTypes(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideEventService(ctrl)
+	es.cache = mcache.NewMemoryCache(config.MemoryCacheConfiguration{})
+
+	// the registry is cached after the first event
+	et, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+	et.EXPECT().LoadEventTypes(gomock.Any(), "abc").Times(1).Return([]datastore.EventTypeDefinition{
+		{
+			Name:   "payment.created",
+			Schema: []byte(`{"type":"object","required":["amount"],"properties":{"amount":{"type":"number"}}}`),
+		},
+		{Name: "payment.failed"},
+	}, nil)
+
+	a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationByID(gomock.Any(), "123").Times(1).Return(&datastore.Application{
+		Title:     "test_app",
+		UID:       "123",
+		GroupID:   "abc",
+		Endpoints: []datastore.Endpoint{{UID: "ref", Events: []string{"*"}, Status: datastore.ActiveEndpointStatus}},
+	}, nil)
+
+	eq, _ := es.createEventQueue.(*mocks.MockQueuer)
+	eq.EXPECT().WriteEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	g := &datastore.Group{
+		UID:  "abc",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			EnforceEventTypes: true,
+		},
+	}
+
+	newEvent := func(eventType, data string) *models.Event {
+		return &models.Event{AppID: "123", EventType: eventType, Data: []byte(data)}
+	}
+
+	_, err := es.CreateAppEvent(ctx, newEvent("payment.created", `{"amount":100}`), g)
+	require.NoError(t, err)
+
+	_, err = es.CreateAppEvent(ctx, newEvent("payment.failed", `{"reason":"declined"}`), g)
+	require.NoError(t, err)
+
+	_, err = es.CreateAppEvent(ctx, newEvent("payment.created", `{"amount":"100"}`), g)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*ServiceError).ErrCode())
+	require.Equal(t, `event data does not match the schema of payment.created: amount: Field must be set to number or not be present`, err.Error())
+
+	_, err = es.CreateAppEvent(ctx, newEvent("payment.refunded", `{"amount":100}`), g)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*ServiceError).ErrCode())
+	require.Equal(t, "event type payment.refunded is not registered for the group", err.Error())
+}
+
 func TestEventService_GetAppEvent(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -566,6 +823,7 @@ func TestEventService_BatchRetryEventDelivery(t *testing.T) {
 					"123",
 					"abc",
 					"13429",
+					"",
 					[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus, datastore.RetryEventStatus},
 					datastore.SearchParams{
 						CreatedAtStart: 1342,
@@ -640,6 +898,7 @@ func TestEventService_BatchRetryEventDelivery(t *testing.T) {
 					"123",
 					"abc",
 					"13429",
+					"",
 					[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus, datastore.RetryEventStatus},
 					datastore.SearchParams{
 						CreatedAtStart: 1342,
@@ -753,6 +1012,7 @@ func TestEventService_CountAffectedEventDeliveries(t *testing.T) {
 					"123",
 					"abc",
 					"ref",
+					"",
 					[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus, datastore.ScheduledEventStatus},
 					datastore.SearchParams{
 						CreatedAtStart: 13323,
@@ -783,6 +1043,7 @@ func TestEventService_CountAffectedEventDeliveries(t *testing.T) {
 					"123",
 					"abc",
 					"ref",
+					"",
 					[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus, datastore.ScheduledEventStatus},
 					datastore.SearchParams{
 						CreatedAtStart: 13323,
@@ -818,6 +1079,127 @@ func TestEventService_CountAffectedEventDeliveries(t *testing.T) {
 	}
 }
 
+func TestEventService_RetryAllFailed(t *testing.T) {
+	tests := []struct {
+		name        string
+		dbFn        func(es *EventService)
+		wantCount   int64
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_retry_all_failed_event_deliveries",
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().RetryAllFailed(gomock.Any(), "123").Times(1).Return(int64(12), nil)
+			},
+			wantCount: 12,
+		},
+		{
+			name: "should_fail_to_retry_all_failed_event_deliveries",
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().RetryAllFailed(gomock.Any(), "123").Times(1).Return(int64(0), errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to retry failed event deliveries",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			count, err := es.RetryAllFailed(context.Background(), &datastore.Group{UID: "123"})
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
+func TestEventService_ForceCompleteStuckDeliveries(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAge      time.Duration
+		dbFn        func(es *EventService)
+		wantCount   int64
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:   "should_force_complete_stuck_event_deliveries",
+			maxAge: 30 * time.Minute,
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().ForceCompleteStuckDeliveries(gomock.Any(), 30*time.Minute).Times(1).Return(int64(3), nil)
+			},
+			wantCount: 3,
+		},
+		{
+			name:        "should_reject_short_max_age",
+			maxAge:      30 * time.Second,
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "max age must be at least 5m0s",
+		},
+		{
+			name:        "should_reject_max_age_a_delivery_can_still_be_sending_for",
+			maxAge:      convoy.MAX_HTTP_TIMEOUT,
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "max age must be at least 5m0s",
+		},
+		{
+			name:   "should_fail_to_force_complete_stuck_event_deliveries",
+			maxAge: time.Hour,
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().ForceCompleteStuckDeliveries(gomock.Any(), time.Hour).Times(1).Return(int64(0), errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to force complete stuck event deliveries",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			count, err := es.ForceCompleteStuckDeliveries(context.Background(), tc.maxAge)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
 func TestEventService_ForceResendEventDeliveries(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -1053,6 +1435,104 @@ func TestEventService_GetEventsPaged(t *testing.T) {
 			wantErrCode: http.StatusInternalServerError,
 			wantErrMsg:  "an error occurred while fetching events",
 		},
+		{
+			name: "should_search_events_paged",
+			args: args{
+				ctx: ctx,
+				filter: &datastore.Filter{
+					Group: &datastore.Group{UID: "123"},
+					AppID: "abc",
+					Query: " 48211 ",
+					Pageable: datastore.Pageable{
+						Page:    1,
+						PerPage: 1,
+						Sort:    1,
+					},
+				},
+			},
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventRepo.(*mocks.MockEventRepository)
+				ed.EXPECT().
+					SearchEventsPaged(gomock.Any(), "123", "abc", "48211", gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]datastore.Event{{UID: "1234"}}, datastore.PaginationData{Total: 1, Page: 1, PerPage: 1, TotalPage: 1}, nil)
+			},
+			wantEvents: []datastore.Event{
+				{UID: "1234"},
+			},
+			wantPaginationData: datastore.PaginationData{Total: 1, Page: 1, PerPage: 1, TotalPage: 1},
+		},
+		{
+			name: "should_filter_events_by_metadata",
+			args: args{
+				ctx: ctx,
+				filter: &datastore.Filter{
+					Group:    &datastore.Group{UID: "123"},
+					Metadata: map[string]string{"customer_id": "cus_123"},
+					Pageable: datastore.Pageable{
+						Page:    1,
+						PerPage: 1,
+						Sort:    1,
+					},
+				},
+			},
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventRepo.(*mocks.MockEventRepository)
+				ed.EXPECT().
+					SearchEventsPaged(gomock.Any(), "123", "", "", map[string]string{"customer_id": "cus_123"}, gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]datastore.Event{{UID: "1234"}}, datastore.PaginationData{Total: 1, Page: 1, PerPage: 1, TotalPage: 1}, nil)
+			},
+			wantEvents: []datastore.Event{
+				{UID: "1234"},
+			},
+			wantPaginationData: datastore.PaginationData{Total: 1, Page: 1, PerPage: 1, TotalPage: 1},
+		},
+		{
+			name: "should_reject_invalid_metadata_key",
+			args: args{
+				ctx: ctx,
+				filter: &datastore.Filter{
+					Group:    &datastore.Group{UID: "123"},
+					Metadata: map[string]string{"$where": "1"},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  `invalid metadata key "$where", keys cannot be empty, contain '.' or start with '$'`,
+		},
+		{
+			name: "should_reject_broad_search_query",
+			args: args{
+				ctx: ctx,
+				filter: &datastore.Filter{
+					Group: &datastore.Group{UID: "123"},
+					Query: "ab",
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "search query is too broad, provide at least 3 characters",
+		},
+		{
+			name: "should_fail_when_search_times_out",
+			args: args{
+				ctx: ctx,
+				filter: &datastore.Filter{
+					Group: &datastore.Group{UID: "123"},
+					Query: "48211",
+				},
+			},
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventRepo.(*mocks.MockEventRepository)
+				ed.EXPECT().
+					SearchEventsPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil, datastore.PaginationData{}, datastore.ErrEventSearchTimeout)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusRequestTimeout,
+			wantErrMsg:  "event search took too long, narrow the date range or filter by application",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1079,6 +1559,53 @@ func TestEventService_GetEventsPaged(t *testing.T) {
 	}
 }
 
+func TestEventService_GetEventsPaged_TimesOutSlowQuery(t *testing.T) {
+	setEnv(t, "CONVOY_DB_QUERY_TIMEOUT", "50ms")
+	require.NoError(t, config.LoadConfig(""))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	es := provideEventService(ctrl)
+
+	// the query only returns once its context is done, as the driver does
+	ev, _ := es.eventRepo.(*mocks.MockEventRepository)
+	ev.EXPECT().LoadEventsPaged(gomock.Any(), "123", "", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ datastore.SearchParams, _ datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+			<-ctx.Done()
+			return nil, datastore.PaginationData{}, ctx.Err()
+		})
+
+	start := time.Now()
+	_, _, err := es.GetEventsPaged(context.Background(), &datastore.Filter{Group: &datastore.Group{UID: "123"}})
+
+	require.Error(t, err)
+	require.Equal(t, http.StatusGatewayTimeout, err.(*ServiceError).ErrCode())
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestEventService_GetEventsPaged_StopsWhenRequestIsCancelled(t *testing.T) {
+	require.NoError(t, config.LoadConfig(""))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	es := provideEventService(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ev, _ := es.eventRepo.(*mocks.MockEventRepository)
+	ev.EXPECT().LoadEventsPaged(gomock.Any(), "123", "", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ datastore.SearchParams, _ datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+			cancel()
+			<-ctx.Done()
+			return nil, datastore.PaginationData{}, ctx.Err()
+		})
+
+	_, _, err := es.GetEventsPaged(ctx, &datastore.Filter{Group: &datastore.Group{UID: "123"}})
+
+	require.Error(t, err)
+	require.Equal(t, http.StatusInternalServerError, err.(*ServiceError).ErrCode())
+}
+
 func TestEventService_GetEventDeliveriesPaged(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -1122,6 +1649,7 @@ func TestEventService_GetEventDeliveriesPaged(t *testing.T) {
 					"123",
 					"abc",
 					"123",
+					"",
 					[]datastore.EventDeliveryStatus{datastore.SuccessEventStatus},
 					datastore.SearchParams{
 						CreatedAtStart: 13323,
@@ -1172,7 +1700,7 @@ func TestEventService_GetEventDeliveriesPaged(t *testing.T) {
 			dbFn: func(es *EventService) {
 				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
 				ed.EXPECT().
-					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).Return(nil, datastore.PaginationData{}, errors.New("failed"))
 			},
 			wantErr:     true,
@@ -1205,6 +1733,274 @@ func TestEventService_GetEventDeliveriesPaged(t *testing.T) {
 	}
 }
 
+func TestEventService_GetEventsCursored(t *testing.T) {
+	ctx := context.Background()
+	events := []datastore.Event{
+		{UID: "e3", CreatedAt: 3000},
+		{UID: "e2", CreatedAt: 2000},
+		{UID: "e1", CreatedAt: 1000},
+	}
+	cursor := func(e datastore.Event, backward bool) string {
+		return (&datastore.Cursor{CreatedAt: e.CreatedAt, UID: e.UID, Backward: backward}).Encode()
+	}
+
+	tests := []struct {
+		name               string
+		filter             *datastore.Filter
+		dbFn               func(es *EventService)
+		wantEvents         []datastore.Event
+		wantPaginationData datastore.PaginationData
+		wantErr            bool
+		wantErrCode        int
+		wantErrMsg         string
+	}{
+		{
+			name: "should_get_first_page",
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				AppID:    "abc",
+				Pageable: datastore.Pageable{PerPage: 2},
+			},
+			dbFn: func(es *EventService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsCursored(gomock.Any(), "123", "abc", "", nil, datastore.SearchParams{}, nil, 3).
+					Times(1).Return(events, nil)
+			},
+			wantEvents:         events[:2],
+			wantPaginationData: datastore.PaginationData{PerPage: 2, NextCursor: cursor(events[1], false)},
+		},
+		{
+			name: "should_get_last_page",
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				Pageable: datastore.Pageable{PerPage: 2},
+				Cursor:   &datastore.Cursor{CreatedAt: 4000, UID: "e4"},
+			},
+			dbFn: func(es *EventService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsCursored(gomock.Any(), "123", "", "", nil, datastore.SearchParams{}, gomock.Any(), 3).
+					Times(1).Return(events[1:], nil)
+			},
+			wantEvents:         events[1:],
+			wantPaginationData: datastore.PaginationData{PerPage: 2, PrevCursor: cursor(events[1], true)},
+		},
+		{
+			name: "should_get_page_before_cursor",
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				Pageable: datastore.Pageable{PerPage: 2},
+				Cursor:   &datastore.Cursor{CreatedAt: 0, UID: "e0", Backward: true},
+			},
+			dbFn: func(es *EventService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsCursored(gomock.Any(), "123", "", "", nil, datastore.SearchParams{}, gomock.Any(), 3).
+					Times(1).Return(events, nil)
+			},
+			wantEvents: events[1:],
+			wantPaginationData: datastore.PaginationData{
+				PerPage:    2,
+				PrevCursor: cursor(events[1], true),
+				NextCursor: cursor(events[2], false),
+			},
+		},
+		{
+			name: "should_reject_search",
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				Query:    "payment",
+				Pageable: datastore.Pageable{PerPage: 2},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "cursor pagination cannot be combined with a search",
+		},
+		{
+			name: "should_fail_to_get_events",
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				Pageable: datastore.Pageable{PerPage: 2},
+			},
+			dbFn: func(es *EventService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().LoadEventsCursored(gomock.Any(), gomock.Any(), gomock.Any(), "", nil, gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "an error occurred while fetching events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			events, paginationData, err := es.GetEventsCursored(ctx, tc.filter)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantEvents, events)
+			require.Equal(t, tc.wantPaginationData, paginationData)
+		})
+	}
+}
+
+func TestEventService_GetEventDeliveriesCursored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	es := provideEventService(ctrl)
+
+	deliveries := []datastore.EventDelivery{
+		{UID: "d2", CreatedAt: 2000},
+		{UID: "d1", CreatedAt: 1000},
+	}
+	status := []datastore.EventDeliveryStatus{datastore.FailureEventStatus}
+	c := &datastore.Cursor{CreatedAt: 3000, UID: "d3"}
+
+	ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	ed.EXPECT().LoadEventDeliveriesCursored(gomock.Any(), "123", "abc", "ref", "", status, datastore.SearchParams{}, c, 2).
+		Times(1).Return(deliveries, nil)
+
+	filter := &datastore.Filter{
+		Group:    &datastore.Group{UID: "123"},
+		AppID:    "abc",
+		EventID:  "ref",
+		Status:   status,
+		Pageable: datastore.Pageable{PerPage: 1},
+		Cursor:   c,
+	}
+
+	got, paginationData, err := es.GetEventDeliveriesCursored(context.Background(), filter)
+	require.Nil(t, err)
+	require.Equal(t, deliveries[:1], got)
+	require.Equal(t, datastore.PaginationData{
+		PerPage:    1,
+		PrevCursor: (&datastore.Cursor{CreatedAt: 2000, UID: "d2", Backward: true}).Encode(),
+		NextCursor: (&datastore.Cursor{CreatedAt: 2000, UID: "d2"}).Encode(),
+	}, paginationData)
+}
+
+func TestEventService_GetEventDeliveryTree(t *testing.T) {
+	ctx := context.Background()
+	event := &datastore.Event{UID: "event-1"}
+	group := &datastore.Group{UID: "123"}
+	pageable := datastore.Pageable{Page: 1, PerPage: 10, Sort: -1}
+
+	deliveries := []datastore.EventDelivery{
+		{
+			UID:              "delivery-1",
+			AppMetadata:      &datastore.AppMetadata{UID: "app-1"},
+			EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus},
+			DeliveryAttempts: []datastore.DeliveryAttempt{{UID: "attempt-1", ResponseData: "0123456789"}},
+		},
+		{
+			UID:              "delivery-2",
+			AppMetadata:      &datastore.AppMetadata{UID: "app-1"},
+			EndpointMetadata: &datastore.EndpointMetadata{UID: "endpoint-2", Status: datastore.ActiveEndpointStatus},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		expandAttempts bool
+		dbFn           func(es *EventService)
+		wantStatus     []datastore.EndpointStatus
+		wantAttempts   [][]datastore.DeliveryAttempt
+		wantErr        bool
+		wantErrCode    int
+		wantErrMsg     string
+	}{
+		{
+			name:           "should_expand_attempts",
+			expandAttempts: true,
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadEventDeliveriesPaged(gomock.Any(), "123", "", "event-1", "", nil, gomock.Any(), pageable).
+					Times(1).Return(deliveries, datastore.PaginationData{Total: 2}, nil)
+
+				a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).
+					Return(&datastore.Application{Endpoints: []datastore.Endpoint{
+						{UID: "endpoint-1", Status: datastore.InactiveEndpointStatus},
+						{UID: "endpoint-2", Status: datastore.ActiveEndpointStatus},
+					}}, nil)
+			},
+			wantStatus: []datastore.EndpointStatus{datastore.InactiveEndpointStatus, datastore.ActiveEndpointStatus},
+			wantAttempts: [][]datastore.DeliveryAttempt{
+				{{UID: "attempt-1", ResponseData: "01234"}},
+				{},
+			},
+		},
+		{
+			name: "should_fall_back_to_delivery_endpoint_status",
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadEventDeliveriesPaged(gomock.Any(), "123", "", "event-1", "", nil, gomock.Any(), pageable).
+					Times(1).Return(deliveries, datastore.PaginationData{Total: 2}, nil)
+
+				a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).
+					Return(nil, datastore.ErrApplicationNotFound)
+			},
+			wantStatus:   []datastore.EndpointStatus{datastore.ActiveEndpointStatus, datastore.ActiveEndpointStatus},
+			wantAttempts: [][]datastore.DeliveryAttempt{nil, nil},
+		},
+		{
+			name: "should_fail_to_load_deliveries",
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().LoadEventDeliveriesPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil, datastore.PaginationData{}, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "an error occurred while fetching event deliveries",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			tc.dbFn(es)
+
+			tree, err := es.GetEventDeliveryTree(ctx, event, group, pageable, tc.expandAttempts, 5)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, event, tree.Event)
+			require.Len(t, tree.Deliveries, len(deliveries))
+
+			for i, delivery := range tree.Deliveries {
+				require.Equal(t, deliveries[i].UID, delivery.UID)
+				require.Equal(t, tc.wantStatus[i], delivery.EndpointStatus)
+				require.Equal(t, tc.wantAttempts[i], delivery.DeliveryAttempts)
+			}
+
+			// the stored attempts are left untouched
+			require.Equal(t, "0123456789", deliveries[0].DeliveryAttempts[0].ResponseData)
+		})
+	}
+}
+
 func TestEventService_ResendEventDelivery(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -1247,6 +2043,28 @@ func TestEventService_ResendEventDelivery(t *testing.T) {
 				g: &datastore.Group{UID: "abc"},
 			},
 		},
+		{
+			name: "should_retry_meta_event_delivery",
+			dbFn: func(es *EventService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus)
+
+				q, _ := es.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().WriteEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				eventDelivery: &datastore.EventDelivery{
+					UID:              "123",
+					Status:           datastore.FailureEventStatus,
+					AppMetadata:      &datastore.AppMetadata{GroupID: "abc"},
+					EndpointMetadata: &datastore.EndpointMetadata{TargetURL: "https://example.com/meta"},
+					IsMetaEvent:      true,
+				},
+				g: &datastore.Group{UID: "abc"},
+			},
+		},
 		{
 			name: "should_error_for_success_status",
 			args: args{
@@ -1727,3 +2545,20 @@ func TestEventService_requeueEventDelivery(t *testing.T) {
 		})
 	}
 }
+
+// setEnv sets the environment variable key for the duration of the test,
+// it restores the previous value on cleanup.
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+			return
+		}
+		_ = os.Unsetenv(key)
+	})
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EventTypeService struct {
+	eventTypeRepo datastore.EventTypeRepository
+	cache         cache.Cache
+}
+
+func NewEventTypeService(eventTypeRepo datastore.EventTypeRepository, cache cache.Cache) *EventTypeService {
+	return &EventTypeService{eventTypeRepo: eventTypeRepo, cache: cache}
+}
+
+// CreateEventType registers a new event type for g.
+func (e *EventTypeService) CreateEventType(ctx context.Context, g *datastore.Group, newEventType *models.EventType) (*datastore.EventTypeDefinition, error) {
+	if err := Validate(newEventType); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(newEventType.Name)
+	if util.IsStringEmpty(name) {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("please provide the event type name"))
+	}
+
+	if len(newEventType.Schema) > 0 {
+		if _, err := parseEventTypeSchema(ctx, newEventType.Schema); err != nil {
+			return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("invalid schema: %v", err))
+		}
+	}
+
+	_, err := e.eventTypeRepo.FindEventTypeByName(ctx, g.UID, name)
+	if err == nil {
+		return nil, NewServiceErrorWithCode(EventTypeConflictErrorCode, fmt.Errorf("event type %s already exists in the group", name))
+	}
+
+	if !errors.Is(err, datastore.ErrEventTypeNotFound) {
+		log.WithError(err).Error("failed to find event type")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to create event type"))
+	}
+
+	eventType := &datastore.EventTypeDefinition{
+		UID:         uuid.New().String(),
+		GroupID:     g.UID,
+		Name:        name,
+		Description: newEventType.Description,
+		Schema:      newEventType.Schema,
+		CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err = e.eventTypeRepo.CreateEventType(ctx, eventType)
+	if err != nil {
+		log.WithError(err).Error("failed to create event type")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to create event type"))
+	}
+
+	e.invalidateEventTypes(ctx, g)
+	return eventType, nil
+}
+
+// LoadEventTypes returns the event types registered for g.
+func (e *EventTypeService) LoadEventTypes(ctx context.Context, g *datastore.Group) ([]datastore.EventTypeDefinition, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	eventTypes, err := loadGroupEventTypes(ctx, e.eventTypeRepo, e.cache, g.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to load event types")
+		return nil, datastoreError(err, http.StatusBadRequest, "an error occurred while fetching event types")
+	}
+
+	return eventTypes, nil
+}
+
+// DeleteEventType removes the event type name from the registry of g.
+func (e *EventTypeService) DeleteEventType(ctx context.Context, g *datastore.Group, name string) error {
+	err := e.eventTypeRepo.DeleteEventType(ctx, g.UID, name)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventTypeNotFound) {
+			return NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to delete event type")
+		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete event type"))
+	}
+
+	e.invalidateEventTypes(ctx, g)
+	return nil
+}
+
+func (e *EventTypeService) invalidateEventTypes(ctx context.Context, g *datastore.Group) {
+	key := convoy.EventTypesCacheKey.Get(g.UID).String()
+
+	err := e.cache.Delete(ctx, key)
+	if err != nil {
+		log.WithError(err).Errorf("failed to delete %s from cache", key)
+	}
+}
+
+// loadGroupEventTypes returns the event types registered for the group
+// groupID, from the cache when it holds them.
+func loadGroupEventTypes(ctx context.Context, eventTypeRepo datastore.EventTypeRepository, c cache.Cache, groupID string) ([]datastore.EventTypeDefinition, error) {
+	var eventTypes []datastore.EventTypeDefinition
+	key := convoy.EventTypesCacheKey.Get(groupID).String()
+
+	err := c.Get(ctx, key, &eventTypes)
+	if err != nil {
+		log.WithError(err).Errorf("failed to load cached event types of group %s", groupID)
+	}
+
+	if eventTypes != nil {
+		return eventTypes, nil
+	}
+
+	eventTypes, err = eventTypeRepo.LoadEventTypes(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Set(ctx, key, eventTypes, time.Minute*5)
+	if err != nil {
+		log.WithError(err).Errorf("failed to cache event types of group %s", groupID)
+	}
+
+	return eventTypes, nil
+}
+
+func parseEventTypeSchema(ctx context.Context, raw json.RawMessage) (*openapi3.Schema, error) {
+	schema := openapi3.NewSchema()
+
+	err := schema.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	err = schema.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// validateEventData checks data against the schema of eventType, if it
+// has one.
+func validateEventData(ctx context.Context, eventType *datastore.EventTypeDefinition, data json.RawMessage) error {
+	if len(eventType.Schema) == 0 {
+		return nil
+	}
+
+	schema, err := parseEventTypeSchema(ctx, eventType.Schema)
+	if err != nil {
+		return fmt.Errorf("the schema of event type %s is invalid: %v", eventType.Name, err)
+	}
+
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("event data is not valid json: %v", err)
+	}
+
+	err = schema.VisitJSON(value)
+	if err != nil {
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err, &schemaErr) {
+			field := strings.Join(schemaErr.JSONPointer(), ".")
+			if field == "" {
+				return fmt.Errorf("event data does not match the schema of %s: %s", eventType.Name, schemaErr.Reason)
+			}
+
+			return fmt.Errorf("event data does not match the schema of %s: %s: %s", eventType.Name, field, schemaErr.Reason)
+		}
+
+		return fmt.Errorf("event data does not match the schema of %s: %v", eventType.Name, err)
+	}
+
+	return nil
+}
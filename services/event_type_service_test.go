@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideEventTypeService(ctrl *gomock.Controller) *EventTypeService {
+	eventTypeRepo := mocks.NewMockEventTypeRepository(ctrl)
+	cache := mocks.NewMockCache(ctrl)
+	return NewEventTypeService(eventTypeRepo, cache)
+}
+
+func TestEventTypeService_CreateEventType(t *testing.T) {
+	group := &datastore.Group{UID: "group-1"}
+
+	tests := []struct {
+		name         string
+		newEventType *models.EventType
+		dbFn         func(es *EventTypeService)
+		wantErr      bool
+		wantErrCode  int
+		wantErrMsg   string
+	}{
+		{
+			name: "should_create_event_type",
+			newEventType: &models.EventType{
+				Name:        "payment.created",
+				Description: "A payment was created",
+				Schema:      []byte(`{"type":"object","properties":{"amount":{"type":"number"}}}`),
+			},
+			dbFn: func(es *EventTypeService) {
+				r, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+				r.EXPECT().FindEventTypeByName(gomock.Any(), "group-1", "payment.created").Times(1).
+					Return(nil, datastore.ErrEventTypeNotFound)
+				r.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "event_types:group-1").Times(1).Return(nil)
+			},
+		},
+		{
+			name:         "should_fail_without_name",
+			newEventType: &models.EventType{Name: "   "},
+			wantErr:      true,
+			wantErrCode:  http.StatusBadRequest,
+			wantErrMsg:   "please provide the event type name",
+		},
+		{
+			name: "should_fail_for_invalid_schema",
+			newEventType: &models.EventType{
+				Name:   "payment.created",
+				Schema: []byte(`{"type":"money"}`),
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  `invalid schema: unsupported 'type' value "money"`,
+		},
+		{
+			name:         "should_fail_for_existing_event_type",
+			newEventType: &models.EventType{Name: "payment.created"},
+			dbFn: func(es *EventTypeService) {
+				r, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+				r.EXPECT().FindEventTypeByName(gomock.Any(), "group-1", "payment.created").Times(1).
+					Return(&datastore.EventTypeDefinition{Name: "payment.created"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  "event type payment.created already exists in the group",
+		},
+		{
+			name:         "should_fail_to_create_event_type",
+			newEventType: &models.EventType{Name: "payment.created"},
+			dbFn: func(es *EventTypeService) {
+				r, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+				r.EXPECT().FindEventTypeByName(gomock.Any(), "group-1", "payment.created").Times(1).
+					Return(nil, datastore.ErrEventTypeNotFound)
+				r.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to create event type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventTypeService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			eventType, err := es.CreateEventType(context.Background(), group, tc.newEventType)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.NotEmpty(t, eventType.UID)
+			require.Equal(t, "group-1", eventType.GroupID)
+			require.Equal(t, tc.newEventType.Name, eventType.Name)
+			require.Equal(t, tc.newEventType.Description, eventType.Description)
+		})
+	}
+}
+
+func TestEventTypeService_DeleteEventType(t *testing.T) {
+	group := &datastore.Group{UID: "group-1"}
+
+	tests := []struct {
+		name        string
+		dbFn        func(es *EventTypeService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_delete_event_type",
+			dbFn: func(es *EventTypeService) {
+				r, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+				r.EXPECT().DeleteEventType(gomock.Any(), "group-1", "payment.created").Times(1).Return(nil)
+
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "event_types:group-1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_fail_for_unknown_event_type",
+			dbFn: func(es *EventTypeService) {
+				r, _ := es.eventTypeRepo.(*mocks.MockEventTypeRepository)
+				r.EXPECT().DeleteEventType(gomock.Any(), "group-1", "payment.created").Times(1).
+					Return(datastore.ErrEventTypeNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "event type not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventTypeService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			err := es.DeleteEventType(context.Background(), group, "payment.created")
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,570 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/objectstore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExportFormat string
+type ExportType string
+
+const (
+	CSVExportFormat    ExportFormat = "csv"
+	NDJSONExportFormat ExportFormat = "ndjson"
+)
+
+const (
+	EventsExportType          ExportType = "events"
+	EventDeliveriesExportType ExportType = "eventdeliveries"
+)
+
+const (
+	// MaxExportRows is the most rows a streamed export may contain,
+	// larger exports must be run as an async export job.
+	MaxExportRows = 10000
+
+	// MaxAsyncExportRows is the most rows an async export job may contain.
+	MaxAsyncExportRows = 1000000
+
+	// ExportJobTTL is how long an export job, and its file, are kept.
+	ExportJobTTL = 24 * time.Hour
+
+	exportBatchSize        = 500
+	exportCleanupBatchSize = 100
+)
+
+var ErrExportTooLarge = errors.New("export too large")
+
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case CSVExportFormat, NDJSONExportFormat:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == NDJSONExportFormat {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+type ExportService struct {
+	eventRepo         datastore.EventRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	exportJobRepo     datastore.ExportJobRepository
+	store             objectstore.ObjectStore
+	exportDir         string
+}
+
+// NewExportService returns an ExportService that uploads the files of
+// export jobs to store, or keeps them in a temporary directory when store
+// is nil.
+func NewExportService(eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, exportJobRepo datastore.ExportJobRepository, store objectstore.ObjectStore) *ExportService {
+	return &ExportService{
+		eventRepo:         eventRepo,
+		eventDeliveryRepo: eventDeliveryRepo,
+		exportJobRepo:     exportJobRepo,
+		store:             store,
+		exportDir:         os.TempDir(),
+	}
+}
+
+// Export streams every record matching filter to w in the given format.
+// Records are read from the repository a batch at a time, and nothing is
+// written to w if the number of matching records exceeds maxRows.
+func (e *ExportService) Export(ctx context.Context, exportType ExportType, filter *datastore.Filter, format ExportFormat, w io.Writer, maxRows int64) (int64, error) {
+	var ew exportWriter
+	switch format {
+	case CSVExportFormat:
+		ew = &csvExportWriter{w: csv.NewWriter(w), header: csvExportHeader(exportType)}
+	case NDJSONExportFormat:
+		ew = &ndjsonExportWriter{enc: json.NewEncoder(w)}
+	default:
+		return 0, NewServiceError(http.StatusBadRequest, errors.New("invalid export format"))
+	}
+
+	// loadPage writes the batch of records past cursor, it returns how
+	// many were written and the cursor of the last one
+	var loadPage func(cursor *datastore.Cursor) (int, *datastore.Cursor, error)
+	switch exportType {
+	case EventsExportType:
+		// the export matches the same events as the events list
+		query, err := validateEventSearch(filter)
+		if err != nil {
+			return 0, err
+		}
+
+		loadPage = func(cursor *datastore.Cursor) (int, *datastore.Cursor, error) {
+			events, err := e.eventRepo.LoadEventsCursored(ctx, filter.Group.UID, filter.AppID, query, filter.Metadata, filter.SearchParams, cursor, exportBatchSize)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			for i := range events {
+				if err = ew.writeEvent(&events[i]); err != nil {
+					return i, nil, err
+				}
+			}
+
+			if len(events) == 0 {
+				return 0, nil, nil
+			}
+
+			last := events[len(events)-1]
+			return len(events), &datastore.Cursor{CreatedAt: last.CreatedAt, UID: last.UID}, nil
+		}
+	case EventDeliveriesExportType:
+		loadPage = func(cursor *datastore.Cursor) (int, *datastore.Cursor, error) {
+			deliveries, err := e.eventDeliveryRepo.LoadEventDeliveriesCursored(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams, cursor, exportBatchSize)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			for i := range deliveries {
+				if err = ew.writeEventDelivery(&deliveries[i]); err != nil {
+					return i, nil, err
+				}
+			}
+
+			if len(deliveries) == 0 {
+				return 0, nil, nil
+			}
+
+			last := deliveries[len(deliveries)-1]
+			return len(deliveries), &datastore.Cursor{CreatedAt: last.CreatedAt, UID: last.UID}, nil
+		}
+	default:
+		return 0, NewServiceError(http.StatusBadRequest, errors.New("invalid export type"))
+	}
+
+	// check the size of the export before anything is written
+	total, err := e.countRecords(ctx, exportType, filter)
+	if err != nil {
+		log.WithError(err).Error("failed to count export records")
+		return 0, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while exporting records"))
+	}
+
+	if total > maxRows {
+		return 0, NewServiceError(http.StatusRequestEntityTooLarge,
+			fmt.Errorf("%w: %d records match this filter, the limit is %d. narrow the filter or use async=true", ErrExportTooLarge, total, maxRows))
+	}
+
+	// records are exported newest first, each batch is seeked to by the
+	// cursor of the last record so deep batches stay as cheap as the first
+	var rows int64
+	var cursor *datastore.Cursor
+	for {
+		n, next, err := loadPage(cursor)
+		rows += int64(n)
+		if err != nil {
+			log.WithError(err).Error("failed to export records")
+			return rows, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while exporting records"))
+		}
+
+		if n < exportBatchSize || rows >= maxRows {
+			break
+		}
+		cursor = next
+	}
+
+	if err = ew.flush(); err != nil {
+		log.WithError(err).Error("failed to flush export")
+		return rows, NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while exporting records"))
+	}
+
+	return rows, nil
+}
+
+func (e *ExportService) countRecords(ctx context.Context, exportType ExportType, filter *datastore.Filter) (int64, error) {
+	if exportType == EventDeliveriesExportType {
+		return e.eventDeliveryRepo.CountEventDeliveries(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.EndpointID, filter.Status, filter.SearchParams)
+	}
+
+	pageable := datastore.Pageable{Page: 1, PerPage: 1, Sort: -1}
+	if !util.IsStringEmpty(filter.Query) || len(filter.Metadata) > 0 {
+		_, paginationData, err := e.eventRepo.SearchEventsPaged(ctx, filter.Group.UID, filter.AppID, strings.TrimSpace(filter.Query), filter.Metadata, filter.SearchParams, pageable)
+		return paginationData.Total, err
+	}
+
+	_, paginationData, err := e.eventRepo.LoadEventsPaged(ctx, filter.Group.UID, filter.AppID, filter.SearchParams, pageable)
+	return paginationData.Total, err
+}
+
+// CreateExportJob runs the export in the background, writing it to a
+// file which can be downloaded once the job completes and until it
+// expires.
+func (e *ExportService) CreateExportJob(ctx context.Context, exportType ExportType, filter *datastore.Filter, format ExportFormat) (*datastore.ExportJob, error) {
+	if !format.IsValid() {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("invalid export format"))
+	}
+
+	if exportType == EventsExportType {
+		if _, err := validateEventSearch(filter); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	job := &datastore.ExportJob{
+		UID:       uuid.New().String(),
+		GroupID:   filter.Group.UID,
+		Type:      string(exportType),
+		Format:    string(format),
+		Status:    datastore.PendingExportJobStatus,
+		CreatedAt: primitive.NewDateTimeFromTime(now),
+		ExpiresAt: primitive.NewDateTimeFromTime(now.Add(ExportJobTTL)),
+	}
+
+	err := e.exportJobRepo.CreateExportJob(ctx, job)
+	if err != nil {
+		log.WithError(err).Error("failed to create export job")
+		return nil, NewServiceError(http.StatusInternalServerError, errors.New("failed to create export job"))
+	}
+
+	pending := *job
+	go e.runExportJob(job, exportType, filter, format)
+
+	return &pending, nil
+}
+
+func (e *ExportService) runExportJob(job *datastore.ExportJob, exportType ExportType, filter *datastore.Filter, format ExportFormat) {
+	ctx := context.Background()
+	filePath := e.exportFilePath(job)
+
+	var rows int64
+	f, err := os.Create(filePath)
+	if err == nil {
+		rows, err = e.Export(ctx, exportType, filter, format, f, MaxAsyncExportRows)
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+	}
+
+	if err == nil && e.store != nil {
+		err = e.uploadExportFile(ctx, job, filePath)
+	}
+
+	job.Rows = rows
+	job.Status = datastore.CompletedExportJobStatus
+	if err != nil {
+		log.WithError(err).Errorf("export job %s failed", job.UID)
+		job.Status = datastore.FailedExportJobStatus
+		job.Error = err.Error()
+		_ = os.Remove(filePath)
+	}
+
+	err = e.exportJobRepo.UpdateExportJob(ctx, job)
+	if err != nil {
+		log.WithError(err).Errorf("failed to update export job %s", job.UID)
+	}
+}
+
+// uploadExportFile moves the file of job to object storage, so that it
+// can be downloaded from any server.
+func (e *ExportService) uploadExportFile(ctx context.Context, job *datastore.ExportJob, filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	key := path.Join("exports", job.GroupID, fmt.Sprintf("%s.%s", job.UID, job.Format))
+	err = e.store.Put(ctx, key, data)
+	if err != nil {
+		return err
+	}
+
+	job.ObjectKey = key
+	return os.Remove(filePath)
+}
+
+func (e *ExportService) exportFilePath(job *datastore.ExportJob) string {
+	return filepath.Join(e.exportDir, fmt.Sprintf("convoy-export-%s.%s", job.UID, job.Format))
+}
+
+func (e *ExportService) GetExportJob(ctx context.Context, groupID, uid string) (*datastore.ExportJob, error) {
+	job, err := e.exportJobRepo.FindExportJobByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, datastore.ErrExportJobNotFound) {
+			return nil, NewServiceError(http.StatusNotFound, errors.New("export job not found"))
+		}
+
+		log.WithError(err).Error("failed to fetch export job")
+		return nil, NewServiceError(http.StatusInternalServerError, errors.New("failed to fetch export job"))
+	}
+
+	// expired jobs are only deleted periodically, until then they're
+	// treated as if they already were
+	if job.GroupID != groupID || job.ExpiresAt.Time().Before(time.Now()) {
+		return nil, NewServiceError(http.StatusNotFound, errors.New("export job not found"))
+	}
+
+	return job, nil
+}
+
+// OpenExportFile opens the file of a completed export job for reading.
+func (e *ExportService) OpenExportFile(ctx context.Context, groupID, uid string) (*datastore.ExportJob, io.ReadCloser, error) {
+	job, err := e.GetExportJob(ctx, groupID, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if job.Status != datastore.CompletedExportJobStatus {
+		return nil, nil, NewServiceError(http.StatusConflict, fmt.Errorf("export job is %s", job.Status))
+	}
+
+	if !util.IsStringEmpty(job.ObjectKey) {
+		if e.store == nil {
+			return nil, nil, NewServiceError(http.StatusInternalServerError, ErrArchiveStorageNotConfigured)
+		}
+
+		data, err := e.store.Get(ctx, job.ObjectKey)
+		if err != nil {
+			log.WithError(err).Error("failed to download export file")
+			return nil, nil, NewServiceError(http.StatusInternalServerError, errors.New("failed to open export file"))
+		}
+
+		return job, io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	f, err := os.Open(e.exportFilePath(job))
+	if err != nil {
+		// without object storage the file is only on the server that ran the job
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, NewServiceError(http.StatusNotFound, errors.New("export file not found"))
+		}
+
+		log.WithError(err).Error("failed to open export file")
+		return nil, nil, NewServiceError(http.StatusInternalServerError, errors.New("failed to open export file"))
+	}
+
+	return job, f, nil
+}
+
+// CleanupExpiredExportJobs deletes the export jobs that have expired along
+// with their files, and the files this server exported that outlived
+// their job.
+func (e *ExportService) CleanupExpiredExportJobs(ctx context.Context) error {
+	now := time.Now()
+
+	for {
+		jobs, err := e.exportJobRepo.LoadExpiredExportJobs(ctx, now, exportCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+
+		deleted := 0
+		for i := range jobs {
+			job := &jobs[i]
+
+			err = e.removeExportFile(ctx, job)
+			if err != nil {
+				log.WithError(err).Errorf("failed to remove the file of export job %s", job.UID)
+				continue
+			}
+
+			err = e.exportJobRepo.DeleteExportJob(ctx, job.UID)
+			if err != nil && !errors.Is(err, datastore.ErrExportJobNotFound) {
+				log.WithError(err).Errorf("failed to delete export job %s", job.UID)
+				continue
+			}
+			deleted++
+		}
+
+		// jobs that failed to be removed are loaded again, so stop rather
+		// than retry them in a loop
+		if len(jobs) < exportCleanupBatchSize || deleted == 0 {
+			break
+		}
+	}
+
+	return e.removeStaleExportFiles(now.Add(-ExportJobTTL))
+}
+
+func (e *ExportService) removeExportFile(ctx context.Context, job *datastore.ExportJob) error {
+	if !util.IsStringEmpty(job.ObjectKey) {
+		if e.store == nil {
+			return nil
+		}
+		return e.store.Delete(ctx, job.ObjectKey)
+	}
+
+	err := os.Remove(e.exportFilePath(job))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// removeStaleExportFiles removes the export files in the export directory
+// last written before olderThan. A job is cleaned up by whichever server
+// gets to it first, which isn't always the one that has its file.
+func (e *ExportService) removeStaleExportFiles(olderThan time.Time) error {
+	files, err := filepath.Glob(filepath.Join(e.exportDir, "convoy-export-*"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil || info.ModTime().After(olderThan) {
+			continue
+		}
+
+		err = os.Remove(file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.WithError(err).Errorf("failed to remove stale export file %s", file)
+		}
+	}
+
+	return nil
+}
+
+type exportWriter interface {
+	writeEvent(*datastore.Event) error
+	writeEventDelivery(*datastore.EventDelivery) error
+	flush() error
+}
+
+// csvExportWriter writes header before the first record, or on flush when
+// there are none, so an empty export is still a valid csv file.
+type csvExportWriter struct {
+	w             *csv.Writer
+	header        []string
+	headerWritten bool
+}
+
+var eventCSVHeader = []string{"uid", "event_type", "app_id", "app_name", "group_id", "matched_endpoints", "created_at", "data"}
+var eventDeliveryCSVHeader = []string{"uid", "event_id", "event_type", "app_id", "group_id", "endpoint_id", "endpoint_url", "status", "description", "num_trials", "created_at", "updated_at", "data"}
+
+func csvExportHeader(exportType ExportType) []string {
+	if exportType == EventDeliveriesExportType {
+		return eventDeliveryCSVHeader
+	}
+	return eventCSVHeader
+}
+
+func (c *csvExportWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(c.header)
+}
+
+func (c *csvExportWriter) writeEvent(ev *datastore.Event) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	app := ev.AppMetadata
+	if app == nil {
+		app = &datastore.AppMetadata{}
+	}
+
+	return c.w.Write([]string{
+		ev.UID,
+		string(ev.EventType),
+		app.UID,
+		app.Title,
+		app.GroupID,
+		strconv.Itoa(ev.MatchedEndpoints),
+		formatExportTime(ev.CreatedAt.Time()),
+		string(ev.Data),
+	})
+}
+
+func (c *csvExportWriter) writeEventDelivery(ed *datastore.EventDelivery) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	event := ed.EventMetadata
+	if event == nil {
+		event = &datastore.EventMetadata{}
+	}
+
+	app := ed.AppMetadata
+	if app == nil {
+		app = &datastore.AppMetadata{}
+	}
+
+	endpoint := ed.EndpointMetadata
+	if endpoint == nil {
+		endpoint = &datastore.EndpointMetadata{}
+	}
+
+	var data []byte
+	var numTrials uint64
+	if ed.Metadata != nil {
+		data = ed.Metadata.Data
+		numTrials = ed.Metadata.NumTrials
+	}
+
+	return c.w.Write([]string{
+		ed.UID,
+		event.UID,
+		string(event.EventType),
+		app.UID,
+		app.GroupID,
+		endpoint.UID,
+		endpoint.TargetURL,
+		string(ed.Status),
+		ed.Description,
+		strconv.FormatUint(numTrials, 10),
+		formatExportTime(ed.CreatedAt.Time()),
+		formatExportTime(ed.UpdatedAt.Time()),
+		string(data),
+	})
+}
+
+func (c *csvExportWriter) flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonExportWriter) writeEvent(ev *datastore.Event) error {
+	return n.enc.Encode(ev)
+}
+
+func (n *ndjsonExportWriter) writeEventDelivery(ed *datastore.EventDelivery) error {
+	return n.enc.Encode(ed)
+}
+
+func (n *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func provideExportService(ctrl *gomock.Controller) *ExportService {
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	exportJobRepo := mocks.NewMockExportJobRepository(ctrl)
+	return NewExportService(eventRepo, eventDeliveryRepo, exportJobRepo, nil)
+}
+
+func TestExportService_Export(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		exportType  ExportType
+		format      ExportFormat
+		filter      *datastore.Filter
+		dbFn        func(es *ExportService)
+		wantRows    int64
+		wantErr     bool
+		wantErrCode int
+		assertFn    func(t *testing.T, out []byte)
+	}{
+		{
+			name:       "should_export_events_as_csv",
+			exportType: EventsExportType,
+			format:     CSVExportFormat,
+			dbFn: func(es *ExportService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					LoadEventsPaged(gomock.Any(), "123", "", gomock.Any(), datastore.Pageable{Page: 1, PerPage: 1, Sort: -1}).
+					Times(1).Return(nil, datastore.PaginationData{Total: 2}, nil)
+
+				e.EXPECT().
+					LoadEventsCursored(gomock.Any(), "123", "", "", nil, gomock.Any(), nil, exportBatchSize).
+					Times(1).Return([]datastore.Event{
+					{
+						UID:         "1",
+						EventType:   "payment.created",
+						Data:        json.RawMessage(`{"amount":100,"note":"a, \"b\""}`),
+						AppMetadata: &datastore.AppMetadata{UID: "abc", Title: "app", GroupID: "123"},
+						CreatedAt:   primitive.NewDateTimeFromTime(createdAt),
+					},
+					{
+						UID:         "2",
+						EventType:   "payment.updated",
+						Data:        json.RawMessage(`{}`),
+						AppMetadata: &datastore.AppMetadata{UID: "abc", Title: "app", GroupID: "123"},
+						CreatedAt:   primitive.NewDateTimeFromTime(createdAt),
+					},
+				}, nil)
+			},
+			wantRows: 2,
+			assertFn: func(t *testing.T, out []byte) {
+				records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+				require.NoError(t, err)
+				require.Equal(t, 3, len(records))
+				require.Equal(t, eventCSVHeader, records[0])
+				require.Equal(t, "2022-03-04T05:06:07Z", records[1][6])
+				require.Equal(t, `{"amount":100,"note":"a, \"b\""}`, records[1][7])
+			},
+		},
+		{
+			name:       "should_write_csv_header_for_empty_export",
+			exportType: EventDeliveriesExportType,
+			format:     CSVExportFormat,
+			dbFn: func(es *ExportService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					CountEventDeliveries(gomock.Any(), "123", "", "", "", gomock.Any(), gomock.Any()).
+					Times(1).Return(int64(0), nil)
+
+				ed.EXPECT().
+					LoadEventDeliveriesCursored(gomock.Any(), "123", "", "", "", gomock.Any(), gomock.Any(), nil, exportBatchSize).
+					Times(1).Return(nil, nil)
+			},
+			wantRows: 0,
+			assertFn: func(t *testing.T, out []byte) {
+				records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+				require.NoError(t, err)
+				require.Equal(t, [][]string{eventDeliveryCSVHeader}, records)
+			},
+		},
+		{
+			name:       "should_export_event_deliveries_as_ndjson",
+			exportType: EventDeliveriesExportType,
+			format:     NDJSONExportFormat,
+			dbFn: func(es *ExportService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					CountEventDeliveries(gomock.Any(), "123", "", "", "", gomock.Any(), gomock.Any()).
+					Times(1).Return(int64(1), nil)
+
+				ed.EXPECT().
+					LoadEventDeliveriesCursored(gomock.Any(), "123", "", "", "", gomock.Any(), gomock.Any(), nil, exportBatchSize).
+					Times(1).Return([]datastore.EventDelivery{
+					{UID: "d1", Status: datastore.FailureEventStatus},
+				}, nil)
+			},
+			wantRows: 1,
+			assertFn: func(t *testing.T, out []byte) {
+				var delivery datastore.EventDelivery
+				require.NoError(t, json.Unmarshal(bytes.TrimSpace(out), &delivery))
+				require.Equal(t, "d1", delivery.UID)
+				require.Equal(t, datastore.FailureEventStatus, delivery.Status)
+			},
+		},
+		{
+			name:       "should_seek_each_batch_by_cursor",
+			exportType: EventsExportType,
+			format:     NDJSONExportFormat,
+			dbFn: func(es *ExportService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					LoadEventsPaged(gomock.Any(), "123", "", gomock.Any(), gomock.Any()).
+					Times(1).Return(nil, datastore.PaginationData{Total: exportBatchSize + 1}, nil)
+
+				batch := make([]datastore.Event, exportBatchSize)
+				for i := range batch {
+					batch[i] = datastore.Event{UID: fmt.Sprintf("e%d", i), CreatedAt: primitive.NewDateTimeFromTime(createdAt)}
+				}
+
+				e.EXPECT().
+					LoadEventsCursored(gomock.Any(), "123", "", "", nil, gomock.Any(), nil, exportBatchSize).
+					Times(1).Return(batch, nil)
+
+				cursor := &datastore.Cursor{CreatedAt: primitive.NewDateTimeFromTime(createdAt), UID: fmt.Sprintf("e%d", exportBatchSize-1)}
+				e.EXPECT().
+					LoadEventsCursored(gomock.Any(), "123", "", "", nil, gomock.Any(), cursor, exportBatchSize).
+					Times(1).Return([]datastore.Event{{UID: "last"}}, nil)
+			},
+			wantRows: exportBatchSize + 1,
+			assertFn: func(t *testing.T, out []byte) {
+				lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+				require.Equal(t, exportBatchSize+1, len(lines))
+				require.Contains(t, string(lines[exportBatchSize]), `"uid":"last"`)
+			},
+		},
+		{
+			name:       "should_reject_export_above_row_cap",
+			exportType: EventDeliveriesExportType,
+			format:     CSVExportFormat,
+			dbFn: func(es *ExportService) {
+				ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().
+					CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(int64(MaxExportRows+1), nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "should_export_events_matching_search",
+			exportType: EventsExportType,
+			format:     NDJSONExportFormat,
+			filter: &datastore.Filter{
+				Group:        &datastore.Group{UID: "123"},
+				Query:        " order ",
+				Metadata:     map[string]string{"tenant": "acme"},
+				SearchParams: datastore.SearchParams{Tag: "replay"},
+				Pageable:     datastore.Pageable{Sort: -1},
+			},
+			dbFn: func(es *ExportService) {
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().
+					SearchEventsPaged(gomock.Any(), "123", "", "order", map[string]string{"tenant": "acme"}, datastore.SearchParams{Tag: "replay"}, datastore.Pageable{Page: 1, PerPage: 1, Sort: -1}).
+					Times(1).Return(nil, datastore.PaginationData{Total: 1}, nil)
+
+				e.EXPECT().
+					LoadEventsCursored(gomock.Any(), "123", "", "order", map[string]string{"tenant": "acme"}, datastore.SearchParams{Tag: "replay"}, nil, exportBatchSize).
+					Times(1).Return([]datastore.Event{{UID: "1"}}, nil)
+			},
+			wantRows: 1,
+			assertFn: func(t *testing.T, out []byte) {
+				require.Contains(t, string(out), `"uid":"1"`)
+			},
+		},
+		{
+			name:       "should_reject_broad_search_query",
+			exportType: EventsExportType,
+			format:     CSVExportFormat,
+			filter: &datastore.Filter{
+				Group:    &datastore.Group{UID: "123"},
+				Query:    "ab",
+				Pageable: datastore.Pageable{Sort: -1},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+		},
+		{
+			name:        "should_reject_invalid_format",
+			exportType:  EventsExportType,
+			format:      "xml",
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideExportService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			var buf bytes.Buffer
+			filter := tc.filter
+			if filter == nil {
+				filter = &datastore.Filter{Group: &datastore.Group{UID: "123"}, Pageable: datastore.Pageable{Sort: -1}}
+			}
+
+			rows, err := es.Export(ctx, tc.exportType, filter, tc.format, &buf, MaxExportRows)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, 0, buf.Len())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantRows, rows)
+			tc.assertFn(t, buf.Bytes())
+		})
+	}
+}
+
+// runTestExportJob runs an export job of a single delivery on es, and
+// returns the job as it was stored once the job finished.
+func runTestExportJob(t *testing.T, es *ExportService) *datastore.ExportJob {
+	ed, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	ed.EXPECT().
+		CountEventDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).Return(int64(1), nil)
+	ed.EXPECT().
+		LoadEventDeliveriesCursored(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil, exportBatchSize).
+		Times(1).Return([]datastore.EventDelivery{{UID: "d1"}}, nil)
+
+	var created datastore.ExportJob
+	finished := make(chan datastore.ExportJob, 1)
+
+	ej, _ := es.exportJobRepo.(*mocks.MockExportJobRepository)
+	ej.EXPECT().CreateExportJob(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, job *datastore.ExportJob) error {
+			created = *job
+			return nil
+		})
+	ej.EXPECT().UpdateExportJob(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, job *datastore.ExportJob) error {
+			finished <- *job
+			return nil
+		})
+
+	filter := &datastore.Filter{Group: &datastore.Group{UID: "123"}, Pageable: datastore.Pageable{Sort: -1}}
+	job, err := es.CreateExportJob(context.Background(), EventDeliveriesExportType, filter, NDJSONExportFormat)
+	require.NoError(t, err)
+	require.Equal(t, datastore.PendingExportJobStatus, job.Status)
+	require.Equal(t, created.UID, job.UID)
+	require.WithinDuration(t, time.Now().Add(ExportJobTTL), job.ExpiresAt.Time(), time.Minute)
+
+	select {
+	case stored := <-finished:
+		return &stored
+	case <-time.After(time.Second):
+		t.Fatal("export job did not finish")
+		return nil
+	}
+}
+
+func TestExportService_CreateExportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideExportService(ctrl)
+	es.exportDir = t.TempDir()
+
+	stored := runTestExportJob(t, es)
+	require.Equal(t, datastore.CompletedExportJobStatus, stored.Status)
+	require.Equal(t, int64(1), stored.Rows)
+	require.Empty(t, stored.ObjectKey)
+
+	ej, _ := es.exportJobRepo.(*mocks.MockExportJobRepository)
+	ej.EXPECT().FindExportJobByID(gomock.Any(), stored.UID).Times(2).Return(stored, nil)
+
+	_, err := es.GetExportJob(context.Background(), "456", stored.UID)
+	require.Equal(t, http.StatusNotFound, err.(*ServiceError).ErrCode())
+
+	completed, f, err := es.OpenExportFile(context.Background(), "123", stored.UID)
+	require.NoError(t, err)
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), completed.Rows)
+	require.Contains(t, string(b), `"uid":"d1"`)
+}
+
+func TestExportService_CreateExportJob_ObjectStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideExportService(ctrl)
+	es.exportDir = t.TempDir()
+
+	store := mocks.NewMockObjectStore(ctrl)
+	es.store = store
+
+	var uploaded []byte
+	store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, key string, data []byte) error {
+			uploaded = data
+			return nil
+		})
+
+	stored := runTestExportJob(t, es)
+	require.Equal(t, datastore.CompletedExportJobStatus, stored.Status)
+	require.Equal(t, fmt.Sprintf("exports/123/%s.ndjson", stored.UID), stored.ObjectKey)
+	require.Contains(t, string(uploaded), `"uid":"d1"`)
+
+	// the local copy is removed once uploaded
+	_, err := os.Stat(es.exportFilePath(stored))
+	require.True(t, os.IsNotExist(err))
+
+	ej, _ := es.exportJobRepo.(*mocks.MockExportJobRepository)
+	ej.EXPECT().FindExportJobByID(gomock.Any(), stored.UID).Times(1).Return(stored, nil)
+	store.EXPECT().Get(gomock.Any(), stored.ObjectKey).Times(1).Return(uploaded, nil)
+
+	_, f, err := es.OpenExportFile(context.Background(), "123", stored.UID)
+	require.NoError(t, err)
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, uploaded, b)
+}
+
+func TestExportService_GetExportJob_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideExportService(ctrl)
+
+	ej, _ := es.exportJobRepo.(*mocks.MockExportJobRepository)
+	ej.EXPECT().FindExportJobByID(gomock.Any(), "job-1").Times(1).Return(&datastore.ExportJob{
+		UID:       "job-1",
+		GroupID:   "123",
+		Status:    datastore.CompletedExportJobStatus,
+		ExpiresAt: primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute)),
+	}, nil)
+
+	_, err := es.GetExportJob(context.Background(), "123", "job-1")
+	require.Equal(t, http.StatusNotFound, err.(*ServiceError).ErrCode())
+}
+
+func TestExportService_CleanupExpiredExportJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es := provideExportService(ctrl)
+	es.exportDir = t.TempDir()
+
+	store := mocks.NewMockObjectStore(ctrl)
+	es.store = store
+
+	local := datastore.ExportJob{UID: "local", Format: string(CSVExportFormat)}
+	uploaded := datastore.ExportJob{UID: "uploaded", Format: string(CSVExportFormat), ObjectKey: "exports/123/uploaded.csv"}
+	require.NoError(t, os.WriteFile(es.exportFilePath(&local), []byte("uid"), 0o600))
+
+	// a file whose job was cleaned up by another server, and one that
+	// is still fresh
+	stale := filepath.Join(es.exportDir, "convoy-export-stale.csv")
+	fresh := filepath.Join(es.exportDir, "convoy-export-fresh.csv")
+	require.NoError(t, os.WriteFile(stale, []byte("uid"), 0o600))
+	require.NoError(t, os.WriteFile(fresh, []byte("uid"), 0o600))
+	old := time.Now().Add(-ExportJobTTL - time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	ej, _ := es.exportJobRepo.(*mocks.MockExportJobRepository)
+	ej.EXPECT().LoadExpiredExportJobs(gomock.Any(), gomock.Any(), exportCleanupBatchSize).Times(1).
+		Return([]datastore.ExportJob{local, uploaded}, nil)
+	store.EXPECT().Delete(gomock.Any(), uploaded.ObjectKey).Times(1).Return(nil)
+	ej.EXPECT().DeleteExportJob(gomock.Any(), "local").Times(1).Return(nil)
+	ej.EXPECT().DeleteExportJob(gomock.Any(), "uploaded").Times(1).Return(nil)
+
+	require.NoError(t, es.CleanupExpiredExportJobs(context.Background()))
+
+	for _, file := range []string{es.exportFilePath(&local), stale} {
+		_, err := os.Stat(file)
+		require.True(t, os.IsNotExist(err), file)
+	}
+
+	_, err := os.Stat(fresh)
+	require.NoError(t, err)
+}
@@ -3,10 +3,17 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/config/algo"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/server/models"
@@ -14,6 +21,7 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
 )
 
 type GroupService struct {
@@ -21,32 +29,108 @@ type GroupService struct {
 	groupRepo         datastore.GroupRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	settingsRepo      datastore.GlobalSettingsRepository
 	limiter           limiter.RateLimiter
+	cache             cache.Cache
 }
 
-func NewGroupService(appRepo datastore.ApplicationRepository, groupRepo datastore.GroupRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, limiter limiter.RateLimiter) *GroupService {
+// groupStatisticsCacheTTL is how long statistics computed on a cache miss
+// are kept. StatsRefresher overwrites them on its next run.
+const groupStatisticsCacheTTL = time.Minute
+
+// maxGroupEventPayloadSize is the largest payload size limit, in bytes, a
+// group can set.
+const maxGroupEventPayloadSize = 5 * 1024 * 1024
+
+// bounds of a group's idempotency window, idempotency keys are remembered
+// for at least a minute and at most three days.
+const (
+	minIdempotencyWindow = time.Minute
+	maxIdempotencyWindow = 72 * time.Hour
+)
+
+func NewGroupService(appRepo datastore.ApplicationRepository, groupRepo datastore.GroupRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, settingsRepo datastore.GlobalSettingsRepository, limiter limiter.RateLimiter, cache cache.Cache) *GroupService {
 	return &GroupService{
 		appRepo:           appRepo,
 		groupRepo:         groupRepo,
 		eventRepo:         eventRepo,
 		eventDeliveryRepo: eventDeliveryRepo,
+		settingsRepo:      settingsRepo,
 		limiter:           limiter,
+		cache:             cache,
 	}
 }
 
+// GetRateLimitUsage returns how much of its api rate limit the group has
+// left, the limit being the group's or the default one when it has none.
+func (gs *GroupService) GetRateLimitUsage(ctx context.Context, g *datastore.Group) (*models.RateLimitUsage, error) {
+	limit := g.RateLimit
+	if limit == 0 {
+		limit = convoy.RATE_LIMIT
+	}
+
+	rawDuration := g.RateLimitDuration
+	if util.IsStringEmpty(rawDuration) {
+		rawDuration = convoy.RATE_LIMIT_DURATION
+	}
+
+	duration, err := time.ParseDuration(rawDuration)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("an error occurred parsing the rate limit duration: %v", err))
+	}
+
+	res, err := gs.limiter.Inspect(ctx, g.UID, limit, int(duration))
+	if err != nil {
+		log.WithError(err).Error("failed to check group rate limit")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to fetch group rate limit usage"))
+	}
+
+	return newRateLimitUsage(limit, duration, res), nil
+}
+
 func (gs *GroupService) CreateGroup(ctx context.Context, newGroup *models.Group) (*datastore.Group, error) {
 	groupName := newGroup.Name
-	err := util.Validate(newGroup)
+	if errs := gs.ValidateGroupConfig(newGroup); len(errs) > 0 {
+		return nil, newValidationError(errs)
+	}
+
+	if isReservedGroupName(groupName) {
+		return nil, NewServiceErrorWithCode(GroupNameConflictErrorCode, errors.New("group name is reserved"))
+	}
+
+	err := encryptProxyPassword(newGroup.Config.OutboundProxy, nil)
 	if err != nil {
-		return nil, NewServiceError(http.StatusBadRequest, err)
+		return nil, err
+	}
+
+	err = encryptMetaEventSecret(newGroup.Config.MetaEvent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// whatever the new group leaves unset is taken from the global settings
+	settings, err := loadGlobalSettings(ctx, gs.settingsRepo)
+	if err != nil {
+		log.WithError(err).Error("failed to load global settings")
+		return nil, datastoreError(err, http.StatusBadRequest, "failed to create group")
 	}
 
 	if newGroup.RateLimit == 0 {
-		newGroup.RateLimit = convoy.RATE_LIMIT
+		newGroup.RateLimit = settings.RateLimit
 	}
 
 	if util.IsStringEmpty(newGroup.RateLimitDuration) {
-		newGroup.RateLimitDuration = convoy.RATE_LIMIT_DURATION
+		newGroup.RateLimitDuration = settings.RateLimitDuration
+	}
+
+	// events of groups with a retention policy are archived before they
+	// are deleted, they aren't expired by the global retention
+	if newGroup.Config.EventRetentionDays == 0 && util.IsStringEmpty(newGroup.Config.RetentionPolicy) {
+		newGroup.Config.EventRetentionDays = settings.EventRetentionDays
+	}
+
+	if util.IsStringEmpty(newGroup.Config.IdempotencyWindowDuration) {
+		newGroup.Config.IdempotencyWindowDuration = datastore.DefaultIdempotencyWindowDuration
 	}
 
 	group := &datastore.Group{
@@ -58,6 +142,8 @@ func (gs *GroupService) CreateGroup(ctx context.Context, newGroup *models.Group)
 		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
 		RateLimit:         newGroup.RateLimit,
 		RateLimitDuration: newGroup.RateLimitDuration,
+		Regions:           newGroup.Regions,
+		PrimaryRegion:     newGroup.PrimaryRegion,
 		DocumentStatus:    datastore.ActiveDocumentStatus,
 	}
 
@@ -67,40 +153,132 @@ func (gs *GroupService) CreateGroup(ctx context.Context, newGroup *models.Group)
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to create group"))
 	}
 
+	if group.EventTTLDays() > 0 {
+		gs.ensureRetentionIndex(ctx, group)
+	}
+
 	return group, nil
 }
 
 func (gs *GroupService) UpdateGroup(ctx context.Context, group *datastore.Group, update *models.Group) (*datastore.Group, error) {
-	err := util.Validate(update)
-	if err != nil {
+	if errs := gs.ValidateGroupConfig(update); len(errs) > 0 {
+		err := newValidationError(errs)
 		log.WithError(err).Error("failed to validate group update")
-		return nil, NewServiceError(http.StatusBadRequest, err)
+		return nil, err
+	}
+
+	if update.Name != group.Name && isReservedGroupName(update.Name) {
+		return nil, NewServiceErrorWithCode(GroupNameConflictErrorCode, errors.New("group name is reserved"))
+	}
+
+	var current *datastore.ProxyConfig
+	var currentMetaEvent *datastore.MetaEventConfiguration
+	if group.Config != nil {
+		current = group.Config.OutboundProxy
+		currentMetaEvent = group.Config.MetaEvent
 	}
 
+	err := encryptProxyPassword(update.Config.OutboundProxy, current)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encryptMetaEventSecret(update.Config.MetaEvent, currentMetaEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	ttlDays := group.EventTTLDays()
+
 	group.Name = update.Name
 	group.Config = &update.Config
 	if !util.IsStringEmpty(update.LogoURL) {
 		group.LogoURL = update.LogoURL
 	}
 
+	if update.Regions != nil {
+		group.Regions = update.Regions
+		group.PrimaryRegion = update.PrimaryRegion
+	}
+
 	err = gs.groupRepo.UpdateGroup(ctx, group)
 	if err != nil {
 		log.WithError(err).Error("failed to to update group")
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating Group"))
 	}
 
+	gs.invalidateGroupCache(ctx, group.UID, group.Name)
+
+	if group.EventTTLDays() != ttlDays {
+		gs.ensureRetentionIndex(ctx, group)
+	}
+
+	return group, nil
+}
+
+// UpdateGroupConfig changes the fields of the config of the group groupID
+// set in patch, the others keep their values.
+func (gs *GroupService) UpdateGroupConfig(ctx context.Context, groupID string, patch *datastore.GroupConfigPatch) (*datastore.Group, error) {
+	group, err := gs.groupRepo.FetchGroupByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrGroupNotFound) {
+			return nil, NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to fetch group")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating Group"))
+	}
+
+	var current datastore.GroupConfig
+	if group.Config != nil {
+		current = *group.Config
+	}
+
+	// the patched config is validated as a whole, a field may only be
+	// valid along with those it is not given with
+	cfg := patch.Apply(current)
+	if errs := gs.ValidateGroupConfig(&models.Group{Name: group.Name, Config: cfg}); len(errs) > 0 {
+		err := newValidationError(errs)
+		log.WithError(err).Error("failed to validate group config update")
+		return nil, err
+	}
+
+	err = encryptProxyPassword(patch.OutboundProxy, current.OutboundProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encryptMetaEventSecret(patch.MetaEvent, current.MetaEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	err = gs.groupRepo.UpdateGroupConfig(ctx, groupID, patch)
+	if err != nil {
+		log.WithError(err).Error("failed to update group config")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating Group"))
+	}
+
+	ttlDays := group.EventTTLDays()
+	group.Config = &cfg
+	gs.invalidateGroupCache(ctx, group.UID, group.Name)
+
+	if group.EventTTLDays() != ttlDays {
+		gs.ensureRetentionIndex(ctx, group)
+	}
+
 	return group, nil
 }
 
 func (gs *GroupService) GetGroups(ctx context.Context, filter *datastore.GroupFilter) ([]*datastore.Group, error) {
-	groups, err := gs.groupRepo.LoadGroups(ctx, filter.WithNamesTrimmed())
+	groups, err := gs.loadGroups(ctx, filter.WithNamesTrimmed())
 	if err != nil {
 		log.WithError(err).Error("failed to load groups")
-		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching Groups"))
+		return nil, datastoreError(err, http.StatusBadRequest, "an error occurred while fetching Groups")
 	}
 
 	for _, group := range groups {
-		err = gs.FillGroupStatistics(ctx, group)
+		err = gs.FillGroupStatistics(ctx, group, false)
 		if err != nil {
 			log.WithError(err).Errorf("failed to fill statistics of group %s", group.UID)
 		}
@@ -108,44 +286,613 @@ func (gs *GroupService) GetGroups(ctx context.Context, filter *datastore.GroupFi
 	return groups, nil
 }
 
-func (gs *GroupService) FillGroupStatistics(ctx context.Context, g *datastore.Group) error {
-	appCount, err := gs.appRepo.CountGroupApplications(ctx, g.UID)
+// loadGroups bounds only the query by the query timeout, each group's
+// statistics are filled in separately afterwards.
+func (gs *GroupService) loadGroups(ctx context.Context, filter *datastore.GroupFilter) ([]*datastore.Group, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	return gs.groupRepo.LoadGroups(ctx, filter)
+}
+
+// GetGroupsPaged loads a page of up to perPage groups from the position of
+// filter.Cursor, or the first page without one.
+func (gs *GroupService) GetGroupsPaged(ctx context.Context, filter *datastore.GroupFilter, perPage int) ([]*datastore.Group, datastore.CursorPaginationData, error) {
+	f := filter.WithNamesTrimmed()
+
+	// an extra group is loaded to tell whether there is a page beyond
+	// this one in the direction of the cursor
+	f.PerPage = perPage + 1
+
+	groups, err := gs.loadGroups(ctx, f)
 	if err != nil {
-		log.WithError(err).Error("failed to count group applications")
-		return NewServiceError(http.StatusBadRequest, errors.New("failed to count group statistics"))
+		log.WithError(err).Error("failed to load groups")
+		return nil, datastore.CursorPaginationData{}, datastoreError(err, http.StatusBadRequest, "an error occurred while fetching Groups")
+	}
+
+	lo, hi, prevCursor, nextCursor := cursorPage(len(groups), perPage, f.Cursor, func(i int) datastore.Cursor {
+		return datastore.Cursor{CreatedAt: groups[i].CreatedAt, UID: groups[i].UID}
+	})
+	groups = groups[lo:hi]
+
+	paginationData := datastore.CursorPaginationData{PerPage: int64(perPage), PrevCursor: prevCursor, NextCursor: nextCursor}
+
+	for _, group := range groups {
+		err = gs.FillGroupStatistics(ctx, group, false)
+		if err != nil {
+			log.WithError(err).Errorf("failed to fill statistics of group %s", group.UID)
+		}
+	}
+
+	return groups, paginationData, nil
+}
+
+// groupAlertFailureRate is the share of deliveries over the last hour
+// that must have failed for a group to be listed as needing attention.
+const groupAlertFailureRate = 0.5
+
+// ListGroupsWithActiveAlerts returns the groups that need attention,
+// those failing most of their deliveries and those with disabled
+// endpoints, each with the reasons it was listed.
+func (gs *GroupService) ListGroupsWithActiveAlerts(ctx context.Context) ([]*datastore.Group, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	failing, err := gs.eventDeliveryRepo.FindGroupsWithHighFailureRate(ctx, groupAlertFailureRate)
+	if err != nil {
+		log.WithError(err).Error("failed to find groups with a high failure rate")
+		return nil, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching groups with alerts")
+	}
+
+	inactive, err := gs.appRepo.FindGroupsWithInactiveEndpoints(ctx)
+	if err != nil {
+		log.WithError(err).Error("failed to find groups with inactive endpoints")
+		return nil, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching groups with alerts")
+	}
+
+	ids := make([]string, 0, len(failing)+len(inactive))
+	reasons := make(map[string][]datastore.GroupAlertReason)
+	addAlert := func(id string, reason datastore.GroupAlertReason) {
+		if _, ok := reasons[id]; !ok {
+			ids = append(ids, id)
+		}
+		reasons[id] = append(reasons[id], reason)
+	}
+
+	for _, id := range failing {
+		addAlert(id, datastore.HighFailureRateAlertReason)
+	}
+
+	for _, id := range inactive {
+		addAlert(id, datastore.InactiveEndpointsAlertReason)
+	}
+
+	if len(ids) == 0 {
+		return []*datastore.Group{}, nil
+	}
+
+	found, err := gs.groupRepo.FetchGroupsByIDs(ctx, ids)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch groups with alerts")
+		return nil, datastoreError(err, http.StatusInternalServerError, "an error occurred while fetching groups with alerts")
+	}
+
+	groups := make([]*datastore.Group, 0, len(found))
+	for i := range found {
+		g := &found[i]
+		g.AlertReasons = reasons[g.UID]
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// FillGroupStatistics computes g's statistics. The per event type breakdown
+// of messages is an extra aggregation over the group's events, so it is
+// only computed when withMessagesByType is set.
+func (gs *GroupService) FillGroupStatistics(ctx context.Context, g *datastore.Group, withMessagesByType bool) error {
+	var appCount, msgCount int64
+
+	// the counts are independent, either failing cancels the other
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		var err error
+		appCount, err = gs.appRepo.CountGroupApplications(egCtx, g.UID)
+		if err != nil {
+			log.WithError(err).Error("failed to count group applications")
+			return NewServiceError(http.StatusBadRequest, errors.New("failed to count group statistics"))
+		}
+		return nil
+	})
+
+	eg.Go(func() error {
+		var err error
+		msgCount, err = gs.eventRepo.CountGroupMessages(egCtx, g.UID)
+		if err != nil {
+			log.WithError(err).Error("failed to count group messages")
+			return NewServiceError(http.StatusBadRequest, errors.New("failed to count group statistics"))
+		}
+		return nil
+	})
+
+	err := eg.Wait()
+	if err != nil {
+		return err
 	}
 
-	msgCount, err := gs.eventRepo.CountGroupMessages(ctx, g.UID)
+	pendingCount, err := gs.eventDeliveryRepo.CountPendingDeliveriesByGroup(ctx, g.UID)
 	if err != nil {
-		log.WithError(err).Error("failed to count group messages")
+		log.WithError(err).Error("failed to count group pending deliveries")
 		return NewServiceError(http.StatusBadRequest, errors.New("failed to count group statistics"))
 	}
 
-	g.Statistics = &datastore.GroupStatistics{
-		MessagesSent: msgCount,
-		TotalApps:    appCount,
+	statistics := &datastore.GroupStatistics{
+		MessagesSent:      msgCount,
+		TotalApps:         appCount,
+		PendingDeliveries: pendingCount,
+	}
+
+	if withMessagesByType {
+		statistics.MessagesByType, err = gs.eventRepo.CountGroupMessagesByType(ctx, g.UID)
+		if err != nil {
+			log.WithError(err).Error("failed to count group messages by type")
+			return NewServiceError(http.StatusBadRequest, errors.New("failed to count group statistics"))
+		}
+	}
+
+	g.Statistics = statistics
+	return nil
+}
+
+// LoadGroupStatistics sets g's statistics from the cache, which
+// StatsRefresher keeps warm. They are only computed here when the cache
+// has none for g, e.g. for a group created since the last refresh.
+// The cache never holds the per event type breakdown, so asking for it
+// with withMessagesByType always computes the statistics afresh.
+func (gs *GroupService) LoadGroupStatistics(ctx context.Context, g *datastore.Group, withMessagesByType bool) error {
+	if withMessagesByType {
+		return gs.FillGroupStatistics(ctx, g, true)
+	}
+
+	var statistics *datastore.GroupStatistics
+	statisticsCacheKey := convoy.GroupStatisticsCacheKey.Get(g.UID).String()
+
+	err := gs.cache.Get(ctx, statisticsCacheKey, &statistics)
+	if err != nil {
+		log.WithError(err).Errorf("failed to load cached statistics of group %s", g.UID)
+	}
+
+	if statistics != nil {
+		g.Statistics = statistics
+		return nil
+	}
+
+	err = gs.FillGroupStatistics(ctx, g, false)
+	if err != nil {
+		return err
+	}
+
+	err = gs.cache.Set(ctx, statisticsCacheKey, g.Statistics, groupStatisticsCacheTTL)
+	if err != nil {
+		log.WithError(err).Errorf("failed to cache statistics of group %s", g.UID)
 	}
+
 	return nil
 }
 
+// UpdateGroupStatus moves group to status. Moves the group's lifecycle
+// doesn't allow are rejected with 409, see GroupStatus.CanTransitionTo.
+// Deleting the group deletes its apps and events and revokes its api
+// keys like DeleteGroup, which can be called again should that fail.
+func (gs *GroupService) UpdateGroupStatus(ctx context.Context, group *datastore.Group, status datastore.GroupStatus) (*datastore.Group, error) {
+	switch status {
+	case datastore.ActiveGroupStatus, datastore.InactiveGroupStatus, datastore.ArchivedGroupStatus, datastore.DeletedGroupStatus:
+	default:
+		return nil, NewServiceError(http.StatusBadRequest, fmt.Errorf("unknown group status %q", status))
+	}
+
+	err := gs.groupRepo.UpdateGroupStatus(ctx, group.UID, status)
+	if err != nil {
+		if errors.Is(err, datastore.ErrIllegalGroupStatusTransition) {
+			return nil, NewServiceError(http.StatusConflict, fmt.Errorf("group can't be moved from %s to %s", groupStatus(group), status))
+		}
+
+		if errors.Is(err, datastore.ErrGroupNotFound) {
+			return nil, NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to update group status")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating group status"))
+	}
+
+	if status == datastore.DeletedGroupStatus {
+		err = gs.DeleteGroup(ctx, group.UID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		gs.invalidateGroupCache(ctx, group.UID, group.Name)
+	}
+
+	updated, err := gs.groupRepo.FetchGroupByID(ctx, group.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch group")
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating group status"))
+	}
+
+	return updated, nil
+}
+
+// groupStatus returns g's status, groups without one are active.
+func groupStatus(g *datastore.Group) datastore.GroupStatus {
+	if g.Status == "" {
+		return datastore.ActiveGroupStatus
+	}
+	return g.Status
+}
+
+// DeleteGroup deletes the group with its apps and events and revokes the
+// api keys scoped to it, see GroupRepository.DeleteGroupCascade.
 func (gs *GroupService) DeleteGroup(ctx context.Context, id string) error {
-	err := gs.groupRepo.DeleteGroup(ctx, id)
+	err := gs.groupRepo.DeleteGroupCascade(ctx, id)
 	if err != nil {
 		log.WithError(err).Error("failed to delete group")
-		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete group"))
+
+		msg := "failed to delete group"
+		var cascadeErr *datastore.GroupCascadeError
+		if errors.As(err, &cascadeErr) {
+			msg = "failed to " + cascadeErr.Step
+		}
+
+		return NewServiceError(http.StatusBadRequest, errors.New(msg))
+	}
+
+	gs.invalidateGroupCache(ctx, id, "", convoy.GroupStatisticsCacheKey.Get(id).String())
+	return nil
+}
+
+// invalidateGroupCache drops the cached copies of the group id along with
+// any other keys derived from it. The default group is also cached under
+// its name, which is passed to drop that copy too. Failures are only
+// logged as the group has already changed, the copies expire regardless.
+func (gs *GroupService) invalidateGroupCache(ctx context.Context, id, name string, keys ...string) {
+	keys = append([]string{convoy.GroupsCacheKey.Get(id).String()}, keys...)
+	if name == defaultGroupName {
+		keys = append(keys, convoy.GroupsCacheKey.Get(name).String())
+	}
+
+	for _, key := range keys {
+		err := gs.cache.Delete(ctx, key)
+		if err != nil {
+			log.WithError(err).Errorf("failed to delete %s from cache", key)
+		}
+	}
+}
+
+// ensureRetentionIndex makes the database expire the events of g after its
+// EventTTLDays. A failure is only logged, the group is already saved
+// and its events are kept until the next successful update.
+func (gs *GroupService) ensureRetentionIndex(ctx context.Context, g *datastore.Group) {
+	err := gs.eventRepo.EnsureRetentionIndex(ctx, g.UID, g.EventTTLDays())
+	if err != nil {
+		log.WithError(err).Errorf("failed to ensure event retention index of group %s", g.UID)
+	}
+}
+
+// ValidationError describes why a field of a request is invalid.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%s:%s", v.Field, v.Message)
+}
+
+// ValidateGroupConfig runs the checks a group must pass before it is
+// created or updated, without touching the repository. It returns nil
+// when g is valid.
+func (gs *GroupService) ValidateGroupConfig(g *models.Group) []ValidationError {
+	var errs []ValidationError
+
+	for field, message := range util.ValidateByField(g) {
+		errs = append(errs, ValidationError{Field: field, Message: message})
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+
+	if err := validateRetryStrategy(g.Config.Strategy); err != nil {
+		errs = append(errs, ValidationError{Field: "strategy", Message: err.Error()})
+	}
+
+	err := validateMetaEventConfig(g.Config.MetaEvent)
+	if err != nil {
+		errs = append(errs, ValidationError{Field: "meta_event", Message: err.Error()})
+	}
+
+	if g.Config.EventRetentionDays < 0 {
+		errs = append(errs, ValidationError{Field: "event_retention_days", Message: "event retention days cannot be negative"})
+	}
+
+	if g.Config.MaxEventPayloadSizeBytes < 0 {
+		errs = append(errs, ValidationError{Field: "max_event_payload_size_bytes", Message: "max event payload size cannot be negative"})
+	}
+
+	if g.Config.MaxEventPayloadSizeBytes > maxGroupEventPayloadSize {
+		errs = append(errs, ValidationError{Field: "max_event_payload_size_bytes", Message: "max event payload size cannot be larger than 5MB"})
+	}
+
+	if !util.IsStringEmpty(g.Config.RetentionPolicy) {
+		retention, err := time.ParseDuration(g.Config.RetentionPolicy)
+		if err != nil || retention <= 0 {
+			errs = append(errs, ValidationError{Field: "retention_policy", Message: "please provide a valid retention policy e.g 720h"})
+		} else if g.Config.EventRetentionDays > 0 && time.Duration(g.Config.EventRetentionDays)*24*time.Hour < retention {
+			// the events would expire before the retention job archives them
+			errs = append(errs, ValidationError{Field: "event_retention_days", Message: "event retention days cannot be shorter than the retention policy"})
+		}
+	}
+
+	if !util.IsStringEmpty(g.Config.DeduplicationWindow) {
+		window, err := time.ParseDuration(g.Config.DeduplicationWindow)
+		if err != nil || window <= 0 {
+			errs = append(errs, ValidationError{Field: "deduplication_window", Message: "please provide a valid deduplication window e.g 5m"})
+		}
+	}
+
+	if !util.IsStringEmpty(g.Config.IdempotencyWindowDuration) {
+		window, err := time.ParseDuration(g.Config.IdempotencyWindowDuration)
+		if err != nil || window <= 0 {
+			errs = append(errs, ValidationError{Field: "idempotency_window_duration", Message: "please provide a valid idempotency window duration e.g 24h"})
+		} else if window < minIdempotencyWindow || window > maxIdempotencyWindow {
+			errs = append(errs, ValidationError{Field: "idempotency_window_duration", Message: "idempotency window duration must be between 1m and 72h"})
+		}
+	}
+
+	err = validateSignatureAlgorithms(g.Config.Signature.Algorithms)
+	if err != nil {
+		errs = append(errs, ValidationError{Field: "algorithms", Message: err.Error()})
+	}
+
+	if g.Config.OutboundProxy != nil {
+		err := validateProxyConfig(g.Config.OutboundProxy)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "outbound_proxy", Message: err.Error()})
+		}
+	}
+
+	err = validateCustomHeaders(g.Config.CustomHeaders)
+	if err != nil {
+		errs = append(errs, ValidationError{Field: "custom_headers", Message: err.Error()})
+	}
+
+	err = validateGroupRegions(g.Regions, g.PrimaryRegion)
+	if err != nil {
+		errs = append(errs, ValidationError{Field: "primary_region", Message: err.Error()})
+	}
+
+	return errs
+}
+
+// validateGroupRegions ensures a group placed in regions names one of
+// them as its primary, writes for the group are sent there.
+func validateGroupRegions(regions []string, primary string) error {
+	if len(regions) == 0 {
+		if !util.IsStringEmpty(primary) {
+			return errors.New("primary region must be one of the group's regions")
+		}
+		return nil
+	}
+
+	seen := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		if util.IsStringEmpty(r) || seen[r] {
+			return fmt.Errorf("regions must be distinct and not empty, got %q", r)
+		}
+		seen[r] = true
+	}
+
+	if !seen[primary] {
+		return errors.New("primary region must be one of the group's regions")
+	}
+
+	return nil
+}
+
+// validateRetryStrategy rejects retry strategies the delivery worker
+// doesn't know or would spin on, an interval of zero seconds retries in
+// a busy loop.
+func validateRetryStrategy(cfg datastore.StrategyConfiguration) error {
+	switch cfg.Type {
+	case config.DefaultStrategyProvider:
+		if cfg.Default.IntervalSeconds < 1 {
+			return errors.New("intervalSeconds must be at least 1")
+		}
+
+		if cfg.Default.RetryLimit < 1 {
+			return errors.New("retryLimit must be at least 1")
+		}
+	case config.ExponentialBackoffStrategyProvider:
+		if cfg.ExponentialBackoff.RetryLimit < 1 {
+			return errors.New("retryLimit must be at least 1")
+		}
+	case config.LinearStrategyProvider:
+		if cfg.Linear.InitialIntervalSeconds < 1 {
+			return errors.New("initialIntervalSeconds must be at least 1")
+		}
+
+		if cfg.Linear.StepSeconds < 1 {
+			return errors.New("stepSeconds must be at least 1")
+		}
+
+		if cfg.Linear.RetryLimit < 1 {
+			return errors.New("retryLimit must be at least 1")
+		}
+	case "":
+		return errors.New("please provide a valid strategy type")
+	default:
+		return errors.New("unsupported strategy type")
+	}
+
+	return nil
+}
+
+func validateSignatureAlgorithms(algorithms []string) error {
+	if len(algorithms) > datastore.MaxSignatureAlgorithms {
+		return fmt.Errorf("at most %d signature algorithms are supported", datastore.MaxSignatureAlgorithms)
+	}
+
+	for _, algorithm := range algorithms {
+		if _, ok := algo.M[algorithm]; !ok {
+			return fmt.Errorf("unsupported signature algorithm %q", algorithm)
+		}
+	}
+
+	return nil
+}
+
+func validateProxyConfig(p *datastore.ProxyConfig) error {
+	u, err := url.Parse(p.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || util.IsStringEmpty(u.Host) {
+		return errors.New("please provide a valid proxy url e.g http://proxy.internal:3128")
+	}
+
+	if u.User != nil {
+		return errors.New("please provide the proxy credentials as username and password, not in the url")
+	}
+
+	if util.IsStringEmpty(p.Username) && !util.IsStringEmpty(p.Password) {
+		return errors.New("please provide the username of the proxy password")
+	}
+
+	for _, host := range p.NoProxyHosts {
+		h := strings.TrimPrefix(host, "*.")
+		if util.IsStringEmpty(h) || strings.Contains(h, "*") {
+			return fmt.Errorf("invalid no proxy host %q, only a leading wildcard like *.internal is supported", host)
+		}
+	}
+
+	return nil
+}
+
+// defaultGroupName is the name of the group requests that don't name one
+// fall back to.
+const defaultGroupName = "default-group"
+
+// reservedGroupNames are the group names convoy keeps for itself, operators
+// can reserve more with the reserved_group_names config.
+var reservedGroupNames = []string{"default", "admin", "convoy"}
+
+// isReservedGroupName reports whether name is a reserved group name,
+// ignoring case and surrounding space. Only exact matches are reserved.
+func isReservedGroupName(name string) bool {
+	names := reservedGroupNames
+	if cfg, err := config.Get(); err == nil {
+		names = append(names[:len(names):len(names)], cfg.Server.ReservedGroupNames...)
+	}
+
+	name = strings.TrimSpace(name)
+	for _, reserved := range names {
+		if strings.EqualFold(name, strings.TrimSpace(reserved)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encryptProxyPassword encrypts the password of p for storage. A password
+// equal to the one in current is already encrypted, as happens when a
+// group's config is sent back unchanged, so it is kept as is.
+func encryptProxyPassword(p, current *datastore.ProxyConfig) error {
+	if p == nil || util.IsStringEmpty(p.Password) {
+		return nil
+	}
+
+	if current != nil && p.Password == current.Password {
+		return nil
+	}
+
+	password, err := encryptGroupSecret(p.Password, "proxy password")
+	if err != nil {
+		return err
+	}
+
+	p.Password = password
+	return nil
+}
+
+// encryptMetaEventSecret encrypts the secret of m for storage. Responses
+// carry datastore.MaskedSecret in its place, so a config sent back with it,
+// or with the encrypted secret of current, keeps the secret of current.
+func encryptMetaEventSecret(m, current *datastore.MetaEventConfiguration) error {
+	if m == nil || util.IsStringEmpty(m.Secret) {
+		return nil
+	}
+
+	if current != nil && (m.Secret == datastore.MaskedSecret || m.Secret == current.Secret) {
+		m.Secret = current.Secret
+		return nil
+	}
+
+	if m.Secret == datastore.MaskedSecret {
+		return NewServiceError(http.StatusBadRequest, errors.New("please provide a meta event secret"))
+	}
+
+	secret, err := encryptGroupSecret(m.Secret, "meta event secret")
+	if err != nil {
+		return err
 	}
 
-	// TODO(daniel,subomi): is returning http error necessary for these? since the group itself has been deleted
-	err = gs.appRepo.DeleteGroupApps(ctx, id)
+	m.Secret = secret
+	return nil
+}
+
+// encryptGroupSecret encrypts secret with the configured encryption key,
+// errors name the secret as what.
+func encryptGroupSecret(secret, what string) (string, error) {
+	cfg, err := config.Get()
 	if err != nil {
-		log.WithError(err).Error("failed to delete group apps")
-		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete group apps"))
+		log.WithError(err).Error("failed to load config")
+		return "", NewServiceError(http.StatusBadRequest, fmt.Errorf("failed to encrypt %s", what))
 	}
 
-	err = gs.eventRepo.DeleteGroupEvents(ctx, id)
+	if util.IsStringEmpty(cfg.EncryptionKey) {
+		return "", NewServiceError(http.StatusBadRequest, fmt.Errorf("an encryption key must be configured to store a %s", what))
+	}
+
+	encrypted, err := util.Encrypt(secret, cfg.EncryptionKey)
 	if err != nil {
-		log.WithError(err).Error("failed to delete group events")
-		return NewServiceError(http.StatusBadRequest, errors.New("failed to delete group events"))
+		log.WithError(err).Errorf("failed to encrypt %s", what)
+		return "", NewServiceError(http.StatusBadRequest, fmt.Errorf("failed to encrypt %s", what))
+	}
+
+	return encrypted, nil
+}
+
+func joinValidationErrors(errs []ValidationError) error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+
+	return errors.New(strings.Join(messages, ", "))
+}
+
+func validateMetaEventConfig(cfg *datastore.MetaEventConfiguration) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if len(cfg.EventTypes) == 0 {
+		return errors.New("please provide at least one meta event type")
+	}
+
+	for _, t := range cfg.EventTypes {
+		if !t.IsValid() {
+			return fmt.Errorf("unsupported meta event type: %s", t)
+		}
 	}
 
 	return nil
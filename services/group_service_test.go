@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	nooplimiter "github.com/frain-dev/convoy/limiter/noop"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-redis/redis_rate/v9"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +24,9 @@ func provideGroupService(ctrl *gomock.Controller) *GroupService {
 	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	eventRepo := mocks.NewMockEventRepository(ctrl)
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
-	return NewGroupService(appRepo, groupRepo, eventRepo, eventDeliveryRepo, nooplimiter.NewNoopLimiter())
+	settingsRepo := mocks.NewMockGlobalSettingsRepository(ctrl)
+	cache := mocks.NewMockCache(ctrl)
+	return NewGroupService(appRepo, groupRepo, eventRepo, eventDeliveryRepo, settingsRepo, nooplimiter.NewNoopLimiter(), cache)
 }
 
 func TestGroupService_CreateGroup(t *testing.T) {
@@ -65,6 +72,9 @@ func TestGroupService_CreateGroup(t *testing.T) {
 			},
 			dbFn: func(gs *GroupService) {
 				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
 				a.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).
 					Times(1).Return(nil)
 			},
@@ -74,6 +84,7 @@ func TestGroupService_CreateGroup(t *testing.T) {
 				RateLimit:         1000,
 				RateLimitDuration: "1m",
 				Config: &datastore.GroupConfig{
+					IdempotencyWindowDuration: datastore.DefaultIdempotencyWindowDuration,
 					Signature: datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
 						Hash:   "SHA256",
@@ -118,6 +129,9 @@ func TestGroupService_CreateGroup(t *testing.T) {
 			},
 			dbFn: func(gs *GroupService) {
 				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
 				a.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).
 					Times(1).Return(nil)
 			},
@@ -127,6 +141,7 @@ func TestGroupService_CreateGroup(t *testing.T) {
 				RateLimit:         5000,
 				RateLimitDuration: "1m",
 				Config: &datastore.GroupConfig{
+					IdempotencyWindowDuration: datastore.DefaultIdempotencyWindowDuration,
 					Signature: datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
 						Hash:   "SHA256",
@@ -171,6 +186,9 @@ func TestGroupService_CreateGroup(t *testing.T) {
 			},
 			dbFn: func(gs *GroupService) {
 				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
 				a.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).
 					Times(1).Return(errors.New("failed"))
 			},
@@ -178,62 +196,11 @@ func TestGroupService_CreateGroup(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "failed to create group",
 		},
-	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			gs := provideGroupService(ctrl)
-
-			// Arrange Expectations
-			if tc.dbFn != nil {
-				tc.dbFn(gs)
-			}
-
-			group, err := gs.CreateGroup(tc.args.ctx, tc.args.newGroup)
-			if tc.wantErr {
-				require.NotNil(t, err)
-				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
-				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
-				return
-			}
-
-			require.Nil(t, err)
-			require.NotEmpty(t, group.UID)
-			require.NotEmpty(t, group.ID)
-			require.NotEmpty(t, group.CreatedAt)
-			require.NotEmpty(t, group.UpdatedAt)
-			require.Empty(t, group.DeletedAt)
-
-			stripVariableFields(t, "group", group)
-			require.Equal(t, tc.wantGroup, group)
-		})
-	}
-}
-
-func TestGroupService_UpdateGroup(t *testing.T) {
-	ctx := context.Background()
-
-	type args struct {
-		ctx    context.Context
-		group  *datastore.Group
-		update *models.Group
-	}
-	tests := []struct {
-		name        string
-		args        args
-		wantErr     bool
-		wantGroup   *datastore.Group
-		dbFn        func(gs *GroupService)
-		wantErrCode int
-		wantErrMsg  string
-	}{
 		{
-			name: "should_update_group",
+			name: "should_error_for_invalid_meta_event_url",
 			args: args{
-				ctx:   ctx,
-				group: &datastore.Group{UID: "12345"},
-				update: &models.Group{
+				ctx: ctx,
+				newGroup: &models.Group{
 					Name:    "test_group",
 					LogoURL: "https://google.com",
 					Config: datastore.GroupConfig{
@@ -248,43 +215,55 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 								RetryLimit:      4,
 							},
 						},
-						DisableEndpoint: true,
-						ReplayAttacks:   true,
+						MetaEvent: &datastore.MetaEventConfiguration{
+							URL:        "not-a-url",
+							Secret:     "meta-secret",
+							EventTypes: []datastore.MetaEventType{datastore.DeliverySuccessMetaEvent},
+						},
 					},
 				},
 			},
-			wantGroup: &datastore.Group{
-				UID:     "12345",
-				Name:    "test_group",
-				LogoURL: "https://google.com",
-				Config: &datastore.GroupConfig{
-					Signature: datastore.SignatureConfiguration{
-						Header: "X-Convoy-Signature",
-						Hash:   "SHA256",
-					},
-					Strategy: datastore.StrategyConfiguration{
-						Type: "default",
-						Default: datastore.DefaultStrategyConfiguration{
-							IntervalSeconds: 20,
-							RetryLimit:      4,
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "url:please provide a valid meta event url",
+		},
+		{
+			name: "should_error_for_unsupported_meta_event_type",
+			args: args{
+				ctx: ctx,
+				newGroup: &models.Group{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      4,
+							},
+						},
+						MetaEvent: &datastore.MetaEventConfiguration{
+							URL:        "https://example.com/meta",
+							Secret:     "meta-secret",
+							EventTypes: []datastore.MetaEventType{"eventdelivery.retried"},
 						},
 					},
-					DisableEndpoint: true,
-					ReplayAttacks:   true,
 				},
 			},
-			dbFn: func(gs *GroupService) {
-				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
-			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "meta_event:unsupported meta event type: eventdelivery.retried",
 		},
 		{
-			name: "should_error_for_empty_name",
+			name: "should_error_for_empty_meta_event_types",
 			args: args{
-				ctx:   ctx,
-				group: &datastore.Group{UID: "12345"},
-				update: &models.Group{
-					Name:    "",
+				ctx: ctx,
+				newGroup: &models.Group{
+					Name:    "test_group",
 					LogoURL: "https://google.com",
 					Config: datastore.GroupConfig{
 						Signature: datastore.SignatureConfiguration{
@@ -298,23 +277,79 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 								RetryLimit:      4,
 							},
 						},
-						DisableEndpoint: true,
-						ReplayAttacks:   true,
+						MetaEvent: &datastore.MetaEventConfiguration{
+							URL:        "https://example.com/meta",
+							Secret:     "meta-secret",
+							EventTypes: []datastore.MetaEventType{},
+						},
 					},
 				},
 			},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "name:please provide a valid name",
+			wantErrMsg:  "meta_event:please provide at least one meta event type",
 		},
 		{
-			name: "should_fail_to_update_group",
+			name: "should_error_for_zero_interval_seconds",
 			args: args{
-				ctx:   ctx,
-				group: &datastore.Group{UID: "12345"},
-				update: &models.Group{
+				ctx: ctx,
+				newGroup: &models.Group{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 0,
+								RetryLimit:      4,
+							},
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "strategy:intervalSeconds must be at least 1",
+		},
+		{
+			name: "should_error_for_zero_retry_limit",
+			args: args{
+				ctx: ctx,
+				newGroup: &models.Group{
 					Name:    "test_group",
 					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      0,
+							},
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "strategy:retryLimit must be at least 1",
+		},
+		{
+			name: "should_error_for_primary_region_outside_regions",
+			args: args{
+				ctx: ctx,
+				newGroup: &models.Group{
+					Name:          "test_group",
+					LogoURL:       "https://google.com",
+					Regions:       []string{"us-east", "eu-west"},
+					PrimaryRegion: "ap-south",
 					Config: datastore.GroupConfig{
 						Signature: datastore.SignatureConfiguration{
 							Header: "X-Convoy-Signature",
@@ -327,18 +362,64 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 								RetryLimit:      4,
 							},
 						},
-						DisableEndpoint: true,
-						ReplayAttacks:   true,
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "primary_region:primary region must be one of the group's regions",
+		},
+		{
+			name: "should_create_group_with_minimum_retry_strategy",
+			args: args{
+				ctx: ctx,
+				newGroup: &models.Group{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 1,
+								RetryLimit:      1,
+							},
+						},
 					},
 				},
 			},
 			dbFn: func(gs *GroupService) {
 				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+				st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+				a.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+			},
+			wantGroup: &datastore.Group{
+				Name:              "test_group",
+				LogoURL:           "https://google.com",
+				RateLimit:         5000,
+				RateLimitDuration: "1m",
+				Config: &datastore.GroupConfig{
+					IdempotencyWindowDuration: datastore.DefaultIdempotencyWindowDuration,
+					Signature: datastore.SignatureConfiguration{
+						Header: "X-Convoy-Signature",
+						Hash:   "SHA256",
+					},
+					Strategy: datastore.StrategyConfiguration{
+						Type: "default",
+						Default: datastore.DefaultStrategyConfiguration{
+							IntervalSeconds: 1,
+							RetryLimit:      1,
+						},
+					},
+				},
+				DocumentStatus: datastore.ActiveDocumentStatus,
 			},
-			wantErr:     true,
-			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "an error occurred while updating Group",
 		},
 	}
 	for _, tc := range tests {
@@ -352,7 +433,7 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 				tc.dbFn(gs)
 			}
 
-			group, err := gs.UpdateGroup(tc.args.ctx, tc.args.group, tc.args.update)
+			group, err := gs.CreateGroup(tc.args.ctx, tc.args.newGroup)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -361,163 +442,1599 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 			}
 
 			require.Nil(t, err)
-			c1 := tc.wantGroup.Config
-			c2 := group.Config
+			require.NotEmpty(t, group.UID)
+			require.NotEmpty(t, group.ID)
+			require.NotEmpty(t, group.CreatedAt)
+			require.NotEmpty(t, group.UpdatedAt)
+			require.Empty(t, group.DeletedAt)
 
-			tc.wantGroup.Config = nil
-			group.Config = nil
+			stripVariableFields(t, "group", group)
 			require.Equal(t, tc.wantGroup, group)
-			require.Equal(t, c1, c2)
 		})
 	}
 }
 
-func TestGroupService_GetGroups(t *testing.T) {
+func TestGroupService_EncryptsProxyPassword(t *testing.T) {
 	ctx := context.Background()
-	type args struct {
-		ctx    context.Context
-		filter *datastore.GroupFilter
-	}
-	tests := []struct {
-		name        string
-		args        args
-		wantErr     bool
-		wantGroups  []*datastore.Group
-		dbFn        func(gs *GroupService)
-		wantErrCode int
-		wantErrMsg  string
-	}{
-		{
-			name: "should_get_groups",
-			args: args{
-				ctx:    ctx,
-				filter: &datastore.GroupFilter{Names: []string{"default_group"}},
-			},
-			dbFn: func(gs *GroupService) {
-				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
-					Times(1).Return([]*datastore.Group{
-					{UID: "123"},
-					{UID: "abc"},
-				}, nil)
 
+	newGroup := func() *models.Group {
+		return &models.Group{
+			Name: "test_group",
+			Config: datastore.GroupConfig{
+				Strategy: datastore.StrategyConfiguration{
+					Type:    "default",
+					Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+				},
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+				OutboundProxy: &datastore.ProxyConfig{
+					URL:      "http://proxy.internal:3128",
+					Username: "convoy",
+					Password: "s3cret",
+				},
+			},
+		}
+	}
+
+	t.Run("should_require_an_encryption_key", func(t *testing.T) {
+		setEnv(t, "CONVOY_ENCRYPTION_KEY", "")
+		require.NoError(t, config.LoadConfig(""))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		_, err := gs.CreateGroup(ctx, newGroup())
+		require.NotNil(t, err)
+		require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+		require.Equal(t, "an encryption key must be configured to store a proxy password", err.(*ServiceError).Error())
+	})
+
+	t.Run("should_store_the_password_encrypted", func(t *testing.T) {
+		setEnv(t, "CONVOY_ENCRYPTION_KEY", "test-encryption-key")
+		require.NoError(t, config.LoadConfig(""))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+		st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+		st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+		g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		g.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		c, _ := gs.cache.(*mocks.MockCache)
+		c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		group, err := gs.CreateGroup(ctx, newGroup())
+		require.Nil(t, err)
+
+		encrypted := group.Config.OutboundProxy.Password
+		require.NotEqual(t, "s3cret", encrypted)
+
+		password, err := util.Decrypt(encrypted, "test-encryption-key")
+		require.NoError(t, err)
+		require.Equal(t, "s3cret", password)
+
+		// sending the stored config back must not encrypt the password twice
+		update := newGroup()
+		update.Config.OutboundProxy.Password = encrypted
+
+		group, err = gs.UpdateGroup(ctx, group, update)
+		require.Nil(t, err)
+		require.Equal(t, encrypted, group.Config.OutboundProxy.Password)
+	})
+}
+
+func TestGroupService_EncryptsMetaEventSecret(t *testing.T) {
+	ctx := context.Background()
+	setEnv(t, "CONVOY_ENCRYPTION_KEY", "test-encryption-key")
+	require.NoError(t, config.LoadConfig(""))
+
+	newGroup := func(secret string) *models.Group {
+		return &models.Group{
+			Name: "test_group",
+			Config: datastore.GroupConfig{
+				Strategy: datastore.StrategyConfiguration{
+					Type:    "default",
+					Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+				},
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+				MetaEvent: &datastore.MetaEventConfiguration{
+					URL:        "https://example.com/meta",
+					Secret:     secret,
+					EventTypes: []datastore.MetaEventType{datastore.DeliverySuccessMetaEvent},
+				},
+			},
+		}
+	}
+
+	t.Run("should_reject_the_mask_without_a_stored_secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		_, err := gs.CreateGroup(ctx, newGroup(datastore.MaskedSecret))
+		require.NotNil(t, err)
+		require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+		require.Equal(t, "please provide a meta event secret", err.(*ServiceError).Error())
+	})
+
+	t.Run("should_store_the_secret_encrypted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+		st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+		st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+		g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		g.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+		c, _ := gs.cache.(*mocks.MockCache)
+		c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+		group, err := gs.CreateGroup(ctx, newGroup("meta-secret"))
+		require.Nil(t, err)
+
+		encrypted := group.Config.MetaEvent.Secret
+		secret, err := util.Decrypt(encrypted, "test-encryption-key")
+		require.NoError(t, err)
+		require.Equal(t, "meta-secret", secret)
+
+		// a config sent back with the masked secret keeps the stored one
+		group, err = gs.UpdateGroup(ctx, group, newGroup(datastore.MaskedSecret))
+		require.Nil(t, err)
+		require.Equal(t, encrypted, group.Config.MetaEvent.Secret)
+
+		group, err = gs.UpdateGroup(ctx, group, newGroup("rotated-secret"))
+		require.Nil(t, err)
+
+		secret, err = util.Decrypt(group.Config.MetaEvent.Secret, "test-encryption-key")
+		require.NoError(t, err)
+		require.Equal(t, "rotated-secret", secret)
+	})
+}
+
+func TestGroupService_RejectsReservedGroupNames(t *testing.T) {
+	ctx := context.Background()
+	setEnv(t, "CONVOY_RESERVED_GROUP_NAMES", "billing,internal")
+	require.NoError(t, config.LoadConfig(""))
+
+	newGroup := func(name string) *models.Group {
+		return &models.Group{
+			Name: name,
+			Config: datastore.GroupConfig{
+				Strategy: datastore.StrategyConfiguration{
+					Type:    "default",
+					Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+				},
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		reserved bool
+	}{
+		{name: "default", reserved: true},
+		{name: "admin", reserved: true},
+		{name: "convoy", reserved: true},
+		{name: " Admin ", reserved: true},
+		{name: "billing", reserved: true},
+		{name: "internal", reserved: true},
+		{name: "admin-team", reserved: false},
+		{name: "convoy-payments", reserved: false},
+		{name: "defaults", reserved: false},
+		{name: "billing_v2", reserved: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			if !tc.reserved {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+				g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			_, err := gs.CreateGroup(ctx, newGroup(tc.name))
+			if !tc.reserved {
+				require.Nil(t, err)
+				return
+			}
+
+			require.NotNil(t, err)
+			require.Equal(t, http.StatusConflict, err.(*ServiceError).ErrCode())
+			require.Equal(t, "group name is reserved", err.(*ServiceError).Error())
+		})
+	}
+
+	t.Run("should_not_rename_a_group_to_a_reserved_name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		_, err := gs.UpdateGroup(ctx, &datastore.Group{UID: "12345", Name: "payments"}, newGroup("convoy"))
+		require.NotNil(t, err)
+		require.Equal(t, http.StatusConflict, err.(*ServiceError).ErrCode())
+		require.Equal(t, "group name is reserved", err.(*ServiceError).Error())
+	})
+
+	t.Run("should_update_a_group_that_already_has_a_reserved_name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+		g.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		c, _ := gs.cache.(*mocks.MockCache)
+		c.EXPECT().Delete(gomock.Any(), "groups:12345").Times(1).Return(nil)
+
+		_, err := gs.UpdateGroup(ctx, &datastore.Group{UID: "12345", Name: "billing"}, newGroup("billing"))
+		require.Nil(t, err)
+	})
+}
+
+func TestGroupService_ValidateGroupConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    *models.Group
+		wantErrs []ValidationError
+	}{
+		{
+			name: "should_accept_valid_group",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+				},
+			},
+		},
+		{
+			name: "should_report_every_invalid_field",
+			group: &models.Group{
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "unsupported",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					MetaEvent: &datastore.MetaEventConfiguration{URL: "https://example.com", Secret: "secret"},
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "name", Message: "please provide a valid name"},
+				{Field: "strategy", Message: "unsupported strategy type"},
+				{Field: "meta_event", Message: "please provide at least one meta event type"},
+			},
+		},
+		{
+			name: "should_reject_negative_max_event_payload_size",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:                datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					MaxEventPayloadSizeBytes: -1,
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "max_event_payload_size_bytes", Message: "max event payload size cannot be negative"},
+			},
+		},
+		{
+			name: "should_reject_max_event_payload_size_over_5mb",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:                datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					MaxEventPayloadSizeBytes: 5*1024*1024 + 1,
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "max_event_payload_size_bytes", Message: "max event payload size cannot be larger than 5MB"},
+			},
+		},
+		{
+			name: "should_reject_invalid_retention_policy",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:       datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					RetentionPolicy: "30 days",
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "retention_policy", Message: "please provide a valid retention policy e.g 720h"},
+			},
+		},
+		{
+			name: "should_reject_invalid_deduplication_window",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:           datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					DeduplicationWindow: "-5m",
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "deduplication_window", Message: "please provide a valid deduplication window e.g 5m"},
+			},
+		},
+		{
+			name: "should_reject_too_short_idempotency_window_duration",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:                 datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					IdempotencyWindowDuration: "30s",
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "idempotency_window_duration", Message: "idempotency window duration must be between 1m and 72h"},
+			},
+		},
+		{
+			name: "should_reject_too_long_idempotency_window_duration",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:                 datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					IdempotencyWindowDuration: "73h",
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "idempotency_window_duration", Message: "idempotency window duration must be between 1m and 72h"},
+			},
+		},
+		{
+			name: "should_reject_invalid_proxy_url",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:     datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					OutboundProxy: &datastore.ProxyConfig{URL: "socks5://proxy.internal:1080"},
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "outbound_proxy", Message: "please provide a valid proxy url e.g http://proxy.internal:3128"},
+			},
+		},
+		{
+			name: "should_reject_inner_wildcard_in_no_proxy_hosts",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					OutboundProxy: &datastore.ProxyConfig{
+						URL:          "http://proxy.internal:3128",
+						NoProxyHosts: []string{"*.internal", "api.*.internal"},
+					},
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "outbound_proxy", Message: `invalid no proxy host "api.*.internal", only a leading wildcard like *.internal is supported`},
+			},
+		},
+		{
+			name: "should_reject_unsupported_signature_algorithms",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA256", Algorithms: []string{"SHA256", "SHA999"}},
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "algorithms", Message: `unsupported signature algorithm "SHA999"`},
+			},
+		},
+		{
+			name: "should_reject_more_than_two_signature_algorithms",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA256", Algorithms: []string{"SHA256", "SHA512", "SHA384"}},
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "algorithms", Message: "at most 2 signature algorithms are supported"},
+			},
+		},
+		{
+			name: "should_reject_negative_event_retention_days",
+			group: &models.Group{
+				Name: "test_group",
+				Config: datastore.GroupConfig{
+					Strategy: datastore.StrategyConfiguration{
+						Type:    "default",
+						Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+					},
+					Signature:          datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+					EventRetentionDays: -1,
+				},
+			},
+			wantErrs: []ValidationError{
+				{Field: "event_retention_days", Message: "event retention days cannot be negative"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// no repository expectations are set, validating must not persist anything
+			gs := provideGroupService(ctrl)
+
+			errs := gs.ValidateGroupConfig(tc.group)
+			require.Equal(t, tc.wantErrs, errs)
+		})
+	}
+}
+
+func TestGroupService_CreateGroup_UsesGlobalDefaults(t *testing.T) {
+	groupConfig := func(retentionDays int) datastore.GroupConfig {
+		return datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			Signature:          datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			EventRetentionDays: retentionDays,
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		newGroup              *models.Group
+		wantRateLimit         int
+		wantRateLimitDuration string
+		wantRetentionDays     int
+	}{
+		{
+			name:                  "should_inherit_global_defaults",
+			newGroup:              &models.Group{Name: "test_group", Config: groupConfig(0)},
+			wantRateLimit:         200,
+			wantRateLimitDuration: "10m",
+			wantRetentionDays:     30,
+		},
+		{
+			name:                  "should_keep_values_set_at_creation",
+			newGroup:              &models.Group{Name: "test_group", RateLimit: 1000, RateLimitDuration: "1m", Config: groupConfig(7)},
+			wantRateLimit:         1000,
+			wantRateLimitDuration: "1m",
+			wantRetentionDays:     7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+			st.EXPECT().Get(gomock.Any()).Times(1).Return(&datastore.GlobalSettings{
+				RateLimit:          200,
+				RateLimitDuration:  "10m",
+				EventRetentionDays: 30,
+			}, nil)
+
+			g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+			e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+			e.EXPECT().EnsureRetentionIndex(gomock.Any(), gomock.Any(), tc.wantRetentionDays).Times(1).Return(nil)
+
+			group, err := gs.CreateGroup(context.Background(), tc.newGroup)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantRateLimit, group.RateLimit)
+			require.Equal(t, tc.wantRateLimitDuration, group.RateLimitDuration)
+			require.Equal(t, tc.wantRetentionDays, group.Config.EventRetentionDays)
+		})
+	}
+}
+
+func TestGroupService_RetentionPolicyWithEventRetentionDays(t *testing.T) {
+	groupConfig := func(retentionDays int, retentionPolicy string) datastore.GroupConfig {
+		return datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			Signature:          datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			EventRetentionDays: retentionDays,
+			RetentionPolicy:    retentionPolicy,
+		}
+	}
+
+	t.Run("should_reject_retention_days_shorter_than_the_policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		_, err := gs.CreateGroup(context.Background(), &models.Group{Name: "test_group", Config: groupConfig(7, "720h")})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+		require.Contains(t, err.Error(), "event retention days cannot be shorter than the retention policy")
+	})
+
+	t.Run("should_not_expire_events_of_groups_with_a_policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		// the global retention isn't applied to a group with a policy
+		st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+		st.EXPECT().Get(gomock.Any()).Times(1).Return(&datastore.GlobalSettings{EventRetentionDays: 7}, nil)
+
+		g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+		g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+		e.EXPECT().EnsureRetentionIndex(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		group, err := gs.CreateGroup(context.Background(), &models.Group{Name: "test_group", Config: groupConfig(0, "720h")})
+		require.NoError(t, err)
+		require.Equal(t, 0, group.Config.EventRetentionDays)
+		require.Zero(t, group.EventExpiry(time.Now()))
+	})
+
+	t.Run("should_stop_expiring_events_once_a_policy_is_set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		gs := provideGroupService(ctrl)
+
+		group := &datastore.Group{UID: "12345", Name: "test_group", Config: &datastore.GroupConfig{EventRetentionDays: 60}}
+
+		g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+		g.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		c, _ := gs.cache.(*mocks.MockCache)
+		c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		// the expiry set on the group's events is removed
+		e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+		e.EXPECT().EnsureRetentionIndex(gomock.Any(), "12345", 0).Times(1).Return(nil)
+
+		updated, err := gs.UpdateGroup(context.Background(), group, &models.Group{Name: "test_group", Config: groupConfig(60, "720h")})
+		require.NoError(t, err)
+		require.Zero(t, updated.EventExpiry(time.Now()))
+	})
+}
+
+func TestGroupService_EnsuresEventRetentionIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	groupConfig := func(retentionDays int) datastore.GroupConfig {
+		return datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			Signature:          datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			EventRetentionDays: retentionDays,
+		}
+	}
+
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	st, _ := gs.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+	st.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+	g.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	g.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+
+	e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+
+	// a group created with a retention gets its index straight away
+	e.EXPECT().EnsureRetentionIndex(gomock.Any(), gomock.Any(), 7).Times(1).Return(nil)
+	group, err := gs.CreateGroup(context.Background(), &models.Group{Name: "test_group", Config: groupConfig(7)})
+	require.NoError(t, err)
+
+	// updates that leave the retention alone don't touch the index
+	_, err = gs.UpdateGroup(context.Background(), group, &models.Group{Name: "test_group", Config: groupConfig(7)})
+	require.NoError(t, err)
+
+	// a failure to update the index doesn't fail the update
+	e.EXPECT().EnsureRetentionIndex(gomock.Any(), group.UID, 30).Times(1).Return(errors.New("failed"))
+	_, err = gs.UpdateGroup(context.Background(), group, &models.Group{Name: "test_group", Config: groupConfig(30)})
+	require.NoError(t, err)
+
+	e.EXPECT().EnsureRetentionIndex(gomock.Any(), group.UID, 0).Times(1).Return(nil)
+	_, err = gs.UpdateGroup(context.Background(), group, &models.Group{Name: "test_group", Config: groupConfig(0)})
+	require.NoError(t, err)
+}
+
+func TestGroupService_UpdateGroupConfig_UpdatesOnlyProvidedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	group := &datastore.Group{
+		UID:  "group-1",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			Signature:           datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+			DisableEndpoint:     true,
+			RetryBudget:         &datastore.RetryBudgetConfig{MaxAttemptsPerHour: 100},
+			DeduplicationWindow: "5m",
+			CustomHeaders:       map[string]string{"X-Tenant": "acme"},
+		},
+	}
+
+	replayAttacks := true
+	threshold := 1024
+	patch := &datastore.GroupConfigPatch{
+		Signature:              &datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"},
+		ReplayAttacks:          &replayAttacks,
+		CompressThresholdBytes: &threshold,
+	}
+
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Times(1).Return(group, nil)
+	g.EXPECT().UpdateGroupConfig(gomock.Any(), "group-1", patch).Times(1).Return(nil)
+
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	updated, err := gs.UpdateGroupConfig(context.Background(), "group-1", patch)
+	require.NoError(t, err)
+
+	require.Equal(t, &datastore.GroupConfig{
+		Strategy: datastore.StrategyConfiguration{
+			Type:    "default",
+			Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+		},
+		Signature:              datastore.SignatureConfiguration{Header: "X-Acme-Signature", Hash: "SHA256"},
+		DisableEndpoint:        true,
+		ReplayAttacks:          true,
+		RetryBudget:            &datastore.RetryBudgetConfig{MaxAttemptsPerHour: 100},
+		CompressThresholdBytes: 1024,
+		DeduplicationWindow:    "5m",
+		CustomHeaders:          map[string]string{"X-Tenant": "acme"},
+	}, updated.Config)
+}
+
+func TestGroupService_UpdateGroupConfig_Fails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	group := &datastore.Group{
+		UID:  "group-1",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    "default",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA512"},
+		},
+	}
+
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupByID(gomock.Any(), "group-2").Times(1).Return(nil, datastore.ErrGroupNotFound)
+
+	_, err := gs.UpdateGroupConfig(context.Background(), "group-2", &datastore.GroupConfigPatch{})
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, err.(*ServiceError).ErrCode())
+
+	// the patched config must be valid as a whole
+	g.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Times(1).Return(group, nil)
+
+	retentionDays := -1
+	_, err = gs.UpdateGroupConfig(context.Background(), "group-1", &datastore.GroupConfigPatch{EventRetentionDays: &retentionDays})
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+	require.Contains(t, err.Error(), "event retention days cannot be negative")
+	require.Equal(t, 0, group.Config.EventRetentionDays)
+}
+
+func TestGroupService_UpdateGroup(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		group  *datastore.Group
+		update *models.Group
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantGroup   *datastore.Group
+		dbFn        func(gs *GroupService)
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_update_group",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.Group{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      4,
+							},
+						},
+						DisableEndpoint: true,
+						ReplayAttacks:   true,
+					},
+				},
+			},
+			wantGroup: &datastore.Group{
+				UID:     "12345",
+				Name:    "test_group",
+				LogoURL: "https://google.com",
+				Config: &datastore.GroupConfig{
+					Signature: datastore.SignatureConfiguration{
+						Header: "X-Convoy-Signature",
+						Hash:   "SHA256",
+					},
+					Strategy: datastore.StrategyConfiguration{
+						Type: "default",
+						Default: datastore.DefaultStrategyConfiguration{
+							IntervalSeconds: 20,
+							RetryLimit:      4,
+						},
+					},
+					DisableEndpoint: true,
+					ReplayAttacks:   true,
+				},
+			},
+			dbFn: func(gs *GroupService) {
+				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := gs.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "groups:12345").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_error_for_empty_name",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.Group{
+					Name:    "",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      4,
+							},
+						},
+						DisableEndpoint: true,
+						ReplayAttacks:   true,
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "name:please provide a valid name",
+		},
+		{
+			name: "should_fail_to_update_group",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.Group{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: "default",
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      4,
+							},
+						},
+						DisableEndpoint: true,
+						ReplayAttacks:   true,
+					},
+				},
+			},
+			dbFn: func(gs *GroupService) {
+				a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "an error occurred while updating Group",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(gs)
+			}
+
+			group, err := gs.UpdateGroup(tc.args.ctx, tc.args.group, tc.args.update)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			c1 := tc.wantGroup.Config
+			c2 := group.Config
+
+			tc.wantGroup.Config = nil
+			group.Config = nil
+			require.Equal(t, tc.wantGroup, group)
+			require.Equal(t, c1, c2)
+		})
+	}
+}
+
+func TestGroupService_GetGroups(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		ctx    context.Context
+		filter *datastore.GroupFilter
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantGroups  []*datastore.Group
+		dbFn        func(gs *GroupService)
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_get_groups",
+			args: args{
+				ctx:    ctx,
+				filter: &datastore.GroupFilter{Names: []string{"default_group"}},
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
+					Times(1).Return([]*datastore.Group{
+					{UID: "123"},
+					{UID: "abc"},
+				}, nil)
+
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), gomock.Any()).Times(2).Return(int64(0), nil)
+			},
+			wantGroups: []*datastore.Group{
+				{
+					UID: "123",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+				{
+					UID: "abc",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_get_groups_trims-whitespaces-from-query",
+			args: args{
+				ctx:    ctx,
+				filter: &datastore.GroupFilter{Names: []string{" default_group "}},
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
+					Times(1).Return([]*datastore.Group{
+					{UID: "123"},
+					{UID: "abc"},
+				}, nil)
+
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), gomock.Any()).Times(2).Return(int64(0), nil)
+			},
+			wantGroups: []*datastore.Group{
+				{
+					UID: "123",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+				{
+					UID: "abc",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_get_groups_trims-whitespaces-from-query-retains-case",
+			args: args{
+				ctx:    ctx,
+				filter: &datastore.GroupFilter{Names: []string{"  deFault_Group"}},
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"deFault_Group"}}).
+					Times(1).Return([]*datastore.Group{
+					{UID: "123"},
+					{UID: "abc"},
+				}, nil)
+
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), gomock.Any()).Times(2).Return(int64(0), nil)
+			},
+			wantGroups: []*datastore.Group{
+				{
+					UID: "123",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+				{
+					UID: "abc",
+					Statistics: &datastore.GroupStatistics{
+						MessagesSent: 1,
+						TotalApps:    1,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_fail_to_get_groups",
+			args: args{
+				ctx:    ctx,
+				filter: &datastore.GroupFilter{Names: []string{"default_group"}},
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "an error occurred while fetching Groups",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(gs)
+			}
+
+			group, err := gs.GetGroups(tc.args.ctx, tc.args.filter)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantGroups, group)
+		})
+	}
+}
+
+func TestGroupService_GetGroupsPaged(t *testing.T) {
+	ctx := context.Background()
+	groups := []*datastore.Group{
+		{UID: "g3", CreatedAt: 3000},
+		{UID: "g2", CreatedAt: 2000},
+		{UID: "g1", CreatedAt: 1000},
+	}
+
+	tests := []struct {
+		name           string
+		cursor         *datastore.Cursor
+		loaded         []*datastore.Group
+		wantUIDs       []string
+		wantPagination datastore.CursorPaginationData
+	}{
+		{
+			name:     "should_return_next_cursor_on_first_page",
+			loaded:   groups,
+			wantUIDs: []string{"g3", "g2"},
+			wantPagination: datastore.CursorPaginationData{
+				PerPage:    2,
+				NextCursor: (&datastore.Cursor{CreatedAt: 2000, UID: "g2"}).Encode(),
+			},
+		},
+		{
+			name:     "should_return_only_prev_cursor_on_last_page",
+			cursor:   &datastore.Cursor{CreatedAt: 2000, UID: "g2"},
+			loaded:   groups[2:],
+			wantUIDs: []string{"g1"},
+			wantPagination: datastore.CursorPaginationData{
+				PerPage:    2,
+				PrevCursor: (&datastore.Cursor{CreatedAt: 1000, UID: "g1", Backward: true}).Encode(),
+			},
+		},
+		{
+			name:     "should_page_backward_from_prev_cursor",
+			cursor:   &datastore.Cursor{CreatedAt: 1000, UID: "g1", Backward: true},
+			loaded:   groups[:2],
+			wantUIDs: []string{"g3", "g2"},
+			wantPagination: datastore.CursorPaginationData{
+				PerPage:    2,
+				NextCursor: (&datastore.Cursor{CreatedAt: 2000, UID: "g2"}).Encode(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+			g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{}, Cursor: tc.cursor, PerPage: 3}).
+				Times(1).Return(tc.loaded, nil)
+
+			a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+			a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(len(tc.wantUIDs)).Return(int64(1), nil)
+
+			e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+			e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(len(tc.wantUIDs)).Return(int64(1), nil)
+
+			d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+			d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), gomock.Any()).Times(len(tc.wantUIDs)).Return(int64(0), nil)
+
+			page, paginationData, err := gs.GetGroupsPaged(ctx, &datastore.GroupFilter{Cursor: tc.cursor}, 2)
+			require.NoError(t, err)
+
+			uids := make([]string, 0, len(page))
+			for _, group := range page {
+				uids = append(uids, group.UID)
+			}
+			require.Equal(t, tc.wantUIDs, uids)
+			require.Equal(t, tc.wantPagination, paginationData)
+		})
+	}
+}
+
+func TestGroupService_FillGroupStatistics(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx context.Context
+		g   *datastore.Group
+	}
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(gs *GroupService)
+		wantGroup   *datastore.Group
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_fill_statistics",
+			args: args{
+				ctx: ctx,
+				g:   &datastore.Group{UID: "1234"},
+			},
+			dbFn: func(gs *GroupService) {
 				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
 
 				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(int64(0), nil)
 			},
-			wantGroups: []*datastore.Group{
-				{
-					UID: "123",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
-				},
-				{
-					UID: "abc",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
+			wantGroup: &datastore.Group{
+				UID: "1234",
+				Statistics: &datastore.GroupStatistics{
+					MessagesSent: 1,
+					TotalApps:    1,
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_get_groups_trims-whitespaces-from-query",
+			name: "should_fail_to_count_group_messages",
 			args: args{
-				ctx:    ctx,
-				filter: &datastore.GroupFilter{Names: []string{" default_group "}},
+				ctx: ctx,
+				g:   &datastore.Group{UID: "1234"},
 			},
 			dbFn: func(gs *GroupService) {
-				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
-					Times(1).Return([]*datastore.Group{
-					{UID: "123"},
-					{UID: "abc"},
-				}, nil)
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
 
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").
+					Times(1).Return(int64(1), errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to count group statistics",
+		},
+		{
+			name: "should_fail_to_count_group_apps",
+			args: args{
+				ctx: ctx,
+				g:   &datastore.Group{UID: "1234"},
+			},
+			dbFn: func(gs *GroupService) {
 				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").
+					Times(1).Return(int64(1), errors.New("failed"))
 
+				// the messages are counted at the same time
 				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").
+					MaxTimes(1).Return(int64(1), nil)
 			},
-			wantGroups: []*datastore.Group{
-				{
-					UID: "123",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
-				},
-				{
-					UID: "abc",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
-				},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to count group statistics",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(gs)
+			}
+
+			err := gs.FillGroupStatistics(tc.args.ctx, tc.args.g, false)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantGroup, tc.args.g)
+		})
+	}
+}
+
+func TestGroupService_FillGroupStatistics_IncludesBreakdownByType(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		withMessagesByType bool
+		dbFn               func(gs *GroupService)
+		wantStatistics     *datastore.GroupStatistics
+		wantErr            bool
+		wantErrCode        int
+		wantErrMsg         string
+	}{
+		{
+			name:               "should_include_messages_by_type",
+			withMessagesByType: true,
+			dbFn: func(gs *GroupService) {
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(2), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(5), nil)
+				e.EXPECT().CountGroupMessagesByType(gomock.Any(), "1234").Times(1).
+					Return(map[string]int64{"payment.created": 3, "payment.failed": 2}, nil)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(int64(0), nil)
+			},
+			wantStatistics: &datastore.GroupStatistics{
+				MessagesSent:   5,
+				TotalApps:      2,
+				MessagesByType: map[string]int64{"payment.created": 3, "payment.failed": 2},
+			},
+		},
+		{
+			name:               "should_not_count_messages_by_type_unless_asked",
+			withMessagesByType: false,
+			dbFn: func(gs *GroupService) {
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(2), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(5), nil)
+				e.EXPECT().CountGroupMessagesByType(gomock.Any(), gomock.Any()).Times(0)
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(int64(0), nil)
+			},
+			wantStatistics: &datastore.GroupStatistics{
+				MessagesSent: 5,
+				TotalApps:    2,
+			},
+		},
+		{
+			name:               "should_fail_to_count_messages_by_type",
+			withMessagesByType: true,
+			dbFn: func(gs *GroupService) {
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(2), nil)
+
+				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(5), nil)
+				e.EXPECT().CountGroupMessagesByType(gomock.Any(), "1234").Times(1).
+					Return(nil, errors.New("failed"))
+
+				d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(int64(0), nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to count group statistics",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			// Arrange Expectations
+			if tc.dbFn != nil {
+				tc.dbFn(gs)
+			}
+
+			g := &datastore.Group{UID: "1234"}
+			err := gs.FillGroupStatistics(ctx, g, tc.withMessagesByType)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantStatistics, g.Statistics)
+		})
+	}
+}
+
+func TestGroupService_FillGroupStatistics_IncludesPendingDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		pending        int64
+		pendingErr     error
+		wantStatistics *datastore.GroupStatistics
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:    "should_report_no_pending_deliveries",
+			pending: 0,
+			wantStatistics: &datastore.GroupStatistics{
+				MessagesSent:      5,
+				TotalApps:         2,
+				PendingDeliveries: 0,
+			},
+		},
+		{
+			name:    "should_report_pending_deliveries",
+			pending: 42,
+			wantStatistics: &datastore.GroupStatistics{
+				MessagesSent:      5,
+				TotalApps:         2,
+				PendingDeliveries: 42,
+			},
+		},
+		{
+			name:       "should_fail_to_count_pending_deliveries",
+			pendingErr: errors.New("failed"),
+			wantErr:    true,
+			wantErrMsg: "failed to count group statistics",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+			a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(2), nil)
+
+			e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+			e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(5), nil)
+
+			d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+			d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(tc.pending, tc.pendingErr)
+
+			g := &datastore.Group{UID: "1234"}
+			err := gs.FillGroupStatistics(ctx, g, false)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantStatistics, g.Statistics)
+		})
+	}
+}
+
+func TestGroupService_FillGroupStatistics_BothQueriesRunConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	// each count waits for the other to start, so they only both return
+	// when they run at the same time
+	var started sync.WaitGroup
+	started.Add(2)
+	waitForBoth := func() error {
+		started.Done()
+
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("the other count did not start")
+		}
+	}
+
+	a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).
+		DoAndReturn(func(context.Context, string) (int64, error) { return 2, waitForBoth() })
+
+	e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).
+		DoAndReturn(func(context.Context, string) (int64, error) { return 5, waitForBoth() })
+
+	d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").Times(1).Return(int64(0), nil)
+
+	g := &datastore.Group{UID: "1234"}
+	err := gs.FillGroupStatistics(context.Background(), g, false)
+	require.Nil(t, err)
+	require.Equal(t, &datastore.GroupStatistics{MessagesSent: 5, TotalApps: 2}, g.Statistics)
+}
+
+func BenchmarkGroupService_FillGroupStatistics(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	// a slow connection, the counts should take one round trip rather
+	// than two
+	const latency = 5 * time.Millisecond
+	slowCount := func(context.Context, string) (int64, error) {
+		time.Sleep(latency)
+		return 1, nil
+	}
+
+	a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().CountGroupApplications(gomock.Any(), "1234").AnyTimes().DoAndReturn(slowCount)
+
+	e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CountGroupMessages(gomock.Any(), "1234").AnyTimes().DoAndReturn(slowCount)
+
+	d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "1234").AnyTimes().Return(int64(0), nil)
+
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		err := gs.FillGroupStatistics(ctx, &datastore.Group{UID: "1234"}, false)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGroupService_DeleteGroup(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		dbFn        func(gs *GroupService)
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_delete_group",
+			args: args{
+				ctx: ctx,
+				id:  "12345",
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).Return(nil)
+
+				c, _ := gs.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "groups:12345").Times(1).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:12345").Times(1).Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_get_groups_trims-whitespaces-from-query-retains-case",
+			name: "should_fail_to_delete_group",
 			args: args{
-				ctx:    ctx,
-				filter: &datastore.GroupFilter{Names: []string{"  deFault_Group"}},
+				ctx: ctx,
+				id:  "12345",
+			},
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group", Err: errors.New("failed")})
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to delete group",
+		},
+		{
+			name: "should_fail_to_delete_group_apps",
+			args: args{
+				ctx: ctx,
+				id:  "12345",
 			},
 			dbFn: func(gs *GroupService) {
 				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"deFault_Group"}}).
-					Times(1).Return([]*datastore.Group{
-					{UID: "123"},
-					{UID: "abc"},
-				}, nil)
-
-				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
-
-				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().CountGroupMessages(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
-			},
-			wantGroups: []*datastore.Group{
-				{
-					UID: "123",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
-				},
-				{
-					UID: "abc",
-					Statistics: &datastore.GroupStatistics{
-						MessagesSent: 1,
-						TotalApps:    1,
-					},
-				},
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group apps", Err: errors.New("failed")})
 			},
-			wantErr: false,
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to delete group apps",
 		},
 		{
-			name: "should_fail_to_get_groups",
+			name: "should_fail_to_delete_group_messages",
 			args: args{
-				ctx:    ctx,
-				filter: &datastore.GroupFilter{Names: []string{"default_group"}},
+				ctx: ctx,
+				id:  "12345",
 			},
 			dbFn: func(gs *GroupService) {
 				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().LoadGroups(gomock.Any(), &datastore.GroupFilter{Names: []string{"default_group"}}).
-					Times(1).Return(nil, errors.New("failed"))
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group events", Err: errors.New("failed")})
 			},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "an error occurred while fetching Groups",
+			wantErrMsg:  "failed to delete group events",
 		},
 	}
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -529,7 +2046,7 @@ func TestGroupService_GetGroups(t *testing.T) {
 				tc.dbFn(gs)
 			}
 
-			group, err := gs.GetGroups(tc.args.ctx, tc.args.filter)
+			err := gs.DeleteGroup(tc.args.ctx, tc.args.id)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -538,95 +2055,113 @@ func TestGroupService_GetGroups(t *testing.T) {
 			}
 
 			require.Nil(t, err)
-			require.Equal(t, tc.wantGroups, group)
 		})
 	}
 }
 
-func TestGroupService_FillGroupStatistics(t *testing.T) {
+func TestGroupService_UpdateGroupStatus(t *testing.T) {
 	ctx := context.Background()
+	group := &datastore.Group{UID: "12345", Name: "test_group", Status: datastore.InactiveGroupStatus}
 
-	type args struct {
-		ctx context.Context
-		g   *datastore.Group
-	}
 	tests := []struct {
 		name        string
-		args        args
+		status      datastore.GroupStatus
 		dbFn        func(gs *GroupService)
-		wantGroup   *datastore.Group
+		want        *datastore.Group
 		wantErr     bool
 		wantErrCode int
 		wantErrMsg  string
 	}{
 		{
-			name: "should_fill_statistics",
-			args: args{
-				ctx: ctx,
-				g:   &datastore.Group{UID: "1234"},
-			},
+			name:   "should_update_group_status",
+			status: datastore.ArchivedGroupStatus,
 			dbFn: func(gs *GroupService) {
-				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), "12345", datastore.ArchivedGroupStatus).Times(1).Return(nil)
+				g.EXPECT().FetchGroupByID(gomock.Any(), "12345").Times(1).
+					Return(&datastore.Group{UID: "12345", Name: "test_group", Status: datastore.ArchivedGroupStatus}, nil)
 
-				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
+				c, _ := gs.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "groups:12345").Times(1).Return(nil)
 			},
-			wantGroup: &datastore.Group{
-				UID: "1234",
-				Statistics: &datastore.GroupStatistics{
-					MessagesSent: 1,
-					TotalApps:    1,
-				},
+			want: &datastore.Group{UID: "12345", Name: "test_group", Status: datastore.ArchivedGroupStatus},
+		},
+		{
+			name:   "should_cascade_group_deletion",
+			status: datastore.DeletedGroupStatus,
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), "12345", datastore.DeletedGroupStatus).Times(1).Return(nil)
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).Return(nil)
+				g.EXPECT().FetchGroupByID(gomock.Any(), "12345").Times(1).
+					Return(&datastore.Group{UID: "12345", Name: "test_group", Status: datastore.DeletedGroupStatus}, nil)
+
+				c, _ := gs.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), "groups:12345").Times(1).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "group_statistics:12345").Times(1).Return(nil)
 			},
-			wantErr: false,
+			want: &datastore.Group{UID: "12345", Name: "test_group", Status: datastore.DeletedGroupStatus},
 		},
 		{
-			name: "should_fail_to_count_group_messages",
-			args: args{
-				ctx: ctx,
-				g:   &datastore.Group{UID: "1234"},
+			name:   "should_fail_when_the_deletion_cascade_fails",
+			status: datastore.DeletedGroupStatus,
+			dbFn: func(gs *GroupService) {
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), "12345", datastore.DeletedGroupStatus).Times(1).Return(nil)
+				g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).
+					Return(&datastore.GroupCascadeError{Step: "delete group apps", Err: errors.New("failed")})
 			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to delete group apps",
+		},
+		{
+			name:   "should_reject_illegal_transition",
+			status: datastore.DeletedGroupStatus,
 			dbFn: func(gs *GroupService) {
-				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").Times(1).Return(int64(1), nil)
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), "12345", datastore.DeletedGroupStatus).Times(1).
+					Return(datastore.ErrIllegalGroupStatusTransition)
 
-				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().CountGroupMessages(gomock.Any(), "1234").
-					Times(1).Return(int64(1), errors.New("failed"))
+				c, _ := gs.cache.(*mocks.MockCache)
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
 			},
 			wantErr:     true,
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  "group can't be moved from inactive to deleted",
+		},
+		{
+			name:        "should_reject_unknown_status",
+			status:      "paused",
+			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "failed to count group statistics",
+			wantErrMsg:  `unknown group status "paused"`,
 		},
 		{
-			name: "should_fail_to_count_group_apps",
-			args: args{
-				ctx: ctx,
-				g:   &datastore.Group{UID: "1234"},
-			},
+			name:   "should_fail_to_update_group_status",
+			status: datastore.ActiveGroupStatus,
 			dbFn: func(gs *GroupService) {
-				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().CountGroupApplications(gomock.Any(), "1234").
-					Times(1).Return(int64(1), errors.New("failed"))
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().UpdateGroupStatus(gomock.Any(), "12345", datastore.ActiveGroupStatus).Times(1).
+					Return(errors.New("failed"))
 			},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "failed to count group statistics",
+			wantErrMsg:  "an error occurred while updating group status",
 		},
 	}
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			gs := provideGroupService(ctrl)
 
-			// Arrange Expectations
 			if tc.dbFn != nil {
 				tc.dbFn(gs)
 			}
 
-			err := gs.FillGroupStatistics(tc.args.ctx, tc.args.g)
+			got, err := gs.UpdateGroupStatus(ctx, group, tc.status)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -635,107 +2170,304 @@ func TestGroupService_FillGroupStatistics(t *testing.T) {
 			}
 
 			require.Nil(t, err)
-			require.Equal(t, tc.wantGroup, tc.args.g)
+			require.Equal(t, tc.want, got)
 		})
 	}
 }
 
-func TestGroupService_DeleteGroup(t *testing.T) {
+func TestGroupService_DeleteGroup_RollsBackOnEventDeleteFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	// the cascade fails deleting the events, before the group and its
+	// apps are deleted
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().DeleteGroupCascade(gomock.Any(), "12345").Times(1).
+		Return(&datastore.GroupCascadeError{Step: "delete group events", Err: errors.New("failed")})
+
+	// the group is kept, so its cached copies are left alone
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+
+	err := gs.DeleteGroup(context.Background(), "12345")
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+	require.Equal(t, "failed to delete group events", err.(*ServiceError).Error())
+}
+
+func TestGroupService_ListGroupsWithActiveAlerts(t *testing.T) {
 	ctx := context.Background()
-	type args struct {
-		ctx context.Context
-		id  string
-	}
 	tests := []struct {
 		name        string
-		args        args
-		wantErr     bool
 		dbFn        func(gs *GroupService)
+		wantReasons map[string][]datastore.GroupAlertReason
+		wantErr     bool
 		wantErrCode int
 		wantErrMsg  string
 	}{
 		{
-			name: "should_delete_group",
-			args: args{
-				ctx: ctx,
-				id:  "12345",
-			},
+			name: "should_combine_reasons_of_groups_listed_twice",
 			dbFn: func(gs *GroupService) {
-				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().DeleteGroup(gomock.Any(), "12345").Times(1).Return(nil)
+				ed, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindGroupsWithHighFailureRate(gomock.Any(), groupAlertFailureRate).Times(1).Return([]string{"123", "456"}, nil)
 
 				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), "12345").Times(1).Return(nil)
+				a.EXPECT().FindGroupsWithInactiveEndpoints(gomock.Any()).Times(1).Return([]string{"456", "789"}, nil)
 
-				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().DeleteGroupEvents(gomock.Any(), "12345").Times(1).Return(nil)
+				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"123", "456", "789"}).Times(1).
+					Return([]datastore.Group{{UID: "123"}, {UID: "456"}, {UID: "789"}}, nil)
+			},
+			wantReasons: map[string][]datastore.GroupAlertReason{
+				"123": {datastore.HighFailureRateAlertReason},
+				"456": {datastore.HighFailureRateAlertReason, datastore.InactiveEndpointsAlertReason},
+				"789": {datastore.InactiveEndpointsAlertReason},
 			},
-			wantErr: false,
 		},
 		{
-			name: "should_fail_to_delete_group",
-			args: args{
-				ctx: ctx,
-				id:  "12345",
-			},
+			name: "should_skip_groups_that_no_longer_exist",
 			dbFn: func(gs *GroupService) {
+				ed, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindGroupsWithHighFailureRate(gomock.Any(), groupAlertFailureRate).Times(1).Return([]string{"123", "456"}, nil)
+
+				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindGroupsWithInactiveEndpoints(gomock.Any()).Times(1).Return([]string{}, nil)
+
 				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().DeleteGroup(gomock.Any(), "12345").Times(1).Return(errors.New("failed"))
+				g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"123", "456"}).Times(1).
+					Return([]datastore.Group{{UID: "456"}}, nil)
+			},
+			wantReasons: map[string][]datastore.GroupAlertReason{
+				"456": {datastore.HighFailureRateAlertReason},
 			},
-			wantErr:     true,
-			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "failed to delete group",
 		},
 		{
-			name: "should_fail_to_delete_group_apps",
-			args: args{
-				ctx: ctx,
-				id:  "12345",
-			},
+			name: "should_not_fetch_groups_without_alerts",
 			dbFn: func(gs *GroupService) {
-				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().DeleteGroup(gomock.Any(), "12345").Times(1).Return(nil)
+				ed, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindGroupsWithHighFailureRate(gomock.Any(), groupAlertFailureRate).Times(1).Return([]string{}, nil)
 
 				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), "12345").Times(1).Return(errors.New("failed"))
+				a.EXPECT().FindGroupsWithInactiveEndpoints(gomock.Any()).Times(1).Return([]string{}, nil)
+			},
+			wantReasons: map[string][]datastore.GroupAlertReason{},
+		},
+		{
+			name: "should_fail_to_find_groups_with_high_failure_rate",
+			dbFn: func(gs *GroupService) {
+				ed, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindGroupsWithHighFailureRate(gomock.Any(), groupAlertFailureRate).Times(1).Return(nil, errors.New("failed"))
 			},
 			wantErr:     true,
-			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "failed to delete group apps",
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "an error occurred while fetching groups with alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gs := provideGroupService(ctrl)
+
+			tc.dbFn(gs)
+
+			groups, err := gs.ListGroupsWithActiveAlerts(ctx)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Len(t, groups, len(tc.wantReasons))
+			for _, g := range groups {
+				require.Equal(t, tc.wantReasons[g.UID], g.AlertReasons)
+			}
+		})
+	}
+}
+
+func Test_validateRetryStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        datastore.StrategyConfiguration
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "should_accept_default_strategy",
+			cfg: datastore.StrategyConfiguration{
+				Type:    config.DefaultStrategyProvider,
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
 		},
 		{
-			name: "should_fail_to_delete_group_messages",
-			args: args{
-				ctx: ctx,
-				id:  "12345",
+			name: "should_reject_default_strategy_without_interval",
+			cfg: datastore.StrategyConfiguration{
+				Type:    config.DefaultStrategyProvider,
+				Default: datastore.DefaultStrategyConfiguration{RetryLimit: 3},
 			},
-			dbFn: func(gs *GroupService) {
-				g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
-				g.EXPECT().DeleteGroup(gomock.Any(), "12345").Times(1).Return(nil)
+			wantErr:    true,
+			wantErrMsg: "intervalSeconds must be at least 1",
+		},
+		{
+			name: "should_reject_default_strategy_without_retry_limit",
+			cfg: datastore.StrategyConfiguration{
+				Type:    config.DefaultStrategyProvider,
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10},
+			},
+			wantErr:    true,
+			wantErrMsg: "retryLimit must be at least 1",
+		},
+		{
+			name: "should_accept_exponential_backoff_strategy",
+			cfg: datastore.StrategyConfiguration{
+				Type:               config.ExponentialBackoffStrategyProvider,
+				ExponentialBackoff: datastore.ExponentialBackoffStrategyConfiguration{RetryLimit: 5},
+			},
+		},
+		{
+			name: "should_reject_exponential_backoff_strategy_without_retry_limit",
+			cfg: datastore.StrategyConfiguration{
+				Type:    config.ExponentialBackoffStrategyProvider,
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			wantErr:    true,
+			wantErrMsg: "retryLimit must be at least 1",
+		},
+		{
+			name: "should_accept_linear_strategy",
+			cfg: datastore.StrategyConfiguration{
+				Type:   config.LinearStrategyProvider,
+				Linear: datastore.LinearStrategyConfiguration{InitialIntervalSeconds: 10, StepSeconds: 30, RetryLimit: 5},
+			},
+		},
+		{
+			name: "should_reject_linear_strategy_without_initial_interval",
+			cfg: datastore.StrategyConfiguration{
+				Type:   config.LinearStrategyProvider,
+				Linear: datastore.LinearStrategyConfiguration{StepSeconds: 30, RetryLimit: 5},
+			},
+			wantErr:    true,
+			wantErrMsg: "initialIntervalSeconds must be at least 1",
+		},
+		{
+			name: "should_reject_linear_strategy_without_step",
+			cfg: datastore.StrategyConfiguration{
+				Type:   config.LinearStrategyProvider,
+				Linear: datastore.LinearStrategyConfiguration{InitialIntervalSeconds: 10, RetryLimit: 5},
+			},
+			wantErr:    true,
+			wantErrMsg: "stepSeconds must be at least 1",
+		},
+		{
+			name: "should_reject_linear_strategy_without_retry_limit",
+			cfg: datastore.StrategyConfiguration{
+				Type:   config.LinearStrategyProvider,
+				Linear: datastore.LinearStrategyConfiguration{InitialIntervalSeconds: 10, StepSeconds: 30},
+			},
+			wantErr:    true,
+			wantErrMsg: "retryLimit must be at least 1",
+		},
+		{
+			name: "should_reject_missing_strategy_type",
+			cfg: datastore.StrategyConfiguration{
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			wantErr:    true,
+			wantErrMsg: "please provide a valid strategy type",
+		},
+		{
+			name: "should_reject_unsupported_strategy_type",
+			cfg: datastore.StrategyConfiguration{
+				Type:    "fibonacci",
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 10, RetryLimit: 3},
+			},
+			wantErr:    true,
+			wantErrMsg: "unsupported strategy type",
+		},
+	}
 
-				a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
-				a.EXPECT().DeleteGroupApps(gomock.Any(), "12345").Times(1).Return(nil)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRetryStrategy(tc.cfg)
+			if tc.wantErr {
+				require.EqualError(t, err, tc.wantErrMsg)
+				return
+			}
 
-				e, _ := gs.eventRepo.(*mocks.MockEventRepository)
-				e.EXPECT().DeleteGroupEvents(gomock.Any(), "12345").Times(1).Return(errors.New("failed"))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGroupService_GetRateLimitUsage(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		group       *datastore.Group
+		dbFn        func(l *mocks.MockRateLimiter)
+		wantUsage   *models.RateLimitUsage
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:  "should_inspect_the_group_limit",
+			group: &datastore.Group{UID: "group-1", RateLimit: 100, RateLimitDuration: "1h"},
+			dbFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().Inspect(gomock.Any(), "group-1", 100, int(time.Hour)).Times(1).
+					Return(&redis_rate.Result{Remaining: 71, ResetAfter: 18 * time.Minute}, nil)
 			},
+			wantUsage: &models.RateLimitUsage{Limit: 100, Duration: "1h0m0s", Used: 30, Remaining: 70, ResetAfter: "18m0s"},
+		},
+		{
+			name:  "should_use_the_default_limit",
+			group: &datastore.Group{UID: "group-1"},
+			dbFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().Inspect(gomock.Any(), "group-1", 5000, int(time.Minute)).Times(1).
+					Return(&redis_rate.Result{Remaining: 0, ResetAfter: time.Minute}, nil)
+			},
+			wantUsage: &models.RateLimitUsage{Limit: 5000, Duration: "1m0s", Used: 5000, Remaining: 0, ResetAfter: "1m0s"},
+		},
+		{
+			name:        "should_fail_to_parse_duration",
+			group:       &datastore.Group{UID: "group-1", RateLimitDuration: "abc"},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "failed to delete group events",
+			wantErrMsg:  `an error occurred parsing the rate limit duration: time: invalid duration "abc"`,
+		},
+		{
+			name:  "should_fail_to_inspect_limiter",
+			group: &datastore.Group{UID: "group-1"},
+			dbFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().Inspect(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to fetch group rate limit usage",
 		},
 	}
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+
+			l := mocks.NewMockRateLimiter(ctrl)
 			gs := provideGroupService(ctrl)
+			gs.limiter = l
 
-			// Arrange Expectations
 			if tc.dbFn != nil {
-				tc.dbFn(gs)
+				tc.dbFn(l)
 			}
 
-			err := gs.DeleteGroup(tc.args.ctx, tc.args.id)
+			usage, err := gs.GetRateLimitUsage(ctx, tc.group)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -744,6 +2476,7 @@ func TestGroupService_DeleteGroup(t *testing.T) {
 			}
 
 			require.Nil(t, err)
+			require.Equal(t, tc.wantUsage, usage)
 		})
 	}
 }
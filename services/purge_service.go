@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	purgeBatchSize = 1000
+
+	// purgeTimeBudget caps how long one run of the hourly purge job keeps
+	// deleting batches, whatever is left is picked up by the next run.
+	purgeTimeBudget = 15 * time.Minute
+)
+
+// purger hard-deletes the soft-deleted documents of a collection.
+type purger interface {
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+}
+
+// PurgeService hard-deletes documents that have been soft-deleted for
+// longer than the ttl. Until then a deleted app can be restored along
+// with its events, and a revoked api key is kept for its audit trail, so
+// nothing is purged while it is still inside that window.
+type PurgeService struct {
+	groupRepo  datastore.GroupRepository
+	appRepo    datastore.ApplicationRepository
+	apiKeyRepo datastore.APIKeyRepository
+	eventRepo  datastore.EventRepository
+	ttl        time.Duration
+	batchSize  int
+	timeBudget time.Duration
+}
+
+func NewPurgeService(groupRepo datastore.GroupRepository, appRepo datastore.ApplicationRepository, apiKeyRepo datastore.APIKeyRepository, eventRepo datastore.EventRepository, ttl time.Duration) *PurgeService {
+	return &PurgeService{
+		groupRepo:  groupRepo,
+		appRepo:    appRepo,
+		apiKeyRepo: apiKeyRepo,
+		eventRepo:  eventRepo,
+		ttl:        ttl,
+		batchSize:  purgeBatchSize,
+		timeBudget: purgeTimeBudget,
+	}
+}
+
+// PurgeDeletedDocuments purges the documents deleted before the ttl from
+// each collection, batch by batch until the collection has none left or
+// the time budget runs out, and returns how many were purged from each.
+// Events are purged along with their deliveries. Collections are purged
+// children first, so that an app is never purged before its events. A
+// collection that fails doesn't stop the rest, the first failure is
+// returned.
+func (p *PurgeService) PurgeDeletedDocuments(ctx context.Context) (map[string]int64, error) {
+	deletedBefore := time.Now().Add(-p.ttl)
+	deadline := time.Now().Add(p.timeBudget)
+
+	collections := []struct {
+		name   string
+		purger purger
+	}{
+		{name: "events", purger: p.eventRepo},
+		{name: "applications", purger: p.appRepo},
+		{name: "api_keys", purger: p.apiKeyRepo},
+		{name: "groups", purger: p.groupRepo},
+	}
+
+	purged := make(map[string]int64, len(collections))
+	var firstErr error
+
+	for _, c := range collections {
+		for {
+			n, err := c.purger.PurgeDeleted(ctx, deletedBefore, p.batchSize)
+			purged[c.name] += n
+			if err != nil {
+				log.WithError(err).Errorf("failed to purge deleted %s", c.name)
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+
+			if n < int64(p.batchSize) || ctx.Err() != nil || time.Now().After(deadline) {
+				break
+			}
+		}
+	}
+
+	return purged, firstErr
+}
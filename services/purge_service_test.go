@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func providePurgeService(ctrl *gomock.Controller) *PurgeService {
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	return NewPurgeService(groupRepo, appRepo, apiKeyRepo, eventRepo, 24*time.Hour)
+}
+
+// cutoffMatcher matches a time within a minute after want.
+type cutoffMatcher struct{ want time.Time }
+
+func (m cutoffMatcher) Matches(x interface{}) bool {
+	got, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+
+	d := got.Sub(m.want)
+	return d >= 0 && d < time.Minute
+}
+
+func (m cutoffMatcher) String() string { return "is about " + m.want.String() }
+
+func TestPurgeService_PurgeDeletedDocuments(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		timeBudget time.Duration
+		dbFn       func(ps *PurgeService)
+		wantPurged map[string]int64
+		wantErr    bool
+	}{
+		{
+			name:       "should_purge_each_collection_until_it_is_empty",
+			timeBudget: purgeTimeBudget,
+			dbFn: func(ps *PurgeService) {
+				e, _ := ps.eventRepo.(*mocks.MockEventRepository)
+				a, _ := ps.appRepo.(*mocks.MockApplicationRepository)
+				k, _ := ps.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				g, _ := ps.groupRepo.(*mocks.MockGroupRepository)
+
+				// children are purged before their parents
+				cutoff := cutoffMatcher{want: time.Now().Add(-24 * time.Hour)}
+				gomock.InOrder(
+					e.EXPECT().PurgeDeleted(gomock.Any(), cutoff, purgeBatchSize).Times(2).Return(int64(purgeBatchSize), nil),
+					e.EXPECT().PurgeDeleted(gomock.Any(), cutoff, purgeBatchSize).Times(1).Return(int64(250), nil),
+					a.EXPECT().PurgeDeleted(gomock.Any(), cutoff, purgeBatchSize).Times(1).Return(int64(3), nil),
+					k.EXPECT().PurgeDeleted(gomock.Any(), cutoff, purgeBatchSize).Times(1).Return(int64(2), nil),
+					g.EXPECT().PurgeDeleted(gomock.Any(), cutoff, purgeBatchSize).Times(1).Return(int64(0), nil),
+				)
+			},
+			wantPurged: map[string]int64{"events": 2250, "applications": 3, "api_keys": 2, "groups": 0},
+		},
+		{
+			name:       "should_stop_once_the_time_budget_runs_out",
+			timeBudget: 0,
+			dbFn: func(ps *PurgeService) {
+				e, _ := ps.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(purgeBatchSize), nil)
+
+				a, _ := ps.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(purgeBatchSize), nil)
+
+				k, _ := ps.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				k.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(0), nil)
+
+				g, _ := ps.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(0), nil)
+			},
+			wantPurged: map[string]int64{"events": purgeBatchSize, "applications": purgeBatchSize, "api_keys": 0, "groups": 0},
+		},
+		{
+			name:       "should_carry_on_past_a_failed_collection",
+			timeBudget: purgeTimeBudget,
+			dbFn: func(ps *PurgeService) {
+				e, _ := ps.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(0), errors.New("failed"))
+
+				a, _ := ps.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(3), nil)
+
+				k, _ := ps.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				k.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(0), nil)
+
+				g, _ := ps.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).Times(1).Return(int64(1), nil)
+			},
+			wantPurged: map[string]int64{"events": 0, "applications": 3, "api_keys": 0, "groups": 1},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ps := providePurgeService(ctrl)
+			ps.timeBudget = tc.timeBudget
+			tc.dbFn(ps)
+
+			purged, err := ps.PurgeDeletedDocuments(ctx)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.wantPurged, purged)
+		})
+	}
+}
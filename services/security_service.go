@@ -20,19 +20,33 @@ import (
 )
 
 type SecurityService struct {
-	groupRepo  datastore.GroupRepository
-	apiKeyRepo datastore.APIKeyRepository
+	groupRepo    datastore.GroupRepository
+	appRepo      datastore.ApplicationRepository
+	apiKeyRepo   datastore.APIKeyRepository
+	auditLogRepo datastore.AuditLogRepository
 }
 
-func NewSecurityService(groupRepo datastore.GroupRepository, apiKeyRepo datastore.APIKeyRepository) *SecurityService {
-	return &SecurityService{groupRepo: groupRepo, apiKeyRepo: apiKeyRepo}
+func NewSecurityService(groupRepo datastore.GroupRepository, appRepo datastore.ApplicationRepository, apiKeyRepo datastore.APIKeyRepository, auditLogRepo datastore.AuditLogRepository) *SecurityService {
+	return &SecurityService{groupRepo: groupRepo, appRepo: appRepo, apiKeyRepo: apiKeyRepo, auditLogRepo: auditLogRepo}
 }
 
-func (ss *SecurityService) CreateAPIKey(ctx context.Context, newApiKey *models.APIKey) (*datastore.APIKey, string, error) {
+func (ss *SecurityService) CreateAPIKey(ctx context.Context, actorID string, newApiKey *models.APIKey) (*datastore.APIKey, string, error) {
+	if newApiKey.NeverExpires {
+		newApiKey.ExpiresAt = time.Time{}
+	}
+
 	if newApiKey.ExpiresAt != (time.Time{}) && newApiKey.ExpiresAt.Before(time.Now()) {
 		return nil, "", NewServiceError(http.StatusBadRequest, errors.New("expiry date is invalid"))
 	}
 
+	if newApiKey.DailyQuota < 0 {
+		return nil, "", NewServiceError(http.StatusBadRequest, errors.New("daily quota cannot be negative"))
+	}
+
+	if newApiKey.RateLimit < 0 {
+		return nil, "", NewServiceError(http.StatusBadRequest, errors.New("rate limit cannot be negative"))
+	}
+
 	err := newApiKey.Role.Validate("api key")
 	if err != nil {
 		log.WithError(err).Error("invalid api key role")
@@ -49,6 +63,11 @@ func (ss *SecurityService) CreateAPIKey(ctx context.Context, newApiKey *models.A
 		return nil, "", NewServiceError(http.StatusBadRequest, errors.New("cannot find group"))
 	}
 
+	err = ss.ensureRoleApps(ctx, &newApiKey.Role)
+	if err != nil {
+		return nil, "", err
+	}
+
 	maskID, key := util.GenerateAPIKey()
 
 	salt, err := util.GenerateSecret()
@@ -68,6 +87,8 @@ func (ss *SecurityService) CreateAPIKey(ctx context.Context, newApiKey *models.A
 		Role:           newApiKey.Role,
 		Hash:           encodedKey,
 		Salt:           salt,
+		DailyQuota:     newApiKey.DailyQuota,
+		RateLimit:      newApiKey.RateLimit,
 		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		DocumentStatus: datastore.ActiveDocumentStatus,
@@ -83,6 +104,8 @@ func (ss *SecurityService) CreateAPIKey(ctx context.Context, newApiKey *models.A
 		return nil, "", NewServiceError(http.StatusBadRequest, errors.New("failed to create api key"))
 	}
 
+	ss.appendAPIKeyAuditLog(ctx, datastore.AuditActionCreate, actorID, apiKey)
+
 	return apiKey, key, nil
 }
 
@@ -137,7 +160,7 @@ func (ss *SecurityService) CreateAppPortalAPIKey(ctx context.Context, group *dat
 	return apiKey, key, nil
 }
 
-func (ss *SecurityService) RevokeAPIKey(ctx context.Context, uid string) error {
+func (ss *SecurityService) RevokeAPIKey(ctx context.Context, actorID string, uid string) error {
 	if util.IsStringEmpty(uid) {
 		return NewServiceError(http.StatusBadRequest, errors.New("key id is empty"))
 	}
@@ -147,6 +170,9 @@ func (ss *SecurityService) RevokeAPIKey(ctx context.Context, uid string) error {
 		log.WithError(err).Error("failed to revoke api key")
 		return NewServiceError(http.StatusBadRequest, errors.New("failed to revoke api key"))
 	}
+
+	ss.appendAPIKeyAuditLog(ctx, datastore.AuditActionRevoke, actorID, &datastore.APIKey{UID: uid})
+
 	return nil
 }
 
@@ -155,16 +181,19 @@ func (ss *SecurityService) GetAPIKeyByID(ctx context.Context, uid string) (*data
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("key id is empty"))
 	}
 
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	apiKey, err := ss.apiKeyRepo.FindAPIKeyByID(ctx, uid)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch api key")
-		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to fetch api key"))
+		return nil, datastoreError(err, http.StatusBadRequest, "failed to fetch api key")
 	}
 
 	return apiKey, nil
 }
 
-func (ss *SecurityService) UpdateAPIKey(ctx context.Context, uid string, role *auth.Role) (*datastore.APIKey, error) {
+func (ss *SecurityService) UpdateAPIKey(ctx context.Context, actorID string, uid string, role *auth.Role) (*datastore.APIKey, error) {
 	if util.IsStringEmpty(uid) {
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("key id is empty"))
 	}
@@ -184,6 +213,11 @@ func (ss *SecurityService) UpdateAPIKey(ctx context.Context, uid string, role *a
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("cannot find group"))
 	}
 
+	err = ss.ensureRoleApps(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
 	apiKey, err := ss.apiKeyRepo.FindAPIKeyByID(ctx, uid)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch api key")
@@ -197,15 +231,106 @@ func (ss *SecurityService) UpdateAPIKey(ctx context.Context, uid string, role *a
 		return nil, NewServiceError(http.StatusBadRequest, errors.New("failed to update api key"))
 	}
 
+	ss.appendAPIKeyAuditLog(ctx, datastore.AuditActionUpdate, actorID, apiKey)
+
 	return apiKey, nil
 }
 
+// ensureRoleApps checks that every app in role exists and belongs to one
+// of the role's groups.
+func (ss *SecurityService) ensureRoleApps(ctx context.Context, role *auth.Role) error {
+	if len(role.Apps) == 0 {
+		return nil
+	}
+
+	apps, err := ss.appRepo.FindApplicationsByIDs(ctx, role.Apps)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch apps by ids")
+		return NewServiceError(http.StatusBadRequest, errors.New("invalid app"))
+	}
+
+	groups := make(map[string]bool, len(role.Groups))
+	for _, id := range role.Groups {
+		groups[id] = true
+	}
+
+	found := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		if groups[app.GroupID] {
+			found[app.UID] = true
+		}
+	}
+
+	for _, id := range role.Apps {
+		if !found[id] {
+			return NewServiceError(http.StatusBadRequest, errors.New("cannot find app"))
+		}
+	}
+
+	return nil
+}
+
 func (ss *SecurityService) GetAPIKeys(ctx context.Context, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	apiKeys, paginationData, err := ss.apiKeyRepo.LoadAPIKeysPaged(ctx, pageable)
 	if err != nil {
 		log.WithError(err).Error("failed to load api keys")
-		return nil, datastore.PaginationData{}, NewServiceError(http.StatusBadRequest, errors.New("failed to load api keys"))
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusBadRequest, "failed to load api keys")
+	}
+
+	return apiKeys, paginationData, nil
+}
+
+// ListAPIKeysByGroup pages through the api keys scoped to the group.
+func (ss *SecurityService) ListAPIKeysByGroup(ctx context.Context, groupID string, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	apiKeys, paginationData, err := ss.apiKeyRepo.FindAPIKeysByGroupID(ctx, groupID, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load group api keys")
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusBadRequest, "failed to load api keys")
 	}
 
 	return apiKeys, paginationData, nil
 }
+
+func (ss *SecurityService) GetAuditLogs(ctx context.Context, resourceType string, pageable datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	logs, paginationData, err := ss.auditLogRepo.LoadAuditLogsPaged(ctx, resourceType, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load audit logs")
+		return nil, datastore.PaginationData{}, datastoreError(err, http.StatusBadRequest, "failed to load audit logs")
+	}
+
+	return logs, paginationData, nil
+}
+
+// appendAPIKeyAuditLog records an operation on an api key. The operation
+// has already been applied, so a failure here is only logged.
+func (ss *SecurityService) appendAPIKeyAuditLog(ctx context.Context, action string, actorID string, apiKey *datastore.APIKey) {
+	entry := &datastore.AuditLog{
+		UID:          uuid.New().String(),
+		Action:       action,
+		ActorID:      actorID,
+		ResourceType: datastore.APIKeyAuditResourceType,
+		ResourceID:   apiKey.UID,
+		Timestamp:    primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	if action != datastore.AuditActionRevoke {
+		entry.Metadata = map[string]string{
+			"name": apiKey.Name,
+			"role": string(apiKey.Role.Type),
+		}
+	}
+
+	err := ss.auditLogRepo.Append(ctx, entry)
+	if err != nil {
+		log.WithError(err).Errorf("failed to append %s audit log for api key %s", action, apiKey.UID)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,8 +21,38 @@ import (
 
 func provideSecurityService(ctrl *gomock.Controller) *SecurityService {
 	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
-	return NewSecurityService(groupRepo, apiKeyRepo)
+	auditLogRepo := mocks.NewMockAuditLogRepository(ctrl)
+	return NewSecurityService(groupRepo, appRepo, apiKeyRepo, auditLogRepo)
+}
+
+// auditLogMatcher matches an api key audit log entry by its action and actor.
+type auditLogMatcher struct {
+	action  string
+	actorID string
+}
+
+func (m auditLogMatcher) Matches(x interface{}) bool {
+	entry, ok := x.(*datastore.AuditLog)
+	if !ok {
+		return false
+	}
+
+	return entry.Action == m.action &&
+		entry.ActorID == m.actorID &&
+		entry.ResourceType == datastore.APIKeyAuditResourceType &&
+		!util.IsStringEmpty(entry.ResourceID) &&
+		entry.Timestamp != 0
+}
+
+func (m auditLogMatcher) String() string {
+	return fmt.Sprintf("is a %s audit log by %s", m.action, m.actorID)
+}
+
+func expectAPIKeyAuditLog(ss *SecurityService, action string) *gomock.Call {
+	l, _ := ss.auditLogRepo.(*mocks.MockAuditLogRepository)
+	return l.EXPECT().Append(gomock.Any(), auditLogMatcher{action: action, actorID: "actor-1"}).Times(1)
 }
 
 func TestSecurityService_CreateAPIKey(t *testing.T) {
@@ -71,12 +102,19 @@ func TestSecurityService_CreateAPIKey(t *testing.T) {
 				g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
 					Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
 
+				ap, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+				ap.EXPECT().FindApplicationsByIDs(gomock.Any(), []string{"1234"}).
+					Times(1).Return([]datastore.Application{{UID: "1234", GroupID: "1234"}}, nil)
+
 				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
 				a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
 					Times(1).Return(nil)
+
+				expectAPIKeyAuditLog(ss, datastore.AuditActionCreate).Return(nil)
 			},
 		},
 		{
+			// the expiry is only checked for keys that expire
 			name: "should_error_for_invalid_expiry",
 			args: args{
 				ctx: ctx,
@@ -88,13 +126,34 @@ func TestSecurityService_CreateAPIKey(t *testing.T) {
 						Groups: []string{"1234"},
 						Apps:   []string{"1234"},
 					},
-					ExpiresAt: expires.Add(-2 * time.Hour),
+					ExpiresAt:    expires.Add(-2 * time.Hour),
+					NeverExpires: false,
 				},
 			},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "expiry date is invalid",
 		},
+		{
+			name: "should_error_for_negative_rate_limit",
+			args: args{
+				ctx: ctx,
+				newApiKey: &models.APIKey{
+					Name: "test_api_key",
+					Type: "api",
+					Role: auth.Role{
+						Type:   auth.RoleAdmin,
+						Groups: []string{"1234"},
+						Apps:   []string{"1234"},
+					},
+					ExpiresAt: expires,
+					RateLimit: -1,
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rate limit cannot be negative",
+		},
 		{
 			name: "should_error_for_invalid_api_key_role",
 			args: args{
@@ -193,6 +252,10 @@ func TestSecurityService_CreateAPIKey(t *testing.T) {
 				g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
 					Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
 
+				ap, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+				ap.EXPECT().FindApplicationsByIDs(gomock.Any(), []string{"1234"}).
+					Times(1).Return([]datastore.Application{{UID: "1234", GroupID: "1234"}}, nil)
+
 				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
 				a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
 					Times(1).Return(errors.New("failed"))
@@ -212,7 +275,7 @@ func TestSecurityService_CreateAPIKey(t *testing.T) {
 				tc.dbFn(ss)
 			}
 
-			apiKey, keyString, err := ss.CreateAPIKey(tc.args.ctx, tc.args.newApiKey)
+			apiKey, keyString, err := ss.CreateAPIKey(tc.args.ctx, "actor-1", tc.args.newApiKey)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -237,6 +300,128 @@ func TestSecurityService_CreateAPIKey(t *testing.T) {
 	}
 }
 
+func TestSecurityService_CreateAPIKey_FailsForAppNotInGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ss := provideSecurityService(ctrl)
+
+	g, _ := ss.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
+		Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
+
+	a, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationsByIDs(gomock.Any(), []string{"app-1"}).
+		Times(1).Return([]datastore.Application{{UID: "app-1", GroupID: "5678"}}, nil)
+
+	_, _, err := ss.CreateAPIKey(context.Background(), "actor-1", &models.APIKey{
+		Name: "test_api_key",
+		Type: "api",
+		Role: auth.Role{
+			Type:   auth.RoleAdmin,
+			Groups: []string{"1234"},
+			Apps:   []string{"app-1"},
+		},
+		NeverExpires: true,
+	})
+
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+	require.Equal(t, "cannot find app", err.Error())
+}
+
+func TestSecurityService_CreateAPIKey_FailsForMissingApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ss := provideSecurityService(ctrl)
+
+	g, _ := ss.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
+		Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
+
+	a, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationsByIDs(gomock.Any(), []string{"app-1", "app-2"}).
+		Times(1).Return([]datastore.Application{{UID: "app-1", GroupID: "1234"}}, nil)
+
+	_, _, err := ss.CreateAPIKey(context.Background(), "actor-1", &models.APIKey{
+		Name: "test_api_key",
+		Type: "api",
+		Role: auth.Role{
+			Type:   auth.RoleAdmin,
+			Groups: []string{"1234"},
+			Apps:   []string{"app-1", "app-2"},
+		},
+		NeverExpires: true,
+	})
+
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*ServiceError).ErrCode())
+	require.Equal(t, "cannot find app", err.Error())
+}
+
+func TestSecurityService_CreateAPIKey_NeverExpiresSkipsExpiryValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ss := provideSecurityService(ctrl)
+
+	g, _ := ss.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
+		Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
+
+	a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+	a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
+		Times(1).Return(nil)
+
+	expectAPIKeyAuditLog(ss, datastore.AuditActionCreate).Return(nil)
+
+	// an expiry in the past is ignored for a key that never expires
+	apiKey, key, err := ss.CreateAPIKey(context.Background(), "actor-1", &models.APIKey{
+		Name: "test_api_key",
+		Type: "api",
+		Role: auth.Role{
+			Type:   auth.RoleAdmin,
+			Groups: []string{"1234"},
+		},
+		ExpiresAt:    time.Now().Add(-time.Hour),
+		NeverExpires: true,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+	require.Equal(t, primitive.DateTime(0), apiKey.ExpiresAt)
+}
+
+func TestSecurityService_CreateAPIKey_MaskIDIsPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ss := provideSecurityService(ctrl)
+
+	g, _ := ss.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupsByIDs(gomock.Any(), []string{"1234"}).
+		Times(1).Return([]datastore.Group{{UID: "1234"}}, nil)
+
+	a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+	a.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
+		Times(1).Return(nil)
+
+	expectAPIKeyAuditLog(ss, datastore.AuditActionCreate).Return(nil)
+
+	apiKey, key, err := ss.CreateAPIKey(context.Background(), "actor-1", &models.APIKey{
+		Name: "test_api_key",
+		Type: "api",
+		Role: auth.Role{
+			Type:   auth.RoleAdmin,
+			Groups: []string{"1234"},
+		},
+		NeverExpires: true,
+	})
+	require.NoError(t, err)
+
+	// the native realm finds keys by the segment after the CO. marker, so
+	// it has to be exactly the mask id
+	require.Regexp(t, `^[A-Za-z0-9]{16}$`, apiKey.MaskID)
+	require.True(t, strings.HasPrefix(key, "CO."+apiKey.MaskID+"."))
+}
+
 func TestSecurityService_CreateAppPortalAPIKey(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -369,6 +554,22 @@ func TestSecurityService_RevokeAPIKey(t *testing.T) {
 				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
 				a.EXPECT().RevokeAPIKeys(gomock.Any(), []string{"1234"}).
 					Times(1).Return(nil)
+
+				expectAPIKeyAuditLog(ss, datastore.AuditActionRevoke).Return(nil)
+			},
+		},
+		{
+			name: "should_revoke_api_key_when_audit_log_fails",
+			args: args{
+				ctx: ctx,
+				uid: "1234",
+			},
+			dbFn: func(ss *SecurityService) {
+				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				a.EXPECT().RevokeAPIKeys(gomock.Any(), []string{"1234"}).
+					Times(1).Return(nil)
+
+				expectAPIKeyAuditLog(ss, datastore.AuditActionRevoke).Return(errors.New("failed"))
 			},
 		},
 		{
@@ -407,7 +608,7 @@ func TestSecurityService_RevokeAPIKey(t *testing.T) {
 				tc.dbFn(ss)
 			}
 
-			err := ss.RevokeAPIKey(tc.args.ctx, tc.args.uid)
+			err := ss.RevokeAPIKey(tc.args.ctx, "actor-1", tc.args.uid)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -546,6 +747,8 @@ func TestSecurityService_UpdateAPIKey(t *testing.T) {
 
 				a.EXPECT().UpdateAPIKey(gomock.Any(), gomock.Any()).
 					Times(1).Return(nil)
+
+				expectAPIKeyAuditLog(ss, datastore.AuditActionUpdate).Return(nil)
 			},
 			wantAPIKey: &datastore.APIKey{
 				UID: "ref",
@@ -696,7 +899,7 @@ func TestSecurityService_UpdateAPIKey(t *testing.T) {
 				tc.dbFn(ss)
 			}
 
-			apiKey, err := ss.UpdateAPIKey(tc.args.ctx, tc.args.uid, tc.args.role)
+			apiKey, err := ss.UpdateAPIKey(tc.args.ctx, "actor-1", tc.args.uid, tc.args.role)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
@@ -851,3 +1054,118 @@ func TestSecurityService_GetAPIKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityService_ListAPIKeysByGroup(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		ctx      context.Context
+		groupID  string
+		pageable *datastore.Pageable
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantAPIKeys        []datastore.APIKey
+		dbFn               func(ss *SecurityService)
+		wantPaginationData datastore.PaginationData
+		wantErr            bool
+		wantErrCode        int
+		wantErrMsg         string
+	}{
+		{
+			name: "should_fetch_group_api_keys",
+			args: args{
+				ctx:     ctx,
+				groupID: "avs",
+				pageable: &datastore.Pageable{
+					Page:    2,
+					PerPage: 1,
+					Sort:    1,
+				},
+			},
+			dbFn: func(ss *SecurityService) {
+				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				a.EXPECT().FindAPIKeysByGroupID(gomock.Any(), "avs", &datastore.Pageable{
+					Page:    2,
+					PerPage: 1,
+					Sort:    1,
+				}).
+					Times(1).Return(
+					[]datastore.APIKey{
+						{
+							UID: "ref",
+							Role: auth.Role{
+								Type:   auth.RoleAPI,
+								Groups: []string{"avs"},
+							},
+						},
+					},
+					datastore.PaginationData{
+						Total:     2,
+						Page:      2,
+						PerPage:   1,
+						Prev:      1,
+						Next:      3,
+						TotalPage: 2,
+					}, nil)
+			},
+			wantAPIKeys: []datastore.APIKey{
+				{
+					UID: "ref",
+					Role: auth.Role{
+						Type:   auth.RoleAPI,
+						Groups: []string{"avs"},
+					},
+				},
+			},
+			wantPaginationData: datastore.PaginationData{
+				Total:     2,
+				Page:      2,
+				PerPage:   1,
+				Prev:      1,
+				Next:      3,
+				TotalPage: 2,
+			},
+		},
+		{
+			name: "should_fail_to_fetch_group_api_keys",
+			args: args{
+				ctx:      ctx,
+				groupID:  "avs",
+				pageable: &datastore.Pageable{Page: 1, PerPage: 1, Sort: 1},
+			},
+			dbFn: func(ss *SecurityService) {
+				a, _ := ss.apiKeyRepo.(*mocks.MockAPIKeyRepository)
+				a.EXPECT().FindAPIKeysByGroupID(gomock.Any(), "avs", gomock.Any()).
+					Times(1).
+					Return(nil, datastore.PaginationData{}, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to load api keys",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ss := provideSecurityService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(ss)
+			}
+
+			apiKeys, paginationData, err := ss.ListAPIKeysByGroup(tc.args.ctx, tc.args.groupID, tc.args.pageable)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantAPIKeys, apiKeys)
+			require.Equal(t, tc.wantPaginationData, paginationData)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/server/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type SettingsService struct {
+	settingsRepo datastore.GlobalSettingsRepository
+}
+
+func NewSettingsService(settingsRepo datastore.GlobalSettingsRepository) *SettingsService {
+	return &SettingsService{settingsRepo: settingsRepo}
+}
+
+// GetGlobalSettings returns the defaults new groups are created with.
+func (s *SettingsService) GetGlobalSettings(ctx context.Context) (*datastore.GlobalSettings, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	settings, err := loadGlobalSettings(ctx, s.settingsRepo)
+	if err != nil {
+		log.WithError(err).Error("failed to load global settings")
+		return nil, datastoreError(err, http.StatusInternalServerError, "failed to load global settings")
+	}
+
+	return settings, nil
+}
+
+// UpdateGlobalSettings replaces the defaults new groups are created with,
+// groups that already exist keep theirs.
+func (s *SettingsService) UpdateGlobalSettings(ctx context.Context, update *models.GlobalSettings) (*datastore.GlobalSettings, error) {
+	if update.RateLimit <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("rate limit must be greater than zero"))
+	}
+
+	if d, err := time.ParseDuration(update.RateLimitDuration); err != nil || d <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("please provide a valid rate limit duration"))
+	}
+
+	if update.EventRetentionDays < 0 {
+		return nil, NewServiceError(http.StatusBadRequest, errors.New("event retention days cannot be negative"))
+	}
+
+	settings := &datastore.GlobalSettings{
+		RateLimit:          update.RateLimit,
+		RateLimitDuration:  update.RateLimitDuration,
+		EventRetentionDays: update.EventRetentionDays,
+	}
+
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	err := s.settingsRepo.Update(ctx, settings)
+	if err != nil {
+		log.WithError(err).Error("failed to update global settings")
+		return nil, datastoreError(err, http.StatusInternalServerError, "failed to update global settings")
+	}
+
+	return settings, nil
+}
+
+// loadGlobalSettings returns the stored global settings, or convoy's
+// built in defaults until they are first updated.
+func loadGlobalSettings(ctx context.Context, settingsRepo datastore.GlobalSettingsRepository) (*datastore.GlobalSettings, error) {
+	settings, err := settingsRepo.Get(ctx)
+	if errors.Is(err, datastore.ErrGlobalSettingsNotFound) {
+		return &datastore.GlobalSettings{
+			RateLimit:         convoy.RATE_LIMIT,
+			RateLimitDuration: convoy.RATE_LIMIT_DURATION,
+		}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideSettingsService(ctrl *gomock.Controller) *SettingsService {
+	settingsRepo := mocks.NewMockGlobalSettingsRepository(ctrl)
+	return NewSettingsService(settingsRepo)
+}
+
+func TestSettingsService_GetGlobalSettings_DefaultsUntilUpdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ss := provideSettingsService(ctrl)
+
+	r, _ := ss.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+	r.EXPECT().Get(gomock.Any()).Times(1).Return(nil, datastore.ErrGlobalSettingsNotFound)
+
+	settings, err := ss.GetGlobalSettings(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, convoy.RATE_LIMIT, settings.RateLimit)
+	require.Equal(t, convoy.RATE_LIMIT_DURATION, settings.RateLimitDuration)
+	require.Equal(t, 0, settings.EventRetentionDays)
+}
+
+func TestSettingsService_UpdateGlobalSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      *models.GlobalSettings
+		dbFn        func(ss *SettingsService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:   "should_update_global_settings",
+			update: &models.GlobalSettings{RateLimit: 200, RateLimitDuration: "10m", EventRetentionDays: 30},
+			dbFn: func(ss *SettingsService) {
+				r, _ := ss.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				r.EXPECT().Update(gomock.Any(), &datastore.GlobalSettings{
+					RateLimit:          200,
+					RateLimitDuration:  "10m",
+					EventRetentionDays: 30,
+				}).Times(1).Return(nil)
+			},
+		},
+		{
+			name:        "should_error_for_zero_rate_limit",
+			update:      &models.GlobalSettings{RateLimitDuration: "1m"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rate limit must be greater than zero",
+		},
+		{
+			name:        "should_error_for_invalid_rate_limit_duration",
+			update:      &models.GlobalSettings{RateLimit: 200, RateLimitDuration: "a minute"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide a valid rate limit duration",
+		},
+		{
+			name:        "should_error_for_negative_retention",
+			update:      &models.GlobalSettings{RateLimit: 200, RateLimitDuration: "1m", EventRetentionDays: -1},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "event retention days cannot be negative",
+		},
+		{
+			name:   "should_fail_to_update_global_settings",
+			update: &models.GlobalSettings{RateLimit: 200, RateLimitDuration: "1m"},
+			dbFn: func(ss *SettingsService) {
+				r, _ := ss.settingsRepo.(*mocks.MockGlobalSettingsRepository)
+				r.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "failed to update global settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ss := provideSettingsService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(ss)
+			}
+
+			settings, err := ss.UpdateGlobalSettings(context.Background(), tc.update)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.update.RateLimit, settings.RateLimit)
+			require.Equal(t, tc.update.RateLimitDuration, settings.RateLimitDuration)
+			require.Equal(t, tc.update.EventRetentionDays, settings.EventRetentionDays)
+		})
+	}
+}
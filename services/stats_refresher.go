@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+)
+
+// StatsRefresher recomputes the statistics of every group on an interval
+// and writes them to the cache, so fetching a group never has to count
+// its apps and messages.
+type StatsRefresher struct {
+	groupService *GroupService
+	interval     time.Duration
+	workers      int
+	quit         chan chan error
+}
+
+func NewStatsRefresher(groupService *GroupService, interval time.Duration, workers int) *StatsRefresher {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &StatsRefresher{
+		groupService: groupService,
+		interval:     interval,
+		workers:      workers,
+		quit:         make(chan chan error),
+	}
+}
+
+// Start refreshes the statistics once, then again on every interval until
+// ctx is done or Close is called.
+func (s *StatsRefresher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.Refresh(ctx)
+
+		for {
+			select {
+			case <-ticker.C:
+				s.Refresh(ctx)
+			case ch := <-s.quit:
+				ch <- nil
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the refresher, waiting for a refresh in progress to finish.
+func (s *StatsRefresher) Close() error {
+	ch := make(chan error)
+	s.quit <- ch
+	return <-ch
+}
+
+// Refresh recomputes and caches the statistics of all groups that have
+// not been deleted, spread across the refresher's workers.
+func (s *StatsRefresher) Refresh(ctx context.Context) {
+	groups, err := s.groupService.groupRepo.LoadGroups(ctx, &datastore.GroupFilter{})
+	if err != nil {
+		log.WithError(err).Error("stats refresher: failed to load groups")
+		return
+	}
+
+	groupChan := make(chan *datastore.Group)
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for g := range groupChan {
+				s.refreshGroup(ctx, g)
+			}
+		}()
+	}
+
+	for _, g := range groups {
+		if g.IsDeleted() || g.DocumentStatus == datastore.DeletedDocumentStatus {
+			continue
+		}
+		groupChan <- g
+	}
+
+	close(groupChan)
+	wg.Wait()
+}
+
+func (s *StatsRefresher) refreshGroup(ctx context.Context, g *datastore.Group) {
+	err := s.groupService.FillGroupStatistics(ctx, g, false)
+	if err != nil {
+		log.WithError(err).Errorf("stats refresher: failed to compute statistics of group %s", g.UID)
+		return
+	}
+
+	// keep the entry around for two intervals, so it outlives a slow refresh
+	statisticsCacheKey := convoy.GroupStatisticsCacheKey.Get(g.UID).String()
+	err = s.groupService.cache.Set(ctx, statisticsCacheKey, g.Statistics, 2*s.interval)
+	if err != nil {
+		log.WithError(err).Errorf("stats refresher: failed to cache statistics of group %s", g.UID)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStatsRefresher_RefreshesOnInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gs := provideGroupService(ctrl)
+
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().LoadGroups(gomock.Any(), gomock.Any()).MinTimes(3).
+		DoAndReturn(func(context.Context, *datastore.GroupFilter) ([]*datastore.Group, error) {
+			return []*datastore.Group{{UID: "12345"}}, nil
+		})
+
+	a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().CountGroupApplications(gomock.Any(), "12345").MinTimes(3).Return(int64(2), nil)
+
+	e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CountGroupMessages(gomock.Any(), "12345").MinTimes(3).Return(int64(10), nil)
+
+	d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "12345").MinTimes(3).Return(int64(4), nil)
+
+	refreshed := make(chan *datastore.GroupStatistics, 10)
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Set(gomock.Any(), "group_statistics:12345", gomock.Any(), 20*time.Millisecond).MinTimes(3).
+		DoAndReturn(func(_ context.Context, _ string, data interface{}, _ time.Duration) error {
+			select {
+			case refreshed <- data.(*datastore.GroupStatistics):
+			default:
+			}
+			return nil
+		})
+
+	s := NewStatsRefresher(gs, 10*time.Millisecond, 2)
+	s.Start(context.Background())
+
+	for i := 0; i < 3; i++ {
+		select {
+		case stats := <-refreshed:
+			require.Equal(t, &datastore.GroupStatistics{MessagesSent: 10, TotalApps: 2, PendingDeliveries: 4}, stats)
+		case <-time.After(time.Second):
+			t.Fatalf("statistics were refreshed %d times, want 3", i)
+		}
+	}
+
+	require.NoError(t, s.Close())
+}
+
+func TestStatsRefresher_SkipsDeletedGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gs := provideGroupService(ctrl)
+
+	g, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().LoadGroups(gomock.Any(), gomock.Any()).Times(1).
+		Return([]*datastore.Group{
+			{UID: "active"},
+			{UID: "soft-deleted", DeletedAt: primitive.NewDateTimeFromTime(time.Now())},
+			{UID: "deleted", DocumentStatus: datastore.DeletedDocumentStatus},
+		}, nil)
+
+	a, _ := gs.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().CountGroupApplications(gomock.Any(), "active").Times(1).Return(int64(1), nil)
+
+	e, _ := gs.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CountGroupMessages(gomock.Any(), "active").Times(1).Return(int64(1), nil)
+
+	d, _ := gs.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	d.EXPECT().CountPendingDeliveriesByGroup(gomock.Any(), "active").Times(1).Return(int64(0), nil)
+
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Set(gomock.Any(), "group_statistics:active", gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	NewStatsRefresher(gs, time.Minute, 4).Refresh(context.Background())
+}
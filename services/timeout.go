@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// withQueryTimeout bounds ctx by the configured database query timeout,
+// so a slow query fails the request instead of holding it open.
+func withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, config.DatabaseConfiguration.GetQueryTimeout, config.DefaultQueryTimeout)
+}
+
+// withBulkWriteTimeout is withQueryTimeout for operations that write
+// many documents.
+func withBulkWriteTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, config.DatabaseConfiguration.GetBulkWriteTimeout, config.DefaultBulkWriteTimeout)
+}
+
+func withTimeout(ctx context.Context, get func(config.DatabaseConfiguration) (time.Duration, error), fallback time.Duration) (context.Context, context.CancelFunc) {
+	timeout := fallback
+
+	cfg, err := config.Get()
+	if err == nil {
+		timeout, err = get(cfg.Database)
+		if err != nil {
+			log.WithError(err).Warnf("using the default timeout of %s", fallback)
+			timeout = fallback
+		}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// datastoreError reports a failed repository call as msg with code, or
+// as a gateway timeout when the call ran out of time.
+func datastoreError(err error, code int, msg string) *ServiceError {
+	if errors.Is(err, context.DeadlineExceeded) || mongo.IsTimeout(err) {
+		return NewServiceErrorWithCode(DatabaseTimeoutErrorCode, errors.New("the database took too long to respond, please try again"))
+	}
+
+	return NewServiceError(code, errors.New(msg))
+}
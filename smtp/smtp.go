@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"html/template"
+	"time"
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/util"
@@ -65,6 +66,34 @@ func New(cfg *config.SMTPConfiguration) (*SmtpClient, error) {
 }
 
 func (s *SmtpClient) SendEmailNotification(email, logoURL, targetURL string, status string) error {
+	return s.sendEndpointUpdate(email, endpointUpdate{
+		URL:     targetURL,
+		LogoURL: logoURL,
+		Status:  status,
+	})
+}
+
+// SendEndpointDisabledEmail tells email that the endpoint at targetURL
+// was disabled at disabledAt, and why.
+func (s *SmtpClient) SendEndpointDisabledEmail(email, logoURL, targetURL, reason string, disabledAt time.Time) error {
+	return s.sendEndpointUpdate(email, endpointUpdate{
+		URL:        targetURL,
+		LogoURL:    logoURL,
+		Status:     "inactive",
+		Reason:     reason,
+		DisabledAt: disabledAt.UTC().Format(time.RFC1123),
+	})
+}
+
+type endpointUpdate struct {
+	URL        string
+	LogoURL    string
+	Status     string
+	Reason     string
+	DisabledAt string
+}
+
+func (s *SmtpClient) sendEndpointUpdate(email string, data endpointUpdate) error {
 	// Compose Message
 	m := s.setHeaders(email)
 
@@ -73,16 +102,7 @@ func (s *SmtpClient) SendEmailNotification(email, logoURL, targetURL string, sta
 
 	// Set data.
 	var body bytes.Buffer
-	err := templ.Execute(&body, struct {
-		URL     string
-		LogoURL string
-		Status  string
-	}{
-		URL:     targetURL,
-		LogoURL: logoURL,
-		Status:  status,
-	})
-
+	err := templ.Execute(&body, data)
 	if err != nil {
 		log.WithError(err).Error("Failed to build template")
 		return err
@@ -0,0 +1,28 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/services"
+)
+
+// NewIngestHandler returns an EventHandler that creates events the way the
+// create event endpoint does, in the group of the event's app.
+func NewIngestHandler(appRepo datastore.ApplicationRepository, groupRepo datastore.GroupRepository, eventService *services.EventService) EventHandler {
+	return func(ctx context.Context, event *models.Event) error {
+		app, err := appRepo.FindApplicationByID(ctx, event.AppID)
+		if err != nil {
+			return err
+		}
+
+		g, err := groupRepo.FetchGroupByID(ctx, app.GroupID)
+		if err != nil {
+			return err
+		}
+
+		_, err = eventService.CreateAppEvent(ctx, event, g)
+		return err
+	}
+}
@@ -0,0 +1,32 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/server/models"
+)
+
+// EventHandler ingests an event read from a source. A source only
+// acknowledges an event once its handler returns without an error.
+type EventHandler func(ctx context.Context, event *models.Event) error
+
+// EventSource reads events from outside convoy and passes each to a handler
+// until ctx is done.
+type EventSource interface {
+	Listen(ctx context.Context, handler EventHandler) error
+}
+
+// NewEventSource returns nil when no source is configured.
+func NewEventSource(cfg config.SourceConfiguration) (EventSource, error) {
+	if cfg.Type == config.SQSSourceProvider {
+		src, err := NewSQSSource(cfg.SQS)
+		if err != nil {
+			return nil, err
+		}
+
+		return src, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,180 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/server/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultSQSRegion = "us-east-1"
+
+type SQSMessage struct {
+	MessageID     string `json:"MessageId"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+	Body          string `json:"Body"`
+}
+
+// SQSClient is the part of the SQS api used by SQSSource.
+type SQSClient interface {
+	ReceiveMessage(ctx context.Context, queueURL string, maxMessages int) ([]SQSMessage, error)
+	DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error
+}
+
+// SQSSource polls an SQS queue for events. Each message body is an event as
+// sent to the create event endpoint, a message is deleted from the queue
+// once its event has been ingested and is otherwise left to be redelivered.
+// A message that isn't an event would fail the same way on every delivery,
+// so it is deleted rather than redelivered.
+type SQSSource struct {
+	client       SQSClient
+	queueURL     string
+	maxMessages  int
+	pollInterval time.Duration
+}
+
+func NewSQSSource(cfg config.SQSSourceConfiguration) (*SQSSource, error) {
+	u, err := url.Parse(cfg.QueueURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid sqs queue url: %s", cfg.QueueURL)
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+
+	if cfg.AccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, cfg.SessionToken)))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+
+	if awsCfg.Region == "" {
+		awsCfg.Region = defaultSQSRegion
+	}
+
+	// the queue url holds the endpoint to call, which also covers queues
+	// outside AWS's own endpoints, such as a local SQS compatible server
+	endpoint := aws.Endpoint{URL: u.Scheme + "://" + u.Host}
+	client := &sqsClient{
+		client: sqs.NewFromConfig(awsCfg, func(o *sqs.Options) {
+			o.EndpointResolver = sqs.EndpointResolverFunc(func(region string, _ sqs.EndpointResolverOptions) (aws.Endpoint, error) {
+				endpoint.SigningRegion = region
+				return endpoint, nil
+			})
+		}),
+	}
+
+	return newSQSSource(client, cfg.QueueURL, cfg.MaxMessages, time.Duration(cfg.PollIntervalSeconds)*time.Second), nil
+}
+
+func newSQSSource(client SQSClient, queueURL string, maxMessages int, pollInterval time.Duration) *SQSSource {
+	return &SQSSource{
+		client:       client,
+		queueURL:     queueURL,
+		maxMessages:  maxMessages,
+		pollInterval: pollInterval,
+	}
+}
+
+// Listen polls the queue until ctx is done. A failed poll is retried after
+// the poll interval.
+func (s *SQSSource) Listen(ctx context.Context, handler EventHandler) error {
+	for {
+		messages, err := s.client.ReceiveMessage(ctx, s.queueURL, s.maxMessages)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("failed to receive sqs messages")
+		}
+
+		for _, msg := range messages {
+			s.process(ctx, msg, handler)
+		}
+
+		if len(messages) > 0 && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func (s *SQSSource) process(ctx context.Context, msg SQSMessage, handler EventHandler) {
+	var event models.Event
+	err := json.Unmarshal([]byte(msg.Body), &event)
+	if err != nil {
+		log.WithError(err).Errorf("failed to parse sqs message %s, deleting it", msg.MessageID)
+		s.delete(ctx, msg)
+		return
+	}
+
+	err = handler(ctx, &event)
+	if err != nil {
+		log.WithError(err).Errorf("failed to ingest sqs message %s", msg.MessageID)
+		return
+	}
+
+	s.delete(ctx, msg)
+}
+
+func (s *SQSSource) delete(ctx context.Context, msg SQSMessage) {
+	err := s.client.DeleteMessage(ctx, s.queueURL, msg.ReceiptHandle)
+	if err != nil {
+		log.WithError(err).Errorf("failed to delete sqs message %s", msg.MessageID)
+	}
+}
+
+// sqsClient is the SQSClient of the AWS SDK.
+type sqsClient struct {
+	client *sqs.Client
+}
+
+func (c *sqsClient) ReceiveMessage(ctx context.Context, queueURL string, maxMessages int) ([]SQSMessage, error) {
+	out, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: int32(maxMessages),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]SQSMessage, len(out.Messages))
+	for i, m := range out.Messages {
+		messages[i] = SQSMessage{
+			MessageID:     aws.ToString(m.MessageId),
+			ReceiptHandle: aws.ToString(m.ReceiptHandle),
+			Body:          aws.ToString(m.Body),
+		}
+	}
+
+	return messages, nil
+}
+
+func (c *sqsClient) DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error {
+	_, err := c.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+	return err
+}
@@ -0,0 +1,142 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSQSClient struct {
+	mu       sync.Mutex
+	messages []SQSMessage
+	deleted  []string
+}
+
+func (f *fakeSQSClient) ReceiveMessage(_ context.Context, _ string, maxMessages int) ([]SQSMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := len(f.messages)
+	if n > maxMessages {
+		n = maxMessages
+	}
+
+	messages := f.messages[:n]
+	f.messages = f.messages[n:]
+	return messages, nil
+}
+
+func (f *fakeSQSClient) DeleteMessage(_ context.Context, _ string, receiptHandle string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deleted = append(f.deleted, receiptHandle)
+	return nil
+}
+
+func (f *fakeSQSClient) deletedHandles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.deleted...)
+}
+
+func TestSQSSource_ProcessesMessageAndDeletesOnSuccess(t *testing.T) {
+	client := &fakeSQSClient{messages: []SQSMessage{
+		{MessageID: "1", ReceiptHandle: "handle-1", Body: `{"app_id":"app-1","event_type":"payment.created","data":{"amount":100}}`},
+		{MessageID: "2", ReceiptHandle: "handle-2", Body: `{"app_id":"app-2","event_type":"payment.failed","data":{}}`},
+		{MessageID: "3", ReceiptHandle: "handle-3", Body: `not json`},
+	}}
+
+	src := newSQSSource(client, "https://sqs.us-east-1.amazonaws.com/123456789012/events", 2, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ingested := make(chan *models.Event, 3)
+	done := make(chan error)
+	go func() {
+		done <- src.Listen(ctx, func(_ context.Context, event *models.Event) error {
+			ingested <- event
+			if event.AppID == "app-2" {
+				return errors.New("app not found")
+			}
+			return nil
+		})
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-ingested:
+			if i == 0 {
+				require.Equal(t, "payment.created", event.EventType)
+				require.JSONEq(t, `{"amount":100}`, string(event.Data))
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%d events were ingested, want 2", i)
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return len(client.messages) == 0
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	// the ingested message is removed from the queue, and so is the one
+	// that isn't an event, the failed one is left to be redelivered
+	require.Equal(t, []string{"handle-1", "handle-3"}, client.deletedHandles())
+}
+
+func TestSQSClient_ReceiveAndDeleteMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/"))
+		require.Equal(t, "session-token", r.Header.Get("X-Amz-Security-Token"))
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/events", r.PostForm.Get("QueueUrl"))
+
+		switch r.PostForm.Get("Action") {
+		case "ReceiveMessage":
+			require.Equal(t, "5", r.PostForm.Get("MaxNumberOfMessages"))
+			_, _ = w.Write([]byte(`<ReceiveMessageResponse><ReceiveMessageResult><Message>` +
+				`<MessageId>1</MessageId><ReceiptHandle>handle-1</ReceiptHandle><Body>{}</Body>` +
+				`</Message></ReceiveMessageResult></ReceiveMessageResponse>`))
+		case "DeleteMessage":
+			require.Equal(t, "handle-1", r.PostForm.Get("ReceiptHandle"))
+			_, _ = w.Write([]byte(`<DeleteMessageResponse></DeleteMessageResponse>`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := NewSQSSource(config.SQSSourceConfiguration{
+		QueueURL:     srv.URL + "/123456789012/events",
+		AccessKey:    "access-key",
+		SecretKey:    "secret-key",
+		SessionToken: "session-token",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/events"
+
+	messages, err := src.client.ReceiveMessage(ctx, queueURL, 5)
+	require.NoError(t, err)
+	require.Equal(t, []SQSMessage{{MessageID: "1", ReceiptHandle: "handle-1", Body: "{}"}}, messages)
+
+	require.NoError(t, src.client.DeleteMessage(ctx, queueURL, "handle-1"))
+}
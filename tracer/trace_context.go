@@ -0,0 +1,94 @@
+package tracer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Headers of the W3C Trace Context, https://www.w3.org/TR/trace-context/
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+type traceContextKey struct{}
+
+// TraceContext is the W3C trace context of a request, it is stored with
+// the events the request creates so their webhooks carry it on.
+type TraceContext struct {
+	TraceParent string
+	TraceState  string
+}
+
+// TraceContextMiddleware reads the trace context of inbound requests into
+// their context. Requests without a valid traceparent start a new trace.
+func TraceContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc := TraceContext{
+			TraceParent: strings.TrimSpace(r.Header.Get(TraceParentHeader)),
+			TraceState:  strings.TrimSpace(r.Header.Get(TraceStateHeader)),
+		}
+
+		if !IsValidTraceParent(tc.TraceParent) {
+			// tracestate is meaningless without the traceparent it
+			// belongs to, so it goes along with the invalid one.
+			tc = TraceContext{TraceParent: NewTraceParent()}
+			r.Header.Set(TraceParentHeader, tc.TraceParent)
+			r.Header.Del(TraceStateHeader)
+		}
+
+		next.ServeHTTP(w, r.WithContext(NewTraceContext(r.Context(), tc)))
+	})
+}
+
+// NewTraceContext returns a copy of ctx carrying tc.
+func NewTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace context stored in ctx, if any.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// NewTraceParent returns the traceparent of a new, sampled trace.
+func NewTraceParent() string {
+	return fmt.Sprintf("00-%s-%s-01", randomHex(16), randomHex(8))
+}
+
+// IsValidTraceParent reports whether s is a version 00 traceparent,
+// version-traceid-parentid-flags with non zero ids.
+func IsValidTraceParent(s string) bool {
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return false
+	}
+
+	for i, size := range []int{2, 32, 16, 2} {
+		if len(parts[i]) != size || !isLowerHex(parts[i]) {
+			return false
+		}
+	}
+
+	return strings.Trim(parts[1], "0") != "" && strings.Trim(parts[2], "0") != ""
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
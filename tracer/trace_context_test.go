@@ -0,0 +1,66 @@
+package tracer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func serveWithTraceContext(t *testing.T, req *http.Request) (TraceContext, *http.Request) {
+	var (
+		tc       TraceContext
+		upstream *http.Request
+	)
+
+	h := TraceContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		tc, ok = TraceContextFromContext(r.Context())
+		require.True(t, ok)
+		upstream = r
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	return tc, upstream
+}
+
+func TestTraceContextMiddleware_ForwardsExistingTraceParent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("tracestate", "vendor=opaque")
+
+	tc, _ := serveWithTraceContext(t, req)
+
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", tc.TraceParent)
+	require.Equal(t, "vendor=opaque", tc.TraceState)
+}
+
+func TestTraceContextMiddleware_GeneratesMissingTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+	}{
+		{name: "missing", traceParent: ""},
+		{name: "malformed", traceParent: "not-a-traceparent"},
+		{name: "zero trace id", traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "unknown version", traceParent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/events", nil)
+			if tt.traceParent != "" {
+				req.Header.Set("traceparent", tt.traceParent)
+			}
+			req.Header.Set("tracestate", "vendor=opaque")
+
+			tc, upstream := serveWithTraceContext(t, req)
+
+			require.True(t, IsValidTraceParent(tc.TraceParent))
+			require.NotEqual(t, tt.traceParent, tc.TraceParent)
+			require.Empty(t, tc.TraceState)
+			require.Equal(t, tc.TraceParent, upstream.Header.Get("traceparent"))
+		})
+	}
+}
@@ -62,11 +62,18 @@ func GetVersion() string {
 }
 
 const (
-	EventProcessor       TaskName = "EventProcessor"
-	DeadLetterProcessor  TaskName = "DeadLetterProcessor"
-	CreateEventProcessor TaskName = "CreateEventProcessor"
-	ApplicationsCacheKey CacheKey = "applications"
-	GroupsCacheKey       CacheKey = "groups"
+	EventProcessor            TaskName = "EventProcessor"
+	DeadLetterProcessor       TaskName = "DeadLetterProcessor"
+	CreateEventProcessor      TaskName = "CreateEventProcessor"
+	ApplicationsCacheKey      CacheKey = "applications"
+	GroupsCacheKey            CacheKey = "groups"
+	GroupStatisticsCacheKey   CacheKey = "group_statistics"
+	EventFingerprintsCacheKey CacheKey = "event_fingerprints"
+	EventIdempotencyCacheKey  CacheKey = "event_idempotency_keys"
+	AppStatisticsCacheKey     CacheKey = "app_statistics"
+	EventTypesCacheKey        CacheKey = "event_types"
+	MissingGroupsCacheKey     CacheKey = "missing_groups"
+	MissingAppsCacheKey       CacheKey = "missing_applications"
 )
 
 const (
@@ -268,3 +268,23 @@ func Test_computeJSONHmac_DifferentHashes(t *testing.T) {
 		})
 	}
 }
+
+func Test_EncryptDecrypt(t *testing.T) {
+	ciphertext, err := Encrypt("s3cret", "key-1")
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	plaintext, err := Decrypt(ciphertext, "key-1")
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if plaintext != "s3cret" {
+		t.Errorf("want plaintext 's3cret', got '%s'", plaintext)
+	}
+
+	if _, err := Decrypt(ciphertext, "key-2"); err == nil {
+		t.Error("want decrypting with another key to fail")
+	}
+}
@@ -8,6 +8,34 @@ import (
 	"net/http"
 )
 
+var ErrBodyTooLarge = errors.New("body is too large")
+
+// MaxBytesReader is like http.MaxBytesReader, but reading past n fails
+// with an error wrapping ErrBodyTooLarge, which can be told apart from
+// other read errors.
+func MaxBytesReader(w http.ResponseWriter, r io.ReadCloser, n int64) io.ReadCloser {
+	return &maxBytesReader{ReadCloser: http.MaxBytesReader(w, r, n), limit: n}
+}
+
+type maxBytesReader struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	n, err := m.ReadCloser.Read(p)
+	m.read += int64(n)
+
+	// http.MaxBytesReader hands out the first limit bytes and fails the
+	// read after them with an error that isn't exported
+	if err != nil && err != io.EOF && m.read >= m.limit {
+		err = fmt.Errorf("%w, the limit is %d bytes", ErrBodyTooLarge, m.limit)
+	}
+
+	return n, err
+}
+
 func IsJSON(s string) bool {
 	var js map[string]interface{}
 	return json.Unmarshal([]byte(s), &js) == nil
@@ -46,6 +74,9 @@ func ReadJSON(r *http.Request, dst interface{}) error {
 		case errors.Is(err, io.EOF):
 			return errors.New("body must not be empty")
 
+		case errors.Is(err, ErrBodyTooLarge):
+			return err
+
 		default:
 			return err
 		}
@@ -9,6 +9,17 @@ import (
 	"github.com/frain-dev/convoy/config/algo"
 )
 
+// ValidateByField validates dst and returns the error messages keyed by
+// field, or nil if dst is valid.
+func ValidateByField(dst interface{}) map[string]string {
+	_, err := govalidator.ValidateStruct(dst)
+	if err != nil {
+		return govalidator.ErrorsByField(err)
+	}
+
+	return nil
+}
+
 func Validate(dst interface{}) error {
 	_, err := govalidator.ValidateStruct(dst)
 
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// The handlers are annotated the way swag reads them, see
+// https://github.com/swaggo/swag#declarative-comments-format.
+var (
+	paramRe    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)(?:\s+"(.*)")?$`)
+	responseRe = regexp.MustCompile(`^([\d,]+)\s+\{(\w+)\}\s+(\S+)(?:\s+"(.*)")?$`)
+	routerRe   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+)
+
+// annotations returns the lines of a comment that start with an @, keyed
+// by the annotation, in the order they're written.
+func annotations(doc *ast.CommentGroup) map[string][]string {
+	values := map[string][]string{}
+	if doc == nil {
+		return values
+	}
+
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		name := line
+		value := ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			name, value = line[:i], strings.TrimSpace(line[i:])
+		}
+
+		values[name] = append(values[name], value)
+	}
+
+	return values
+}
+
+func first(values map[string][]string, name string) string {
+	if v := values[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// addInfo reads the general api info from the comments of the package's
+// main.go.
+func (g *generator) addInfo(p *pkg) {
+	values := map[string][]string{}
+	for _, f := range p.files {
+		if filepath.Base(g.fset.File(f.Pos()).Name()) != "main.go" {
+			continue
+		}
+
+		for _, c := range f.Comments {
+			for k, v := range annotations(c) {
+				values[k] = append(values[k], v...)
+			}
+		}
+	}
+
+	g.doc.Info = &openapi3.Info{
+		Title:          first(values, "@title"),
+		Version:        first(values, "@version"),
+		Description:    first(values, "@description"),
+		TermsOfService: first(values, "@termsOfService"),
+		Contact: &openapi3.Contact{
+			Name:  first(values, "@contact.name"),
+			URL:   first(values, "@contact.url"),
+			Email: first(values, "@contact.email"),
+		},
+		License: &openapi3.License{
+			Name: first(values, "@license.name"),
+			URL:  first(values, "@license.url"),
+		},
+	}
+
+	for _, scheme := range strings.Fields(first(values, "@schemes")) {
+		g.doc.Servers = append(g.doc.Servers, &openapi3.Server{
+			URL: fmt.Sprintf("%s://%s%s", scheme, first(values, "@host"), first(values, "@BasePath")),
+		})
+	}
+
+	names, descriptions := values["@tag.name"], values["@tag.description"]
+	for i, name := range names {
+		tag := &openapi3.Tag{Name: name}
+		if i < len(descriptions) {
+			tag.Description = descriptions[i]
+		}
+		g.doc.Tags = append(g.doc.Tags, tag)
+	}
+}
+
+// addOperations adds an operation for every function of the package that
+// has a @Router annotation.
+func (g *generator) addOperations(p *pkg) error {
+	var funcs []*ast.FuncDecl
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil {
+				funcs = append(funcs, fn)
+			}
+		}
+	}
+
+	// the files of a package come out of a map
+	sort.Slice(funcs, func(i, j int) bool {
+		return g.fset.Position(funcs[i].Pos()).String() < g.fset.Position(funcs[j].Pos()).String()
+	})
+
+	for _, fn := range funcs {
+		values := annotations(fn.Doc)
+		router := first(values, "@Router")
+		if router == "" {
+			continue
+		}
+
+		m := routerRe.FindStringSubmatch(router)
+		if m == nil {
+			return fmt.Errorf("%s: invalid @Router %q", fn.Name.Name, router)
+		}
+		route, method := m[1], strings.ToUpper(m[2])
+
+		if item := g.doc.Paths[route]; item != nil && item.GetOperation(method) != nil {
+			return fmt.Errorf("%s: %s %s is annotated twice", fn.Name.Name, method, route)
+		}
+
+		op, err := g.operation(p, fn.Name.Name, values)
+		if err != nil {
+			return errors.Wrap(err, fn.Name.Name)
+		}
+
+		g.doc.AddOperation(route, method, op)
+	}
+
+	return nil
+}
+
+func (g *generator) operation(p *pkg, name string, values map[string][]string) (*openapi3.Operation, error) {
+	op := &openapi3.Operation{
+		OperationID: name,
+		Summary:     first(values, "@Summary"),
+		Description: first(values, "@Description"),
+		Responses:   openapi3.Responses{},
+	}
+
+	for _, tag := range strings.Split(first(values, "@Tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			op.Tags = append(op.Tags, tag)
+		}
+	}
+
+	consumes := mimeTypes(first(values, "@Accept"))
+	produces := mimeTypes(first(values, "@Produce"))
+
+	for _, param := range values["@Param"] {
+		m := paramRe.FindStringSubmatch(param)
+		if m == nil {
+			return nil, fmt.Errorf("invalid @Param %q", param)
+		}
+		paramName, in, typ, description := m[1], m[2], m[3], m[5]
+		required := m[4] == "true"
+
+		s, err := g.annotationSchema(p, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		if in == "body" {
+			op.RequestBody = &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithDescription(description).
+					WithRequired(required).
+					WithSchemaRef(s, consumes),
+			}
+			continue
+		}
+
+		parameter := &openapi3.Parameter{
+			Name:        paramName,
+			In:          in,
+			Description: description,
+			Required:    required,
+			Schema:      s,
+		}
+
+		if typ == "object" {
+			// e.g metadata[key]=value
+			explode := true
+			parameter.Style, parameter.Explode = openapi3.SerializationDeepObject, &explode
+		}
+
+		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: parameter})
+	}
+
+	for _, annotation := range []string{"@Success", "@Failure"} {
+		for _, response := range values[annotation] {
+			m := responseRe.FindStringSubmatch(response)
+			if m == nil {
+				return nil, fmt.Errorf("invalid %s %q", annotation, response)
+			}
+
+			s, err := g.annotationSchema(p, m[3])
+			if err != nil {
+				return nil, err
+			}
+
+			if m[2] == "array" {
+				items := s
+				s = openapi3.NewArraySchema().NewRef()
+				s.Value.Items = items
+			}
+
+			for _, code := range strings.Split(m[1], ",") {
+				description := m[4]
+				if description == "" {
+					n, _ := strconv.Atoi(code)
+					description = http.StatusText(n)
+				}
+
+				op.Responses[code] = &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().
+						WithDescription(description).
+						WithContent(openapi3.NewContentWithSchemaRef(s, produces)),
+				}
+			}
+		}
+	}
+
+	if len(values["@Security"]) > 0 {
+		security := g.security
+		op.Security = &security
+	}
+
+	return op, nil
+}
+
+// annotationSchema builds the schema of a type written the way swag reads
+// it, e.g serverResponse{data=pagedResponse{content=[]datastore.Application}}
+// is a serverResponse whose data is a pagedResponse of applications.
+func (g *generator) annotationSchema(p *pkg, expr string) (*openapi3.SchemaRef, error) {
+	if strings.HasPrefix(expr, "[]") {
+		items, err := g.annotationSchema(p, expr[2:])
+		if err != nil {
+			return nil, err
+		}
+
+		s := openapi3.NewArraySchema()
+		s.Items = items
+		return s.NewRef(), nil
+	}
+
+	base, overrides := expr, ""
+	if i := strings.Index(expr, "{"); i >= 0 {
+		if !strings.HasSuffix(expr, "}") {
+			return nil, fmt.Errorf("invalid type %q", expr)
+		}
+		base, overrides = expr[:i], expr[i+1:len(expr)-1]
+	}
+
+	s := openapi3.NewSchemaRef("", builtinSchema(base))
+	if s.Value == nil {
+		var err error
+		s, err = g.namedSchema(p, p.imports, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if overrides == "" {
+		return s, nil
+	}
+
+	props := openapi3.NewObjectSchema()
+	for _, override := range splitTopLevel(overrides) {
+		kv := strings.SplitN(override, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid field %q in type %q", override, expr)
+		}
+
+		fs, err := g.annotationSchema(p, kv[1])
+		if err != nil {
+			return nil, err
+		}
+		props.Properties[kv[0]] = fs
+	}
+
+	all := openapi3.NewSchema()
+	all.AllOf = openapi3.SchemaRefs{s, props.NewRef()}
+
+	return all.NewRef(), nil
+}
+
+// splitTopLevel splits s at the commas that aren't inside braces.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func mimeTypes(value string) []string {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		switch t = strings.TrimSpace(t); t {
+		case "":
+		case "json":
+			types = append(types, "application/json")
+		case "plain":
+			types = append(types, "text/plain")
+		default:
+			types = append(types, t)
+		}
+	}
+
+	if len(types) == 0 {
+		return []string{"application/json"}
+	}
+
+	return types
+}
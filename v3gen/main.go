@@ -1,13 +1,17 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"go/token"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/getkin/kin-openapi/openapi2"
-	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/frain-dev/convoy/config"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
@@ -15,84 +19,204 @@ import (
 )
 
 const (
-	v2Fame      = "docs/swagger.json"
-	v3FnameJSON = "docs/v3/openapi3.json"
-	v3FnameYAML = "docs/v3/openapi3.yaml"
+	v3FnameJSON = "openapi3.json"
+	v3FnameYAML = "openapi3.yaml"
+
+	// handlersPath is the package, relative to the module, whose
+	// annotated handlers are documented.
+	handlersPath = "server"
 )
 
+const (
+	apiKeySecurityScheme = "ApiKeyAuth"
+	basicSecurityScheme  = "BasicAuth"
+)
+
+type generator struct {
+	root   string
+	module string
+	fset   *token.FileSet
+	pkgs   map[string]*pkg
+	doc    *openapi3.T
+
+	// security is the requirement of the operations annotated with
+	// @Security, any realm of the chain can authenticate them.
+	security openapi3.SecurityRequirements
+}
+
 func main() {
-	docV2, err := loadV2()
-	if err != nil {
-		log.WithError(err).Fatal("loadV2 failed")
+	root := flag.String("root", ".", "root directory of the module")
+	out := flag.String("out", "docs/v3", "directory the spec is written to")
+	cfgPath := flag.String("config", "", "configuration file whose auth realms are documented, every realm is when empty")
+	flag.Parse()
+
+	authCfg := everyRealm()
+	if *cfgPath != "" {
+		err := config.LoadConfig(*cfgPath)
+		if err != nil {
+			log.WithError(err).Fatal("failed to load config")
+		}
+
+		cfg, err := config.Get()
+		if err != nil {
+			log.WithError(err).Fatal("failed to load config")
+		}
+		authCfg = cfg.Auth
 	}
 
-	docV3, err := convertToV3(docV2)
+	docV3, err := generate(*root, authCfg)
 	if err != nil {
-		log.WithError(err).Fatal("convertToV3 failed")
+		log.WithError(err).Fatal("generate failed")
 	}
 
-	err = writeOutDocV3(docV3)
+	err = writeOutDocV3(docV3, *out)
 	if err != nil {
 		log.WithError(err).Fatal("writeOutDocV3 failed")
 	}
 }
 
-func loadV2() (*openapi2.T, error) {
-	f, err := os.Open(v2Fame)
+// everyRealm is an auth configuration with every realm the realm chain
+// can be built with enabled.
+func everyRealm() config.AuthConfiguration {
+	return config.AuthConfiguration{
+		RequireAuth: true,
+		File: config.FileRealmOption{
+			Basic:  config.BasicAuthConfig{{}},
+			APIKey: config.APIKeyAuthConfig{{}},
+		},
+		Native: config.NativeRealmOptions{Enabled: true},
+	}
+}
+
+// generate builds the spec from the annotations on the module's handlers,
+// documenting the security schemes of the realms in authCfg.
+func generate(root string, authCfg config.AuthConfiguration) (*openapi3.T, error) {
+	module, err := modulePath(root)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open "+v2Fame)
+		return nil, err
 	}
-	defer f.Close()
 
-	docV2 := &openapi2.T{}
-	err = json.NewDecoder(f).Decode(docV2)
+	g := &generator{
+		root:   root,
+		module: module,
+		fset:   token.NewFileSet(),
+		pkgs:   map[string]*pkg{},
+		doc: &openapi3.T{
+			OpenAPI:    "3.0.3",
+			Paths:      openapi3.Paths{},
+			Components: openapi3.Components{Schemas: openapi3.Schemas{}},
+		},
+	}
+	g.doc.Components.SecuritySchemes, g.security = securitySchemes(authCfg)
+
+	p, err := g.loadPackage(module + "/" + handlersPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode "+v2Fame)
+		return nil, err
 	}
 
-	return docV2, nil
+	g.addInfo(p)
+
+	err = g.addOperations(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.doc, nil
 }
 
-func convertToV3(docV2 *openapi2.T) (*openapi3.T, error) {
-	docV3, err := openapi2conv.ToV3(docV2)
+// securitySchemes returns the schemes of the credentials the realm chain
+// built from authCfg accepts, and a requirement that any one of them is
+// used.
+func securitySchemes(authCfg config.AuthConfiguration) (openapi3.SecuritySchemes, openapi3.SecurityRequirements) {
+	if !authCfg.RequireAuth {
+		// the noop realm authenticates every request
+		return nil, openapi3.SecurityRequirements{}
+	}
+
+	schemes := openapi3.SecuritySchemes{}
+	requirements := openapi3.SecurityRequirements{}
+
+	if authCfg.Native.Enabled || len(authCfg.File.APIKey) > 0 {
+		schemes[apiKeySecurityScheme] = &openapi3.SecuritySchemeRef{
+			Value: openapi3.NewSecurityScheme().
+				WithType("http").
+				WithScheme("bearer").
+				WithDescription("An API key, sent as Authorization: Bearer <key>"),
+		}
+		requirements.With(openapi3.NewSecurityRequirement().Authenticate(apiKeySecurityScheme))
+	}
+
+	if len(authCfg.File.Basic) > 0 {
+		schemes[basicSecurityScheme] = &openapi3.SecuritySchemeRef{
+			Value: openapi3.NewSecurityScheme().
+				WithType("http").
+				WithScheme("basic").
+				WithDescription("A username and password from the file realm"),
+		}
+		requirements.With(openapi3.NewSecurityRequirement().Authenticate(basicSecurityScheme))
+	}
+
+	return schemes, requirements
+}
+
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate docV3")
+		return "", errors.Wrap(err, "failed to open go.mod")
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
 	}
 
-	return docV3, nil
+	return "", errors.New("go.mod has no module directive")
 }
 
-func writeOutDocV3(docV3 *openapi3.T) error {
+func marshalDocV3(docV3 *openapi3.T) ([]byte, []byte, error) {
 	buf, err := docV3.MarshalJSON()
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal spec3")
+		return nil, nil, errors.Wrap(err, "failed to marshal spec3")
 	}
 
 	indentBuf := &bytes.Buffer{}
 	err = json.Indent(indentBuf, buf, "", "	")
 	if err != nil {
-		return errors.Wrap(err, "failed to indent docV3 json")
+		return nil, nil, errors.Wrap(err, "failed to indent docV3 json")
 	}
 
-	err = os.WriteFile(v3FnameJSON, indentBuf.Bytes(), 0644)
+	yamlBuf, err := yaml.JSONToYAML(buf)
 	if err != nil {
-		return errors.Wrap(err, "failed to write "+v3FnameJSON)
+		return nil, nil, errors.Wrap(err, "failed to convert json docV3 to yaml")
 	}
 
-	fmt.Println("created", v3FnameJSON)
+	return indentBuf.Bytes(), yamlBuf, nil
+}
 
-	yamlBuf, err := yaml.JSONToYAML(buf)
+func writeOutDocV3(docV3 *openapi3.T, out string) error {
+	jsonBuf, yamlBuf, err := marshalDocV3(docV3)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert json docV3 to yaml")
+		return err
 	}
 
-	err = os.WriteFile(v3FnameYAML, yamlBuf, 0644)
+	jsonPath := filepath.Join(out, v3FnameJSON)
+	err = os.WriteFile(jsonPath, jsonBuf, 0644)
 	if err != nil {
-		return errors.Wrap(err, "failed to write "+v3FnameYAML)
+		return errors.Wrap(err, "failed to write "+jsonPath)
 	}
 
-	fmt.Println("created", v3FnameYAML)
+	fmt.Println("created", jsonPath)
 
-	return nil
+	yamlPath := filepath.Join(out, v3FnameYAML)
+	err = os.WriteFile(yamlPath, yamlBuf, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to write "+yamlPath)
+	}
 
+	fmt.Println("created", yamlPath)
+
+	return nil
 }
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func loadGenerated(t *testing.T, authCfg config.AuthConfiguration) *openapi3.T {
+	docV3, err := generate("..", authCfg)
+	require.NoError(t, err)
+
+	_, yamlBuf, err := marshalDocV3(docV3)
+	require.NoError(t, err)
+
+	loaded, err := openapi3.NewLoader().LoadFromData(yamlBuf)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Validate(context.Background()))
+
+	return loaded
+}
+
+func TestGenerate_SpecIsValid(t *testing.T) {
+	doc := loadGenerated(t, everyRealm())
+
+	require.Equal(t, "Convoy API Specification", doc.Info.Title)
+	require.Equal(t, "https://cloud.staging.getconvoy.io/api/v1", doc.Servers[0].URL)
+
+	op := doc.Paths.Find("/applications/{appID}").Get
+	require.NotNil(t, op)
+	require.Equal(t, "GetApp", op.OperationID)
+
+	var params []string
+	for _, p := range op.Parameters {
+		params = append(params, p.Value.In+":"+p.Value.Name)
+	}
+	require.ElementsMatch(t, []string{"query:groupId", "path:appID"}, params)
+
+	// serverResponse{data=datastore.Application}
+	res := op.Responses.Get(200).Value.Content.Get("application/json").Schema.Value
+	require.Len(t, res.AllOf, 2)
+	require.Equal(t, "#/components/schemas/server.serverResponse", res.AllOf[0].Ref)
+	require.Equal(t, "#/components/schemas/datastore.Application", res.AllOf[1].Value.Properties["data"].Ref)
+
+	app := doc.Components.Schemas["datastore.Application"].Value
+	require.Equal(t, "string", app.Properties["uid"].Value.Type)
+	require.Equal(t, "array", app.Properties["endpoints"].Value.Type)
+	require.Equal(t, "#/components/schemas/datastore.Endpoint", app.Properties["endpoints"].Value.Items.Ref)
+
+	// the embedded Response is marshalled as part of serverResponse
+	serverResponse := doc.Components.Schemas["server.serverResponse"].Value
+	require.Contains(t, serverResponse.Properties, "status")
+	require.NotContains(t, serverResponse.Properties, "Response")
+}
+
+func TestGenerate_RequestBodies(t *testing.T) {
+	doc := loadGenerated(t, everyRealm())
+
+	body := doc.Paths.Find("/applications").Post.RequestBody.Value
+	require.True(t, body.Required)
+	require.Equal(t, "#/components/schemas/models.Application", body.Content.Get("application/json").Schema.Ref)
+
+	app := doc.Components.Schemas["models.Application"].Value
+	require.Contains(t, app.Required, "name")
+}
+
+func TestGenerate_SecurityFollowsRealmChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		authCfg config.AuthConfiguration
+		schemes []string
+	}{
+		{
+			name:    "every realm",
+			authCfg: everyRealm(),
+			schemes: []string{apiKeySecurityScheme, basicSecurityScheme},
+		},
+		{
+			name: "native realm only",
+			authCfg: config.AuthConfiguration{
+				RequireAuth: true,
+				Native:      config.NativeRealmOptions{Enabled: true},
+			},
+			schemes: []string{apiKeySecurityScheme},
+		},
+		{
+			name: "file realm basic auth only",
+			authCfg: config.AuthConfiguration{
+				RequireAuth: true,
+				File:        config.FileRealmOption{Basic: config.BasicAuthConfig{{Username: "default"}}},
+			},
+			schemes: []string{basicSecurityScheme},
+		},
+		{
+			name:    "auth not required",
+			authCfg: config.AuthConfiguration{Native: config.NativeRealmOptions{Enabled: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadGenerated(t, tt.authCfg)
+
+			var schemes []string
+			for name := range doc.Components.SecuritySchemes {
+				schemes = append(schemes, name)
+			}
+			require.ElementsMatch(t, tt.schemes, schemes)
+
+			op := doc.Paths.Find("/applications").Get
+			require.NotNil(t, op.Security)
+			require.Len(t, *op.Security, len(tt.schemes))
+			for i, requirement := range *op.Security {
+				require.Contains(t, requirement, tt.schemes[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// pkg is a package of the module, parsed just enough to build the schemas
+// of the types declared in it.
+type pkg struct {
+	name  string
+	path  string
+	types map[string]*typeDecl
+	files []*ast.File
+
+	// imports maps the names the package's files import other packages
+	// under to their paths, the annotations name types the same way.
+	imports map[string]string
+}
+
+type typeDecl struct {
+	spec    *ast.TypeSpec
+	pkg     *pkg
+	imports map[string]string
+}
+
+// externalTypes are the types declared outside the module that show up in
+// request and response bodies, any other one is documented as any value.
+var externalTypes = map[string]func() *openapi3.Schema{
+	"time.Time":          openapi3.NewDateTimeSchema,
+	"time.Duration":      openapi3.NewInt64Schema,
+	"primitive.DateTime": openapi3.NewDateTimeSchema,
+	"primitive.ObjectID": openapi3.NewStringSchema,
+	"json.RawMessage":    openapi3.NewSchema,
+	"http.Header": func() *openapi3.Schema {
+		return openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))
+	},
+}
+
+// loadPackage parses the package at importPath, it must be in the module.
+func (g *generator) loadPackage(importPath string) (*pkg, error) {
+	if p, ok := g.pkgs[importPath]; ok {
+		return p, nil
+	}
+
+	parsed, err := parser.ParseDir(g.fset, g.dir(importPath), func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse "+importPath)
+	}
+
+	p := &pkg{path: importPath, types: map[string]*typeDecl{}, imports: map[string]string{}}
+	g.pkgs[importPath] = p
+
+	for name, astPkg := range parsed {
+		p.name = name
+		for _, f := range astPkg.Files {
+			p.files = append(p.files, f)
+
+			imports, err := g.fileImports(f)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range imports {
+				p.imports[k] = v
+			}
+
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					p.types[ts.Name.Name] = &typeDecl{spec: ts, pkg: p, imports: imports}
+				}
+			}
+		}
+	}
+
+	if p.name == "" {
+		return nil, fmt.Errorf("no go files in %s", importPath)
+	}
+
+	return p, nil
+}
+
+func (g *generator) fileImports(f *ast.File) (map[string]string, error) {
+	imports := map[string]string{}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		var name string
+		switch {
+		case spec.Name != nil:
+			name = spec.Name.Name
+		case g.inModule(importPath):
+			name, err = g.packageName(importPath)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			name = path.Base(importPath)
+			if strings.HasPrefix(name, "v") && path.Dir(importPath) != "." {
+				if _, err := strconv.Atoi(name[1:]); err == nil {
+					name = path.Base(path.Dir(importPath))
+				}
+			}
+		}
+
+		imports[name] = importPath
+	}
+
+	return imports, nil
+}
+
+// packageName reads the name of a package of the module from its package
+// clause, it isn't always the name of its directory.
+func (g *generator) packageName(importPath string) (string, error) {
+	parsed, err := parser.ParseDir(g.fset, g.dir(importPath), func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.PackageClauseOnly)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse "+importPath)
+	}
+
+	for name := range parsed {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("no go files in %s", importPath)
+}
+
+func (g *generator) dir(importPath string) string {
+	return filepath.Join(g.root, strings.TrimPrefix(strings.TrimPrefix(importPath, g.module), "/"))
+}
+
+func (g *generator) inModule(importPath string) bool {
+	return importPath == g.module || strings.HasPrefix(importPath, g.module+"/")
+}
+
+// namedSchema returns a reference to the component schema of the type
+// called name, name is qualified with its package's name unless it's
+// declared in p.
+func (g *generator) namedSchema(p *pkg, imports map[string]string, name string) (*openapi3.SchemaRef, error) {
+	if newSchema, ok := externalTypes[name]; ok {
+		return newSchema().NewRef(), nil
+	}
+
+	typePkg := p
+	typeName := name
+	if i := strings.Index(name, "."); i >= 0 {
+		importPath, ok := imports[name[:i]]
+		if !ok {
+			return nil, fmt.Errorf("unknown package in type %s", name)
+		}
+
+		if !g.inModule(importPath) {
+			return openapi3.NewSchema().NewRef(), nil
+		}
+
+		var err error
+		typePkg, err = g.loadPackage(importPath)
+		if err != nil {
+			return nil, err
+		}
+		typeName = name[i+1:]
+	}
+
+	decl, ok := typePkg.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+
+	key := typePkg.name + "." + typeName
+	ref := "#/components/schemas/" + key
+	if _, ok := g.doc.Components.Schemas[key]; ok {
+		return openapi3.NewSchemaRef(ref, nil), nil
+	}
+
+	// the placeholder stops types that refer to themselves from recursing
+	g.doc.Components.Schemas[key] = openapi3.NewSchemaRef("", openapi3.NewSchema())
+
+	s, err := g.exprSchema(decl.pkg, decl.imports, decl.spec.Type)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the schema of "+key)
+	}
+
+	if s.Ref != "" {
+		// a type declared as another named type
+		alias := openapi3.NewSchema()
+		alias.AllOf = openapi3.SchemaRefs{s}
+		s = alias.NewRef()
+	}
+
+	g.doc.Components.Schemas[key] = s
+
+	return openapi3.NewSchemaRef(ref, nil), nil
+}
+
+func (g *generator) exprSchema(p *pkg, imports map[string]string, expr ast.Expr) (*openapi3.SchemaRef, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if s := builtinSchema(t.Name); s != nil {
+			return s.NewRef(), nil
+		}
+		return g.namedSchema(p, imports, t.Name)
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return openapi3.NewSchema().NewRef(), nil
+		}
+		return g.namedSchema(p, imports, x.Name+"."+t.Sel.Name)
+	case *ast.StarExpr:
+		return g.exprSchema(p, imports, t.X)
+	case *ast.ArrayType:
+		if elt, ok := t.Elt.(*ast.Ident); ok && elt.Name == "byte" {
+			return openapi3.NewBytesSchema().NewRef(), nil
+		}
+
+		items, err := g.exprSchema(p, imports, t.Elt)
+		if err != nil {
+			return nil, err
+		}
+
+		s := openapi3.NewArraySchema()
+		s.Items = items
+		return s.NewRef(), nil
+	case *ast.MapType:
+		values, err := g.exprSchema(p, imports, t.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		s := openapi3.NewObjectSchema()
+		s.AdditionalProperties = values
+		return s.NewRef(), nil
+	case *ast.StructType:
+		s, err := g.structSchema(p, imports, t)
+		if err != nil {
+			return nil, err
+		}
+		return s.NewRef(), nil
+	default:
+		// interfaces hold any value, funcs and channels are never
+		// marshalled
+		return openapi3.NewSchema().NewRef(), nil
+	}
+}
+
+func (g *generator) structSchema(p *pkg, imports map[string]string, st *ast.StructType) (*openapi3.Schema, error) {
+	s := openapi3.NewObjectSchema()
+
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+
+		jsonName, _ := splitTag(tag.Get("json"))
+		if jsonName == "-" || tag.Get("swaggerignore") == "true" {
+			continue
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			// the fields of embedded structs are marshalled as the
+			// struct's own
+			if err := g.embed(p, imports, field.Type, s); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		names := make([]string, 0, len(field.Names))
+		for _, n := range field.Names {
+			if ast.IsExported(n.Name) {
+				names = append(names, n.Name)
+			}
+		}
+		if len(field.Names) == 0 {
+			names = append(names, embeddedName(field.Type))
+		}
+
+		var fs *openapi3.SchemaRef
+		if swaggerType := tag.Get("swaggertype"); swaggerType != "" {
+			fs = swaggerTypeSchema(swaggerType).NewRef()
+		} else {
+			var err error
+			fs, err = g.exprSchema(p, imports, field.Type)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		required := strings.HasPrefix(tag.Get("valid"), "required")
+		for _, name := range names {
+			if jsonName != "" {
+				name = jsonName
+			}
+
+			s.Properties[name] = fs
+			if required {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// embed adds the properties of the embedded struct of type expr to s.
+func (g *generator) embed(p *pkg, imports map[string]string, expr ast.Expr, s *openapi3.Schema) error {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	var decl *typeDecl
+	switch t := expr.(type) {
+	case *ast.Ident:
+		decl = p.types[t.Name]
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok || !g.inModule(imports[x.Name]) {
+			return nil
+		}
+
+		typePkg, err := g.loadPackage(imports[x.Name])
+		if err != nil {
+			return err
+		}
+		decl = typePkg.types[t.Sel.Name]
+	}
+
+	if decl == nil {
+		return nil
+	}
+
+	st, ok := decl.spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+
+	embedded, err := g.structSchema(decl.pkg, decl.imports, st)
+	if err != nil {
+		return err
+	}
+
+	for name, prop := range embedded.Properties {
+		s.Properties[name] = prop
+	}
+	s.Required = append(s.Required, embedded.Required...)
+
+	return nil
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+func splitTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+func builtinSchema(name string) *openapi3.Schema {
+	switch name {
+	case "string", "error":
+		return openapi3.NewStringSchema()
+	case "bool", "boolean":
+		return openapi3.NewBoolSchema()
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "byte", "rune", "integer":
+		return openapi3.NewIntegerSchema()
+	case "int64", "uint64":
+		return openapi3.NewInt64Schema()
+	case "float32", "float64", "number":
+		return openapi3.NewFloat64Schema()
+	case "object":
+		return openapi3.NewObjectSchema()
+	case "interface{}", "any":
+		return openapi3.NewSchema()
+	default:
+		return nil
+	}
+}
+
+// swaggerTypeSchema returns the schema of a field's swaggertype tag, e.g
+// "string" or "array,string".
+func swaggerTypeSchema(swaggerType string) *openapi3.Schema {
+	parts := strings.Split(swaggerType, ",")
+	switch parts[0] {
+	case "array":
+		s := openapi3.NewArraySchema()
+		if len(parts) > 1 {
+			s.Items = swaggerTypeSchema(strings.Join(parts[1:], ",")).NewRef()
+		}
+		return s
+	case "primitive":
+		if len(parts) > 1 {
+			return swaggerTypeSchema(parts[1])
+		}
+	}
+
+	if s := builtinSchema(parts[0]); s != nil {
+		return s
+	}
+
+	return openapi3.NewSchema()
+}
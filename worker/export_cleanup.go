@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const ExportCleanupJobInterval = time.Hour
+
+// RegisterExportCleanupJob deletes the export jobs that have expired, and
+// their files, on every interval.
+func RegisterExportCleanupJob(ctx context.Context, jobs *sync.WaitGroup, exportService *services.ExportService, interval time.Duration) {
+	runJob(ctx, jobs, interval, func(ctx context.Context) {
+		err := exportService.CleanupExpiredExportJobs(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to clean up expired export jobs")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/worker/task"
+	log "github.com/sirupsen/logrus"
+)
+
+const HealthScoreJobInterval = 5 * time.Minute
+
+// RegisterHealthScoreJob updates the health score of all apps on every
+// interval.
+func RegisterHealthScoreJob(ctx context.Context, jobs *sync.WaitGroup, updater *task.HealthScoreUpdater, interval time.Duration) {
+	runJob(ctx, jobs, interval, func(ctx context.Context) {
+		err := updater.UpdateAll(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to update app health scores")
+		}
+	})
+}
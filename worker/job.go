@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// runJob calls run on every interval until ctx is done. A run that has
+// started is given a context of its own so that shutting down lets it
+// finish rather than cutting it short, jobs tracks it until it does.
+func runJob(ctx context.Context, jobs *sync.WaitGroup, interval time.Duration, run func(context.Context)) {
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				run(context.Background())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
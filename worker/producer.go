@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/taskq/v3"
@@ -32,6 +33,17 @@ func (p *Producer) Start(ctx context.Context) {
 	}()
 }
 
+// Stop stops the consumer taking new jobs and waits for the jobs it is
+// running to finish, giving up when ctx is done.
+func (p *Producer) Stop(ctx context.Context) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return p.consumer.Stop()
+	}
+
+	return p.consumer.StopTimeout(time.Until(deadline))
+}
+
 func (p *Producer) Close() error {
 	ch := make(chan error)
 	p.quit <- ch
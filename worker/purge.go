@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/services"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const PurgeJobInterval = time.Hour
+
+var purgedDocuments = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "purge",
+		Name:      "documents_purged_total",
+		Help:      "Number of soft-deleted documents purged.",
+	},
+	[]string{"collection"},
+)
+
+// RegisterPurgeJob purges the long deleted documents of each collection
+// on every interval.
+func RegisterPurgeJob(ctx context.Context, jobs *sync.WaitGroup, purgeService *services.PurgeService, interval time.Duration) {
+	err := prometheus.Register(purgedDocuments)
+	if err != nil {
+		log.Errorf("Metrics: Error registering documents_purged_total %v", err)
+	}
+
+	runJob(ctx, jobs, interval, func(ctx context.Context) {
+		purged, err := purgeService.PurgeDeletedDocuments(ctx)
+		for collection, n := range purged {
+			purgedDocuments.WithLabelValues(collection).Add(float64(n))
+		}
+
+		if err != nil {
+			log.WithError(err).Error("failed to purge deleted documents")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const RetentionJobInterval = time.Hour
+
+// RegisterRetentionJob applies the retention policies of all groups on every
+// interval, events are archived to object storage before they are deleted.
+func RegisterRetentionJob(ctx context.Context, jobs *sync.WaitGroup, archiveService *services.ArchiveService, interval time.Duration) {
+	runJob(ctx, jobs, interval, func(ctx context.Context) {
+		err := archiveService.ApplyRetentionPolicies(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to apply retention policies")
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/notification"
 	"github.com/frain-dev/convoy/queue"
 	redisqueue "github.com/frain-dev/convoy/queue/redis"
 	"github.com/frain-dev/convoy/worker/task"
@@ -17,7 +18,7 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func RegisterNewGroupTask(applicationRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, eventRepo datastore.EventRepository, cache cache.Cache, eventQueue queue.Queuer) {
+func RegisterNewGroupTask(applicationRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, eventRepo datastore.EventRepository, cache cache.Cache, eventQueue queue.Queuer, bridge *task.EventBridge, metaEvents *task.MetaEventEmitter, notifier notification.Notifier) {
 	go func() {
 		for {
 			filter := &datastore.GroupFilter{}
@@ -31,7 +32,7 @@ func RegisterNewGroupTask(applicationRepo datastore.ApplicationRepository, event
 
 				if t := taskq.Tasks.Get(string(pEvtCrtTask)); t == nil {
 					if s := taskq.Tasks.Get(string(pEvtDelTask)); s == nil {
-						handler := task.ProcessEventDelivery(applicationRepo, eventDeliveryRepo, groupRepo, rateLimiter)
+						handler := task.ProcessEventDelivery(applicationRepo, eventDeliveryRepo, groupRepo, rateLimiter, bridge, metaEvents, notifier)
 						log.Infof("Registering event delivery task handler for %s", g.Name)
 						task.CreateTask(pEvtDelTask, *g, handler)
 
@@ -92,7 +93,7 @@ func RequeueEventDeliveries(status string, timeInterval string, eventDeliveryRep
 	log.Infof("total number of event deliveries to requeue is %d", counter)
 
 	for {
-		deliveries, _, err := eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, "", "", "", []datastore.EventDeliveryStatus{s}, searchParams, pageable)
+		deliveries, _, err := eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, "", "", "", "", []datastore.EventDeliveryStatus{s}, searchParams, pageable)
 		if err != nil {
 			log.WithError(err).Errorf("successfully fetched %d event deliveries, encountered error fetching page %d", count, pageable.Page)
 			close(deliveryChan)
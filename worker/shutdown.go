@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Shutdown stops producers taking new jobs, then waits for the jobs in
+// flight and the background jobs tracked by jobs to finish. It gives up
+// waiting once ctx is done and returns its error.
+func Shutdown(ctx context.Context, jobs *sync.WaitGroup, producers ...*Producer) error {
+	var wg sync.WaitGroup
+	for _, p := range producers {
+		wg.Add(1)
+		go func(p *Producer) {
+			defer wg.Done()
+
+			err := p.Stop(ctx)
+			if err != nil {
+				log.WithError(err).Error("failed to stop consumer")
+			}
+		}(p)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/queue/memqueue"
+	"github.com/frain-dev/taskq/v3"
+	tmemqueue "github.com/frain-dev/taskq/v3/memqueue"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryWorker_CompletesInFlightOnShutdown(t *testing.T) {
+	q := memqueue.NewQueue(queue.QueueOptions{
+		Name:    uuid.NewString(),
+		Type:    "in-memory",
+		Storage: queue.NewLocalStorage(),
+		Factory: tmemqueue.NewFactory(),
+	})
+
+	started := make(chan struct{})
+	var completed int32
+
+	taskName := convoy.EventProcessor.SetPrefix(uuid.NewString())
+	taskq.RegisterTask(&taskq.TaskOptions{
+		Name: string(taskName),
+		Handler: func(job *queue.Job) error {
+			close(started)
+			time.Sleep(500 * time.Millisecond)
+			atomic.StoreInt32(&completed, 1)
+			return nil
+		},
+	})
+
+	stopCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	err := q.WriteEvent(context.Background(), taskName, &datastore.Event{UID: uuid.NewString()}, 0)
+	require.NoError(t, err)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery was not started")
+	}
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+	<-stopCtx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var jobs sync.WaitGroup
+	err = Shutdown(shutdownCtx, &jobs, NewProducer(q))
+	require.NoError(t, err)
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&completed), "in flight delivery was abandoned")
+}
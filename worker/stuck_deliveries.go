@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const StuckDeliveriesJobInterval = 5 * time.Minute
+
+// StuckDeliveryMaxAge is how long a delivery is left in processing before
+// the stuck deliveries job fails it.
+const StuckDeliveryMaxAge = time.Hour
+
+// RegisterStuckDeliveriesJob fails the deliveries that have been in
+// processing for longer than maxAge on every interval, they're left there
+// when a worker crashes while sending them.
+func RegisterStuckDeliveriesJob(ctx context.Context, jobs *sync.WaitGroup, eventService *services.EventService, interval, maxAge time.Duration) {
+	runJob(ctx, jobs, interval, func(ctx context.Context) {
+		_, err := eventService.ForceCompleteStuckDeliveries(ctx, maxAge)
+		if err != nil {
+			log.WithError(err).Error("failed to force complete stuck event deliveries")
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bridgedAppsPerPage is how many apps of a destination group are loaded at
+// a time when an event is forwarded to them.
+const bridgedAppsPerPage = 100
+
+var ErrInvalidTransformedData = errors.New("transform template did not produce valid json")
+
+// EventBridge forwards delivered events to other groups following the
+// event bridge rules of the group they were sent in.
+type EventBridge struct {
+	ruleRepo         datastore.EventBridgeRepository
+	appRepo          datastore.ApplicationRepository
+	groupRepo        datastore.GroupRepository
+	eventRepo        datastore.EventRepository
+	createEventQueue queue.Queuer
+}
+
+func NewEventBridge(ruleRepo datastore.EventBridgeRepository, appRepo datastore.ApplicationRepository, groupRepo datastore.GroupRepository,
+	eventRepo datastore.EventRepository, createEventQueue queue.Queuer) *EventBridge {
+	return &EventBridge{
+		ruleRepo:         ruleRepo,
+		appRepo:          appRepo,
+		groupRepo:        groupRepo,
+		eventRepo:        eventRepo,
+		createEventQueue: createEventQueue,
+	}
+}
+
+// Forward sends the event m is a delivery of to the apps of every group a
+// rule matching its type bridges m's group to. Only the first delivery of
+// an event to succeed forwards it, the event is marked bridged first so
+// deliveries succeeding at the same time can't both forward it. Events
+// that were themselves forwarded are not forwarded again, so rules can
+// never make events go around in a loop.
+func (b *EventBridge) Forward(ctx context.Context, m *datastore.EventDelivery) error {
+	if m.EventMetadata == nil {
+		return nil
+	}
+
+	rules, err := b.ruleRepo.LoadEventBridgeRules(ctx, m.AppMetadata.GroupID)
+	if err != nil {
+		return err
+	}
+
+	matched := make([]datastore.EventBridgeRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Matches(m.EventMetadata.EventType) {
+			matched = append(matched, rule)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	event, err := b.eventRepo.FindEventByID(ctx, m.EventMetadata.UID)
+	if err != nil {
+		return err
+	}
+
+	if !util.IsStringEmpty(event.BridgedFrom) {
+		return nil
+	}
+
+	marked, err := b.eventRepo.MarkEventBridged(ctx, event.UID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// another delivery of the event got to forward it
+	if !marked {
+		return nil
+	}
+
+	for _, rule := range matched {
+		err = b.forwardEvent(ctx, event, &rule)
+		if err != nil {
+			log.WithError(err).Errorf("failed to forward event %s with event bridge rule %s", event.UID, rule.UID)
+		}
+	}
+
+	return nil
+}
+
+func (b *EventBridge) forwardEvent(ctx context.Context, event *datastore.Event, rule *datastore.EventBridgeRule) error {
+	data, err := transformEventData(rule.TransformTemplate, event.Data)
+	if err != nil {
+		return err
+	}
+
+	g, err := b.groupRepo.FetchGroupByID(ctx, rule.DestGroupID)
+	if err != nil {
+		return err
+	}
+
+	taskName := convoy.CreateEventProcessor.SetPrefix(g.Name)
+	pageable := datastore.Pageable{Page: 1, PerPage: bridgedAppsPerPage, Sort: 1}
+
+	for {
+		apps, paginationData, err := b.appRepo.LoadApplicationsPagedByGroupId(ctx, g.UID, pageable)
+		if err != nil {
+			return err
+		}
+
+		for _, app := range apps {
+			if app.IsDisabled || len(app.Endpoints) == 0 {
+				continue
+			}
+
+			forwarded := &datastore.Event{
+				UID:          uuid.New().String(),
+				EventType:    event.EventType,
+				EventVersion: event.EventVersion,
+				Metadata:     event.Metadata,
+				Tags:         event.Tags,
+				Data:         data,
+				BridgedFrom:  event.UID,
+				AppMetadata: &datastore.AppMetadata{
+					Title:        app.Title,
+					UID:          app.UID,
+					GroupID:      app.GroupID,
+					SupportEmail: app.SupportEmail,
+				},
+				CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			}
+
+			err = b.createEventQueue.WriteEvent(ctx, taskName, forwarded, 1*time.Second)
+			if err != nil {
+				return err
+			}
+		}
+
+		if int64(pageable.Page) >= paginationData.TotalPage {
+			return nil
+		}
+
+		pageable.Page++
+	}
+}
+
+// transformEventData executes tmpl with data decoded from JSON. The "json"
+// function encodes a value back to JSON within the template. Data is
+// returned as is when tmpl is empty.
+func transformEventData(tmpl string, data json.RawMessage) (json.RawMessage, error) {
+	if util.IsStringEmpty(tmpl) {
+		return data, nil
+	}
+
+	t, err := ParseTransformTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, ErrInvalidTransformedData
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseTransformTemplate parses the transform template of an event bridge
+// rule.
+func ParseTransformTemplate(tmpl string) (*template.Template, error) {
+	return template.New("transform").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", fmt.Errorf("failed to encode %v: %v", v, err)
+			}
+			return string(b), nil
+		},
+	}).Option("missingkey=error").Parse(tmpl)
+}
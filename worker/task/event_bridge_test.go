@@ -0,0 +1,191 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideEventBridge(ctrl *gomock.Controller) (*EventBridge, *mocks.MockEventBridgeRepository) {
+	ruleRepo := mocks.NewMockEventBridgeRepository(ctrl)
+	bridge := NewEventBridge(ruleRepo, mocks.NewMockApplicationRepository(ctrl), mocks.NewMockGroupRepository(ctrl),
+		mocks.NewMockEventRepository(ctrl), mocks.NewMockQueuer(ctrl))
+
+	return bridge, ruleRepo
+}
+
+func bridgedDelivery(eventType datastore.EventType) *datastore.EventDelivery {
+	return &datastore.EventDelivery{
+		UID:           "delivery-1",
+		EventMetadata: &datastore.EventMetadata{UID: "event-1", EventType: eventType},
+		AppMetadata:   &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+		Status:        datastore.SuccessEventStatus,
+	}
+}
+
+func TestEventBridge_ForwardsOnMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bridge, ruleRepo := provideEventBridge(ctrl)
+
+	ruleRepo.EXPECT().LoadEventBridgeRules(gomock.Any(), "group-1").Times(1).
+		Return([]datastore.EventBridgeRule{
+			{
+				UID:               "rule-1",
+				SourceGroupID:     "group-1",
+				DestGroupID:       "group-2",
+				EventTypeFilter:   []string{"payment.created"},
+				TransformTemplate: `{"amount": {{json .amount}}, "source": "group-1"}`,
+			},
+		}, nil)
+
+	e, _ := bridge.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().FindEventByID(gomock.Any(), "event-1").Times(1).
+		Return(&datastore.Event{
+			UID:         "event-1",
+			EventType:   "payment.created",
+			Tags:        []string{"checkout"},
+			Data:        json.RawMessage(`{"amount": 100, "currency": "NGN"}`),
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+		}, nil)
+	e.EXPECT().MarkEventBridged(gomock.Any(), "event-1", gomock.Any()).Times(1).Return(true, nil)
+
+	g, _ := bridge.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupByID(gomock.Any(), "group-2").Times(1).
+		Return(&datastore.Group{UID: "group-2", Name: "analytics"}, nil)
+
+	a, _ := bridge.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().LoadApplicationsPagedByGroupId(gomock.Any(), "group-2", gomock.Any()).Times(1).
+		Return([]datastore.Application{
+			{UID: "app-2", GroupID: "group-2", Endpoints: []datastore.Endpoint{{UID: "endpoint-1"}}},
+			{UID: "app-3", GroupID: "group-2"},
+		}, datastore.PaginationData{TotalPage: 1}, nil)
+
+	var forwarded []*datastore.Event
+	q, _ := bridge.createEventQueue.(*mocks.MockQueuer)
+	q.EXPECT().WriteEvent(gomock.Any(), convoy.CreateEventProcessor.SetPrefix("analytics"), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ convoy.TaskName, event *datastore.Event, _ interface{}) error {
+			forwarded = append(forwarded, event)
+			return nil
+		})
+
+	err := bridge.Forward(context.Background(), bridgedDelivery("payment.created"))
+	require.NoError(t, err)
+
+	// app-3 has no endpoints, so the event only goes to app-2
+	require.Len(t, forwarded, 1)
+	require.Equal(t, "event-1", forwarded[0].BridgedFrom)
+	require.Equal(t, "app-2", forwarded[0].AppMetadata.UID)
+	require.Equal(t, "group-2", forwarded[0].AppMetadata.GroupID)
+	require.Equal(t, datastore.EventType("payment.created"), forwarded[0].EventType)
+	require.Equal(t, []string{"checkout"}, forwarded[0].Tags)
+	require.JSONEq(t, `{"amount": 100, "source": "group-1"}`, string(forwarded[0].Data))
+}
+
+func TestEventBridge_SkipsOnTypeMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bridge, ruleRepo := provideEventBridge(ctrl)
+
+	ruleRepo.EXPECT().LoadEventBridgeRules(gomock.Any(), "group-1").Times(1).
+		Return([]datastore.EventBridgeRule{
+			{
+				UID:             "rule-1",
+				SourceGroupID:   "group-1",
+				DestGroupID:     "group-2",
+				EventTypeFilter: []string{"payment.created"},
+			},
+		}, nil)
+
+	// nothing else is looked up, the mocks fail on any other call
+	err := bridge.Forward(context.Background(), bridgedDelivery("payment.refunded"))
+	require.NoError(t, err)
+}
+
+func TestEventBridge_SkipsBridgedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bridge, ruleRepo := provideEventBridge(ctrl)
+
+	ruleRepo.EXPECT().LoadEventBridgeRules(gomock.Any(), "group-1").Times(1).
+		Return([]datastore.EventBridgeRule{{UID: "rule-1", DestGroupID: "group-2", EventTypeFilter: []string{"*"}}}, nil)
+
+	e, _ := bridge.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().FindEventByID(gomock.Any(), "event-1").Times(1).
+		Return(&datastore.Event{UID: "event-1", BridgedFrom: "event-0"}, nil)
+
+	err := bridge.Forward(context.Background(), bridgedDelivery("payment.created"))
+	require.NoError(t, err)
+}
+
+func TestEventBridge_ForwardsOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bridge, ruleRepo := provideEventBridge(ctrl)
+
+	ruleRepo.EXPECT().LoadEventBridgeRules(gomock.Any(), "group-1").Times(1).
+		Return([]datastore.EventBridgeRule{{UID: "rule-1", DestGroupID: "group-2", EventTypeFilter: []string{"*"}}}, nil)
+
+	e, _ := bridge.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().FindEventByID(gomock.Any(), "event-1").Times(1).
+		Return(&datastore.Event{UID: "event-1"}, nil)
+
+	// another delivery of the event marked it first, nothing is queued
+	e.EXPECT().MarkEventBridged(gomock.Any(), "event-1", gomock.Any()).Times(1).Return(false, nil)
+
+	err := bridge.Forward(context.Background(), bridgedDelivery("payment.created"))
+	require.NoError(t, err)
+}
+
+func Test_transformEventData(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		data     string
+		wantData string
+		wantErr  bool
+	}{
+		{
+			name:     "should_return_data_without_template",
+			data:     `{"amount": 100}`,
+			wantData: `{"amount": 100}`,
+		},
+		{
+			name:     "should_transform_data",
+			tmpl:     `{"total": {{json .amount}}}`,
+			data:     `{"amount": 100}`,
+			wantData: `{"total": 100}`,
+		},
+		{
+			name:    "should_fail_on_invalid_json",
+			tmpl:    `total {{.amount}}`,
+			data:    `{"amount": 100}`,
+			wantErr: true,
+		},
+		{
+			name:    "should_fail_on_missing_key",
+			tmpl:    `{"total": {{json .total}}}`,
+			data:    `{"amount": 100}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := transformEventData(tc.tmpl, json.RawMessage(tc.data))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.wantData, string(data))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultHealthScoreWindow is the number of recent delivery attempts
+	// the health score of an app is computed over.
+	DefaultHealthScoreWindow = 100
+
+	unhealthyScoreThreshold = 0.5
+	healthScoreAppsPerPage  = 50
+)
+
+// HealthScoreUpdater keeps the health score of apps up to date. The score
+// is the share of an app's last delivery attempts that succeeded.
+type HealthScoreUpdater struct {
+	groupRepo         datastore.GroupRepository
+	appRepo           datastore.ApplicationRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	metaEvents        *MetaEventEmitter
+	window            int
+}
+
+func NewHealthScoreUpdater(groupRepo datastore.GroupRepository, appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, metaEvents *MetaEventEmitter, window int) *HealthScoreUpdater {
+	if window < 1 {
+		window = DefaultHealthScoreWindow
+	}
+
+	return &HealthScoreUpdater{
+		groupRepo:         groupRepo,
+		appRepo:           appRepo,
+		eventDeliveryRepo: eventDeliveryRepo,
+		metaEvents:        metaEvents,
+		window:            window,
+	}
+}
+
+// UpdateAll updates the health score of the apps of every group. Apps
+// that fail to update are logged and skipped.
+func (h *HealthScoreUpdater) UpdateAll(ctx context.Context) error {
+	groups, err := h.groupRepo.LoadGroups(ctx, &datastore.GroupFilter{})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		pageable := datastore.Pageable{Page: 1, PerPage: healthScoreAppsPerPage, Sort: 1}
+
+		for {
+			apps, paginationData, err := h.appRepo.LoadApplicationsPagedByGroupId(ctx, g.UID, pageable)
+			if err != nil {
+				log.WithError(err).Errorf("failed to load the apps of group %s", g.UID)
+				break
+			}
+
+			for i := range apps {
+				err = h.UpdateApp(ctx, g, &apps[i])
+				if err != nil {
+					log.WithError(err).Errorf("failed to update the health score of app %s", apps[i].UID)
+				}
+			}
+
+			if int64(pageable.Page) >= paginationData.TotalPage {
+				break
+			}
+			pageable.Page++
+		}
+	}
+
+	return nil
+}
+
+// UpdateApp stores the health score of app. Apps without delivery attempts
+// are left as they are. The group is sent an application.unhealthy meta
+// event when the score drops the app from healthy to unhealthy.
+func (h *HealthScoreUpdater) UpdateApp(ctx context.Context, g *datastore.Group, app *datastore.Application) error {
+	attempts, err := h.eventDeliveryRepo.FindRecentAttemptsByApp(ctx, app.UID, h.window)
+	if err != nil {
+		return err
+	}
+
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	score := computeHealthScore(attempts)
+
+	status := datastore.HealthyApplicationStatus
+	if score < unhealthyScoreThreshold {
+		status = datastore.UnhealthyApplicationStatus
+	}
+
+	err = h.appRepo.UpdateApplicationHealth(ctx, app.UID, score, status)
+	if err != nil {
+		return err
+	}
+
+	if status == datastore.UnhealthyApplicationStatus && app.HealthStatus != datastore.UnhealthyApplicationStatus {
+		h.metaEvents.EmitApplicationUnhealthy(g, app, score)
+	}
+
+	return nil
+}
+
+func computeHealthScore(attempts []datastore.DeliveryAttempt) float64 {
+	var successCount int
+	for _, attempt := range attempts {
+		if attempt.Status {
+			successCount++
+		}
+	}
+
+	return float64(successCount) / float64(len(attempts))
+}
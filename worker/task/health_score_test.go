@@ -0,0 +1,117 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func attemptsWithSuccesses(total, successes int) []datastore.DeliveryAttempt {
+	attempts := make([]datastore.DeliveryAttempt, total)
+	for i := 0; i < successes; i++ {
+		attempts[i].Status = true
+	}
+	return attempts
+}
+
+func TestHealthScoreUpdater_ComputesCorrectScore(t *testing.T) {
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		attempts     []datastore.DeliveryAttempt
+		prevStatus   datastore.ApplicationHealthStatus
+		wantScore    float64
+		wantStatus   datastore.ApplicationHealthStatus
+		wantMetaEvts int
+	}{
+		{
+			name:       "should_score_a_healthy_app",
+			attempts:   attemptsWithSuccesses(4, 3),
+			wantScore:  0.75,
+			wantStatus: datastore.HealthyApplicationStatus,
+		},
+		{
+			name:       "should_keep_an_app_scoring_half_healthy",
+			attempts:   attemptsWithSuccesses(10, 5),
+			wantScore:  0.5,
+			wantStatus: datastore.HealthyApplicationStatus,
+		},
+		{
+			name:         "should_mark_an_app_unhealthy",
+			attempts:     attemptsWithSuccesses(4, 1),
+			prevStatus:   datastore.HealthyApplicationStatus,
+			wantScore:    0.25,
+			wantStatus:   datastore.UnhealthyApplicationStatus,
+			wantMetaEvts: 1,
+		},
+		{
+			name:       "should_not_notify_again_for_an_unhealthy_app",
+			attempts:   attemptsWithSuccesses(3, 0),
+			prevStatus: datastore.UnhealthyApplicationStatus,
+			wantScore:  0,
+			wantStatus: datastore.UnhealthyApplicationStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			metaEvents, queued := newTestMetaEventEmitter(t, ctrl)
+
+			appRepo := mocks.NewMockApplicationRepository(ctrl)
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+			eventDeliveryRepo.EXPECT().
+				FindRecentAttemptsByApp(gomock.Any(), "app-1", 20).
+				Return(tc.attempts, nil).Times(1)
+
+			var score float64
+			var status datastore.ApplicationHealthStatus
+			appRepo.EXPECT().
+				UpdateApplicationHealth(gomock.Any(), "app-1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, s float64, st datastore.ApplicationHealthStatus) error {
+					score, status = s, st
+					return nil
+				}).Times(1)
+
+			g := metaEventGroup("https://example.com/meta", datastore.ApplicationUnhealthyMetaEvent)
+			app := &datastore.Application{UID: "app-1", HealthStatus: tc.prevStatus}
+
+			updater := NewHealthScoreUpdater(nil, appRepo, eventDeliveryRepo, metaEvents, 20)
+			require.NoError(t, updater.UpdateApp(context.Background(), g, app))
+
+			require.InDelta(t, tc.wantScore, score, 0.0001)
+			require.Equal(t, tc.wantStatus, status)
+
+			require.Len(t, *queued, tc.wantMetaEvts)
+			if tc.wantMetaEvts > 0 {
+				require.Equal(t, datastore.ApplicationUnhealthyMetaEvent, queuedMetaEvent(t, (*queued)[0]).EventType)
+			}
+		})
+	}
+}
+
+func TestHealthScoreUpdater_SkipsAppsWithoutAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+	eventDeliveryRepo.EXPECT().
+		FindRecentAttemptsByApp(gomock.Any(), "app-1", DefaultHealthScoreWindow).
+		Return([]datastore.DeliveryAttempt{}, nil).Times(1)
+
+	updater := NewHealthScoreUpdater(nil, appRepo, eventDeliveryRepo, nil, 0)
+	err := updater.UpdateApp(context.Background(), &datastore.Group{UID: "group-1"}, &datastore.Application{UID: "app-1"})
+	require.NoError(t, err)
+}
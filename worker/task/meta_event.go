@@ -0,0 +1,317 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pubsub"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrMetaEventRecursion = errors.New("meta events cannot emit meta events")
+
+type metaEventCtxKey struct{}
+
+// MetaEvent is the body sent to a group's meta event url.
+type MetaEvent struct {
+	UID       string                  `json:"uid"`
+	EventType datastore.MetaEventType `json:"event_type"`
+	GroupID   string                  `json:"group_id"`
+	Data      interface{}             `json:"data"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// MetaEventDelivery describes the event delivery a meta event is about.
+// It leaves out the endpoint secret that is part of datastore.EventDelivery.
+type MetaEventDelivery struct {
+	UID         string                        `json:"uid"`
+	EventID     string                        `json:"event_id,omitempty"`
+	EventType   datastore.EventType           `json:"event_type,omitempty"`
+	AppID       string                        `json:"app_id"`
+	EndpointID  string                        `json:"endpoint_id"`
+	TargetURL   string                        `json:"target_url"`
+	Status      datastore.EventDeliveryStatus `json:"status"`
+	Description string                        `json:"description,omitempty"`
+	NumTrials   uint64                        `json:"num_trials"`
+}
+
+// MetaEventEndpoint describes the endpoint a meta event is about.
+type MetaEventEndpoint struct {
+	UID            string                   `json:"uid"`
+	AppID          string                   `json:"app_id"`
+	TargetURL      string                   `json:"target_url"`
+	Status         datastore.EndpointStatus `json:"status"`
+	DisabledReason string                   `json:"disabled_reason,omitempty"`
+	DisabledAt     string                   `json:"disabled_at,omitempty"`
+}
+
+// MetaEventApplication describes the app a meta event is about.
+type MetaEventApplication struct {
+	UID          string                            `json:"uid"`
+	Title        string                            `json:"name"`
+	HealthScore  float64                           `json:"health_score"`
+	HealthStatus datastore.ApplicationHealthStatus `json:"health_status"`
+}
+
+func newMetaEventDelivery(m *datastore.EventDelivery) *MetaEventDelivery {
+	d := &MetaEventDelivery{
+		UID:         m.UID,
+		AppID:       m.AppMetadata.UID,
+		EndpointID:  m.EndpointMetadata.UID,
+		TargetURL:   m.EndpointMetadata.TargetURL,
+		Status:      m.Status,
+		Description: m.Description,
+		NumTrials:   m.Metadata.NumTrials,
+	}
+
+	if m.EventMetadata != nil {
+		d.EventID = m.EventMetadata.UID
+		d.EventType = m.EventMetadata.EventType
+	}
+
+	return d
+}
+
+// MetaEventEmitter emits the meta events of groups. Each one is stored as
+// an event delivery to the group's meta event url and queued, so it is
+// retried with the group's strategy and recorded like any other delivery.
+// A nil MetaEventEmitter emits nothing.
+type MetaEventEmitter struct {
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventQueue        queue.Queuer
+}
+
+func NewMetaEventEmitter(eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer) *MetaEventEmitter {
+	return &MetaEventEmitter{eventDeliveryRepo: eventDeliveryRepo, eventQueue: eventQueue}
+}
+
+// Emit queues a meta event of eventType about data if g is subscribed to
+// eventType.
+//
+// Sending a meta event must never lead to another one being emitted, so
+// the context meta event deliveries are sent with is marked and any
+// emission made with it, or about a meta event, is refused.
+func (e *MetaEventEmitter) Emit(ctx context.Context, g *datastore.Group, eventType datastore.MetaEventType, data interface{}) error {
+	if ctx.Value(metaEventCtxKey{}) != nil {
+		return ErrMetaEventRecursion
+	}
+
+	if _, ok := data.(*MetaEvent); ok {
+		return ErrMetaEventRecursion
+	}
+
+	if e == nil {
+		return nil
+	}
+
+	mc := g.Config.MetaEvent
+	if mc == nil || !mc.IsSubscribed(eventType) {
+		return nil
+	}
+
+	intervalSeconds, stepSeconds, retryLimit, ok := groupRetrySettings(g)
+	if !ok {
+		return fmt.Errorf("unknown retry strategy %s", g.Config.Strategy.Type)
+	}
+
+	event := &MetaEvent{
+		UID:       uuid.New().String(),
+		EventType: eventType,
+		GroupID:   g.UID,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	delivery := &datastore.EventDelivery{
+		UID: uuid.New().String(),
+		EventMetadata: &datastore.EventMetadata{
+			UID:       event.UID,
+			EventType: datastore.EventType(eventType),
+		},
+		EndpointMetadata: &datastore.EndpointMetadata{
+			TargetURL: mc.URL,
+			Status:    datastore.ActiveEndpointStatus,
+		},
+		AppMetadata: &datastore.AppMetadata{
+			GroupID: g.UID,
+		},
+		Metadata: &datastore.Metadata{
+			Data:            payload,
+			Strategy:        g.Config.Strategy.Type,
+			IntervalSeconds: intervalSeconds,
+			StepSeconds:     stepSeconds,
+			RetryLimit:      retryLimit,
+			NextSendTime:    primitive.NewDateTimeFromTime(time.Now()),
+		},
+		IsMetaEvent:      true,
+		Status:           datastore.ScheduledEventStatus,
+		DeliveryAttempts: []datastore.DeliveryAttempt{},
+		DocumentStatus:   datastore.ActiveDocumentStatus,
+		ExpiresAt:        g.EventExpiry(time.Now()),
+		CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err = e.eventDeliveryRepo.CreateEventDelivery(ctx, delivery)
+	if err != nil {
+		return err
+	}
+
+	return e.eventQueue.WriteEventDelivery(ctx, convoy.EventProcessor.SetPrefix(g.Name), delivery, 1*time.Second)
+}
+
+func (e *MetaEventEmitter) EmitDelivery(g *datastore.Group, m *datastore.EventDelivery, eventType datastore.MetaEventType) {
+	if m.IsMetaEvent {
+		return
+	}
+
+	err := e.Emit(context.Background(), g, eventType, newMetaEventDelivery(m))
+	if err != nil {
+		log.WithError(err).Errorf("failed to emit %s meta event for %s", eventType, m.UID)
+	}
+}
+
+func (e *MetaEventEmitter) EmitEndpointDisabled(g *datastore.Group, m *datastore.EventDelivery, endpoint *datastore.Endpoint, reason string, disabledAt time.Time) {
+	data := &MetaEventEndpoint{
+		UID:            endpoint.UID,
+		AppID:          m.AppMetadata.UID,
+		TargetURL:      endpoint.TargetURL,
+		Status:         datastore.InactiveEndpointStatus,
+		DisabledReason: reason,
+		DisabledAt:     disabledAt.UTC().Format(time.RFC3339),
+	}
+
+	err := e.Emit(context.Background(), g, datastore.EndpointDisabledMetaEvent, data)
+	if err != nil {
+		log.WithError(err).Errorf("failed to emit %s meta event for endpoint %s", datastore.EndpointDisabledMetaEvent, endpoint.UID)
+	}
+}
+
+func (e *MetaEventEmitter) EmitApplicationUnhealthy(g *datastore.Group, app *datastore.Application, score float64) {
+	data := &MetaEventApplication{
+		UID:          app.UID,
+		Title:        app.Title,
+		HealthScore:  score,
+		HealthStatus: datastore.UnhealthyApplicationStatus,
+	}
+
+	err := e.Emit(context.Background(), g, datastore.ApplicationUnhealthyMetaEvent, data)
+	if err != nil {
+		log.WithError(err).Errorf("failed to emit %s meta event for app %s", datastore.ApplicationUnhealthyMetaEvent, app.UID)
+	}
+}
+
+// processMetaEventDelivery sends m, the delivery of a meta event, signed
+// with the group's meta event secret. Failed attempts are retried like
+// those of any delivery, but never emit meta events themselves.
+func processMetaEventDelivery(eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, m *datastore.EventDelivery, delayDuration time.Duration) error {
+	ctx := context.WithValue(context.Background(), metaEventCtxKey{}, m.EventMetadata.EventType)
+
+	moved, err := eventDeliveryRepo.TransitionStatus(ctx, m.UID, m.Status, datastore.ProcessingEventStatus)
+	if err != nil {
+		log.WithError(err).Error("failed to update status of event delivery")
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	if !moved {
+		log.Debugf("%s is no longer %s, skipping it", m.UID, m.Status)
+		return nil
+	}
+	m.Status = datastore.ProcessingEventStatus
+
+	g, err := groupRepo.FetchGroupByID(ctx, m.AppMetadata.GroupID)
+	if err != nil {
+		log.WithError(err).Errorf("could not retrieve group %s", m.AppMetadata.GroupID)
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	// the group stopped sending meta events since this one was emitted
+	if g.Config.MetaEvent == nil {
+		_, err = eventDeliveryRepo.TransitionStatus(ctx, m.UID, m.Status, datastore.DiscardedEventStatus)
+		if err != nil {
+			log.WithError(err).Error("failed to update status of event delivery")
+		}
+
+		return nil
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	httpDuration, err := time.ParseDuration(convoy.HTTP_TIMEOUT)
+	if err != nil {
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	secret, err := util.Decrypt(g.Config.MetaEvent.Secret, cfg.EncryptionKey)
+	if err != nil {
+		return &EndpointError{Err: fmt.Errorf("failed to decrypt meta event secret: %v", err), delay: delayDuration}
+	}
+
+	hmac, timestamp, signatures, err := signPayload(g, secret, string(m.Metadata.Data))
+	if err != nil {
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	dispatch, err := newGroupDispatcher(g, httpDuration, cfg.EncryptionKey)
+	if err != nil {
+		return &EndpointError{Err: err, delay: delayDuration}
+	}
+
+	e := m.EndpointMetadata
+	resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), m.Metadata.Data, g, signatures, hmac, timestamp, int64(cfg.MaxResponseSize))
+
+	done := err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299
+	if done {
+		log.Debugf("meta event %s sent to %s", m.EventMetadata.EventType, e.TargetURL)
+		e.Sent = true
+		m.Status = datastore.SuccessEventStatus
+		m.Description = ""
+	} else {
+		log.Errorf("meta event %s failed. Reason: %s", m.UID, deliveryFailureReason(resp.Status, err))
+		m.Status = datastore.RetryEventStatus
+		m.Metadata.NextSendTime = primitive.NewDateTimeFromTime(time.Now().Add(delayDuration))
+	}
+
+	attempt := ParseAttemptFromResponse(m, e, resp, done)
+
+	m.Metadata.NumTrials++
+	if !done && m.Metadata.NumTrials >= m.Metadata.RetryLimit {
+		log.Errorf("%s retry limit exceeded ", m.UID)
+		m.Description = "Retry limit exceeded"
+		m.Status = datastore.FailureEventStatus
+	}
+
+	updated, err := eventDeliveryRepo.UpdateEventDeliveryWithAttempt(ctx, *m, attempt)
+	if err != nil {
+		log.WithError(err).Error("failed to update message ", m.UID)
+	} else if !updated {
+		log.Warnf("meta event delivery %s is no longer processing, dropping its attempt", m.UID)
+		return nil
+	} else {
+		pubsub.Publish(*m)
+	}
+
+	if !done && m.Metadata.NumTrials < m.Metadata.RetryLimit {
+		return &EndpointError{Err: ErrDeliveryAttemptFailed, delay: delayDuration}
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type metaEventReceiver struct {
+	mu        sync.Mutex
+	events    []MetaEvent
+	bodies    []string
+	signature []string
+}
+
+// newMetaEventServer returns a meta event url that responds with status.
+func newMetaEventServer(t *testing.T, status int) (*httptest.Server, *metaEventReceiver) {
+	rcv := &metaEventReceiver{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var event MetaEvent
+		require.NoError(t, json.Unmarshal(b, &event))
+
+		rcv.mu.Lock()
+		rcv.events = append(rcv.events, event)
+		rcv.bodies = append(rcv.bodies, string(b))
+		rcv.signature = append(rcv.signature, r.Header.Get("X-Convoy-Signature"))
+		rcv.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+
+	return srv, rcv
+}
+
+// newTestMetaEventEmitter returns an emitter whose deliveries are kept in
+// queued, every one of them is expected to be both stored and queued.
+func newTestMetaEventEmitter(t *testing.T, ctrl *gomock.Controller) (*MetaEventEmitter, *[]*datastore.EventDelivery) {
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	eventQueue := mocks.NewMockQueuer(ctrl)
+
+	var queued []*datastore.EventDelivery
+	eventDeliveryRepo.EXPECT().
+		CreateEventDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *datastore.EventDelivery) error {
+			queued = append(queued, d)
+			return nil
+		}).AnyTimes()
+
+	eventQueue.EXPECT().
+		WriteEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ convoy.TaskName, d *datastore.EventDelivery, _ interface{}) error {
+			require.Contains(t, queued, d)
+			return nil
+		}).AnyTimes()
+
+	return NewMetaEventEmitter(eventDeliveryRepo, eventQueue), &queued
+}
+
+func queuedMetaEvent(t *testing.T, d *datastore.EventDelivery) MetaEvent {
+	var event MetaEvent
+	require.NoError(t, json.Unmarshal(d.Metadata.Data, &event))
+	return event
+}
+
+// encryptedMetaEventSecret is "meta-secret" encrypted with the key of the
+// test config, as the secrets of stored groups are.
+var encryptedMetaEventSecret = func() string {
+	secret, err := util.Encrypt("meta-secret", "test-encryption-key")
+	if err != nil {
+		panic(err)
+	}
+	return secret
+}()
+
+func metaEventGroup(url string, eventTypes ...datastore.MetaEventType) *datastore.Group {
+	return &datastore.Group{
+		UID:  "group-1",
+		Name: "test-group",
+		Config: &datastore.GroupConfig{
+			Strategy: datastore.StrategyConfiguration{
+				Type:    config.DefaultStrategyProvider,
+				Default: datastore.DefaultStrategyConfiguration{IntervalSeconds: 20, RetryLimit: 2},
+			},
+			Signature: datastore.SignatureConfiguration{
+				Header: config.SignatureHeaderProvider("X-Convoy-Signature"),
+				Hash:   "SHA256",
+			},
+			MetaEvent: &datastore.MetaEventConfiguration{
+				URL:        url,
+				Secret:     encryptedMetaEventSecret,
+				EventTypes: eventTypes,
+			},
+		},
+	}
+}
+
+func TestMetaEventEmitter_Emit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaEvents, queued := newTestMetaEventEmitter(t, ctrl)
+
+	g := metaEventGroup("https://example.com/meta", datastore.DeliverySuccessMetaEvent)
+	delivery := &MetaEventDelivery{UID: "delivery-1", Status: datastore.SuccessEventStatus}
+
+	err := metaEvents.Emit(context.Background(), g, datastore.DeliverySuccessMetaEvent, delivery)
+	require.NoError(t, err)
+
+	// not subscribed
+	err = metaEvents.Emit(context.Background(), g, datastore.EndpointDisabledMetaEvent, &MetaEventEndpoint{UID: "endpoint-1"})
+	require.NoError(t, err)
+
+	require.Len(t, *queued, 1)
+
+	d := (*queued)[0]
+	require.True(t, d.IsMetaEvent)
+	require.Equal(t, datastore.ScheduledEventStatus, d.Status)
+	require.Equal(t, "https://example.com/meta", d.EndpointMetadata.TargetURL)
+	require.Equal(t, "group-1", d.AppMetadata.GroupID)
+	require.Equal(t, uint64(2), d.Metadata.RetryLimit)
+	require.Equal(t, uint64(20), d.Metadata.IntervalSeconds)
+
+	event := queuedMetaEvent(t, d)
+	require.Equal(t, datastore.DeliverySuccessMetaEvent, event.EventType)
+	require.Equal(t, "group-1", event.GroupID)
+	require.Equal(t, event.UID, d.EventMetadata.UID)
+}
+
+func TestMetaEventEmitter_RecursionGuard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaEvents, queued := newTestMetaEventEmitter(t, ctrl)
+
+	g := metaEventGroup("https://example.com/meta", datastore.DeliverySuccessMetaEvent, datastore.DeliveryDeadLetteredMetaEvent)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		data interface{}
+	}{
+		{
+			name: "should_refuse_to_emit_while_sending_a_meta_event",
+			ctx:  context.WithValue(context.Background(), metaEventCtxKey{}, datastore.DeliverySuccessMetaEvent),
+			data: &MetaEventDelivery{UID: "delivery-1"},
+		},
+		{
+			name: "should_refuse_to_emit_a_meta_event_about_a_meta_event",
+			ctx:  context.Background(),
+			data: &MetaEvent{UID: "meta-1", EventType: datastore.DeliverySuccessMetaEvent},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := metaEvents.Emit(tc.ctx, g, datastore.DeliveryDeadLetteredMetaEvent, tc.data)
+			require.ErrorIs(t, err, ErrMetaEventRecursion)
+		})
+	}
+
+	// the delivery of a meta event failing isn't reported either
+	metaEvents.EmitDelivery(g, &datastore.EventDelivery{UID: "delivery-2", IsMetaEvent: true}, datastore.DeliveryDeadLetteredMetaEvent)
+
+	require.Empty(t, *queued)
+}
+
+func TestProcessEventDelivery_SendsMetaEvents(t *testing.T) {
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		status     int
+		numTrials  uint64
+		wantStatus datastore.EventDeliveryStatus
+		wantErr    bool
+	}{
+		{
+			name:       "should_send_a_meta_event",
+			status:     http.StatusOK,
+			wantStatus: datastore.SuccessEventStatus,
+		},
+		{
+			name:       "should_retry_a_failed_meta_event",
+			status:     http.StatusInternalServerError,
+			wantStatus: datastore.RetryEventStatus,
+			wantErr:    true,
+		},
+		{
+			name:       "should_fail_a_meta_event_out_of_retries",
+			status:     http.StatusInternalServerError,
+			numTrials:  1,
+			wantStatus: datastore.FailureEventStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv, rcv := newMetaEventServer(t, tc.status)
+			defer srv.Close()
+
+			metaEvents, queued := newTestMetaEventEmitter(t, ctrl)
+
+			g := metaEventGroup(srv.URL, datastore.DeliverySuccessMetaEvent)
+			err := metaEvents.Emit(context.Background(), g, datastore.DeliverySuccessMetaEvent, &MetaEventDelivery{UID: "delivery-1"})
+			require.NoError(t, err)
+			require.Len(t, *queued, 1)
+
+			d := (*queued)[0]
+			d.Metadata.NumTrials = tc.numTrials
+
+			groupRepo := mocks.NewMockGroupRepository(ctrl)
+			msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+			msgRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), d.UID).Return(d, nil).Times(1)
+			msgRepo.EXPECT().
+				TransitionStatus(gomock.Any(), d.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus).
+				Return(true, nil).Times(1)
+			groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-1").Return(g, nil).Times(1)
+
+			var updated datastore.EventDelivery
+			var attempt datastore.DeliveryAttempt
+			msgRepo.EXPECT().
+				UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, m datastore.EventDelivery, a datastore.DeliveryAttempt) (bool, error) {
+					updated, attempt = m, a
+					return true, nil
+				}).Times(1)
+
+			// meta event deliveries have no app, so no app is loaded
+			processFn := ProcessEventDelivery(mocks.NewMockApplicationRepository(ctrl), msgRepo, groupRepo, nil, nil, metaEvents, nil)
+
+			err = processFn(&queue.Job{ID: d.UID})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.wantStatus, updated.Status)
+			require.Equal(t, tc.numTrials+1, updated.Metadata.NumTrials)
+			require.Equal(t, tc.status == http.StatusOK, attempt.Status)
+
+			require.Len(t, rcv.events, 1)
+			require.Equal(t, datastore.DeliverySuccessMetaEvent, rcv.events[0].EventType)
+
+			hmac, err := util.ComputeJSONHmac("SHA256", rcv.bodies[0], "meta-secret", false)
+			require.NoError(t, err)
+			require.Equal(t, hmac, rcv.signature[0])
+
+			// sending a meta event never emits another
+			require.Len(t, *queued, 1)
+		})
+	}
+}
+
+func TestProcessEventDelivery_EmitsSuccessMetaEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaEvents, queued := newTestMetaEventEmitter(t, ctrl)
+	groupConfig := metaEventGroup("https://example.com/meta", datastore.DeliverySuccessMetaEvent).Config
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	deliverEvent(t, srv.URL, `{"event":"payment.created"}`, nil, groupConfig, &datastore.Application{}, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus}, metaEvents)
+
+	require.Len(t, *queued, 1)
+	require.Equal(t, datastore.DeliverySuccessMetaEvent, queuedMetaEvent(t, (*queued)[0]).EventType)
+	require.NotContains(t, string((*queued)[0].Metadata.Data), "aaaaaaaaaaaaaaa", "the endpoint secret must not be sent")
+}
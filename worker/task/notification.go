@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/frain-dev/convoy/util"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/frain-dev/convoy/notification"
 	"github.com/frain-dev/convoy/notification/email"
 	"github.com/frain-dev/convoy/notification/slack"
+	log "github.com/sirupsen/logrus"
 )
 
 func sendNotification(
@@ -77,3 +79,57 @@ func sendSlackNotification(ctx context.Context, slackWebhookURL string, n *notif
 	}
 	return nil
 }
+
+// disableEndpoint marks endpoint inactive with reason, then tells the group
+// with a meta event and the owner of app through notifier.
+func disableEndpoint(
+	ctx context.Context,
+	appRepo datastore.ApplicationRepository,
+	metaEvents *MetaEventEmitter,
+	notifier notification.Notifier,
+	g *datastore.Group,
+	app *datastore.Application,
+	m *datastore.EventDelivery,
+	endpoint *datastore.Endpoint,
+	reason string,
+) error {
+	err := appRepo.DisableApplicationEndpoint(ctx, app.UID, endpoint.UID, reason)
+	if err != nil {
+		return err
+	}
+
+	disabledAt := time.Now()
+	metaEvents.EmitEndpointDisabled(g, m, endpoint, reason, disabledAt)
+
+	if notifier == nil {
+		return nil
+	}
+
+	n := &notification.EndpointDisabled{
+		Email:           app.SupportEmail,
+		LogoURL:         g.LogoURL,
+		AppID:           app.UID,
+		AppTitle:        app.Title,
+		EndpointID:      endpoint.UID,
+		TargetURL:       endpoint.TargetURL,
+		EventDeliveryID: m.UID,
+		Reason:          reason,
+		DisabledAt:      disabledAt,
+	}
+
+	err = notifier.NotifyEndpointDisabled(ctx, n)
+	if err != nil {
+		log.WithError(err).Errorf("failed to notify the owner of app %s that endpoint %s was disabled", app.UID, endpoint.UID)
+	}
+
+	return nil
+}
+
+// deliveryFailureReason describes why sending an event delivery failed.
+func deliveryFailureReason(status string, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("endpoint responded with status %s", status)
+}
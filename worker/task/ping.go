@@ -0,0 +1,55 @@
+package task
+
+import (
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/util"
+)
+
+// SendToEndpoint sends payload to endpoint the way event deliveries are
+// sent: signed with the endpoint's secret, with the custom headers of g
+// and app, through g's outbound proxy and within the endpoint's timeout.
+// At most maxResponseSize bytes of the response body are read.
+func SendToEndpoint(g *datastore.Group, app *datastore.Application, endpoint *datastore.Endpoint, payload []byte, encryptionKey string, maxResponseSize int64) (*net.Response, error) {
+	httpDuration, err := endpointHttpTimeout(endpoint.HttpTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	dispatch, err := newGroupDispatcher(g, httpDuration, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := endpointSecret(app, endpoint, &datastore.EndpointMetadata{})
+	hmac, timestamp, signatures, err := signPayload(g, secret, string(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	headers := withHeaders(mergeCustomHeaders(g.Config.CustomHeaders, app.CustomHeaders), signatures)
+	return dispatch.SendRequest(endpoint.TargetURL, string(convoy.HttpPost), payload, g, headers, hmac, timestamp, maxResponseSize)
+}
+
+// endpointHttpTimeout parses timeout, an endpoint's http timeout, which
+// defaults to convoy.HTTP_TIMEOUT when unset. It is capped at
+// convoy.MAX_HTTP_TIMEOUT, so a delivery is never sent for longer.
+func endpointHttpTimeout(timeout string) (time.Duration, error) {
+	if util.IsStringEmpty(timeout) {
+		timeout = convoy.HTTP_TIMEOUT
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration > convoy.MAX_HTTP_TIMEOUT {
+		return convoy.MAX_HTTP_TIMEOUT, nil
+	}
+
+	return duration, nil
+}
@@ -3,12 +3,14 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pubsub"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -70,29 +72,30 @@ func ProcessEventCreated(appRepo datastore.ApplicationRepository, eventRepo data
 
 		matchedEndpoints := matchEndpointsForDelivery(event.EventType, app.Endpoints, nil)
 		event.MatchedEndpoints = len(matchedEndpoints)
+		event.ExpiresAt = group.EventExpiry(event.CreatedAt.Time())
 		err = eventRepo.CreateEvent(ctx, event)
 		if err != nil {
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		var intervalSeconds uint64
-		var retryLimit uint64
-		if string(group.Config.Strategy.Type) == string(config.DefaultStrategyProvider) {
-			intervalSeconds = group.Config.Strategy.Default.IntervalSeconds
-			retryLimit = group.Config.Strategy.Default.RetryLimit
-		} else if string(group.Config.Strategy.Type) == string(config.ExponentialBackoffStrategyProvider) {
-			intervalSeconds = 0
-			retryLimit = group.Config.Strategy.ExponentialBackoff.RetryLimit
-		} else {
+		intervalSeconds, stepSeconds, retryLimit, ok := groupRetrySettings(group)
+		if !ok {
 			return nil
 		}
 
+		eventDeliveries := make([]*datastore.EventDelivery, 0, len(matchedEndpoints))
 		for _, v := range matchedEndpoints {
+			rateLimit, rateLimitDuration := v.DeliveryRateLimit(group)
+
 			eventDelivery := &datastore.EventDelivery{
 				UID: uuid.New().String(),
 				EventMetadata: &datastore.EventMetadata{
-					UID:       event.UID,
-					EventType: event.EventType,
+					UID:          event.UID,
+					EventType:    event.EventType,
+					EventVersion: event.EventVersion,
+					Tags:         event.Tags,
+					TraceParent:  event.TraceParent,
+					TraceState:   event.TraceState,
 				},
 				EndpointMetadata: &datastore.EndpointMetadata{
 					UID:               v.UID,
@@ -100,8 +103,8 @@ func ProcessEventCreated(appRepo datastore.ApplicationRepository, eventRepo data
 					Status:            v.Status,
 					Secret:            v.Secret,
 					Sent:              false,
-					RateLimit:         v.RateLimit,
-					RateLimitDuration: v.RateLimitDuration,
+					RateLimit:         rateLimit,
+					RateLimitDuration: rateLimitDuration,
 					HttpTimeout:       v.HttpTimeout,
 				},
 				AppMetadata: &datastore.AppMetadata{
@@ -115,23 +118,27 @@ func ProcessEventCreated(appRepo datastore.ApplicationRepository, eventRepo data
 					Strategy:        group.Config.Strategy.Type,
 					NumTrials:       0,
 					IntervalSeconds: intervalSeconds,
+					StepSeconds:     stepSeconds,
 					RetryLimit:      retryLimit,
 					NextSendTime:    primitive.NewDateTimeFromTime(time.Now()),
 				},
-				Status:           getEventDeliveryStatus(v),
+				Status:           getEventDeliveryStatus(v, app),
+				Description:      eventDeliverySkipReason(v),
 				DeliveryAttempts: []datastore.DeliveryAttempt{},
 				DocumentStatus:   datastore.ActiveDocumentStatus,
+				ExpiresAt:        event.ExpiresAt,
 				CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
 				UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
 			}
 
-			err = eventDeliveryRepo.CreateEventDelivery(ctx, eventDelivery)
-			if err != nil {
-				log.WithError(err).Error("error occurred creating event delivery")
-			}
+			eventDeliveries = append(eventDeliveries, eventDelivery)
+		}
 
-			taskName := convoy.EventProcessor.SetPrefix(group.Name)
-			if eventDelivery.Status != datastore.DiscardedEventStatus {
+		taskName := convoy.EventProcessor.SetPrefix(group.Name)
+		for _, eventDelivery := range createEventDeliveries(ctx, eventDeliveryRepo, eventDeliveries) {
+			pubsub.Publish(*eventDelivery)
+
+			if eventDelivery.Status == datastore.ScheduledEventStatus {
 				err = eventQueue.WriteEventDelivery(ctx, taskName, eventDelivery, 1*time.Second)
 				if err != nil {
 					log.Errorf("Error occurred sending new event to the queue %s", err)
@@ -143,14 +150,83 @@ func ProcessEventCreated(appRepo datastore.ApplicationRepository, eventRepo data
 	}
 }
 
-func getEventDeliveryStatus(endpoint datastore.Endpoint) datastore.EventDeliveryStatus {
+// groupRetrySettings returns the retry interval, step and limit of the
+// deliveries of g, ok is false when g's strategy is unknown.
+func groupRetrySettings(g *datastore.Group) (intervalSeconds, stepSeconds, retryLimit uint64, ok bool) {
+	switch string(g.Config.Strategy.Type) {
+	case string(config.DefaultStrategyProvider):
+		return g.Config.Strategy.Default.IntervalSeconds, 0, g.Config.Strategy.Default.RetryLimit, true
+	case string(config.ExponentialBackoffStrategyProvider):
+		return 0, 0, g.Config.Strategy.ExponentialBackoff.RetryLimit, true
+	case string(config.LinearStrategyProvider):
+		return g.Config.Strategy.Linear.InitialIntervalSeconds, g.Config.Strategy.Linear.StepSeconds, g.Config.Strategy.Linear.RetryLimit, true
+	}
+
+	return 0, 0, 0, false
+}
+
+// createEventDeliveries writes deliveries in bulk, retrying once those that
+// failed to be written, and returns the ones that were written.
+func createEventDeliveries(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, deliveries []*datastore.EventDelivery) []*datastore.EventDelivery {
+	pending := deliveries
+	for attempt := 0; attempt < 2 && len(pending) > 0; attempt++ {
+		err := eventDeliveryRepo.CreateEventDeliveries(ctx, pending)
+		if err == nil {
+			pending = nil
+			break
+		}
+
+		var writeErr *datastore.DeliveriesWriteError
+		if !errors.As(err, &writeErr) {
+			// nothing is known to have been written, retry them all
+			writeErr = &datastore.DeliveriesWriteError{Failed: pending, Err: err}
+		}
+
+		log.WithError(err).Errorf("error occurred creating %d event deliveries", len(writeErr.Failed))
+		pending = writeErr.Failed
+	}
+
+	if len(pending) == 0 {
+		return deliveries
+	}
+
+	failed := make(map[*datastore.EventDelivery]bool, len(pending))
+	for _, d := range pending {
+		failed[d] = true
+	}
+
+	created := make([]*datastore.EventDelivery, 0, len(deliveries)-len(pending))
+	for _, d := range deliveries {
+		if !failed[d] {
+			created = append(created, d)
+		}
+	}
+
+	return created
+}
+
+func getEventDeliveryStatus(endpoint datastore.Endpoint, app *datastore.Application) datastore.EventDeliveryStatus {
 	if endpoint.Status != datastore.ActiveEndpointStatus {
 		return datastore.DiscardedEventStatus
 	}
 
+	if app.IsPaused {
+		return datastore.HeldEventStatus
+	}
+
 	return datastore.ScheduledEventStatus
 }
 
+// eventDeliverySkipReason records why no attempt will be made to deliver
+// to endpoint, deliveries to endpoints that aren't active are discarded.
+func eventDeliverySkipReason(endpoint datastore.Endpoint) string {
+	if endpoint.Status == datastore.ActiveEndpointStatus {
+		return ""
+	}
+
+	return fmt.Sprintf("endpoint is %s", endpoint.Status)
+}
+
 func matchEndpointsForDelivery(ev datastore.EventType, endpoints, matched []datastore.Endpoint) []datastore.Endpoint {
 	if len(endpoints) == 0 {
 		return matched
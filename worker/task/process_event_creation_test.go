@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEventDeliveries(t *testing.T) {
+	d1 := &datastore.EventDelivery{UID: "delivery-1"}
+	d2 := &datastore.EventDelivery{UID: "delivery-2"}
+	d3 := &datastore.EventDelivery{UID: "delivery-3"}
+	deliveries := []*datastore.EventDelivery{d1, d2, d3}
+
+	tests := []struct {
+		name    string
+		dbFn    func(ed *mocks.MockEventDeliveryRepository)
+		created []*datastore.EventDelivery
+	}{
+		{
+			name: "should_create_all_deliveries",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository) {
+				ed.EXPECT().CreateEventDeliveries(gomock.Any(), deliveries).Times(1).Return(nil)
+			},
+			created: deliveries,
+		},
+		{
+			name: "should_retry_only_failed_deliveries",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository) {
+				gomock.InOrder(
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), deliveries).Times(1).
+						Return(&datastore.DeliveriesWriteError{Failed: []*datastore.EventDelivery{d2}, Err: errors.New("failed")}),
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), []*datastore.EventDelivery{d2}).Times(1).Return(nil),
+				)
+			},
+			created: deliveries,
+		},
+		{
+			name: "should_drop_deliveries_that_fail_twice",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository) {
+				writeErr := &datastore.DeliveriesWriteError{Failed: []*datastore.EventDelivery{d2}, Err: errors.New("failed")}
+				gomock.InOrder(
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), deliveries).Times(1).Return(writeErr),
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), []*datastore.EventDelivery{d2}).Times(1).Return(writeErr),
+				)
+			},
+			created: []*datastore.EventDelivery{d1, d3},
+		},
+		{
+			name: "should_retry_all_deliveries_on_other_errors",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository) {
+				gomock.InOrder(
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), deliveries).Times(1).Return(errors.New("failed")),
+					ed.EXPECT().CreateEventDeliveries(gomock.Any(), deliveries).Times(1).Return(errors.New("failed")),
+				)
+			},
+			created: []*datastore.EventDelivery{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ed := mocks.NewMockEventDeliveryRepository(ctrl)
+			tc.dbFn(ed)
+
+			created := createEventDeliveries(context.Background(), ed, deliveries)
+			require.Equal(t, tc.created, created)
+		})
+	}
+}
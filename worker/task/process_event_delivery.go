@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -14,8 +16,11 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/notification"
+	"github.com/frain-dev/convoy/pubsub"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/retrystrategies"
+	"github.com/frain-dev/convoy/tracer"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -23,6 +28,7 @@ import (
 )
 
 var ErrDeliveryAttemptFailed = errors.New("Error sending event")
+var ErrRetryBudgetExhausted = errors.New("group retry budget exhausted")
 var defaultDelay time.Duration = 30
 
 type EndpointError struct {
@@ -43,7 +49,11 @@ type SignatureValues struct {
 	Timestamp string
 }
 
-func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter) func(*queue.Job) error {
+// ProcessEventDelivery returns the handler sending event deliveries. Once
+// one succeeds its event is forwarded with bridge, when it is not nil.
+// Meta events about deliveries are emitted with metaEvents, and owners of
+// apps whose endpoints get disabled are told through notifier.
+func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, bridge *EventBridge, metaEvents *MetaEventEmitter, notifier notification.Notifier) func(*queue.Job) error {
 	return func(job *queue.Job) error {
 		Id := job.ID
 
@@ -58,10 +68,97 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 
 		switch m.Status {
 		case datastore.ProcessingEventStatus,
-			datastore.SuccessEventStatus:
+			datastore.SuccessEventStatus,
+			datastore.HeldEventStatus:
 			return nil
 		}
 
+		if m.IsMetaEvent {
+			return processMetaEventDelivery(eventDeliveryRepo, groupRepo, m, delayDuration)
+		}
+
+		app, err := appRepo.FindApplicationByID(context.Background(), m.AppMetadata.UID)
+		if err != nil {
+			log.WithError(err).Errorf("could not retrieve app %s", m.AppMetadata.UID)
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		// deliveries to a paused app are held until it is resumed, which
+		// puts them back on the queue
+		if app.IsPaused {
+			log.Debugf("app %s is paused, holding %s", app.UID, m.UID)
+
+			held, err := eventDeliveryRepo.TransitionStatus(context.Background(), m.UID, m.Status, datastore.HeldEventStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update status of event delivery")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			if !held {
+				return nil
+			}
+
+			// a resume that loaded the app's held deliveries before this one
+			// was held never releases it, so the app is checked again now
+			// that it is and the delivery released here if it was resumed
+			app, err = appRepo.FindApplicationByID(context.Background(), m.AppMetadata.UID)
+			if err != nil {
+				log.WithError(err).Errorf("could not retrieve app %s", m.AppMetadata.UID)
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			if app.IsPaused {
+				return nil
+			}
+
+			released, err := eventDeliveryRepo.TransitionStatus(context.Background(), m.UID, datastore.HeldEventStatus, datastore.ScheduledEventStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update status of event delivery")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			// the resume released it first and queued it again
+			if !released {
+				return nil
+			}
+
+			m.Status = datastore.ScheduledEventStatus
+		}
+
+		g, err := groupRepo.FetchGroupByID(context.Background(), m.AppMetadata.GroupID)
+		if err != nil {
+			log.WithError(err).Error("could not find error")
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		// the budget is checked before the delivery is moved to processing,
+		// so a postponed delivery never charges the endpoint's rate limit
+		var budgetKey string
+		var maxAttempts int
+		if g.Config.RetryBudget != nil && g.Config.RetryBudget.MaxAttemptsPerHour > 0 {
+			now := time.Now()
+			budgetKey = retryBudgetKey(g.UID, now)
+			maxAttempts = int(g.Config.RetryBudget.MaxAttemptsPerHour)
+
+			res, err := rateLimiter.ShouldAllow(context.Background(), budgetKey, maxAttempts, int(time.Hour))
+			if err != nil {
+				log.WithError(err).Error("failed to check group retry budget")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			if res.Remaining <= 0 {
+				log.Warnf("%s postponed, group %s has used its retry budget of %d attempts for this hour", m.UID, g.UID, maxAttempts)
+
+				_, err = eventDeliveryRepo.TransitionStatus(context.Background(), m.UID, m.Status, datastore.PostponedEventStatus)
+				if err != nil {
+					log.WithError(err).Error("failed to update status of event delivery")
+				}
+
+				nextWindow := now.UTC().Truncate(time.Hour).Add(time.Hour)
+				return &EndpointError{Err: ErrRetryBudgetExhausted, delay: nextWindow.Sub(now)}
+			}
+		}
+
 		var rateLimitDuration time.Duration
 		if util.IsStringEmpty(m.EndpointMetadata.RateLimitDuration) {
 			rateLimitDuration, err = time.ParseDuration(convoy.RATE_LIMIT_DURATION)
@@ -102,56 +199,44 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			return nil
 		}
 
-		err = eventDeliveryRepo.UpdateStatusOfEventDelivery(context.Background(), *m, datastore.ProcessingEventStatus)
+		dbEndpoint, err := appRepo.FindApplicationEndpointByID(context.Background(), m.AppMetadata.UID, m.EndpointMetadata.UID)
 		if err != nil {
-			log.WithError(err).Error("failed to update status of messages - ")
+			log.WithError(err).Errorf("could not retrieve endpoint %s", m.EndpointMetadata.UID)
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
-		var attempt datastore.DeliveryAttempt
-		var secret = m.EndpointMetadata.Secret
-
-		cfg, err := config.Get()
-		if err != nil {
-			return &EndpointError{Err: err, delay: delayDuration}
-		}
+		// an endpoint disabled after exhausting its retries, see
+		// GroupConfig.DisableEndpoint, gets nothing until it is reactivated
+		if dbEndpoint.Status == datastore.InactiveEndpointStatus {
+			log.Debugf("endpoint %s is inactive, discarding %s", m.EndpointMetadata.TargetURL, m.UID)
 
-		var httpDuration time.Duration
-		if util.IsStringEmpty(m.EndpointMetadata.HttpTimeout) {
-			httpDuration, err = time.ParseDuration(convoy.HTTP_TIMEOUT)
+			_, err = eventDeliveryRepo.TransitionStatus(context.Background(), m.UID, m.Status, datastore.DiscardedEventStatus)
 			if err != nil {
-				log.WithError(err).Errorf("failed to parse endpoint duration")
-				return nil
+				log.WithError(err).Error("failed to update status of event delivery")
+				return &EndpointError{Err: err, delay: delayDuration}
 			}
-		} else {
-			httpDuration, err = time.ParseDuration(m.EndpointMetadata.HttpTimeout)
-			if err != nil {
-				log.WithError(err).Errorf("failed to parse endpoint duration")
-				return nil
-			}
-		}
 
-		dispatch := net.NewDispatcher(httpDuration)
-
-		var done = true
-
-		e := m.EndpointMetadata
-		if m.Status == datastore.SuccessEventStatus {
-			log.Debugf("endpoint %s already merged with message %s\n", e.TargetURL, m.UID)
 			return nil
 		}
 
-		dbEndpoint, err := appRepo.FindApplicationEndpointByID(context.Background(), m.AppMetadata.UID, e.UID)
+		var attempt datastore.DeliveryAttempt
+		var secret = endpointSecret(app, dbEndpoint, m.EndpointMetadata)
+
+		cfg, err := config.Get()
 		if err != nil {
-			log.WithError(err).Errorf("could not retrieve endpoint %s", e.UID)
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
-		if dbEndpoint.Status == datastore.InactiveEndpointStatus {
-			log.Debugf("endpoint %s is inactive, failing to send.", e.TargetURL)
+		httpDuration, err := endpointHttpTimeout(m.EndpointMetadata.HttpTimeout)
+		if err != nil {
+			log.WithError(err).Errorf("failed to parse endpoint duration")
 			return nil
 		}
 
+		var done = true
+
+		e := m.EndpointMetadata
+
 		buff := bytes.NewBuffer([]byte{})
 		encoder := json.NewEncoder(buff)
 		encoder.SetEscapeHTML(false)
@@ -162,42 +247,66 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 
 		bStr := strings.TrimSuffix(buff.String(), "\n")
 
-		g, err := groupRepo.FetchGroupByID(context.Background(), m.AppMetadata.GroupID)
+		dispatch, err := newGroupDispatcher(g, httpDuration, cfg.EncryptionKey)
 		if err != nil {
-			log.WithError(err).Error("could not find error")
+			log.WithError(err).Errorf("failed to set up the outbound proxy of group %s", g.UID)
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
-		var signedPayload strings.Builder
-		var timestamp string
-		if g.Config.ReplayAttacks {
-			timestamp = fmt.Sprint(time.Now().Unix())
-			signedPayload.WriteString(timestamp)
-			signedPayload.WriteString(",")
-		}
-		signedPayload.WriteString(bStr)
 
-		hmac, err := util.ComputeJSONHmac(g.Config.Signature.Hash, signedPayload.String(), secret, false)
+		hmac, timestamp, signatures, err := signPayload(g, secret, bStr)
 		if err != nil {
 			log.Errorf("error occurred while generating hmac - %+v\n", err)
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
+		targetURL, err := EventVersionRouter{}.TargetURL(app, e, m.EventMetadata)
+		if err != nil {
+			log.WithError(err).Errorf("failed to route %s to a versioned endpoint", m.UID)
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		if budgetKey != "" {
+			_, err = rateLimiter.Allow(context.Background(), budgetKey, maxAttempts, int(time.Hour))
+			if err != nil {
+				log.WithError(err).Error("failed to increment group retry budget")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+		}
+
+		// nothing below may return before the attempt is recorded, the
+		// delivery would be left in processing
+		moved, err := eventDeliveryRepo.TransitionStatus(context.Background(), m.UID, m.Status, datastore.ProcessingEventStatus)
+		if err != nil {
+			log.WithError(err).Error("failed to update status of messages - ")
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		// the delivery changed since it was loaded, most likely another
+		// worker picked it up, it is left to that change
+		if !moved {
+			log.Debugf("%s is no longer %s, skipping it", m.UID, m.Status)
+			return nil
+		}
+		m.Status = datastore.ProcessingEventStatus
+
 		attemptStatus := false
 		start := time.Now()
 
-		resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), []byte(bStr), g, hmac, timestamp, int64(cfg.MaxResponseSize))
+		headers := withHeaders(deliveryHeaders(g, app, m.EventMetadata), signatures)
+		resp, err := dispatch.SendRequest(targetURL, string(convoy.HttpPost), []byte(bStr), g, headers, hmac, timestamp, int64(cfg.MaxResponseSize))
 		status := "-"
 		statusCode := 0
 		if resp != nil {
 			status = resp.Status
 			statusCode = resp.StatusCode
 		}
+		failure := deliveryFailureReason(status, err)
 
 		duration := time.Since(start)
 		// log request details
 		requestLogger := log.WithFields(log.Fields{
 			"status":   status,
-			"uri":      e.TargetURL,
+			"uri":      targetURL,
 			"method":   convoy.HttpPost,
 			"duration": duration,
 		})
@@ -245,16 +354,15 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		}
 
 		if !done && dbEndpoint.Status == datastore.PendingEndpointStatus {
-			endpoints := []string{dbEndpoint.UID}
-			endpointStatus := datastore.InactiveEndpointStatus
+			reason := fmt.Sprintf("failed to receive event delivery %s while being re-activated: %s", m.UID, failure)
 
-			err := appRepo.UpdateApplicationEndpointsStatus(context.Background(), m.AppMetadata.UID, endpoints, endpointStatus)
+			err := disableEndpoint(context.Background(), appRepo, metaEvents, notifier, g, app, m, dbEndpoint, reason)
 			if err != nil {
-				log.WithError(err).Error("Failed to reactivate endpoint after successful retry")
+				log.WithError(err).Error("Failed to disable endpoint after failed retry")
 			}
 		}
 
-		attempt = parseAttemptFromResponse(m, e, resp, attemptStatus)
+		attempt = ParseAttemptFromResponse(m, e, resp, attemptStatus)
 
 		m.Metadata.NumTrials++
 
@@ -272,12 +380,12 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 
 			endpointStatus := dbEndpoint.Status
 			if g.Config.DisableEndpoint && dbEndpoint.Status != datastore.PendingEndpointStatus {
-				endpoints := []string{dbEndpoint.UID}
 				endpointStatus = datastore.InactiveEndpointStatus
+				reason := fmt.Sprintf("retry limit of %d was hit sending event delivery %s: %s", m.Metadata.RetryLimit, m.UID, failure)
 
-				err := appRepo.UpdateApplicationEndpointsStatus(context.Background(), m.AppMetadata.UID, endpoints, endpointStatus)
+				err := disableEndpoint(context.Background(), appRepo, metaEvents, notifier, g, app, m, dbEndpoint, reason)
 				if err != nil {
-					log.WithError(err).Error("Failed to reactivate endpoint after successful retry")
+					log.WithError(err).Error("Failed to disable endpoint after retry limit was hit")
 				}
 			}
 
@@ -287,9 +395,30 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			}
 		}
 
-		err = eventDeliveryRepo.UpdateEventDeliveryWithAttempt(context.Background(), *m, attempt)
+		if m.Status == datastore.SuccessEventStatus && bridge != nil {
+			err = bridge.Forward(context.Background(), m)
+			if err != nil {
+				log.WithError(err).Errorf("failed to forward the event of %s", m.UID)
+			}
+		}
+
+		updated, err := eventDeliveryRepo.UpdateEventDeliveryWithAttempt(context.Background(), *m, attempt)
 		if err != nil {
 			log.WithError(err).Error("failed to update message ", m.UID)
+		} else if !updated {
+			// the delivery was force completed, or otherwise moved on, while
+			// it was being sent, its current status is left as it is
+			log.Warnf("event delivery %s is no longer processing, dropping its attempt", m.UID)
+			return nil
+		} else {
+			pubsub.Publish(*m)
+		}
+
+		switch m.Status {
+		case datastore.SuccessEventStatus:
+			metaEvents.EmitDelivery(g, m, datastore.DeliverySuccessMetaEvent)
+		case datastore.FailureEventStatus:
+			metaEvents.EmitDelivery(g, m, datastore.DeliveryDeadLetteredMetaEvent)
 		}
 
 		if !done && m.Metadata.NumTrials < m.Metadata.RetryLimit {
@@ -299,11 +428,178 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		return nil
 	}
 }
-func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.EndpointMetadata, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
+
+// EventVersionRouter picks the url a delivery is sent to. Apps using
+// versioned endpoints get each version of an event at {url}/v{version}
+// of their endpoints, other apps get every version at the endpoint's url.
+type EventVersionRouter struct{}
+
+// TargetURL returns the url of endpoint e that app gets the event em at.
+// Events recorded before they had versions are routed as version 1.
+func (EventVersionRouter) TargetURL(app *datastore.Application, e *datastore.EndpointMetadata, em *datastore.EventMetadata) (string, error) {
+	if !app.UseVersionedEndpoints {
+		return e.TargetURL, nil
+	}
+
+	version := datastore.DefaultEventVersion
+	if em != nil && !util.IsStringEmpty(em.EventVersion) {
+		version = em.EventVersion
+	}
+
+	u, err := url.Parse(e.TargetURL)
+	if err != nil {
+		return "", err
+	}
+
+	// the version is escaped so it stays a single path segment
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/v" + url.PathEscape(version)
+	u.Path, err = url.PathUnescape(u.RawPath)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// signPayload computes the signature of payload with the group's signature
+// config, prefixing it with a timestamp when replay attack prevention is on.
+// The signatures of the group's additional algorithms are returned as
+// headers, each named after the signature header and its algorithm.
+func signPayload(g *datastore.Group, secret string, payload string) (string, string, map[string]string, error) {
+	var signedPayload strings.Builder
+	var timestamp string
+	if g.Config.ReplayAttacks {
+		timestamp = fmt.Sprint(time.Now().Unix())
+		signedPayload.WriteString(timestamp)
+		signedPayload.WriteString(",")
+	}
+	signedPayload.WriteString(payload)
+
+	hmac, err := util.ComputeJSONHmac(g.Config.Signature.Hash, signedPayload.String(), secret, false)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	headers := make(map[string]string, len(g.Config.Signature.Algorithms))
+	for _, algorithm := range g.Config.Signature.Algorithms {
+		signature, err := util.ComputeJSONHmac(algorithm, signedPayload.String(), secret, false)
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		headers[datastore.AlgorithmSignatureHeader(g.Config.Signature.Header, algorithm)] = signature
+	}
+
+	return hmac, timestamp, headers, nil
+}
+
+// withHeaders returns headers with extra added, extra taking precedence.
+// Names are canonicalized so that names differing only in case collide.
+func withHeaders(headers, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(headers)+len(extra))
+	for name, value := range headers {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+
+	for name, value := range extra {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+
+	return merged
+}
+
+// endpointSecret returns the secret webhooks to endpoint are signed with.
+// The endpoint's own secret is authoritative, so a rotated secret applies
+// to deliveries already queued. The secret copied onto the delivery, then
+// the app's deprecated secret, are used for endpoints that have none.
+func endpointSecret(app *datastore.Application, endpoint *datastore.Endpoint, metadata *datastore.EndpointMetadata) string {
+	if !util.IsStringEmpty(endpoint.Secret) {
+		return endpoint.Secret
+	}
+
+	if !util.IsStringEmpty(metadata.Secret) {
+		return metadata.Secret
+	}
+
+	return app.Secret
+}
+
+// mergeCustomHeaders returns the group's custom headers with the app's
+// merged on top, an app header replaces a group header of the same name.
+func mergeCustomHeaders(groupHeaders, appHeaders map[string]string) map[string]string {
+	headers := make(map[string]string, len(groupHeaders)+len(appHeaders))
+	for name, value := range groupHeaders {
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+
+	for name, value := range appHeaders {
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+
+	return headers
+}
+
+// deliveryHeaders returns the custom headers of a webhook, along with the
+// trace context of its event so the consumer can join the producer's trace.
+func deliveryHeaders(g *datastore.Group, app *datastore.Application, em *datastore.EventMetadata) map[string]string {
+	headers := mergeCustomHeaders(g.Config.CustomHeaders, app.CustomHeaders)
+	if em == nil || util.IsStringEmpty(em.TraceParent) {
+		return headers
+	}
+
+	headers[http.CanonicalHeaderKey(tracer.TraceParentHeader)] = em.TraceParent
+	if !util.IsStringEmpty(em.TraceState) {
+		headers[http.CanonicalHeaderKey(tracer.TraceStateHeader)] = em.TraceState
+	}
+
+	return headers
+}
+
+// newGroupDispatcher returns a Dispatcher that sends requests through the
+// outbound proxy of g, if it has one.
+func newGroupDispatcher(g *datastore.Group, timeout time.Duration, encryptionKey string) (*net.Dispatcher, error) {
+	p := g.Config.OutboundProxy
+	if p == nil || util.IsStringEmpty(p.URL) {
+		return net.NewDispatcher(timeout), nil
+	}
+
+	proxyURL, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.IsStringEmpty(p.Username) {
+		var password string
+		if !util.IsStringEmpty(p.Password) {
+			password, err = util.Decrypt(p.Password, encryptionKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt proxy password: %v", err)
+			}
+		}
+
+		proxyURL.User = url.UserPassword(p.Username, password)
+	}
+
+	return net.NewProxyDispatcher(timeout, g.UID, proxyURL, p.NoProxyHosts), nil
+}
+
+// retryBudgetKey returns the limiter key for a group's retry budget in the
+// hour window containing t, so the counter resets on every hour boundary.
+func retryBudgetKey(groupID string, t time.Time) string {
+	return fmt.Sprintf("retry_budget:%s:%s", groupID, t.UTC().Format("2006010215"))
+}
+
+// ParseAttemptFromResponse records resp as an attempt of delivery m to e.
+func ParseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.EndpointMetadata, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
 
 	responseHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
 	requestHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.RequestHeader)
 
+	var region string
+	if cfg, err := config.Get(); err == nil {
+		region = cfg.Region.Name
+	}
+
 	return datastore.DeliveryAttempt{
 		ID:         primitive.NewObjectID(),
 		UID:        uuid.New().String(),
@@ -314,14 +610,24 @@ func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.EndpointM
 		APIVersion: "2021-08-27",
 
 		IPAddress:        resp.IP,
+		DNSLookupMs:      durationMs(resp.DNSLookup),
+		ConnectMs:        durationMs(resp.Connect),
+		TLSHandshakeMs:   durationMs(resp.TLSHandshake),
 		ResponseHeader:   *responseHeader,
 		RequestHeader:    *requestHeader,
 		HttpResponseCode: resp.Status,
 		ResponseData:     string(resp.Body),
 		Error:            resp.Error,
 		Status:           attemptStatus,
+		Region:           region,
 
 		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
 	}
 }
+
+// durationMs rounds d up to whole milliseconds so that a phase that took
+// place is never reported as zero.
+func durationMs(d time.Duration) int64 {
+	return int64((d + time.Millisecond - 1) / time.Millisecond)
+}
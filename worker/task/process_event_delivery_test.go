@@ -1,19 +1,34 @@
 package task
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/go-redis/redis_rate/v9"
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/notification"
+	"github.com/frain-dev/convoy/notification/noop"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
 	"github.com/golang/mock/gomock"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProcessEventDelivery(t *testing.T) {
@@ -84,14 +99,96 @@ func TestProcessEventDelivery(t *testing.T) {
 				}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&datastore.Endpoint{
 						Status: datastore.InactiveEndpointStatus,
 					}, nil).Times(1)
+
+				o.EXPECT().
+					FetchGroupByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Group{Config: &datastore.GroupConfig{}}, nil).Times(1)
+			},
+		},
+		{
+			name:          "App is paused - delivery is held",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockApplicationRepository, o *mocks.MockGroupRepository, m *mocks.MockEventDeliveryRepository, r *mocks.MockRateLimiter) {
+				m.EXPECT().
+					FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							NumTrials:       1,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						AppMetadata: &datastore.AppMetadata{UID: "app-1"},
+						EndpointMetadata: &datastore.EndpointMetadata{
+							Status: datastore.ActiveEndpointStatus,
+						},
+						Status: datastore.RetryEventStatus,
+					}, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), "app-1").
+					Return(&datastore.Application{UID: "app-1", IsPaused: true}, nil).Times(2)
+
+				m.EXPECT().
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), datastore.HeldEventStatus).
+					Return(true, nil).Times(1)
+			},
+		},
+		{
+			name:          "App is resumed while the delivery is held - resume releases it",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockApplicationRepository, o *mocks.MockGroupRepository, m *mocks.MockEventDeliveryRepository, r *mocks.MockRateLimiter) {
+				m.EXPECT().
+					FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						UID: "delivery-1",
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							NumTrials:       1,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						AppMetadata: &datastore.AppMetadata{UID: "app-1"},
+						EndpointMetadata: &datastore.EndpointMetadata{
+							Status: datastore.ActiveEndpointStatus,
+						},
+						Status: datastore.RetryEventStatus,
+					}, nil).Times(1)
+
+				gomock.InOrder(
+					a.EXPECT().
+						FindApplicationByID(gomock.Any(), "app-1").
+						Return(&datastore.Application{UID: "app-1", IsPaused: true}, nil),
+					m.EXPECT().
+						TransitionStatus(gomock.Any(), "delivery-1", datastore.RetryEventStatus, datastore.HeldEventStatus).
+						Return(true, nil),
+					a.EXPECT().
+						FindApplicationByID(gomock.Any(), "app-1").
+						Return(&datastore.Application{UID: "app-1"}, nil),
+					m.EXPECT().
+						TransitionStatus(gomock.Any(), "delivery-1", datastore.HeldEventStatus, datastore.ScheduledEventStatus).
+						Return(false, nil),
+				)
 			},
 		},
 		{
@@ -155,8 +252,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -166,7 +267,7 @@ func TestProcessEventDelivery(t *testing.T) {
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -242,8 +343,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -253,7 +358,7 @@ func TestProcessEventDelivery(t *testing.T) {
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -311,8 +416,12 @@ func TestProcessEventDelivery(t *testing.T) {
 				}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -341,12 +450,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				a.EXPECT().
-					UpdateApplicationEndpointsStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DisableApplicationEndpoint(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -422,8 +531,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -434,7 +547,7 @@ func TestProcessEventDelivery(t *testing.T) {
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -492,8 +605,12 @@ func TestProcessEventDelivery(t *testing.T) {
 				}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -523,12 +640,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				a.EXPECT().
-					UpdateApplicationEndpointsStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DisableApplicationEndpoint(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -604,8 +721,12 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -616,7 +737,7 @@ func TestProcessEventDelivery(t *testing.T) {
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -674,8 +795,12 @@ func TestProcessEventDelivery(t *testing.T) {
 				}, nil).Times(1)
 
 				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Application{}, nil).Times(1)
 
 				a.EXPECT().
 					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -710,7 +835,7 @@ func TestProcessEventDelivery(t *testing.T) {
 
 				m.EXPECT().
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+					Return(true, nil).Times(1)
 			},
 			nFn: func() func() {
 				httpmock.Activate()
@@ -760,7 +885,7 @@ func TestProcessEventDelivery(t *testing.T) {
 				tc.dbFn(appRepo, groupRepo, msgRepo, rateLimiter)
 			}
 
-			processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter)
+			processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
 
 			job := queue.Job{
 				ID: tc.msg.UID,
@@ -773,3 +898,1181 @@ func TestProcessEventDelivery(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessEventDelivery_RetryBudget(t *testing.T) {
+	tt := []struct {
+		name          string
+		remaining     int
+		expectedError error
+		dbFn          func(*mocks.MockApplicationRepository, *mocks.MockEventDeliveryRepository, *mocks.MockRateLimiter, string)
+		nFn           func() func()
+	}{
+		{
+			name:          "Budget available - counter is incremented",
+			remaining:     5,
+			expectedError: &EndpointError{Err: ErrDeliveryAttemptFailed, delay: 20 * time.Second},
+			dbFn: func(a *mocks.MockApplicationRepository, m *mocks.MockEventDeliveryRepository, r *mocks.MockRateLimiter, budgetKey string) {
+				r.EXPECT().ShouldAllow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+					Limit:     redis_rate.PerMinute(10),
+					Allowed:   10,
+					Remaining: 10,
+				}, nil).Times(1)
+
+				r.EXPECT().Allow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+					Limit:     redis_rate.PerMinute(10),
+					Allowed:   10,
+					Remaining: 10,
+				}, nil).Times(1)
+
+				a.EXPECT().
+					FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						Status: datastore.ActiveEndpointStatus,
+					}, nil).Times(1)
+
+				m.EXPECT().
+					TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), datastore.ProcessingEventStatus).
+					Return(true, nil).Times(1)
+
+				r.EXPECT().Allow(gomock.Any(), budgetKey, 10, int(time.Hour)).Return(&redis_rate.Result{
+					Limit:     redis_rate.PerHour(10),
+					Allowed:   1,
+					Remaining: 4,
+				}, nil).Times(1)
+
+				m.EXPECT().
+					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).Times(1)
+			},
+			nFn: func() func() {
+				httpmock.Activate()
+
+				httpmock.RegisterResponder("POST", "https://google.com",
+					httpmock.NewStringResponder(400, ``))
+
+				return func() {
+					httpmock.DeactivateAndReset()
+				}
+			},
+		},
+		{
+			name:          "Budget exhausted - delivery is postponed",
+			remaining:     0,
+			expectedError: ErrRetryBudgetExhausted,
+			dbFn: func(a *mocks.MockApplicationRepository, m *mocks.MockEventDeliveryRepository, r *mocks.MockRateLimiter, budgetKey string) {
+				// the delivery is postponed before it is moved to processing
+				// or charged to the endpoint's rate limit
+				m.EXPECT().
+					TransitionStatus(gomock.Any(), gomock.Any(), datastore.RetryEventStatus, datastore.PostponedEventStatus).
+					Return(true, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			groupRepo := mocks.NewMockGroupRepository(ctrl)
+			appRepo := mocks.NewMockApplicationRepository(ctrl)
+			msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+			err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+			if err != nil {
+				t.Errorf("Failed to load config file: %v", err)
+			}
+
+			if tc.nFn != nil {
+				deferFn := tc.nFn()
+				defer deferFn()
+			}
+
+			budgetKey := retryBudgetKey("group-1", time.Now())
+
+			msgRepo.EXPECT().
+				FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+				Return(&datastore.EventDelivery{
+					AppMetadata: &datastore.AppMetadata{GroupID: "group-1"},
+					Metadata: &datastore.Metadata{
+						Data:            []byte(`{"event": "invoice.completed"}`),
+						NumTrials:       0,
+						RetryLimit:      3,
+						IntervalSeconds: 20,
+					},
+					EndpointMetadata: &datastore.EndpointMetadata{
+						Secret:    "aaaaaaaaaaaaaaa",
+						Status:    datastore.ActiveEndpointStatus,
+						TargetURL: "https://google.com",
+						UID:       "1234567890",
+					},
+					Status: datastore.RetryEventStatus,
+				}, nil).Times(1)
+
+			appRepo.EXPECT().
+				FindApplicationByID(gomock.Any(), gomock.Any()).
+				Return(&datastore.Application{}, nil).Times(1)
+
+			groupRepo.EXPECT().
+				FetchGroupByID(gomock.Any(), "group-1").
+				Return(&datastore.Group{
+					UID: "group-1",
+					Config: &datastore.GroupConfig{
+						Signature: datastore.SignatureConfiguration{
+							Header: config.SignatureHeaderProvider("X-Convoy-Signature"),
+							Hash:   "SHA256",
+						},
+						Strategy: datastore.StrategyConfiguration{
+							Type: config.StrategyProvider("default"),
+							Default: datastore.DefaultStrategyConfiguration{
+								IntervalSeconds: 20,
+								RetryLimit:      3,
+							},
+						},
+						RetryBudget: &datastore.RetryBudgetConfig{MaxAttemptsPerHour: 10},
+					},
+				}, nil).Times(1)
+
+			rateLimiter.EXPECT().ShouldAllow(gomock.Any(), budgetKey, 10, int(time.Hour)).Return(&redis_rate.Result{
+				Limit:     redis_rate.PerHour(10),
+				Allowed:   10 - tc.remaining,
+				Remaining: tc.remaining,
+			}, nil).Times(1)
+
+			tc.dbFn(appRepo, msgRepo, rateLimiter, budgetKey)
+
+			processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+			err = processFn(&queue.Job{ID: ""})
+
+			if tc.expectedError == ErrRetryBudgetExhausted {
+				var endpointErr *EndpointError
+				assert.ErrorAs(t, err, &endpointErr)
+				assert.Equal(t, ErrRetryBudgetExhausted, endpointErr.Err)
+				assert.LessOrEqual(t, endpointErr.Delay(), time.Hour)
+				return
+			}
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDeliveryWorker_SkipsDeliveryWhenGroupDisableEndpointIsTrue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+		Return(&datastore.EventDelivery{
+			UID:         "delivery-1",
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(`{"event": "invoice.completed"}`),
+				NumTrials:       0,
+				RetryLimit:      3,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				UID:       "endpoint-1",
+				TargetURL: srv.URL,
+				Status:    datastore.ActiveEndpointStatus,
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	rateLimiter.EXPECT().Allow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), "app-1").
+		Return(&datastore.Application{UID: "app-1", GroupID: "group-1"}, nil).Times(1)
+
+	// the group has DisableEndpoint set, so the endpoint was deactivated
+	// after an earlier delivery exhausted its retries
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), "app-1", "endpoint-1").
+		Return(&datastore.Endpoint{
+			UID:    "endpoint-1",
+			Status: datastore.InactiveEndpointStatus,
+		}, nil).Times(1)
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), "group-1").
+		Return(&datastore.Group{
+			UID:    "group-1",
+			Config: &datastore.GroupConfig{DisableEndpoint: true},
+		}, nil).AnyTimes()
+
+	var status datastore.EventDeliveryStatus
+	msgRepo.EXPECT().
+		TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _, to datastore.EventDeliveryStatus) (bool, error) {
+			status = to
+			return true, nil
+		}).Times(1)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+	err = processFn(&queue.Job{ID: "delivery-1"})
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.DiscardedEventStatus, status)
+	require.False(t, called)
+}
+
+func TestDeliveryWorker_NotifiesOwnerWhenEndpointIsDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+	notifier := mocks.NewMockNotifier(ctrl)
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+		Return(&datastore.EventDelivery{
+			UID:         "delivery-1",
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(`{"event": "invoice.completed"}`),
+				NumTrials:       0,
+				RetryLimit:      1,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				UID:       "endpoint-1",
+				TargetURL: srv.URL,
+				Status:    datastore.ActiveEndpointStatus,
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	rateLimiter.EXPECT().Allow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	msgRepo.EXPECT().
+		TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(true, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), "app-1").
+		Return(&datastore.Application{UID: "app-1", GroupID: "group-1", Title: "billing"}, nil).AnyTimes()
+
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), "app-1", "endpoint-1").
+		Return(&datastore.Endpoint{
+			UID:       "endpoint-1",
+			TargetURL: srv.URL,
+			Status:    datastore.ActiveEndpointStatus,
+		}, nil).Times(1)
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), "group-1").
+		Return(&datastore.Group{
+			UID:     "group-1",
+			LogoURL: "https://example.com/logo.png",
+			Config: &datastore.GroupConfig{
+				Signature: datastore.SignatureConfiguration{
+					Header: config.SignatureHeaderProvider("X-Convoy-Signature"),
+					Hash:   "SHA256",
+				},
+				DisableEndpoint: true,
+			},
+		}, nil).Times(1)
+
+	var reason string
+	appRepo.EXPECT().
+		DisableApplicationEndpoint(gomock.Any(), "app-1", "endpoint-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, r string) error {
+			reason = r
+			return nil
+		}).Times(1)
+
+	var n *notification.EndpointDisabled
+	notifier.EXPECT().
+		NotifyEndpointDisabled(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *notification.EndpointDisabled) error {
+			n = d
+			return nil
+		}).Times(1)
+
+	msgRepo.EXPECT().
+		UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(true, nil).Times(1)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, notifier)
+
+	err = processFn(&queue.Job{ID: "delivery-1"})
+	require.NoError(t, err)
+
+	require.Contains(t, reason, "retry limit of 1 was hit")
+	require.Contains(t, reason, "500 Internal Server Error")
+
+	require.NotNil(t, n)
+	require.Equal(t, "app-1", n.AppID)
+	require.Equal(t, "billing", n.AppTitle)
+	require.Equal(t, "endpoint-1", n.EndpointID)
+	require.Equal(t, srv.URL, n.TargetURL)
+	require.Equal(t, "delivery-1", n.EventDeliveryID)
+	require.Equal(t, "https://example.com/logo.png", n.LogoURL)
+	require.Equal(t, reason, n.Reason)
+	require.False(t, n.DisabledAt.IsZero())
+}
+
+func TestDeliveryWorker_LinearBackoffComputesCorrectDelays(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	var delays []time.Duration
+	for numTrials := uint64(0); numTrials < 4; numTrials++ {
+		ctrl := gomock.NewController(t)
+
+		groupRepo := mocks.NewMockGroupRepository(ctrl)
+		appRepo := mocks.NewMockApplicationRepository(ctrl)
+		msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+		msgRepo.EXPECT().
+			FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+			Return(&datastore.EventDelivery{
+				AppMetadata: &datastore.AppMetadata{GroupID: "group-1"},
+				Metadata: &datastore.Metadata{
+					Data:            []byte(`{"event": "invoice.completed"}`),
+					Strategy:        config.LinearStrategyProvider,
+					NumTrials:       numTrials,
+					RetryLimit:      5,
+					IntervalSeconds: 10,
+					StepSeconds:     30,
+				},
+				EndpointMetadata: &datastore.EndpointMetadata{
+					Secret:    "aaaaaaaaaaaaaaa",
+					Status:    datastore.ActiveEndpointStatus,
+					TargetURL: srv.URL,
+					UID:       "1234567890",
+				},
+				Status: datastore.RetryEventStatus,
+			}, nil).Times(1)
+
+		rateLimiter.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+			Limit:     redis_rate.PerMinute(10),
+			Allowed:   10,
+			Remaining: 10,
+		}, nil).Times(1)
+
+		rateLimiter.EXPECT().Allow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+			Limit:     redis_rate.PerMinute(10),
+			Allowed:   10,
+			Remaining: 10,
+		}, nil).Times(1)
+
+		msgRepo.EXPECT().
+			TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), datastore.ProcessingEventStatus).
+			Return(true, nil).Times(1)
+
+		appRepo.EXPECT().
+			FindApplicationByID(gomock.Any(), gomock.Any()).
+			Return(&datastore.Application{}, nil).Times(1)
+
+		appRepo.EXPECT().
+			FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&datastore.Endpoint{Status: datastore.ActiveEndpointStatus}, nil).Times(1)
+
+		groupRepo.EXPECT().
+			FetchGroupByID(gomock.Any(), "group-1").
+			Return(&datastore.Group{
+				UID: "group-1",
+				Config: &datastore.GroupConfig{
+					Signature: datastore.SignatureConfiguration{
+						Header: config.SignatureHeaderProvider("X-Convoy-Signature"),
+						Hash:   "SHA256",
+					},
+					Strategy: datastore.StrategyConfiguration{
+						Type: config.LinearStrategyProvider,
+						Linear: datastore.LinearStrategyConfiguration{
+							InitialIntervalSeconds: 10,
+							StepSeconds:            30,
+							RetryLimit:             5,
+						},
+					},
+				},
+			}, nil).Times(1)
+
+		var nextSendTime time.Time
+		msgRepo.EXPECT().
+			UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, d datastore.EventDelivery, _ datastore.DeliveryAttempt) (bool, error) {
+				nextSendTime = d.Metadata.NextSendTime.Time()
+				return true, nil
+			}).Times(1)
+
+		processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+		start := time.Now()
+		err = processFn(&queue.Job{ID: ""})
+
+		var endpointErr *EndpointError
+		require.ErrorAs(t, err, &endpointErr)
+		require.Equal(t, ErrDeliveryAttemptFailed, endpointErr.Err)
+		require.WithinDuration(t, start.Add(endpointErr.Delay()), nextSendTime, time.Second)
+
+		delays = append(delays, endpointErr.Delay())
+		ctrl.Finish()
+	}
+
+	require.Equal(t, []time.Duration{10 * time.Second, 40 * time.Second, 70 * time.Second, 100 * time.Second}, delays)
+}
+
+func TestDeliveryWorker_MergesGroupAndAppHeaders(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	groupConfig := &datastore.GroupConfig{
+		CustomHeaders: map[string]string{
+			"X-Environment": "staging",
+			"x-team":        "payments",
+			"X-Source":      "group",
+		},
+	}
+
+	app := &datastore.Application{
+		CustomHeaders: map[string]string{
+			"X-Source":           "app",
+			"Authorization":      "Bearer app-token",
+			"X-Convoy-Signature": "forged",
+			"Connection":         "close",
+		},
+	}
+
+	attempt := deliverToApp(t, srv.URL, `{"event": "payment.created"}`, groupConfig, app, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus})
+	require.True(t, attempt.Status)
+
+	require.Equal(t, "staging", header.Get("X-Environment"))
+	require.Equal(t, "payments", header.Get("X-Team"))
+	require.Equal(t, "app", header.Get("X-Source"))
+	require.Equal(t, "Bearer app-token", header.Get("Authorization"))
+
+	// custom headers can neither replace convoy's headers nor hop-by-hop ones
+	require.NotEqual(t, "forged", header.Get("X-Convoy-Signature"))
+	require.Equal(t, "application/json", header.Get("Content-Type"))
+	require.NotEqual(t, "close", header.Get("Connection"))
+}
+
+func TestDeliveryWorker_InjectsTraceParentHeader(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	eventMetadata := &datastore.EventMetadata{
+		UID:         "event-1",
+		EventType:   "payment.created",
+		TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		TraceState:  "vendor=opaque",
+	}
+
+	app := &datastore.Application{
+		CustomHeaders: map[string]string{"traceparent": "00-00000000000000000000000000000001-0000000000000001-00"},
+	}
+
+	attempt := deliverEventToApp(t, srv.URL, `{"event": "payment.created"}`, eventMetadata, &datastore.GroupConfig{}, app, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus})
+	require.True(t, attempt.Status)
+
+	require.Equal(t, eventMetadata.TraceParent, header.Get("traceparent"))
+	require.Equal(t, eventMetadata.TraceState, header.Get("tracestate"))
+}
+
+func TestDeliveryWorker_RoutesToVersionedEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		eventMetadata *datastore.EventMetadata
+		app           *datastore.Application
+		wantPath      string
+	}{
+		{
+			name:          "should_route_to_the_event_version",
+			path:          "/hooks",
+			eventMetadata: &datastore.EventMetadata{UID: "event-1", EventVersion: "2"},
+			app:           &datastore.Application{UseVersionedEndpoints: true},
+			wantPath:      "/hooks/v2",
+		},
+		{
+			name:          "should_drop_the_trailing_slash",
+			path:          "/hooks/",
+			eventMetadata: &datastore.EventMetadata{UID: "event-1", EventVersion: "3"},
+			app:           &datastore.Application{UseVersionedEndpoints: true},
+			wantPath:      "/hooks/v3",
+		},
+		{
+			name:     "should_route_events_without_a_version_to_v1",
+			path:     "/hooks",
+			app:      &datastore.Application{UseVersionedEndpoints: true},
+			wantPath: "/hooks/v1",
+		},
+		{
+			name:          "should_keep_the_endpoint_url_for_unversioned_apps",
+			path:          "/hooks",
+			eventMetadata: &datastore.EventMetadata{UID: "event-1", EventVersion: "2"},
+			app:           &datastore.Application{},
+			wantPath:      "/hooks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var path string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			attempt := deliverEventToApp(t, srv.URL+tc.path, `{"event": "payment.created"}`, tc.eventMetadata, &datastore.GroupConfig{}, tc.app, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus})
+			require.True(t, attempt.Status)
+
+			require.Equal(t, tc.wantPath, path)
+			require.Equal(t, srv.URL+tc.wantPath, attempt.URL)
+		})
+	}
+}
+
+func TestEventVersionRouter_KeepsVersionInOneSegment(t *testing.T) {
+	app := &datastore.Application{UseVersionedEndpoints: true}
+	e := &datastore.EndpointMetadata{TargetURL: "https://example.com/hooks?team=payments"}
+
+	u, err := EventVersionRouter{}.TargetURL(app, e, &datastore.EventMetadata{EventVersion: "2/../admin"})
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/hooks/v2%2F..%2Fadmin?team=payments", u)
+}
+
+func TestDeliveryWorker_SignsWithEndpointSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   *datastore.Endpoint
+		wantSecret string
+	}{
+		{
+			name:       "endpoint_with_own_secret",
+			endpoint:   &datastore.Endpoint{Status: datastore.ActiveEndpointStatus, Secret: "rotated-secret"},
+			wantSecret: "rotated-secret",
+		},
+		{
+			name:       "endpoint_without_own_secret",
+			endpoint:   &datastore.Endpoint{Status: datastore.ActiveEndpointStatus},
+			wantSecret: "aaaaaaaaaaaaaaa",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var signature, body string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				signature = r.Header.Get("X-Convoy-Signature")
+
+				b, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("failed to read request body: %v", err)
+				}
+				body = string(b)
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			app := &datastore.Application{Secret: "app-secret"}
+
+			attempt := deliverToApp(t, srv.URL, `{"event":"payment.created"}`, &datastore.GroupConfig{}, app, tc.endpoint)
+			require.True(t, attempt.Status)
+
+			want, err := util.ComputeJSONHmac("SHA256", body, tc.wantSecret, false)
+			require.NoError(t, err)
+			require.Equal(t, want, signature)
+		})
+	}
+}
+
+func TestDeliveryWorker_SignsWithEachAlgorithm(t *testing.T) {
+	var header http.Header
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		body = string(b)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	groupConfig := &datastore.GroupConfig{
+		Signature: datastore.SignatureConfiguration{Algorithms: []string{"SHA256", "SHA512"}},
+	}
+
+	app := &datastore.Application{
+		CustomHeaders: map[string]string{"X-Convoy-Signature-SHA512": "forged"},
+	}
+
+	attempt := deliverToApp(t, srv.URL, `{"event":"payment.created"}`, groupConfig, app, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus})
+	require.True(t, attempt.Status)
+
+	for _, algorithm := range []string{"SHA256", "SHA512"} {
+		want, err := util.ComputeJSONHmac(algorithm, body, "aaaaaaaaaaaaaaa", false)
+		require.NoError(t, err)
+		require.Equal(t, want, header.Get("X-Convoy-Signature-"+algorithm))
+	}
+
+	// the group's own signature header is still sent
+	require.Equal(t, header.Get("X-Convoy-Signature-SHA256"), header.Get("X-Convoy-Signature"))
+}
+
+func Test_endpointSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		app      *datastore.Application
+		endpoint *datastore.Endpoint
+		metadata *datastore.EndpointMetadata
+		want     string
+	}{
+		{
+			name:     "should_prefer_the_endpoint_secret",
+			app:      &datastore.Application{Secret: "app-secret"},
+			endpoint: &datastore.Endpoint{Secret: "endpoint-secret"},
+			metadata: &datastore.EndpointMetadata{Secret: "old-endpoint-secret"},
+			want:     "endpoint-secret",
+		},
+		{
+			name:     "should_use_the_delivery_secret_for_an_endpoint_without_one",
+			app:      &datastore.Application{Secret: "app-secret"},
+			endpoint: &datastore.Endpoint{},
+			metadata: &datastore.EndpointMetadata{Secret: "old-endpoint-secret"},
+			want:     "old-endpoint-secret",
+		},
+		{
+			name:     "should_use_the_app_secret_for_an_endpoint_without_one",
+			app:      &datastore.Application{Secret: "app-secret"},
+			endpoint: &datastore.Endpoint{},
+			metadata: &datastore.EndpointMetadata{},
+			want:     "app-secret",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, endpointSecret(tc.app, tc.endpoint, tc.metadata))
+		})
+	}
+}
+
+func Test_endpointHttpTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "should_default_an_unset_timeout", want: 30 * time.Second},
+		{name: "should_use_the_endpoint_timeout", timeout: "10s", want: 10 * time.Second},
+		{name: "should_cap_a_timeout_over_the_max", timeout: "1h", want: convoy.MAX_HTTP_TIMEOUT},
+		{name: "should_error_for_an_invalid_timeout", timeout: "m", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := endpointHttpTimeout(tc.timeout)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDeliveryWorker_CompressesLargePayload(t *testing.T) {
+	payload := fmt.Sprintf(`{"data":"%s"}`, strings.Repeat("a", 5000))
+
+	encoding, body := deliverPayload(t, payload, &datastore.GroupConfig{CompressPayload: true})
+
+	assert.Equal(t, "gzip", encoding)
+	assert.Equal(t, payload, body)
+}
+
+func TestDeliveryWorker_SkipsCompressionForSmallPayload(t *testing.T) {
+	payload := `{"event":"invoice.completed"}`
+
+	encoding, body := deliverPayload(t, payload, &datastore.GroupConfig{CompressPayload: true})
+
+	assert.Equal(t, "", encoding)
+	assert.Equal(t, payload, body)
+}
+
+func TestDeliveryWorker_CapturesDNSAndConnectTimings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// a host name, unlike the server's ip, has to be resolved
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	u.Host = net.JoinHostPort("localhost", u.Port())
+
+	attempt := deliver(t, u.String(), `{"event":"invoice.completed"}`, &datastore.GroupConfig{})
+
+	assert.True(t, attempt.Status)
+	assert.NotZero(t, attempt.DNSLookupMs)
+	assert.NotZero(t, attempt.ConnectMs)
+	assert.Zero(t, attempt.TLSHandshakeMs)
+}
+
+func TestDeliveryWorker_RoutesRequestsThroughProxy(t *testing.T) {
+	var proxiedURL, proxyAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	password, err := util.Encrypt("s3cret", "test-encryption-key")
+	require.NoError(t, err)
+
+	// the target host doesn't resolve, the request only succeeds through the proxy
+	attempt := deliver(t, "http://webhooks.convoy.test/hook", `{"event": "payment.created"}`, &datastore.GroupConfig{
+		OutboundProxy: &datastore.ProxyConfig{
+			URL:      proxy.URL,
+			Username: "convoy",
+			Password: password,
+		},
+	})
+
+	require.True(t, attempt.Status)
+	require.Equal(t, "http://webhooks.convoy.test/hook", proxiedURL)
+
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("convoy:s3cret"))
+	require.Equal(t, wantAuth, proxyAuth)
+}
+
+func TestDeliveryWorker_BypassesProxyForNoProxyHosts(t *testing.T) {
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	var delivered bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	attempt := deliver(t, target.URL, `{"event": "payment.created"}`, &datastore.GroupConfig{
+		OutboundProxy: &datastore.ProxyConfig{
+			URL:          proxy.URL,
+			NoProxyHosts: []string{"*.internal", "127.0.0.1"},
+		},
+	})
+
+	require.True(t, attempt.Status)
+	require.True(t, delivered)
+	require.False(t, proxied)
+}
+
+// deliverPayload runs a delivery against a test server and returns the
+// Content-Encoding header and the decompressed body it received.
+func deliverPayload(t *testing.T, payload string, groupConfig *datastore.GroupConfig) (string, string) {
+	var encoding, body string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+
+		var reader io.Reader = r.Body
+		if encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("failed to create gzip reader: %v", err)
+				return
+			}
+			defer zr.Close()
+			reader = zr
+		}
+
+		b, err := io.ReadAll(reader)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		body = string(b)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	deliver(t, srv.URL, payload, groupConfig)
+
+	return encoding, body
+}
+
+// deliver runs a delivery of payload to targetURL and returns the attempt
+// that was recorded for it.
+func deliver(t *testing.T, targetURL string, payload string, groupConfig *datastore.GroupConfig) datastore.DeliveryAttempt {
+	return deliverToApp(t, targetURL, payload, groupConfig, &datastore.Application{}, &datastore.Endpoint{Status: datastore.ActiveEndpointStatus})
+}
+
+// deliverToApp is deliver for a delivery to endpoint, one of app's
+// endpoints. The delivery has a copy of the endpoint's secret from when it
+// was created, which endpoint's secret may have been rotated since.
+func deliverToApp(t *testing.T, targetURL string, payload string, groupConfig *datastore.GroupConfig, app *datastore.Application, endpoint *datastore.Endpoint) datastore.DeliveryAttempt {
+	return deliverEventToApp(t, targetURL, payload, nil, groupConfig, app, endpoint)
+}
+
+func deliverEventToApp(t *testing.T, targetURL string, payload string, eventMetadata *datastore.EventMetadata, groupConfig *datastore.GroupConfig, app *datastore.Application, endpoint *datastore.Endpoint) datastore.DeliveryAttempt {
+	return deliverEvent(t, targetURL, payload, eventMetadata, groupConfig, app, endpoint, nil)
+}
+
+// deliverEvent is deliverEventToApp emitting meta events with metaEvents.
+func deliverEvent(t *testing.T, targetURL string, payload string, eventMetadata *datastore.EventMetadata, groupConfig *datastore.GroupConfig, app *datastore.Application, endpoint *datastore.Endpoint, metaEvents *MetaEventEmitter) datastore.DeliveryAttempt {
+	var attempt datastore.DeliveryAttempt
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	if err != nil {
+		t.Errorf("Failed to load config file: %v", err)
+	}
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), gomock.Any()).
+		Return(&datastore.EventDelivery{
+			EventMetadata: eventMetadata,
+			AppMetadata:   &datastore.AppMetadata{},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(payload),
+				NumTrials:       0,
+				RetryLimit:      3,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				Secret:    "aaaaaaaaaaaaaaa",
+				Status:    datastore.ActiveEndpointStatus,
+				TargetURL: targetURL,
+				UID:       "1234567890",
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&redis_rate.Result{
+		Limit:     redis_rate.PerMinute(10),
+		Allowed:   10,
+		Remaining: 10,
+	}, nil).Times(1)
+
+	msgRepo.EXPECT().
+		TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(true, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), gomock.Any()).
+		Return(app, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(endpoint, nil).Times(1)
+
+	groupConfig.Signature.Header = config.SignatureHeaderProvider("X-Convoy-Signature")
+	groupConfig.Signature.Hash = "SHA256"
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), gomock.Any()).
+		Return(&datastore.Group{Config: groupConfig}, nil).Times(1)
+
+	msgRepo.EXPECT().
+		UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ datastore.EventDelivery, a datastore.DeliveryAttempt) (bool, error) {
+			attempt = a
+			return true, nil
+		}).Times(1)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, metaEvents, noop.NewNoopNotifier())
+
+	err = processFn(&queue.Job{ID: ""})
+	assert.NoError(t, err)
+
+	return attempt
+}
+
+func TestProcessEventDelivery_SkipsDeliveryTakenByAnotherWorker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), "delivery-1").
+		Return(&datastore.EventDelivery{
+			UID:         "delivery-1",
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(`{"event": "invoice.completed"}`),
+				RetryLimit:      3,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				UID:       "endpoint-1",
+				TargetURL: "https://google.com",
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), "app-1").
+		Return(&datastore.Application{UID: "app-1"}, nil).Times(1)
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), "group-1").
+		Return(&datastore.Group{
+			UID: "group-1",
+			Config: &datastore.GroupConfig{
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "SHA256"},
+			},
+		}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+	rateLimiter.EXPECT().Allow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), "app-1", "endpoint-1").
+		Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil).Times(1)
+
+	// another worker moved the delivery on since it was loaded, so it is
+	// neither sent nor updated here
+	msgRepo.EXPECT().
+		TransitionStatus(gomock.Any(), "delivery-1", datastore.ScheduledEventStatus, datastore.ProcessingEventStatus).
+		Return(false, nil).Times(1)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+	err = processFn(&queue.Job{ID: "delivery-1"})
+	require.NoError(t, err)
+}
+
+func TestProcessEventDelivery_LeavesDeliveryScheduledWhenSigningFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), "delivery-1").
+		Return(&datastore.EventDelivery{
+			UID:         "delivery-1",
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(`{"event": "invoice.completed"}`),
+				RetryLimit:      3,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				UID:       "endpoint-1",
+				TargetURL: "https://google.com",
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), "app-1").
+		Return(&datastore.Application{UID: "app-1"}, nil).Times(1)
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), "group-1").
+		Return(&datastore.Group{
+			UID: "group-1",
+			Config: &datastore.GroupConfig{
+				Signature: datastore.SignatureConfiguration{Header: "X-Convoy-Signature", Hash: "unknown"},
+			},
+		}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+	rateLimiter.EXPECT().Allow(gomock.Any(), "https://google.com", gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), "app-1", "endpoint-1").
+		Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil).Times(1)
+
+	// the payload can't be signed, so the delivery is never moved to
+	// processing and is picked up again when the job is retried
+	msgRepo.EXPECT().TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+	err = processFn(&queue.Job{ID: "delivery-1"})
+
+	var endpointErr *EndpointError
+	require.ErrorAs(t, err, &endpointErr)
+}
+
+func TestProcessEventDelivery_DropsAttemptOfDeliveryNoLongerProcessing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	groupRepo := mocks.NewMockGroupRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	msgRepo.EXPECT().
+		FindEventDeliveryByID(gomock.Any(), "delivery-1").
+		Return(&datastore.EventDelivery{
+			UID:         "delivery-1",
+			AppMetadata: &datastore.AppMetadata{UID: "app-1", GroupID: "group-1"},
+			Metadata: &datastore.Metadata{
+				Data:            []byte(`{"event": "invoice.completed"}`),
+				RetryLimit:      3,
+				IntervalSeconds: 20,
+			},
+			EndpointMetadata: &datastore.EndpointMetadata{
+				UID:       "endpoint-1",
+				TargetURL: srv.URL,
+				Status:    datastore.ActiveEndpointStatus,
+			},
+			Status: datastore.ScheduledEventStatus,
+		}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationByID(gomock.Any(), "app-1").
+		Return(&datastore.Application{UID: "app-1"}, nil).Times(1)
+
+	rateLimiter.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+	rateLimiter.EXPECT().Allow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).
+		Return(&redis_rate.Result{Limit: redis_rate.PerMinute(10), Allowed: 10, Remaining: 10}, nil).Times(1)
+
+	appRepo.EXPECT().
+		FindApplicationEndpointByID(gomock.Any(), "app-1", "endpoint-1").
+		Return(&datastore.Endpoint{UID: "endpoint-1", TargetURL: srv.URL, Status: datastore.ActiveEndpointStatus}, nil).Times(1)
+
+	msgRepo.EXPECT().
+		TransitionStatus(gomock.Any(), "delivery-1", datastore.ScheduledEventStatus, datastore.ProcessingEventStatus).
+		Return(true, nil).Times(1)
+
+	groupRepo.EXPECT().
+		FetchGroupByID(gomock.Any(), "group-1").
+		Return(&datastore.Group{
+			UID: "group-1",
+			Config: &datastore.GroupConfig{
+				Signature: datastore.SignatureConfiguration{
+					Header: config.SignatureHeaderProvider("X-Convoy-Signature"),
+					Hash:   "SHA256",
+				},
+			},
+		}, nil).Times(1)
+
+	// the delivery was force completed while it was being sent, so the
+	// failed attempt isn't saved over it nor retried
+	msgRepo.EXPECT().
+		UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(false, nil).Times(1)
+
+	processFn := ProcessEventDelivery(appRepo, msgRepo, groupRepo, rateLimiter, nil, nil, noop.NewNoopNotifier())
+
+	err = processFn(&queue.Job{ID: "delivery-1"})
+	require.NoError(t, err)
+}